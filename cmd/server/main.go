@@ -2,28 +2,55 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"govuk-reports-dashboard/internal/config"
 	"govuk-reports-dashboard/internal/handlers"
 	"govuk-reports-dashboard/internal/modules/costs"
+	"govuk-reports-dashboard/internal/modules/ecs"
+	"govuk-reports-dashboard/internal/modules/eks"
 	"govuk-reports-dashboard/internal/modules/elasticache"
+	"govuk-reports-dashboard/internal/modules/lambda"
 	"govuk-reports-dashboard/internal/modules/rds"
+	"govuk-reports-dashboard/internal/modules/s3"
+	"govuk-reports-dashboard/internal/modules/savingsplans"
+	"govuk-reports-dashboard/internal/modules/trustedadvisor"
 	"govuk-reports-dashboard/internal/reports"
 	"govuk-reports-dashboard/pkg/aws"
 	"govuk-reports-dashboard/pkg/govuk"
 	"govuk-reports-dashboard/pkg/logger"
+	"govuk-reports-dashboard/pkg/metrics"
+	"govuk-reports-dashboard/pkg/notifications"
+	"govuk-reports-dashboard/pkg/pagerduty"
+	"govuk-reports-dashboard/pkg/sentry"
+	"govuk-reports-dashboard/pkg/tracing"
 
 	"github.com/gin-gonic/gin"
 )
 
 func main() {
-	cfg, err := config.Load()
+	configFile := flag.String("config", os.Getenv("CONFIG_FILE"), "Path to a YAML, JSON or TOML config file; environment variables override its values")
+	exampleConfig := flag.String("write-example-config", "", "Write an example config file with the default settings to this path and exit")
+	flag.Parse()
+
+	if *exampleConfig != "" {
+		if err := writeExampleConfig(*exampleConfig); err != nil {
+			fmt.Fprintf(os.Stderr, "Example config error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	cfg, err := config.LoadWithFile(*configFile)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
 		os.Exit(1)
@@ -35,6 +62,12 @@ func main() {
 		Output:     cfg.Log.Output,
 		TimeFormat: cfg.Log.TimeFormat,
 		Colorize:   cfg.Log.Colorize,
+		Rotation: logger.LogRotationConfig{
+			MaxSizeMB:  cfg.Log.MaxSizeMB,
+			MaxAgeDays: cfg.Log.MaxAgeDays,
+			MaxBackups: cfg.Log.MaxBackups,
+			Compress:   cfg.Log.Compress,
+		},
 	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Logger error: %v\n", err)
@@ -50,16 +83,89 @@ func main() {
 		"log_level":   cfg.Log.Level,
 	})
 
-	awsClient, err := aws.NewClient(cfg, log)
+	for _, warning := range cfg.Warnings() {
+		log.Warn().Msg(warning)
+	}
+
+	// Prometheus metrics, served at /metrics. Left nil when disabled, which
+	// the registry methods treat as a no-op.
+	var metricsRegistry *metrics.Registry
+	if cfg.Monitoring.MetricsEnabled {
+		metricsRegistry = metrics.NewRegistry()
+	}
+
+	// OpenTelemetry tracing. Without an exporter endpoint the global tracer
+	// provider does nothing, so spans cost next to nothing.
+	shutdownTracing := func() {}
+	if cfg.Monitoring.TracingEndpoint != "" {
+		shutdown, err := tracing.InitProvider(cfg.Monitoring.TracingServiceName, cfg.Monitoring.TracingEndpoint)
+		if err != nil {
+			log.WithError(err).Fatal().Msg("Failed to initialise tracing")
+		}
+		shutdownTracing = shutdown
+		log.WithField("endpoint", cfg.Monitoring.TracingEndpoint).Info().Msg("Exporting traces")
+	}
+
+	// RDS and ElastiCache are discovered in every configured account; the
+	// rest of the dashboard uses the default credentials' account
+	var awsClients []*aws.Client
+	if len(cfg.AWS.AssumeRoles) > 0 {
+		awsClients, err = aws.NewMultiAccountClient(cfg, log)
+	} else {
+		var awsClient *aws.Client
+		awsClient, err = aws.NewClient(cfg, log)
+		awsClients = []*aws.Client{awsClient}
+	}
 	if err != nil {
 		log.WithError(err).Fatal().Msg("Failed to create AWS client")
 	}
+	awsClient := awsClients[0]
+	if metricsRegistry != nil {
+		for _, client := range awsClients {
+			client.SetAPICallRecorder(metricsRegistry)
+		}
+	}
+
+	if cfg.AWS.AWSPermissionCheck {
+		log.Info().Msg("Checking AWS IAM permissions")
+		checkCtx, cancelCheck := context.WithTimeout(context.Background(), 30*time.Second)
+		permissionErrors := awsClient.ValidatePermissions(checkCtx)
+		cancelCheck()
+
+		if len(permissionErrors) > 0 && cfg.AWS.FailOnPermissionError {
+			log.WithField("failed_checks", len(permissionErrors)).Fatal().Msg("Required AWS permissions are missing")
+		} else if len(permissionErrors) == 0 {
+			log.Info().Msg("AWS IAM permission check passed")
+		}
+	}
 
 	govukClient := govuk.NewClient(cfg, log)
+	govukClient.ChangeEvents = make(chan govuk.ApplicationChangeEvent, govuk.ChangeEventsBufferSize)
+	refreshCtx, stopRefresh := context.WithCancel(context.Background())
+	defer stopRefresh()
+	go logApplicationChanges(refreshCtx, govukClient.ChangeEvents, log)
+	govukClient.StartBackgroundRefresh(refreshCtx)
 
 	// Initialize reports manager
 	log.Info().Msg("Initializing reports management framework")
-	reportsManager := reports.NewManager(log)
+	managerOptions := []reports.ManagerOption{
+		reports.WithConcurrencyLimit(cfg.Server.ReportsMaxConcurrent),
+		reports.WithCacheMaxEntries(cfg.Cache.MaxSize),
+		reports.WithMetrics(metricsRegistry),
+	}
+	if eolNotifier := newEOLNotifier(cfg, log); eolNotifier != nil {
+		managerOptions = append(managerOptions, reports.WithNotifier(eolNotifier, notifications.EOLAlertPolicy{
+			NotifyOnEOL:           cfg.Notifications.NotifyOnEOL,
+			NotifyOnOutdated:      cfg.Notifications.NotifyOnOutdated,
+			NotifyOnCriticalPatch: cfg.Notifications.NotifyOnCriticalPatch,
+			EOLWarningDays:        cfg.Notifications.AlertThresholds.EOLWarningDays,
+			OutdatedWarningDays:   cfg.Notifications.AlertThresholds.OutdatedWarningDays,
+		}), reports.WithNotificationState(cfg.Notifications.StatePath))
+	}
+	reportsManager := reports.NewManager(log, managerOptions...)
+	registerCacheMetrics(metricsRegistry, govukClient, reportsManager)
+	webhookDispatcher := notifications.NewWebhookDispatcher(log)
+	reportsManager.SetEventPublisher(webhookDispatcher)
 
 	// Initialize report modules with proper error handling
 	var costService *costs.CostService
@@ -68,16 +174,45 @@ func main() {
 	var elastiCacheHandler *elasticache.ElastiCacheHandler
 	var rdsService *rds.RDSService
 	var costHandler *costs.CostHandler
+	var forecastHandler *costs.ForecastHandler
+	var teamCostHandler *costs.TeamCostHandler
 	var applicationHandler *costs.ApplicationHandler
 	var rdsHandler *rds.RDSHandler
+	var eksService *eks.EKSService
+	var eksHandler *eks.EKSHandler
+	var s3Handler *s3.S3Handler
+	var lambdaHandler *lambda.LambdaHandler
+	var ecsHandler *ecs.ECSHandler
+
+	// Initialize EKS module (used by the cost report for namespace attribution)
+	log.Info().Msg("Initializing EKS cost attribution module")
+	eksService = eks.NewEKSService(awsClient, govukClient, cfg, log)
+	eksHandler = eks.NewEKSHandler(eksService, log)
 
 	// Initialize cost module
 	log.Info().Msg("Initializing cost reporting module")
 	costService = costs.NewCostService(awsClient, govukClient, log)
 	applicationService = costs.NewApplicationService(awsClient, govukClient, log)
+	costModel, err := costs.LoadCostEstimationModel(cfg.AWS.CostModelPath)
+	if err != nil {
+		log.WithError(err).Fatal().Msg("Failed to load cost estimation model")
+	}
+	applicationService.SetCostEstimationModel(costModel)
+	applicationService.SetEC2Inventory(awsClient)
+	if cfg.GOVUK.TeamRosterURL != "" {
+		applicationService.SetTeamRosterClient(govuk.NewTeamRosterClient(cfg, log))
+	}
+	if cfg.Monitoring.SentryAPIToken != "" {
+		applicationService.SetSentryClient(sentry.NewSentryClient(cfg.Monitoring.SentryAPIToken, cfg.Monitoring.SentryOrganization, log))
+	}
+	if cfg.Monitoring.PagerDutyAPIToken != "" {
+		applicationService.SetPagerDutyClient(pagerduty.NewClient(cfg.Monitoring.PagerDutyAPIToken, log), cfg.Monitoring.TeamPagerDutySchedules)
+	}
 
 	// Create and register cost report with error handling
 	costReport := costs.NewCostReport(costService, applicationService, log)
+	costReport.SetEKSService(eksService)
+	costReport.SetInfrastructureChangeSource(awsClient)
 	err = reportsManager.Register(costReport)
 	if err != nil {
 		log.WithError(err).Error().Msg("Failed to register cost report - cost reporting will be unavailable")
@@ -88,7 +223,8 @@ func main() {
 
 	// Initialize ElastiCache module with error handling
 	log.Info().Msg("Initializing ElastiCache reporting module")
-	elastiCacheService = elasticache.NewElastiCacheService(awsClient.GetConfig(), cfg, log)
+	elastiCacheService = elasticache.NewMultiAccountElastiCacheService(awsClients, cfg, log)
+	elastiCacheService.SetApplicationsClient(govukClient)
 	elastiCacheHandler = elasticache.NewElastiCacheHandler(elastiCacheService, log)
 
 	elastiCacheReport := elasticache.NewElastiCacheReport(elastiCacheService, log)
@@ -99,12 +235,74 @@ func main() {
 		log.Info().Msg("ElastiCache reporting module registered successfully")
 	}
 
+	// Initialize S3 module with error handling
+	log.Info().Msg("Initializing S3 reporting module")
+	s3Service := s3.NewS3Service(awsClient, cfg, log)
+	s3Handler = s3.NewS3Handler(s3Service, log)
+
+	s3Report := s3.NewS3Report(s3Service, log)
+	err = reportsManager.Register(s3Report)
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to register S3 report - S3 reporting will be unavailable")
+	} else {
+		log.Info().Msg("S3 reporting module registered successfully")
+	}
+
+	// Initialize Lambda module with error handling
+	log.Info().Msg("Initializing Lambda reporting module")
+	lambdaService := lambda.NewLambdaService(awsClient, cfg, log)
+	lambdaHandler = lambda.NewLambdaHandler(lambdaService, log)
+
+	lambdaReport := lambda.NewLambdaReport(lambdaService, log)
+	err = reportsManager.Register(lambdaReport)
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to register Lambda report - Lambda reporting will be unavailable")
+	} else {
+		log.Info().Msg("Lambda reporting module registered successfully")
+	}
+
+	// Initialize ECS module with error handling
+	log.Info().Msg("Initializing ECS reporting module")
+	ecsService := ecs.NewECSService(awsClient, cfg, log)
+	ecsHandler = ecs.NewECSHandler(ecsService, log)
+
+	ecsReport := ecs.NewECSReport(ecsService, log)
+	err = reportsManager.Register(ecsReport)
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to register ECS report - ECS reporting will be unavailable")
+	} else {
+		log.Info().Msg("ECS reporting module registered successfully")
+	}
+
 	// Initialize RDS module with error handling
 	log.Info().Msg("Initializing RDS reporting module")
-	rdsService = rds.NewRDSService(awsClient.GetConfig(), cfg, log)
+	rdsService = rds.NewMultiAccountRDSService(awsClients, cfg, log)
+	applicationService.SetInfrastructureServices(rdsService, elastiCacheService)
 
 	// Create and register RDS report with error handling
 	rdsReport := rds.NewRDSReport(rdsService, log)
+	var rdsNotifier notifications.Notifier = webhookDispatcher
+	if cfg.Monitoring.PagerDutyRoutingKey != "" {
+		rdsNotifier = notifications.MultiNotifier{
+			notifications.NewPagerDutyNotifier(cfg.Monitoring.PagerDutyRoutingKey, log),
+			webhookDispatcher,
+		}
+		log.Info().Msg("PagerDuty alerting enabled for RDS report")
+	}
+	if cfg.Monitoring.PagerDutyAPIToken != "" {
+		// Add the on-call person to alerts so chat notifications can mention them
+		rdsNotifier = notifications.NewOnCallNotifier(rdsNotifier, func(ctx context.Context, team string) (*notifications.OnCallContact, error) {
+			schedule, err := applicationService.GetTeamOnCall(ctx, team)
+			if err != nil {
+				return nil, err
+			}
+			return &notifications.OnCallContact{
+				Name:  schedule.CurrentOnCall.Name,
+				Email: schedule.CurrentOnCall.Email,
+			}, nil
+		}, cfg.Monitoring.AlertTeam, log)
+	}
+	rdsReport.SetNotifier(rdsNotifier)
 	err = reportsManager.Register(rdsReport)
 	if err != nil {
 		log.WithError(err).Error().Msg("Failed to register RDS report - RDS reporting will be unavailable")
@@ -113,18 +311,63 @@ func main() {
 		log.Info().Msg("RDS reporting module registered successfully")
 	}
 
+	// Create and register Savings Plans report with error handling
+	log.Info().Msg("Initializing Savings Plans reporting module")
+	savingsPlansReport := savingsplans.NewSavingsPlansReport(awsClient, log)
+	err = reportsManager.Register(savingsPlansReport)
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to register Savings Plans report - Savings Plans reporting will be unavailable")
+	} else {
+		log.Info().Msg("Savings Plans reporting module registered successfully")
+	}
+
+	// Create and register Trusted Advisor report with error handling
+	log.Info().Msg("Initializing Trusted Advisor reporting module")
+	trustedAdvisorReport := trustedadvisor.NewTrustedAdvisorReport(awsClient, log)
+	err = reportsManager.Register(trustedAdvisorReport)
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to register Trusted Advisor report - Trusted Advisor reporting will be unavailable")
+	} else {
+		log.Info().Msg("Trusted Advisor reporting module registered successfully")
+	}
+
 	// Log summary of registered reports
 	availableReports := reportsManager.ListReports()
 	log.WithField("report_count", len(availableReports)).Info().Msg("Reports framework initialization complete")
 
+	// Check the EOL reports on a schedule so new instances are notified
+	// without anyone viewing them
+	reportsManager.StartEOLChecks(refreshCtx, cfg.Notifications.CheckInterval)
+
+	// Restore cached reports saved by the previous process so the first
+	// requests after a restart don't all hit AWS
+	if path := cfg.Cache.CachePersistencePath; path != "" {
+		reportCache := reportsManager.GetCache()
+		if err := reportCache.LoadFromFile(path); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				log.WithField("path", path).Info().Msg("No persisted report cache found")
+			} else {
+				log.WithError(err).WithField("path", path).Warn().Msg("Failed to restore report cache")
+			}
+		} else {
+			log.LogStartup("Report cache", "1.0.0", map[string]interface{}{
+				"path":             path,
+				"restored_entries": reportCache.GetStats().TotalEntries,
+			})
+		}
+	}
+
 	// Initialize handlers with proper null checks
 	log.Info().Msg("Initializing HTTP handlers")
 	healthHandler := handlers.NewHealthHandler()
+	healthHandler.SetReportsManager(reportsManager)
 
 	// Initialize cost handlers (these should always be available)
 	if costService != nil && applicationService != nil {
 		costHandler = costs.NewCostHandler(costService, log)
+		forecastHandler = costs.NewForecastHandler(costService, log)
 		applicationHandler = costs.NewApplicationHandler(applicationService, log)
+		teamCostHandler = costs.NewTeamCostHandler(applicationService, log)
 		log.Info().Msg("Cost and application handlers initialized")
 	} else {
 		log.Error().Msg("Cost services not available - cost handlers will not be initialized")
@@ -138,7 +381,7 @@ func main() {
 		log.Error().Msg("RDS service not available - RDS handlers will not be initialized")
 	}
 
-	router := setupRouter(cfg, log, healthHandler, costHandler, applicationHandler, elastiCacheHandler, rdsHandler, reportsManager)
+	router := setupRouter(cfg, log, healthHandler, costHandler, forecastHandler, teamCostHandler, applicationHandler, elastiCacheHandler, rdsHandler, eksHandler, s3Handler, lambdaHandler, ecsHandler, reportsManager, govukClient, awsClient, webhookDispatcher, metricsRegistry)
 
 	srv := &http.Server{
 		Addr:         cfg.GetBindAddress(),
@@ -161,6 +404,7 @@ func main() {
 
 	shutdownStart := time.Now()
 	log.Info().Msg("Shutting down server...")
+	stopRefresh()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -170,33 +414,94 @@ func main() {
 	} else {
 		log.LogShutdown("GOV.UK Reports Dashboard", time.Since(shutdownStart))
 	}
+
+	shutdownTracing()
+
+	if err := webhookDispatcher.Wait(ctx); err != nil {
+		log.WithError(err).Warn().Msg("Webhook deliveries still in progress at shutdown")
+	}
+
+	if path := cfg.Cache.CachePersistencePath; path != "" {
+		if err := reportsManager.GetCache().SaveToFile(path); err != nil {
+			log.WithError(err).WithField("path", path).Error().Msg("Failed to save report cache")
+		} else {
+			log.WithField("path", path).Info().Msg("Saved report cache")
+		}
+	}
+
+	if err := log.Sync(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to flush logs: %v\n", err)
+	}
+}
+
+// newEOLNotifier returns a notifier for the configured EOL notification
+// webhooks, or nil if none are configured
+func newEOLNotifier(cfg *config.Config, log *logger.Logger) notifications.EOLNotifier {
+	var notifiers notifications.MultiEOLNotifier
+	if cfg.Notifications.WebhookURL != "" {
+		notifiers = append(notifiers, notifications.NewEOLWebhookNotifier(cfg.Notifications.WebhookURL, log))
+	}
+	if cfg.Notifications.SlackWebhookURL != "" {
+		notifiers = append(notifiers, notifications.NewSlackNotifier(cfg.Notifications.SlackWebhookURL, log))
+	}
+	if len(notifiers) == 0 {
+		return nil
+	}
+
+	log.WithField("notifiers", len(notifiers)).Info().Msg("EOL notifications enabled for RDS and ElastiCache reports")
+	return notifiers
 }
 
-func setupRouter(cfg *config.Config, log *logger.Logger, healthHandler *handlers.HealthHandler, costHandler *costs.CostHandler, applicationHandler *costs.ApplicationHandler, elastiCacheHandler *elasticache.ElastiCacheHandler, rdsHandler *rds.RDSHandler, reportsManager *reports.Manager) *gin.Engine {
+func setupRouter(cfg *config.Config, log *logger.Logger, healthHandler *handlers.HealthHandler, costHandler *costs.CostHandler, forecastHandler *costs.ForecastHandler, teamCostHandler *costs.TeamCostHandler, applicationHandler *costs.ApplicationHandler, elastiCacheHandler *elasticache.ElastiCacheHandler, rdsHandler *rds.RDSHandler, eksHandler *eks.EKSHandler, s3Handler *s3.S3Handler, lambdaHandler *lambda.LambdaHandler, ecsHandler *ecs.ECSHandler, reportsManager *reports.Manager, govukClient *govuk.Client, awsClient *aws.Client, webhookDispatcher *notifications.WebhookDispatcher, metricsRegistry *metrics.Registry) *gin.Engine {
 	if cfg.Server.Environment == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
 	router := gin.New()
 
+	// Only trust X-Forwarded-For from configured proxies, so clients can't
+	// choose the IP they are rate limited by
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.WithError(err).Fatal().Msg("Invalid trusted proxies")
+	}
+
+	// Tracing comes first so the request span covers the other middleware
+	if cfg.Monitoring.TracingEndpoint != "" {
+		router.Use(handlers.TracingMiddleware(tracing.Tracer()))
+	}
+
+	// Request IDs, so every log line for a request can be found together
+	router.Use(handlers.RequestIDMiddleware())
+
 	// Request timeout middleware
-	router.Use(handlers.TimeoutMiddleware(30*time.Second, log))
+	router.Use(handlers.AdaptiveTimeoutMiddleware(cfg.Server.RouteTimeouts, cfg.Server.RequestTimeout, log))
 
 	// Security headers
-	router.Use(handlers.SecurityHeadersMiddleware())
-
-	// CORS with configuration
-	router.Use(handlers.CORSMiddleware(cfg))
+	router.Use(handlers.SecurityHeadersMiddleware(cfg))
+
+	// CORS with configuration. Report routes never need credentials and only
+	// allow POST for bulk generation; admin routes also allow PATCH.
+	corsConfig := handlers.DefaultCORSConfig(cfg)
+	reportsCORS := corsConfig
+	reportsCORS.AllowedMethods = []string{"GET", "HEAD", "POST", "OPTIONS"}
+	reportsCORS.AllowedHeaders = []string{"Accept", "Accept-Encoding", "Cache-Control", "Content-Type", "Last-Event-ID", "X-Requested-With", handlers.RequestIDHeader}
+	reportsCORS.AllowCredentials = false
+	adminCORS := corsConfig
+	adminCORS.AllowedMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+	router.Use(handlers.CORSMiddlewareWithOverrides(corsConfig, map[string]handlers.CORSConfig{
+		"/api/reports/": reportsCORS,
+		"/api/admin/":   adminCORS,
+	}))
 
 	// Rate limiting and bot detection
-	router.Use(handlers.RateLimitMiddleware(log))
+	router.Use(handlers.RateLimitMiddleware(handlers.NewRateLimiter(cfg.Monitoring.RateLimit, log), log))
 
 	// Structured logging
 	router.Use(handlers.LoggerMiddleware(log))
 
 	// Metrics collection
 	if cfg.Monitoring.MetricsEnabled {
-		router.Use(handlers.MetricsMiddleware(log))
+		router.Use(handlers.MetricsMiddleware(metricsRegistry))
 	}
 
 	// Health check middleware for circuit breaker
@@ -212,41 +517,115 @@ func setupRouter(cfg *config.Config, log *logger.Logger, healthHandler *handlers
 	// Available endpoints:
 	// - /api/health - Service health check
 	// - /api/applications - List all applications
+	// - /api/applications/stats - Application counts by hosting platform and team
+	// - /api/applications/diff - Last change to the GOV.UK application list
+	// - /api/applications/diff/stream - Server-sent events for each change to the application list
 	// - /api/applications/:name - Get specific application
 	// - /api/applications/:name/services - Get application services
+	// - /api/applications/:name/infrastructure - RDS and ElastiCache resources for an application
+	// - /api/applications/:name/sentry - Sentry project and error count for an application
+	// - /api/bff/applications/:name - Application, costs and infrastructure for the detail page
+	// - /api/teams - List teams that own applications
+	// - /api/teams/:team/on-call - Current on-call person from the team's PagerDuty schedule
 	// - /api/costs - Legacy cost summary (backwards compatibility)
 	// - /api/costs/summary - Cost module summary
+	// - /api/costs/attribution-stats - Cost attribution confidence and tagging suggestions
+	// - /api/costs/teams - Application costs totalled by owning team
 	// - /api/elasticache/health - ElastiCache service health check
 	// - /api/elasticache/clusters - List ElastiCache clusters
+	// - /api/elasticache/clusters/:id/metrics - CloudWatch metrics for an ElastiCache cluster
+	// - /api/elasticache/parameter-groups - ElastiCache parameter group compliance
+	// - /api/elasticache/node-type-recommendations - ElastiCache node type upgrade recommendations
+	// - /api/elasticache/multi-az-compliance - Multi-AZ for production ElastiCache replication groups
+	// - /api/elasticache/backup-compliance - Automatic backups for production ElastiCache replication groups
+	// - /api/elasticache/serverless-scaling - ElastiCache serverless caches near their scaling limits
+	// - /api/elasticache/outdated - ElastiCache clusters with end-of-life or outdated engine versions
 	// - /api/rds/health - RDS service health check
 	// - /api/rds/summary - RDS summary statistics
 	// - /api/rds/instances - List PostgreSQL instances
 	// - /api/rds/instances/:id - Get specific instance
+	// - /api/rds/instances/:id/slow-queries - Performance Insights slow queries
 	// - /api/rds/versions - Version check results
 	// - /api/rds/outdated - Outdated instances
+	// - /api/rds/snapshot-costs - Estimated snapshot storage costs
+	// - /api/rds/cross-region-compliance - Cross-region replication of production databases
+	// - /api/rds/tagging-audit - Instances missing required tags
+	// - /api/rds/alarm-compliance - CloudWatch alarms on production instances
+	// - /api/rds/encryption-compliance - Storage encryption, required on production instances
+	// - /api/rds/compliance - Backup retention, deletion protection and minor version upgrades
+	// - /api/rds/connection-pooling-recommendations - Instances near max_connections that need PgBouncer
+	// - /api/rds/recommendations - Right-sizing of over-provisioned instances, with estimated savings
+	// - /api/rds/aurora - Aurora PostgreSQL clusters and their member instances
+	// - /api/eks/namespace-costs - EKS cost by Kubernetes namespace
+	// - /api/s3/buckets - S3 buckets with their storage settings and estimated costs
+	// - /api/s3/summary - Counts of S3 buckets missing each storage setting
+	// - /api/s3/compliance - S3 buckets missing encryption, versioning, logging or a lifecycle policy
+	// - /api/lambda/functions - Lambda functions with their runtimes and EOL status
+	// - /api/lambda/outdated - Lambda functions on deprecated or soon-to-be-deprecated runtimes
+	// - /api/lambda/summary - Counts of Lambda functions by runtime and runtime compliance
+	// - /api/ecs/services - ECS services with their task counts, launch types and sizes
+	// - /api/ecs/summary - Counts of ECS services by launch type and those not at their desired count
+	// - /api/ecs/clusters - ECS clusters with their service and task counts
+	// - /api/ec2/instances - Running EC2 instances with estimated hourly costs
+	// - /api/infrastructure/changes - Recent RDS, ElastiCache and EC2 changes from CloudTrail
+	// - /api/tags/apply (POST) - Apply suggested tags to resources (needs ADMIN_API_TOKEN)
+	// - /api/webhooks (GET, POST) - List or register webhooks for report events (needs ADMIN_API_TOKEN)
+	// - /api/webhooks/:id (DELETE) - Remove a webhook (needs ADMIN_API_TOKEN)
 	// - /api/reports/ - List available reports (backwards compatibility)
 	// - /api/reports/list - List available reports with metadata
 	// - /api/reports/summary - Dashboard summary for all reports
+	// - /api/reports/health - Availability of each report and the report cache
 	// - /api/reports/:id - Get specific report by ID
+	// - /api/reports/bulk (POST) - Generate several reports in one request
+	// - /api/reports/:id/stream - Stream a report as server-sent events
+	// - /api/reports/:id/export?format=csv|xlsx - Download a report table as a spreadsheet
+	// - /api/reports/:id (DELETE) - Unregister a report (needs ADMIN_API_TOKEN)
+	// - /api/reports/:id/enable, /api/reports/:id/disable (POST) - Enable or disable a report (needs ADMIN_API_TOKEN)
 	// - /api/reports/costs - Cost report via reports framework
 	// - /api/reports/rds - RDS report via reports framework
-	api := router.Group("/api")
-	{
+	// - /api/reports/savings-plans - Savings Plans report via reports framework
+	// - /api/reports/trusted-advisor - Trusted Advisor report via reports framework
+	// - /api/admin/client-stats - GOV.UK API client connection stats
+	// - /api/admin/govuk-client-metrics - GOV.UK API client request and cache metrics
+	// - /api/admin/cache/stats - Report cache hits, misses and entries
+	// - /api/admin/log-level - Get or change the log level at runtime
+	//   (all /api/admin routes need the admin token)
+	// - /metrics - Prometheus metrics for requests, reports, AWS API calls and caches (when METRICS_ENABLED)
+	//
+	// Every API route is served under /api/v1 and, as a deprecated alias, at
+	// its unversioned path above. See pkg/api for the deprecation timeline.
+	for _, api := range handlers.APIGroups(router, cfg.Server.DefaultAPIVersion) {
 		// Health endpoint (keep at /api/health for backward compatibility)
 		api.GET("/health", healthHandler.HealthCheck)
 
 		// Application endpoints (only register if handlers are available)
 		if applicationHandler != nil {
 			api.GET("/applications", applicationHandler.GetApplications)
+			api.GET("/applications/stats", applicationHandler.GetApplicationStats)
 			api.GET("/applications/:name", applicationHandler.GetApplication)
 			api.GET("/applications/:name/services", applicationHandler.GetApplicationServices)
+			api.GET("/applications/:name/infrastructure", applicationHandler.GetApplicationInfrastructure)
+			api.GET("/applications/:name/sentry", applicationHandler.GetApplicationSentry)
+			api.GET("/bff/applications/:name", applicationHandler.GetApplicationCostContext)
+			api.GET("/teams", applicationHandler.GetTeams)
+			api.GET("/teams/:team/on-call", applicationHandler.GetTeamOnCall)
 		} else {
 			// Provide service unavailable responses
 			api.GET("/applications", getServiceUnavailableHandler("Applications service unavailable", log))
+			api.GET("/applications/stats", getServiceUnavailableHandler("Applications service unavailable", log))
 			api.GET("/applications/:name", getServiceUnavailableHandler("Applications service unavailable", log))
 			api.GET("/applications/:name/services", getServiceUnavailableHandler("Applications service unavailable", log))
+			api.GET("/applications/:name/infrastructure", getServiceUnavailableHandler("Applications service unavailable", log))
+			api.GET("/applications/:name/sentry", getServiceUnavailableHandler("Applications service unavailable", log))
+			api.GET("/bff/applications/:name", getServiceUnavailableHandler("Applications service unavailable", log))
+			api.GET("/teams", getServiceUnavailableHandler("Applications service unavailable", log))
+			api.GET("/teams/:team/on-call", getServiceUnavailableHandler("Applications service unavailable", log))
 		}
 
+		// Application list changes come straight from the GOV.UK client
+		api.GET("/applications/diff", getApplicationsDiff(govukClient))
+		api.GET("/applications/diff/stream", streamApplicationsDiff(govukClient, log))
+
 		// Legacy cost endpoints (keep for backwards compatibility)
 		if costHandler != nil {
 			api.GET("/costs", costHandler.GetCostSummary)
@@ -255,10 +634,16 @@ func setupRouter(cfg *config.Config, log *logger.Logger, healthHandler *handlers
 			costs := api.Group("/costs")
 			{
 				costs.GET("/summary", costHandler.GetCostSummary)
+				costs.GET("/forecast", forecastHandler.GetCostForecast)
+				costs.GET("/teams", teamCostHandler.GetTeamCosts)
+				costs.GET("/attribution-stats", applicationHandler.GetAttributionStats)
 			}
 		} else {
 			// Provide service unavailable responses
 			api.GET("/costs", getServiceUnavailableHandler("Cost service unavailable", log))
+			api.GET("/costs/attribution-stats", getServiceUnavailableHandler("Cost service unavailable", log))
+			api.GET("/costs/forecast", getServiceUnavailableHandler("Cost service unavailable", log))
+			api.GET("/costs/teams", getServiceUnavailableHandler("Cost service unavailable", log))
 		}
 
 		// ElastiCache endpoints (only register if handler is available)
@@ -266,10 +651,24 @@ func setupRouter(cfg *config.Config, log *logger.Logger, healthHandler *handlers
 		if elastiCacheHandler != nil {
 			elasticache.GET("/health", elastiCacheHandler.GetHealth)
 			elasticache.GET("/clusters", elastiCacheHandler.GetClusters)
+			elasticache.GET("/clusters/:id/metrics", elastiCacheHandler.GetClusterMetrics)
+			elasticache.GET("/parameter-groups", elastiCacheHandler.GetParameterGroups)
+			elasticache.GET("/node-type-recommendations", elastiCacheHandler.GetNodeTypeRecommendations)
+			elasticache.GET("/multi-az-compliance", elastiCacheHandler.GetMultiAZCompliance)
+			elasticache.GET("/backup-compliance", elastiCacheHandler.GetBackupCompliance)
+			elasticache.GET("/serverless-scaling", elastiCacheHandler.GetServerlessScaling)
+			elasticache.GET("/outdated", elastiCacheHandler.GetOutdatedClusters)
 		} else {
 			// Provide service unavailaible responses when ElastiCache is not available
 			elasticache.GET("/health", getServiceUnavailableHandler("ElastiCache service unavailable", log))
 			elasticache.GET("/clusters", getServiceUnavailableHandler("ElastiCache service unavailaible", log))
+			elasticache.GET("/clusters/:id/metrics", getServiceUnavailableHandler("ElastiCache service unavailable", log))
+			elasticache.GET("/parameter-groups", getServiceUnavailableHandler("ElastiCache service unavailable", log))
+			elasticache.GET("/node-type-recommendations", getServiceUnavailableHandler("ElastiCache service unavailable", log))
+			elasticache.GET("/multi-az-compliance", getServiceUnavailableHandler("ElastiCache service unavailable", log))
+			elasticache.GET("/backup-compliance", getServiceUnavailableHandler("ElastiCache service unavailable", log))
+			elasticache.GET("/serverless-scaling", getServiceUnavailableHandler("ElastiCache service unavailable", log))
+			elasticache.GET("/outdated", getServiceUnavailableHandler("ElastiCache service unavailable", log))
 		}
 
 		// RDS endpoints (only register if handler is available)
@@ -280,8 +679,18 @@ func setupRouter(cfg *config.Config, log *logger.Logger, healthHandler *handlers
 				rds.GET("/summary", rdsHandler.GetSummary)
 				rds.GET("/instances", rdsHandler.GetInstances)
 				rds.GET("/instances/:id", rdsHandler.GetInstance)
+				rds.GET("/instances/:id/slow-queries", rdsHandler.GetSlowQueries)
 				rds.GET("/versions", rdsHandler.GetVersions)
 				rds.GET("/outdated", rdsHandler.GetOutdated)
+				rds.GET("/snapshot-costs", rdsHandler.GetSnapshotCosts)
+				rds.GET("/cross-region-compliance", rdsHandler.GetCrossRegionCompliance)
+				rds.GET("/tagging-audit", rdsHandler.GetTaggingAudit)
+				rds.GET("/alarm-compliance", rdsHandler.GetAlarmCompliance)
+				rds.GET("/encryption-compliance", rdsHandler.GetEncryptionCompliance)
+				rds.GET("/compliance", rdsHandler.GetBackupCompliance)
+				rds.GET("/connection-pooling-recommendations", rdsHandler.GetConnectionPoolingRecommendations)
+				rds.GET("/recommendations", rdsHandler.GetRightSizingRecommendations)
+				rds.GET("/aurora", rdsHandler.GetAuroraClusters)
 			}
 		} else {
 			// Provide service unavailable responses for RDS endpoints
@@ -291,26 +700,132 @@ func setupRouter(cfg *config.Config, log *logger.Logger, healthHandler *handlers
 				rds.GET("/summary", getServiceUnavailableHandler("RDS service unavailable", log))
 				rds.GET("/instances", getServiceUnavailableHandler("RDS service unavailable", log))
 				rds.GET("/instances/:id", getServiceUnavailableHandler("RDS service unavailable", log))
+				rds.GET("/instances/:id/slow-queries", getServiceUnavailableHandler("RDS service unavailable", log))
 				rds.GET("/versions", getServiceUnavailableHandler("RDS service unavailable", log))
 				rds.GET("/outdated", getServiceUnavailableHandler("RDS service unavailable", log))
+				rds.GET("/snapshot-costs", getServiceUnavailableHandler("RDS service unavailable", log))
+				rds.GET("/cross-region-compliance", getServiceUnavailableHandler("RDS service unavailable", log))
+				rds.GET("/tagging-audit", getServiceUnavailableHandler("RDS service unavailable", log))
+				rds.GET("/alarm-compliance", getServiceUnavailableHandler("RDS service unavailable", log))
+				rds.GET("/encryption-compliance", getServiceUnavailableHandler("RDS service unavailable", log))
+				rds.GET("/compliance", getServiceUnavailableHandler("RDS service unavailable", log))
+				rds.GET("/connection-pooling-recommendations", getServiceUnavailableHandler("RDS service unavailable", log))
+				rds.GET("/recommendations", getServiceUnavailableHandler("RDS service unavailable", log))
+				rds.GET("/aurora", getServiceUnavailableHandler("RDS service unavailable", log))
 			}
 		}
 
+		// EKS endpoints
+		eks := api.Group("/eks")
+		if eksHandler != nil {
+			eks.GET("/namespace-costs", eksHandler.GetNamespaceCosts)
+		} else {
+			eks.GET("/namespace-costs", getServiceUnavailableHandler("EKS service unavailable", log))
+		}
+
+		// S3 endpoints
+		s3 := api.Group("/s3")
+		if s3Handler != nil {
+			s3.GET("/buckets", s3Handler.GetBuckets)
+			s3.GET("/summary", s3Handler.GetSummary)
+			s3.GET("/compliance", s3Handler.GetCompliance)
+		} else {
+			s3.GET("/buckets", getServiceUnavailableHandler("S3 service unavailable", log))
+			s3.GET("/summary", getServiceUnavailableHandler("S3 service unavailable", log))
+			s3.GET("/compliance", getServiceUnavailableHandler("S3 service unavailable", log))
+		}
+
+		// Lambda endpoints
+		lambdaGroup := api.Group("/lambda")
+		if lambdaHandler != nil {
+			lambdaGroup.GET("/functions", lambdaHandler.GetFunctions)
+			lambdaGroup.GET("/outdated", lambdaHandler.GetOutdatedFunctions)
+			lambdaGroup.GET("/summary", lambdaHandler.GetSummary)
+		} else {
+			lambdaGroup.GET("/functions", getServiceUnavailableHandler("Lambda service unavailable", log))
+			lambdaGroup.GET("/outdated", getServiceUnavailableHandler("Lambda service unavailable", log))
+			lambdaGroup.GET("/summary", getServiceUnavailableHandler("Lambda service unavailable", log))
+		}
+
+		// ECS endpoints
+		ecsGroup := api.Group("/ecs")
+		if ecsHandler != nil {
+			ecsGroup.GET("/services", ecsHandler.GetServices)
+			ecsGroup.GET("/summary", ecsHandler.GetSummary)
+			ecsGroup.GET("/clusters", ecsHandler.GetClusters)
+		} else {
+			ecsGroup.GET("/services", getServiceUnavailableHandler("ECS service unavailable", log))
+			ecsGroup.GET("/summary", getServiceUnavailableHandler("ECS service unavailable", log))
+			ecsGroup.GET("/clusters", getServiceUnavailableHandler("ECS service unavailable", log))
+		}
+
+		// EC2 endpoints
+		api.GET("/ec2/instances", getEC2Instances(awsClient, log))
+
+		// Infrastructure endpoints
+		api.GET("/infrastructure/changes", getInfrastructureChanges(awsClient, log))
+
+		// Tagging endpoints
+		taggingHandler := handlers.NewTaggingHandler(awsClient, log)
+		api.POST("/tags/apply", handlers.AuthMiddleware(cfg.Server.AdminAPIToken, log), taggingHandler.ApplyTags)
+
+		// Webhook endpoints
+		webhookHandler := handlers.NewWebhookHandler(webhookDispatcher, log)
+		webhooks := api.Group("/webhooks", handlers.AuthMiddleware(cfg.Server.AdminAPIToken, log))
+		{
+			webhooks.GET("", webhookHandler.ListWebhooks)
+			webhooks.POST("", webhookHandler.RegisterWebhook)
+			webhooks.DELETE("/:id", webhookHandler.DeleteWebhook)
+		}
+
 		// Reports endpoints
 		reports := api.Group("/reports")
 		{
-			reports.GET("/", getReportsList(reportsManager, log))           // Keep for backwards compatibility
-			reports.GET("/list", getReportsList(reportsManager, log))       // New cleaner endpoint
-			reports.GET("/summary", getReportsSummary(reportsManager, log)) // Dashboard summary data
-			reports.GET("/:id", getReport(reportsManager, log))             // Individual report by ID
+			reports.GET("/", getReportsList(reportsManager, log))                                   // Keep for backwards compatibility
+			reports.GET("/list", getReportsList(reportsManager, log))                               // New cleaner endpoint
+			reports.GET("/summary", getReportsSummary(reportsManager, log))                         // Dashboard summary data
+			reports.GET("/health", getReportsHealth(reportsManager, log))                           // Per-report availability
+			reports.GET("/:id", getReport(reportsManager, log))                                     // Individual report by ID
+			reports.GET("/:id/stream", handlers.NewStreamHandler(reportsManager, log).StreamReport) // Partial results as server-sent events
+			reports.GET("/:id/export", handlers.NewExportHandler(reportsManager, log).ExportReport) // CSV or XLSX download
+			reports.POST("/bulk", generateBulkReports(reportsManager, log))                         // Several reports in one request
+
+			// Operator endpoints for taking a broken report out of service
+			auth := handlers.AuthMiddleware(cfg.Server.AdminAPIToken, log)
+			reports.DELETE("/:id", auth, unregisterReport(reportsManager, log))
+			reports.POST("/:id/enable", auth, setReportEnabled(reportsManager, true, log))
+			reports.POST("/:id/disable", auth, setReportEnabled(reportsManager, false, log))
 
 			// Specific report type endpoints
 			reports.GET("/costs", getSpecificReport(reportsManager, "costs", log))
 			reports.GET("/rds", getSpecificReport(reportsManager, "rds", log))
 			reports.GET("/elasticache", getSpecificReport(reportsManager, "elasticache", log))
+			reports.GET("/s3", getSpecificReport(reportsManager, "s3", log))
+			reports.GET("/lambda", getSpecificReport(reportsManager, "lambda", log))
+			reports.GET("/ecs", getSpecificReport(reportsManager, "ecs", log))
+			reports.GET("/savings-plans", getSpecificReport(reportsManager, "savings-plans", log))
+			reports.GET("/trusted-advisor", getSpecificReport(reportsManager, "trusted-advisor", log))
+		}
+
+		// Admin endpoints
+		logLevelHandler := handlers.NewLogLevelHandler(log)
+		// Admin routes are off until an admin token is configured
+		admin := api.Group("/admin")
+		admin.Use(handlers.RequireAdminToken(cfg.Server.AdminAPIToken), handlers.AuthMiddleware(cfg.Server.AdminAPIToken, log))
+		{
+			admin.GET("/client-stats", getClientStats(govukClient, log))
+			admin.GET("/govuk-client-metrics", getGovUKClientMetrics(govukClient, log))
+			admin.GET("/cache/stats", getReportCacheStats(reportsManager))
+			admin.POST("/cache/clear", clearReportCache(reportsManager, log))
+			admin.GET("/log-level", logLevelHandler.GetLogLevel)
+			admin.PUT("/log-level", logLevelHandler.SetLogLevel)
 		}
 	}
 
+	if cfg.Monitoring.MetricsEnabled {
+		router.GET(handlers.MetricsPath, gin.WrapH(metricsRegistry.Handler()))
+	}
+
 	// Static files
 	router.Static("/static", "./web/static")
 	router.LoadHTMLGlob("web/templates/*")
@@ -322,9 +837,11 @@ func setupRouter(cfg *config.Config, log *logger.Logger, healthHandler *handlers
 	if applicationHandler != nil {
 		router.GET("/applications", applicationHandler.GetApplicationsPage)
 		router.GET("/applications/:name", applicationHandler.GetApplicationPage)
+		router.GET("/teams", teamCostHandler.TeamsPage)
 	} else {
 		router.GET("/applications", getServiceUnavailablePageHandler("Applications service unavailable", log))
 		router.GET("/applications/:name", getServiceUnavailablePageHandler("Applications service unavailable", log))
+		router.GET("/teams", getServiceUnavailablePageHandler("Applications service unavailable", log))
 	}
 
 	// ElastiCache pages (only register if handlers are available
@@ -375,24 +892,28 @@ func getReportsSummary(manager *reports.Manager, log *logger.Logger) gin.Handler
 			UseCache: true,
 		}
 
-		summaries, err := manager.GenerateSummary(c.Request.Context(), params)
+		summaryResponse, err := manager.GenerateSummaryWithErrors(c.Request.Context(), params)
 		if err != nil {
 			log.WithError(err).Error().Msg("Failed to generate reports summary")
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error":  "Failed to generate reports summary",
 				"status": "error",
+				"errors": summaryResponse.Errors,
 			})
 			return
 		}
+		summaries := summaryResponse.Summaries
 
 		// Get available reports for additional metadata
 		availableReports := manager.GetAvailableReports(c.Request.Context())
 
 		response := gin.H{
-			"summaries": summaries,
-			"count":     len(summaries),
-			"status":    "success",
-			"reports":   availableReports,
+			"summaries":       summaries,
+			"count":           len(summaries),
+			"status":          "success",
+			"reports":         availableReports,
+			"errors":          summaryResponse.Errors,
+			"partial_success": summaryResponse.PartialSuccess,
 			"generated_at": map[string]interface{}{
 				"timestamp": "now", // This could be enhanced with actual timestamps
 				"timezone":  "UTC",
@@ -400,8 +921,9 @@ func getReportsSummary(manager *reports.Manager, log *logger.Logger) gin.Handler
 		}
 
 		log.WithFields(map[string]interface{}{
-			"summary_count": len(summaries),
-			"reports_count": len(availableReports),
+			"summary_count":  len(summaries),
+			"reports_count":  len(availableReports),
+			"failed_reports": len(summaryResponse.Errors),
 		}).Info().Msg("Generated reports summary for dashboard")
 
 		c.JSON(http.StatusOK, response)
@@ -420,9 +942,28 @@ func getReport(manager *reports.Manager, log *logger.Logger) gin.HandlerFunc {
 
 		params := reports.ReportParams{
 			UseCache: true,
+			Format:   reportFormat(c),
+		}
+		if !validReportFormats[params.Format] {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("Unsupported format %q, expected json, yaml or toml", params.Format),
+			})
+			return
 		}
 
 		reportData, err := manager.GenerateReport(c.Request.Context(), reportID, params)
+		if errors.Is(err, reports.ErrReportNotFound) || errors.Is(err, reports.ErrReportDisabled) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, reports.ErrInvalidParams) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
 		if err != nil {
 			log.WithError(err).Error().Msg("Failed to generate report")
 			c.JSON(http.StatusInternalServerError, gin.H{
@@ -431,8 +972,362 @@ func getReport(manager *reports.Manager, log *logger.Logger) gin.HandlerFunc {
 			return
 		}
 
+		writeReport(c, reportData, params.Format, log)
+	}
+}
+
+// getReportsHealth handles GET /api/reports/health
+func getReportsHealth(manager *reports.Manager, log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		health := manager.GetHealth(c.Request.Context())
+
+		log.WithFields(map[string]interface{}{
+			"overall_status":      health.OverallStatus,
+			"unavailable_reports": len(health.UnavailableReports),
+		}).Debug().Msg("Checked reports health")
+
+		c.JSON(http.StatusOK, health)
+	}
+}
+
+// unregisterReport handles DELETE /api/reports/:id
+func unregisterReport(manager *reports.Manager, log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		reportID := c.Param("id")
+		if err := manager.Unregister(reportID); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":     err.Error(),
+				"report_id": reportID,
+			})
+			return
+		}
+
+		log.WithField("report_id", reportID).Warn().Msg("Report unregistered by operator")
+		c.JSON(http.StatusOK, gin.H{
+			"status":    "unregistered",
+			"report_id": reportID,
+		})
+	}
+}
+
+// setReportEnabled handles POST /api/reports/:id/enable and
+// POST /api/reports/:id/disable
+func setReportEnabled(manager *reports.Manager, enabled bool, log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		reportID := c.Param("id")
+		if err := manager.SetEnabled(reportID, enabled); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":     err.Error(),
+				"report_id": reportID,
+			})
+			return
+		}
+
+		log.WithFields(map[string]interface{}{
+			"report_id": reportID,
+			"enabled":   enabled,
+		}).Warn().Msg("Report enabled state changed by operator")
+		c.JSON(http.StatusOK, gin.H{
+			"report_id": reportID,
+			"enabled":   enabled,
+		})
+	}
+}
+
+// getReportCacheStats handles GET /api/admin/cache/stats
+func getReportCacheStats(manager *reports.Manager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"cache_stats": manager.GetCacheStats(),
+		})
+	}
+}
+
+// clearReportCache handles POST /api/admin/cache/clear
+func clearReportCache(manager *reports.Manager, log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cleared := manager.GetCacheStats().TotalEntries
+		manager.RefreshCache()
+
+		log.WithField("entries_cleared", cleared).Warn().Msg("Report cache cleared by operator")
+		c.JSON(http.StatusOK, gin.H{
+			"status":          "cleared",
+			"entries_cleared": cleared,
+		})
+	}
+}
+
+var validReportFormats = map[string]bool{"json": true, "yaml": true, "toml": true}
+
+// reportFormat returns the format requested with the format query parameter,
+// or failing that the Accept header, defaulting to JSON
+func reportFormat(c *gin.Context) string {
+	if format := c.Query("format"); format != "" {
+		return strings.ToLower(format)
+	}
+
+	accept := c.GetHeader("Accept")
+	switch {
+	case strings.Contains(accept, "application/yaml"), strings.Contains(accept, "application/x-yaml"):
+		return "yaml"
+	case strings.Contains(accept, "application/toml"):
+		return "toml"
+	}
+	return "json"
+}
+
+// writeReport serialises report data as JSON, YAML or TOML
+func writeReport(c *gin.Context, reportData reports.ReportData, format string, log *logger.Logger) {
+	var body string
+	var err error
+	var contentType string
+
+	switch format {
+	case "yaml":
+		body, err = reports.NewRenderer().ToYAML(reportData)
+		contentType = "application/yaml; charset=utf-8"
+	case "toml":
+		body, err = reports.NewRenderer().ToTOML(reportData)
+		contentType = "application/toml; charset=utf-8"
+	default:
 		c.JSON(http.StatusOK, reportData)
+		return
 	}
+
+	if err != nil {
+		log.WithError(err).WithField("format", format).Error().Msg("Failed to serialise report")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to serialise report",
+		})
+		return
+	}
+
+	c.Data(http.StatusOK, contentType, []byte(body))
+}
+
+// bulkReportRequest is the body for POST /api/reports/bulk. Report parameters
+// are given alongside report_ids at the top level.
+type bulkReportRequest struct {
+	ReportIDs []string `json:"report_ids"`
+	reports.ReportParams
+}
+
+func generateBulkReports(manager *reports.Manager, log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		request := bulkReportRequest{
+			ReportParams: reports.ReportParams{
+				UseCache: true,
+			},
+		}
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid request body: " + err.Error(),
+			})
+			return
+		}
+
+		var validIDs []string
+		invalid := make(map[string]string)
+		for _, reportID := range request.ReportIDs {
+			if _, err := manager.GetReport(reportID); err != nil {
+				invalid[reportID] = err.Error()
+				continue
+			}
+			validIDs = append(validIDs, reportID)
+		}
+
+		if len(validIDs) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":  "No valid report IDs given",
+				"errors": invalid,
+			})
+			return
+		}
+
+		response := manager.GenerateReports(c.Request.Context(), validIDs, request.ReportParams)
+		for reportID, message := range invalid {
+			response.Errors[reportID] = message
+		}
+
+		status := http.StatusOK
+		if len(response.Errors) > 0 {
+			status = http.StatusMultiStatus
+			if len(response.Results) == 0 {
+				status = http.StatusInternalServerError
+			}
+		}
+
+		log.WithFields(map[string]interface{}{
+			"requested": len(request.ReportIDs),
+			"succeeded": len(response.Results),
+			"failed":    len(response.Errors),
+		}).Info().Msg("Generated bulk reports")
+
+		c.JSON(status, response)
+	}
+}
+
+// getClientStats reports the GOV.UK API client's protocol and connection usage
+func getClientStats(govukClient *govuk.Client, log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		stats := govukClient.GetClientStats()
+
+		log.WithFields(map[string]interface{}{
+			"http2_active":     stats.HTTP2Active,
+			"idle_connections": stats.IdleConnections,
+		}).Debug().Msg("Fetched GOV.UK client stats")
+
+		c.JSON(http.StatusOK, gin.H{
+			"govuk_client": stats,
+		})
+	}
+}
+
+// getApplicationsDiff returns the last change to the GOV.UK application
+// list, or a null diff if it hasn't changed since the server started
+func getApplicationsDiff(govukClient *govuk.Client) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		diff, found := govukClient.LastDiff()
+		if !found {
+			c.JSON(http.StatusOK, gin.H{"diff": nil})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"diff": diff})
+	}
+}
+
+// diffStreamDeadlineMargin is how long before the request deadline the
+// application diff stream is closed, so it ends cleanly and EventSource
+// clients reconnect rather than receiving a timeout response
+const diffStreamDeadlineMargin = time.Second
+
+// streamApplicationsDiff sends a server-sent "diff" event whenever the GOV.UK
+// application list changes. The stream is closed shortly before the route
+// timeout and clients are expected to reconnect.
+func streamApplicationsDiff(govukClient *govuk.Client, log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		if deadline, ok := ctx.Deadline(); ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithDeadline(ctx, deadline.Add(-diffStreamDeadlineMargin))
+			defer cancel()
+		}
+
+		diffs, unsubscribe := govukClient.SubscribeDiffs()
+		defer unsubscribe()
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Header("X-Accel-Buffering", "no")
+		handlers.ClearWriteDeadline(c, log)
+		c.Status(http.StatusOK)
+		c.Writer.Flush()
+
+		for {
+			select {
+			case diff := <-diffs:
+				c.SSEvent("diff", diff)
+				c.Writer.Flush()
+			case <-ctx.Done():
+				log.Debug().Msg("Application diff stream closed")
+				return
+			}
+		}
+	}
+}
+
+// getEC2Instances handles GET /api/ec2/instances
+func getEC2Instances(awsClient *aws.Client, log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		instances, err := awsClient.GetEC2Instances(c.Request.Context())
+		if err != nil {
+			log.WithError(err).Error().Msg("Failed to get EC2 instances")
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to get EC2 instances",
+			})
+			return
+		}
+
+		totalHourlyCost := 0.0
+		for _, instance := range instances {
+			totalHourlyCost += instance.EstimatedHourlyCost
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"instances":                   instances,
+			"count":                       len(instances),
+			"total_estimated_hourly_cost": totalHourlyCost,
+		})
+	}
+}
+
+// getInfrastructureChanges handles GET /api/infrastructure/changes
+func getInfrastructureChanges(awsClient *aws.Client, log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		hours, err := strconv.Atoi(c.DefaultQuery("hours", "24"))
+		if err != nil || hours < 1 || hours > 168 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "hours must be a number between 1 and 168",
+			})
+			return
+		}
+
+		events, err := awsClient.GetRecentInfrastructureChanges(c.Request.Context(), hours)
+		if err != nil {
+			log.WithError(err).Error().Msg("Failed to get infrastructure changes")
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to get infrastructure changes",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"events": events,
+			"count":  len(events),
+			"hours":  hours,
+		})
+	}
+}
+
+// getGovUKClientMetrics handles GET /api/admin/govuk-client-metrics
+func getGovUKClientMetrics(govukClient *govuk.Client, log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		metrics := govukClient.GetMetrics()
+
+		log.WithFields(map[string]interface{}{
+			"total_requests": metrics.TotalRequests,
+			"cache_hits":     metrics.CacheHits,
+		}).Debug().Msg("Fetched GOV.UK client metrics")
+
+		c.JSON(http.StatusOK, gin.H{
+			"govuk_client": metrics,
+			"latency":      govukClient.GetLatencyStats(),
+		})
+	}
+}
+
+// registerCacheMetrics exports the hit and miss counts of the GOV.UK API and
+// report caches, and the GOV.UK client metrics, to registry
+func registerCacheMetrics(registry *metrics.Registry, govukClient *govuk.Client, reportsManager *reports.Manager) {
+	if registry == nil {
+		return
+	}
+
+	registry.RegisterCache("govuk_api", func() (int64, int64) {
+		clientMetrics := govukClient.GetMetrics()
+		return clientMetrics.CacheHits, clientMetrics.CacheMisses
+	})
+	registry.RegisterCache("report_summary", func() (int64, int64) {
+		stats := reportsManager.GetCacheStats()
+		return stats.SummaryHits, stats.SummaryMisses
+	})
+	registry.RegisterCache("report", func() (int64, int64) {
+		stats := reportsManager.GetCacheStats()
+		return stats.ReportHits, stats.ReportMisses
+	})
+	registry.MustRegister(govukClient.PrometheusCollector())
 }
 
 // getSpecificReport handles requests for specific report types
@@ -440,9 +1335,31 @@ func getSpecificReport(manager *reports.Manager, reportID string, log *logger.Lo
 	return func(c *gin.Context) {
 		params := reports.ReportParams{
 			UseCache: true,
+			Format:   reportFormat(c),
+		}
+		if !validReportFormats[params.Format] {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":     fmt.Sprintf("Unsupported format %q, expected json, yaml or toml", params.Format),
+				"report_id": reportID,
+			})
+			return
 		}
 
 		reportData, err := manager.GenerateReport(c.Request.Context(), reportID, params)
+		if errors.Is(err, reports.ErrReportNotFound) || errors.Is(err, reports.ErrReportDisabled) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":     err.Error(),
+				"report_id": reportID,
+			})
+			return
+		}
+		if errors.Is(err, reports.ErrInvalidParams) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":     err.Error(),
+				"report_id": reportID,
+			})
+			return
+		}
 		if err != nil {
 			log.WithError(err).WithField("report_id", reportID).Error().Msg("Failed to generate specific report")
 			c.JSON(http.StatusInternalServerError, gin.H{
@@ -452,7 +1369,7 @@ func getSpecificReport(manager *reports.Manager, reportID string, log *logger.Lo
 			return
 		}
 
-		c.JSON(http.StatusOK, reportData)
+		writeReport(c, reportData, params.Format, log)
 	}
 }
 
@@ -496,3 +1413,29 @@ func getServiceUnavailablePageHandler(message string, log *logger.Logger) gin.Ha
 		})
 	}
 }
+
+// writeExampleConfig writes the default configuration as YAML
+func writeExampleConfig(path string) error {
+	header := "# Example configuration generated by `make build`. Environment variables\n# take precedence over values in this file.\n"
+	return os.WriteFile(path, []byte(header+config.GenerateDefaultConfig()), 0644)
+}
+
+// logApplicationChanges logs GOV.UK application changes as audit events
+// until ctx is cancelled
+func logApplicationChanges(ctx context.Context, events <-chan govuk.ApplicationChangeEvent, log *logger.Logger) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-events:
+			log.WithFields(map[string]interface{}{
+				"audit_event": "application_changed",
+				"app_name":    event.AppName,
+				"field":       event.Field,
+				"old_value":   event.OldValue,
+				"new_value":   event.NewValue,
+				"detected_at": event.DetectedAt,
+			}).Info().Msg("GOV.UK application changed")
+		}
+	}
+}
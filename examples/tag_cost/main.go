@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -39,6 +40,8 @@ func main() {
 		log.Fatalf("Failed to create AWS client: %v", err)
 	}
 	
+	ctx := context.Background()
+
 	// Test tag prefix configuration
 	fmt.Printf("📊 Current tag prefix: %s\n", os.Getenv("GOVUK_APP_TAG_PREFIX"))
 	if os.Getenv("GOVUK_APP_TAG_PREFIX") == "" {
@@ -54,7 +57,7 @@ func main() {
 		fmt.Printf("  • Querying costs for: %s\n", appName)
 		
 		// This would query for tag "govuk-{appName}" by default
-		costData, err := client.GetCostDataForApplication(appName)
+		costData, err := client.GetCostDataForApplication(ctx, appName, 1)
 		if err != nil {
 			fmt.Printf("    ❌ Error: %v\n", err)
 			continue
@@ -75,7 +78,7 @@ func main() {
 	fmt.Println("🏷️  Getting all costs grouped by system tags:")
 	
 	// Query all costs grouped by system tags
-	allTagCosts, err := client.GetCostDataBySystemTag()
+	allTagCosts, err := client.GetCostDataBySystemTag(ctx)
 	if err != nil {
 		fmt.Printf("❌ Error querying by system tags: %v\n", err)
 		return
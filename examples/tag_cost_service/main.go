@@ -7,6 +7,7 @@ import (
 
 	"govuk-reports-dashboard/internal/config"
 	"govuk-reports-dashboard/internal/modules/costs"
+	"govuk-reports-dashboard/internal/reports"
 	"govuk-reports-dashboard/pkg/aws"
 	"govuk-reports-dashboard/pkg/govuk"
 	"govuk-reports-dashboard/pkg/logger"
@@ -92,7 +93,7 @@ func main() {
 	
 	fmt.Println("\n🏛️  Getting overview of all applications with cost sources:")
 	
-	allApps, err := appService.GetAllApplications(ctx)
+	allApps, err := appService.GetAllApplications(ctx, reports.ReportParams{}, nil)
 	if err != nil {
 		fmt.Printf("❌ Error getting all applications: %v\n", err)
 		return
@@ -2,76 +2,238 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
+
+	"govuk-reports-dashboard/pkg/api"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	Server     ServerConfig
-	AWS        AWSConfig
-	GOVUK      GOVUKConfig
-	Log        LogConfig
-	Cache      CacheConfig
-	Monitoring MonitoringConfig
+	Server     ServerConfig     `yaml:"server"`
+	AWS        AWSConfig        `yaml:"aws"`
+	GOVUK      GOVUKConfig      `yaml:"govuk"`
+	Log        LogConfig        `yaml:"log"`
+	Cache      CacheConfig      `yaml:"cache"`
+	Monitoring MonitoringConfig `yaml:"monitoring"`
+
+	Notifications NotificationConfig `yaml:"notifications"`
+
+	warnings []string
 }
 
 type ServerConfig struct {
-	Port         string
-	Host         string
-	Environment  string
-	ReadTimeout  int
-	WriteTimeout int
-	IdleTimeout  int
-	TLSEnabled   bool
-	CertFile     string
-	KeyFile      string
+	Port           string                   `yaml:"port"`
+	Host           string                   `yaml:"host"`
+	Environment    string                   `yaml:"environment"`
+	ReadTimeout    int                      `yaml:"read_timeout"`
+	WriteTimeout   int                      `yaml:"write_timeout"`
+	IdleTimeout    int                      `yaml:"idle_timeout"`
+	RequestTimeout time.Duration            `yaml:"request_timeout"`
+	RouteTimeouts  map[string]time.Duration `yaml:"route_timeouts"`
+	TLSEnabled     bool                     `yaml:"tls_enabled"`
+	CertFile       string                   `yaml:"cert_file"`
+	KeyFile        string                   `yaml:"key_file"`
+
+	// HSTSPreload adds "preload" to the Strict-Transport-Security header.
+	// Once the domain is on the browsers' preload list it is hard to remove.
+	HSTSPreload bool `yaml:"hsts_preload"`
+
+	// CORSAdditionalOrigins are allowed cross-origin in production as well as
+	// the GOV.UK domains
+	CORSAdditionalOrigins []string `yaml:"cors_additional_origins"`
+
+	// TrustedProxies are the IP addresses or CIDR ranges of proxies whose
+	// X-Forwarded-For headers are trusted to give the client IP, which rate
+	// limiting is keyed on. When empty the connection's address is used.
+	TrustedProxies []string `yaml:"trusted_proxies"`
+
+	// AdminAPIToken is the bearer token required by routes that change
+	// server state, such as disabling reports or applying tags. Those routes
+	// are refused when it is empty.
+	AdminAPIToken string `yaml:"admin_api_token"`
+
+	// ReportsMaxConcurrent caps how many reports are generated at once, such
+	// as when building the dashboard summary. Zero means no limit.
+	ReportsMaxConcurrent int `yaml:"reports_max_concurrent"`
+
+	// DefaultAPIVersion is the API version unversioned /api routes serve
+	// when the Accept header doesn't request one
+	DefaultAPIVersion string `yaml:"default_api_version"`
 }
 
+// DefaultRouteTimeouts are the per-route request timeouts, keyed by path
+// prefix. Entries in ROUTE_TIMEOUTS override or extend these.
+var DefaultRouteTimeouts = map[string]time.Duration{
+	"/api/reports":      120 * time.Second,
+	"/api/health":       5 * time.Second,
+	"/api/applications": 30 * time.Second,
+}
+
+// MaxRequestTimeout is the longest request timeout allowed for any route
+const MaxRequestTimeout = 300 * time.Second
+
 type AWSConfig struct {
-	Region             string
-	AccessKeyID        string
-	SecretAccessKey    string
-	SessionToken       string
-	Profile            string
-	MFAToken           string
-	CostExplorerRegion string
-	MaxRetries         int
-	RetryDelay         time.Duration
+	Region             string        `yaml:"region"`
+	AccessKeyID        string        `yaml:"access_key_id"`
+	SecretAccessKey    string        `yaml:"secret_access_key"`
+	SessionToken       string        `yaml:"session_token"`
+	Profile            string        `yaml:"profile"`
+	MFAToken           string        `yaml:"mfa_token"`
+	CostExplorerRegion string        `yaml:"cost_explorer_region"`
+	MaxRetries         int           `yaml:"max_retries"`
+	RetryDelay         time.Duration `yaml:"retry_delay"`
+	EKSClusterName     string        `yaml:"eks_cluster_name"`
+	ReportingCurrency  string        `yaml:"reporting_currency"`
+
+	// RequiredRDSTags are the tags every RDS instance should have
+	RequiredRDSTags []string `yaml:"required_rds_tags"`
+
+	// MinElastiCacheSnapshotRetention is the fewest days of automatic
+	// backups production ElastiCache replication groups should keep
+	MinElastiCacheSnapshotRetention int `yaml:"min_elasticache_snapshot_retention"`
+
+	// CostModelPath is a YAML file overriding the defaults used to estimate
+	// costs for applications without matching AWS cost data
+	CostModelPath string `yaml:"cost_model_path"`
+
+	// AWSPermissionCheck probes the required AWS APIs at startup, and
+	// FailOnPermissionError stops the server if any of them fail
+	AWSPermissionCheck    bool `yaml:"aws_permission_check"`
+	FailOnPermissionError bool `yaml:"fail_on_permission_error"`
+
+	// AssumeRoles are roles in other AWS accounts, such as integration and
+	// staging, whose RDS and ElastiCache resources are reported alongside
+	// those of the default credentials
+	AssumeRoles []AssumeRoleConfig `yaml:"assume_roles"`
+}
+
+// AssumeRoleConfig is an IAM role assumed with the default credentials.
+// ExternalID and MFASerial are only needed if the role's trust policy
+// requires them.
+type AssumeRoleConfig struct {
+	RoleARN     string `yaml:"role_arn"`
+	SessionName string `yaml:"session_name"`
+	ExternalID  string `yaml:"external_id"`
+	MFASerial   string `yaml:"mfa_serial"`
 }
 
 type GOVUKConfig struct {
-	APIBaseURL      string
-	APIKey          string
-	AppsAPITimeout  time.Duration
-	AppsAPICacheTTL time.Duration
-	AppsAPIRetries  int
-	RateLimit       int
-	UserAgent       string
+	APIBaseURL        string        `yaml:"api_base_url"`
+	APIKey            string        `yaml:"api_key"`
+	AppsAPITimeout    time.Duration `yaml:"apps_api_timeout"`
+	AppsAPICacheTTL   time.Duration `yaml:"apps_api_cache_ttl"`
+	AppsAPIRetries    int           `yaml:"apps_api_retries"`
+	RateLimit         int           `yaml:"rate_limit"`
+	UserAgent         string        `yaml:"user_agent"`
+	EnableHTTP2       bool          `yaml:"enable_http2"`
+	EnableCompression bool          `yaml:"enable_compression"`
+	TeamRosterURL     string        `yaml:"team_roster_url"`
+
+	// TeamRosterAPIKey is sent as a bearer token to TeamRosterURL. No
+	// Authorization header is sent when it is empty.
+	TeamRosterAPIKey string `yaml:"team_roster_api_key"`
 }
 
 type LogConfig struct {
-	Level      string
-	Format     string
-	Output     string
-	TimeFormat string
-	Colorize   bool
+	Level      string `yaml:"level"`
+	Format     string `yaml:"format"`
+	Output     string `yaml:"output"`
+	TimeFormat string `yaml:"time_format"`
+	Colorize   bool   `yaml:"colorize"`
+	MaxSizeMB  int    `yaml:"max_size_mb"`
+	MaxAgeDays int    `yaml:"max_age_days"`
+	MaxBackups int    `yaml:"max_backups"`
+	Compress   bool   `yaml:"compress"`
 }
 
 type CacheConfig struct {
-	DefaultTTL     time.Duration
-	CleanupPeriod  time.Duration
-	MaxSize        int
-	EvictionPolicy string
+	DefaultTTL           time.Duration `yaml:"default_ttl"`
+	CleanupPeriod        time.Duration `yaml:"cleanup_period"`
+	MaxSize              int           `yaml:"max_size"`
+	EvictionPolicy       string        `yaml:"eviction_policy"`
+	CachePersistencePath string        `yaml:"cache_persistence_path"`
 }
 
 type MonitoringConfig struct {
-	MetricsEnabled bool
-	MetricsPort    string
-	HealthPath     string
-	ReadyzPath     string
-	LivezPath      string
+	MetricsEnabled bool   `yaml:"metrics_enabled"`
+	MetricsPort    string `yaml:"metrics_port"`
+	HealthPath     string `yaml:"health_path"`
+	ReadyzPath     string `yaml:"readyz_path"`
+	LivezPath      string `yaml:"livez_path"`
+
+	// PagerDutyRoutingKey enables PagerDuty alerts for critical report findings
+	PagerDutyRoutingKey string `yaml:"pagerduty_routing_key"`
+
+	// SentryAPIToken enables Sentry project details and error rates for
+	// applications
+	SentryAPIToken     string `yaml:"sentry_api_token"`
+	SentryOrganization string `yaml:"sentry_organization"`
+
+	// PagerDutyAPIToken enables on-call lookups from the PagerDuty REST API
+	// for the teams in TeamPagerDutySchedules, which maps GOV.UK team names
+	// to PagerDuty schedule IDs
+	PagerDutyAPIToken      string            `yaml:"pagerduty_api_token"`
+	TeamPagerDutySchedules map[string]string `yaml:"team_pagerduty_schedules"`
+
+	// AlertTeam is the team whose on-call person is added to alerts that
+	// don't name a team, such as RDS end-of-life alerts
+	AlertTeam string `yaml:"alert_team"`
+
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+
+	// TracingEndpoint enables OpenTelemetry tracing, exporting spans over
+	// OTLP/HTTP to a collector such as "http://otel-collector:4318"
+	TracingEndpoint    string `yaml:"tracing_endpoint"`
+	TracingServiceName string `yaml:"tracing_service_name"`
+}
+
+// RateLimitConfig limits API requests per client IP. Counts are shared
+// between instances in Redis when RedisAddr is set, and kept in memory
+// otherwise. BurstSize requests are allowed on top of RequestsPerMinute.
+type RateLimitConfig struct {
+	Enabled           bool   `yaml:"enabled"`
+	RedisAddr         string `yaml:"redis_addr"`
+	RequestsPerMinute int    `yaml:"requests_per_minute"`
+	BurstSize         int    `yaml:"burst_size"`
+	KeyPrefix         string `yaml:"key_prefix"`
+}
+
+// NotificationConfig sends notifications when reports find RDS instances or
+// ElastiCache clusters that are newly end-of-life, outdated or missing
+// critical patches. WebhookURL receives a JSON payload and SlackWebhookURL
+// a Slack message; neither is used when empty.
+//
+// The reports are checked every CheckInterval, so instances are notified
+// even if nobody views the reports, with zero meaning only when they are
+// generated. StatePath is a file recording the instances already notified,
+// so they aren't sent again after a restart; when empty this is kept in
+// memory.
+type NotificationConfig struct {
+	WebhookURL            string                `yaml:"webhook_url"`
+	SlackWebhookURL       string                `yaml:"slack_webhook_url"`
+	NotifyOnEOL           bool                  `yaml:"notify_on_eol"`
+	NotifyOnOutdated      bool                  `yaml:"notify_on_outdated"`
+	NotifyOnCriticalPatch bool                  `yaml:"notify_on_critical_patch"`
+	AlertThresholds       AlertThresholdsConfig `yaml:"alert_thresholds"`
+	CheckInterval         time.Duration         `yaml:"check_interval"`
+	StatePath             string                `yaml:"state_path"`
+}
+
+// AlertThresholdsConfig sets how close to end-of-life an outdated version
+// must be before it is notified. EOLWarningDays notifies it as approaching
+// end-of-life when NotifyOnEOL is set; OutdatedWarningDays limits outdated
+// notifications, with zero meaning every outdated version.
+type AlertThresholdsConfig struct {
+	EOLWarningDays      int `yaml:"eol_warning_days"`
+	OutdatedWarningDays int `yaml:"outdated_warning_days"`
 }
 
 // ValidationError represents a configuration validation error
@@ -84,61 +246,99 @@ func (e ValidationError) Error() string {
 	return fmt.Sprintf("config validation error for %s: %s", e.Field, e.Message)
 }
 
-// Load loads and validates configuration from environment variables
-func Load() (*Config, error) {
-	config := &Config{
+// Default returns the configuration used when neither a config file nor
+// environment variables set a value
+func Default() *Config {
+	return &Config{
 		Server: ServerConfig{
-			Port:         getEnv("PORT", "8080"),
-			Host:         getEnv("HOST", ""),
-			Environment:  getEnv("ENVIRONMENT", "development"),
-			ReadTimeout:  getEnvAsInt("READ_TIMEOUT", 30),
-			WriteTimeout: getEnvAsInt("WRITE_TIMEOUT", 30),
-			IdleTimeout:  getEnvAsInt("IDLE_TIMEOUT", 120),
-			TLSEnabled:   getEnvAsBool("TLS_ENABLED", false),
-			CertFile:     getEnv("TLS_CERT_FILE", ""),
-			KeyFile:      getEnv("TLS_KEY_FILE", ""),
+			Port:           "8080",
+			Environment:    "development",
+			ReadTimeout:    30,
+			WriteTimeout:   130,
+			IdleTimeout:    120,
+			RequestTimeout: 30 * time.Second,
+			RouteTimeouts:  copyDurations(DefaultRouteTimeouts),
+
+			ReportsMaxConcurrent: 10,
+			DefaultAPIVersion:    api.Latest,
 		},
 		AWS: AWSConfig{
-			Region:             getEnv("AWS_REGION", "eu-west-2"),
-			AccessKeyID:        getEnv("AWS_ACCESS_KEY_ID", ""),
-			SecretAccessKey:    getEnv("AWS_SECRET_ACCESS_KEY", ""),
-			SessionToken:       getEnv("AWS_SESSION_TOKEN", ""),
-			Profile:            getEnv("AWS_PROFILE", ""),
-			MFAToken:           getEnv("AWS_MFA_TOKEN", ""),
-			CostExplorerRegion: getEnv("AWS_COST_EXPLORER_REGION", "us-east-1"),
-			MaxRetries:         getEnvAsInt("AWS_MAX_RETRIES", 3),
-			RetryDelay:         getEnvAsDuration("AWS_RETRY_DELAY", 1*time.Second),
+			Region:             "eu-west-2",
+			CostExplorerRegion: "us-east-1",
+			MaxRetries:         3,
+			RetryDelay:         1 * time.Second,
+			ReportingCurrency:  "GBP",
+			RequiredRDSTags:    []string{"system", "environment"},
+
+			MinElastiCacheSnapshotRetention: 1,
 		},
 		GOVUK: GOVUKConfig{
-			APIBaseURL:      getEnv("GOVUK_API_BASE_URL", "https://www.gov.uk/api"),
-			APIKey:          getEnv("GOVUK_API_KEY", ""),
-			AppsAPITimeout:  getEnvAsDuration("GOVUK_APPS_API_TIMEOUT", 30*time.Second),
-			AppsAPICacheTTL: getEnvAsDuration("GOVUK_APPS_API_CACHE_TTL", 15*time.Minute),
-			AppsAPIRetries:  getEnvAsInt("GOVUK_APPS_API_RETRIES", 3),
-			RateLimit:       getEnvAsInt("GOVUK_RATE_LIMIT", 100),
-			UserAgent:       getEnv("GOVUK_USER_AGENT", "GOV.UK-Cost-Dashboard/1.0"),
+			APIBaseURL:        "https://www.gov.uk/api",
+			AppsAPITimeout:    30 * time.Second,
+			AppsAPICacheTTL:   15 * time.Minute,
+			AppsAPIRetries:    3,
+			RateLimit:         100,
+			UserAgent:         "GOV.UK-Cost-Dashboard/1.0",
+			EnableHTTP2:       true,
+			EnableCompression: true,
 		},
 		Log: LogConfig{
-			Level:      getEnv("LOG_LEVEL", "info"),
-			Format:     getEnv("LOG_FORMAT", "console"),
-			Output:     getEnv("LOG_OUTPUT", "stdout"),
-			TimeFormat: getEnv("LOG_TIME_FORMAT", "rfc3339"),
-			Colorize:   getEnvAsBool("LOG_COLORIZE", true),
+			Level:      "info",
+			Format:     "console",
+			Output:     "stdout",
+			TimeFormat: "rfc3339",
+			Colorize:   true,
 		},
 		Cache: CacheConfig{
-			DefaultTTL:     getEnvAsDuration("CACHE_DEFAULT_TTL", 10*time.Minute),
-			CleanupPeriod:  getEnvAsDuration("CACHE_CLEANUP_PERIOD", 5*time.Minute),
-			MaxSize:        getEnvAsInt("CACHE_MAX_SIZE", 1000),
-			EvictionPolicy: getEnv("CACHE_EVICTION_POLICY", "LRU"),
+			DefaultTTL:     10 * time.Minute,
+			CleanupPeriod:  5 * time.Minute,
+			MaxSize:        1000,
+			EvictionPolicy: "LRU",
 		},
 		Monitoring: MonitoringConfig{
-			MetricsEnabled: getEnvAsBool("METRICS_ENABLED", true),
-			MetricsPort:    getEnv("METRICS_PORT", "9090"),
-			HealthPath:     getEnv("HEALTH_PATH", "/api/health"),
-			ReadyzPath:     getEnv("READYZ_PATH", "/api/readyz"),
-			LivezPath:      getEnv("LIVEZ_PATH", "/api/livez"),
+			MetricsEnabled: true,
+			MetricsPort:    "9090",
+			HealthPath:     "/api/health",
+			ReadyzPath:     "/api/readyz",
+			LivezPath:      "/api/livez",
+
+			SentryOrganization: "govuk",
+			TracingServiceName: "govuk-reports-dashboard",
+
+			RateLimit: RateLimitConfig{
+				RequestsPerMinute: 120,
+				BurstSize:         20,
+				KeyPrefix:         "govuk-reports:ratelimit:",
+			},
+		},
+		Notifications: NotificationConfig{
+			NotifyOnEOL: true,
+			AlertThresholds: AlertThresholdsConfig{
+				EOLWarningDays: 30,
+			},
+			CheckInterval: time.Hour,
 		},
 	}
+}
+
+// Load loads and validates configuration from the file named by CONFIG_FILE,
+// if set, and environment variables
+func Load() (*Config, error) {
+	return LoadWithFile(os.Getenv("CONFIG_FILE"))
+}
+
+// LoadWithFile loads configuration from a YAML, JSON or TOML file, if path is set,
+// then overlays any environment variables that are set, so environment
+// variables take precedence over file values
+func LoadWithFile(path string) (*Config, error) {
+	config := Default()
+	if path != "" {
+		if err := config.readFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	config.applyEnv()
 
 	if err := config.Validate(); err != nil {
 		return nil, err
@@ -147,9 +347,186 @@ func Load() (*Config, error) {
 	return config, nil
 }
 
+// LoadFromFile loads and validates configuration from a YAML, JSON or TOML
+// file only. Settings missing from the file keep their defaults.
+func LoadFromFile(path string) (*Config, error) {
+	config := Default()
+	if err := config.readFile(path); err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// readFile unmarshals a config file over c. JSON is valid YAML, so both
+// formats are read with the YAML decoder. Files ending .toml are converted
+// to YAML first, so every format shares the yaml struct tags and duration
+// strings such as "45s".
+func (c *Config) readFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		var values map[string]interface{}
+		if err := toml.Unmarshal(data, &values); err != nil {
+			return fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+		if data, err = yaml.Marshal(values); err != nil {
+			return fmt.Errorf("failed to convert config file %s: %w", path, err)
+		}
+	}
+
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// GenerateDefaultConfig returns the default configuration as YAML, for
+// documenting the config file format
+func GenerateDefaultConfig() string {
+	data, err := yaml.Marshal(Default())
+	if err != nil {
+		// The default config only holds plain values, so always marshals
+		panic(fmt.Sprintf("failed to marshal default config: %v", err))
+	}
+	return string(data)
+}
+
+// applyEnv overrides c with every environment variable that is set
+func (c *Config) applyEnv() {
+	c.Server.Port = getEnv("PORT", c.Server.Port)
+	c.Server.Host = getEnv("HOST", c.Server.Host)
+	c.Server.Environment = getEnv("ENVIRONMENT", c.Server.Environment)
+	c.Server.ReadTimeout = getEnvAsInt("READ_TIMEOUT", c.Server.ReadTimeout)
+	c.Server.WriteTimeout = getEnvAsInt("WRITE_TIMEOUT", c.Server.WriteTimeout)
+	c.Server.IdleTimeout = getEnvAsInt("IDLE_TIMEOUT", c.Server.IdleTimeout)
+	c.Server.RequestTimeout = getEnvAsDuration("REQUEST_TIMEOUT", c.Server.RequestTimeout)
+	c.Server.RouteTimeouts = getEnvAsDurationMap("ROUTE_TIMEOUTS", c.Server.RouteTimeouts)
+	c.Server.TLSEnabled = getEnvAsBool("TLS_ENABLED", c.Server.TLSEnabled)
+	c.Server.CertFile = getEnv("TLS_CERT_FILE", c.Server.CertFile)
+	c.Server.KeyFile = getEnv("TLS_KEY_FILE", c.Server.KeyFile)
+	c.Server.HSTSPreload = getEnvAsBool("HSTS_PRELOAD", c.Server.HSTSPreload)
+	c.Server.CORSAdditionalOrigins = getEnvAsSlice("CORS_ADDITIONAL_ORIGINS", c.Server.CORSAdditionalOrigins)
+	c.Server.TrustedProxies = getEnvAsSlice("TRUSTED_PROXIES", c.Server.TrustedProxies)
+	c.Server.AdminAPIToken = getEnv("ADMIN_API_TOKEN", c.Server.AdminAPIToken)
+	c.Server.ReportsMaxConcurrent = getEnvAsInt("REPORTS_MAX_CONCURRENT", c.Server.ReportsMaxConcurrent)
+	c.Server.DefaultAPIVersion = getEnv("DEFAULT_API_VERSION", c.Server.DefaultAPIVersion)
+
+	c.AWS.Region = getEnv("AWS_REGION", c.AWS.Region)
+	c.AWS.AccessKeyID = getEnv("AWS_ACCESS_KEY_ID", c.AWS.AccessKeyID)
+	c.AWS.SecretAccessKey = getEnv("AWS_SECRET_ACCESS_KEY", c.AWS.SecretAccessKey)
+	c.AWS.SessionToken = getEnv("AWS_SESSION_TOKEN", c.AWS.SessionToken)
+	c.AWS.Profile = getEnv("AWS_PROFILE", c.AWS.Profile)
+	c.AWS.MFAToken = getEnv("AWS_MFA_TOKEN", c.AWS.MFAToken)
+	c.AWS.CostExplorerRegion = getEnv("AWS_COST_EXPLORER_REGION", c.AWS.CostExplorerRegion)
+	c.AWS.CostModelPath = getEnv("COST_MODEL_PATH", c.AWS.CostModelPath)
+	c.AWS.MaxRetries = getEnvAsInt("AWS_MAX_RETRIES", c.AWS.MaxRetries)
+	c.AWS.RetryDelay = getEnvAsDuration("AWS_RETRY_DELAY", c.AWS.RetryDelay)
+	c.AWS.EKSClusterName = getEnv("EKS_CLUSTER_NAME", c.AWS.EKSClusterName)
+	c.AWS.ReportingCurrency = getEnv("AWS_REPORTING_CURRENCY", c.AWS.ReportingCurrency)
+	c.AWS.RequiredRDSTags = getEnvAsSlice("RDS_REQUIRED_TAGS", c.AWS.RequiredRDSTags)
+	c.AWS.MinElastiCacheSnapshotRetention = getEnvAsInt("ELASTICACHE_MIN_SNAPSHOT_RETENTION", c.AWS.MinElastiCacheSnapshotRetention)
+
+	c.AWS.AWSPermissionCheck = getEnvAsBool("AWS_PERMISSION_CHECK", c.AWS.AWSPermissionCheck)
+	c.AWS.FailOnPermissionError = getEnvAsBool("AWS_FAIL_ON_PERMISSION_ERROR", c.AWS.FailOnPermissionError)
+	if roleARNs := getEnvAsSlice("AWS_ASSUME_ROLE_ARNS", nil); roleARNs != nil {
+		c.AWS.AssumeRoles = make([]AssumeRoleConfig, len(roleARNs))
+		for i, roleARN := range roleARNs {
+			c.AWS.AssumeRoles[i] = AssumeRoleConfig{RoleARN: roleARN}
+		}
+	}
+
+	c.GOVUK.APIBaseURL = getEnv("GOVUK_API_BASE_URL", c.GOVUK.APIBaseURL)
+	c.GOVUK.APIKey = getEnv("GOVUK_API_KEY", c.GOVUK.APIKey)
+	c.GOVUK.AppsAPITimeout = getEnvAsDuration("GOVUK_APPS_API_TIMEOUT", c.GOVUK.AppsAPITimeout)
+	c.GOVUK.AppsAPICacheTTL = getEnvAsDuration("GOVUK_APPS_API_CACHE_TTL", c.GOVUK.AppsAPICacheTTL)
+	c.GOVUK.AppsAPIRetries = getEnvAsInt("GOVUK_APPS_API_RETRIES", c.GOVUK.AppsAPIRetries)
+	c.GOVUK.RateLimit = getEnvAsInt("GOVUK_RATE_LIMIT", c.GOVUK.RateLimit)
+	c.GOVUK.UserAgent = getEnv("GOVUK_USER_AGENT", c.GOVUK.UserAgent)
+	c.GOVUK.EnableHTTP2 = getEnvAsBool("GOVUK_ENABLE_HTTP2", c.GOVUK.EnableHTTP2)
+	c.GOVUK.EnableCompression = getEnvAsBool("GOVUK_ENABLE_COMPRESSION", c.GOVUK.EnableCompression)
+	c.GOVUK.TeamRosterURL = getEnv("GOVUK_TEAM_ROSTER_URL", c.GOVUK.TeamRosterURL)
+	c.GOVUK.TeamRosterAPIKey = getEnv("GOVUK_TEAM_ROSTER_API_KEY", c.GOVUK.TeamRosterAPIKey)
+
+	c.Log.Level = getEnv("LOG_LEVEL", c.Log.Level)
+	c.Log.Format = getEnv("LOG_FORMAT", c.Log.Format)
+	c.Log.Output = getEnv("LOG_OUTPUT", c.Log.Output)
+	c.Log.TimeFormat = getEnv("LOG_TIME_FORMAT", c.Log.TimeFormat)
+	c.Log.Colorize = getEnvAsBool("LOG_COLORIZE", c.Log.Colorize)
+	c.Log.MaxSizeMB = getEnvAsInt("LOG_MAX_SIZE_MB", c.Log.MaxSizeMB)
+	c.Log.MaxAgeDays = getEnvAsInt("LOG_MAX_AGE_DAYS", c.Log.MaxAgeDays)
+	c.Log.MaxBackups = getEnvAsInt("LOG_MAX_BACKUPS", c.Log.MaxBackups)
+	c.Log.Compress = getEnvAsBool("LOG_COMPRESS", c.Log.Compress)
+
+	c.Cache.DefaultTTL = getEnvAsDuration("CACHE_DEFAULT_TTL", c.Cache.DefaultTTL)
+	c.Cache.CleanupPeriod = getEnvAsDuration("CACHE_CLEANUP_PERIOD", c.Cache.CleanupPeriod)
+	c.Cache.MaxSize = getEnvAsInt("CACHE_MAX_SIZE", c.Cache.MaxSize)
+	c.Cache.EvictionPolicy = getEnv("CACHE_EVICTION_POLICY", c.Cache.EvictionPolicy)
+	c.Cache.CachePersistencePath = getEnv("CACHE_PERSISTENCE_PATH", c.Cache.CachePersistencePath)
+
+	c.Monitoring.MetricsEnabled = getEnvAsBool("METRICS_ENABLED", c.Monitoring.MetricsEnabled)
+	c.Monitoring.MetricsPort = getEnv("METRICS_PORT", c.Monitoring.MetricsPort)
+	c.Monitoring.HealthPath = getEnv("HEALTH_PATH", c.Monitoring.HealthPath)
+	c.Monitoring.ReadyzPath = getEnv("READYZ_PATH", c.Monitoring.ReadyzPath)
+	c.Monitoring.LivezPath = getEnv("LIVEZ_PATH", c.Monitoring.LivezPath)
+
+	c.Monitoring.PagerDutyRoutingKey = getEnv("PAGERDUTY_ROUTING_KEY", c.Monitoring.PagerDutyRoutingKey)
+	c.Monitoring.SentryAPIToken = getEnv("SENTRY_API_TOKEN", c.Monitoring.SentryAPIToken)
+	c.Monitoring.SentryOrganization = getEnv("SENTRY_ORGANIZATION", c.Monitoring.SentryOrganization)
+	c.Monitoring.PagerDutyAPIToken = getEnv("PAGERDUTY_API_TOKEN", c.Monitoring.PagerDutyAPIToken)
+	c.Monitoring.TeamPagerDutySchedules = getEnvAsStringMap("TEAM_PAGERDUTY_SCHEDULES", c.Monitoring.TeamPagerDutySchedules)
+	c.Monitoring.AlertTeam = getEnv("ALERT_TEAM", c.Monitoring.AlertTeam)
+	c.Monitoring.TracingEndpoint = getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", c.Monitoring.TracingEndpoint)
+	c.Monitoring.TracingServiceName = getEnv("OTEL_SERVICE_NAME", c.Monitoring.TracingServiceName)
+	c.Monitoring.RateLimit.Enabled = getEnvAsBool("RATE_LIMIT_ENABLED", c.Monitoring.RateLimit.Enabled)
+	c.Monitoring.RateLimit.RedisAddr = getEnv("RATE_LIMIT_REDIS_ADDR", c.Monitoring.RateLimit.RedisAddr)
+	c.Monitoring.RateLimit.RequestsPerMinute = getEnvAsInt("RATE_LIMIT_REQUESTS_PER_MINUTE", c.Monitoring.RateLimit.RequestsPerMinute)
+	c.Monitoring.RateLimit.BurstSize = getEnvAsInt("RATE_LIMIT_BURST_SIZE", c.Monitoring.RateLimit.BurstSize)
+	c.Monitoring.RateLimit.KeyPrefix = getEnv("RATE_LIMIT_KEY_PREFIX", c.Monitoring.RateLimit.KeyPrefix)
+
+	c.Notifications.WebhookURL = getEnv("NOTIFICATION_WEBHOOK_URL", c.Notifications.WebhookURL)
+	c.Notifications.SlackWebhookURL = getEnv("SLACK_WEBHOOK_URL", c.Notifications.SlackWebhookURL)
+	c.Notifications.NotifyOnEOL = getEnvAsBool("NOTIFY_ON_EOL", c.Notifications.NotifyOnEOL)
+	c.Notifications.NotifyOnOutdated = getEnvAsBool("NOTIFY_ON_OUTDATED", c.Notifications.NotifyOnOutdated)
+	c.Notifications.NotifyOnCriticalPatch = getEnvAsBool("NOTIFY_ON_CRITICAL_PATCH", c.Notifications.NotifyOnCriticalPatch)
+	c.Notifications.AlertThresholds.EOLWarningDays = getEnvAsInt("EOL_WARNING_DAYS", c.Notifications.AlertThresholds.EOLWarningDays)
+	c.Notifications.AlertThresholds.OutdatedWarningDays = getEnvAsInt("OUTDATED_WARNING_DAYS", c.Notifications.AlertThresholds.OutdatedWarningDays)
+	c.Notifications.CheckInterval = getEnvAsDuration("NOTIFICATION_CHECK_INTERVAL", c.Notifications.CheckInterval)
+	c.Notifications.StatePath = getEnv("NOTIFICATION_STATE_PATH", c.Notifications.StatePath)
+}
+
+// MarshalYAML writes the configuration with credentials removed, so it can be
+// used to produce example config files
+func (c Config) MarshalYAML() (interface{}, error) {
+	type plainConfig Config
+	redacted := plainConfig(c)
+	redacted.AWS.AccessKeyID = ""
+	redacted.AWS.SecretAccessKey = ""
+	redacted.AWS.SessionToken = ""
+	redacted.AWS.MFAToken = ""
+	redacted.Server.AdminAPIToken = ""
+	redacted.GOVUK.APIKey = ""
+	redacted.GOVUK.TeamRosterAPIKey = ""
+	redacted.Monitoring.PagerDutyRoutingKey = ""
+	redacted.Monitoring.SentryAPIToken = ""
+	redacted.Monitoring.PagerDutyAPIToken = ""
+	redacted.Notifications.WebhookURL = ""
+	redacted.Notifications.SlackWebhookURL = ""
+	return redacted, nil
+}
+
 // Validate performs comprehensive validation of the configuration
 func (c *Config) Validate() error {
 	var errors []ValidationError
+	c.warnings = nil
 
 	// Server validation
 	if c.Server.Port == "" {
@@ -173,6 +550,44 @@ func (c *Config) Validate() error {
 		errors = append(errors, ValidationError{"server.write_timeout", "write timeout must be between 1 and 300 seconds"})
 	}
 
+	if c.Server.RequestTimeout <= 0 || c.Server.RequestTimeout > MaxRequestTimeout {
+		errors = append(errors, ValidationError{"server.request_timeout", "request timeout must be between 1 and 300 seconds"})
+	}
+
+	if c.Server.ReportsMaxConcurrent < 0 {
+		errors = append(errors, ValidationError{"server.reports_max_concurrent", "reports max concurrent must not be negative"})
+	}
+
+	if !api.IsSupported(c.Server.DefaultAPIVersion) {
+		errors = append(errors, ValidationError{"server.default_api_version", "default API version must be one of: " + strings.Join(api.Supported, ", ")})
+	}
+
+	for prefix, timeout := range c.Server.RouteTimeouts {
+		if timeout <= 0 || timeout > MaxRequestTimeout {
+			errors = append(errors, ValidationError{"server.route_timeouts", fmt.Sprintf("timeout for %s must be between 1 and 300 seconds", prefix)})
+		}
+	}
+
+	// A write timeout shorter than a request timeout would cut the connection
+	// before the handler's response, or the timeout response, is written
+	longest := c.Server.RequestTimeout
+	for _, timeout := range c.Server.RouteTimeouts {
+		if timeout > longest {
+			longest = timeout
+		}
+	}
+	if time.Duration(c.Server.WriteTimeout)*time.Second < longest {
+		errors = append(errors, ValidationError{"server.write_timeout", fmt.Sprintf("write timeout must be at least the longest request timeout (%s)", longest)})
+	}
+
+	for _, proxy := range c.Server.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				errors = append(errors, ValidationError{"server.trusted_proxies", fmt.Sprintf("trusted proxy %q must be an IP address or CIDR range", proxy)})
+			}
+		}
+	}
+
 	if c.Server.TLSEnabled {
 		if c.Server.CertFile == "" {
 			errors = append(errors, ValidationError{"server.cert_file", "TLS cert file path required when TLS is enabled"})
@@ -182,6 +597,10 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if !c.Server.TLSEnabled && c.IsProduction() {
+		c.warnings = append(c.warnings, "TLS is disabled in production; HTTPS must be terminated in front of the server")
+	}
+
 	// AWS validation
 	if c.AWS.Region == "" {
 		errors = append(errors, ValidationError{"aws.region", "AWS region cannot be empty"})
@@ -200,6 +619,12 @@ func (c *Config) Validate() error {
 		errors = append(errors, ValidationError{"aws.max_retries", "max retries must be between 0 and 10"})
 	}
 
+	for i, role := range c.AWS.AssumeRoles {
+		if !strings.HasPrefix(role.RoleARN, "arn:aws:iam::") || !strings.Contains(role.RoleARN, ":role/") {
+			errors = append(errors, ValidationError{fmt.Sprintf("aws.assume_roles[%d].role_arn", i), fmt.Sprintf("%q is not an IAM role ARN", role.RoleARN)})
+		}
+	}
+
 	// GOVUK validation
 	if c.GOVUK.APIBaseURL == "" {
 		errors = append(errors, ValidationError{"govuk.api_base_url", "GOVUK API base URL cannot be empty"})
@@ -245,6 +670,33 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.Monitoring.RateLimit.Enabled {
+		if c.Monitoring.RateLimit.RequestsPerMinute < 1 {
+			errors = append(errors, ValidationError{"monitoring.rate_limit.requests_per_minute", "requests per minute must be at least 1"})
+		}
+		if c.Monitoring.RateLimit.BurstSize < 0 {
+			errors = append(errors, ValidationError{"monitoring.rate_limit.burst_size", "burst size cannot be negative"})
+		}
+	}
+
+	// Notification validation
+	if c.Notifications.WebhookURL != "" && !isHTTPURL(c.Notifications.WebhookURL) {
+		errors = append(errors, ValidationError{"notifications.webhook_url", "webhook URL must be an absolute http or https URL"})
+	}
+	if c.Notifications.SlackWebhookURL != "" && !isHTTPURL(c.Notifications.SlackWebhookURL) {
+		errors = append(errors, ValidationError{"notifications.slack_webhook_url", "Slack webhook URL must be an absolute http or https URL"})
+	}
+
+	if c.Notifications.AlertThresholds.EOLWarningDays < 0 {
+		errors = append(errors, ValidationError{"notifications.alert_thresholds.eol_warning_days", "EOL warning days cannot be negative"})
+	}
+	if c.Notifications.AlertThresholds.OutdatedWarningDays < 0 {
+		errors = append(errors, ValidationError{"notifications.alert_thresholds.outdated_warning_days", "outdated warning days cannot be negative"})
+	}
+	if c.Notifications.CheckInterval < 0 {
+		errors = append(errors, ValidationError{"notifications.check_interval", "check interval cannot be negative"})
+	}
+
 	if len(errors) > 0 {
 		return &ConfigValidationError{Errors: errors}
 	}
@@ -265,6 +717,11 @@ func (e *ConfigValidationError) Error() string {
 	return fmt.Sprintf("configuration validation failed:\n%s", strings.Join(messages, "\n"))
 }
 
+// Warnings returns non-fatal problems found by the last call to Validate
+func (c *Config) Warnings() []string {
+	return c.warnings
+}
+
 // IsDevelopment returns true if running in development mode
 func (c *Config) IsDevelopment() bool {
 	return c.Server.Environment == "development"
@@ -318,6 +775,73 @@ func getEnvAsDuration(key string, defaultVal time.Duration) time.Duration {
 	return defaultVal
 }
 
+// getEnvAsSlice parses a comma-separated list, ignoring empty entries
+func getEnvAsSlice(key string, defaultVal []string) []string {
+	valueStr := getEnv(key, "")
+	if valueStr == "" {
+		return defaultVal
+	}
+
+	var result []string
+	for _, value := range strings.Split(valueStr, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// getEnvAsDurationMap parses "prefix=duration" pairs separated by commas,
+// e.g. "/api/reports=120s,/api/health=5s", on top of a copy of the defaults.
+// Malformed pairs are ignored.
+func getEnvAsDurationMap(key string, defaultVal map[string]time.Duration) map[string]time.Duration {
+	result := make(map[string]time.Duration, len(defaultVal))
+	for k, v := range defaultVal {
+		result[k] = v
+	}
+
+	for _, pair := range strings.Split(getEnv(key, ""), ",") {
+		name, valueStr, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found || name == "" {
+			continue
+		}
+		if value, err := time.ParseDuration(strings.TrimSpace(valueStr)); err == nil {
+			result[strings.TrimSpace(name)] = value
+		}
+	}
+
+	return result
+}
+
+// getEnvAsStringMap parses "name=value" pairs separated by commas, e.g.
+// "publishing-platform=P1234567,search=P7654321", on top of a copy of the
+// defaults. Malformed pairs are ignored.
+func getEnvAsStringMap(key string, defaultVal map[string]string) map[string]string {
+	result := make(map[string]string, len(defaultVal))
+	for k, v := range defaultVal {
+		result[k] = v
+	}
+
+	for _, pair := range strings.Split(getEnv(key, ""), ",") {
+		name, value, found := strings.Cut(strings.TrimSpace(pair), "=")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !found || name == "" || value == "" {
+			continue
+		}
+		result[name] = value
+	}
+
+	return result
+}
+
+func copyDurations(durations map[string]time.Duration) map[string]time.Duration {
+	result := make(map[string]time.Duration, len(durations))
+	for k, v := range durations {
+		result[k] = v
+	}
+	return result
+}
+
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
 		if s == item {
@@ -325,4 +849,10 @@ func contains(slice []string, item string) bool {
 		}
 	}
 	return false
-}
\ No newline at end of file
+}
+
+// isHTTPURL reports whether value is an absolute http or https URL
+func isHTTPURL(value string) bool {
+	parsed, err := url.Parse(value)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
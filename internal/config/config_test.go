@@ -1,9 +1,15 @@
 package config
 
 import (
+	"errors"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 func TestLoad(t *testing.T) {
@@ -32,6 +38,10 @@ func TestLoad(t *testing.T) {
 	if cfg.Log.Level != "info" {
 		t.Errorf("Expected default log level info, got %s", cfg.Log.Level)
 	}
+
+	if cfg.Server.RouteTimeouts["/api/reports"] != 120*time.Second {
+		t.Errorf("Expected default reports timeout 120s, got %v", cfg.Server.RouteTimeouts["/api/reports"])
+	}
 }
 
 func TestValidation(t *testing.T) {
@@ -96,6 +106,95 @@ func TestValidation(t *testing.T) {
 			expectError: true,
 			errorField:  "log.level",
 		},
+		{
+			name: "negative reports max concurrent",
+			envVars: map[string]string{
+				"PORT":                   "8080",
+				"AWS_PROFILE":            "test-profile",
+				"GOVUK_API_BASE_URL":     "https://api.test.gov.uk",
+				"REPORTS_MAX_CONCURRENT": "-1",
+			},
+			expectError: true,
+			errorField:  "server.reports_max_concurrent",
+		},
+		{
+			name: "unsupported default API version",
+			envVars: map[string]string{
+				"PORT":                "8080",
+				"AWS_PROFILE":         "test-profile",
+				"GOVUK_API_BASE_URL":  "https://api.test.gov.uk",
+				"DEFAULT_API_VERSION": "v2",
+			},
+			expectError: true,
+			errorField:  "server.default_api_version",
+		},
+		{
+			name: "route timeout too long",
+			envVars: map[string]string{
+				"PORT":                    "8080",
+				"AWS_PROFILE":            "test-profile",
+				"GOVUK_API_BASE_URL":     "https://api.test.gov.uk",
+				"ROUTE_TIMEOUTS":         "/api/reports=10m",
+			},
+			expectError: true,
+			errorField:  "server.route_timeouts",
+		},
+		{
+			name: "write timeout shorter than a route timeout",
+			envVars: map[string]string{
+				"PORT":               "8080",
+				"AWS_PROFILE":        "test-profile",
+				"GOVUK_API_BASE_URL": "https://api.test.gov.uk",
+				"WRITE_TIMEOUT":      "60",
+			},
+			expectError: true,
+			errorField:  "server.write_timeout",
+		},
+		{
+			name: "invalid trusted proxy",
+			envVars: map[string]string{
+				"PORT":               "8080",
+				"AWS_PROFILE":        "test-profile",
+				"GOVUK_API_BASE_URL": "https://api.test.gov.uk",
+				"TRUSTED_PROXIES":    "10.0.0.0/8,load-balancer",
+			},
+			expectError: true,
+			errorField:  "server.trusted_proxies",
+		},
+		{
+			name: "invalid rate limit",
+			envVars: map[string]string{
+				"PORT":                           "8080",
+				"AWS_PROFILE":                    "test-profile",
+				"GOVUK_API_BASE_URL":             "https://api.test.gov.uk",
+				"RATE_LIMIT_ENABLED":             "true",
+				"RATE_LIMIT_REQUESTS_PER_MINUTE": "0",
+			},
+			expectError: true,
+			errorField:  "monitoring.rate_limit.requests_per_minute",
+		},
+		{
+			name: "invalid notification webhook URL",
+			envVars: map[string]string{
+				"PORT":                     "8080",
+				"AWS_PROFILE":              "test-profile",
+				"GOVUK_API_BASE_URL":       "https://api.test.gov.uk",
+				"NOTIFICATION_WEBHOOK_URL": "hooks.example.org/eol",
+			},
+			expectError: true,
+			errorField:  "notifications.webhook_url",
+		},
+		{
+			name: "negative EOL warning days",
+			envVars: map[string]string{
+				"PORT":               "8080",
+				"AWS_PROFILE":        "test-profile",
+				"GOVUK_API_BASE_URL": "https://api.test.gov.uk",
+				"EOL_WARNING_DAYS":   "-1",
+			},
+			expectError: true,
+			errorField:  "notifications.alert_thresholds.eol_warning_days",
+		},
 	}
 
 	for _, tt := range tests {
@@ -146,6 +245,164 @@ func TestValidation(t *testing.T) {
 	}
 }
 
+func TestLoadFromFile(t *testing.T) {
+	clearEnvVars()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"config.yaml": "server:\n  port: \"9000\"\n  request_timeout: 45s\n  route_timeouts:\n    /api/costs: 60s\nlog:\n  level: debug\n",
+		"config.json": `{"server": {"port": "9000", "request_timeout": "45s", "route_timeouts": {"/api/costs": "60s"}}, "log": {"level": "debug"}}`,
+		"config.toml": "[server]\nport = \"9000\"\nrequest_timeout = \"45s\"\n\n[server.route_timeouts]\n\"/api/costs\" = \"60s\"\n\n[log]\nlevel = \"debug\"\n",
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := LoadFromFile(path)
+			if err != nil {
+				t.Fatalf("LoadFromFile failed: %v", err)
+			}
+
+			if cfg.Server.Port != "9000" || cfg.Log.Level != "debug" || cfg.Server.RequestTimeout != 45*time.Second {
+				t.Errorf("File values not applied: %+v", cfg.Server)
+			}
+			if cfg.Server.RouteTimeouts["/api/costs"] != 60*time.Second || cfg.Server.RouteTimeouts["/api/reports"] != 120*time.Second {
+				t.Errorf("Unexpected route timeouts: %v", cfg.Server.RouteTimeouts)
+			}
+			if cfg.AWS.Region != "eu-west-2" {
+				t.Errorf("Expected default AWS region for unset field, got %s", cfg.AWS.Region)
+			}
+		})
+	}
+
+	if DefaultRouteTimeouts["/api/costs"] != 0 {
+		t.Error("Expected DefaultRouteTimeouts not to be modified")
+	}
+
+	if _, err := LoadFromFile(filepath.Join(dir, "missing.yaml")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected a wrapped not exist error for missing file, got %v", err)
+	}
+
+	malformed := map[string]string{
+		"malformed.yaml": "server:\n  port: \"9000\n  - log\n",
+		"malformed.toml": "[server\nport = 9000\n",
+	}
+	for name, content := range malformed {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadFromFile(path); err == nil || !strings.Contains(err.Error(), "failed to parse config file") {
+			t.Errorf("Expected a parse error for %s, got %v", name, err)
+		}
+	}
+}
+
+func TestLoadFromFile_DefaultConfig(t *testing.T) {
+	clearEnvVars()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(GenerateDefaultConfig()), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+	// Empty lists and maps load as empty rather than nil, so the configs are
+	// compared in their YAML form
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != GenerateDefaultConfig() {
+		t.Errorf("Expected the generated default config to load as the defaults, got:\n%s", data)
+	}
+}
+
+func TestLoad_EnvOverridesConfigFile(t *testing.T) {
+	clearEnvVars()
+	defer clearEnvVars()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("server:\n  port: \"9000\"\nlog:\n  level: debug\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	os.Setenv("CONFIG_FILE", path)
+	os.Setenv("PORT", "9100")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.Server.Port != "9100" {
+		t.Errorf("Expected PORT to override file, got %s", cfg.Server.Port)
+	}
+	if cfg.Log.Level != "debug" {
+		t.Errorf("Expected log level from file, got %s", cfg.Log.Level)
+	}
+}
+
+func TestConfig_MarshalYAML(t *testing.T) {
+	cfg := Default()
+	cfg.AWS.SecretAccessKey = "test-secret-value"
+	cfg.GOVUK.APIKey = "test-api-key"
+	cfg.GOVUK.TeamRosterAPIKey = "test-roster-key"
+	cfg.Server.AdminAPIToken = "test-admin-token"
+	cfg.Notifications.WebhookURL = "https://example.com/hooks/test-webhook-secret"
+	cfg.Notifications.SlackWebhookURL = "https://hooks.slack.com/services/test-slack-secret"
+
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	for _, secret := range []string{"test-secret-value", "test-api-key", "test-roster-key", "test-admin-token", "test-webhook-secret", "test-slack-secret"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("Expected %s to be redacted:\n%s", secret, data)
+		}
+	}
+
+	var restored Config
+	if err := yaml.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if restored.Server.Port != "8080" || restored.GOVUK.AppsAPICacheTTL != 15*time.Minute {
+		t.Errorf("Unexpected round-tripped config: %+v", restored)
+	}
+	if cfg.AWS.SecretAccessKey != "test-secret-value" {
+		t.Error("Expected MarshalYAML not to modify the config")
+	}
+}
+
+func TestValidate_WarnsWithoutTLSInProduction(t *testing.T) {
+	cfg := Default()
+	cfg.Server.Environment = "production"
+	cfg.AWS.Profile = "test-profile"
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if len(cfg.Warnings()) != 1 {
+		t.Errorf("Expected 1 warning, got %v", cfg.Warnings())
+	}
+
+	cfg.Server.Environment = "staging"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if len(cfg.Warnings()) != 0 {
+		t.Errorf("Expected no warnings outside production, got %v", cfg.Warnings())
+	}
+}
+
 func TestConfigMethods(t *testing.T) {
 	cfg := &Config{
 		Server: ServerConfig{
@@ -226,6 +483,33 @@ func TestGetEnvHelpers(t *testing.T) {
 		t.Errorf("Expected %v, got %v", time.Second, value)
 	}
 
+	// Test getEnvAsDurationMap
+	os.Setenv("TEST_DURATION_MAP", "/api/reports=60s, /api/costs=10s,invalid")
+	defaults := map[string]time.Duration{"/api/reports": 120 * time.Second, "/api/health": 5 * time.Second}
+	durations := getEnvAsDurationMap("TEST_DURATION_MAP", defaults)
+	if durations["/api/reports"] != 60*time.Second || durations["/api/costs"] != 10*time.Second || durations["/api/health"] != 5*time.Second {
+		t.Errorf("Unexpected duration map: %v", durations)
+	}
+	if defaults["/api/reports"] != 120*time.Second {
+		t.Error("Expected defaults not to be modified")
+	}
+
+	// Test getEnvAsStringMap
+	os.Setenv("TEST_STRING_MAP", "publishing-platform=P1234567, search=P7654321,invalid,empty=")
+	schedules := getEnvAsStringMap("TEST_STRING_MAP", map[string]string{"search": "PDEFAULT", "govuk-platform": "P0000001"})
+	if len(schedules) != 3 || schedules["publishing-platform"] != "P1234567" || schedules["search"] != "P7654321" || schedules["govuk-platform"] != "P0000001" {
+		t.Errorf("Unexpected string map: %v", schedules)
+	}
+
+	// Test getEnvAsSlice
+	os.Setenv("TEST_SLICE", "https://a.example.org, ,https://b.example.org")
+	if value := getEnvAsSlice("TEST_SLICE", nil); len(value) != 2 || value[1] != "https://b.example.org" {
+		t.Errorf("Unexpected slice: %v", value)
+	}
+	if value := getEnvAsSlice("NON_EXISTENT", []string{"default"}); len(value) != 1 || value[0] != "default" {
+		t.Errorf("Expected default slice, got %v", value)
+	}
+
 	// Clean up
 	clearEnvVars()
 }
@@ -233,16 +517,27 @@ func TestGetEnvHelpers(t *testing.T) {
 func clearEnvVars() {
 	envVars := []string{
 		"PORT", "HOST", "ENVIRONMENT", "READ_TIMEOUT", "WRITE_TIMEOUT", "IDLE_TIMEOUT",
-		"TLS_ENABLED", "TLS_CERT_FILE", "TLS_KEY_FILE",
+		"REQUEST_TIMEOUT", "ROUTE_TIMEOUTS",
+		"TLS_ENABLED", "TLS_CERT_FILE", "TLS_KEY_FILE", "HSTS_PRELOAD", "CORS_ADDITIONAL_ORIGINS", "TRUSTED_PROXIES", "ADMIN_API_TOKEN",
+		"REPORTS_MAX_CONCURRENT", "DEFAULT_API_VERSION",
 		"AWS_REGION", "AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN",
 		"AWS_PROFILE", "AWS_MFA_TOKEN", "AWS_COST_EXPLORER_REGION", "AWS_MAX_RETRIES", "AWS_RETRY_DELAY",
+		"COST_MODEL_PATH", "RDS_REQUIRED_TAGS", "ELASTICACHE_MIN_SNAPSHOT_RETENTION",
 		"GOVUK_API_BASE_URL", "GOVUK_API_KEY", "GOVUK_APPS_API_TIMEOUT", "GOVUK_APPS_API_CACHE_TTL",
 		"GOVUK_APPS_API_RETRIES", "GOVUK_RATE_LIMIT", "GOVUK_USER_AGENT",
 		"LOG_LEVEL", "LOG_FORMAT", "LOG_OUTPUT",
 		"CACHE_DEFAULT_TTL", "CACHE_CLEANUP_PERIOD", "CACHE_MAX_SIZE", "CACHE_EVICTION_POLICY",
 		"METRICS_ENABLED", "METRICS_PORT", "HEALTH_PATH", "READYZ_PATH", "LIVEZ_PATH",
+		"PAGERDUTY_ROUTING_KEY", "SENTRY_API_TOKEN", "SENTRY_ORGANIZATION",
+		"PAGERDUTY_API_TOKEN", "TEAM_PAGERDUTY_SCHEDULES", "ALERT_TEAM",
+		"AWS_ASSUME_ROLE_ARNS", "OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_SERVICE_NAME",
+		"RATE_LIMIT_ENABLED", "RATE_LIMIT_REDIS_ADDR", "RATE_LIMIT_REQUESTS_PER_MINUTE", "RATE_LIMIT_BURST_SIZE", "RATE_LIMIT_KEY_PREFIX",
+		"NOTIFICATION_WEBHOOK_URL", "SLACK_WEBHOOK_URL", "NOTIFY_ON_EOL", "NOTIFY_ON_OUTDATED", "NOTIFY_ON_CRITICAL_PATCH",
+		"EOL_WARNING_DAYS", "OUTDATED_WARNING_DAYS", "NOTIFICATION_CHECK_INTERVAL", "NOTIFICATION_STATE_PATH",
+		"CONFIG_FILE",
 		"TEST_STRING", "TEST_INT", "TEST_INT_INVALID", "TEST_BOOL_TRUE", "TEST_BOOL_FALSE",
-		"TEST_BOOL_ONE", "TEST_DURATION", "TEST_DURATION_INVALID",
+		"TEST_BOOL_ONE", "TEST_DURATION", "TEST_DURATION_INVALID", "TEST_DURATION_MAP",
+		"TEST_STRING_MAP",
 	}
 
 	for _, envVar := range envVars {
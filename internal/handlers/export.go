@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"govuk-reports-dashboard/internal/models"
+	"govuk-reports-dashboard/internal/reports"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReportGenerator is the part of reports.Manager used to export reports
+type ReportGenerator interface {
+	GenerateReport(ctx context.Context, reportID string, params reports.ReportParams) (reports.ReportData, error)
+}
+
+var _ ReportGenerator = (*reports.Manager)(nil)
+
+// exportFormat is a spreadsheet format reports can be exported in
+type exportFormat struct {
+	contentType string
+	render      func(*reports.Renderer, reports.TableData) ([]byte, error)
+}
+
+var exportFormats = map[string]exportFormat{
+	"csv": {
+		contentType: "text/csv; charset=utf-8",
+		render:      (*reports.Renderer).ToCSV,
+	},
+	"xlsx": {
+		contentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		render:      (*reports.Renderer).ToXLSX,
+	},
+}
+
+// ExportHandler serves reports as spreadsheet downloads
+type ExportHandler struct {
+	generator ReportGenerator
+	renderer  *reports.Renderer
+	logger    *logger.Logger
+}
+
+func NewExportHandler(generator ReportGenerator, log *logger.Logger) *ExportHandler {
+	return &ExportHandler{
+		generator: generator,
+		renderer:  reports.NewRenderer(),
+		logger:    log,
+	}
+}
+
+// ExportReport handles GET /api/reports/:id/export?format=csv|xlsx. The
+// report's first table is exported, or another chosen with ?table=<index>.
+// Reports without tables export their data points.
+func (h *ExportHandler) ExportReport(c *gin.Context) {
+	log := h.logger.WithRequestID(GetRequestID(c))
+	reportID := c.Param("id")
+
+	formatName := strings.ToLower(c.Query("format"))
+	format, ok := exportFormats[formatName]
+	if !ok {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "bad_request",
+			Message: fmt.Sprintf("Unsupported export format %q, expected csv or xlsx", formatName),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	tableIndex := 0
+	if table := c.Query("table"); table != "" {
+		index, err := strconv.Atoi(table)
+		if err != nil || index < 0 {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "bad_request",
+				Message: "table must be a table index from 0",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		tableIndex = index
+	}
+
+	reportData, err := h.generator.GenerateReport(c.Request.Context(), reportID, reports.ReportParams{UseCache: true})
+	if errors.Is(err, reports.ErrReportNotFound) || errors.Is(err, reports.ErrReportDisabled) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: err.Error(),
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+	if err != nil {
+		log.WithError(err).WithField("report_id", reportID).Error().Msg("Failed to generate report for export")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to generate report",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	var table reports.TableData
+	switch {
+	case tableIndex < len(reportData.Tables):
+		table = reportData.Tables[tableIndex]
+	case tableIndex == 0:
+		table = h.renderer.GenerateTableData(reportData.Metadata.Name, reportData.DataPoints, nil)
+	default:
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: fmt.Sprintf("Report %s has %d tables", reportID, len(reportData.Tables)),
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	body, err := format.render(h.renderer, table)
+	if err != nil {
+		log.WithError(err).WithFields(map[string]interface{}{
+			"report_id": reportID,
+			"format":    formatName,
+		}).Error().Msg("Failed to export report")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to export report",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	filename := fmt.Sprintf("report-%s-%s.%s", reportID, time.Now().UTC().Format("2006-01-02"), formatName)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, format.contentType, body)
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"govuk-reports-dashboard/internal/reports"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// stubGenerator returns data for the report "costs" and ErrReportNotFound
+// for any other
+type stubGenerator struct {
+	data reports.ReportData
+}
+
+func (g *stubGenerator) GenerateReport(ctx context.Context, reportID string, params reports.ReportParams) (reports.ReportData, error) {
+	if reportID != "costs" {
+		return reports.ReportData{}, reports.ErrReportNotFound
+	}
+	return g.data, nil
+}
+
+func newExportRouter(data reports.ReportData) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+
+	router := gin.New()
+	router.GET("/api/reports/:id/export", NewExportHandler(&stubGenerator{data: data}, log).ExportReport)
+	return router
+}
+
+func TestExportHandler_ExportReport(t *testing.T) {
+	router := newExportRouter(reports.ReportData{
+		Tables: []reports.TableData{{
+			Title:   "Costs",
+			Headers: []reports.TableHeader{{Key: "service", Label: "Service"}, {Key: "cost", Label: "Cost"}},
+			Rows:    []map[string]interface{}{{"service": "rds", "cost": 12.5}},
+		}},
+	})
+	date := time.Now().UTC().Format("2006-01-02")
+
+	tests := []struct {
+		name        string
+		query       string
+		status      int
+		contentType string
+		filename    string
+	}{
+		{"csv", "format=csv", http.StatusOK, "text/csv; charset=utf-8", "report-costs-" + date + ".csv"},
+		{"xlsx", "format=XLSX", http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "report-costs-" + date + ".xlsx"},
+		{"unknown format", "format=pdf", http.StatusBadRequest, "", ""},
+		{"no format", "", http.StatusBadRequest, "", ""},
+		{"invalid table", "format=csv&table=first", http.StatusBadRequest, "", ""},
+		{"missing table", "format=csv&table=1", http.StatusNotFound, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/reports/costs/export?"+tt.query, nil))
+
+			if w.Code != tt.status {
+				t.Fatalf("Expected status %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			if w.Header().Get("Content-Type") != tt.contentType {
+				t.Errorf("Expected Content-Type %q, got %q", tt.contentType, w.Header().Get("Content-Type"))
+			}
+			if expected := `attachment; filename="` + tt.filename + `"`; w.Header().Get("Content-Disposition") != expected {
+				t.Errorf("Expected Content-Disposition %q, got %q", expected, w.Header().Get("Content-Disposition"))
+			}
+		})
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/reports/costs/export?format=csv", nil))
+	if w.Body.String() != "Service,Cost\nrds,12.5\n" {
+		t.Errorf("Unexpected CSV body:\n%s", w.Body.String())
+	}
+}
+
+func TestExportHandler_DataPointsAndMissingReport(t *testing.T) {
+	router := newExportRouter(reports.ReportData{
+		Metadata: reports.ReportMetadata{ID: "costs", Name: "Costs"},
+		DataPoints: []reports.DataPoint{{
+			Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			Labels:    map[string]string{"service": "rds"},
+			Values:    map[string]interface{}{"cost": 12.5},
+		}},
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/reports/costs/export?format=csv", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "12.5,rds,2024-01-02 03:04:05") {
+		t.Errorf("Expected data points exported without tables, got %d:\n%s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/reports/missing/export?format=csv", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for an unknown report, got %d", w.Code)
+	}
+}
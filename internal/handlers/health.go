@@ -5,16 +5,25 @@ import (
 	"time"
 
 	"govuk-reports-dashboard/internal/models"
+	"govuk-reports-dashboard/internal/reports"
 
 	"github.com/gin-gonic/gin"
 )
 
-type HealthHandler struct{}
+type HealthHandler struct {
+	reportsManager *reports.Manager
+}
 
 func NewHealthHandler() *HealthHandler {
 	return &HealthHandler{}
 }
 
+// SetReportsManager includes a summary of the reports framework's health in
+// health checks
+func (h *HealthHandler) SetReportsManager(manager *reports.Manager) {
+	h.reportsManager = manager
+}
+
 func (h *HealthHandler) HealthCheck(c *gin.Context) {
 	healthCheck := models.HealthCheck{
 		Status:    "healthy",
@@ -27,5 +36,15 @@ func (h *HealthHandler) HealthCheck(c *gin.Context) {
 		},
 	}
 
+	if h.reportsManager != nil {
+		reportsHealth := h.reportsManager.GetHealth(c.Request.Context())
+		healthCheck.ReportsHealth = &models.ReportsHealthSummary{
+			Status:             reportsHealth.OverallStatus,
+			RegisteredReports:  reportsHealth.RegisteredReports,
+			AvailableReports:   reportsHealth.AvailableReports,
+			UnavailableReports: reportsHealth.UnavailableReports,
+		}
+	}
+
 	c.JSON(http.StatusOK, healthCheck)
 }
\ No newline at end of file
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+
+	"govuk-reports-dashboard/internal/models"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LogLevel is the body of PUT /api/admin/log-level and the response of both
+// log level endpoints
+type LogLevel struct {
+	Level string `json:"level" binding:"required"`
+}
+
+// LogLevelHandler reads and changes the log level at runtime, so debug
+// logging can be turned on without a restart. Changes aren't persisted.
+type LogLevelHandler struct {
+	logger *logger.Logger
+}
+
+func NewLogLevelHandler(log *logger.Logger) *LogLevelHandler {
+	return &LogLevelHandler{
+		logger: log,
+	}
+}
+
+// GetLogLevel handles GET /api/admin/log-level
+func (h *LogLevelHandler) GetLogLevel(c *gin.Context) {
+	c.JSON(http.StatusOK, LogLevel{Level: h.logger.GetLevel()})
+}
+
+// SetLogLevel handles PUT /api/admin/log-level
+func (h *LogLevelHandler) SetLogLevel(c *gin.Context) {
+	var request LogLevel
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "bad_request",
+			Message: "Request body must be JSON with a level",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	previous := h.logger.GetLevel()
+	if err := h.logger.SetLevel(request.Level); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "bad_request",
+			Message: "Level must be one of trace, debug, info, warn, error, fatal or panic",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	// Logged at warn so the change is recorded whatever the new level is
+	h.logger.WithRequestID(GetRequestID(c)).WithFields(map[string]interface{}{
+		"previous_level": previous,
+		"level":          h.logger.GetLevel(),
+		"client_ip":      c.ClientIP(),
+	}).Warn().Msg("Log level changed")
+
+	c.JSON(http.StatusOK, LogLevel{Level: h.logger.GetLevel()})
+}
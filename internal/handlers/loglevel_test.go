@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newLogLevelRouter returns a router serving the log level endpoints in an
+// admin group protected by adminToken, as main does. The log level is restored after the test.
+func newLogLevelRouter(t *testing.T, adminToken string) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	t.Cleanup(func() { log.SetLevel("error") })
+
+	handler := NewLogLevelHandler(log)
+	router := gin.New()
+	admin := router.Group("/api/admin")
+	admin.Use(RequireAdminToken(adminToken), AuthMiddleware(adminToken, log))
+	admin.GET("/log-level", handler.GetLogLevel)
+	admin.PUT("/log-level", handler.SetLogLevel)
+	return router
+}
+
+func logLevelRequest(router *gin.Engine, method, token, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, "/api/admin/log-level", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set(AdminTokenHeader, token)
+	}
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestLogLevelHandler_SetLogLevel(t *testing.T) {
+	router := newLogLevelRouter(t, "secret")
+
+	w := logLevelRequest(router, http.MethodPut, "secret", `{"level": "debug"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = logLevelRequest(router, http.MethodGet, "secret", "")
+	var response LogLevel
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if w.Code != http.StatusOK || response.Level != "debug" {
+		t.Errorf("Expected the new level debug, got %d %q", w.Code, response.Level)
+	}
+}
+
+func TestLogLevelHandler_SetLogLevel_WrongToken(t *testing.T) {
+	router := newLogLevelRouter(t, "secret")
+
+	for _, token := range []string{"", "wrong"} {
+		w := logLevelRequest(router, http.MethodPut, token, `{"level": "debug"}`)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status 401 for token %q, got %d", token, w.Code)
+		}
+	}
+
+	w := logLevelRequest(router, http.MethodGet, "secret", "")
+	if !strings.Contains(w.Body.String(), `"level":"error"`) {
+		t.Errorf("Expected the level to be unchanged, got %s", w.Body.String())
+	}
+}
+
+func TestLogLevelHandler_SetLogLevel_InvalidLevel(t *testing.T) {
+	router := newLogLevelRouter(t, "secret")
+
+	for _, body := range []string{`{"level": "verbose"}`, `{}`, `not json`} {
+		if w := logLevelRequest(router, http.MethodPut, "secret", body); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for %s, got %d", body, w.Code)
+		}
+	}
+}
+
+func TestLogLevelHandler_NoAdminToken(t *testing.T) {
+	router := newLogLevelRouter(t, "")
+
+	if w := logLevelRequest(router, http.MethodPut, "", `{"level": "debug"}`); w.Code != http.StatusNotImplemented {
+		t.Errorf("Expected status 501 without an admin token, got %d", w.Code)
+	}
+}
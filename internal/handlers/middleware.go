@@ -2,17 +2,28 @@ package handlers
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"runtime/debug"
+	"strconv"
 	"strings"
 	"time"
 
 	"govuk-reports-dashboard/internal/config"
 	"govuk-reports-dashboard/internal/models"
+	"govuk-reports-dashboard/pkg/api"
 	"govuk-reports-dashboard/pkg/logger"
+	"govuk-reports-dashboard/pkg/metrics"
+	"govuk-reports-dashboard/pkg/tracing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // ErrorHandler provides comprehensive error handling with proper logging
@@ -21,7 +32,7 @@ func ErrorHandler(log *logger.Logger) gin.HandlerFunc {
 		defer func() {
 			if recovery := recover(); recovery != nil {
 				// Log panic with stack trace
-				log.Error().
+				log.WithRequestID(GetRequestID(c)).Error().
 					Interface("panic", recovery).
 					Str("stack", string(debug.Stack())).
 					Str("path", c.Request.URL.Path).
@@ -45,7 +56,7 @@ func ErrorHandler(log *logger.Logger) gin.HandlerFunc {
 			err := c.Errors.Last()
 			
 			// Log the error
-			log.Error().
+			log.WithRequestID(GetRequestID(c)).Error().
 				Str("error", err.Error()).
 				Interface("type", err.Type).
 				Str("path", c.Request.URL.Path).
@@ -87,9 +98,13 @@ func ErrorHandler(log *logger.Logger) gin.HandlerFunc {
 	}
 }
 
-// TimeoutMiddleware adds request timeout handling
-func TimeoutMiddleware(timeout time.Duration, log *logger.Logger) gin.HandlerFunc {
+// AdaptiveTimeoutMiddleware adds request timeout handling, using the timeout
+// of the longest path prefix in routeTimeouts that matches the request, or
+// defaultTimeout if none match
+func AdaptiveTimeoutMiddleware(routeTimeouts map[string]time.Duration, defaultTimeout time.Duration, log *logger.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		timeout := routeTimeout(api.UnversionedPath(c.Request.URL.Path), routeTimeouts, defaultTimeout)
+
 		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
 		defer cancel()
 
@@ -104,7 +119,7 @@ func TimeoutMiddleware(timeout time.Duration, log *logger.Logger) gin.HandlerFun
 		select {
 		case <-ctx.Done():
 			if ctx.Err() == context.DeadlineExceeded {
-				log.Warn().
+				log.WithRequestID(GetRequestID(c)).Warn().
 					Str("path", c.Request.URL.Path).
 					Str("method", c.Request.Method).
 					Str("client_ip", c.ClientIP()).
@@ -124,22 +139,37 @@ func TimeoutMiddleware(timeout time.Duration, log *logger.Logger) gin.HandlerFun
 	}
 }
 
-// RateLimitMiddleware provides basic rate limiting (simplified implementation)
-func RateLimitMiddleware(log *logger.Logger) gin.HandlerFunc {
+// routeTimeout returns the timeout for the longest matching path prefix
+func routeTimeout(path string, routeTimeouts map[string]time.Duration, defaultTimeout time.Duration) time.Duration {
+	timeout := defaultTimeout
+	longest := -1
+	for prefix, prefixTimeout := range routeTimeouts {
+		if strings.HasPrefix(path, prefix) && len(prefix) > longest {
+			timeout = prefixTimeout
+			longest = len(prefix)
+		}
+	}
+	return timeout
+}
+
+// RateLimitMiddleware refuses requests over limiter's limit for the client
+// IP with 429 Too Many Requests. Requests are allowed if limiter is nil or
+// can't check the limit, so an unavailable Redis doesn't block all traffic.
+// Health checks and metrics scrapes are never limited.
+func RateLimitMiddleware(limiter RateLimiter, log *logger.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Skip rate limiting for health checks
-		if strings.HasPrefix(c.Request.URL.Path, "/api/health") ||
-		   strings.HasPrefix(c.Request.URL.Path, "/api/readyz") ||
-		   strings.HasPrefix(c.Request.URL.Path, "/api/livez") {
+		// Skip rate limiting for health checks and metrics scrapes
+		path := api.UnversionedPath(c.Request.URL.Path)
+		if strings.HasPrefix(path, "/api/health") ||
+		   strings.HasPrefix(path, "/api/readyz") ||
+		   strings.HasPrefix(path, "/api/livez") ||
+		   path == MetricsPath {
 			c.Next()
 			return
 		}
 
-		// For demo purposes, we'll use a simple client IP based approach
+		log := log.WithRequestID(GetRequestID(c))
 		clientIP := c.ClientIP()
-		
-		// In production, you'd integrate with Redis or similar
-		// For now, we'll just log potential abuse
 		userAgent := c.Request.UserAgent()
 		if userAgent == "" || strings.Contains(strings.ToLower(userAgent), "bot") {
 			log.LogSecurityEvent("potential_bot_traffic", clientIP, userAgent, map[string]interface{}{
@@ -147,26 +177,263 @@ func RateLimitMiddleware(log *logger.Logger) gin.HandlerFunc {
 			})
 		}
 
+		if limiter == nil {
+			c.Next()
+			return
+		}
+
+		allowed, retryAfter, err := limiter.Allow(c.Request.Context(), clientIP)
+		if err != nil {
+			log.WithError(err).Warn().Str("client_ip", clientIP).Msg("Rate limit check failed, allowing request")
+			c.Next()
+			return
+		}
+
+		if !allowed {
+			log.LogSecurityEvent("rate_limit_exceeded", clientIP, userAgent, map[string]interface{}{
+				"path": c.Request.URL.Path,
+			})
+			c.Header("Retry-After", strconv.Itoa(max(int(retryAfter.Seconds()+0.5), 1)))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, models.ErrorResponse{
+				Error:   "rate_limited",
+				Message: "Too many requests. Please try again later.",
+				Code:    http.StatusTooManyRequests,
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// AdminTokenHeader is the header the admin token can be sent in, instead of
+// as a bearer token
+const AdminTokenHeader = "X-Admin-Token"
+
+// AuthMiddleware requires an "Authorization: Bearer <token>" or X-Admin-Token
+// header matching token. Every request is refused if token is empty, so
+// protected routes are off until a token is configured.
+func AuthMiddleware(token string, log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, models.ErrorResponse{
+				Error:   "forbidden",
+				Message: "No admin API token is configured",
+				Code:    http.StatusForbidden,
+			})
+			return
+		}
+
+		provided, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok {
+			provided = c.GetHeader(AdminTokenHeader)
+			ok = provided != ""
+		}
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			log.WithRequestID(GetRequestID(c)).LogSecurityEvent("unauthorized_admin_request", c.ClientIP(), c.Request.UserAgent(), map[string]interface{}{
+				"path":   c.Request.URL.Path,
+				"method": c.Request.Method,
+			})
+			c.Header("WWW-Authenticate", "Bearer")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, models.ErrorResponse{
+				Error:   "unauthorized",
+				Message: "A valid bearer token is required",
+				Code:    http.StatusUnauthorized,
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireAdminToken responds 501 Not Implemented while no admin token is
+// configured, so admin routes are off rather than forbidden until one is set.
+// It goes before AuthMiddleware.
+func RequireAdminToken(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusNotImplemented, models.ErrorResponse{
+				Error:   "not_implemented",
+				Message: "Admin routes need an admin API token to be configured",
+				Code:    http.StatusNotImplemented,
+			})
+			return
+		}
 		c.Next()
 	}
 }
 
-// SecurityHeadersMiddleware adds security headers
-func SecurityHeadersMiddleware() gin.HandlerFunc {
+// SecurityHeadersMiddleware adds security headers. Strict-Transport-Security
+// is sent when TLS is enabled or in production, but never in development.
+func SecurityHeadersMiddleware(cfg *config.Config) gin.HandlerFunc {
+	hsts := hstsHeader(cfg)
+
 	return func(c *gin.Context) {
 		c.Header("X-Content-Type-Options", "nosniff")
 		c.Header("X-Frame-Options", "DENY")
 		c.Header("X-XSS-Protection", "1; mode=block")
 		c.Header("Referrer-Policy", "strict-origin-when-cross-origin")
 		c.Header("Content-Security-Policy", "default-src 'self'; script-src 'self' 'unsafe-inline'; style-src 'self' 'unsafe-inline'; img-src 'self' data: https:; font-src 'self'")
+		c.Header("X-Permitted-Cross-Domain-Policies", "none")
+		c.Header("Permissions-Policy", "geolocation=(), microphone=(), camera=()")
+		if hsts != "" {
+			c.Header("Strict-Transport-Security", hsts)
+		}
 		
 		c.Next()
 	}
 }
 
-// LoggerMiddleware provides structured request logging
+// hstsHeader returns the Strict-Transport-Security value for cfg, or "" if
+// the header should not be sent
+func hstsHeader(cfg *config.Config) string {
+	if cfg.IsDevelopment() || (!cfg.Server.TLSEnabled && !cfg.IsProduction()) {
+		return ""
+	}
+
+	header := "max-age=31536000; includeSubDomains"
+	if cfg.Server.HSTSPreload {
+		header += "; preload"
+	}
+	return header
+}
+
+// RequestIDHeader is the header carrying the ID that correlates a request's
+// log lines
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDContextKey is the gin context key for the request ID
+const requestIDContextKey = "request_id"
+
+// maxRequestIDLength is the longest request ID accepted from a client
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware gives each request an ID, taken from the X-Request-ID
+// header so IDs from a proxy or caller carry through, or generated if the
+// header is missing or unusable. The ID is echoed in the response header and
+// added to the request context so handlers and services can log it.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+
+		c.Set(requestIDContextKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(logger.ContextWithRequestID(c.Request.Context(), id))
+
+		c.Next()
+	}
+}
+
+// GetRequestID returns the ID RequestIDMiddleware gave the request, or "" if
+// it didn't run
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(requestIDContextKey)
+}
+
+// APIVersionHeader is the response header naming the API version that
+// served a request
+const APIVersionHeader = "X-API-Version"
+
+// apiVersionContextKey is the gin context key for the API version
+const apiVersionContextKey = "api_version"
+
+// APIGroups returns a route group for each supported API version, such as
+// /api/v1, followed by the unversioned /api group, each using
+// APIVersionMiddleware. Every API route should be registered on all of
+// them, so unversioned paths stay as aliases of the versioned ones.
+func APIGroups(router *gin.Engine, defaultVersion string) []*gin.RouterGroup {
+	versionMiddleware := APIVersionMiddleware(defaultVersion)
+
+	groups := make([]*gin.RouterGroup, 0, len(api.Supported)+1)
+	for _, version := range api.Supported {
+		groups = append(groups, router.Group(api.VersionedPrefix(version), versionMiddleware))
+	}
+	return append(groups, router.Group(api.Prefix, versionMiddleware))
+}
+
+// APIVersionMiddleware decides which API version serves a request: the one
+// in its path, as in /api/v1/rds/instances, or else the one requested with
+// an Accept header such as "application/vnd.govuk.v1+json", or else
+// defaultVersion. The version is set in the gin context and the
+// X-API-Version header. Versions requested with Accept that aren't
+// supported are refused with 406 Not Acceptable.
+//
+// Deprecated versions, including unversioned routes, answer with a
+// Deprecation header giving when they were deprecated. Unversioned routes
+// also link to the same route under their version.
+func APIVersionMiddleware(defaultVersion string) gin.HandlerFunc {
+	if defaultVersion == "" {
+		defaultVersion = api.Latest
+	}
+
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		version := api.PathVersion(path)
+		deprecatedAt, deprecated := api.DeprecatedAt[version]
+
+		if version == api.Unversioned {
+			version = defaultVersion
+			if requested := api.MediaTypeVersion(c.GetHeader("Accept")); requested != "" {
+				if !api.IsSupported(requested) {
+					c.AbortWithStatusJSON(http.StatusNotAcceptable, models.ErrorResponse{
+						Error:   "not_acceptable",
+						Message: fmt.Sprintf("API version %q is not supported, use one of %s", requested, strings.Join(api.Supported, ", ")),
+						Code:    http.StatusNotAcceptable,
+					})
+					return
+				}
+				version = requested
+			}
+			if deprecated {
+				successor := api.VersionedPrefix(version) + strings.TrimPrefix(path, api.Prefix)
+				c.Header("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, successor))
+			}
+		}
+
+		if deprecated {
+			// RFC 9745 gives the deprecation date as a Unix timestamp
+			c.Header("Deprecation", fmt.Sprintf("@%d", deprecatedAt.Unix()))
+		}
+
+		c.Set(apiVersionContextKey, version)
+		c.Header(APIVersionHeader, version)
+
+		c.Next()
+	}
+}
+
+// GetAPIVersion returns the API version APIVersionMiddleware chose for the
+// request, or "" if it didn't run
+func GetAPIVersion(c *gin.Context) string {
+	return c.GetString(apiVersionContextKey)
+}
+
+// validRequestID reports whether a client-supplied ID is safe to log and echo
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		if r < 0x21 || r > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// LoggerMiddleware provides structured request logging. Metrics scrapes are
+// not logged, as they arrive every few seconds.
 func LoggerMiddleware(log *logger.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if c.Request.URL.Path == MetricsPath {
+			c.Next()
+			return
+		}
+
 		start := time.Now()
 		path := c.Request.URL.Path
 		raw := c.Request.URL.RawQuery
@@ -184,45 +451,72 @@ func LoggerMiddleware(log *logger.Logger) gin.HandlerFunc {
 		}
 
 		// Use the optimized HTTP request logging helper
-		log.LogHTTPRequest(method, path, statusCode, latency, clientIP, bodySize)
+		log.WithRequestID(GetRequestID(c)).LogHTTPRequest(method, path, statusCode, latency, clientIP, bodySize)
 	}
 }
 
-// CORSMiddleware provides configurable CORS handling
+// CORSConfig is a CORS policy. An AllowedOrigins entry of "*" allows any
+// origin, and entries such as "https://*.gov.uk" allow any matching subdomain.
+type CORSConfig struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	MaxAge           int
+	AllowCredentials bool
+}
+
+// ProductionCORSOrigins are the origins allowed cross-origin in production,
+// along with any in CORS_ADDITIONAL_ORIGINS
+var ProductionCORSOrigins = []string{
+	"https://gov.uk",
+	"https://*.gov.uk",
+	"https://publishing.service.gov.uk",
+}
+
+// DefaultCORSConfig returns the policy for standard API routes. Outside
+// production any origin is allowed.
+func DefaultCORSConfig(cfg *config.Config) CORSConfig {
+	origins := []string{"*"}
+	if cfg.IsProduction() {
+		origins = append(append([]string{}, ProductionCORSOrigins...), cfg.Server.CORSAdditionalOrigins...)
+	}
+
+	return CORSConfig{
+		AllowedOrigins:   origins,
+		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Content-Type", "Content-Length", "Accept-Encoding", "X-CSRF-Token", "Authorization", "accept", "origin", "Cache-Control", "X-Requested-With", RequestIDHeader, AdminTokenHeader},
+		MaxAge:           86400,
+		AllowCredentials: true,
+	}
+}
+
+// CORSMiddleware applies DefaultCORSConfig to every route
 func CORSMiddleware(cfg *config.Config) gin.HandlerFunc {
+	return CORSMiddlewareWithConfig(DefaultCORSConfig(cfg))
+}
+
+// CORSMiddlewareWithConfig applies a single CORS policy to every route
+func CORSMiddlewareWithConfig(corsConfig CORSConfig) gin.HandlerFunc {
+	return CORSMiddlewareWithOverrides(corsConfig, nil)
+}
+
+// CORSMiddlewareWithOverrides applies the policy for the longest matching
+// path prefix in overrides, or defaultConfig if none match. Overrides are
+// chosen here rather than on route groups so preflight OPTIONS requests,
+// which have no route of their own, get the same policy as the route.
+func CORSMiddlewareWithOverrides(defaultConfig CORSConfig, overrides map[string]CORSConfig) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		origin := c.Request.Header.Get("Origin")
-		
-		// In production, restrict origins
-		if cfg.IsProduction() {
-			allowedOrigins := []string{
-				"https://gov.uk",
-				"https://*.gov.uk",
-				"https://publishing.service.gov.uk",
+		corsConfig := defaultConfig
+		matched := ""
+		path := api.UnversionedPath(c.Request.URL.Path)
+		for prefix, override := range overrides {
+			if strings.HasPrefix(path, prefix) && len(prefix) > len(matched) {
+				corsConfig = override
+				matched = prefix
 			}
-			
-			allowed := false
-			for _, allowedOrigin := range allowedOrigins {
-				if origin == allowedOrigin || 
-				   (strings.Contains(allowedOrigin, "*") && 
-				    strings.HasSuffix(origin, strings.TrimPrefix(allowedOrigin, "*"))) {
-					allowed = true
-					break
-				}
-			}
-			
-			if allowed {
-				c.Header("Access-Control-Allow-Origin", origin)
-			}
-		} else {
-			// Development mode - allow all origins
-			c.Header("Access-Control-Allow-Origin", "*")
 		}
 
-		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Max-Age", "86400")
+		applyCORSHeaders(c, corsConfig)
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(http.StatusNoContent)
@@ -233,13 +527,72 @@ func CORSMiddleware(cfg *config.Config) gin.HandlerFunc {
 	}
 }
 
+func applyCORSHeaders(c *gin.Context, corsConfig CORSConfig) {
+	origin := c.Request.Header.Get("Origin")
+
+	for _, allowedOrigin := range corsConfig.AllowedOrigins {
+		if allowedOrigin == "*" {
+			c.Header("Access-Control-Allow-Origin", "*")
+			break
+		}
+		if corsOriginAllowed(origin, allowedOrigin) {
+			c.Header("Access-Control-Allow-Origin", origin)
+			c.Header("Vary", "Origin")
+			break
+		}
+	}
+
+	if corsConfig.AllowCredentials {
+		c.Header("Access-Control-Allow-Credentials", "true")
+	}
+	c.Header("Access-Control-Allow-Headers", strings.Join(corsConfig.AllowedHeaders, ", "))
+	c.Header("Access-Control-Allow-Methods", strings.Join(corsConfig.AllowedMethods, ", "))
+	if corsConfig.MaxAge > 0 {
+		c.Header("Access-Control-Max-Age", strconv.Itoa(corsConfig.MaxAge))
+	}
+}
+
+// corsOriginAllowed reports whether origin matches an AllowedOrigins entry.
+// A "*." host in the entry, as in "https://*.gov.uk", matches any subdomain
+// with the same scheme and port, but not the domain itself.
+func corsOriginAllowed(origin, allowedOrigin string) bool {
+	if origin == "" {
+		return false
+	}
+	if origin == allowedOrigin {
+		return true
+	}
+
+	suffix, ok := strings.CutPrefix(allowedOrigin, "*")
+	if !ok {
+		allowed, err := url.Parse(allowedOrigin)
+		if err != nil {
+			return false
+		}
+		suffix, ok = strings.CutPrefix(allowed.Hostname(), "*")
+		if !ok {
+			return false
+		}
+
+		parsed, err := url.Parse(origin)
+		if err != nil || parsed.Scheme != allowed.Scheme || parsed.Port() != allowed.Port() {
+			return false
+		}
+		origin = parsed.Hostname()
+	}
+
+	name, found := strings.CutSuffix(origin, suffix)
+	return found && name != ""
+}
+
 // HealthCheckMiddleware provides circuit breaker functionality for health checks
 func HealthCheckMiddleware(log *logger.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Skip for actual health check endpoints
-		if strings.HasPrefix(c.Request.URL.Path, "/api/health") ||
-		   strings.HasPrefix(c.Request.URL.Path, "/api/readyz") ||
-		   strings.HasPrefix(c.Request.URL.Path, "/api/livez") {
+		path := api.UnversionedPath(c.Request.URL.Path)
+		if strings.HasPrefix(path, "/api/health") ||
+		   strings.HasPrefix(path, "/api/readyz") ||
+		   strings.HasPrefix(path, "/api/livez") {
 			c.Next()
 			return
 		}
@@ -250,22 +603,64 @@ func HealthCheckMiddleware(log *logger.Logger) gin.HandlerFunc {
 	}
 }
 
-// MetricsMiddleware collects basic metrics
-func MetricsMiddleware(log *logger.Logger) gin.HandlerFunc {
+// MetricsPath is where Prometheus metrics are served
+const MetricsPath = "/metrics"
+
+// MetricsMiddleware counts and times requests in registry. Requests are
+// labelled with their route pattern, or "unmatched" if no route matched, so
+// IDs in paths don't create a series each.
+func MetricsMiddleware(registry *metrics.Registry) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
-		
+
 		c.Next()
-		
-		duration := time.Since(start)
-		
-		// Log metrics for monitoring systems to pick up
-		log.LogPerformance("http_request", duration, map[string]interface{}{
-			"method":        c.Request.Method,
-			"path":         c.Request.URL.Path,
-			"status_code":  c.Writer.Status(),
-			"response_size": c.Writer.Size(),
-		})
+
+		path := c.FullPath()
+		if path == "" {
+			path = "unmatched"
+		}
+		registry.ObserveHTTPRequest(c.Request.Method, path, c.Writer.Status(), time.Since(start))
+	}
+}
+
+// TracingMiddleware starts a span for each request, continuing any trace
+// the caller sent, and puts it in the request context so service and AWS
+// calls become its children. Spans are named after the route pattern, like
+// MetricsMiddleware's labels. Metrics scrapes aren't traced.
+func TracingMiddleware(tracer trace.Tracer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.URL.Path == MetricsPath {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		ctx := tracing.ExtractHTTPHeaders(c.Request.Context(), c.Request.Header)
+		ctx, span := tracer.Start(ctx, c.Request.Method+" "+c.Request.URL.Path,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", c.Request.Method),
+				attribute.String("url.path", c.Request.URL.Path),
+				attribute.String("client.address", c.ClientIP()),
+			),
+		)
+		defer span.End()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		status := c.Writer.Status()
+		if route := c.FullPath(); route != "" {
+			span.SetName(c.Request.Method + " " + route)
+			span.SetAttributes(attribute.String("http.route", route))
+		}
+		span.SetAttributes(
+			attribute.Int("http.response.status_code", status),
+			attribute.Float64("http.server.latency_ms", float64(time.Since(start).Microseconds())/1000),
+		)
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
 	}
 }
 
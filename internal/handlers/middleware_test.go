@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"govuk-reports-dashboard/pkg/api"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// newRequestIDRouter returns a router with request IDs and request logging
+// written to logPath. The test route responds with the IDs the handler sees.
+func newRequestIDRouter(t *testing.T, logPath string) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	log, err := logger.New(logger.Config{Level: "info", Format: "json", Output: logPath})
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	t.Cleanup(func() { log.Sync() })
+
+	router := gin.New()
+	router.Use(RequestIDMiddleware())
+	router.Use(LoggerMiddleware(log))
+	router.GET("/api/test", func(c *gin.Context) {
+		c.String(http.StatusOK, GetRequestID(c)+" "+logger.RequestIDFromContext(c.Request.Context()))
+	})
+	return router
+}
+
+func TestRequestIDMiddleware_EchoesHeader(t *testing.T) {
+	router := newRequestIDRouter(t, filepath.Join(t.TempDir(), "requests.log"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/test", nil)
+	req.Header.Set(RequestIDHeader, "proxy-abc-123")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if got := w.Header().Get(RequestIDHeader); got != "proxy-abc-123" {
+		t.Errorf("Expected the request ID to be echoed, got %q", got)
+	}
+	if w.Body.String() != "proxy-abc-123 proxy-abc-123" {
+		t.Errorf("Expected handlers to see the request ID in gin and the request context, got %q", w.Body.String())
+	}
+}
+
+func TestRequestIDMiddleware_GeneratesID(t *testing.T) {
+	router := newRequestIDRouter(t, filepath.Join(t.TempDir(), "requests.log"))
+
+	for name, header := range map[string]string{
+		"missing":   "",
+		"too long":  strings.Repeat("a", maxRequestIDLength+1),
+		"has space": "abc 123",
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/test", nil)
+			if header != "" {
+				req.Header.Set(RequestIDHeader, header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			id := w.Header().Get(RequestIDHeader)
+			if _, err := uuid.Parse(id); err != nil {
+				t.Errorf("Expected a generated UUID, got %q", id)
+			}
+		})
+	}
+}
+
+func TestRequestIDMiddleware_LogsRequestID(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "requests.log")
+	router := newRequestIDRouter(t, logPath)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/test", nil))
+
+	contents, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	id := w.Header().Get(RequestIDHeader)
+	if id == "" || !strings.Contains(string(contents), `"request_id":"`+id+`"`) {
+		t.Errorf("Expected the request log to contain request ID %q, got %s", id, contents)
+	}
+}
+
+func TestAPIVersionMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	for _, group := range APIGroups(router, api.V1) {
+		group.GET("/test", func(c *gin.Context) {
+			c.String(http.StatusOK, GetAPIVersion(c))
+		})
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		accept         string
+		wantStatus     int
+		wantVersion    string
+		wantDeprecated bool
+		wantLink       string
+	}{
+		{name: "versioned path", path: "/api/v1/test", wantStatus: http.StatusOK, wantVersion: "v1"},
+		{name: "versioned path ignores accept", path: "/api/v1/test", accept: "application/vnd.govuk.v2+json", wantStatus: http.StatusOK, wantVersion: "v1"},
+		{name: "unversioned path", path: "/api/test", wantStatus: http.StatusOK, wantVersion: "v1", wantDeprecated: true, wantLink: `</api/v1/test>; rel="successor-version"`},
+		{name: "accept header", path: "/api/test", accept: "text/html, application/vnd.govuk.v1+json;q=0.9", wantStatus: http.StatusOK, wantVersion: "v1", wantDeprecated: true, wantLink: `</api/v1/test>; rel="successor-version"`},
+		{name: "unsupported accept version", path: "/api/test", accept: "application/vnd.govuk.v2+json", wantStatus: http.StatusNotAcceptable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if w.Body.String() != tt.wantVersion || w.Header().Get(APIVersionHeader) != tt.wantVersion {
+				t.Errorf("Expected version %s in the context and header, got %q and %q", tt.wantVersion, w.Body.String(), w.Header().Get(APIVersionHeader))
+			}
+			if deprecated := w.Header().Get("Deprecation") != ""; deprecated != tt.wantDeprecated {
+				t.Errorf("Expected deprecated %t, got Deprecation header %q", tt.wantDeprecated, w.Header().Get("Deprecation"))
+			}
+			if got := w.Header().Get("Link"); got != tt.wantLink {
+				t.Errorf("Expected Link %q, got %q", tt.wantLink, got)
+			}
+		})
+	}
+}
+
+func TestCORSMiddlewareWithOverrides(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	corsConfig := CORSConfig{
+		AllowedOrigins: ProductionCORSOrigins,
+		AllowedMethods: []string{"GET", "POST", "OPTIONS"},
+		AllowedHeaders: []string{"Content-Type"},
+	}
+	reportsCORS := corsConfig
+	reportsCORS.AllowedMethods = []string{"GET", "HEAD", "POST", "OPTIONS"}
+
+	router := gin.New()
+	router.Use(CORSMiddlewareWithOverrides(corsConfig, map[string]CORSConfig{"/api/reports/": reportsCORS}))
+	router.GET("/api/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		origin      string
+		wantStatus  int
+		wantAllowed bool
+		wantMethods string
+	}{
+		{name: "exact origin", method: http.MethodGet, path: "/api/test", origin: "https://publishing.service.gov.uk", wantStatus: http.StatusOK, wantAllowed: true},
+		{name: "wildcard subdomain", method: http.MethodGet, path: "/api/test", origin: "https://www.gov.uk", wantStatus: http.StatusOK, wantAllowed: true},
+		{name: "nested wildcard subdomain", method: http.MethodGet, path: "/api/test", origin: "https://content-publisher.integration.publishing.service.gov.uk", wantStatus: http.StatusOK, wantAllowed: true},
+		{name: "wildcard scheme mismatch", method: http.MethodGet, path: "/api/test", origin: "http://www.gov.uk", wantStatus: http.StatusOK},
+		{name: "wildcard port mismatch", method: http.MethodGet, path: "/api/test", origin: "https://www.gov.uk:8443", wantStatus: http.StatusOK},
+		{name: "lookalike domain", method: http.MethodGet, path: "/api/test", origin: "https://evilgov.uk", wantStatus: http.StatusOK},
+		{name: "other domain", method: http.MethodGet, path: "/api/test", origin: "https://gov.uk.example.com", wantStatus: http.StatusOK},
+		{name: "preflight", method: http.MethodOptions, path: "/api/test", origin: "https://www.gov.uk", wantStatus: http.StatusNoContent, wantAllowed: true, wantMethods: "GET, POST, OPTIONS"},
+		{name: "preflight with override", method: http.MethodOptions, path: "/api/v1/reports/bulk", origin: "https://www.gov.uk", wantStatus: http.StatusNoContent, wantAllowed: true, wantMethods: "GET, HEAD, POST, OPTIONS"},
+		{name: "rejected preflight", method: http.MethodOptions, path: "/api/test", origin: "https://example.com", wantStatus: http.StatusNoContent, wantMethods: "GET, POST, OPTIONS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Origin", tt.origin)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			allowOrigin := w.Header().Get("Access-Control-Allow-Origin")
+			if tt.wantAllowed && allowOrigin != tt.origin {
+				t.Errorf("Expected origin %s to be allowed, got %q", tt.origin, allowOrigin)
+			}
+			if !tt.wantAllowed && allowOrigin != "" {
+				t.Errorf("Expected origin %s to be rejected, got %q", tt.origin, allowOrigin)
+			}
+			if tt.wantMethods != "" && w.Header().Get("Access-Control-Allow-Methods") != tt.wantMethods {
+				t.Errorf("Expected methods %q, got %q", tt.wantMethods, w.Header().Get("Access-Control-Allow-Methods"))
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"govuk-reports-dashboard/internal/config"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/time/rate"
+)
+
+// rateLimitWindow is the period RequestsPerMinute applies to
+const rateLimitWindow = time.Minute
+
+// RateLimiter decides whether a client may make another request. When it
+// may not, retryAfter is how long it should wait. An error means the limit
+// couldn't be checked.
+type RateLimiter interface {
+	Allow(ctx context.Context, key string) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// NewRateLimiter returns the rate limiter for cfg: shared between instances
+// in Redis if cfg.RedisAddr is set, or in memory otherwise. It returns nil if
+// rate limiting is disabled.
+func NewRateLimiter(cfg config.RateLimitConfig, log *logger.Logger) RateLimiter {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.RedisAddr == "" {
+		log.Info().Msg("Rate limiting in memory, as no Redis address is configured")
+		return NewMemoryRateLimiter(cfg)
+	}
+
+	// Short timeouts and no retries, so requests aren't held up when Redis is
+	// unavailable and the limiter fails open
+	client := redis.NewClient(&redis.Options{
+		Addr:         cfg.RedisAddr,
+		DialTimeout:  500 * time.Millisecond,
+		ReadTimeout:  200 * time.Millisecond,
+		WriteTimeout: 200 * time.Millisecond,
+		MaxRetries:   -1,
+	})
+	log.WithField("redis_addr", cfg.RedisAddr).Info().Msg("Rate limiting in Redis")
+	return NewRedisRateLimiter(client, cfg)
+}
+
+// RedisClient is the subset of *redis.Client used for rate limiting
+type RedisClient interface {
+	Incr(ctx context.Context, key string) *redis.IntCmd
+	Expire(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd
+	Get(ctx context.Context, key string) *redis.StringCmd
+}
+
+// RedisRateLimiter counts requests in Redis with a sliding window: each
+// client has a counter per minute, and the previous minute's count is
+// weighted by how much of it still falls within the last minute.
+type RedisRateLimiter struct {
+	client    RedisClient
+	limit     float64
+	keyPrefix string
+	now       func() time.Time
+}
+
+// NewRedisRateLimiter creates a rate limiter storing its counters in client
+func NewRedisRateLimiter(client RedisClient, cfg config.RateLimitConfig) *RedisRateLimiter {
+	return &RedisRateLimiter{
+		client:    client,
+		limit:     float64(cfg.RequestsPerMinute + cfg.BurstSize),
+		keyPrefix: cfg.KeyPrefix,
+		now:       time.Now,
+	}
+}
+
+// Allow counts a request from key and checks it against the limit
+func (r *RedisRateLimiter) Allow(ctx context.Context, key string) (bool, time.Duration, error) {
+	now := r.now()
+	windowStart := now.Truncate(rateLimitWindow)
+	elapsed := now.Sub(windowStart)
+
+	currentKey := fmt.Sprintf("%s%s:%d", r.keyPrefix, key, windowStart.Unix())
+	previousKey := fmt.Sprintf("%s%s:%d", r.keyPrefix, key, windowStart.Add(-rateLimitWindow).Unix())
+
+	current, err := r.client.Incr(ctx, currentKey).Result()
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to increment rate limit counter: %w", err)
+	}
+	if current == 1 {
+		// Kept for the following window, where it is the previous count
+		if err := r.client.Expire(ctx, currentKey, 2*rateLimitWindow).Err(); err != nil {
+			return false, 0, fmt.Errorf("failed to set rate limit counter expiry: %w", err)
+		}
+	}
+
+	previous, err := r.client.Get(ctx, previousKey).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return false, 0, fmt.Errorf("failed to get previous rate limit counter: %w", err)
+	}
+
+	weight := 1 - float64(elapsed)/float64(rateLimitWindow)
+	if float64(previous)*weight+float64(current) <= r.limit {
+		return true, 0, nil
+	}
+
+	// The count only falls for certain once the current window ends
+	return false, (rateLimitWindow - elapsed).Round(time.Second), nil
+}
+
+// MemoryRateLimiter limits requests with a token bucket per client, for a
+// single instance without Redis
+type MemoryRateLimiter struct {
+	mu        sync.Mutex
+	limiters  map[string]*clientLimiter
+	limit     rate.Limit
+	burst     int
+	idleAfter time.Duration
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// NewMemoryRateLimiter creates an in-memory rate limiter. Clients may make
+// BurstSize requests at once, refilled at RequestsPerMinute.
+func NewMemoryRateLimiter(cfg config.RateLimitConfig) *MemoryRateLimiter {
+	limit := rate.Every(rateLimitWindow / time.Duration(cfg.RequestsPerMinute))
+	burst := max(cfg.BurstSize, 1)
+
+	// A client idle for this long has a full bucket again, the same as a
+	// new one, so it can be forgotten
+	idleAfter := max(rateLimitWindow, time.Duration(float64(burst)/float64(limit)*float64(time.Second)))
+
+	return &MemoryRateLimiter{
+		limiters:  make(map[string]*clientLimiter),
+		limit:     limit,
+		burst:     burst,
+		idleAfter: idleAfter,
+		now:       time.Now,
+	}
+}
+
+// Allow takes a token from key's bucket if there is one
+func (m *MemoryRateLimiter) Allow(ctx context.Context, key string) (bool, time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if now.Sub(m.lastSweep) > m.idleAfter {
+		for k, client := range m.limiters {
+			if now.Sub(client.lastSeen) > m.idleAfter {
+				delete(m.limiters, k)
+			}
+		}
+		m.lastSweep = now
+	}
+
+	client, ok := m.limiters[key]
+	if !ok {
+		client = &clientLimiter{limiter: rate.NewLimiter(m.limit, m.burst)}
+		m.limiters[key] = client
+	}
+	client.lastSeen = now
+
+	reservation := client.limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay, nil
+	}
+	return true, 0, nil
+}
@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"govuk-reports-dashboard/internal/config"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+// mockRedis keeps counters in a map
+type mockRedis struct {
+	counters map[string]int64
+	expiries map[string]time.Duration
+}
+
+func newMockRedis() *mockRedis {
+	return &mockRedis{counters: make(map[string]int64), expiries: make(map[string]time.Duration)}
+}
+
+func (m *mockRedis) Incr(ctx context.Context, key string) *redis.IntCmd {
+	m.counters[key]++
+	return redis.NewIntResult(m.counters[key], nil)
+}
+
+func (m *mockRedis) Expire(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd {
+	m.expiries[key] = expiration
+	return redis.NewBoolResult(true, nil)
+}
+
+func (m *mockRedis) Get(ctx context.Context, key string) *redis.StringCmd {
+	count, ok := m.counters[key]
+	if !ok {
+		return redis.NewStringResult("", redis.Nil)
+	}
+	return redis.NewStringResult(strconv.FormatInt(count, 10), nil)
+}
+
+// newRateLimitRouter returns a router limited by limiter with a test route
+// and a health check
+func newRateLimitRouter(limiter RateLimiter) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+
+	router := gin.New()
+	router.Use(RateLimitMiddleware(limiter, log))
+	router.GET("/api/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/api/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+func request(router *gin.Engine, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("User-Agent", "test")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimitMiddleware_Redis(t *testing.T) {
+	mock := newMockRedis()
+	limiter := NewRedisRateLimiter(mock, config.RateLimitConfig{RequestsPerMinute: 2, BurstSize: 1, KeyPrefix: "test:"})
+	now := time.Date(2026, 1, 1, 12, 0, 15, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+	router := newRateLimitRouter(limiter)
+
+	for i := 0; i < 3; i++ {
+		if w := request(router, "/api/test"); w.Code != http.StatusOK {
+			t.Fatalf("Expected request %d to be allowed, got status %d", i+1, w.Code)
+		}
+	}
+
+	w := request(router, "/api/test")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected status 429 over the limit, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") != "45" {
+		t.Errorf("Expected Retry-After 45 at the end of the window, got %q", w.Header().Get("Retry-After"))
+	}
+
+	key := "test:192.0.2.1:" + strconv.FormatInt(now.Truncate(time.Minute).Unix(), 10)
+	if mock.counters[key] != 4 {
+		t.Errorf("Expected 4 requests counted in %s, got %v", key, mock.counters)
+	}
+	if mock.expiries[key] != 2*time.Minute {
+		t.Errorf("Expected the counter to expire after 2 minutes, got %v", mock.expiries[key])
+	}
+}
+
+func TestRedisRateLimiter_SlidingWindow(t *testing.T) {
+	mock := newMockRedis()
+	limiter := NewRedisRateLimiter(mock, config.RateLimitConfig{RequestsPerMinute: 10, KeyPrefix: "test:"})
+
+	// 10 requests in the previous minute
+	previousMinute := time.Date(2026, 1, 1, 11, 59, 0, 0, time.UTC)
+	mock.counters["test:client:"+strconv.FormatInt(previousMinute.Unix(), 10)] = 10
+
+	// A quarter of the way into the next minute, three quarters of them
+	// still count, leaving room for 2 more
+	limiter.now = func() time.Time { return time.Date(2026, 1, 1, 12, 0, 15, 0, time.UTC) }
+	for i := 0; i < 2; i++ {
+		if allowed, _, err := limiter.Allow(context.Background(), "client"); err != nil || !allowed {
+			t.Fatalf("Expected request %d to be allowed, got %v, %v", i+1, allowed, err)
+		}
+	}
+	if allowed, _, _ := limiter.Allow(context.Background(), "client"); allowed {
+		t.Error("Expected the previous minute's requests to count towards the limit")
+	}
+
+	// Three quarters of the way in, only a quarter of them count
+	limiter.now = func() time.Time { return time.Date(2026, 1, 1, 12, 0, 45, 0, time.UTC) }
+	if allowed, _, _ := limiter.Allow(context.Background(), "client"); !allowed {
+		t.Error("Expected the previous minute's requests to count less as the window slides")
+	}
+}
+
+func TestRateLimitMiddleware_Memory(t *testing.T) {
+	limiter := NewMemoryRateLimiter(config.RateLimitConfig{RequestsPerMinute: 60, BurstSize: 2})
+	router := newRateLimitRouter(limiter)
+
+	for i := 0; i < 2; i++ {
+		if w := request(router, "/api/test"); w.Code != http.StatusOK {
+			t.Fatalf("Expected request %d to be allowed, got status %d", i+1, w.Code)
+		}
+	}
+
+	w := request(router, "/api/test")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected status 429 over the burst size, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") != "1" {
+		t.Errorf("Expected Retry-After 1 for one request a second, got %q", w.Header().Get("Retry-After"))
+	}
+}
+
+func TestRateLimitMiddleware_TrustedProxies(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		wantLimited    bool
+	}{
+		// httptest requests come from 192.0.2.1
+		{"forwarded header ignored by default", nil, true},
+		{"forwarded header from a trusted proxy", []string{"192.0.2.0/24"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := NewMemoryRateLimiter(config.RateLimitConfig{RequestsPerMinute: 60, BurstSize: 2})
+			router := newRateLimitRouter(limiter)
+			if err := router.SetTrustedProxies(tt.trustedProxies); err != nil {
+				t.Fatalf("Failed to set trusted proxies: %v", err)
+			}
+
+			var w *httptest.ResponseRecorder
+			for i := 0; i < 3; i++ {
+				w = httptest.NewRecorder()
+				req := httptest.NewRequest(http.MethodGet, "/api/test", nil)
+				req.Header.Set("X-Forwarded-For", "203.0.113."+strconv.Itoa(i+1))
+				router.ServeHTTP(w, req)
+			}
+
+			if limited := w.Code == http.StatusTooManyRequests; limited != tt.wantLimited {
+				t.Errorf("Expected limited %v with a new X-Forwarded-For per request, got status %d", tt.wantLimited, w.Code)
+			}
+		})
+	}
+}
+
+func TestMemoryRateLimiter_ForgetsIdleClients(t *testing.T) {
+	limiter := NewMemoryRateLimiter(config.RateLimitConfig{RequestsPerMinute: 60, BurstSize: 1})
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+
+	limiter.Allow(context.Background(), "first")
+	now = now.Add(2 * time.Minute)
+	limiter.Allow(context.Background(), "second")
+
+	if _, ok := limiter.limiters["first"]; ok {
+		t.Error("Expected an idle client to be forgotten")
+	}
+	if _, ok := limiter.limiters["second"]; !ok {
+		t.Error("Expected an active client to be kept")
+	}
+}
+
+func TestRateLimitMiddleware_ExemptPaths(t *testing.T) {
+	limiter := NewMemoryRateLimiter(config.RateLimitConfig{RequestsPerMinute: 1})
+	router := newRateLimitRouter(limiter)
+
+	request(router, "/api/test")
+	if w := request(router, "/api/test"); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected status 429 over the limit, got %d", w.Code)
+	}
+	for i := 0; i < 3; i++ {
+		if w := request(router, "/api/health"); w.Code != http.StatusOK {
+			t.Errorf("Expected health checks not to be rate limited, got status %d", w.Code)
+		}
+	}
+}
+
+func TestRateLimitMiddleware_FailsOpen(t *testing.T) {
+	// Nothing listens on the discard port
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:9", DialTimeout: 100 * time.Millisecond, MaxRetries: -1})
+	defer client.Close()
+	router := newRateLimitRouter(NewRedisRateLimiter(client, config.RateLimitConfig{RequestsPerMinute: 1}))
+
+	for i := 0; i < 3; i++ {
+		if w := request(router, "/api/test"); w.Code != http.StatusOK {
+			t.Errorf("Expected requests to be allowed when Redis is unavailable, got status %d", w.Code)
+		}
+	}
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+
+	if limiter := NewRateLimiter(config.RateLimitConfig{RequestsPerMinute: 1}, log); limiter != nil {
+		t.Errorf("Expected no rate limiter when disabled, got %T", limiter)
+	}
+	if limiter := NewRateLimiter(config.RateLimitConfig{Enabled: true, RequestsPerMinute: 1}, log); limiter == nil {
+		t.Error("Expected an in-memory rate limiter without Redis")
+	} else if _, ok := limiter.(*MemoryRateLimiter); !ok {
+		t.Errorf("Expected an in-memory rate limiter without Redis, got %T", limiter)
+	}
+	if _, ok := NewRateLimiter(config.RateLimitConfig{Enabled: true, RedisAddr: "localhost:6379", RequestsPerMinute: 1}, log).(*RedisRateLimiter); !ok {
+		t.Error("Expected a Redis rate limiter with a Redis address")
+	}
+}
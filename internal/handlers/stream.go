@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"govuk-reports-dashboard/internal/models"
+	"govuk-reports-dashboard/internal/reports"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReportStreamer is the part of reports.Manager used to stream reports
+type ReportStreamer interface {
+	GenerateReportStream(ctx context.Context, reportID string, params reports.ReportParams) (reports.ReportStream, error)
+}
+
+var _ ReportStreamer = (*reports.Manager)(nil)
+
+// StreamHandler serves reports as server-sent events
+type StreamHandler struct {
+	streamer ReportStreamer
+	logger   *logger.Logger
+}
+
+func NewStreamHandler(streamer ReportStreamer, log *logger.Logger) *StreamHandler {
+	return &StreamHandler{
+		streamer: streamer,
+		logger:   log,
+	}
+}
+
+// StreamReport handles GET /api/reports/:id/stream, sending one event per
+// chunk so clients can render summary data before slower sections are
+// ready. Errors found before the stream starts get an HTTP status: 404 for
+// unknown or disabled reports, 400 for invalid parameters and 500 otherwise.
+// Later errors are sent as an "error" event.
+func (h *StreamHandler) StreamReport(c *gin.Context) {
+	log := h.logger.WithRequestID(GetRequestID(c))
+	reportID := c.Param("id")
+
+	params := reports.ReportParams{
+		UseCache: true,
+	}
+
+	stream, err := h.streamer.GenerateReportStream(c.Request.Context(), reportID, params)
+	if errors.Is(err, reports.ErrReportNotFound) || errors.Is(err, reports.ErrReportDisabled) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: err.Error(),
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+	if errors.Is(err, reports.ErrInvalidParams) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "bad_request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err != nil {
+		log.WithError(err).WithField("report_id", reportID).Error().Msg("Failed to start report stream")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to generate report",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	ClearWriteDeadline(c, log)
+	c.Status(http.StatusOK)
+
+	for {
+		select {
+		case chunk, ok := <-stream:
+			if !ok {
+				return
+			}
+			c.SSEvent(string(chunk.ChunkType), chunk)
+			c.Writer.Flush()
+		case <-c.Request.Context().Done():
+			log.WithField("report_id", reportID).Debug().Msg("Client disconnected from report stream")
+			return
+		}
+	}
+}
+
+// ClearWriteDeadline exempts a server-sent event stream from the server's
+// write timeout, which would otherwise cut it off mid-stream. The stream is
+// still bounded by its route's request timeout.
+func ClearWriteDeadline(c *gin.Context, log *logger.Logger) {
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		log.WithError(err).Debug().Msg("Failed to clear write deadline for event stream")
+	}
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"govuk-reports-dashboard/internal/reports"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// stubStreamer streams a summary and done chunk for the report "costs", and
+// returns the error in errs for any other
+type stubStreamer struct {
+	errs map[string]error
+}
+
+func (s *stubStreamer) GenerateReportStream(ctx context.Context, reportID string, params reports.ReportParams) (reports.ReportStream, error) {
+	if reportID != "costs" {
+		return nil, s.errs[reportID]
+	}
+
+	chunks := make(chan reports.ReportChunk, 2)
+	chunks <- reports.ReportChunk{ChunkType: reports.ChunkTypeSummary, Payload: "summary", SequenceNumber: 1}
+	chunks <- reports.ReportChunk{ChunkType: reports.ChunkTypeDone, Payload: "report", SequenceNumber: 2}
+	close(chunks)
+	return chunks, nil
+}
+
+func newStreamRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+
+	streamer := &stubStreamer{errs: map[string]error{
+		"missing":  fmt.Errorf("report with ID missing: %w", reports.ErrReportNotFound),
+		"disabled": fmt.Errorf("report disabled: %w", reports.ErrReportDisabled),
+		"invalid":  fmt.Errorf("%w: unknown filter", reports.ErrInvalidParams),
+		"broken":   errors.New("report broken is not currently available"),
+	}}
+
+	router := gin.New()
+	router.GET("/api/reports/:id/stream", NewStreamHandler(streamer, log).StreamReport)
+	return router
+}
+
+func TestStreamHandler_StreamReport(t *testing.T) {
+	router := newStreamRouter()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/reports/costs/stream", nil))
+
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("Expected an event stream, got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	body := w.Body.String()
+	summary := strings.Index(body, "event:summary")
+	done := strings.Index(body, "event:done")
+	if summary < 0 || done < summary {
+		t.Errorf("Expected summary then done events, got:\n%s", body)
+	}
+}
+
+func TestStreamHandler_StreamReport_ErrorStatuses(t *testing.T) {
+	router := newStreamRouter()
+
+	tests := []struct {
+		reportID string
+		want     int
+	}{
+		{"missing", http.StatusNotFound},
+		{"disabled", http.StatusNotFound},
+		{"invalid", http.StatusBadRequest},
+		{"broken", http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reportID, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/reports/"+tt.reportID+"/stream", nil))
+			if w.Code != tt.want {
+				t.Errorf("Expected status %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+			if strings.Contains(w.Header().Get("Content-Type"), "text/event-stream") {
+				t.Error("Expected an error response rather than an event stream")
+			}
+		})
+	}
+}
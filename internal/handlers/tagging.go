@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"govuk-reports-dashboard/internal/models"
+	"govuk-reports-dashboard/pkg/aws"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TagResourcesPermission is the IAM action needed to apply tags
+const TagResourcesPermission = "tag:TagResources"
+
+// ResourceTagger is the part of aws.Client used to apply tags
+type ResourceTagger interface {
+	PermissionCheck(ctx context.Context, action string) error
+	TagResources(ctx context.Context, tags map[string]map[string]string) ([]aws.TaggingError, error)
+}
+
+var _ ResourceTagger = (*aws.Client)(nil)
+
+// TagSuggestion is a set of tags to apply to one resource
+type TagSuggestion struct {
+	ResourceARN string            `json:"resource_arn"`
+	Tags        map[string]string `json:"tags"`
+}
+
+// ApplyTagsRequest is the body for POST /api/tags/apply
+type ApplyTagsRequest struct {
+	Suggestions []TagSuggestion `json:"suggestions"`
+}
+
+// ApplyTagsResponse lists the resources tagged and those that failed
+type ApplyTagsResponse struct {
+	Applied []string           `json:"applied"`
+	Failed  []aws.TaggingError `json:"failed"`
+}
+
+// TaggingHandler applies suggested cost centre tags to AWS resources
+type TaggingHandler struct {
+	tagger ResourceTagger
+	logger *logger.Logger
+}
+
+func NewTaggingHandler(tagger ResourceTagger, log *logger.Logger) *TaggingHandler {
+	return &TaggingHandler{
+		tagger: tagger,
+		logger: log,
+	}
+}
+
+// ApplyTags handles POST /api/tags/apply
+func (h *TaggingHandler) ApplyTags(c *gin.Context) {
+	log := h.logger.WithRequestID(GetRequestID(c))
+	var request ApplyTagsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "bad_request",
+			Message: "Request body must be JSON with a suggestions list",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	if len(request.Suggestions) == 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "bad_request",
+			Message: "At least one suggestion is required",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	// Suggestions for the same resource are merged
+	tags := make(map[string]map[string]string)
+	for _, suggestion := range request.Suggestions {
+		if !strings.HasPrefix(suggestion.ResourceARN, "arn:") || len(suggestion.Tags) == 0 {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "bad_request",
+				Message: "Each suggestion needs a resource_arn and at least one tag",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		if tags[suggestion.ResourceARN] == nil {
+			tags[suggestion.ResourceARN] = make(map[string]string)
+		}
+		for key, value := range suggestion.Tags {
+			tags[suggestion.ResourceARN][key] = value
+		}
+	}
+
+	ctx := c.Request.Context()
+	if err := h.tagger.PermissionCheck(ctx, TagResourcesPermission); err != nil {
+		log.WithError(err).Error().Msg("Permission check for tagging failed")
+
+		status := http.StatusBadGateway
+		var permissionErr aws.PermissionError
+		if errors.As(err, &permissionErr) && permissionErr.ErrorCode == "AccessDenied" {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, models.ErrorResponse{
+			Error:   "permission_check_failed",
+			Message: "The dashboard is not permitted to tag resources",
+			Code:    status,
+		})
+		return
+	}
+
+	failures, err := h.tagger.TagResources(ctx, tags)
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to apply tags")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to apply tags",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	failed := make(map[string]aws.TaggingError, len(failures))
+	for _, failure := range failures {
+		failed[failure.ResourceARN] = failure
+	}
+
+	response := ApplyTagsResponse{Applied: []string{}, Failed: failures}
+	for arn, resourceTags := range tags {
+		result := "applied"
+		if failure, isFailed := failed[arn]; isFailed {
+			result = "failed: " + failure.ErrorCode
+		} else {
+			response.Applied = append(response.Applied, arn)
+		}
+
+		log.LogSecurityEvent("resource_tags_applied", c.ClientIP(), c.Request.UserAgent(), map[string]interface{}{
+			"resource_arn": arn,
+			"tags":         resourceTags,
+			"result":       result,
+		})
+	}
+	sort.Strings(response.Applied)
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"govuk-reports-dashboard/internal/models"
+	"govuk-reports-dashboard/pkg/logger"
+	"govuk-reports-dashboard/pkg/notifications"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterWebhookRequest is the body for POST /api/webhooks
+type RegisterWebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Secret string   `json:"secret"`
+}
+
+// WebhookHandler manages webhook registrations for report events
+type WebhookHandler struct {
+	dispatcher *notifications.WebhookDispatcher
+	logger     *logger.Logger
+}
+
+func NewWebhookHandler(dispatcher *notifications.WebhookDispatcher, log *logger.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		dispatcher: dispatcher,
+		logger:     log,
+	}
+}
+
+// RegisterWebhook handles POST /api/webhooks
+func (h *WebhookHandler) RegisterWebhook(c *gin.Context) {
+	log := h.logger.WithRequestID(GetRequestID(c))
+	var request RegisterWebhookRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "bad_request",
+			Message: "Request body must be JSON with url, events and secret",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	if request.Secret == "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "bad_request",
+			Message: "A secret is required to sign webhook payloads",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	registration, err := h.dispatcher.Register(request.URL, request.Events, request.Secret)
+	if errors.Is(err, notifications.ErrInvalidWebhook) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "bad_request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to register webhook")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to register webhook",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	log.LogSecurityEvent("webhook_registered", c.ClientIP(), c.Request.UserAgent(), map[string]interface{}{
+		"webhook_id": registration.ID,
+		"url":        registration.URL,
+	})
+	c.JSON(http.StatusCreated, registration)
+}
+
+// ListWebhooks handles GET /api/webhooks. Secrets are not returned.
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	registrations := h.dispatcher.List()
+	c.JSON(http.StatusOK, gin.H{
+		"webhooks": registrations,
+		"count":    len(registrations),
+	})
+}
+
+// DeleteWebhook handles DELETE /api/webhooks/:id
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	log := h.logger.WithRequestID(GetRequestID(c))
+	id := c.Param("id")
+	if !h.dispatcher.Delete(id) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Webhook not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	log.LogSecurityEvent("webhook_deleted", c.ClientIP(), c.Request.UserAgent(), map[string]interface{}{
+		"webhook_id": id,
+	})
+	c.JSON(http.StatusOK, gin.H{
+		"status":     "deleted",
+		"webhook_id": id,
+	})
+}
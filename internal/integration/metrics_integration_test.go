@@ -0,0 +1,91 @@
+//go:build integration
+
+package integration
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"govuk-reports-dashboard/internal/handlers"
+	"govuk-reports-dashboard/internal/reports"
+	"govuk-reports-dashboard/pkg/logger"
+	"govuk-reports-dashboard/pkg/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMetricsEndpointIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+
+	registry := metrics.NewRegistry()
+	env := setupTestEnv(t, &stubCostExplorer{}, false, reports.WithMetrics(registry))
+	registry.RegisterCache("report", func() (int64, int64) {
+		stats := env.manager.GetCacheStats()
+		return stats.ReportHits, stats.ReportMisses
+	})
+
+	// The same middleware order as setupRouter
+	router := gin.New()
+	router.Use(handlers.RateLimitMiddleware(nil, log))
+	router.Use(handlers.LoggerMiddleware(log))
+	router.Use(handlers.MetricsMiddleware(registry))
+	router.GET("/api/reports/:id", func(c *gin.Context) {
+		data, err := env.manager.GenerateReport(c.Request.Context(), c.Param("id"), reports.ReportParams{UseCache: true})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, data)
+	})
+	router.GET(handlers.MetricsPath, gin.WrapH(registry.Handler()))
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	// The second cost report request is served from the cache
+	for _, path := range []string{"/api/reports/costs", "/api/reports/costs", "/api/unknown"} {
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s failed: %v", path, err)
+		}
+		resp.Body.Close()
+	}
+
+	resp, err := http.Get(server.URL + handlers.MetricsPath)
+	if err != nil {
+		t.Fatalf("GET /metrics failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200 from /metrics, got %d", resp.StatusCode)
+	}
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read metrics: %v", err)
+	}
+	body := string(raw)
+
+	for _, expected := range []string{
+		`govuk_http_requests_total{method="GET",path="/api/reports/:id",status="200"} 2`,
+		`govuk_http_requests_total{method="GET",path="unmatched",status="404"} 1`,
+		`govuk_http_request_duration_seconds_count{method="GET",path="/api/reports/:id"} 2`,
+		`govuk_report_generation_duration_seconds_count{report_id="costs"} 1`,
+		`govuk_cache_operations_total{hit="true",type="report"} 1`,
+		`govuk_cache_operations_total{hit="false",type="report"} 1`,
+	} {
+		if !strings.Contains(body, expected+"\n") {
+			t.Errorf("Expected %q in metrics, got:\n%s", expected, body)
+		}
+	}
+
+	// Scrapes are counted once they have been served, not during their own
+	// response
+	if strings.Contains(body, `path="/metrics"`) {
+		t.Errorf("Expected the scrape not to count itself, got:\n%s", body)
+	}
+}
@@ -0,0 +1,277 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"govuk-reports-dashboard/internal/config"
+	"govuk-reports-dashboard/internal/modules/costs"
+	"govuk-reports-dashboard/internal/modules/rds"
+	"govuk-reports-dashboard/internal/reports"
+	awsclient "govuk-reports-dashboard/pkg/aws"
+	"govuk-reports-dashboard/pkg/govuk"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+)
+
+// stubCostExplorer returns the same canned cost breakdown for every query,
+// or err if set
+type stubCostExplorer struct {
+	err error
+}
+
+func (s *stubCostExplorer) GetCostAndUsage(ctx context.Context, params *costexplorer.GetCostAndUsageInput, optFns ...func(*costexplorer.Options)) (*costexplorer.GetCostAndUsageOutput, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	group := func(key, amount string) types.Group {
+		return types.Group{
+			Keys:    []string{key},
+			Metrics: map[string]types.MetricValue{"BlendedCost": {Amount: aws.String(amount), Unit: aws.String("GBP")}},
+		}
+	}
+
+	return &costexplorer.GetCostAndUsageOutput{
+		ResultsByTime: []types.ResultByTime{
+			{
+				TimePeriod: params.TimePeriod,
+				Groups: []types.Group{
+					group("Amazon Elastic Compute Cloud - Compute", "1200.00"),
+					group("Amazon Relational Database Service", "450.50"),
+					group("Amazon Simple Storage Service", "80.25"),
+				},
+			},
+		},
+	}, nil
+}
+
+func (s *stubCostExplorer) GetSavingsPlansCoverage(ctx context.Context, params *costexplorer.GetSavingsPlansCoverageInput, optFns ...func(*costexplorer.Options)) (*costexplorer.GetSavingsPlansCoverageOutput, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &costexplorer.GetSavingsPlansCoverageOutput{}, nil
+}
+
+func (s *stubCostExplorer) GetSavingsPlansUtilization(ctx context.Context, params *costexplorer.GetSavingsPlansUtilizationInput, optFns ...func(*costexplorer.Options)) (*costexplorer.GetSavingsPlansUtilizationOutput, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &costexplorer.GetSavingsPlansUtilizationOutput{}, nil
+}
+
+func (s *stubCostExplorer) GetCostForecast(ctx context.Context, params *costexplorer.GetCostForecastInput, optFns ...func(*costexplorer.Options)) (*costexplorer.GetCostForecastOutput, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &costexplorer.GetCostForecastOutput{
+		Total: &types.MetricValue{Amount: aws.String("2500.00"), Unit: aws.String("GBP")},
+	}, nil
+}
+
+const rdsXMLNamespace = "http://rds.amazonaws.com/doc/2014-10-31/"
+
+// rdsQueryResponses holds canned RDS Query API results keyed by action
+var rdsQueryResponses = map[string]string{
+	"DescribeDBInstances": `<DBInstances>
+		<DBInstance>
+			<DBInstanceIdentifier>publishing-api-postgres-production</DBInstanceIdentifier>
+			<DBName>publishing_api</DBName>
+			<Engine>postgres</Engine>
+			<EngineVersion>11.22</EngineVersion>
+			<DBInstanceClass>db.m5.large</DBInstanceClass>
+			<DBInstanceStatus>available</DBInstanceStatus>
+			<AvailabilityZone>eu-west-2a</AvailabilityZone>
+			<AllocatedStorage>100</AllocatedStorage>
+		</DBInstance>
+		<DBInstance>
+			<DBInstanceIdentifier>content-store-postgres-production</DBInstanceIdentifier>
+			<DBName>content_store</DBName>
+			<Engine>postgres</Engine>
+			<EngineVersion>16.3</EngineVersion>
+			<DBInstanceClass>db.m5.large</DBInstanceClass>
+			<DBInstanceStatus>available</DBInstanceStatus>
+			<AvailabilityZone>eu-west-2b</AvailabilityZone>
+			<AllocatedStorage>50</AllocatedStorage>
+		</DBInstance>
+	</DBInstances>`,
+	"DescribeDBSnapshots": `<DBSnapshots>
+		<DBSnapshot>
+			<DBSnapshotIdentifier>publishing-api-manual</DBSnapshotIdentifier>
+			<DBInstanceIdentifier>publishing-api-postgres-production</DBInstanceIdentifier>
+			<SnapshotType>manual</SnapshotType>
+			<Engine>postgres</Engine>
+			<AllocatedStorage>100</AllocatedStorage>
+		</DBSnapshot>
+	</DBSnapshots>`,
+	"DescribeDBClusterSnapshots": `<DBClusterSnapshots></DBClusterSnapshots>`,
+	"DescribeDBClusters":         `<DBClusters></DBClusters>`,
+	"DescribeGlobalClusters":     `<GlobalClusters></GlobalClusters>`,
+	"ListTagsForResource":        `<TagList></TagList>`,
+}
+
+// newRDSServer mimics the RDS Query API. When denied is set every action
+// fails with AccessDenied.
+func newRDSServer(t *testing.T, denied bool) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("Failed to parse RDS request: %v", err)
+		}
+		action := r.PostForm.Get("Action")
+
+		w.Header().Set("Content-Type", "text/xml")
+		if denied {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprintf(w, `<ErrorResponse xmlns=%q><Error><Type>Sender</Type><Code>AccessDenied</Code><Message>not authorized</Message></Error><RequestId>test</RequestId></ErrorResponse>`, rdsXMLNamespace)
+			return
+		}
+
+		result, ok := rdsQueryResponses[action]
+		if !ok {
+			t.Errorf("Unexpected RDS action %q", action)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprintf(w, `<%[1]sResponse xmlns=%[2]q><%[1]sResult>%[3]s</%[1]sResult><ResponseMetadata><RequestId>test</RequestId></ResponseMetadata></%[1]sResponse>`, action, rdsXMLNamespace, result)
+	}))
+}
+
+// newAppsServer mimics the GOV.UK apps.json endpoint
+func newAppsServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	apps := []govuk.Application{
+		{AppName: "Publishing API", Shortname: "publishing-api", Team: "#publishing-platform", ProductionHostedOn: "eks"},
+		{AppName: "Content Store", Shortname: "content-store", Team: "#publishing-platform", ProductionHostedOn: "eks"},
+		{AppName: "Frontend", Shortname: "frontend", Team: "#govuk-frontenders", ProductionHostedOn: "eks"},
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apps.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(apps)
+	}))
+}
+
+type testEnv struct {
+	manager    *reports.Manager
+	costReport *costs.CostReport
+	rdsReport  *rds.RDSReport
+}
+
+func setupTestEnv(t *testing.T, costExplorer awsclient.CostExplorerAPI, rdsDenied bool, opts ...reports.ManagerOption) *testEnv {
+	t.Helper()
+
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+
+	appsServer := newAppsServer(t)
+	t.Cleanup(appsServer.Close)
+	rdsServer := newRDSServer(t, rdsDenied)
+	t.Cleanup(rdsServer.Close)
+
+	cfg := &config.Config{
+		AWS: config.AWSConfig{Region: "eu-west-2"},
+	}
+
+	awsCfg := aws.Config{
+		Region:       "eu-west-2",
+		BaseEndpoint: aws.String(rdsServer.URL),
+		Credentials: aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "test", SecretAccessKey: "test", Source: "test"}, nil
+		}),
+	}
+
+	awsClient := awsclient.NewClientWithCostExplorer(awsCfg, costExplorer, log)
+	govukClient := govuk.NewClientWithOptions(cfg, log, govuk.ClientOptions{
+		Timeout:      5 * time.Second,
+		Retries:      1,
+		RetryDelay:   10 * time.Millisecond,
+		AppsEndpoint: appsServer.URL + "/apps.json",
+	})
+
+	costReport := costs.NewCostReport(
+		costs.NewCostService(awsClient, govukClient, log),
+		costs.NewApplicationService(awsClient, govukClient, log),
+		log,
+	)
+	rdsReport := rds.NewRDSReport(rds.NewRDSService(awsClient, cfg, log), log)
+
+	manager := reports.NewManager(log, opts...)
+	if err := manager.Register(costReport); err != nil {
+		t.Fatalf("Failed to register cost report: %v", err)
+	}
+	if err := manager.Register(rdsReport); err != nil {
+		t.Fatalf("Failed to register RDS report: %v", err)
+	}
+
+	return &testEnv{manager: manager, costReport: costReport, rdsReport: rdsReport}
+}
+
+func TestReportsManagerIntegration(t *testing.T) {
+	env := setupTestEnv(t, &stubCostExplorer{}, false)
+	ctx := context.Background()
+	params := reports.ReportParams{}
+
+	summaries, err := env.manager.GenerateSummary(ctx, params)
+	if err != nil {
+		t.Fatalf("GenerateSummary failed: %v", err)
+	}
+	if len(summaries) == 0 {
+		t.Error("Expected summaries from registered reports")
+	}
+
+	for _, reportID := range []string{"costs", "rds"} {
+		t.Run(reportID, func(t *testing.T) {
+			data, err := env.manager.GenerateReport(ctx, reportID, params)
+			if err != nil {
+				t.Fatalf("GenerateReport failed: %v", err)
+			}
+
+			if data.Status != reports.StatusCompleted {
+				t.Errorf("Expected status %s, got %s", reports.StatusCompleted, data.Status)
+			}
+			if len(data.DataPoints) == 0 {
+				t.Error("Expected data points")
+			}
+			if len(data.Errors) > 0 {
+				t.Errorf("Expected no errors, got %+v", data.Errors)
+			}
+			if data.Metadata.ID != reportID {
+				t.Errorf("Expected metadata for %s, got %s", reportID, data.Metadata.ID)
+			}
+		})
+	}
+}
+
+func TestReportsManagerIntegration_Unavailable(t *testing.T) {
+	env := setupTestEnv(t, &stubCostExplorer{err: errors.New("AccessDeniedException: not authorized")}, true)
+	ctx := context.Background()
+
+	if env.costReport.IsAvailable(ctx) {
+		t.Error("Expected cost report to be unavailable when Cost Explorer fails")
+	}
+	if env.rdsReport.IsAvailable(ctx) {
+		t.Error("Expected RDS report to be unavailable when RDS denies access")
+	}
+
+	if _, err := env.manager.GenerateReport(ctx, "costs", reports.ReportParams{}); err == nil {
+		t.Error("Expected GenerateReport to fail for an unavailable report")
+	}
+}
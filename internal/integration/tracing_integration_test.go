@@ -0,0 +1,134 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"govuk-reports-dashboard/internal/handlers"
+	"govuk-reports-dashboard/internal/reports"
+	"govuk-reports-dashboard/pkg/logger"
+	"govuk-reports-dashboard/pkg/tracing"
+
+	"github.com/gin-gonic/gin"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestTracingIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	tracing.SetProvider(provider)
+	t.Cleanup(func() { tracing.SetProvider(noop.NewTracerProvider()) })
+
+	env := setupTestEnv(t, &stubCostExplorer{}, false)
+
+	// The same middleware order as setupRouter
+	router := gin.New()
+	router.Use(handlers.TracingMiddleware(tracing.Tracer()))
+	router.Use(handlers.RateLimitMiddleware(nil, log))
+	router.Use(handlers.LoggerMiddleware(log))
+	router.GET("/api/reports/:id", func(c *gin.Context) {
+		data, err := env.manager.GenerateReport(c.Request.Context(), c.Param("id"), reports.ReportParams{})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, data)
+	})
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	// The caller's trace is continued
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/api/reports/rds", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET /api/reports/rds failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	spans := exporter.GetSpans().Snapshots()
+	byID := make(map[trace.SpanID]sdktrace.ReadOnlySpan, len(spans))
+	for _, span := range spans {
+		byID[span.SpanContext().SpanID()] = span
+	}
+	find := func(name string) []sdktrace.ReadOnlySpan {
+		var found []sdktrace.ReadOnlySpan
+		for _, span := range spans {
+			if span.Name() == name {
+				found = append(found, span)
+			}
+		}
+		return found
+	}
+
+	roots := find("GET /api/reports/:id")
+	if len(roots) != 1 {
+		t.Fatalf("Expected one request span, got %d of %d spans", len(roots), len(spans))
+	}
+	root := roots[0]
+	if root.SpanContext().TraceID().String() != traceID {
+		t.Errorf("Expected the request span to continue trace %s, got %s", traceID, root.SpanContext().TraceID())
+	}
+	if root.SpanKind() != trace.SpanKindServer {
+		t.Errorf("Expected a server span for the request, got %s", root.SpanKind())
+	}
+
+	services := find("rds.get_all_instances")
+	if len(services) == 0 {
+		t.Fatal("Expected a span for discovering RDS instances")
+	}
+	for _, span := range services {
+		if !descendsFrom(span, root, byID) {
+			t.Errorf("Expected %s to descend from the request span", span.Name())
+		}
+	}
+
+	calls := find("aws.rds.DescribeDBInstances")
+	if len(calls) == 0 {
+		t.Fatal("Expected a span for the DescribeDBInstances call")
+	}
+	underService := false
+	for _, span := range calls {
+		if parent, ok := byID[span.Parent().SpanID()]; ok && parent.Name() == "rds.get_all_instances" {
+			underService = true
+		}
+		if !descendsFrom(span, root, byID) {
+			t.Errorf("Expected %s to descend from the request span", span.Name())
+		}
+		if span.SpanKind() != trace.SpanKindClient {
+			t.Errorf("Expected a client span for the AWS call, got %s", span.SpanKind())
+		}
+	}
+	if !underService {
+		t.Error("Expected a DescribeDBInstances span to be a child of rds.get_all_instances")
+	}
+}
+
+// descendsFrom reports whether ancestor is among span's parents
+func descendsFrom(span, ancestor sdktrace.ReadOnlySpan, byID map[trace.SpanID]sdktrace.ReadOnlySpan) bool {
+	for span.Parent().IsValid() {
+		if span.Parent().SpanID() == ancestor.SpanContext().SpanID() {
+			return true
+		}
+		parent, ok := byID[span.Parent().SpanID()]
+		if !ok {
+			return false
+		}
+		span = parent
+	}
+	return false
+}
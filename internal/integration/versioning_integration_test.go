@@ -0,0 +1,98 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"govuk-reports-dashboard/internal/config"
+	"govuk-reports-dashboard/internal/handlers"
+	"govuk-reports-dashboard/internal/modules/rds"
+	"govuk-reports-dashboard/pkg/api"
+	awsclient "govuk-reports-dashboard/pkg/aws"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gin-gonic/gin"
+)
+
+func TestAPIVersioningIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+
+	rdsServer := newRDSServer(t, false)
+	t.Cleanup(rdsServer.Close)
+
+	awsClient := awsclient.NewClientWithCostExplorer(aws.Config{
+		Region:       "eu-west-2",
+		BaseEndpoint: aws.String(rdsServer.URL),
+		Credentials: aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "test", SecretAccessKey: "test", Source: "test"}, nil
+		}),
+	}, &stubCostExplorer{}, log)
+	cfg := &config.Config{AWS: config.AWSConfig{Region: "eu-west-2"}}
+	rdsHandler := rds.NewRDSHandler(rds.NewRDSService(awsClient, cfg, log), log)
+
+	router := gin.New()
+	for _, group := range handlers.APIGroups(router, api.V1) {
+		group.GET("/rds/instances", rdsHandler.GetInstances)
+	}
+
+	get := func(path string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		t.Helper()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200 from %s, got %d: %s", path, w.Code, w.Body.String())
+		}
+
+		var body map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Failed to decode %s response: %v", path, err)
+		}
+		// The summary and instances are stamped with when they were fetched
+		delete(body, "last_updated")
+		instances, _ := body["instances"].([]interface{})
+		for _, instance := range instances {
+			delete(instance.(map[string]interface{}), "last_modified")
+		}
+		return w, body
+	}
+
+	versioned, versionedBody := get("/api/v1/rds/instances")
+	unversioned, unversionedBody := get("/api/rds/instances")
+
+	if !reflect.DeepEqual(versionedBody, unversionedBody) {
+		t.Errorf("Expected the same body from both paths, got %v and %v", versionedBody, unversionedBody)
+	}
+	if versionedBody["total_instances"] != float64(2) {
+		t.Errorf("Expected 2 instances, got %v", versionedBody["total_instances"])
+	}
+
+	if got := versioned.Header().Get(handlers.APIVersionHeader); got != api.V1 {
+		t.Errorf("Expected %s header %s on the versioned path, got %q", handlers.APIVersionHeader, api.V1, got)
+	}
+	if got := versioned.Header().Get("Content-Type"); got != "application/json; charset=utf-8" {
+		t.Errorf("Expected a JSON content type on the versioned path, got %q", got)
+	}
+	if versioned.Header().Get("Deprecation") != "" || versioned.Header().Get("Link") != "" {
+		t.Errorf("Expected the versioned path not to be deprecated, got Deprecation %q and Link %q",
+			versioned.Header().Get("Deprecation"), versioned.Header().Get("Link"))
+	}
+
+	if got := unversioned.Header().Get(handlers.APIVersionHeader); got != api.V1 {
+		t.Errorf("Expected the unversioned path to be served as %s, got %q", api.V1, got)
+	}
+	if unversioned.Header().Get("Deprecation") == "" {
+		t.Error("Expected a Deprecation header on the unversioned path")
+	}
+	if got, want := unversioned.Header().Get("Link"), `</api/v1/rds/instances>; rel="successor-version"`; got != want {
+		t.Errorf("Expected Link %q on the unversioned path, got %q", want, got)
+	}
+}
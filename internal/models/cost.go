@@ -3,8 +3,18 @@ package models
 import "time"
 
 type HealthCheck struct {
-	Status    string            `json:"status"`
-	Version   string            `json:"version"`
-	Timestamp time.Time         `json:"timestamp"`
-	Checks    map[string]string `json:"checks"`
+	Status        string                `json:"status"`
+	Version       string                `json:"version"`
+	Timestamp     time.Time             `json:"timestamp"`
+	Checks        map[string]string     `json:"checks"`
+	ReportsHealth *ReportsHealthSummary `json:"reports_health,omitempty"`
+}
+
+// ReportsHealthSummary summarises the reports framework's health in
+// /api/health; /api/reports/health has the full details
+type ReportsHealthSummary struct {
+	Status             string   `json:"status"`
+	RegisteredReports  int      `json:"registered_reports"`
+	AvailableReports   int      `json:"available_reports"`
+	UnavailableReports []string `json:"unavailable_reports"`
 }
\ No newline at end of file
@@ -2,54 +2,152 @@ package costs
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math/rand"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"govuk-reports-dashboard/internal/modules/elasticache"
+	"govuk-reports-dashboard/internal/modules/rds"
+	"govuk-reports-dashboard/internal/reports"
 	"govuk-reports-dashboard/pkg/aws"
+	"govuk-reports-dashboard/pkg/common"
 	"govuk-reports-dashboard/pkg/govuk"
 	"govuk-reports-dashboard/pkg/logger"
+	"govuk-reports-dashboard/pkg/pagerduty"
+	"govuk-reports-dashboard/pkg/sentry"
+	"govuk-reports-dashboard/pkg/tracing"
 )
 
 type ApplicationService struct {
-	awsClient   *aws.Client
-	govukClient *govuk.Client
-	logger      *logger.Logger
+	awsClient          aws.CostDataClient
+	govukClient        govuk.ApplicationsClient
+	rosterClient       *govuk.TeamRosterClient
+	rdsService         *rds.RDSService
+	elastiCacheService *elasticache.ElastiCacheService
+	sentryClient       *sentry.SentryClient
+	pagerDutyClient    *pagerduty.Client
+	teamSchedules      map[string]string
+	ec2Inventory       EC2Inventory
+	costModel          *CostEstimationModel
+	logger             *logger.Logger
+
+	previousCostsMu sync.Mutex
+	previousCosts   *periodCosts
 }
 
-func NewApplicationService(awsClient *aws.Client, govukClient *govuk.Client, log *logger.Logger) *ApplicationService {
+// periodCosts are costs by system tag for the period starting on start
+type periodCosts struct {
+	start string
+	costs map[string]float64
+}
+
+// EC2Inventory lists running EC2 instances, as aws.Client does
+type EC2Inventory interface {
+	GetEC2Instances(ctx context.Context) ([]aws.EC2Instance, error)
+}
+
+func NewApplicationService(awsClient aws.CostDataClient, govukClient govuk.ApplicationsClient, log *logger.Logger) *ApplicationService {
 	return &ApplicationService{
 		awsClient:   awsClient,
 		govukClient: govukClient,
+		costModel:   DefaultCostEstimationModel(),
 		logger:      log,
 	}
 }
 
-// GetAllApplications returns all applications with cost summaries
-func (s *ApplicationService) GetAllApplications(ctx context.Context) (*ApplicationListResponse, error) {
-	s.logger.Info().Msg("Fetching all applications with cost data")
+// SetCostEstimationModel replaces the default model used to estimate the cost
+// of applications without matching AWS cost data
+func (s *ApplicationService) SetCostEstimationModel(model *CostEstimationModel) {
+	s.costModel = model
+}
+
+// SetTeamRosterClient enables team contact enrichment of application summaries
+func (s *ApplicationService) SetTeamRosterClient(rosterClient *govuk.TeamRosterClient) {
+	s.rosterClient = rosterClient
+}
+
+// SetInfrastructureServices enables linking applications to their RDS
+// instances and ElastiCache clusters
+func (s *ApplicationService) SetInfrastructureServices(rdsService *rds.RDSService, elastiCacheService *elasticache.ElastiCacheService) {
+	s.rdsService = rdsService
+	s.elastiCacheService = elastiCacheService
+}
+
+// SetSentryClient enables Sentry project details and error rates for
+// applications
+func (s *ApplicationService) SetSentryClient(sentryClient *sentry.SentryClient) {
+	s.sentryClient = sentryClient
+}
+
+// SetPagerDutyClient enables on-call lookups for teams, using teamSchedules
+// to map GOV.UK team names to PagerDuty schedule IDs
+func (s *ApplicationService) SetPagerDutyClient(pagerDutyClient *pagerduty.Client, teamSchedules map[string]string) {
+	s.pagerDutyClient = pagerDutyClient
+	s.teamSchedules = teamSchedules
+}
+
+// SetEC2Inventory enables breaking an application's EC2 cost down by
+// instance
+func (s *ApplicationService) SetEC2Inventory(inventory EC2Inventory) {
+	s.ec2Inventory = inventory
+}
+
+// SentryEnabled reports whether a Sentry client has been set
+func (s *ApplicationService) SentryEnabled() bool {
+	return s.sentryClient != nil
+}
+
+// trendMonths is the number of months shown in an application's cost sparkline
+const trendMonths = 6
+
+// GetAllApplications returns all applications with cost summaries. Setting the
+// "include_trend" filter to "true" adds a 6-month cost trend to each summary,
+// and "include_team_contacts" adds owning team contacts from the team roster.
+// A comma-separated "names" filter limits the response to those applications,
+// listing any that do not exist in NotFound. A "regex" filter limits it to
+// applications matching the pattern; see govuk.Client.SearchApplicationsRegex.
+// A nil filter returns every application.
+func (s *ApplicationService) GetAllApplications(ctx context.Context, params reports.ReportParams, filter *ApplicationFilter) (*ApplicationListResponse, error) {
+	ctx, span := tracing.Start(ctx, "applications.get_all_applications")
+	defer span.End()
+
+	log := s.logger.ForContext(ctx)
+	log.Info().Msg("Fetching all applications with cost data")
 
 	// Get applications from GOV.UK API
-	apps, err := s.govukClient.GetAllApplications(ctx)
+	apps, notFound, err := s.getApplications(ctx, params)
 	if err != nil {
-		s.logger.WithError(err).Error().Msg("Failed to fetch applications")
+		log.WithError(err).Error().Msg("Failed to fetch applications")
 		return nil, err
 	}
+	if filter == nil {
+		filter = &ApplicationFilter{}
+	}
+	apps = filter.filter(apps)
 
 	// Get cost data from AWS (for demo, we'll simulate costs)
-	costData, err := s.awsClient.GetCostData()
+	costData, err := s.awsClient.GetCostData(ctx)
 	if err != nil {
-		s.logger.WithError(err).Warn().Msg("Failed to fetch AWS cost data, using simulated data")
+		log.WithError(err).Warn().Msg("Failed to fetch AWS cost data, using simulated data")
 		costData = s.generateSimulatedCosts(apps)
 	}
 
+	includeTrend := params.Filters["include_trend"] == "true"
+	includeTeamContacts := params.Filters["include_team_contacts"] == "true" && s.rosterClient != nil
+	teamContacts := make(map[string]*govuk.TeamContacts)
+
 	var applicationSummaries []ApplicationSummary
-	var totalCost float64
+	appsByName := make(map[string]govuk.Application, len(apps))
 
 	for _, app := range apps {
+		appsByName[app.AppName] = app
+
 		// Calculate cost for this application with metadata
-		costResult := s.calculateApplicationCost(app, costData)
-		totalCost += costResult.Cost
+		costResult := s.calculateApplicationCost(ctx, app, costData)
 
 		summary := ApplicationSummary{
 			Name:               app.AppName,
@@ -57,7 +155,7 @@ func (s *ApplicationService) GetAllApplications(ctx context.Context) (*Applicati
 			Team:               app.Team,
 			ProductionHostedOn: app.ProductionHostedOn,
 			TotalCost:          costResult.Cost,
-			Currency:           "GBP",
+			Currency:           s.awsClient.ReportingCurrency(),
 			ServiceCount:       s.estimateServiceCount(app),
 			LastUpdated:        time.Now(),
 			CostSource:         costResult.Source,
@@ -73,25 +171,280 @@ func (s *ApplicationService) GetAllApplications(ctx context.Context) (*Applicati
 		applicationSummaries = append(applicationSummaries, summary)
 	}
 
+	aggregates := s.ComputeAggregates(applicationSummaries)
+	total := len(applicationSummaries)
+	filter.sort(applicationSummaries)
+	page := filter.page(applicationSummaries)
+
+	// Previous month costs, trends and team contacts need further requests,
+	// so are only fetched for the applications being returned
+	previousCosts := s.getPreviousMonthCosts(ctx, page)
+	for i := range page {
+		page[i].PreviousMonthCost = s.previousMonthCost(appsByName[page[i].Name], page[i], previousCosts)
+		page[i].CostChange, page[i].CostChangePercent = calculateCostChange(page[i].TotalCost, page[i].PreviousMonthCost)
+
+		if includeTrend {
+			page[i].Trend = s.getCostTrend(ctx, appsByName[page[i].Name])
+		}
+
+		team := page[i].Team
+		if includeTeamContacts && team != "" {
+			contacts, seen := teamContacts[team]
+			if !seen {
+				contacts, err = s.rosterClient.GetTeamContacts(ctx, team)
+				if err != nil {
+					log.WithError(err).WithField("team", team).Warn().Msg("Failed to fetch team contacts")
+				}
+				teamContacts[team] = contacts
+			}
+			page[i].TeamContacts = contacts
+		}
+	}
+
 	response := &ApplicationListResponse{
-		Applications: applicationSummaries,
-		TotalCost:    totalCost,
-		Currency:     "GBP",
-		Count:        len(applicationSummaries),
+		Applications: page,
+		TotalCost:    aggregates.TotalCost,
+		Currency:     s.awsClient.ReportingCurrency(),
+		Count:        len(page),
+		Total:        total,
+		Limit:        filter.Limit,
+		Offset:       filter.Offset,
+		HasMore:      filter.Offset+len(page) < total,
 		LastUpdated:  time.Now(),
+		NotFound:     notFound,
+		Aggregates:   aggregates,
 	}
 
-	s.logger.WithFields(map[string]interface{}{
-		"app_count":  len(applicationSummaries),
-		"total_cost": totalCost,
+	log.WithFields(map[string]interface{}{
+		"app_count":  len(page),
+		"total":      total,
+		"total_cost": aggregates.TotalCost,
 	}).Info().Msg("Successfully processed applications with costs")
 
 	return response, nil
 }
 
+// filter returns the applications matching the filter's team, hosting
+// platform and search term
+func (f *ApplicationFilter) filter(apps []govuk.Application) []govuk.Application {
+	if f.Team == "" && f.Hosting == "" && f.Search == "" {
+		return apps
+	}
+
+	search := strings.ToLower(f.Search)
+	var matched []govuk.Application
+	for _, app := range apps {
+		if f.Team != "" && app.Team != f.Team {
+			continue
+		}
+		if f.Hosting != "" && app.ProductionHostedOn != f.Hosting {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(app.AppName), search) &&
+			!strings.Contains(strings.ToLower(app.Shortname), search) {
+			continue
+		}
+		matched = append(matched, app)
+	}
+	return matched
+}
+
+// sort orders applications by the filter's sort field, keeping the existing
+// order of applications that compare equal
+func (f *ApplicationFilter) sort(apps []ApplicationSummary) {
+	var less func(a, b ApplicationSummary) bool
+	switch f.Sort {
+	case "name":
+		less = func(a, b ApplicationSummary) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) }
+	case "cost":
+		less = func(a, b ApplicationSummary) bool { return a.TotalCost < b.TotalCost }
+	case "team":
+		less = func(a, b ApplicationSummary) bool { return a.Team < b.Team }
+	case "hosting":
+		less = func(a, b ApplicationSummary) bool { return a.ProductionHostedOn < b.ProductionHostedOn }
+	default:
+		return
+	}
+
+	descending := f.Order == "desc"
+	sort.SliceStable(apps, func(i, j int) bool {
+		if descending {
+			return less(apps[j], apps[i])
+		}
+		return less(apps[i], apps[j])
+	})
+}
+
+// page returns the applications from Offset, up to Limit of them
+func (f *ApplicationFilter) page(apps []ApplicationSummary) []ApplicationSummary {
+	if f.Offset >= len(apps) {
+		return []ApplicationSummary{}
+	}
+	apps = apps[f.Offset:]
+	if f.Limit > 0 && len(apps) > f.Limit {
+		apps = apps[:f.Limit]
+	}
+	return apps
+}
+
+// topExpensiveAppCount is how many applications ComputeAggregates lists as
+// the most expensive
+const topExpensiveAppCount = 10
+
+// ComputeAggregates totals application costs by team, hosting platform and
+// cost confidence in a single pass, and finds the average, median and most
+// expensive applications
+func (s *ApplicationService) ComputeAggregates(apps []ApplicationSummary) ApplicationAggregates {
+	aggregates := ApplicationAggregates{
+		ByTeam:           make(map[string]float64),
+		ByPlatform:       make(map[string]float64),
+		ByConfidence:     make(map[string]int),
+		TopExpensiveApps: []ApplicationSummary{},
+	}
+	if len(apps) == 0 {
+		return aggregates
+	}
+
+	for _, app := range apps {
+		aggregates.TotalCost += app.TotalCost
+		aggregates.ByTeam[app.Team] += app.TotalCost
+		aggregates.ByPlatform[app.ProductionHostedOn] += app.TotalCost
+		aggregates.ByConfidence[app.CostConfidence]++
+	}
+	aggregates.AverageCost = aggregates.TotalCost / float64(len(apps))
+
+	byCost := make([]ApplicationSummary, len(apps))
+	copy(byCost, apps)
+	sort.SliceStable(byCost, func(i, j int) bool {
+		return byCost[i].TotalCost > byCost[j].TotalCost
+	})
+
+	middle := len(byCost) / 2
+	if len(byCost)%2 == 0 {
+		aggregates.MedianCost = (byCost[middle-1].TotalCost + byCost[middle].TotalCost) / 2
+	} else {
+		aggregates.MedianCost = byCost[middle].TotalCost
+	}
+
+	aggregates.TopExpensiveApps = byCost[:min(topExpensiveAppCount, len(byCost))]
+
+	return aggregates
+}
+
+// unknownTeam is the team applications without an owning team are grouped
+// under, as in govuk.HostingStats
+const unknownTeam = "unknown"
+
+// GetCostsByTeam returns the cost of every application grouped by owning
+// team, most expensive team first
+func (s *ApplicationService) GetCostsByTeam(ctx context.Context) (*TeamCostResponse, error) {
+	ctx, span := tracing.Start(ctx, "applications.get_costs_by_team")
+	defer span.End()
+
+	appData, err := s.GetAllApplications(ctx, reports.ReportParams{}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	teams := groupCostsByTeam(appData.Applications, appData.Currency)
+	s.logger.ForContext(ctx).WithField("team_count", len(teams)).Info().Msg("Grouped application costs by team")
+
+	return &TeamCostResponse{
+		Teams:       teams,
+		TotalCost:   appData.TotalCost,
+		Currency:    appData.Currency,
+		Count:       len(teams),
+		LastUpdated: time.Now(),
+	}, nil
+}
+
+// groupCostsByTeam totals application costs by team, sorted by total cost
+// descending and then by team name. It returns an empty slice, not nil,
+// when there are no applications.
+func groupCostsByTeam(apps []ApplicationSummary, currency string) []TeamCostSummary {
+	byTeam := make(map[string]*TeamCostSummary)
+	for _, app := range apps {
+		team := app.Team
+		if team == "" {
+			team = unknownTeam
+		}
+
+		summary, ok := byTeam[team]
+		if !ok {
+			summary = &TeamCostSummary{Team: team, Currency: currency, HighestCostApp: app}
+			byTeam[team] = summary
+		}
+		summary.TotalCost += app.TotalCost
+		summary.ApplicationCount++
+		if app.TotalCost > summary.HighestCostApp.TotalCost {
+			summary.HighestCostApp = app
+		}
+	}
+
+	teams := make([]TeamCostSummary, 0, len(byTeam))
+	for _, summary := range byTeam {
+		summary.AverageCost = summary.TotalCost / float64(summary.ApplicationCount)
+		teams = append(teams, *summary)
+	}
+	sort.Slice(teams, func(i, j int) bool {
+		if teams[i].TotalCost != teams[j].TotalCost {
+			return teams[i].TotalCost > teams[j].TotalCost
+		}
+		return teams[i].Team < teams[j].Team
+	})
+
+	return teams
+}
+
+// getApplications returns every application, or only those named in the
+// "names" filter along with the names that were not found
+func (s *ApplicationService) getApplications(ctx context.Context, params reports.ReportParams) ([]govuk.Application, []string, error) {
+	if pattern, _ := params.Filters["regex"].(string); pattern != "" {
+		apps, err := s.govukClient.SearchApplicationsRegex(ctx, pattern)
+		return apps, nil, err
+	}
+
+	namesFilter, _ := params.Filters["names"].(string)
+	if namesFilter == "" {
+		apps, err := s.govukClient.GetAllApplications(ctx)
+		return apps, nil, err
+	}
+
+	var names []string
+	for _, name := range strings.Split(namesFilter, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	found, missing, err := s.govukClient.BulkGetApplicationsByName(ctx, names)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Keep the requested order, and include an application only once if it
+	// was requested by both its name and shortname
+	var apps []govuk.Application
+	seen := make(map[string]bool)
+	for _, name := range names {
+		app, ok := found[name]
+		if !ok || seen[app.AppName] {
+			continue
+		}
+		seen[app.AppName] = true
+		apps = append(apps, *app)
+	}
+
+	return apps, missing, nil
+}
+
 // GetApplicationByName returns detailed application data with cost breakdown
 func (s *ApplicationService) GetApplicationByName(ctx context.Context, name string) (*ApplicationDetail, error) {
-	s.logger.WithField("app_name", name).Info().Msg("Fetching application details")
+	ctx, span := tracing.Start(ctx, "applications.get_application_by_name")
+	defer span.End()
+
+	log := s.logger.ForContext(ctx)
+	log.WithField("app_name", name).Info().Msg("Fetching application details")
 
 	// Get specific application
 	app, err := s.govukClient.GetApplicationByName(ctx, name)
@@ -100,17 +453,17 @@ func (s *ApplicationService) GetApplicationByName(ctx context.Context, name stri
 	}
 
 	// Get cost data
-	costData, err := s.awsClient.GetCostData()
+	costData, err := s.awsClient.GetCostData(ctx)
 	if err != nil {
-		s.logger.WithError(err).Warn().Msg("Failed to fetch AWS cost data, using simulated data")
+		log.WithError(err).Warn().Msg("Failed to fetch AWS cost data, using simulated data")
 		costData = s.generateSimulatedCosts([]govuk.Application{*app})
 	}
 
 	// Calculate cost with metadata
-	costResult := s.calculateApplicationCost(*app, costData)
+	costResult := s.calculateApplicationCost(ctx, *app, costData)
 
 	// Generate service breakdown
-	services := s.generateServiceBreakdown(*app, costData, costResult)
+	services := s.generateServiceBreakdown(ctx, *app, costData, costResult)
 
 	detail := &ApplicationDetail{
 		ApplicationSummary: ApplicationSummary{
@@ -119,7 +472,7 @@ func (s *ApplicationService) GetApplicationByName(ctx context.Context, name stri
 			Team:               app.Team,
 			ProductionHostedOn: app.ProductionHostedOn,
 			TotalCost:          costResult.Cost,
-			Currency:           "GBP",
+			Currency:           s.awsClient.ReportingCurrency(),
 			ServiceCount:       len(services),
 			LastUpdated:        time.Now(),
 			CostSource:         costResult.Source,
@@ -134,12 +487,24 @@ func (s *ApplicationService) GetApplicationByName(ctx context.Context, name stri
 		Services: services,
 	}
 
+	if s.sentryClient != nil {
+		project, err := s.getSentryProject(ctx, *app)
+		if err != nil && !errors.Is(err, ErrNoSentryProject) {
+			log.WithError(err).WithField("app_name", name).Warn().Msg("Failed to fetch Sentry project")
+		}
+		detail.SentryProject = project
+	}
+
 	return detail, nil
 }
 
 // GetApplicationServices returns service cost breakdown for an application
 func (s *ApplicationService) GetApplicationServices(ctx context.Context, name string) ([]ServiceCost, error) {
-	s.logger.WithField("app_name", name).Info().Msg("Fetching application service costs")
+	ctx, span := tracing.Start(ctx, "applications.get_application_services")
+	defer span.End()
+
+	log := s.logger.ForContext(ctx)
+	log.WithField("app_name", name).Info().Msg("Fetching application service costs")
 
 	// Get specific application
 	app, err := s.govukClient.GetApplicationByName(ctx, name)
@@ -148,36 +513,399 @@ func (s *ApplicationService) GetApplicationServices(ctx context.Context, name st
 	}
 
 	// Get cost data
-	costData, err := s.awsClient.GetCostData()
+	costData, err := s.awsClient.GetCostData(ctx)
 	if err != nil {
-		s.logger.WithError(err).Warn().Msg("Failed to fetch AWS cost data, using simulated data")
+		log.WithError(err).Warn().Msg("Failed to fetch AWS cost data, using simulated data")
 		costData = s.generateSimulatedCosts([]govuk.Application{*app})
 	}
 
 	// Calculate cost with metadata
-	costResult := s.calculateApplicationCost(*app, costData)
+	costResult := s.calculateApplicationCost(ctx, *app, costData)
 
-	services := s.generateServiceBreakdown(*app, costData, costResult)
+	services := s.generateServiceBreakdown(ctx, *app, costData, costResult)
 	return services, nil
 }
 
+// taggingSuggestionCount is the number of estimated applications suggested
+// for tagging in AttributionStats
+const taggingSuggestionCount = 5
+
+// GetAttributionStats counts applications by cost source and confidence
+func (s *ApplicationService) GetAttributionStats(ctx context.Context) (*AttributionStats, error) {
+	ctx, span := tracing.Start(ctx, "applications.get_attribution_stats")
+	defer span.End()
+
+	s.logger.ForContext(ctx).Info().Msg("Calculating cost attribution stats")
+
+	appData, err := s.GetAllApplications(ctx, reports.ReportParams{}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := calculateAttributionStats(appData.Applications, appData.Currency)
+	return &stats, nil
+}
+
+// calculateAttributionStats counts applications by cost confidence and
+// source, and lists the most expensive estimated applications as tagging
+// suggestions
+func calculateAttributionStats(apps []ApplicationSummary, currency string) AttributionStats {
+	stats := AttributionStats{
+		Currency:           currency,
+		TaggingSuggestions: []ApplicationSummary{},
+	}
+
+	var estimated []ApplicationSummary
+	for _, app := range apps {
+		switch app.CostConfidence {
+		case "high":
+			stats.HighConfidenceCount++
+		case "medium":
+			stats.MediumConfidenceCount++
+		case "low":
+			stats.LowConfidenceCount++
+		}
+
+		switch app.CostSource {
+		case "real_aws_tags":
+			stats.TaggedCount++
+		case "estimation":
+			stats.EstimatedCount++
+			stats.ImprovementPotentialGBP += app.TotalCost
+			estimated = append(estimated, app)
+		}
+	}
+
+	if len(apps) > 0 {
+		stats.TagCoveragePercent = float64(stats.TaggedCount) / float64(len(apps)) * 100
+	}
+
+	sort.Slice(estimated, func(i, j int) bool {
+		return estimated[i].TotalCost > estimated[j].TotalCost
+	})
+	if len(estimated) > taggingSuggestionCount {
+		estimated = estimated[:taggingSuggestionCount]
+	}
+	stats.TaggingSuggestions = append(stats.TaggingSuggestions, estimated...)
+
+	return stats
+}
+
+// SentryErrorPeriod is the period Sentry error counts and rates cover
+const SentryErrorPeriod = 24 * time.Hour
+
+// ErrSentryUnavailable is returned when Sentry details are requested but no
+// Sentry client has been set
+var ErrSentryUnavailable = errors.New("sentry is not configured")
+
+// ErrNoSentryProject is returned when an application has no matching project
+// in Sentry
+var ErrNoSentryProject = errors.New("application has no sentry project")
+
+// GetApplicationSentryProject returns an application's Sentry project with
+// its error count over the last SentryErrorPeriod
+func (s *ApplicationService) GetApplicationSentryProject(ctx context.Context, name string) (*sentry.SentryProject, error) {
+	ctx, span := tracing.Start(ctx, "applications.get_application_sentry_project")
+	defer span.End()
+
+	s.logger.ForContext(ctx).WithField("app_name", name).Info().Msg("Fetching application Sentry project")
+
+	if s.sentryClient == nil {
+		return nil, ErrSentryUnavailable
+	}
+
+	app, err := s.govukClient.GetApplicationByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getSentryProject(ctx, *app)
+}
+
+// GetApplicationErrorRate returns the average number of Sentry errors per hour
+// an application received over the last SentryErrorPeriod
+func (s *ApplicationService) GetApplicationErrorRate(ctx context.Context, app ApplicationSummary) (float64, error) {
+	ctx, span := tracing.Start(ctx, "applications.get_application_error_rate")
+	defer span.End()
+
+	if s.sentryClient == nil {
+		return 0, ErrSentryUnavailable
+	}
+
+	slug := sentryProjectSlug(app.Links.SentryURL, app.Shortname)
+	return s.sentryClient.GetProjectErrorRate(ctx, slug, SentryErrorPeriod)
+}
+
+func (s *ApplicationService) getSentryProject(ctx context.Context, app govuk.Application) (*sentry.SentryProject, error) {
+	slug := sentryProjectSlug(s.getSentryURL(app.Links.SentryURL), app.Shortname)
+
+	projects, err := s.sentryClient.GetProjects(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Sentry projects: %w", err)
+	}
+
+	for _, project := range projects {
+		if project.Slug != slug {
+			continue
+		}
+
+		count, err := s.sentryClient.GetProjectErrorCount(ctx, slug, SentryErrorPeriod)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Sentry error count: %w", err)
+		}
+		project.ErrorCount = count
+		return &project, nil
+	}
+
+	return nil, ErrNoSentryProject
+}
+
+// sentryProjectSlug returns the Sentry project named by an application's
+// Sentry URL, falling back to its shortname
+func sentryProjectSlug(sentryURL, shortname string) string {
+	if slug := sentry.ProjectSlugFromURL(sentryURL); slug != "" {
+		return slug
+	}
+	return shortname
+}
+
+// ErrPagerDutyUnavailable is returned when on-call details are requested but
+// no PagerDuty client has been set
+var ErrPagerDutyUnavailable = errors.New("pagerduty is not configured")
+
+// ErrNoOnCallSchedule is returned for a team without a PagerDuty schedule
+var ErrNoOnCallSchedule = errors.New("team has no pagerduty schedule")
+
+// GetTeamOnCall returns who is currently on call for a team
+func (s *ApplicationService) GetTeamOnCall(ctx context.Context, team string) (*pagerduty.OnCallSchedule, error) {
+	ctx, span := tracing.Start(ctx, "applications.get_team_on_call")
+	defer span.End()
+
+	if s.pagerDutyClient == nil {
+		return nil, ErrPagerDutyUnavailable
+	}
+
+	scheduleID, found := s.teamSchedules[team]
+	if !found {
+		return nil, fmt.Errorf("team %s: %w", team, ErrNoOnCallSchedule)
+	}
+
+	return s.pagerDutyClient.GetOnCallSchedule(ctx, scheduleID)
+}
+
+// ErrInfrastructureUnavailable is returned when application infrastructure is
+// requested but the RDS and ElastiCache services have not been set
+var ErrInfrastructureUnavailable = errors.New("infrastructure services are not configured")
+
+// infrastructureServiceNames are the service breakdown entries counted towards
+// an application's infrastructure cost
+var infrastructureServiceNames = map[string]bool{
+	"Amazon RDS":         true,
+	"Amazon ElastiCache": true,
+}
+
+// GetApplicationInfrastructure returns an application together with the RDS
+// instances and ElastiCache clusters carrying its system tag. Instances
+// without a system tag are matched on the application name derived from
+// their identifier.
+func (s *ApplicationService) GetApplicationInfrastructure(ctx context.Context, name string) (*ApplicationInfrastructure, error) {
+	ctx, span := tracing.Start(ctx, "applications.get_application_infrastructure")
+	defer span.End()
+
+	s.logger.ForContext(ctx).WithField("app_name", name).Info().Msg("Fetching application infrastructure")
+
+	if s.rdsService == nil || s.elastiCacheService == nil {
+		return nil, ErrInfrastructureUnavailable
+	}
+
+	detail, err := s.GetApplicationByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.findApplicationInfrastructure(ctx, detail)
+}
+
+// findApplicationInfrastructure matches RDS instances and ElastiCache clusters
+// to an application whose details have already been fetched
+func (s *ApplicationService) findApplicationInfrastructure(ctx context.Context, detail *ApplicationDetail) (*ApplicationInfrastructure, error) {
+	name := detail.Name
+	systemTag := s.mapAppNameToSystemTag(govuk.Application{AppName: detail.Name, Shortname: detail.Shortname})
+
+	var wg sync.WaitGroup
+	var instances *rds.InstancesSummary
+	var clusters *elasticache.CacheClustersSummary
+	var rdsErr, elastiCacheErr error
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		instances, rdsErr = s.rdsService.GetAllInstances(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		clusters, elastiCacheErr = s.elastiCacheService.GetAllClusters(ctx)
+	}()
+	wg.Wait()
+
+	if rdsErr != nil {
+		return nil, fmt.Errorf("failed to get RDS instances: %w", rdsErr)
+	}
+	if elastiCacheErr != nil {
+		return nil, fmt.Errorf("failed to get ElastiCache clusters: %w", elastiCacheErr)
+	}
+
+	infrastructure := &ApplicationInfrastructure{
+		Application:         *detail,
+		SystemTag:           systemTag,
+		RDSInstances:        []rds.PostgreSQLInstance{},
+		ElastiCacheClusters: []elasticache.ElastiCacheCluster{},
+		Currency:            detail.Currency,
+	}
+
+	for _, instance := range instances.Instances {
+		if matchesSystemTag(instance.Application, systemTag) {
+			infrastructure.RDSInstances = append(infrastructure.RDSInstances, instance)
+		}
+	}
+
+	for _, cluster := range clusters.AllCacheClusters {
+		if matchesSystemTag(cluster.Application, systemTag) {
+			infrastructure.ElastiCacheClusters = append(infrastructure.ElastiCacheClusters, cluster)
+		}
+	}
+
+	for _, service := range detail.Services {
+		if infrastructureServiceNames[service.ServiceName] {
+			infrastructure.TotalInfrastructureCost += service.Cost
+		}
+	}
+
+	s.logger.ForContext(ctx).WithFields(map[string]interface{}{
+		"app_name":             name,
+		"system_tag":           systemTag,
+		"rds_instances":        len(infrastructure.RDSInstances),
+		"elasticache_clusters": len(infrastructure.ElastiCacheClusters),
+	}).Info().Msg("Successfully fetched application infrastructure")
+
+	return infrastructure, nil
+}
+
+// GetApplicationCostContext returns everything the application detail page
+// needs in one call: the GOV.UK application record, its cost breakdown and
+// its RDS and ElastiCache resources. If the infrastructure services are not
+// configured or fail, the context is returned without infrastructure items.
+func (s *ApplicationService) GetApplicationCostContext(ctx context.Context, name string) (*ApplicationCostContext, error) {
+	ctx, span := tracing.Start(ctx, "applications.get_application_cost_context")
+	defer span.End()
+
+	log := s.logger.ForContext(ctx)
+	log.WithField("app_name", name).Info().Msg("Fetching application cost context")
+
+	app, err := s.govukClient.GetApplicationByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	detail, err := s.GetApplicationByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	costContext := &ApplicationCostContext{
+		Application:         *app,
+		CostSummary:         *detail,
+		InfrastructureItems: []InfrastructureItem{},
+	}
+
+	if s.rdsService == nil || s.elastiCacheService == nil {
+		return costContext, nil
+	}
+
+	infrastructure, err := s.findApplicationInfrastructure(ctx, detail)
+	if err != nil {
+		log.WithError(err).WithField("app_name", name).Warn().Msg("Failed to fetch application infrastructure")
+		return costContext, nil
+	}
+
+	costContext.InfrastructureAvailable = true
+	for _, instance := range infrastructure.RDSInstances {
+		costContext.InfrastructureItems = append(costContext.InfrastructureItems, InfrastructureItem{
+			Type:          InfrastructureTypeRDS,
+			ID:            instance.InstanceID,
+			ARN:           instance.ARN,
+			Engine:        instance.Engine,
+			EngineVersion: instance.Version,
+			NodeType:      instance.InstanceClass,
+			Status:        instance.Status,
+			Environment:   instance.Environment,
+		})
+	}
+	for _, cluster := range infrastructure.ElastiCacheClusters {
+		costContext.InfrastructureItems = append(costContext.InfrastructureItems, InfrastructureItem{
+			Type:          InfrastructureTypeElastiCache,
+			ID:            cluster.Id,
+			ARN:           cluster.ARN,
+			Engine:        cluster.Engine,
+			EngineVersion: cluster.EngineVersion,
+			NodeType:      cluster.NodeType,
+			Status:        cluster.Status,
+			Environment:   cluster.Environment,
+		})
+	}
+
+	return costContext, nil
+}
+
+// GetHostingStats returns aggregate application counts by hosting platform and team
+func (s *ApplicationService) GetHostingStats(ctx context.Context) (*govuk.HostingStats, error) {
+	ctx, span := tracing.Start(ctx, "applications.get_hosting_stats")
+	defer span.End()
+
+	log := s.logger.ForContext(ctx)
+	log.Info().Msg("Fetching application hosting stats")
+
+	stats, err := s.govukClient.GetHostingPlatformStats(ctx)
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to fetch hosting stats")
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// GetAllTeams returns the names of all teams that own applications
+func (s *ApplicationService) GetAllTeams(ctx context.Context) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "applications.get_all_teams")
+	defer span.End()
+
+	teams, err := s.govukClient.GetAllTeams(ctx)
+	if err != nil {
+		s.logger.ForContext(ctx).WithError(err).Error().Msg("Failed to fetch teams")
+		return nil, err
+	}
+
+	return teams, nil
+}
+
 // Helper functions
 
 // tryGetRealTagBasedCost attempts to get real cost data using AWS tags
-func (s *ApplicationService) tryGetRealTagBasedCost(app govuk.Application) (float64, string) {
+func (s *ApplicationService) tryGetRealTagBasedCost(ctx context.Context, app govuk.Application) (float64, string) {
+	log := s.logger.ForContext(ctx)
 	// Map GOV.UK app name to system tag format
 	systemTagName := s.mapAppNameToSystemTag(app)
 
-	s.logger.WithFields(map[string]interface{}{
+	log.WithFields(map[string]interface{}{
 		"app":        app.AppName,
 		"shortname":  app.Shortname,
 		"mapped_tag": systemTagName,
 	}).Debug().Msg("Attempting to get real tag-based cost")
 
 	// Try to get cost data for this specific application tag
-	tagCostData, err := s.awsClient.GetCostDataForApplication(systemTagName)
+	tagCostData, err := s.awsClient.GetCostDataForApplication(ctx, systemTagName, 1)
 	if err != nil {
-		s.logger.WithFields(map[string]interface{}{
+		log.WithFields(map[string]interface{}{
 			"app":   app.AppName,
 			"tag":   systemTagName,
 			"error": err.Error(),
@@ -186,7 +914,7 @@ func (s *ApplicationService) tryGetRealTagBasedCost(app govuk.Application) (floa
 	}
 
 	if len(tagCostData) == 0 {
-		s.logger.WithFields(map[string]interface{}{
+		log.WithFields(map[string]interface{}{
 			"app": app.AppName,
 			"tag": systemTagName,
 		}).Debug().Msg("No cost data found for application tag")
@@ -194,15 +922,12 @@ func (s *ApplicationService) tryGetRealTagBasedCost(app govuk.Application) (floa
 	}
 
 	// Sum up all costs for this application
-	totalCost := 0.0
-	for _, costItem := range tagCostData {
-		totalCost += costItem.Amount
-	}
+	totalCost := common.CostDataSlice(tagCostData).Sum()
 
 	// Determine confidence based on data quality
 	confidence := s.determineCostConfidence(tagCostData, app)
 
-	s.logger.WithFields(map[string]interface{}{
+	log.WithFields(map[string]interface{}{
 		"app":        app.AppName,
 		"tag":        systemTagName,
 		"total_cost": totalCost,
@@ -213,6 +938,131 @@ func (s *ApplicationService) tryGetRealTagBasedCost(app govuk.Application) (floa
 	return totalCost, confidence
 }
 
+// getCostTrend builds a 6-month cost trend for an application from its
+// system tag costs. Returns nil if the cost data cannot be fetched.
+func (s *ApplicationService) getCostTrend(ctx context.Context, app govuk.Application) *CostTrendIndicator {
+	systemTagName := s.mapAppNameToSystemTag(app)
+
+	tagCostData, err := s.awsClient.GetCostDataForApplication(ctx, systemTagName, trendMonths)
+	if err != nil {
+		s.logger.ForContext(ctx).WithFields(map[string]interface{}{
+			"app":   app.AppName,
+			"tag":   systemTagName,
+			"error": err.Error(),
+		}).Debug().Msg("Failed to get cost trend data")
+		return nil
+	}
+
+	return buildCostTrend(tagCostData, time.Now())
+}
+
+// getPreviousMonthCosts returns costs by system tag for the month before the
+// one calculateApplicationCost covers, fetched in a single request and cached
+// until the period moves on. Only tag-based costs have history, so nothing is
+// fetched unless one of apps has them. It returns nil if the costs can't be
+// fetched.
+func (s *ApplicationService) getPreviousMonthCosts(ctx context.Context, apps []ApplicationSummary) map[string]float64 {
+	needed := false
+	for _, app := range apps {
+		if app.CostSource == "real_aws_tags" {
+			needed = true
+			break
+		}
+	}
+	if !needed {
+		return nil
+	}
+
+	// calculateApplicationCost looks back a month from today, so the month
+	// before it ends a month ago
+	end := time.Now().AddDate(0, -1, 0)
+	start := end.AddDate(0, -1, 0)
+	period := start.Format("2006-01-02")
+
+	s.previousCostsMu.Lock()
+	defer s.previousCostsMu.Unlock()
+
+	if s.previousCosts != nil && s.previousCosts.start == period {
+		return s.previousCosts.costs
+	}
+
+	costData, err := s.awsClient.GetCostsBySystemTag(ctx, start, end)
+	if err != nil {
+		s.logger.ForContext(ctx).WithError(err).Warn().Msg("Failed to get previous month cost data")
+		return nil
+	}
+
+	costs := make(map[string]float64)
+	for _, cost := range costData {
+		costs[cost.Service] += cost.Amount
+	}
+	s.previousCosts = &periodCosts{start: period, costs: costs}
+
+	return costs
+}
+
+// previousMonthCost returns an application's cost from previousCosts. Only
+// tag-based costs have history, so estimates and service name matches are
+// treated as unchanged, as are all costs if previousCosts couldn't be fetched.
+func (s *ApplicationService) previousMonthCost(app govuk.Application, summary ApplicationSummary, previousCosts map[string]float64) float64 {
+	if summary.CostSource != "real_aws_tags" || previousCosts == nil {
+		return summary.TotalCost
+	}
+	return previousCosts[s.mapAppNameToSystemTag(app)]
+}
+
+// calculateCostChange returns the absolute and percentage change from
+// previous to current. A new application with no previous cost counts as a
+// 100% increase, as in buildCostTrend.
+func calculateCostChange(current, previous float64) (float64, float64) {
+	change := current - previous
+	if previous > 0 {
+		return change, change / previous * 100
+	}
+	if current > 0 {
+		return change, 100
+	}
+	return change, 0
+}
+
+// buildCostTrend buckets cost data into the trendMonths calendar months ending
+// with now's month, and compares the latest month with the one before it
+func buildCostTrend(costData []common.CostData, now time.Time) *CostTrendIndicator {
+	sparkline := make([]float64, trendMonths)
+	currentMonth := now.Year()*12 + int(now.Month()) - 1
+
+	for _, cost := range costData {
+		month := cost.StartDate.Year()*12 + int(cost.StartDate.Month()) - 1
+		index := trendMonths - 1 - (currentMonth - month)
+		if index >= 0 && index < trendMonths {
+			sparkline[index] += cost.Amount
+		}
+	}
+
+	latest := sparkline[trendMonths-1]
+	previous := sparkline[trendMonths-2]
+
+	trend := &CostTrendIndicator{
+		Direction: "flat",
+		Sparkline: sparkline,
+	}
+
+	if previous > 0 {
+		trend.ChangePercent = (latest - previous) / previous * 100
+	} else if latest > 0 {
+		trend.ChangePercent = 100
+	}
+
+	// Treat movements of under 1% as flat
+	if trend.ChangePercent >= 1 {
+		trend.Direction = "up"
+	} else if trend.ChangePercent <= -1 {
+		trend.Direction = "down"
+	}
+
+	return trend
+}
+
 // mapAppNameToSystemTag maps GOV.UK application names to system tag values
 // Returns system tag values in the format: govuk-{system-name}
 func (s *ApplicationService) mapAppNameToSystemTag(app govuk.Application) string {
@@ -275,8 +1125,18 @@ func (s *ApplicationService) mapAppNameToSystemTag(app govuk.Application) string
 	return "govuk-" + appName
 }
 
+// matchesSystemTag reports whether a resource's application, taken from its
+// system tag or identifier, belongs to the given system tag value
+func matchesSystemTag(application, systemTag string) bool {
+	if application == "" {
+		return false
+	}
+	application = strings.ToLower(application)
+	return application == systemTag || "govuk-"+application == systemTag
+}
+
 // determineCostConfidence assesses the reliability of cost data
-func (s *ApplicationService) determineCostConfidence(costData []CostData, app govuk.Application) string {
+func (s *ApplicationService) determineCostConfidence(costData []common.CostData, app govuk.Application) string {
 	if len(costData) == 0 {
 		return "none"
 	}
@@ -321,10 +1181,11 @@ type CostCalculationResult struct {
 	Confidence string // "high", "medium", "low", "none"
 }
 
-func (s *ApplicationService) calculateApplicationCost(app govuk.Application, costData []CostData) CostCalculationResult {
+func (s *ApplicationService) calculateApplicationCost(ctx context.Context, app govuk.Application, costData []common.CostData) CostCalculationResult {
+	log := s.logger.ForContext(ctx)
 	// First, try to get real tag-based cost data from AWS
-	if realCost, confidence := s.tryGetRealTagBasedCost(app); realCost > 0 {
-		s.logger.WithFields(map[string]interface{}{
+	if realCost, confidence := s.tryGetRealTagBasedCost(ctx, app); realCost > 0 {
+		log.WithFields(map[string]interface{}{
 			"app":        app.AppName,
 			"cost":       realCost,
 			"confidence": confidence,
@@ -339,7 +1200,7 @@ func (s *ApplicationService) calculateApplicationCost(app govuk.Application, cos
 
 	// Try to find exact cost match from existing AWS data
 	if exactCost := s.findExactCostMatch(app, costData); exactCost > 0 {
-		s.logger.WithFields(map[string]interface{}{
+		log.WithFields(map[string]interface{}{
 			"app":        app.AppName,
 			"cost":       exactCost,
 			"confidence": "medium",
@@ -354,7 +1215,7 @@ func (s *ApplicationService) calculateApplicationCost(app govuk.Application, cos
 
 	// Fall back to intelligent estimation
 	estimatedCost := s.estimateApplicationCost(app, costData)
-	s.logger.WithFields(map[string]interface{}{
+	log.WithFields(map[string]interface{}{
 		"app":        app.AppName,
 		"cost":       estimatedCost,
 		"confidence": "low",
@@ -369,7 +1230,7 @@ func (s *ApplicationService) calculateApplicationCost(app govuk.Application, cos
 }
 
 // findExactCostMatch attempts to find direct cost attribution
-func (s *ApplicationService) findExactCostMatch(app govuk.Application, costData []CostData) float64 {
+func (s *ApplicationService) findExactCostMatch(app govuk.Application, costData []common.CostData) float64 {
 	// Try different naming convention matches
 	possibleMatches := []string{
 		app.AppName,   // Direct name match
@@ -403,18 +1264,18 @@ func (s *ApplicationService) findExactCostMatch(app govuk.Application, costData
 }
 
 // estimateApplicationCost provides intelligent cost estimation
-func (s *ApplicationService) estimateApplicationCost(app govuk.Application, costData []CostData) float64 {
-	// Base cost calculation using multiple factors
-	baseCost := s.calculateBaseCost(app)
+func (s *ApplicationService) estimateApplicationCost(app govuk.Application, costData []common.CostData) float64 {
+	// Base cost adjusted for the application type inferred from its name
+	baseCost := s.costModel.BaseCostGBP * s.costModel.AppTypeMultiplier(app.AppName)
 
 	// Apply team-based scaling
-	teamMultiplier := s.getTeamCostMultiplier(app.Team)
+	teamMultiplier := s.costModel.TeamMultiplier(app.Team)
 
 	// Apply hosting platform multiplier
-	platformMultiplier := s.getHostingPlatformMultiplier(app.ProductionHostedOn)
+	platformMultiplier := s.costModel.PlatformMultiplier(app.ProductionHostedOn)
 
 	// Apply application complexity multiplier
-	complexityMultiplier := s.getComplexityMultiplier(app)
+	complexityMultiplier := s.costModel.ComplexityMultiplier(app.AppName)
 
 	// Calculate final cost
 	finalCost := baseCost * teamMultiplier * platformMultiplier * complexityMultiplier
@@ -436,111 +1297,6 @@ func (s *ApplicationService) estimateApplicationCost(app govuk.Application, cost
 	return finalCost
 }
 
-// calculateBaseCost determines base cost based on application characteristics
-func (s *ApplicationService) calculateBaseCost(app govuk.Application) float64 {
-	baseCost := 150.0 // Starting base cost in GBP
-
-	// Adjust based on application type (inferred from name patterns)
-	if strings.Contains(strings.ToLower(app.AppName), "api") {
-		baseCost *= 1.3 // APIs typically consume more resources
-	}
-	if strings.Contains(strings.ToLower(app.AppName), "frontend") {
-		baseCost *= 0.8 // Frontends typically consume less
-	}
-	if strings.Contains(strings.ToLower(app.AppName), "publisher") {
-		baseCost *= 1.2 // Publishing apps have moderate load
-	}
-	if strings.Contains(strings.ToLower(app.AppName), "admin") {
-		baseCost *= 0.7 // Admin tools typically have lower usage
-	}
-	if strings.Contains(strings.ToLower(app.AppName), "search") {
-		baseCost *= 1.5 // Search systems are resource intensive
-	}
-
-	return baseCost
-}
-
-// getTeamCostMultiplier returns cost multiplier based on team size and activity
-func (s *ApplicationService) getTeamCostMultiplier(team string) float64 {
-	teamMultipliers := map[string]float64{
-		"GOV.UK Platform":      1.4, // Platform team manages high-traffic infrastructure
-		"Publishing Platform":  1.3, // Core publishing infrastructure
-		"Data Products":        1.2, // Data processing workloads
-		"Content":              1.0, // Standard content applications
-		"Design System":        0.8, // Lower traffic design tools
-		"Developer docs":       0.7, // Documentation sites
-		"Performance":          1.1, // Monitoring and analytics
-		"Cyber Security":       1.0, // Security tooling
-		"Specialist Publisher": 0.9, // Specialized publishing tools
-	}
-
-	if multiplier, exists := teamMultipliers[team]; exists {
-		return multiplier
-	}
-
-	// Default multiplier for unknown teams
-	return 1.0
-}
-
-// getHostingPlatformMultiplier returns multiplier based on hosting platform costs
-func (s *ApplicationService) getHostingPlatformMultiplier(platform string) float64 {
-	switch strings.ToLower(platform) {
-	case "eks", "kubernetes":
-		return 1.6 // EKS with all the managed services
-	case "ec2":
-		return 1.2 // Traditional EC2 instances
-	case "heroku":
-		return 0.9 // Heroku's efficiency for smaller apps
-	case "gcp", "google cloud":
-		return 1.3 // GCP services
-	case "aws fargate":
-		return 1.4 // Serverless containers
-	case "aws lambda":
-		return 0.6 // Pay-per-execution model
-	case "cloudflare":
-		return 0.3 // CDN and edge compute
-	default:
-		return 1.0 // Unknown platforms
-	}
-}
-
-// getComplexityMultiplier estimates complexity based on application characteristics
-func (s *ApplicationService) getComplexityMultiplier(app govuk.Application) float64 {
-	complexity := 1.0
-
-	appNameLower := strings.ToLower(app.AppName)
-
-	// Database-heavy applications
-	if strings.Contains(appNameLower, "db") ||
-		strings.Contains(appNameLower, "database") ||
-		strings.Contains(appNameLower, "store") {
-		complexity *= 1.3
-	}
-
-	// Workflow/orchestration applications
-	if strings.Contains(appNameLower, "workflow") ||
-		strings.Contains(appNameLower, "router") ||
-		strings.Contains(appNameLower, "gateway") {
-		complexity *= 1.4
-	}
-
-	// Simple static sites or documentation
-	if strings.Contains(appNameLower, "static") ||
-		strings.Contains(appNameLower, "docs") ||
-		strings.Contains(appNameLower, "guide") {
-		complexity *= 0.6
-	}
-
-	// High-traffic public-facing applications
-	if strings.Contains(appNameLower, "www") ||
-		strings.Contains(appNameLower, "frontend") ||
-		strings.Contains(appNameLower, "gov.uk") {
-		complexity *= 1.2
-	}
-
-	return complexity
-}
-
 // getConsistentHashMultiplier provides deterministic variation based on app name
 func (s *ApplicationService) getConsistentHashMultiplier(appName string) float64 {
 	// Simple hash function for consistent results
@@ -566,7 +1322,7 @@ func (s *ApplicationService) estimateServiceCount(app govuk.Application) int {
 	}
 }
 
-func (s *ApplicationService) generateServiceBreakdown(app govuk.Application, costData []CostData, appCostResult CostCalculationResult) []ServiceCost {
+func (s *ApplicationService) generateServiceBreakdown(ctx context.Context, app govuk.Application, costData []common.CostData, appCostResult CostCalculationResult) []ServiceCost {
 	// Common AWS services used by GOV.UK applications
 	serviceNames := []string{
 		"Amazon EC2",
@@ -583,6 +1339,23 @@ func (s *ApplicationService) generateServiceBreakdown(app govuk.Application, cos
 	totalCost := appCostResult.Cost
 	now := time.Now()
 
+	// Use real per-service spend to weight the breakdown where possible. If the
+	// full cost data has no entries for these services, query just them.
+	serviceSpend := sumServiceCosts(costData, serviceNames)
+	if len(serviceSpend) == 0 && s.awsClient != nil {
+		serviceData, err := s.awsClient.GetCostDataForServices(ctx, serviceNames, now.AddDate(0, -1, 0), now)
+		if err != nil {
+			s.logger.ForContext(ctx).WithError(err).Warn().Msg("Failed to fetch targeted service costs, using estimated distribution")
+		} else {
+			serviceSpend = sumServiceCosts(serviceData, serviceNames)
+		}
+	}
+
+	spendTotal := 0.0
+	for _, amount := range serviceSpend {
+		spendTotal += amount
+	}
+
 	// Generate realistic service distribution
 	serviceCount := s.estimateServiceCount(app)
 
@@ -596,10 +1369,12 @@ func (s *ApplicationService) generateServiceBreakdown(app govuk.Application, cos
 	for i, serviceName := range usedServices {
 		// Generate realistic cost distribution
 		var percentage float64
-		switch i {
-		case 0: // Primary service (usually EC2 or EKS)
+		switch {
+		case spendTotal > 0: // Proportional to actual account spend
+			percentage = serviceSpend[serviceName] / spendTotal
+		case i == 0: // Primary service (usually EC2 or EKS)
 			percentage = 0.4 + rand.Float64()*0.3 // 40-70%
-		case 1: // Secondary service (usually RDS)
+		case i == 1: // Secondary service (usually RDS)
 			percentage = 0.15 + rand.Float64()*0.2 // 15-35%
 		default: // Other services
 			percentage = 0.02 + rand.Float64()*0.1 // 2-12%
@@ -610,7 +1385,7 @@ func (s *ApplicationService) generateServiceBreakdown(app govuk.Application, cos
 		service := ServiceCost{
 			ServiceName: serviceName,
 			Cost:        cost,
-			Currency:    "GBP",
+			Currency:    s.awsClient.ReportingCurrency(),
 			Percentage:  percentage * 100,
 			StartDate:   now.AddDate(0, -1, 0),
 			EndDate:     now,
@@ -622,7 +1397,82 @@ func (s *ApplicationService) generateServiceBreakdown(app govuk.Application, cos
 	// Normalize percentages to ensure they add up to 100%
 	s.normalizeServiceCosts(services, totalCost)
 
-	return services
+	return s.breakDownEC2Cost(ctx, app, services)
+}
+
+// breakDownEC2Cost replaces the "Amazon EC2" entry with one entry per running
+// instance tagged with the application, split by estimated hourly cost.
+// Services are returned unchanged if there is no EC2 inventory or no tagged
+// instances.
+func (s *ApplicationService) breakDownEC2Cost(ctx context.Context, app govuk.Application, services []ServiceCost) []ServiceCost {
+	if s.ec2Inventory == nil {
+		return services
+	}
+
+	ec2Index := -1
+	for i, service := range services {
+		if service.ServiceName == "Amazon EC2" {
+			ec2Index = i
+			break
+		}
+	}
+	if ec2Index < 0 {
+		return services
+	}
+
+	instances, err := s.ec2Inventory.GetEC2Instances(ctx)
+	if err != nil {
+		s.logger.ForContext(ctx).WithError(err).Warn().Msg("Failed to fetch EC2 instances, not breaking down EC2 cost")
+		return services
+	}
+
+	var appInstances []aws.EC2Instance
+	totalHourlyCost := 0.0
+	for _, instance := range instances {
+		if strings.EqualFold(instance.Application, app.Shortname) || strings.EqualFold(instance.Application, app.AppName) {
+			appInstances = append(appInstances, instance)
+			totalHourlyCost += instance.EstimatedHourlyCost
+		}
+	}
+	if len(appInstances) == 0 {
+		return services
+	}
+
+	ec2 := services[ec2Index]
+	breakdown := make([]ServiceCost, 0, len(services)+len(appInstances)-1)
+	breakdown = append(breakdown, services[:ec2Index]...)
+	for _, instance := range appInstances {
+		// Split evenly if none of the instance types have a known price
+		share := 1.0 / float64(len(appInstances))
+		if totalHourlyCost > 0 {
+			share = instance.EstimatedHourlyCost / totalHourlyCost
+		}
+
+		entry := ec2
+		entry.ServiceName = fmt.Sprintf("Amazon EC2 (%s, %s)", instance.InstanceID, instance.InstanceType)
+		entry.Cost = ec2.Cost * share
+		entry.Percentage = ec2.Percentage * share
+		breakdown = append(breakdown, entry)
+	}
+	breakdown = append(breakdown, services[ec2Index+1:]...)
+
+	return breakdown
+}
+
+// sumServiceCosts totals cost data entries for the named services
+func sumServiceCosts(costData []common.CostData, serviceNames []string) map[string]float64 {
+	wanted := make(map[string]bool, len(serviceNames))
+	for _, name := range serviceNames {
+		wanted[name] = true
+	}
+
+	totals := common.CostDataSlice(costData).ByService()
+	for service := range totals {
+		if !wanted[service] {
+			delete(totals, service)
+		}
+	}
+	return totals
 }
 
 func (s *ApplicationService) normalizeServiceCosts(services []ServiceCost, totalCost float64) {
@@ -645,17 +1495,17 @@ func (s *ApplicationService) normalizeServiceCosts(services []ServiceCost, total
 	}
 }
 
-func (s *ApplicationService) generateSimulatedCosts(apps []govuk.Application) []CostData {
-	var costData []CostData
+func (s *ApplicationService) generateSimulatedCosts(apps []govuk.Application) []common.CostData {
+	var costData []common.CostData
 	now := time.Now()
 
 	for _, app := range apps {
 		// For simulated costs, we'll use estimation (can't use real tags when generating simulated data)
 		estimatedCost := s.estimateApplicationCost(app, nil)
-		cost := CostData{
+		cost := common.CostData{
 			Service:     app.AppName,
 			Amount:      estimatedCost,
-			Currency:    "GBP",
+			Currency:    s.awsClient.ReportingCurrency(),
 			StartDate:   now.AddDate(0, -1, 0),
 			EndDate:     now,
 			Granularity: "MONTHLY",
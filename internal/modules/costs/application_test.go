@@ -0,0 +1,428 @@
+package costs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"govuk-reports-dashboard/internal/reports"
+	"govuk-reports-dashboard/pkg/aws"
+	"govuk-reports-dashboard/pkg/common"
+	"govuk-reports-dashboard/pkg/govuk"
+	"govuk-reports-dashboard/pkg/logger"
+	"govuk-reports-dashboard/pkg/pagerduty"
+	"govuk-reports-dashboard/pkg/sentry"
+)
+
+func TestApplicationService_GetApplicationSentryProject(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/organizations/govuk/projects/":
+			fmt.Fprint(w, `[{"slug":"publishing-api","name":"Publishing API","platform":"ruby","isMember":true}]`)
+		case "/projects/govuk/publishing-api/stats/":
+			fmt.Fprint(w, `[[1700000000, 4], [1700003600, 3]]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	sentryURL := "https://sentry.io/organizations/govuk/projects/publishing-api/"
+	govukClient := &govuk.MockApplicationsClient{
+		GetApplicationByNameResult: &govuk.Application{
+			AppName:   "Publishing API",
+			Shortname: "publishing_api",
+			Links:     govuk.Links{SentryURL: &sentryURL},
+		},
+	}
+
+	service := NewApplicationService(&aws.MockCostDataClient{}, govukClient, log)
+	if _, err := service.GetApplicationSentryProject(context.Background(), "publishing-api"); !errors.Is(err, ErrSentryUnavailable) {
+		t.Fatalf("Expected ErrSentryUnavailable without a Sentry client, got %v", err)
+	}
+
+	service.SetSentryClient(sentry.NewSentryClient("test-token", "govuk", log).WithBaseURL(server.URL))
+
+	project, err := service.GetApplicationSentryProject(context.Background(), "publishing-api")
+	if err != nil {
+		t.Fatalf("GetApplicationSentryProject failed: %v", err)
+	}
+	if project.Slug != "publishing-api" || project.ErrorCount != 7 {
+		t.Errorf("Unexpected Sentry project: %+v", project)
+	}
+
+	detail, err := service.GetApplicationByName(context.Background(), "publishing-api")
+	if err != nil {
+		t.Fatalf("GetApplicationByName failed: %v", err)
+	}
+	if detail.SentryProject == nil || detail.SentryProject.Name != "Publishing API" {
+		t.Errorf("Expected application detail to include its Sentry project, got %+v", detail.SentryProject)
+	}
+
+	govukClient.GetApplicationByNameResult.Links.SentryURL = nil
+	if _, err := service.GetApplicationSentryProject(context.Background(), "publishing-api"); !errors.Is(err, ErrNoSentryProject) {
+		t.Errorf("Expected ErrNoSentryProject for unmatched shortname, got %v", err)
+	}
+}
+
+func TestApplicationService_GetTeamOnCall(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("schedule_ids[]") != "P1234567" {
+			t.Errorf("Unexpected schedule requested: %s", r.URL)
+		}
+		fmt.Fprint(w, `{"oncalls":[{"escalation_level":1,"end":"2026-10-19T09:00:00Z",
+			"schedule":{"id":"P1234567","summary":"Publishing Platform"},
+			"user":{"id":"PFIRST","name":"First Line","email":"first@digital.cabinet-office.gov.uk"}}]}`)
+	}))
+	defer server.Close()
+
+	service := NewApplicationService(&aws.MockCostDataClient{}, &govuk.MockApplicationsClient{}, log)
+	if _, err := service.GetTeamOnCall(context.Background(), "publishing-platform"); !errors.Is(err, ErrPagerDutyUnavailable) {
+		t.Fatalf("Expected ErrPagerDutyUnavailable without a PagerDuty client, got %v", err)
+	}
+
+	service.SetPagerDutyClient(pagerduty.NewClient("test-token", log).WithBaseURL(server.URL), map[string]string{
+		"publishing-platform": "P1234567",
+	})
+
+	schedule, err := service.GetTeamOnCall(context.Background(), "publishing-platform")
+	if err != nil {
+		t.Fatalf("GetTeamOnCall failed: %v", err)
+	}
+	if schedule.CurrentOnCall.Name != "First Line" {
+		t.Errorf("Unexpected on-call schedule: %+v", schedule)
+	}
+
+	if _, err := service.GetTeamOnCall(context.Background(), "search"); !errors.Is(err, ErrNoOnCallSchedule) {
+		t.Errorf("Expected ErrNoOnCallSchedule for unmapped team, got %v", err)
+	}
+}
+
+func TestCalculateAttributionStats(t *testing.T) {
+	apps := []ApplicationSummary{
+		{Name: "Publishing API", TotalCost: 500, CostSource: "real_aws_tags", CostConfidence: "high"},
+		{Name: "Content Store", TotalCost: 300, CostSource: "service_name_match", CostConfidence: "medium"},
+		{Name: "Frontend", TotalCost: 100, CostSource: "estimation", CostConfidence: "low"},
+		{Name: "Search API", TotalCost: 400, CostSource: "estimation", CostConfidence: "low"},
+	}
+	for i := 0; i < 5; i++ {
+		apps = append(apps, ApplicationSummary{Name: fmt.Sprintf("Small App %d", i), TotalCost: 10, CostSource: "estimation", CostConfidence: "low"})
+	}
+
+	stats := calculateAttributionStats(apps, "GBP")
+
+	if stats.HighConfidenceCount != 1 || stats.MediumConfidenceCount != 1 || stats.LowConfidenceCount != 7 {
+		t.Errorf("Unexpected confidence counts: %+v", stats)
+	}
+	if stats.TaggedCount != 1 || stats.EstimatedCount != 7 {
+		t.Errorf("Expected 1 tagged and 7 estimated apps, got %d and %d", stats.TaggedCount, stats.EstimatedCount)
+	}
+	if math.Abs(stats.TagCoveragePercent-100.0/9) > 0.001 {
+		t.Errorf("Expected tag coverage %.2f%%, got %.2f%%", 100.0/9, stats.TagCoveragePercent)
+	}
+	if stats.ImprovementPotentialGBP != 550 {
+		t.Errorf("Expected improvement potential 550, got %v", stats.ImprovementPotentialGBP)
+	}
+
+	if len(stats.TaggingSuggestions) != taggingSuggestionCount {
+		t.Fatalf("Expected %d tagging suggestions, got %d", taggingSuggestionCount, len(stats.TaggingSuggestions))
+	}
+	if stats.TaggingSuggestions[0].Name != "Search API" || stats.TaggingSuggestions[1].Name != "Frontend" {
+		t.Errorf("Expected suggestions sorted by cost, got %s then %s", stats.TaggingSuggestions[0].Name, stats.TaggingSuggestions[1].Name)
+	}
+}
+
+func TestApplicationService_ComputeAggregates(t *testing.T) {
+	apps := []ApplicationSummary{
+		{Name: "Publishing API", Team: "#publishing-platform", ProductionHostedOn: "eks", TotalCost: 500, CostConfidence: "high"},
+		{Name: "Content Store", Team: "#publishing-platform", ProductionHostedOn: "eks", TotalCost: 300, CostConfidence: "medium"},
+		{Name: "Frontend", Team: "#frontend", ProductionHostedOn: "heroku", TotalCost: 100, CostConfidence: "low"},
+	}
+	for i := 0; i < 10; i++ {
+		apps = append(apps, ApplicationSummary{Name: fmt.Sprintf("Small App %d", i), Team: "#frontend", ProductionHostedOn: "eks", TotalCost: 10, CostConfidence: "low"})
+	}
+
+	s := &ApplicationService{}
+	aggregates := s.ComputeAggregates(apps)
+
+	if aggregates.TotalCost != 1000 || aggregates.AverageCost != 1000.0/13 {
+		t.Errorf("Expected total 1000 and average %.2f, got %v and %v", 1000.0/13, aggregates.TotalCost, aggregates.AverageCost)
+	}
+	if aggregates.ByTeam["#publishing-platform"] != 800 || aggregates.ByTeam["#frontend"] != 200 {
+		t.Errorf("Unexpected team breakdown: %v", aggregates.ByTeam)
+	}
+	if aggregates.ByPlatform["eks"] != 900 || aggregates.ByPlatform["heroku"] != 100 {
+		t.Errorf("Unexpected platform breakdown: %v", aggregates.ByPlatform)
+	}
+	if aggregates.ByConfidence["high"] != 1 || aggregates.ByConfidence["low"] != 11 {
+		t.Errorf("Unexpected confidence counts: %v", aggregates.ByConfidence)
+	}
+	if aggregates.MedianCost != 10 {
+		t.Errorf("Expected median 10, got %v", aggregates.MedianCost)
+	}
+	if len(aggregates.TopExpensiveApps) != topExpensiveAppCount || aggregates.TopExpensiveApps[0].Name != "Publishing API" {
+		t.Errorf("Expected top %d apps led by Publishing API, got %+v", topExpensiveAppCount, aggregates.TopExpensiveApps)
+	}
+	if apps[0].Name != "Publishing API" || apps[2].Name != "Frontend" {
+		t.Error("Expected the input to be left in its original order")
+	}
+
+	if median := s.ComputeAggregates(apps[:2]).MedianCost; median != 400 {
+		t.Errorf("Expected median of two apps to be 400, got %v", median)
+	}
+	if empty := s.ComputeAggregates(nil); empty.TotalCost != 0 || empty.ByTeam == nil {
+		t.Errorf("Expected empty aggregates with initialised maps, got %+v", empty)
+	}
+}
+
+func TestGroupCostsByTeam(t *testing.T) {
+	apps := []ApplicationSummary{
+		{Name: "Frontend", Team: "#frontend", TotalCost: 100},
+		{Name: "Publishing API", Team: "#publishing-platform", TotalCost: 500},
+		{Name: "Collections", Team: "#frontend", TotalCost: 150},
+		{Name: "Content Store", Team: "#publishing-platform", TotalCost: 300},
+		{Name: "Search API", Team: "#search", TotalCost: 250},
+		{Name: "Unowned", TotalCost: 40},
+	}
+
+	teams := groupCostsByTeam(apps, "GBP")
+
+	var order []string
+	for _, team := range teams {
+		order = append(order, team.Team)
+	}
+	// #frontend and #search both cost 250, so are ordered by name
+	if want := "#publishing-platform #frontend #search unknown"; strings.Join(order, " ") != want {
+		t.Fatalf("Expected teams sorted by cost %q, got %q", want, strings.Join(order, " "))
+	}
+
+	publishing := teams[0]
+	if publishing.TotalCost != 800 || publishing.ApplicationCount != 2 || publishing.AverageCost != 400 {
+		t.Errorf("Expected #publishing-platform to total 800 over 2 apps, got %+v", publishing)
+	}
+	if publishing.HighestCostApp.Name != "Publishing API" || publishing.Currency != "GBP" {
+		t.Errorf("Expected Publishing API as the most expensive GBP app, got %+v", publishing)
+	}
+
+	frontend := teams[1]
+	if frontend.TotalCost != 250 || frontend.ApplicationCount != 2 || frontend.HighestCostApp.Name != "Collections" {
+		t.Errorf("Expected #frontend to total 250 led by Collections, got %+v", frontend)
+	}
+
+	if unowned := teams[3]; unowned.ApplicationCount != 1 || unowned.HighestCostApp.Name != "Unowned" {
+		t.Errorf("Expected applications without a team grouped under unknown, got %+v", unowned)
+	}
+}
+
+func TestApplicationService_GetCostsByTeam_NoApplications(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	service := NewApplicationService(&aws.MockCostDataClient{Currency: "GBP"}, &govuk.MockApplicationsClient{}, log)
+
+	response, err := service.GetCostsByTeam(context.Background())
+	if err != nil {
+		t.Fatalf("GetCostsByTeam failed: %v", err)
+	}
+	if response.Teams == nil || len(response.Teams) != 0 || response.Count != 0 {
+		t.Errorf("Expected an empty, non-nil team list, got %#v", response.Teams)
+	}
+
+	body, _ := json.Marshal(response)
+	if !strings.Contains(string(body), `"teams":[]`) {
+		t.Errorf("Expected teams to be encoded as an empty array, got %s", body)
+	}
+}
+
+// stubEC2Inventory returns fixed instances
+type stubEC2Inventory []aws.EC2Instance
+
+func (s stubEC2Inventory) GetEC2Instances(ctx context.Context) ([]aws.EC2Instance, error) {
+	return s, nil
+}
+
+func TestApplicationService_BreakDownEC2Cost(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	service := NewApplicationService(&aws.MockCostDataClient{}, &govuk.MockApplicationsClient{}, log)
+	app := govuk.Application{AppName: "Publishing API", Shortname: "publishing-api"}
+	services := []ServiceCost{
+		{ServiceName: "Amazon EC2", Cost: 90, Percentage: 60},
+		{ServiceName: "Amazon RDS", Cost: 60, Percentage: 40},
+	}
+
+	if got := service.breakDownEC2Cost(context.Background(), app, services); len(got) != 2 {
+		t.Errorf("Expected services unchanged without an EC2 inventory, got %+v", got)
+	}
+
+	service.SetEC2Inventory(stubEC2Inventory{
+		{InstanceID: "i-1", InstanceType: "m5.large", Application: "publishing-api", EstimatedHourlyCost: 0.2},
+		{InstanceID: "i-2", InstanceType: "m5.large", Application: "Publishing API", EstimatedHourlyCost: 0.1},
+		{InstanceID: "i-3", InstanceType: "m5.large", Application: "whitehall", EstimatedHourlyCost: 0.1},
+	})
+
+	got := service.breakDownEC2Cost(context.Background(), app, services)
+	if len(got) != 3 {
+		t.Fatalf("Expected two EC2 instances and RDS, got %+v", got)
+	}
+	if got[0].ServiceName != "Amazon EC2 (i-1, m5.large)" || math.Abs(got[0].Cost-60) > 0.001 || math.Abs(got[0].Percentage-40) > 0.001 {
+		t.Errorf("Unexpected first instance entry: %+v", got[0])
+	}
+	if math.Abs(got[1].Cost-30) > 0.001 || got[2].ServiceName != "Amazon RDS" {
+		t.Errorf("Unexpected breakdown: %+v", got)
+	}
+}
+
+func TestApplicationService_GetAllApplications_NamesFilter(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+
+	whitehall := &govuk.Application{AppName: "Whitehall", Shortname: "whitehall"}
+	govukClient := &govuk.MockApplicationsClient{
+		BulkGetApplicationsByNameResult:  map[string]*govuk.Application{"whitehall": whitehall, "Whitehall": whitehall},
+		BulkGetApplicationsByNameMissing: []string{"no-such-app"},
+	}
+
+	service := NewApplicationService(&aws.MockCostDataClient{}, govukClient, log)
+	response, err := service.GetAllApplications(context.Background(), reports.ReportParams{
+		Filters: map[string]interface{}{"names": "whitehall, Whitehall,no-such-app"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("GetAllApplications failed: %v", err)
+	}
+
+	if response.Count != 1 || response.Applications[0].Name != "Whitehall" {
+		t.Errorf("Expected only Whitehall, got %+v", response.Applications)
+	}
+	if len(response.NotFound) != 1 || response.NotFound[0] != "no-such-app" {
+		t.Errorf("Expected no-such-app to be not found, got %v", response.NotFound)
+	}
+	if govukClient.Calls("GetAllApplications") != 0 {
+		t.Error("Expected the names filter not to list every application")
+	}
+}
+
+func TestCalculateCostChange(t *testing.T) {
+	tests := []struct {
+		name              string
+		current, previous float64
+		wantChange        float64
+		wantPercent       float64
+	}{
+		{"new application", 50, 0, 50, 100},
+		{"no cost in either month", 0, 0, 0, 0},
+		{"cost fell", 75, 100, -25, -25},
+		{"cost rose", 120, 100, 20, 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change, percent := calculateCostChange(tt.current, tt.previous)
+			if change != tt.wantChange || percent != tt.wantPercent {
+				t.Errorf("Expected change %v (%v%%), got %v (%v%%)", tt.wantChange, tt.wantPercent, change, percent)
+			}
+		})
+	}
+}
+
+func TestApplicationService_GetAllApplications_PreviousMonthCost(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+
+	whitehall := &govuk.Application{AppName: "Whitehall", Shortname: "whitehall"}
+	publisher := &govuk.Application{AppName: "Publisher", Shortname: "publisher"}
+	govukClient := &govuk.MockApplicationsClient{
+		BulkGetApplicationsByNameResult: map[string]*govuk.Application{"whitehall": whitehall, "publisher": publisher},
+	}
+
+	// Publisher has no cost in the month before the current one
+	awsClient := &aws.MockCostDataClient{
+		GetCostDataForApplicationResult: []common.CostData{{Service: "govuk-whitehall", Amount: 40}},
+		GetCostsBySystemTagResult:       []common.CostData{{Service: "govuk-whitehall", Amount: 32}},
+	}
+
+	service := NewApplicationService(awsClient, govukClient, log)
+	params := reports.ReportParams{
+		Filters: map[string]interface{}{"names": "whitehall,publisher"},
+	}
+	for i := 0; i < 2; i++ {
+		response, err := service.GetAllApplications(context.Background(), params, nil)
+		if err != nil {
+			t.Fatalf("GetAllApplications failed: %v", err)
+		}
+
+		app := response.Applications[0]
+		if app.TotalCost != 40 || app.PreviousMonthCost != 32 || app.CostChange != 8 || app.CostChangePercent != 25 {
+			t.Errorf("Expected a 25%% rise from 32 to 40, got %+v", app)
+		}
+		app = response.Applications[1]
+		if app.TotalCost != 40 || app.PreviousMonthCost != 0 || app.CostChange != 40 || app.CostChangePercent != 100 {
+			t.Errorf("Expected a new application costing 40, got %+v", app)
+		}
+	}
+
+	// Previous month costs are fetched for every application at once, and
+	// cached
+	if calls := awsClient.Calls("GetCostsBySystemTag"); calls != 1 {
+		t.Errorf("Expected 1 previous month lookup, got %d", calls)
+	}
+}
+
+func TestApplicationService_GetAllApplications_PreviousMonthCostOnlyForPage(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+
+	whitehall := &govuk.Application{AppName: "Whitehall", Shortname: "whitehall"}
+	govukClient := &govuk.MockApplicationsClient{
+		BulkGetApplicationsByNameResult: map[string]*govuk.Application{"whitehall": whitehall},
+	}
+	awsClient := &aws.MockCostDataClient{
+		GetCostDataForApplicationResult: []common.CostData{{Service: "govuk-whitehall", Amount: 40}},
+	}
+
+	service := NewApplicationService(awsClient, govukClient, log)
+	response, err := service.GetAllApplications(context.Background(), reports.ReportParams{
+		Filters: map[string]interface{}{"names": "whitehall"},
+	}, &ApplicationFilter{Offset: 1})
+	if err != nil {
+		t.Fatalf("GetAllApplications failed: %v", err)
+	}
+
+	if len(response.Applications) != 0 || response.Total != 1 {
+		t.Errorf("Expected an empty page of 1 application, got %+v", response)
+	}
+	if calls := awsClient.Calls("GetCostsBySystemTag"); calls != 0 {
+		t.Errorf("Expected no previous month lookup for an empty page, got %d", calls)
+	}
+}
+
+func TestApplicationService_GetApplicationCostContext(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+
+	govukClient := &govuk.MockApplicationsClient{
+		GetApplicationByNameResult: &govuk.Application{AppName: "Whitehall", Shortname: "whitehall", Team: "#govuk-whitehall"},
+	}
+	service := NewApplicationService(&aws.MockCostDataClient{Currency: "GBP"}, govukClient, log)
+
+	// Without RDS and ElastiCache services the context has no infrastructure
+	costContext, err := service.GetApplicationCostContext(context.Background(), "whitehall")
+	if err != nil {
+		t.Fatalf("GetApplicationCostContext failed: %v", err)
+	}
+	if costContext.Application.Team != "#govuk-whitehall" || costContext.CostSummary.Name != "Whitehall" {
+		t.Errorf("Unexpected cost context: %+v", costContext)
+	}
+	if costContext.InfrastructureAvailable || len(costContext.InfrastructureItems) != 0 {
+		t.Errorf("Expected no infrastructure, got %+v", costContext.InfrastructureItems)
+	}
+
+	govukClient.GetApplicationByNameResult = nil
+	govukClient.GetApplicationByNameErr = govuk.ErrNotFound
+	if _, err := service.GetApplicationCostContext(context.Background(), "no-such-app"); !errors.Is(err, govuk.ErrNotFound) {
+		t.Errorf("Expected govuk.ErrNotFound, got %v", err)
+	}
+}
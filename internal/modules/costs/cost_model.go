@@ -0,0 +1,164 @@
+package costs
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed cost_model_defaults.yaml
+var defaultCostModelYAML []byte
+
+// CostEstimationModel holds the parameters used to estimate the cost of
+// applications that cannot be matched to AWS cost data
+type CostEstimationModel struct {
+	BaseCostGBP           float64            `yaml:"base_cost_gbp"`
+	TeamMultipliers       map[string]float64 `yaml:"team_multipliers"`
+	PlatformMultipliers   map[string]float64 `yaml:"platform_multipliers"`
+	AppTypeMultipliers    map[string]float64 `yaml:"app_type_multipliers"`
+	ComplexityMultipliers []ComplexityGroup  `yaml:"complexity_multipliers"`
+}
+
+// ComplexityGroup applies Multiplier once to applications whose name
+// contains any of Keywords, however many of them match
+type ComplexityGroup struct {
+	Keywords   []string `yaml:"keywords"`
+	Multiplier float64  `yaml:"multiplier"`
+}
+
+// DefaultCostEstimationModel returns the built-in cost estimation model
+func DefaultCostEstimationModel() *CostEstimationModel {
+	model := &CostEstimationModel{}
+	if err := yaml.Unmarshal(defaultCostModelYAML, model); err != nil {
+		panic(fmt.Sprintf("invalid embedded cost model: %v", err))
+	}
+	return model
+}
+
+// LoadCostEstimationModel returns the default cost estimation model with any
+// values in the YAML file at path applied over it. An empty path returns the
+// defaults.
+func LoadCostEstimationModel(path string) (*CostEstimationModel, error) {
+	model := DefaultCostEstimationModel()
+	if path == "" {
+		return model, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cost model %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, model); err != nil {
+		return nil, fmt.Errorf("failed to parse cost model %s: %w", path, err)
+	}
+
+	if err := model.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid cost model %s: %w", path, err)
+	}
+
+	return model, nil
+}
+
+// Validate checks the base cost and all multipliers are positive
+func (m *CostEstimationModel) Validate() error {
+	if m.BaseCostGBP <= 0 {
+		return fmt.Errorf("base_cost_gbp must be greater than 0, got %v", m.BaseCostGBP)
+	}
+
+	multipliers := []struct {
+		name   string
+		values map[string]float64
+	}{
+		{"team_multipliers", m.TeamMultipliers},
+		{"platform_multipliers", m.PlatformMultipliers},
+		{"app_type_multipliers", m.AppTypeMultipliers},
+	}
+	for _, group := range multipliers {
+		for _, key := range sortedKeys(group.values) {
+			if group.values[key] <= 0 {
+				return fmt.Errorf("%s[%q] must be greater than 0, got %v", group.name, key, group.values[key])
+			}
+		}
+	}
+
+	for i, group := range m.ComplexityMultipliers {
+		if group.Multiplier <= 0 {
+			return fmt.Errorf("complexity_multipliers[%d].multiplier must be greater than 0, got %v", i, group.Multiplier)
+		}
+		if len(group.Keywords) == 0 {
+			return fmt.Errorf("complexity_multipliers[%d].keywords must not be empty", i)
+		}
+	}
+
+	return nil
+}
+
+// TeamMultiplier returns the multiplier for a team, or 1.0 for unknown teams
+func (m *CostEstimationModel) TeamMultiplier(team string) float64 {
+	if multiplier, exists := m.TeamMultipliers[team]; exists {
+		return multiplier
+	}
+	return 1.0
+}
+
+// PlatformMultiplier returns the multiplier for a hosting platform, or 1.0
+// for unknown platforms
+func (m *CostEstimationModel) PlatformMultiplier(platform string) float64 {
+	platform = strings.ToLower(platform)
+	for name, multiplier := range m.PlatformMultipliers {
+		if strings.ToLower(name) == platform {
+			return multiplier
+		}
+	}
+	return 1.0
+}
+
+// AppTypeMultiplier returns the product of the app type multipliers whose
+// keyword appears in the application name
+func (m *CostEstimationModel) AppTypeMultiplier(appName string) float64 {
+	return keywordMultiplier(m.AppTypeMultipliers, appName)
+}
+
+// ComplexityMultiplier returns the product of the multipliers of the
+// complexity groups with a keyword in the application name. Each group is
+// applied once, however many of its keywords match.
+func (m *CostEstimationModel) ComplexityMultiplier(appName string) float64 {
+	appNameLower := strings.ToLower(appName)
+
+	result := 1.0
+	for _, group := range m.ComplexityMultipliers {
+		for _, keyword := range group.Keywords {
+			if strings.Contains(appNameLower, strings.ToLower(keyword)) {
+				result *= group.Multiplier
+				break
+			}
+		}
+	}
+	return result
+}
+
+func keywordMultiplier(multipliers map[string]float64, appName string) float64 {
+	appNameLower := strings.ToLower(appName)
+
+	// Keys are sorted so the result doesn't depend on map order
+	result := 1.0
+	for _, keyword := range sortedKeys(multipliers) {
+		if strings.Contains(appNameLower, strings.ToLower(keyword)) {
+			result *= multipliers[keyword]
+		}
+	}
+	return result
+}
+
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
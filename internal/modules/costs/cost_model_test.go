@@ -0,0 +1,84 @@
+package costs
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDefaultCostEstimationModel(t *testing.T) {
+	model := DefaultCostEstimationModel()
+	if err := model.Validate(); err != nil {
+		t.Fatalf("Default model is invalid: %v", err)
+	}
+
+	if model.BaseCostGBP != 150 {
+		t.Errorf("Expected base cost 150, got %v", model.BaseCostGBP)
+	}
+	if got := model.TeamMultiplier("GOV.UK Platform"); got != 1.4 {
+		t.Errorf("Expected GOV.UK Platform multiplier 1.4, got %v", got)
+	}
+	if got := model.TeamMultiplier("Unknown Team"); got != 1.0 {
+		t.Errorf("Expected unknown team multiplier 1.0, got %v", got)
+	}
+	if got := model.PlatformMultiplier("EKS"); got != 1.6 {
+		t.Errorf("Expected EKS multiplier 1.6, got %v", got)
+	}
+	if got := model.AppTypeMultiplier("Search API"); math.Abs(got-1.95) > 1e-9 {
+		t.Errorf("Expected Search API multiplier 1.95, got %v", got)
+	}
+	if got := model.ComplexityMultiplier("Router"); got != 1.4 {
+		t.Errorf("Expected Router complexity 1.4, got %v", got)
+	}
+}
+
+func TestCostEstimationModel_ComplexityMultiplier_OncePerGroup(t *testing.T) {
+	model := DefaultCostEstimationModel()
+
+	// "docs" and "guide" are in the same group, "frontend" in another
+	if got := model.ComplexityMultiplier("docs-guide"); math.Abs(got-0.6) > 1e-9 {
+		t.Errorf("Expected docs-guide complexity 0.6, got %v", got)
+	}
+	if got := model.ComplexityMultiplier("frontend-docs"); math.Abs(got-0.72) > 1e-9 {
+		t.Errorf("Expected frontend-docs complexity 0.72, got %v", got)
+	}
+}
+
+func TestLoadCostEstimationModel_Overrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cost_model.yaml")
+	content := "base_cost_gbp: 200\nteam_multipliers:\n  Content: 1.5\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write cost model: %v", err)
+	}
+
+	model, err := LoadCostEstimationModel(path)
+	if err != nil {
+		t.Fatalf("LoadCostEstimationModel failed: %v", err)
+	}
+
+	if model.BaseCostGBP != 200 {
+		t.Errorf("Expected base cost 200, got %v", model.BaseCostGBP)
+	}
+	if got := model.TeamMultiplier("Content"); got != 1.5 {
+		t.Errorf("Expected overridden Content multiplier 1.5, got %v", got)
+	}
+	if got := model.TeamMultiplier("Design System"); got != 0.8 {
+		t.Errorf("Expected default Design System multiplier 0.8 to be kept, got %v", got)
+	}
+}
+
+func TestLoadCostEstimationModel_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cost_model.yaml")
+	if err := os.WriteFile(path, []byte("platform_multipliers:\n  eks: 0\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write cost model: %v", err)
+	}
+
+	if _, err := LoadCostEstimationModel(path); err == nil {
+		t.Error("Expected error for zero multiplier")
+	}
+
+	if _, err := LoadCostEstimationModel(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
@@ -1,15 +1,29 @@
 package costs
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
+	"govuk-reports-dashboard/internal/handlers"
 	"govuk-reports-dashboard/internal/models"
+	"govuk-reports-dashboard/internal/reports"
+	"govuk-reports-dashboard/pkg/govuk"
 	"govuk-reports-dashboard/pkg/logger"
+	"govuk-reports-dashboard/pkg/pagerduty"
 
 	"github.com/gin-gonic/gin"
 )
 
+// statusClientClosedRequest is the non-standard status, from nginx, for a
+// request the client gave up on before it was answered
+const statusClientClosedRequest = 499
+
 type CostHandler struct {
 	costService *CostService
 	logger      *logger.Logger
@@ -23,11 +37,12 @@ func NewCostHandler(costService *CostService, log *logger.Logger) *CostHandler {
 }
 
 func (h *CostHandler) GetCostSummary(c *gin.Context) {
-	h.logger.Info().Msg("Fetching cost summary")
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Fetching cost summary")
 
-	summary, err := h.costService.GetCostSummary()
+	summary, err := h.costService.GetCostSummary(c.Request.Context())
 	if err != nil {
-		h.logger.WithError(err).Error().Msg("Failed to fetch cost summary")
+		log.WithError(err).Error().Msg("Failed to fetch cost summary")
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "internal_server_error",
 			Message: "Failed to fetch cost summary",
@@ -42,6 +57,102 @@ func (h *CostHandler) GetCostSummary(c *gin.Context) {
 	})
 }
 
+type ForecastHandler struct {
+	costService *CostService
+	logger      *logger.Logger
+}
+
+func NewForecastHandler(costService *CostService, log *logger.Logger) *ForecastHandler {
+	return &ForecastHandler{
+		costService: costService,
+		logger:      log,
+	}
+}
+
+// GetCostForecast handles GET /api/costs/forecast
+// days sets how many days ahead to forecast, and defaults to the rest of
+// the current month.
+func (h *ForecastHandler) GetCostForecast(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Fetching cost forecast")
+
+	days := daysUntilMonthEnd(time.Now().UTC())
+	if raw := c.Query("days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < MinForecastDays || parsed > MaxForecastDays {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "bad_request",
+				Message: fmt.Sprintf("days must be a number between %d and %d", MinForecastDays, MaxForecastDays),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		days = parsed
+	}
+
+	forecast, err := h.costService.GetCostForecast(c.Request.Context(), days)
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to fetch cost forecast")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to fetch cost forecast",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	message := "Cost forecast retrieved successfully"
+	if !forecast.Available {
+		message = forecast.Message
+	}
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Data:    forecast,
+		Message: message,
+	})
+}
+
+type TeamCostHandler struct {
+	applicationService *ApplicationService
+	logger             *logger.Logger
+}
+
+func NewTeamCostHandler(applicationService *ApplicationService, log *logger.Logger) *TeamCostHandler {
+	return &TeamCostHandler{
+		applicationService: applicationService,
+		logger:             log,
+	}
+}
+
+// GetTeamCosts handles GET /api/costs/teams
+func (h *TeamCostHandler) GetTeamCosts(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for team costs")
+
+	response, err := h.applicationService.GetCostsByTeam(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to fetch team costs")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to fetch team costs",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	log.WithField("team_count", response.Count).Info().Msg("Successfully fetched team costs")
+	c.JSON(http.StatusOK, response)
+}
+
+// TeamsPage handles GET /teams - serves the team costs page
+func (h *TeamCostHandler) TeamsPage(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Serving team costs page")
+
+	c.HTML(http.StatusOK, "teams.html", gin.H{
+		"title": "Team Costs - GOV.UK Reports Dashboard",
+	})
+}
+
 type ApplicationHandler struct {
 	applicationService *ApplicationService
 	logger             *logger.Logger
@@ -55,12 +166,66 @@ func NewApplicationHandler(applicationService *ApplicationService, log *logger.L
 }
 
 // GetApplications handles GET /api/applications
+// Pass include_trend=true to add a 6-month cost sparkline to each application,
+// include_team_contacts=true to add the owning team's contact details, and
+// names=<app>,<app> to only return those applications. Results are paged
+// with limit and offset, and can be sorted and filtered; see
+// parseApplicationFilter.
 func (h *ApplicationHandler) GetApplications(c *gin.Context) {
-	h.logger.Info().Msg("Handling request for all applications")
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for all applications")
+
+	filter, err := parseApplicationFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "bad_request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	params := reports.ReportParams{
+		Filters: map[string]interface{}{},
+	}
+	if c.Query("include_trend") == "true" {
+		params.Filters["include_trend"] = "true"
+	}
+	if c.Query("include_team_contacts") == "true" {
+		params.Filters["include_team_contacts"] = "true"
+	}
+	if names := c.Query("names"); names != "" {
+		params.Filters["names"] = names
+	}
+	if regex := c.Query("regex"); regex != "" {
+		params.Filters["regex"] = regex
+	}
 
-	applications, err := h.applicationService.GetAllApplications(c.Request.Context())
+	applications, err := h.applicationService.GetAllApplications(c.Request.Context(), params, filter)
+	if errors.Is(err, govuk.ErrInvalidSearchPattern) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "bad_request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if errors.Is(err, context.Canceled) {
+		log.Debug().Msg("Client cancelled the applications request")
+		c.AbortWithStatus(statusClientClosedRequest)
+		return
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		log.WithError(err).Warn().Msg("Timed out fetching applications")
+		c.JSON(http.StatusGatewayTimeout, models.ErrorResponse{
+			Error:   "gateway_timeout",
+			Message: "Timed out fetching applications",
+			Code:    http.StatusGatewayTimeout,
+		})
+		return
+	}
 	if err != nil {
-		h.logger.WithError(err).Error().Msg("Failed to fetch applications")
+		log.WithError(err).Error().Msg("Failed to fetch applications")
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "internal_server_error",
 			Message: "Failed to fetch applications",
@@ -69,12 +234,145 @@ func (h *ApplicationHandler) GetApplications(c *gin.Context) {
 		return
 	}
 
-	h.logger.WithField("app_count", applications.Count).Info().Msg("Successfully fetched applications")
+	log.WithField("app_count", applications.Count).Info().Msg("Successfully fetched applications")
 	c.JSON(http.StatusOK, applications)
 }
 
+// parseApplicationFilter reads the /api/applications query parameters:
+// limit (default DefaultApplicationLimit, at most MaxApplicationLimit),
+// offset, sort (one of ApplicationSortFields), order (asc or desc), team and
+// hosting (exact matches) and search (part of a name or shortname)
+func parseApplicationFilter(c *gin.Context) (*ApplicationFilter, error) {
+	filter := &ApplicationFilter{
+		Team:    c.Query("team"),
+		Hosting: c.Query("hosting"),
+		Search:  strings.TrimSpace(c.Query("search")),
+		Sort:    c.Query("sort"),
+		Order:   c.DefaultQuery("order", "asc"),
+		Limit:   DefaultApplicationLimit,
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value < 1 {
+			return nil, fmt.Errorf("limit must be a positive integer")
+		}
+		filter.Limit = min(value, MaxApplicationLimit)
+	}
+
+	if offset := c.Query("offset"); offset != "" {
+		value, err := strconv.Atoi(offset)
+		if err != nil || value < 0 {
+			return nil, fmt.Errorf("offset must be a non-negative integer")
+		}
+		filter.Offset = value
+	}
+
+	if filter.Sort != "" && !slices.Contains(ApplicationSortFields, filter.Sort) {
+		return nil, fmt.Errorf("sort must be one of %s", strings.Join(ApplicationSortFields, ", "))
+	}
+
+	if filter.Order != "asc" && filter.Order != "desc" {
+		return nil, fmt.Errorf("order must be asc or desc")
+	}
+
+	return filter, nil
+}
+
+// GetApplicationStats handles GET /api/applications/stats
+func (h *ApplicationHandler) GetApplicationStats(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for application stats")
+
+	stats, err := h.applicationService.GetHostingStats(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to fetch application stats")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to fetch application stats",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// GetTeams handles GET /api/teams
+func (h *ApplicationHandler) GetTeams(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for teams")
+
+	teams, err := h.applicationService.GetAllTeams(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to fetch teams",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"teams": teams,
+		"count": len(teams),
+	})
+}
+
+// GetTeamOnCall handles GET /api/teams/{team}/on-call
+func (h *ApplicationHandler) GetTeamOnCall(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	team := c.Param("team")
+
+	log.WithField("team", team).Info().Msg("Handling request for team on-call schedule")
+
+	schedule, err := h.applicationService.GetTeamOnCall(c.Request.Context(), team)
+	if err != nil {
+		if errors.Is(err, ErrPagerDutyUnavailable) {
+			c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+				Error:   "service_unavailable",
+				Message: "PagerDuty integration is not configured",
+				Code:    http.StatusServiceUnavailable,
+			})
+			return
+		}
+
+		if errors.Is(err, ErrNoOnCallSchedule) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error:   "not_found",
+				Message: "Team has no PagerDuty schedule",
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+
+		if errors.Is(err, pagerduty.ErrNoOnCall) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error:   "not_found",
+				Message: "Nobody is on call for this team",
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+
+		log.WithError(err).Error().Msg("Failed to fetch team on-call schedule")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to fetch team on-call schedule",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"team":     team,
+		"schedule": schedule,
+	})
+}
+
 // GetApplication handles GET /api/applications/{name}
 func (h *ApplicationHandler) GetApplication(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
 	name := c.Param("name")
 	if name == "" {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -85,11 +383,11 @@ func (h *ApplicationHandler) GetApplication(c *gin.Context) {
 		return
 	}
 
-	h.logger.WithField("app_name", name).Info().Msg("Handling request for specific application")
+	log.WithField("app_name", name).Info().Msg("Handling request for specific application")
 
 	application, err := h.applicationService.GetApplicationByName(c.Request.Context(), name)
 	if err != nil {
-		if strings.Contains(err.Error(), "application not found") {
+		if errors.Is(err, govuk.ErrNotFound) {
 			c.JSON(http.StatusNotFound, models.ErrorResponse{
 				Error:   "not_found",
 				Message: "Application not found",
@@ -98,7 +396,7 @@ func (h *ApplicationHandler) GetApplication(c *gin.Context) {
 			return
 		}
 
-		h.logger.WithError(err).Error().Msg("Failed to fetch application")
+		log.WithError(err).Error().Msg("Failed to fetch application")
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "internal_server_error",
 			Message: "Failed to fetch application",
@@ -107,12 +405,13 @@ func (h *ApplicationHandler) GetApplication(c *gin.Context) {
 		return
 	}
 
-	h.logger.WithField("app_name", name).Info().Msg("Successfully fetched application")
+	log.WithField("app_name", name).Info().Msg("Successfully fetched application")
 	c.JSON(http.StatusOK, application)
 }
 
 // GetApplicationServices handles GET /api/applications/{name}/services
 func (h *ApplicationHandler) GetApplicationServices(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
 	name := c.Param("name")
 	if name == "" {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -123,11 +422,11 @@ func (h *ApplicationHandler) GetApplicationServices(c *gin.Context) {
 		return
 	}
 
-	h.logger.WithField("app_name", name).Info().Msg("Handling request for application services")
+	log.WithField("app_name", name).Info().Msg("Handling request for application services")
 
 	services, err := h.applicationService.GetApplicationServices(c.Request.Context(), name)
 	if err != nil {
-		if strings.Contains(err.Error(), "application not found") {
+		if errors.Is(err, govuk.ErrNotFound) {
 			c.JSON(http.StatusNotFound, models.ErrorResponse{
 				Error:   "not_found",
 				Message: "Application not found",
@@ -136,7 +435,7 @@ func (h *ApplicationHandler) GetApplicationServices(c *gin.Context) {
 			return
 		}
 
-		h.logger.WithError(err).Error().Msg("Failed to fetch application services")
+		log.WithError(err).Error().Msg("Failed to fetch application services")
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "internal_server_error",
 			Message: "Failed to fetch application services",
@@ -151,7 +450,7 @@ func (h *ApplicationHandler) GetApplicationServices(c *gin.Context) {
 		"count":       len(services),
 	}
 
-	h.logger.WithFields(map[string]interface{}{
+	log.WithFields(map[string]interface{}{
 		"app_name":      name,
 		"service_count": len(services),
 	}).Info().Msg("Successfully fetched application services")
@@ -159,9 +458,172 @@ func (h *ApplicationHandler) GetApplicationServices(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// GetApplicationInfrastructure handles GET /api/applications/{name}/infrastructure
+func (h *ApplicationHandler) GetApplicationInfrastructure(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	name := c.Param("name")
+	if name == "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "bad_request",
+			Message: "Application name is required",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	log.WithField("app_name", name).Info().Msg("Handling request for application infrastructure")
+
+	infrastructure, err := h.applicationService.GetApplicationInfrastructure(c.Request.Context(), name)
+	if err != nil {
+		if errors.Is(err, ErrInfrastructureUnavailable) {
+			c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+				Error:   "service_unavailable",
+				Message: "Infrastructure inventory is unavailable",
+				Code:    http.StatusServiceUnavailable,
+			})
+			return
+		}
+
+		if errors.Is(err, govuk.ErrNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error:   "not_found",
+				Message: "Application not found",
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+
+		log.WithError(err).Error().Msg("Failed to fetch application infrastructure")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to fetch application infrastructure",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, infrastructure)
+}
+
+// GetApplicationSentry handles GET /api/applications/{name}/sentry
+func (h *ApplicationHandler) GetApplicationSentry(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	name := c.Param("name")
+	if name == "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "bad_request",
+			Message: "Application name is required",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	log.WithField("app_name", name).Info().Msg("Handling request for application Sentry project")
+
+	project, err := h.applicationService.GetApplicationSentryProject(c.Request.Context(), name)
+	if err != nil {
+		if errors.Is(err, ErrSentryUnavailable) {
+			c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+				Error:   "service_unavailable",
+				Message: "Sentry integration is not configured",
+				Code:    http.StatusServiceUnavailable,
+			})
+			return
+		}
+
+		if errors.Is(err, govuk.ErrNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error:   "not_found",
+				Message: "Application not found",
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+
+		if errors.Is(err, ErrNoSentryProject) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error:   "not_found",
+				Message: "Application has no Sentry project",
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+
+		log.WithError(err).Error().Msg("Failed to fetch application Sentry project")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to fetch application Sentry project",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+// GetApplicationCostContext handles GET /api/bff/applications/{name}, which
+// returns the application, its costs and its infrastructure in one response
+// for the application detail page
+func (h *ApplicationHandler) GetApplicationCostContext(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	name := c.Param("name")
+	if name == "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "bad_request",
+			Message: "Application name is required",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	log.WithField("app_name", name).Info().Msg("Handling request for application cost context")
+
+	costContext, err := h.applicationService.GetApplicationCostContext(c.Request.Context(), name)
+	if err != nil {
+		if errors.Is(err, govuk.ErrNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error:   "not_found",
+				Message: "Application not found",
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+
+		log.WithError(err).Error().Msg("Failed to fetch application cost context")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to fetch application cost context",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, costContext)
+}
+
+// GetAttributionStats handles GET /api/costs/attribution-stats
+func (h *ApplicationHandler) GetAttributionStats(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for cost attribution stats")
+
+	stats, err := h.applicationService.GetAttributionStats(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to calculate cost attribution stats")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to calculate cost attribution stats",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
 // GetApplicationsPage handles GET / - serves the main dashboard page
 func (h *ApplicationHandler) GetApplicationsPage(c *gin.Context) {
-	h.logger.Info().Msg("Serving applications dashboard page")
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Serving applications dashboard page")
 	
 	c.HTML(http.StatusOK, "applications.html", gin.H{
 		"title": "GOV.UK Reports Dashboard",
@@ -170,6 +632,7 @@ func (h *ApplicationHandler) GetApplicationsPage(c *gin.Context) {
 
 // GetApplicationPage handles GET /applications/{name} - serves individual application page
 func (h *ApplicationHandler) GetApplicationPage(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
 	name := c.Param("name")
 	if name == "" {
 		c.HTML(http.StatusBadRequest, "error.html", gin.H{
@@ -179,7 +642,7 @@ func (h *ApplicationHandler) GetApplicationPage(c *gin.Context) {
 		return
 	}
 
-	h.logger.WithField("app_name", name).Info().Msg("Serving application detail page")
+	log.WithField("app_name", name).Info().Msg("Serving application detail page")
 	
 	c.HTML(http.StatusOK, "application-detail.html", gin.H{
 		"title":           "GOV.UK Reports Dashboard - " + name,
@@ -0,0 +1,293 @@
+package costs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"govuk-reports-dashboard/pkg/aws"
+	"govuk-reports-dashboard/pkg/common"
+	"govuk-reports-dashboard/pkg/govuk"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestApplicationHandler_GetApplications_Filters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+
+	govukClient := &govuk.MockApplicationsClient{
+		GetAllApplicationsResult: []govuk.Application{
+			{AppName: "publisher", Shortname: "publisher", Team: "#govuk-publishing-platform", ProductionHostedOn: "eks"},
+			{AppName: "whitehall-admin", Shortname: "whitehall", Team: "#govuk-whitehall", ProductionHostedOn: "eks"},
+			{AppName: "content-store", Shortname: "content-store", Team: "#govuk-publishing-platform", ProductionHostedOn: "eks"},
+			{AppName: "Signon", Shortname: "signon-rails", Team: "#govuk-platform-security", ProductionHostedOn: "ec2"},
+		},
+	}
+	// Each application matches one service by name, giving it a fixed cost
+	awsClient := &aws.MockCostDataClient{
+		GetCostDataResult: []common.CostData{
+			{Service: "publisher", Amount: 30},
+			{Service: "whitehall", Amount: 10},
+			{Service: "content-store", Amount: 20},
+			{Service: "signon", Amount: 40},
+		},
+	}
+
+	handler := NewApplicationHandler(NewApplicationService(awsClient, govukClient, log), log)
+	router := gin.New()
+	router.GET("/api/applications", handler.GetApplications)
+
+	tests := []struct {
+		name          string
+		query         string
+		wantStatus    int
+		wantApps      []string
+		wantTotal     int
+		wantLimit     int
+		wantHasMore   bool
+		wantTotalCost float64
+	}{
+		{
+			name:          "defaults",
+			wantStatus:    http.StatusOK,
+			wantApps:      []string{"publisher", "whitehall-admin", "content-store", "Signon"},
+			wantTotal:     4,
+			wantLimit:     DefaultApplicationLimit,
+			wantTotalCost: 100,
+		},
+		{
+			name:          "team",
+			query:         "team=%23govuk-publishing-platform",
+			wantStatus:    http.StatusOK,
+			wantApps:      []string{"publisher", "content-store"},
+			wantTotal:     2,
+			wantLimit:     DefaultApplicationLimit,
+			wantTotalCost: 50,
+		},
+		{
+			name:          "hosting",
+			query:         "hosting=ec2",
+			wantStatus:    http.StatusOK,
+			wantApps:      []string{"Signon"},
+			wantTotal:     1,
+			wantLimit:     DefaultApplicationLimit,
+			wantTotalCost: 40,
+		},
+		{
+			name:          "search matches shortname ignoring case",
+			query:         "search=RAILS",
+			wantStatus:    http.StatusOK,
+			wantApps:      []string{"Signon"},
+			wantTotal:     1,
+			wantLimit:     DefaultApplicationLimit,
+			wantTotalCost: 40,
+		},
+		{
+			name:          "search matches name",
+			query:         "search=admin",
+			wantStatus:    http.StatusOK,
+			wantApps:      []string{"whitehall-admin"},
+			wantTotal:     1,
+			wantLimit:     DefaultApplicationLimit,
+			wantTotalCost: 10,
+		},
+		{
+			name:          "sort by name",
+			query:         "sort=name",
+			wantStatus:    http.StatusOK,
+			wantApps:      []string{"content-store", "publisher", "Signon", "whitehall-admin"},
+			wantTotal:     4,
+			wantLimit:     DefaultApplicationLimit,
+			wantTotalCost: 100,
+		},
+		{
+			name:          "sort by cost descending",
+			query:         "sort=cost&order=desc",
+			wantStatus:    http.StatusOK,
+			wantApps:      []string{"Signon", "publisher", "content-store", "whitehall-admin"},
+			wantTotal:     4,
+			wantLimit:     DefaultApplicationLimit,
+			wantTotalCost: 100,
+		},
+		{
+			name:          "page",
+			query:         "sort=cost&limit=2&offset=1",
+			wantStatus:    http.StatusOK,
+			wantApps:      []string{"content-store", "publisher"},
+			wantTotal:     4,
+			wantLimit:     2,
+			wantHasMore:   true,
+			wantTotalCost: 100,
+		},
+		{
+			name:          "last page",
+			query:         "limit=2&offset=2",
+			wantStatus:    http.StatusOK,
+			wantApps:      []string{"content-store", "Signon"},
+			wantTotal:     4,
+			wantLimit:     2,
+			wantTotalCost: 100,
+		},
+		{
+			name:          "offset beyond total",
+			query:         "offset=10",
+			wantStatus:    http.StatusOK,
+			wantApps:      []string{},
+			wantTotal:     4,
+			wantLimit:     DefaultApplicationLimit,
+			wantTotalCost: 100,
+		},
+		{
+			name:          "limit above maximum",
+			query:         "limit=1000",
+			wantStatus:    http.StatusOK,
+			wantApps:      []string{"publisher", "whitehall-admin", "content-store", "Signon"},
+			wantTotal:     4,
+			wantLimit:     MaxApplicationLimit,
+			wantTotalCost: 100,
+		},
+		{
+			name:          "combined filters",
+			query:         "team=%23govuk-publishing-platform&hosting=eks&search=S&sort=cost&order=desc&limit=1",
+			wantStatus:    http.StatusOK,
+			wantApps:      []string{"publisher"},
+			wantTotal:     2,
+			wantLimit:     1,
+			wantHasMore:   true,
+			wantTotalCost: 50,
+		},
+		{name: "invalid sort field", query: "sort=size", wantStatus: http.StatusBadRequest},
+		{name: "invalid order", query: "sort=name&order=up", wantStatus: http.StatusBadRequest},
+		{name: "zero limit", query: "limit=0", wantStatus: http.StatusBadRequest},
+		{name: "non-numeric limit", query: "limit=all", wantStatus: http.StatusBadRequest},
+		{name: "negative offset", query: "offset=-1", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/applications?"+tt.query, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var response ApplicationListResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+
+			var names []string
+			for _, app := range response.Applications {
+				names = append(names, app.Name)
+			}
+			if len(names) != len(tt.wantApps) {
+				t.Fatalf("Expected applications %v, got %v", tt.wantApps, names)
+			}
+			for i := range names {
+				if names[i] != tt.wantApps[i] {
+					t.Fatalf("Expected applications %v, got %v", tt.wantApps, names)
+				}
+			}
+
+			if response.Count != len(tt.wantApps) || response.Total != tt.wantTotal || response.Limit != tt.wantLimit || response.HasMore != tt.wantHasMore {
+				t.Errorf("Expected count %d, total %d, limit %d, has_more %v, got %d, %d, %d, %v",
+					len(tt.wantApps), tt.wantTotal, tt.wantLimit, tt.wantHasMore, response.Count, response.Total, response.Limit, response.HasMore)
+			}
+			if response.TotalCost != tt.wantTotalCost {
+				t.Errorf("Expected total cost %v across all matches, got %v", tt.wantTotalCost, response.TotalCost)
+			}
+		})
+	}
+}
+
+func TestApplicationHandler_GetApplications_SearchErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+
+	tests := []struct {
+		err        error
+		wantStatus int
+	}{
+		{fmt.Errorf("%w: compilation timed out", govuk.ErrInvalidSearchPattern), http.StatusBadRequest},
+		{context.Canceled, statusClientClosedRequest},
+		{context.DeadlineExceeded, http.StatusGatewayTimeout},
+	}
+
+	for _, tt := range tests {
+		govukClient := &govuk.MockApplicationsClient{SearchApplicationsRegexErr: tt.err}
+		handler := NewApplicationHandler(NewApplicationService(&aws.MockCostDataClient{}, govukClient, log), log)
+		router := gin.New()
+		router.GET("/api/applications", handler.GetApplications)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/applications?regex=api", nil))
+		if w.Code != tt.wantStatus {
+			t.Errorf("Expected status %d for %v, got %d", tt.wantStatus, tt.err, w.Code)
+		}
+	}
+}
+
+func TestForecastHandler_GetCostForecast(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+
+	awsClient := &aws.MockCostDataClient{
+		GetCostForecastResult: &common.CostForecast{MeanCost: 2500.75, LowerBound: 2000, UpperBound: 3000, Currency: "GBP"},
+	}
+	handler := NewForecastHandler(NewCostService(awsClient, nil, log), log)
+	router := gin.New()
+	router.GET("/api/costs/forecast", handler.GetCostForecast)
+
+	tests := []struct {
+		name          string
+		query         string
+		forecastErr   error
+		wantStatus    int
+		wantAvailable bool
+		wantMean      float64
+	}{
+		{name: "default period", wantStatus: http.StatusOK, wantAvailable: true, wantMean: 2500.75},
+		{name: "days", query: "days=90", wantStatus: http.StatusOK, wantAvailable: true, wantMean: 2500.75},
+		{name: "insufficient data", forecastErr: aws.ErrInsufficientForecastData, wantStatus: http.StatusOK},
+		{name: "aws error", forecastErr: errors.New("access denied"), wantStatus: http.StatusInternalServerError},
+		{name: "invalid days", query: "days=abc", wantStatus: http.StatusBadRequest},
+		{name: "too many days", query: "days=366", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			awsClient.GetCostForecastErr = tt.forecastErr
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/costs/forecast?"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Data CostForecast `json:"data"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if response.Data.Available != tt.wantAvailable || response.Data.MeanCost != tt.wantMean {
+				t.Errorf("Unexpected forecast %+v", response.Data)
+			}
+		})
+	}
+}
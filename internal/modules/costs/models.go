@@ -3,23 +3,43 @@ package costs
 import (
 	"time"
 
+	"govuk-reports-dashboard/internal/modules/elasticache"
+	"govuk-reports-dashboard/internal/modules/rds"
+	"govuk-reports-dashboard/internal/reports"
 	"govuk-reports-dashboard/pkg/govuk"
 	"govuk-reports-dashboard/pkg/common"
+	"govuk-reports-dashboard/pkg/sentry"
 )
 
-// CostData is imported from pkg/common
-type CostData = common.CostData
-
 // CostSummary represents a summary of costs across services
 type CostSummary struct {
 	TotalCost     float64    `json:"total_cost"`
 	Currency      string     `json:"currency"`
 	PeriodStart   time.Time  `json:"period_start"`
 	PeriodEnd     time.Time  `json:"period_end"`
-	Services      []CostData `json:"services"`
+	Services      []common.CostData `json:"services"`
 	LastUpdated   time.Time  `json:"last_updated"`
+	// PreviousMonthCost is what the same services cost in the month before
+	// PeriodStart. Trend is unset if it couldn't be fetched.
+	PreviousMonthCost float64            `json:"previous_month_cost"`
+	Trend             *reports.TrendData `json:"trend,omitempty"`
+}
+
+// CostForecast is the projected AWS spend over the coming days. When Cost
+// Explorer can't make a forecast Available is false, Message says why and
+// the amounts are zero.
+type CostForecast struct {
+	common.CostForecast
+	Available bool   `json:"available"`
+	Message   string `json:"message,omitempty"`
 }
 
+// Forecast lengths, in days. Cost Explorer forecasts up to 12 months ahead.
+const (
+	MinForecastDays = 1
+	MaxForecastDays = 365
+)
+
 // ApplicationCost represents an application with its associated costs
 type ApplicationCost struct {
 	Application govuk.Application `json:"application"`
@@ -31,24 +51,76 @@ type ApplicationCost struct {
 
 // ApplicationSummary is a simplified view for list endpoints
 type ApplicationSummary struct {
-	Name               string    `json:"name"`
-	Shortname          string    `json:"shortname"`
-	Team               string    `json:"team"`
-	ProductionHostedOn string    `json:"production_hosted_on"`
-	TotalCost          float64   `json:"total_cost"`
-	Currency           string    `json:"currency"`
-	ServiceCount       int       `json:"service_count"`
-	LastUpdated        time.Time `json:"last_updated"`
-	CostSource         string    `json:"cost_source"`         // "real_aws_tags", "service_name_match", "estimation"
-	CostConfidence     string    `json:"cost_confidence"`     // "high", "medium", "low", "none"
-	Links              Links     `json:"links"`
+	Name               string              `json:"name"`
+	Shortname          string              `json:"shortname"`
+	Team               string              `json:"team"`
+	ProductionHostedOn string              `json:"production_hosted_on"`
+	TotalCost          float64             `json:"total_cost"`
+	PreviousMonthCost  float64             `json:"previous_month_cost"`
+	CostChange         float64             `json:"cost_change"`
+	CostChangePercent  float64             `json:"cost_change_percent"`
+	Currency           string              `json:"currency"`
+	ServiceCount       int                 `json:"service_count"`
+	LastUpdated        time.Time           `json:"last_updated"`
+	CostSource         string              `json:"cost_source"`     // "real_aws_tags", "service_name_match", "estimation"
+	CostConfidence     string              `json:"cost_confidence"` // "high", "medium", "low", "none"
+	Links              Links               `json:"links"`
+	Trend              *CostTrendIndicator `json:"trend,omitempty"`
+	TeamContacts       *govuk.TeamContacts `json:"team_contacts,omitempty"`
+}
+
+// CostTrendIndicator summarises recent cost movement for list views
+type CostTrendIndicator struct {
+	Direction     string    `json:"direction"` // "up", "down", "flat"
+	ChangePercent float64   `json:"change_percent"`
+	Sparkline     []float64 `json:"sparkline"` // Last 6 months, oldest first
 }
 
 // ApplicationDetail provides detailed cost breakdown
 type ApplicationDetail struct {
 	ApplicationSummary
-	Services    []ServiceCost `json:"services"`
-	CostHistory []HistoricalCost `json:"cost_history,omitempty"`
+	Services      []ServiceCost         `json:"services"`
+	CostHistory   []HistoricalCost      `json:"cost_history,omitempty"`
+	SentryProject *sentry.SentryProject `json:"sentry_project,omitempty"`
+}
+
+// ApplicationInfrastructure links an application to the RDS instances and
+// ElastiCache clusters tagged with its system tag
+type ApplicationInfrastructure struct {
+	Application             ApplicationDetail                `json:"application"`
+	SystemTag               string                           `json:"system_tag"`
+	RDSInstances            []rds.PostgreSQLInstance         `json:"rds_instances"`
+	ElastiCacheClusters     []elasticache.ElastiCacheCluster `json:"elasticache_clusters"`
+	TotalInfrastructureCost float64                          `json:"total_infrastructure_cost"`
+	Currency                string                           `json:"currency"`
+}
+
+// Infrastructure item types
+const (
+	InfrastructureTypeRDS         = "rds"
+	InfrastructureTypeElastiCache = "elasticache"
+)
+
+// InfrastructureItem is an RDS instance or ElastiCache cluster belonging to
+// an application, reduced to the fields shared by both
+type InfrastructureItem struct {
+	Type          string `json:"type"`
+	ID            string `json:"id"`
+	ARN           string `json:"arn"`
+	Engine        string `json:"engine"`
+	EngineVersion string `json:"engine_version"`
+	NodeType      string `json:"node_type"`
+	Status        string `json:"status"`
+	Environment   string `json:"environment,omitempty"`
+}
+
+// ApplicationCostContext is the data behind the application detail page,
+// returned by GET /api/bff/applications/{name}
+type ApplicationCostContext struct {
+	Application             govuk.Application    `json:"application"`
+	CostSummary             ApplicationDetail    `json:"cost_summary"`
+	InfrastructureItems     []InfrastructureItem `json:"infrastructure_items"`
+	InfrastructureAvailable bool                 `json:"infrastructure_available"`
 }
 
 // ServiceCost represents cost data for a specific AWS service
@@ -67,13 +139,93 @@ type HistoricalCost struct {
 	Cost float64   `json:"cost"`
 }
 
-// ApplicationListResponse represents the response for listing applications
+// ApplicationListResponse represents the response for listing applications.
+// Count is the number of applications in this page, and Total the number
+// matching the filter; TotalCost and Aggregates cover every match.
 type ApplicationListResponse struct {
-	Applications []ApplicationSummary `json:"applications"`
-	TotalCost    float64              `json:"total_cost"`
-	Currency     string               `json:"currency"`
-	Count        int                  `json:"count"`
-	LastUpdated  time.Time            `json:"last_updated"`
+	Applications []ApplicationSummary  `json:"applications"`
+	TotalCost    float64               `json:"total_cost"`
+	Currency     string                `json:"currency"`
+	Count        int                   `json:"count"`
+	Total        int                   `json:"total"`
+	Limit        int                   `json:"limit"`
+	Offset       int                   `json:"offset"`
+	HasMore      bool                  `json:"has_more"`
+	LastUpdated  time.Time             `json:"last_updated"`
+	NotFound     []string              `json:"not_found,omitempty"`
+	Aggregates   ApplicationAggregates `json:"aggregates"`
+}
+
+// Application list page sizes
+const (
+	DefaultApplicationLimit = 50
+	MaxApplicationLimit     = 500
+)
+
+// ApplicationSortFields are the fields applications can be sorted by
+var ApplicationSortFields = []string{"name", "cost", "team", "hosting"}
+
+// ApplicationFilter narrows, sorts and pages the applications returned by
+// GetAllApplications. Team and Hosting must match exactly, and Search
+// matches part of the name or shortname, ignoring case. Without a Sort the
+// applications keep the order of the GOV.UK application list. A Limit of 0
+// returns every application after Offset.
+type ApplicationFilter struct {
+	Team    string
+	Hosting string
+	Search  string
+	Sort    string // One of ApplicationSortFields
+	Order   string // "asc" or "desc"
+	Limit   int
+	Offset  int
+}
+
+// ApplicationAggregates breaks down the cost of a list of applications by
+// team, hosting platform and cost confidence
+type ApplicationAggregates struct {
+	TotalCost        float64              `json:"total_cost"`
+	ByTeam           map[string]float64   `json:"by_team"`
+	ByPlatform       map[string]float64   `json:"by_platform"`
+	ByConfidence     map[string]int       `json:"by_confidence"`
+	AverageCost      float64              `json:"average_cost"`
+	MedianCost       float64              `json:"median_cost"`
+	TopExpensiveApps []ApplicationSummary `json:"top_expensive_apps"`
+}
+
+// TeamCostSummary is the total cost of the applications a team owns
+type TeamCostSummary struct {
+	Team             string             `json:"team"`
+	TotalCost        float64            `json:"total_cost"`
+	ApplicationCount int                `json:"application_count"`
+	AverageCost      float64            `json:"average_cost"`
+	HighestCostApp   ApplicationSummary `json:"highest_cost_app"`
+	Currency         string             `json:"currency"`
+}
+
+// TeamCostResponse is the response for GET /api/costs/teams, with teams
+// sorted by total cost, most expensive first
+type TeamCostResponse struct {
+	Teams       []TeamCostSummary `json:"teams"`
+	TotalCost   float64           `json:"total_cost"`
+	Currency    string            `json:"currency"`
+	Count       int               `json:"count"`
+	LastUpdated time.Time         `json:"last_updated"`
+}
+
+// AttributionStats summarises how application costs were attributed, and
+// which estimated applications would gain most from AWS tagging
+type AttributionStats struct {
+	HighConfidenceCount   int     `json:"high_confidence_count"`
+	MediumConfidenceCount int     `json:"medium_confidence_count"`
+	LowConfidenceCount    int     `json:"low_confidence_count"`
+	TaggedCount           int     `json:"tagged_count"`
+	EstimatedCount        int     `json:"estimated_count"`
+	TagCoveragePercent    float64 `json:"tag_coverage_percent"`
+	// ImprovementPotentialGBP is the total cost of estimated applications,
+	// which could be tracked accurately with proper tagging
+	ImprovementPotentialGBP float64              `json:"improvement_potential_gbp"`
+	Currency                string               `json:"currency"`
+	TaggingSuggestions      []ApplicationSummary `json:"tagging_suggestions"`
 }
 
 // Links represents URL links for an application
@@ -3,9 +3,13 @@ package costs
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
+	"govuk-reports-dashboard/internal/modules/eks"
 	"govuk-reports-dashboard/internal/reports"
+	"govuk-reports-dashboard/pkg/aws"
+	"govuk-reports-dashboard/pkg/common"
 	"govuk-reports-dashboard/pkg/logger"
 )
 
@@ -13,10 +17,25 @@ import (
 type CostReport struct {
 	costService        *CostService
 	applicationService *ApplicationService
+	eksService         *eks.EKSService
+	changeSource       InfrastructureChangeSource
 	renderer           *reports.Renderer
 	logger             *logger.Logger
 }
 
+// recentChangesHours is how far back the Recent Changes table looks
+const recentChangesHours = 24
+
+// remainingSpendBeforeDay is the day of the month from which the summary
+// stops showing the Forecast Remaining Spend card
+const remainingSpendBeforeDay = 20
+
+// InfrastructureChangeSource lists recent infrastructure changes from
+// CloudTrail, as aws.Client does
+type InfrastructureChangeSource interface {
+	GetRecentInfrastructureChanges(ctx context.Context, hours int) ([]aws.CloudTrailEvent, error)
+}
+
 // NewCostReport creates a new cost report instance
 func NewCostReport(costService *CostService, applicationService *ApplicationService, logger *logger.Logger) *CostReport {
 	return &CostReport{
@@ -27,6 +46,17 @@ func NewCostReport(costService *CostService, applicationService *ApplicationServ
 	}
 }
 
+// SetEKSService enables the EKS namespace cost chart in detailed reports
+func (r *CostReport) SetEKSService(eksService *eks.EKSService) {
+	r.eksService = eksService
+}
+
+// SetInfrastructureChangeSource enables the Recent Changes table in detailed
+// reports
+func (r *CostReport) SetInfrastructureChangeSource(source InfrastructureChangeSource) {
+	r.changeSource = source
+}
+
 // GetMetadata returns metadata about this report module
 func (r *CostReport) GetMetadata() reports.ReportMetadata {
 	return reports.ReportMetadata{
@@ -46,13 +76,13 @@ func (r *CostReport) GenerateSummary(ctx context.Context, params reports.ReportP
 	r.logger.Info().Msg("Generating cost summary for dashboard")
 
 	// Get cost summary data
-	costSummary, err := r.costService.GetCostSummary()
+	costSummary, err := r.costService.GetCostSummary(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cost summary: %w", err)
 	}
 
 	// Get application data for additional metrics
-	appData, err := r.applicationService.GetAllApplications(ctx)
+	appData, err := r.applicationService.GetAllApplications(ctx, params, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get application data: %w", err)
 	}
@@ -62,13 +92,32 @@ func (r *CostReport) GenerateSummary(ctx context.Context, params reports.ReportP
 	// Total Cost Summary
 	totalCostSummary := r.renderer.CreateSummaryCard(
 		"Total Monthly Cost",
-		r.renderer.FormatCurrency(costSummary.TotalCost, "GBP"),
+		r.renderer.FormatCurrency(costSummary.TotalCost, costSummary.Currency),
 		"Current month",
 		reports.SummaryTypeCurrency,
-		r.calculateCostTrend(costSummary.TotalCost),
+		costSummary.Trend,
 	)
 	summaries = append(summaries, totalCostSummary)
 
+	// Early in the month the spend so far says little about the month as a
+	// whole, so show the forecast for the rest of it. The forecast only
+	// covers today to the end of the month, not the month's total.
+	if time.Now().Day() < remainingSpendBeforeDay {
+		if forecast, err := r.costService.GetMonthEndForecast(ctx); err != nil {
+			r.logger.WithError(err).Warn().Msg("Failed to get month-end cost forecast")
+		} else if forecast.Available {
+			summaries = append(summaries, r.renderer.CreateSummaryCard(
+				"Forecast Remaining Spend",
+				r.renderer.FormatCurrency(forecast.MeanCost, forecast.Currency),
+				fmt.Sprintf("To month end, %s to %s",
+					r.renderer.FormatCurrency(forecast.LowerBound, forecast.Currency),
+					r.renderer.FormatCurrency(forecast.UpperBound, forecast.Currency)),
+				reports.SummaryTypeCurrency,
+				nil,
+			))
+		}
+	}
+
 	// Application Count Summary
 	appCountSummary := r.renderer.CreateSummaryCard(
 		"Applications",
@@ -86,7 +135,7 @@ func (r *CostReport) GenerateSummary(ctx context.Context, params reports.ReportP
 	}
 	avgCostSummary := r.renderer.CreateSummaryCard(
 		"Average Cost",
-		r.renderer.FormatCurrency(avgCost, "GBP"),
+		r.renderer.FormatCurrency(avgCost, costSummary.Currency),
 		"Per application",
 		reports.SummaryTypeCurrency,
 		nil,
@@ -99,19 +148,85 @@ func (r *CostReport) GenerateSummary(ctx context.Context, params reports.ReportP
 		topServiceSummary := r.renderer.CreateSummaryCard(
 			"Top Service",
 			topService.Service,
-			r.renderer.FormatCurrency(topService.Amount, "GBP"),
+			r.renderer.FormatCurrency(topService.Amount, costSummary.Currency),
 			reports.SummaryTypeMetric,
 			nil,
 		)
 		summaries = append(summaries, topServiceSummary)
 	}
 
+	// Cost attribution confidence
+	attribution := calculateAttributionStats(appData.Applications, appData.Currency)
+
+	highConfidenceSummary := r.renderer.CreateSummaryCard(
+		"High Confidence Apps",
+		r.renderer.FormatNumber(attribution.TaggedCount),
+		"Costs from AWS tags",
+		reports.SummaryTypeCount,
+		nil,
+	)
+	summaries = append(summaries, highConfidenceSummary)
+
+	estimatedSummary := r.renderer.CreateSummaryCard(
+		"Estimated Apps",
+		r.renderer.FormatNumber(attribution.EstimatedCount),
+		fmt.Sprintf("%s untracked", r.renderer.FormatCurrency(attribution.ImprovementPotentialGBP, attribution.Currency)),
+		reports.SummaryTypeAlert,
+		nil,
+	)
+	if attribution.EstimatedCount > 0 {
+		estimatedSummary.(*reports.BasicSummary).SetHealthy(false)
+	}
+	summaries = append(summaries, estimatedSummary)
+
+	tagCoverageSummary := r.renderer.CreateSummaryCard(
+		"Tag Coverage",
+		r.renderer.FormatPercentage(attribution.TagCoveragePercent, 1),
+		"Applications with real attribution",
+		reports.SummaryTypeHealth,
+		nil,
+	)
+	if attribution.TagCoveragePercent < 50 {
+		tagCoverageSummary.(*reports.BasicSummary).SetHealthy(false)
+	}
+	summaries = append(summaries, tagCoverageSummary)
+
 	r.logger.WithField("summary_count", len(summaries)).Info().Msg("Generated cost summaries")
 	return summaries, nil
 }
 
 // GenerateReport creates detailed report data
 func (r *CostReport) GenerateReport(ctx context.Context, params reports.ReportParams) (reports.ReportData, error) {
+	data, _ := r.generateReport(ctx, params, func(reports.ChunkType, interface{}) bool { return true })
+	return data, nil
+}
+
+// GenerateReportStream streams the detailed report, sending the summary,
+// data points, charts and tables as each is ready and then the whole report.
+// A report that fails is sent as an error chunk.
+func (r *CostReport) GenerateReportStream(ctx context.Context, params reports.ReportParams) (<-chan reports.ReportChunk, error) {
+	chunks := make(chan reports.ReportChunk)
+	go func() {
+		defer close(chunks)
+		send := reports.NewChunkSender(ctx, chunks)
+
+		data, ok := r.generateReport(ctx, params, send)
+		if !ok {
+			return
+		}
+		if data.Status == reports.StatusFailed {
+			reportErr := data.Errors[len(data.Errors)-1]
+			send(reports.ChunkTypeError, fmt.Sprintf("%s: %s", reportErr.Message, reportErr.Details))
+			return
+		}
+		send(reports.ChunkTypeDone, data)
+	}()
+	return chunks, nil
+}
+
+// generateReport builds the detailed report, passing each section to send
+// once it is complete. It stops, returning false, if send does.
+func (r *CostReport) generateReport(ctx context.Context, params reports.ReportParams, send func(chunkType reports.ChunkType, payload interface{}) bool) (reports.ReportData, bool) {
 	r.logger.Info().Msg("Generating detailed cost report")
 
 	data := reports.ReportData{
@@ -119,8 +234,23 @@ func (r *CostReport) GenerateReport(ctx context.Context, params reports.ReportPa
 		GeneratedAt: time.Now(),
 	}
 
+	// Generate summary data first, as it is the quickest to show
+	var err error
+	data.Summary, err = r.GenerateSummary(ctx, params)
+	if err != nil {
+		data.Warnings = append(data.Warnings, reports.ReportWarning{
+			Code:      "SUMMARY_GENERATION_WARNING",
+			Message:   "Failed to generate summary data",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+		})
+	}
+	if !send(reports.ChunkTypeSummary, data.Summary) {
+		return data, false
+	}
+
 	// Get cost summary
-	costSummary, err := r.costService.GetCostSummary()
+	costSummary, err := r.costService.GetCostSummary(ctx)
 	if err != nil {
 		data.Status = reports.StatusFailed
 		data.Errors = append(data.Errors, reports.ReportError{
@@ -129,11 +259,11 @@ func (r *CostReport) GenerateReport(ctx context.Context, params reports.ReportPa
 			Details:   err.Error(),
 			Timestamp: time.Now(),
 		})
-		return data, nil
+		return data, true
 	}
 
 	// Get application data
-	appData, err := r.applicationService.GetAllApplications(ctx)
+	appData, err := r.applicationService.GetAllApplications(ctx, params, nil)
 	if err != nil {
 		data.Status = reports.StatusFailed
 		data.Errors = append(data.Errors, reports.ReportError{
@@ -142,28 +272,68 @@ func (r *CostReport) GenerateReport(ctx context.Context, params reports.ReportPa
 			Details:   err.Error(),
 			Timestamp: time.Now(),
 		})
-		return data, nil
+		return data, true
 	}
 
 	// Generate data points
 	data.DataPoints = r.generateDataPoints(costSummary, appData)
 
-	// Generate summary data
-	data.Summary, err = r.GenerateSummary(ctx, params)
-	if err != nil {
-		data.Warnings = append(data.Warnings, reports.ReportWarning{
-			Code:      "SUMMARY_GENERATION_WARNING",
-			Message:   "Failed to generate summary data",
-			Details:   err.Error(),
-			Timestamp: time.Now(),
-		})
+	if r.applicationService.SentryEnabled() {
+		errorRatePoints, err := r.generateErrorRateDataPoints(ctx, appData)
+		if err != nil {
+			data.Warnings = append(data.Warnings, reports.ReportWarning{
+				Code:      "SENTRY_ERROR_RATE_WARNING",
+				Message:   "Failed to get Sentry error rates",
+				Details:   err.Error(),
+				Timestamp: time.Now(),
+			})
+		}
+		data.DataPoints = append(data.DataPoints, errorRatePoints...)
+	}
+	if !send(reports.ChunkTypeDataPoints, data.DataPoints) {
+		return data, false
 	}
 
 	// Generate charts
 	data.Charts = r.generateCharts(costSummary, appData)
 
+	if r.eksService != nil {
+		namespaceCosts, err := r.eksService.GetNamespaceCosts(ctx, r.eksService.DefaultClusterName())
+		if err != nil {
+			data.Warnings = append(data.Warnings, reports.ReportWarning{
+				Code:      "EKS_NAMESPACE_COST_WARNING",
+				Message:   "Failed to get EKS namespace costs",
+				Details:   err.Error(),
+				Timestamp: time.Now(),
+			})
+		} else if len(namespaceCosts) > 0 {
+			data.Charts = append(data.Charts, r.generateNamespaceChart(namespaceCosts))
+		}
+	}
+	if !send(reports.ChunkTypeCharts, data.Charts) {
+		return data, false
+	}
+
 	// Generate tables
 	data.Tables = r.generateTables(appData)
+	data.Tables = append(data.Tables, r.generateTeamPlatformTable(data.DataPoints, costSummary.Currency))
+
+	if r.changeSource != nil {
+		changes, err := r.changeSource.GetRecentInfrastructureChanges(ctx, recentChangesHours)
+		if err != nil {
+			data.Warnings = append(data.Warnings, reports.ReportWarning{
+				Code:      "INFRASTRUCTURE_CHANGES_WARNING",
+				Message:   "Failed to get recent infrastructure changes",
+				Details:   err.Error(),
+				Timestamp: time.Now(),
+			})
+		} else if len(changes) > 0 {
+			data.Tables = append(data.Tables, r.generateRecentChangesTable(changes))
+		}
+	}
+	if !send(reports.ChunkTypeTables, data.Tables) {
+		return data, false
+	}
 
 	data.Status = reports.StatusCompleted
 	r.logger.WithFields(map[string]interface{}{
@@ -172,13 +342,13 @@ func (r *CostReport) GenerateReport(ctx context.Context, params reports.ReportPa
 		"tables":      len(data.Tables),
 	}).Info().Msg("Generated detailed cost report")
 
-	return data, nil
+	return data, true
 }
 
 // IsAvailable checks if this report can run with current configuration
 func (r *CostReport) IsAvailable(ctx context.Context) bool {
 	// Check if cost service is available
-	_, err := r.costService.GetCostSummary()
+	_, err := r.costService.GetCostSummary(ctx)
 	return err == nil
 }
 
@@ -195,14 +365,7 @@ func (r *CostReport) Validate(params reports.ReportParams) error {
 
 // Helper methods
 
-func (r *CostReport) calculateCostTrend(currentCost float64) *reports.TrendData {
-	// For demo purposes, simulate a trend
-	// In a real implementation, you'd compare with historical data
-	previousCost := currentCost * 0.95 // Simulate 5% increase
-	return r.renderer.FormatTrend(currentCost, previousCost, "vs last month")
-}
-
-func (r *CostReport) getTopCostService(services []CostData) *CostData {
+func (r *CostReport) getTopCostService(services []common.CostData) *common.CostData {
 	if len(services) == 0 {
 		return nil
 	}
@@ -275,6 +438,57 @@ func (r *CostReport) generateDataPoints(costSummary *CostSummary, appData *Appli
 	return dataPoints
 }
 
+// sentryErrorRateApplications is the number of most expensive applications
+// whose Sentry error rate is added to the report
+const sentryErrorRateApplications = 10
+
+// generateErrorRateDataPoints adds the Sentry error rate of the most expensive
+// applications, so cost changes can be compared against error spikes.
+// Applications without a Sentry URL are skipped, and the last error is
+// returned alongside the data points that could be fetched.
+func (r *CostReport) generateErrorRateDataPoints(ctx context.Context, appData *ApplicationListResponse) ([]reports.DataPoint, error) {
+	apps := make([]ApplicationSummary, 0, len(appData.Applications))
+	for _, app := range appData.Applications {
+		if app.Links.SentryURL != "" {
+			apps = append(apps, app)
+		}
+	}
+	sort.Slice(apps, func(i, j int) bool {
+		return apps[i].TotalCost > apps[j].TotalCost
+	})
+	if len(apps) > sentryErrorRateApplications {
+		apps = apps[:sentryErrorRateApplications]
+	}
+
+	var dataPoints []reports.DataPoint
+	var lastErr error
+	now := time.Now()
+	for _, app := range apps {
+		rate, err := r.applicationService.GetApplicationErrorRate(ctx, app)
+		if err != nil {
+			r.logger.WithError(err).WithField("app_name", app.Name).Warn().Msg("Failed to get Sentry error rate")
+			lastErr = err
+			continue
+		}
+
+		dataPoints = append(dataPoints, reports.DataPoint{
+			Timestamp: now,
+			Labels: map[string]string{
+				"type":        "error_rate",
+				"source":      "sentry",
+				"application": app.Name,
+			},
+			Values: map[string]interface{}{
+				"errors_per_hour": rate,
+				"period_hours":    SentryErrorPeriod.Hours(),
+				"cost":            app.TotalCost,
+			},
+		})
+	}
+
+	return dataPoints, lastErr
+}
+
 func (r *CostReport) generateCharts(costSummary *CostSummary, appData *ApplicationListResponse) []reports.ChartData {
 	var charts []reports.ChartData
 
@@ -299,7 +513,8 @@ func (r *CostReport) generateCharts(costSummary *CostSummary, appData *Applicati
 		charts = append(charts, serviceChart)
 	}
 
-	// Application cost bar chart
+	// Application cost bar chart, comparing the 10 most expensive
+	// applications with the previous month
 	if len(appData.Applications) > 0 {
 		appChart := reports.ChartData{
 			Title: "Cost by Application",
@@ -308,29 +523,77 @@ func (r *CostReport) generateCharts(costSummary *CostSummary, appData *Applicati
 			YAxis: "cost",
 		}
 
-		var series reports.ChartSeries
-		series.Name = "Application Costs"
-		
-		// Sort applications by cost (top 10)
-		apps := appData.Applications
+		apps := make([]ApplicationSummary, len(appData.Applications))
+		copy(apps, appData.Applications)
+		sort.SliceStable(apps, func(i, j int) bool {
+			return apps[i].TotalCost > apps[j].TotalCost
+		})
 		if len(apps) > 10 {
-			// In a real implementation, you'd sort by cost first
 			apps = apps[:10]
 		}
-		
+
+		current := reports.ChartSeries{Name: "Current Month"}
+		previous := reports.ChartSeries{Name: "Previous Month"}
 		for _, app := range apps {
-			series.Data = append(series.Data, reports.ChartPoint{
-				X: app.Name,
-				Y: app.TotalCost,
-			})
+			current.Data = append(current.Data, reports.ChartPoint{X: app.Name, Y: app.TotalCost})
+			previous.Data = append(previous.Data, reports.ChartPoint{X: app.Name, Y: app.PreviousMonthCost})
 		}
-		appChart.Series = append(appChart.Series, series)
+		appChart.Series = append(appChart.Series, current, previous)
 		charts = append(charts, appChart)
 	}
 
+	// Team cost bar chart of the 10 most expensive teams
+	if len(appData.Applications) > 0 {
+		teamChart := reports.ChartData{
+			Title: "Cost by Team",
+			Type:  "bar",
+			XAxis: "team",
+			YAxis: "cost",
+		}
+
+		teams := groupCostsByTeam(appData.Applications, appData.Currency)
+		if len(teams) > 10 {
+			teams = teams[:10]
+		}
+
+		series := reports.ChartSeries{Name: "Team Costs"}
+		for _, team := range teams {
+			series.Data = append(series.Data, reports.ChartPoint{X: team.Team, Y: team.TotalCost})
+		}
+		teamChart.Series = append(teamChart.Series, series)
+		charts = append(charts, teamChart)
+	}
+
 	return charts
 }
 
+func (r *CostReport) generateNamespaceChart(namespaceCosts []eks.NamespaceCostItem) reports.ChartData {
+	namespaceChart := reports.ChartData{
+		Title: "Cost by EKS Namespace",
+		Type:  "bar",
+		XAxis: "namespace",
+		YAxis: "cost",
+	}
+
+	var series reports.ChartSeries
+	series.Name = "Namespace Costs"
+
+	// Namespace costs are sorted by cost, so keep the top 10
+	if len(namespaceCosts) > 10 {
+		namespaceCosts = namespaceCosts[:10]
+	}
+
+	for _, item := range namespaceCosts {
+		series.Data = append(series.Data, reports.ChartPoint{
+			X: item.Namespace,
+			Y: item.EstimatedCost,
+		})
+	}
+	namespaceChart.Series = append(namespaceChart.Series, series)
+
+	return namespaceChart
+}
+
 func (r *CostReport) generateTables(appData *ApplicationListResponse) []reports.TableData {
 	var tables []reports.TableData
 
@@ -351,12 +614,89 @@ func (r *CostReport) generateTables(appData *ApplicationListResponse) []reports.
 			"name":       app.Name,
 			"team":       app.Team,
 			"hosting":    app.ProductionHostedOn,
-			"cost":       r.renderer.FormatCurrency(app.TotalCost, "GBP"),
+			"cost":       r.renderer.FormatCurrency(app.TotalCost, app.Currency),
 			"confidence": app.CostConfidence,
 		}
 		appTable.Rows = append(appTable.Rows, row)
 	}
 
 	tables = append(tables, appTable)
+
+	// Team cost table, for allocating budgets
+	teamTable := reports.TableData{
+		Title: "Team Costs",
+		Headers: []reports.TableHeader{
+			{Key: "team", Label: "Team", Type: "string", Sortable: true, Filterable: true},
+			{Key: "cost", Label: "Monthly Cost", Type: "currency", Sortable: true, Filterable: false},
+			{Key: "applications", Label: "Applications", Type: "number", Sortable: true, Filterable: false},
+			{Key: "average_cost", Label: "Average Cost", Type: "currency", Sortable: true, Filterable: false},
+			{Key: "highest_cost_app", Label: "Most Expensive Application", Type: "string", Sortable: true, Filterable: true},
+		},
+	}
+
+	for _, team := range groupCostsByTeam(appData.Applications, appData.Currency) {
+		teamTable.Rows = append(teamTable.Rows, map[string]interface{}{
+			"team":             team.Team,
+			"cost":             r.renderer.FormatCurrency(team.TotalCost, team.Currency),
+			"applications":     team.ApplicationCount,
+			"average_cost":     r.renderer.FormatCurrency(team.AverageCost, team.Currency),
+			"highest_cost_app": team.HighestCostApp.Name,
+		})
+	}
+
+	tables = append(tables, teamTable)
 	return tables
-}
\ No newline at end of file
+}
+
+// generateTeamPlatformTable pivots application cost data points by team and
+// hosting platform, formatting each cell as currency
+func (r *CostReport) generateTeamPlatformTable(dataPoints []reports.DataPoint, currency string) reports.TableData {
+	var appPoints []reports.DataPoint
+	for _, point := range dataPoints {
+		if point.Labels["type"] == "application_cost" {
+			appPoints = append(appPoints, point)
+		}
+	}
+
+	table := r.renderer.GeneratePivotTable("Cost by Team and Platform", appPoints, "team", "hosting", "cost")
+	for i, header := range table.Headers {
+		if header.Key == "team" {
+			continue
+		}
+		table.Headers[i].Type = "currency"
+		for _, row := range table.Rows {
+			row[header.Key] = r.renderer.FormatCurrency(row[header.Key], currency)
+		}
+	}
+
+	return table
+}
+
+// generateRecentChangesTable lists CloudTrail infrastructure changes, which
+// often explain a change in cost
+func (r *CostReport) generateRecentChangesTable(changes []aws.CloudTrailEvent) reports.TableData {
+	table := reports.TableData{
+		Title: "Recent Changes",
+		Headers: []reports.TableHeader{
+			{Key: "time", Label: "Time", Type: "date", Sortable: true, Filterable: false},
+			{Key: "event", Label: "Event", Type: "string", Sortable: true, Filterable: true},
+			{Key: "resource", Label: "Resource", Type: "string", Sortable: true, Filterable: true},
+			{Key: "resource_type", Label: "Resource Type", Type: "string", Sortable: true, Filterable: true},
+			{Key: "user", Label: "User", Type: "string", Sortable: true, Filterable: true},
+			{Key: "region", Label: "Region", Type: "string", Sortable: true, Filterable: true},
+		},
+	}
+
+	for _, change := range changes {
+		table.Rows = append(table.Rows, map[string]interface{}{
+			"time":          change.EventTime.Format("2006-01-02 15:04:05"),
+			"event":         change.EventName,
+			"resource":      change.ResourceName,
+			"resource_type": change.ResourceType,
+			"user":          change.Username,
+			"region":        change.AWSRegion,
+		})
+	}
+
+	return table
+}
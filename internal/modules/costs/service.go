@@ -1,20 +1,25 @@
 package costs
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"time"
 
+	"govuk-reports-dashboard/internal/reports"
 	"govuk-reports-dashboard/pkg/aws"
+	"govuk-reports-dashboard/pkg/common"
 	"govuk-reports-dashboard/pkg/govuk"
 	"govuk-reports-dashboard/pkg/logger"
 )
 
 type CostService struct {
-	awsClient   *aws.Client
-	govukClient *govuk.Client
+	awsClient   aws.CostDataClient
+	govukClient govuk.ApplicationsClient
 	logger      *logger.Logger
 }
 
-func NewCostService(awsClient *aws.Client, govukClient *govuk.Client, log *logger.Logger) *CostService {
+func NewCostService(awsClient aws.CostDataClient, govukClient govuk.ApplicationsClient, log *logger.Logger) *CostService {
 	return &CostService{
 		awsClient:   awsClient,
 		govukClient: govukClient,
@@ -22,31 +27,91 @@ func NewCostService(awsClient *aws.Client, govukClient *govuk.Client, log *logge
 	}
 }
 
-func (s *CostService) GetCostSummary() (*CostSummary, error) {
+func (s *CostService) GetCostSummary(ctx context.Context) (*CostSummary, error) {
 	s.logger.Info().Msg("Fetching AWS cost data")
 
-	costData, err := s.awsClient.GetCostData()
+	costData, err := s.awsClient.GetCostData(ctx)
 	if err != nil {
 		s.logger.WithError(err).Error().Msg("Failed to fetch AWS cost data")
 		return nil, err
 	}
 
 	summary := &CostSummary{
-		TotalCost:   calculateTotal(costData),
-		Currency:    "GBP",
+		TotalCost:   common.CostDataSlice(costData).Sum(),
+		Currency:    s.awsClient.ReportingCurrency(),
 		PeriodStart: time.Now().AddDate(0, -1, 0),
 		PeriodEnd:   time.Now(),
 		Services:    costData,
 		LastUpdated: time.Now(),
 	}
 
+	if len(costData) > 0 {
+		s.addPreviousMonthTrend(ctx, summary)
+	}
+
 	return summary, nil
 }
 
-func calculateTotal(costs []CostData) float64 {
-	total := 0.0
-	for _, cost := range costs {
-		total += cost.Amount
+// addPreviousMonthTrend fetches what the summary's services cost in the month
+// before its period and compares it with the current total. Failing to fetch
+// it leaves the summary without a trend.
+func (s *CostService) addPreviousMonthTrend(ctx context.Context, summary *CostSummary) {
+	services := make([]string, 0, len(summary.Services))
+	for _, service := range summary.Services {
+		services = append(services, service.Service)
+	}
+
+	previousData, err := s.awsClient.GetCostDataForServices(ctx, services, summary.PeriodStart.AddDate(0, -1, 0), summary.PeriodStart)
+	if err != nil {
+		s.logger.WithError(err).Warn().Msg("Failed to fetch previous month cost data")
+		return
+	}
+
+	summary.PreviousMonthCost = common.CostDataSlice(previousData).Sum()
+	summary.Trend = reports.NewRenderer().FormatTrend(summary.TotalCost, summary.PreviousMonthCost, "vs last month")
+}
+
+// GetCostForecast forecasts the cost of the next daysAhead days, starting
+// today. If Cost Explorer doesn't have enough history to forecast from, it
+// returns a forecast that isn't Available rather than an error.
+func (s *CostService) GetCostForecast(ctx context.Context, daysAhead int) (*CostForecast, error) {
+	if daysAhead < MinForecastDays || daysAhead > MaxForecastDays {
+		return nil, fmt.Errorf("forecast days must be between %d and %d, got %d", MinForecastDays, MaxForecastDays, daysAhead)
+	}
+
+	start := time.Now().UTC().Truncate(24 * time.Hour)
+	end := start.AddDate(0, 0, daysAhead)
+
+	s.logger.WithField("days_ahead", daysAhead).Info().Msg("Fetching AWS cost forecast")
+
+	forecast, err := s.awsClient.GetCostForecast(ctx, start, end)
+	if errors.Is(err, aws.ErrInsufficientForecastData) {
+		s.logger.WithError(err).Warn().Msg("Not enough cost data for a forecast")
+		return &CostForecast{
+			CostForecast: common.CostForecast{
+				Currency:       s.awsClient.ReportingCurrency(),
+				ForecastPeriod: common.DateRange{Start: start, End: end},
+			},
+			Message: "Not enough cost history to make a forecast yet",
+		}, nil
+	}
+	if err != nil {
+		s.logger.WithError(err).Error().Msg("Failed to fetch AWS cost forecast")
+		return nil, err
 	}
-	return total
-}
\ No newline at end of file
+
+	return &CostForecast{CostForecast: *forecast, Available: true}, nil
+}
+
+// GetMonthEndForecast forecasts the cost of the rest of the current month
+func (s *CostService) GetMonthEndForecast(ctx context.Context) (*CostForecast, error) {
+	return s.GetCostForecast(ctx, daysUntilMonthEnd(time.Now().UTC()))
+}
+
+// daysUntilMonthEnd is the number of days from now, inclusive, to the end
+// of its month
+func daysUntilMonthEnd(now time.Time) int {
+	firstOfNextMonth := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, now.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return int(firstOfNextMonth.Sub(today).Hours() / 24)
+}
@@ -0,0 +1,130 @@
+package costs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"govuk-reports-dashboard/internal/reports"
+	"govuk-reports-dashboard/pkg/aws"
+	"govuk-reports-dashboard/pkg/common"
+	"govuk-reports-dashboard/pkg/logger"
+)
+
+func TestCostService_GetCostSummary(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	awsClient := &aws.MockCostDataClient{
+		GetCostDataResult: []common.CostData{
+			{Service: "Amazon Elastic Compute Cloud - Compute", Amount: 120.50, Currency: "GBP"},
+			{Service: "Amazon Relational Database Service", Amount: 79.50, Currency: "GBP"},
+		},
+	}
+
+	summary, err := NewCostService(awsClient, nil, log).GetCostSummary(context.Background())
+	if err != nil {
+		t.Fatalf("GetCostSummary failed: %v", err)
+	}
+
+	if summary.TotalCost != 200 {
+		t.Errorf("Expected total cost 200, got %v", summary.TotalCost)
+	}
+	if summary.Currency != "GBP" {
+		t.Errorf("Expected currency GBP, got %s", summary.Currency)
+	}
+	if len(summary.Services) != 2 {
+		t.Errorf("Expected 2 services, got %d", len(summary.Services))
+	}
+}
+
+func TestCostService_GetCostSummary_PreviousMonthTrend(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	awsClient := &aws.MockCostDataClient{
+		GetCostDataResult:            []common.CostData{{Service: "Amazon Relational Database Service", Amount: 90}},
+		GetCostDataForServicesResult: []common.CostData{{Service: "Amazon Relational Database Service", Amount: 100}},
+	}
+
+	summary, err := NewCostService(awsClient, nil, log).GetCostSummary(context.Background())
+	if err != nil {
+		t.Fatalf("GetCostSummary failed: %v", err)
+	}
+
+	if summary.PreviousMonthCost != 100 {
+		t.Errorf("Expected previous month cost 100, got %v", summary.PreviousMonthCost)
+	}
+	if summary.Trend == nil || summary.Trend.Direction != reports.TrendDown || summary.Trend.Value != "-10.0%" {
+		t.Errorf("Expected a 10%% fall, got %+v", summary.Trend)
+	}
+
+	awsClient.GetCostDataForServicesErr = errors.New("access denied")
+	summary, err = NewCostService(awsClient, nil, log).GetCostSummary(context.Background())
+	if err != nil {
+		t.Fatalf("Expected the previous month to be optional, got %v", err)
+	}
+	if summary.Trend != nil {
+		t.Errorf("Expected no trend without previous month data, got %+v", summary.Trend)
+	}
+}
+
+func TestCostService_GetCostSummary_Error(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	awsClient := &aws.MockCostDataClient{GetCostDataErr: errors.New("access denied")}
+
+	if _, err := NewCostService(awsClient, nil, log).GetCostSummary(context.Background()); err == nil {
+		t.Error("Expected error from GetCostSummary")
+	}
+	if awsClient.Calls("GetCostData") != 1 {
+		t.Errorf("Expected 1 GetCostData call, got %d", awsClient.Calls("GetCostData"))
+	}
+}
+
+func TestCostService_GetCostForecast(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	awsClient := &aws.MockCostDataClient{
+		GetCostForecastResult: &common.CostForecast{MeanCost: 1500, LowerBound: 1200, UpperBound: 1800, Currency: "GBP"},
+	}
+	service := NewCostService(awsClient, nil, log)
+
+	forecast, err := service.GetCostForecast(context.Background(), 30)
+	if err != nil {
+		t.Fatalf("GetCostForecast failed: %v", err)
+	}
+	if !forecast.Available || forecast.MeanCost != 1500 || forecast.LowerBound != 1200 || forecast.UpperBound != 1800 {
+		t.Errorf("Unexpected forecast %+v", forecast)
+	}
+
+	awsClient.GetCostForecastResult = nil
+	awsClient.GetCostForecastErr = aws.ErrInsufficientForecastData
+	forecast, err = service.GetCostForecast(context.Background(), 30)
+	if err != nil {
+		t.Fatalf("Expected a degraded forecast, got error %v", err)
+	}
+	if forecast.Available || forecast.Message == "" || forecast.Currency != "GBP" {
+		t.Errorf("Expected an unavailable forecast with a message, got %+v", forecast)
+	}
+	if days := forecast.ForecastPeriod.End.Sub(forecast.ForecastPeriod.Start).Hours() / 24; days != 30 {
+		t.Errorf("Expected a 30 day forecast period, got %v days", days)
+	}
+
+	if _, err := service.GetCostForecast(context.Background(), 0); err == nil {
+		t.Error("Expected an error for 0 days ahead")
+	}
+}
+
+func TestDaysUntilMonthEnd(t *testing.T) {
+	tests := []struct {
+		now  time.Time
+		want int
+	}{
+		{time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC), 30},
+		{time.Date(2025, 6, 30, 23, 0, 0, 0, time.UTC), 1},
+		{time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC), 20},
+		{time.Date(2025, 12, 25, 12, 0, 0, 0, time.UTC), 7},
+	}
+
+	for _, tt := range tests {
+		if got := daysUntilMonthEnd(tt.now); got != tt.want {
+			t.Errorf("daysUntilMonthEnd(%s) = %d, want %d", tt.now.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
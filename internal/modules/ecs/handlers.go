@@ -0,0 +1,94 @@
+package ecs
+
+import (
+	"net/http"
+
+	"govuk-reports-dashboard/internal/handlers"
+	"govuk-reports-dashboard/internal/models"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ECSHandler struct {
+	ecsService *ECSService
+	logger     *logger.Logger
+}
+
+func NewECSHandler(ecsService *ECSService, logger *logger.Logger) *ECSHandler {
+	return &ECSHandler{
+		ecsService: ecsService,
+		logger:     logger,
+	}
+}
+
+// GetServices handles GET /api/ecs/services
+func (h *ECSHandler) GetServices(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for ECS services")
+
+	summary, ok := h.getAllServices(c, log, "ECS services")
+	if !ok {
+		return
+	}
+
+	log.WithField("service_count", summary.TotalServices).Info().Msg("Successfully fetched ECS services")
+	c.JSON(http.StatusOK, gin.H{
+		"services": summary.Services,
+		"count":    summary.TotalServices,
+	})
+}
+
+// GetSummary handles GET /api/ecs/summary
+func (h *ECSHandler) GetSummary(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for ECS summary")
+
+	summary, ok := h.getAllServices(c, log, "ECS summary")
+	if !ok {
+		return
+	}
+
+	log.WithField("service_count", summary.TotalServices).Info().Msg("Successfully summarised ECS services")
+	c.JSON(http.StatusOK, gin.H{
+		"total_clusters":     summary.TotalClusters,
+		"total_services":     summary.TotalServices,
+		"fargate_services":   summary.FargateServices,
+		"ec2_services":       summary.EC2Services,
+		"unhealthy_services": summary.UnhealthyServices,
+		"last_updated":       summary.LastUpdated,
+	})
+}
+
+// GetClusters handles GET /api/ecs/clusters
+func (h *ECSHandler) GetClusters(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for ECS clusters")
+
+	summary, ok := h.getAllServices(c, log, "ECS clusters")
+	if !ok {
+		return
+	}
+
+	log.WithField("cluster_count", summary.TotalClusters).Info().Msg("Successfully fetched ECS clusters")
+	c.JSON(http.StatusOK, gin.H{
+		"clusters": summary.Clusters,
+		"count":    summary.TotalClusters,
+	})
+}
+
+// getAllServices fetches every ECS service, responding with an error if
+// that fails. what names the data being fetched in the error.
+func (h *ECSHandler) getAllServices(c *gin.Context, log *logger.Logger, what string) (*ServicesSummary, bool) {
+	summary, err := h.ecsService.GetAllServices(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to get " + what)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get " + what,
+			Code:    http.StatusInternalServerError,
+		})
+		return nil, false
+	}
+	return summary, true
+}
@@ -0,0 +1,114 @@
+package ecs
+
+import (
+	"strings"
+	"time"
+)
+
+// baseImageRelease is a release of a base image and when it stopped
+// receiving security updates
+type baseImageRelease struct {
+	// Tag is the version or codename tags of the release start with, such
+	// as "3.7" for python:3.7-slim-buster
+	Tag     string
+	EOLDate time.Time
+}
+
+// baseImageEOLData lists end-of-life releases of the official Docker Hub
+// images GOV.UK services build on, keyed by image name
+// Reference: https://endoflife.date
+var baseImageEOLData = map[string][]baseImageRelease{
+	"node": {
+		{"10", date(2021, 4, 30)},
+		{"12", date(2022, 4, 30)},
+		{"14", date(2023, 4, 30)},
+		{"16", date(2023, 9, 11)},
+		{"18", date(2025, 4, 30)},
+	},
+	"python": {
+		{"2.7", date(2020, 1, 1)},
+		{"3.6", date(2021, 12, 23)},
+		{"3.7", date(2023, 6, 27)},
+		{"3.8", date(2024, 10, 7)},
+		{"3.9", date(2025, 10, 31)},
+	},
+	"ruby": {
+		{"2.6", date(2022, 4, 12)},
+		{"2.7", date(2023, 3, 31)},
+		{"3.0", date(2024, 4, 23)},
+		{"3.1", date(2025, 3, 26)},
+	},
+	"golang": {
+		{"1.20", date(2024, 2, 6)},
+		{"1.21", date(2024, 8, 13)},
+		{"1.22", date(2025, 2, 11)},
+	},
+	"alpine": {
+		{"3.15", date(2023, 11, 1)},
+		{"3.16", date(2024, 5, 23)},
+		{"3.17", date(2024, 11, 22)},
+	},
+	"ubuntu": {
+		{"16.04", date(2021, 4, 30)},
+		{"xenial", date(2021, 4, 30)},
+		{"18.04", date(2023, 5, 31)},
+		{"bionic", date(2023, 5, 31)},
+		{"20.04", date(2025, 5, 29)},
+		{"focal", date(2025, 5, 29)},
+	},
+	"debian": {
+		{"9", date(2022, 6, 30)},
+		{"stretch", date(2022, 6, 30)},
+		{"10", date(2024, 6, 30)},
+		{"buster", date(2024, 6, 30)},
+	},
+}
+
+// officialImagePrefixes are the registry paths official Docker Hub images
+// may be referenced by
+var officialImagePrefixes = []string{
+	"public.ecr.aws/docker/library/",
+	"docker.io/library/",
+	"docker.io/",
+	"library/",
+}
+
+// checkBaseImageEOL reports whether image is a release in baseImageEOLData
+// that reached end-of-life before now, and when it did
+func checkBaseImageEOL(image string, now time.Time) (bool, *time.Time) {
+	name, tag := parseImage(image)
+	for _, release := range baseImageEOLData[name] {
+		if tag == release.Tag || strings.HasPrefix(tag, release.Tag+".") || strings.HasPrefix(tag, release.Tag+"-") {
+			eolDate := release.EOLDate
+			return now.After(eolDate), &eolDate
+		}
+	}
+	return false, nil
+}
+
+// parseImage splits an image reference into the name of the official image
+// it refers to and its tag. The name is empty for other images.
+func parseImage(image string) (string, string) {
+	image, _, _ = strings.Cut(image, "@")
+
+	name, tag := image, "latest"
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		name, tag = image[:i], image[i+1:]
+	}
+
+	for _, prefix := range officialImagePrefixes {
+		if trimmed, found := strings.CutPrefix(name, prefix); found {
+			name = trimmed
+			break
+		}
+	}
+	if strings.Contains(name, "/") {
+		return "", tag
+	}
+	return name, tag
+}
+
+// date returns midnight UTC on the given day
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
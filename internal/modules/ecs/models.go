@@ -0,0 +1,95 @@
+package ecs
+
+import (
+	"time"
+)
+
+// Launch types services are counted under. EXTERNAL services are counted
+// as neither.
+const (
+	LaunchTypeFargate = "FARGATE"
+	LaunchTypeEC2     = "EC2"
+)
+
+// ContainerService is an ECS service with the size of its task definition
+type ContainerService struct {
+	ServiceName       string            `json:"service_name"`
+	ServiceARN        string            `json:"service_arn"`
+	ClusterName       string            `json:"cluster_name"`
+	Status            string            `json:"status"`
+	TaskDefinitionARN string            `json:"task_definition_arn"`
+	RunningCount      int32             `json:"running_count"`
+	DesiredCount      int32             `json:"desired_count"`
+	PendingCount      int32             `json:"pending_count"`
+	LaunchType        string            `json:"launch_type"`
+	PlatformVersion   string            `json:"platform_version,omitempty"`
+	Tags              map[string]string `json:"tags"`
+	Application       string            `json:"application"`
+
+	// CPU and Memory are the task-level sizes from the service's task
+	// definition, in CPU units and MiB. They're empty if the task definition
+	// couldn't be described or doesn't set them, as EC2 tasks needn't.
+	CPU    string `json:"cpu,omitempty"`
+	Memory string `json:"memory,omitempty"`
+}
+
+// AtDesiredCount reports whether the service is running as many tasks as it
+// wants
+func (s ContainerService) AtDesiredCount() bool {
+	return s.RunningCount == s.DesiredCount
+}
+
+// ClusterSummary counts the services and tasks in an ECS cluster
+type ClusterSummary struct {
+	ClusterName       string `json:"cluster_name"`
+	ServiceCount      int    `json:"service_count"`
+	RunningTasks      int32  `json:"running_tasks"`
+	DesiredTasks      int32  `json:"desired_tasks"`
+	FargateServices   int    `json:"fargate_services"`
+	EC2Services       int    `json:"ec2_services"`
+	UnhealthyServices int    `json:"unhealthy_services"`
+}
+
+// ServicesSummary represents a summary of ECS services across all clusters
+type ServicesSummary struct {
+	TotalClusters   int `json:"total_clusters"`
+	TotalServices   int `json:"total_services"`
+	FargateServices int `json:"fargate_services"`
+	EC2Services     int `json:"ec2_services"`
+
+	// UnhealthyServices is the number of services whose running task count
+	// differs from their desired count
+	UnhealthyServices int                `json:"unhealthy_services"`
+	Services          []ContainerService `json:"services"`
+	Clusters          []ClusterSummary   `json:"clusters"`
+	LastUpdated       time.Time          `json:"last_updated"`
+}
+
+// TaskDefinition is the runtime platform and container images of a task
+// definition used by a service
+type TaskDefinition struct {
+	TaskDefinitionARN     string           `json:"task_definition_arn"`
+	Family                string           `json:"family"`
+	Revision              int32            `json:"revision"`
+	CPU                   string           `json:"cpu,omitempty"`
+	Memory                string           `json:"memory,omitempty"`
+	CPUArchitecture       string           `json:"cpu_architecture,omitempty"`
+	OperatingSystemFamily string           `json:"operating_system_family,omitempty"`
+	Containers            []ContainerImage `json:"containers"`
+
+	// UsesEOLBaseImage is whether any container's image is an end-of-life
+	// release of a well-known base image
+	UsesEOLBaseImage bool `json:"uses_eol_base_image"`
+}
+
+// ContainerImage is a container in a task definition and the image it runs
+type ContainerImage struct {
+	Name  string `json:"name"`
+	Image string `json:"image"`
+
+	// IsEOL is whether Image is an end-of-life release of a base image in
+	// baseImageEOLData. Images built on top of a base image, such as those
+	// in ECR, can't be checked from their reference so are never EOL here.
+	IsEOL   bool       `json:"is_eol"`
+	EOLDate *time.Time `json:"eol_date,omitempty"`
+}
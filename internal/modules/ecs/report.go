@@ -0,0 +1,233 @@
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"govuk-reports-dashboard/internal/reports"
+	"govuk-reports-dashboard/pkg/logger"
+)
+
+type ECSReport struct {
+	ecsService *ECSService
+	renderer   *reports.Renderer
+	logger     *logger.Logger
+}
+
+func NewECSReport(ecsService *ECSService, logger *logger.Logger) *ECSReport {
+	return &ECSReport{
+		ecsService: ecsService,
+		renderer:   reports.NewRenderer(),
+		logger:     logger,
+	}
+}
+
+func (r *ECSReport) GetMetadata() reports.ReportMetadata {
+	return reports.ReportMetadata{
+		ID:          "ecs",
+		Name:        "ECS service report",
+		Description: "ECS and Fargate services, whether they're running their desired task count, and task definitions on end-of-life base images",
+		Type:        reports.ReportTypeHealth,
+		Version:     "1.0.0",
+		Author:      "GOV.UK Platform Team",
+		Tags:        []string{"ecs", "fargate", "containers", "eol"},
+		Priority:    reports.PriorityMedium,
+	}
+}
+
+func (r *ECSReport) GenerateSummary(ctx context.Context, params reports.ReportParams) ([]reports.Summary, error) {
+	r.logger.Info().Msg("Generating ECS summary for dashboard")
+
+	summary, err := r.ecsService.GetAllServices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ECS services: %w", err)
+	}
+
+	return r.generateSummaries(summary), nil
+}
+
+func (r *ECSReport) GenerateReport(ctx context.Context, params reports.ReportParams) (reports.ReportData, error) {
+	r.logger.Info().Msg("Generating detailed ECS report")
+
+	data := reports.ReportData{
+		Status:      reports.StatusRunning,
+		GeneratedAt: time.Now(),
+	}
+
+	summary, err := r.ecsService.GetAllServices(ctx)
+	if err != nil {
+		data.Status = reports.StatusFailed
+		data.Errors = append(data.Errors, reports.ReportError{
+			Code:      "ECS_FETCH_ERROR",
+			Message:   "Failed to fetch ECS services",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+		})
+		return data, nil
+	}
+
+	data.Summary = r.generateSummaries(summary)
+	data.DataPoints = r.generateDataPoints(summary)
+	data.Tables = []reports.TableData{r.generateServicesTable(summary)}
+
+	// Services are still reported if their task definitions can't be
+	taskDefinitions, err := r.ecsService.GetAllTaskDefinitions(ctx)
+	if err != nil {
+		data.Errors = append(data.Errors, reports.ReportError{
+			Code:      "ECS_TASK_DEFINITION_ERROR",
+			Message:   "Failed to fetch ECS task definitions",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+		})
+	} else {
+		data.Tables = append(data.Tables, r.generateImagesTable(taskDefinitions))
+	}
+
+	data.Status = reports.StatusCompleted
+	r.logger.WithFields(map[string]interface{}{
+		"data_points": len(data.DataPoints),
+		"tables":      len(data.Tables),
+	}).Info().Msg("Generated detailed ECS report")
+
+	return data, nil
+}
+
+func (r *ECSReport) IsAvailable(ctx context.Context) bool {
+	return r.ecsService.IsAvailable(ctx) == nil
+}
+
+// GetRefreshInterval returns how often this report should be refreshed
+func (r *ECSReport) GetRefreshInterval() time.Duration {
+	return 15 * time.Minute // Task counts change as services scale and deploy
+}
+
+// Validate checks if the provided parameters are valid for this report
+func (r *ECSReport) Validate(params reports.ReportParams) error {
+	// ECS reports don't have specific parameter requirements currently
+	return nil
+}
+
+// generateSummaries creates the service count, desired count and launch
+// type cards
+func (r *ECSReport) generateSummaries(summary *ServicesSummary) []reports.Summary {
+	var summaries []reports.Summary
+
+	summaries = append(summaries, r.renderer.CreateSummaryCard(
+		"ECS Services",
+		r.renderer.FormatNumber(summary.TotalServices),
+		fmt.Sprintf("Across %d clusters", summary.TotalClusters),
+		reports.SummaryTypeCount,
+		nil,
+	))
+
+	unhealthySummary := r.renderer.CreateSummaryCard(
+		"Services Not At Desired Count",
+		r.renderer.FormatNumber(summary.UnhealthyServices),
+		"Running tasks differ from desired tasks",
+		reports.SummaryTypeAlert,
+		nil,
+	)
+	if summary.UnhealthyServices > 0 {
+		unhealthySummary.(*reports.BasicSummary).SetHealthy(false)
+	}
+	summaries = append(summaries, unhealthySummary)
+
+	summaries = append(summaries, r.renderer.CreateSummaryCard(
+		"Fargate / EC2",
+		fmt.Sprintf("%d / %d", summary.FargateServices, summary.EC2Services),
+		"Services by launch type",
+		reports.SummaryTypeMetric,
+		nil,
+	))
+
+	return summaries
+}
+
+func (r *ECSReport) generateDataPoints(summary *ServicesSummary) []reports.DataPoint {
+	var dataPoints []reports.DataPoint
+	now := time.Now()
+
+	for _, service := range summary.Services {
+		dataPoints = append(dataPoints, reports.DataPoint{
+			Timestamp: now,
+			Labels: map[string]string{
+				"type":        "ecs_service",
+				"service":     service.ServiceName,
+				"cluster":     service.ClusterName,
+				"launch_type": service.LaunchType,
+				"application": service.Application,
+			},
+			Values: map[string]interface{}{
+				"running_count": service.RunningCount,
+				"desired_count": service.DesiredCount,
+			},
+		})
+	}
+
+	return dataPoints
+}
+
+func (r *ECSReport) generateServicesTable(summary *ServicesSummary) reports.TableData {
+	table := reports.TableData{
+		Title: "ECS Services",
+		Headers: []reports.TableHeader{
+			{Key: "service_name", Label: "Service", Type: "string", Sortable: true, Filterable: true},
+			{Key: "cluster_name", Label: "Cluster", Type: "string", Sortable: true, Filterable: true},
+			{Key: "application", Label: "Application", Type: "string", Sortable: true, Filterable: true},
+			{Key: "launch_type", Label: "Launch Type", Type: "string", Sortable: true, Filterable: true},
+			{Key: "running_count", Label: "Running", Type: "number", Sortable: true, Filterable: false},
+			{Key: "desired_count", Label: "Desired", Type: "number", Sortable: true, Filterable: false},
+			{Key: "cpu", Label: "CPU Units", Type: "string", Sortable: true, Filterable: false},
+			{Key: "memory", Label: "Memory (MiB)", Type: "string", Sortable: true, Filterable: false},
+			{Key: "platform_version", Label: "Platform Version", Type: "string", Sortable: true, Filterable: true},
+		},
+	}
+
+	for _, service := range summary.Services {
+		table.Rows = append(table.Rows, map[string]interface{}{
+			"service_name":     service.ServiceName,
+			"cluster_name":     service.ClusterName,
+			"application":      service.Application,
+			"launch_type":      service.LaunchType,
+			"running_count":    service.RunningCount,
+			"desired_count":    service.DesiredCount,
+			"cpu":              service.CPU,
+			"memory":           service.Memory,
+			"platform_version": service.PlatformVersion,
+		})
+	}
+
+	return table
+}
+
+// generateImagesTable lists each container image in the task definitions,
+// so EOL base images can be found and upgraded
+func (r *ECSReport) generateImagesTable(taskDefinitions []TaskDefinition) reports.TableData {
+	table := reports.TableData{
+		Title: "Container Images",
+		Headers: []reports.TableHeader{
+			{Key: "task_definition", Label: "Task Definition", Type: "string", Sortable: true, Filterable: true},
+			{Key: "container", Label: "Container", Type: "string", Sortable: true, Filterable: true},
+			{Key: "image", Label: "Image", Type: "string", Sortable: true, Filterable: true},
+			{Key: "cpu_architecture", Label: "Architecture", Type: "string", Sortable: true, Filterable: true},
+			{Key: "is_eol", Label: "EOL Base Image", Type: "boolean", Sortable: true, Filterable: true},
+			{Key: "eol_date", Label: "EOL Date", Type: "date", Sortable: true, Filterable: false},
+		},
+	}
+
+	for _, taskDefinition := range taskDefinitions {
+		for _, container := range taskDefinition.Containers {
+			table.Rows = append(table.Rows, map[string]interface{}{
+				"task_definition":  fmt.Sprintf("%s:%d", taskDefinition.Family, taskDefinition.Revision),
+				"container":        container.Name,
+				"image":            container.Image,
+				"cpu_architecture": taskDefinition.CPUArchitecture,
+				"is_eol":           container.IsEOL,
+				"eol_date":         container.EOLDate,
+			})
+		}
+	}
+
+	return table
+}
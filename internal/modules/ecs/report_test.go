@@ -0,0 +1,37 @@
+package ecs
+
+import (
+	"context"
+	"testing"
+
+	"govuk-reports-dashboard/internal/reports"
+	"govuk-reports-dashboard/pkg/logger"
+)
+
+func TestECSReport_GenerateSummary(t *testing.T) {
+	service, _ := newTestECSService(t)
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+
+	summaries, err := NewECSReport(service, log).GenerateSummary(context.Background(), reports.ReportParams{})
+	if err != nil {
+		t.Fatalf("GenerateSummary failed: %v", err)
+	}
+
+	want := []struct {
+		title   string
+		value   string
+		healthy bool
+	}{
+		{"ECS Services", "6", true},
+		{"Services Not At Desired Count", "2", false},
+		{"Fargate / EC2", "4 / 2", true},
+	}
+	if len(summaries) != len(want) {
+		t.Fatalf("Expected %d summaries, got %d", len(want), len(summaries))
+	}
+	for i, w := range want {
+		if summaries[i].GetTitle() != w.title || summaries[i].GetValue() != w.value || summaries[i].IsHealthy() != w.healthy {
+			t.Errorf("Expected %s card %q (healthy %t), got %q (healthy %t)", w.title, w.value, w.healthy, summaries[i].GetValue(), summaries[i].IsHealthy())
+		}
+	}
+}
@@ -0,0 +1,346 @@
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"govuk-reports-dashboard/internal/config"
+	awsclient "govuk-reports-dashboard/pkg/aws"
+	"govuk-reports-dashboard/pkg/logger"
+	"govuk-reports-dashboard/pkg/tracing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+// DescribeServicesBatchSize is the most services DescribeServices accepts
+// in one call
+const DescribeServicesBatchSize = 10
+
+// TaskDefinitionWorkers is how many task definitions are described at once
+const TaskDefinitionWorkers = 10
+
+type ECSService struct {
+	client *ecs.Client
+	config *config.Config
+	logger *logger.Logger
+}
+
+// NewECSService creates a new ECS service instance using the AWS client's
+// shared ECS client
+func NewECSService(awsClient *awsclient.Client, config *config.Config, logger *logger.Logger) *ECSService {
+	return &ECSService{
+		client: awsClient.NewServiceClient(awsclient.ServiceECS).(*ecs.Client),
+		config: config,
+		logger: logger,
+	}
+}
+
+// GetAllServices lists every service in every ECS cluster in the region,
+// with its tags and the CPU and memory of its task definition
+func (s *ECSService) GetAllServices(ctx context.Context) (*ServicesSummary, error) {
+	ctx, span := tracing.Start(ctx, "ecs.get_all_services")
+	defer span.End()
+
+	log := s.logger.ForContext(ctx)
+	log.Info().Msg("Discovering ECS services")
+
+	clusterNames, services, err := s.listServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	taskDefinitions := s.describeTaskDefinitions(ctx, services)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	for i := range services {
+		if taskDefinition, exists := taskDefinitions[services[i].TaskDefinitionARN]; exists {
+			services[i].CPU = aws.ToString(taskDefinition.Cpu)
+			services[i].Memory = aws.ToString(taskDefinition.Memory)
+		}
+	}
+
+	summary := generateServicesSummary(clusterNames, services)
+
+	log.WithFields(map[string]interface{}{
+		"total_clusters":     summary.TotalClusters,
+		"total_services":     summary.TotalServices,
+		"unhealthy_services": summary.UnhealthyServices,
+	}).Info().Msg("ECS service discovery completed")
+
+	return summary, nil
+}
+
+// GetAllTaskDefinitions returns the task definitions used by ECS services,
+// with their runtime platform and whether their images are end-of-life
+// base images
+func (s *ECSService) GetAllTaskDefinitions(ctx context.Context) ([]TaskDefinition, error) {
+	ctx, span := tracing.Start(ctx, "ecs.get_all_task_definitions")
+	defer span.End()
+
+	s.logger.ForContext(ctx).Info().Msg("Discovering ECS task definitions")
+
+	_, services, err := s.listServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	described := s.describeTaskDefinitions(ctx, services)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	taskDefinitions := make([]TaskDefinition, 0, len(described))
+	for _, taskDefinition := range described {
+		taskDefinitions = append(taskDefinitions, convertTaskDefinition(taskDefinition, now))
+	}
+	sort.Slice(taskDefinitions, func(i, j int) bool {
+		return taskDefinitions[i].TaskDefinitionARN < taskDefinitions[j].TaskDefinitionARN
+	})
+
+	return taskDefinitions, nil
+}
+
+// IsAvailable checks the clusters can be listed
+func (s *ECSService) IsAvailable(ctx context.Context) error {
+	_, err := s.client.ListClusters(ctx, &ecs.ListClustersInput{MaxResults: aws.Int32(1)})
+	return err
+}
+
+// listServices lists every cluster and describes the services in each,
+// sorted by cluster then service name
+func (s *ECSService) listServices(ctx context.Context) ([]string, []ContainerService, error) {
+	var clusterARNs []string
+	clusters := ecs.NewListClustersPaginator(s.client, &ecs.ListClustersInput{})
+	for clusters.HasMorePages() {
+		page, err := clusters.NextPage(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list ECS clusters: %w", err)
+		}
+		clusterARNs = append(clusterARNs, page.ClusterArns...)
+	}
+
+	clusterNames := make([]string, 0, len(clusterARNs))
+	var services []ContainerService
+	for _, clusterARN := range clusterARNs {
+		clusterNames = append(clusterNames, resourceName(clusterARN))
+
+		clusterServices, err := s.describeClusterServices(ctx, clusterARN)
+		if err != nil {
+			return nil, nil, err
+		}
+		services = append(services, clusterServices...)
+	}
+
+	sort.Strings(clusterNames)
+	sort.Slice(services, func(i, j int) bool {
+		if services[i].ClusterName != services[j].ClusterName {
+			return services[i].ClusterName < services[j].ClusterName
+		}
+		return services[i].ServiceName < services[j].ServiceName
+	})
+
+	return clusterNames, services, nil
+}
+
+// describeClusterServices describes the services in a cluster,
+// DescribeServicesBatchSize at a time
+func (s *ECSService) describeClusterServices(ctx context.Context, clusterARN string) ([]ContainerService, error) {
+	var serviceARNs []string
+	paginator := ecs.NewListServicesPaginator(s.client, &ecs.ListServicesInput{Cluster: aws.String(clusterARN)})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list services in ECS cluster %s: %w", resourceName(clusterARN), err)
+		}
+		serviceARNs = append(serviceARNs, page.ServiceArns...)
+	}
+
+	services := make([]ContainerService, 0, len(serviceARNs))
+	for batch := range slices.Chunk(serviceARNs, DescribeServicesBatchSize) {
+		result, err := s.client.DescribeServices(ctx, &ecs.DescribeServicesInput{
+			Cluster:  aws.String(clusterARN),
+			Services: batch,
+			Include:  []types.ServiceField{types.ServiceFieldTags},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe services in ECS cluster %s: %w", resourceName(clusterARN), err)
+		}
+		for _, failure := range result.Failures {
+			s.logger.ForContext(ctx).WithFields(map[string]interface{}{
+				"service_arn": aws.ToString(failure.Arn),
+				"reason":      aws.ToString(failure.Reason),
+			}).Warn().Msg("Failed to describe ECS service")
+		}
+		for _, service := range result.Services {
+			services = append(services, convertService(service))
+		}
+	}
+
+	return services, nil
+}
+
+// describeTaskDefinitions describes each task definition used by services,
+// keyed by ARN. Task definitions that can't be described are left out.
+func (s *ECSService) describeTaskDefinitions(ctx context.Context, services []ContainerService) map[string]types.TaskDefinition {
+	var arns []string
+	for _, service := range services {
+		if service.TaskDefinitionARN != "" && !slices.Contains(arns, service.TaskDefinitionARN) {
+			arns = append(arns, service.TaskDefinitionARN)
+		}
+	}
+
+	var mu sync.Mutex
+	taskDefinitions := make(map[string]types.TaskDefinition, len(arns))
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < min(TaskDefinitionWorkers, len(arns)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for arn := range jobs {
+				result, err := s.client.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{TaskDefinition: aws.String(arn)})
+				if err != nil || result.TaskDefinition == nil {
+					s.logger.ForContext(ctx).WithError(err).WithField("task_definition_arn", arn).Warn().Msg("Failed to describe ECS task definition")
+					continue
+				}
+				mu.Lock()
+				taskDefinitions[arn] = *result.TaskDefinition
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, arn := range arns {
+		jobs <- arn
+	}
+	close(jobs)
+	wg.Wait()
+
+	return taskDefinitions
+}
+
+// convertService converts a service from DescribeServices
+func convertService(service types.Service) ContainerService {
+	converted := ContainerService{
+		ServiceName:       aws.ToString(service.ServiceName),
+		ServiceARN:        aws.ToString(service.ServiceArn),
+		ClusterName:       resourceName(aws.ToString(service.ClusterArn)),
+		Status:            aws.ToString(service.Status),
+		TaskDefinitionARN: aws.ToString(service.TaskDefinition),
+		RunningCount:      service.RunningCount,
+		DesiredCount:      service.DesiredCount,
+		PendingCount:      service.PendingCount,
+		LaunchType:        launchType(service),
+		PlatformVersion:   aws.ToString(service.PlatformVersion),
+		Tags:              make(map[string]string),
+	}
+	for _, tag := range service.Tags {
+		converted.Tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	converted.Application = converted.Tags["system"]
+	return converted
+}
+
+// launchType returns the service's launch type. Services using a capacity
+// provider strategy have none, so are FARGATE if any provider is Fargate
+// and EC2 otherwise.
+func launchType(service types.Service) string {
+	if service.LaunchType != "" {
+		return string(service.LaunchType)
+	}
+	for _, item := range service.CapacityProviderStrategy {
+		if strings.HasPrefix(aws.ToString(item.CapacityProvider), LaunchTypeFargate) {
+			return LaunchTypeFargate
+		}
+	}
+	return LaunchTypeEC2
+}
+
+// convertTaskDefinition converts a task definition from
+// DescribeTaskDefinition, checking each container's image against
+// baseImageEOLData
+func convertTaskDefinition(taskDefinition types.TaskDefinition, now time.Time) TaskDefinition {
+	converted := TaskDefinition{
+		TaskDefinitionARN: aws.ToString(taskDefinition.TaskDefinitionArn),
+		Family:            aws.ToString(taskDefinition.Family),
+		Revision:          taskDefinition.Revision,
+		CPU:               aws.ToString(taskDefinition.Cpu),
+		Memory:            aws.ToString(taskDefinition.Memory),
+		Containers:        make([]ContainerImage, 0, len(taskDefinition.ContainerDefinitions)),
+	}
+	if platform := taskDefinition.RuntimePlatform; platform != nil {
+		converted.CPUArchitecture = string(platform.CpuArchitecture)
+		converted.OperatingSystemFamily = string(platform.OperatingSystemFamily)
+	}
+
+	for _, container := range taskDefinition.ContainerDefinitions {
+		image := ContainerImage{
+			Name:  aws.ToString(container.Name),
+			Image: aws.ToString(container.Image),
+		}
+		image.IsEOL, image.EOLDate = checkBaseImageEOL(image.Image, now)
+		if image.IsEOL {
+			converted.UsesEOLBaseImage = true
+		}
+		converted.Containers = append(converted.Containers, image)
+	}
+
+	return converted
+}
+
+// generateServicesSummary counts services by launch type and health, in
+// total and for each cluster
+func generateServicesSummary(clusterNames []string, services []ContainerService) *ServicesSummary {
+	summary := &ServicesSummary{
+		TotalClusters: len(clusterNames),
+		TotalServices: len(services),
+		Services:      services,
+		Clusters:      make([]ClusterSummary, len(clusterNames)),
+		LastUpdated:   time.Now(),
+	}
+
+	clusters := make(map[string]*ClusterSummary, len(clusterNames))
+	for i, name := range clusterNames {
+		summary.Clusters[i].ClusterName = name
+		clusters[name] = &summary.Clusters[i]
+	}
+
+	for _, service := range services {
+		cluster, exists := clusters[service.ClusterName]
+		if !exists {
+			cluster = &ClusterSummary{}
+		}
+		cluster.ServiceCount += 1
+		cluster.RunningTasks += service.RunningCount
+		cluster.DesiredTasks += service.DesiredCount
+
+		switch service.LaunchType {
+		case LaunchTypeFargate:
+			summary.FargateServices += 1
+			cluster.FargateServices += 1
+		case LaunchTypeEC2:
+			summary.EC2Services += 1
+			cluster.EC2Services += 1
+		}
+		if !service.AtDesiredCount() {
+			summary.UnhealthyServices += 1
+			cluster.UnhealthyServices += 1
+		}
+	}
+
+	return summary
+}
+
+// resourceName returns the name at the end of an ECS ARN, such as the
+// cluster name in arn:aws:ecs:eu-west-2:123456789012:cluster/govuk
+func resourceName(arn string) string {
+	return arn[strings.LastIndex(arn, "/")+1:]
+}
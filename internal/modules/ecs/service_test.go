@@ -0,0 +1,239 @@
+package ecs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"govuk-reports-dashboard/internal/config"
+	awsclient "govuk-reports-dashboard/pkg/aws"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+)
+
+const ecsTargetPrefix = "AmazonEC2ContainerServiceV20141113."
+
+// testClusters are the services in each test cluster
+var testClusters = map[string][]string{
+	"govuk-apps":     {"publisher", "content-store", "router"},
+	"govuk-internal": {"search-api", "signon", "asset-manager"},
+}
+
+// testServices are the DescribeServices results for each service
+var testServices = map[string]string{
+	"publisher":     `{"launchType": "FARGATE", "platformVersion": "1.4.0", "runningCount": 2, "desiredCount": 2, "taskDefinition": "arn:aws:ecs:eu-west-2:123456789012:task-definition/publisher:7", "tags": [{"key": "system", "value": "publisher"}]}`,
+	"content-store": `{"launchType": "FARGATE", "platformVersion": "1.4.0", "runningCount": 1, "desiredCount": 3, "taskDefinition": "arn:aws:ecs:eu-west-2:123456789012:task-definition/content-store:12"}`,
+	"router":        `{"launchType": "EC2", "runningCount": 4, "desiredCount": 4, "taskDefinition": "arn:aws:ecs:eu-west-2:123456789012:task-definition/router:3"}`,
+	"search-api":    `{"capacityProviderStrategy": [{"capacityProvider": "FARGATE_SPOT", "weight": 1}], "runningCount": 2, "desiredCount": 2, "taskDefinition": "arn:aws:ecs:eu-west-2:123456789012:task-definition/search-api:40"}`,
+	"signon":        `{"launchType": "FARGATE", "runningCount": 0, "desiredCount": 1, "taskDefinition": "arn:aws:ecs:eu-west-2:123456789012:task-definition/signon:5"}`,
+	"asset-manager": `{"capacityProviderStrategy": [{"capacityProvider": "govuk-asg", "weight": 1}], "runningCount": 1, "desiredCount": 1, "taskDefinition": "arn:aws:ecs:eu-west-2:123456789012:task-definition/publisher:7"}`,
+}
+
+// testTaskDefinition is the DescribeTaskDefinition result for every task
+// definition, apart from the image of its app container
+const testTaskDefinition = `{"taskDefinition": {"taskDefinitionArn": %q, "family": %q, "revision": %s, "cpu": "512", "memory": "1024",
+	"runtimePlatform": {"cpuArchitecture": "ARM64", "operatingSystemFamily": "LINUX"},
+	"containerDefinitions": [{"name": "app", "image": %q}, {"name": "nginx", "image": "public.ecr.aws/nginx/nginx:1.27"}]}}`
+
+// testImages are the app container images of each task definition family
+var testImages = map[string]string{
+	"publisher":     "123456789012.dkr.ecr.eu-west-2.amazonaws.com/publisher:release-123",
+	"content-store": "ruby:2.7-slim",
+	"router":        "golang:1.24",
+	"search-api":    "python:3.12-slim",
+	"signon":        "docker.io/library/node:14.21.3-alpine",
+}
+
+// ecsCalls records the calls made to the test ECS endpoint
+type ecsCalls struct {
+	mu                   sync.Mutex
+	describeServices     [][]string
+	describeServicesTags bool
+	taskDefinitions      []string
+}
+
+func newTestECSService(t *testing.T) (*ECSService, *ecsCalls) {
+	t.Helper()
+
+	calls := &ecsCalls{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var input struct {
+			Cluster        string   `json:"cluster"`
+			NextToken      string   `json:"nextToken"`
+			Services       []string `json:"services"`
+			Include        []string `json:"include"`
+			TaskDefinition string   `json:"taskDefinition"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		switch strings.TrimPrefix(r.Header.Get("X-Amz-Target"), ecsTargetPrefix) {
+		case "ListClusters":
+			// The clusters are returned a page at a time
+			if input.NextToken == "" {
+				w.Write([]byte(`{"clusterArns": ["arn:aws:ecs:eu-west-2:123456789012:cluster/govuk-internal"], "nextToken": "page-2"}`))
+			} else {
+				w.Write([]byte(`{"clusterArns": ["arn:aws:ecs:eu-west-2:123456789012:cluster/govuk-apps"]}`))
+			}
+		case "ListServices":
+			cluster := resourceName(input.Cluster)
+			var arns []string
+			for _, name := range testClusters[cluster] {
+				arns = append(arns, fmt.Sprintf("%q", "arn:aws:ecs:eu-west-2:123456789012:service/"+cluster+"/"+name))
+			}
+			fmt.Fprintf(w, `{"serviceArns": [%s]}`, strings.Join(arns, ","))
+		case "DescribeServices":
+			calls.mu.Lock()
+			calls.describeServices = append(calls.describeServices, input.Services)
+			calls.describeServicesTags = len(input.Include) == 1 && input.Include[0] == "TAGS"
+			calls.mu.Unlock()
+
+			var services []string
+			for _, arn := range input.Services {
+				name := resourceName(arn)
+				service := strings.TrimSuffix(testServices[name], "}")
+				services = append(services, fmt.Sprintf(`%s, "serviceName": %q, "serviceArn": %q, "clusterArn": %q, "status": "ACTIVE"}`,
+					service, name, arn, input.Cluster))
+			}
+			fmt.Fprintf(w, `{"services": [%s], "failures": []}`, strings.Join(services, ","))
+		case "DescribeTaskDefinition":
+			calls.mu.Lock()
+			calls.taskDefinitions = append(calls.taskDefinitions, input.TaskDefinition)
+			calls.mu.Unlock()
+
+			family, revision, _ := strings.Cut(resourceName(input.TaskDefinition), ":")
+			fmt.Fprintf(w, testTaskDefinition, input.TaskDefinition, family, revision, testImages[family])
+		default:
+			t.Errorf("Unexpected request %s", r.Header.Get("X-Amz-Target"))
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	awsClient := awsclient.NewClientWithCostExplorer(aws.Config{
+		Region:       "eu-west-2",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}, nil, log)
+
+	return NewECSService(awsClient, &config.Config{}, log), calls
+}
+
+func TestECSService_GetAllServices(t *testing.T) {
+	service, calls := newTestECSService(t)
+
+	summary, err := service.GetAllServices(context.Background())
+	if err != nil {
+		t.Fatalf("GetAllServices failed: %v", err)
+	}
+
+	if summary.TotalClusters != 2 || summary.TotalServices != 6 {
+		t.Fatalf("Expected 6 services in 2 clusters, got %d in %d", summary.TotalServices, summary.TotalClusters)
+	}
+	if len(calls.describeServices) != 2 || !calls.describeServicesTags {
+		t.Errorf("Expected services described with tags once per cluster, got %v", calls.describeServices)
+	}
+	// publisher:7 is shared by two services, so is only described once
+	if len(calls.taskDefinitions) != 5 {
+		t.Errorf("Expected 5 task definitions described, got %v", calls.taskDefinitions)
+	}
+
+	var names []string
+	for _, s := range summary.Services {
+		names = append(names, s.ClusterName+"/"+s.ServiceName)
+	}
+	want := "govuk-apps/content-store govuk-apps/publisher govuk-apps/router govuk-internal/asset-manager govuk-internal/search-api govuk-internal/signon"
+	if strings.Join(names, " ") != want {
+		t.Errorf("Expected services sorted by cluster and name, got %v", names)
+	}
+
+	publisher := summary.Services[1]
+	if publisher.LaunchType != LaunchTypeFargate || publisher.PlatformVersion != "1.4.0" || publisher.Application != "publisher" {
+		t.Errorf("Unexpected publisher service %+v", publisher)
+	}
+	if publisher.CPU != "512" || publisher.Memory != "1024" || publisher.Status != "ACTIVE" {
+		t.Errorf("Expected publisher's task size and status, got %+v", publisher)
+	}
+	if summary.Services[3].LaunchType != LaunchTypeEC2 || summary.Services[4].LaunchType != LaunchTypeFargate {
+		t.Errorf("Expected launch types from capacity providers, got %s and %s", summary.Services[3].LaunchType, summary.Services[4].LaunchType)
+	}
+
+	if summary.FargateServices != 4 || summary.EC2Services != 2 {
+		t.Errorf("Expected 4 Fargate and 2 EC2 services, got %d and %d", summary.FargateServices, summary.EC2Services)
+	}
+	if summary.UnhealthyServices != 2 {
+		t.Errorf("Expected content-store and signon below their desired count, got %d", summary.UnhealthyServices)
+	}
+
+	apps := summary.Clusters[0]
+	if apps.ClusterName != "govuk-apps" || apps.ServiceCount != 3 || apps.RunningTasks != 7 || apps.DesiredTasks != 9 || apps.UnhealthyServices != 1 {
+		t.Errorf("Unexpected govuk-apps cluster summary %+v", apps)
+	}
+}
+
+func TestECSService_GetAllTaskDefinitions(t *testing.T) {
+	service, _ := newTestECSService(t)
+
+	taskDefinitions, err := service.GetAllTaskDefinitions(context.Background())
+	if err != nil {
+		t.Fatalf("GetAllTaskDefinitions failed: %v", err)
+	}
+	if len(taskDefinitions) != 5 {
+		t.Fatalf("Expected 5 task definitions, got %d", len(taskDefinitions))
+	}
+
+	eol := make(map[string]bool)
+	for _, taskDefinition := range taskDefinitions {
+		eol[taskDefinition.Family] = taskDefinition.UsesEOLBaseImage
+		if taskDefinition.CPUArchitecture != "ARM64" || taskDefinition.OperatingSystemFamily != "LINUX" || len(taskDefinition.Containers) != 2 {
+			t.Errorf("Unexpected task definition %+v", taskDefinition)
+		}
+	}
+	want := map[string]bool{"content-store": true, "publisher": false, "router": false, "search-api": false, "signon": true}
+	for family, wantEOL := range want {
+		if eol[family] != wantEOL {
+			t.Errorf("Expected %s to use an EOL base image: %t, got %t", family, wantEOL, eol[family])
+		}
+	}
+}
+
+func TestCheckBaseImageEOL(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		image      string
+		isEOL      bool
+		hasEOLDate bool
+	}{
+		{"ruby:2.7", true, true},
+		{"ruby:2.7.8-slim-bullseye", true, true},
+		{"ruby:3.3-slim", false, false},
+		{"node:18-alpine", true, true},
+		{"node:20", false, false},
+		{"python:3.9-slim", false, true},
+		{"python:3.10", false, false},
+		{"public.ecr.aws/docker/library/debian:buster-slim", true, true},
+		{"ubuntu:20.04@sha256:abc123", true, true},
+		{"alpine", false, false},
+		{"123456789012.dkr.ecr.eu-west-2.amazonaws.com/ruby:2.7", false, false},
+		{"localhost:5000/node:14", false, false},
+	}
+
+	for _, tt := range tests {
+		isEOL, eolDate := checkBaseImageEOL(tt.image, now)
+		if isEOL != tt.isEOL || (eolDate != nil) != tt.hasEOLDate {
+			t.Errorf("checkBaseImageEOL(%q) = %t, %v, want %t with date %t", tt.image, isEOL, eolDate, tt.isEOL, tt.hasEOLDate)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package eks
+
+import (
+	"net/http"
+	"time"
+
+	"govuk-reports-dashboard/internal/handlers"
+	"govuk-reports-dashboard/internal/models"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EKSHandler handles HTTP requests for EKS endpoints
+type EKSHandler struct {
+	eksService *EKSService
+	logger     *logger.Logger
+}
+
+// NewEKSHandler creates a new EKS handler
+func NewEKSHandler(eksService *EKSService, logger *logger.Logger) *EKSHandler {
+	return &EKSHandler{
+		eksService: eksService,
+		logger:     logger,
+	}
+}
+
+// GetNamespaceCosts handles GET /api/eks/namespace-costs
+// Pass cluster=<name> to override the configured EKS cluster
+func (h *EKSHandler) GetNamespaceCosts(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	clusterName := c.DefaultQuery("cluster", h.eksService.DefaultClusterName())
+
+	log.WithField("cluster", clusterName).Info().Msg("Handling request for EKS namespace costs")
+
+	items, err := h.eksService.GetNamespaceCosts(c.Request.Context(), clusterName)
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to get EKS namespace costs")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get EKS namespace costs",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	response := NamespaceCostsResponse{
+		ClusterName: clusterName,
+		Namespaces:  items,
+		Currency:    h.eksService.ReportingCurrency(),
+		Count:       len(items),
+		GeneratedAt: time.Now(),
+	}
+	for _, item := range items {
+		response.TotalCost += item.EstimatedCost
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,26 @@
+package eks
+
+import (
+	"time"
+)
+
+// NamespaceCostItem attributes EKS cost to a Kubernetes namespace and the
+// GOV.UK application deployed in it
+type NamespaceCostItem struct {
+	Namespace     string  `json:"namespace"`
+	Application   string  `json:"application"`
+	Team          string  `json:"team"`
+	EstimatedCost float64 `json:"estimated_cost"`
+	PodCount      int     `json:"pod_count"`
+	CostPerPod    float64 `json:"cost_per_pod"`
+}
+
+// NamespaceCostsResponse is returned by the namespace costs endpoint
+type NamespaceCostsResponse struct {
+	ClusterName string              `json:"cluster_name,omitempty"`
+	Namespaces  []NamespaceCostItem `json:"namespaces"`
+	TotalCost   float64             `json:"total_cost"`
+	Currency    string              `json:"currency"`
+	Count       int                 `json:"count"`
+	GeneratedAt time.Time           `json:"generated_at"`
+}
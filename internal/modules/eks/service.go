@@ -0,0 +1,123 @@
+package eks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"govuk-reports-dashboard/internal/config"
+	"govuk-reports-dashboard/pkg/aws"
+	"govuk-reports-dashboard/pkg/govuk"
+	"govuk-reports-dashboard/pkg/logger"
+)
+
+// PodCounter reports how many pods are running in a namespace, typically
+// backed by Kubernetes metrics
+type PodCounter interface {
+	CountPods(ctx context.Context, clusterName, namespace string) (int, error)
+}
+
+type EKSService struct {
+	awsClient   *aws.Client
+	govukClient govuk.ApplicationsClient
+	podCounter  PodCounter
+	config      *config.Config
+	logger      *logger.Logger
+}
+
+// NewEKSService creates a new EKS service instance
+func NewEKSService(awsClient *aws.Client, govukClient govuk.ApplicationsClient, config *config.Config, logger *logger.Logger) *EKSService {
+	return &EKSService{
+		awsClient:   awsClient,
+		govukClient: govukClient,
+		config:      config,
+		logger:      logger,
+	}
+}
+
+// SetPodCounter enables pod counts and per-pod costs in namespace results
+func (s *EKSService) SetPodCounter(podCounter PodCounter) {
+	s.podCounter = podCounter
+}
+
+// ReportingCurrency returns the currency namespace costs are reported in
+func (s *EKSService) ReportingCurrency() string {
+	return s.awsClient.ReportingCurrency()
+}
+
+// DefaultClusterName returns the configured EKS cluster, if any
+func (s *EKSService) DefaultClusterName() string {
+	return s.config.AWS.EKSClusterName
+}
+
+// GetNamespaceCosts returns last month's EKS cost per namespace, matching each
+// namespace to the GOV.UK application with the same shortname
+func (s *EKSService) GetNamespaceCosts(ctx context.Context, clusterName string) ([]NamespaceCostItem, error) {
+	s.logger.WithField("cluster", clusterName).Info().Msg("Fetching EKS namespace costs")
+
+	endDate := time.Now()
+	startDate := endDate.AddDate(0, -1, 0)
+
+	costData, err := s.awsClient.GetEKSCostsBySystemTag(ctx, clusterName, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get EKS costs: %w", err)
+	}
+
+	appsByShortname := make(map[string]govuk.Application)
+	apps, err := s.govukClient.GetAllApplications(ctx)
+	if err != nil {
+		// Costs are still useful without application metadata
+		s.logger.WithError(err).Warn().Msg("Failed to fetch applications for namespace matching")
+	}
+	for _, app := range apps {
+		appsByShortname[app.Shortname] = app
+	}
+
+	tagPrefix := aws.TagPrefix()
+	costByNamespace := make(map[string]float64)
+	for _, cost := range costData {
+		namespace := strings.TrimPrefix(cost.Service, tagPrefix)
+		costByNamespace[namespace] += cost.Amount
+	}
+
+	var items []NamespaceCostItem
+	for namespace, cost := range costByNamespace {
+		item := NamespaceCostItem{
+			Namespace:     namespace,
+			EstimatedCost: cost,
+		}
+
+		if app, ok := appsByShortname[namespace]; ok {
+			item.Application = app.AppName
+			item.Team = app.Team
+		}
+
+		if s.podCounter != nil {
+			podCount, err := s.podCounter.CountPods(ctx, clusterName, namespace)
+			if err != nil {
+				s.logger.WithError(err).WithField("namespace", namespace).Warn().Msg("Failed to count pods")
+			} else {
+				item.PodCount = podCount
+			}
+		}
+
+		if item.PodCount > 0 {
+			item.CostPerPod = item.EstimatedCost / float64(item.PodCount)
+		}
+
+		items = append(items, item)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].EstimatedCost > items[j].EstimatedCost
+	})
+
+	s.logger.WithFields(map[string]interface{}{
+		"cluster":         clusterName,
+		"namespace_count": len(items),
+	}).Info().Msg("Successfully fetched EKS namespace costs")
+
+	return items, nil
+}
@@ -1,9 +1,13 @@
 package elasticache
 
 import (
+	"errors"
+	"fmt"
+	"govuk-reports-dashboard/internal/handlers"
 	"govuk-reports-dashboard/internal/models"
 	"govuk-reports-dashboard/pkg/logger"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -20,13 +24,28 @@ func NewElastiCacheHandler(elastiCacheService *ElastiCacheService, logger *logge
 	}
 }
 
+// GetClusters handles GET /api/elasticache/clusters
+// Pass application=<system tag> to only return that application's caches,
+// and include_metrics=true to add each cluster's CloudWatch metrics over
+// period (default 1h)
 func (h *ElastiCacheHandler) GetClusters(c *gin.Context) {
-	h.logger.Info().Msg("Handling request for ElastiCache instances")
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	application := c.Query("application")
+	log.WithField("application", application).Info().Msg("Handling request for ElastiCache instances")
 
-	summary, err := h.elastiCacheService.GetAllClusters(c.Request.Context())
+	includeMetrics := c.Query("include_metrics") == "true"
+	period := DefaultClusterMetricsPeriod
+	if includeMetrics {
+		var ok bool
+		if period, ok = h.metricsPeriod(c); !ok {
+			return
+		}
+	}
+
+	summary, err := h.elastiCacheService.GetClustersForApplication(c.Request.Context(), application)
 
 	if err != nil {
-		h.logger.WithError(err).Error().Msg("Failed to get ElastiCache Clusters")
+		log.WithError(err).Error().Msg("Failed to get ElastiCache Clusters")
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "internal_server_error",
 			Message: "Failed to get ElastiCache clusters",
@@ -35,12 +54,254 @@ func (h *ElastiCacheHandler) GetClusters(c *gin.Context) {
 		return
 	}
 
-	h.logger.WithField("cluster_count", summary.TotalClusters).Info().Msg("Successfully fetched ElastiCache clusters")
+	if includeMetrics {
+		h.elastiCacheService.PopulateClusterMetrics(c.Request.Context(), summary, period)
+	}
+
+	log.WithField("cluster_count", summary.TotalClusters).Info().Msg("Successfully fetched ElastiCache clusters")
 	c.JSON(http.StatusOK, summary)
 }
 
+// GetClusterMetrics handles GET /api/elasticache/clusters/:id/metrics
+// Pass period=<duration>, such as 6h, for metrics over a period other than
+// the last hour
+func (h *ElastiCacheHandler) GetClusterMetrics(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	clusterID := c.Param("id")
+	log.WithField("cache_cluster_id", clusterID).Info().Msg("Handling request for ElastiCache cluster metrics")
+
+	period, ok := h.metricsPeriod(c)
+	if !ok {
+		return
+	}
+
+	metrics, err := h.elastiCacheService.GetClusterMetrics(c.Request.Context(), clusterID, period)
+	if errors.Is(err, ErrNoMetricData) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: fmt.Sprintf("No metrics found for ElastiCache cluster %s in the last %s", clusterID, period),
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to get ElastiCache cluster metrics")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get ElastiCache cluster metrics",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	log.WithField("cache_cluster_id", clusterID).Info().Msg("Successfully fetched ElastiCache cluster metrics")
+	c.JSON(http.StatusOK, gin.H{
+		"cache_cluster_id": clusterID,
+		"period":           period.String(),
+		"metrics":          metrics,
+	})
+}
+
+// metricsPeriod parses the period query parameter, defaulting to
+// DefaultClusterMetricsPeriod. It responds with 400 and returns false if
+// the period is invalid.
+func (h *ElastiCacheHandler) metricsPeriod(c *gin.Context) (time.Duration, bool) {
+	periodStr := c.Query("period")
+	if periodStr == "" {
+		return DefaultClusterMetricsPeriod, true
+	}
+
+	period, err := time.ParseDuration(periodStr)
+	if err != nil || !validMetricsPeriod(period) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "bad_request",
+			Message: ErrInvalidMetricsPeriod.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return 0, false
+	}
+	return period, true
+}
+
+// GetParameterGroups handles GET /api/elasticache/parameter-groups
+func (h *ElastiCacheHandler) GetParameterGroups(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for ElastiCache parameter group compliance")
+
+	items, err := h.elastiCacheService.GetParameterGroupReport(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to get ElastiCache parameter group compliance")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get ElastiCache parameter group compliance",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	log.WithField("parameter_group_count", len(items)).Info().Msg("Successfully checked ElastiCache parameter groups")
+	c.JSON(http.StatusOK, gin.H{
+		"parameter_groups": items,
+		"count":            len(items),
+	})
+}
+
+// GetMultiAZCompliance handles GET /api/elasticache/multi-az-compliance
+func (h *ElastiCacheHandler) GetMultiAZCompliance(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for ElastiCache Multi-AZ compliance")
+
+	items, err := h.elastiCacheService.GetMultiAZReport(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to get ElastiCache Multi-AZ compliance")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get ElastiCache Multi-AZ compliance",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	nonCompliant := 0
+	for _, item := range items {
+		if !item.IsCompliant {
+			nonCompliant += 1
+		}
+	}
+
+	log.WithField("replication_group_count", len(items)).Info().Msg("Successfully checked ElastiCache Multi-AZ compliance")
+	c.JSON(http.StatusOK, gin.H{
+		"replication_groups": items,
+		"count":              len(items),
+		"non_compliant":      nonCompliant,
+	})
+}
+
+// GetBackupCompliance handles GET /api/elasticache/backup-compliance
+func (h *ElastiCacheHandler) GetBackupCompliance(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for ElastiCache backup compliance")
+
+	items, err := h.elastiCacheService.GetBackupComplianceReport(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to get ElastiCache backup compliance")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get ElastiCache backup compliance",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	nonCompliant := 0
+	for _, item := range items {
+		if !item.IsCompliant {
+			nonCompliant += 1
+		}
+	}
+
+	log.WithField("replication_group_count", len(items)).Info().Msg("Successfully checked ElastiCache backup compliance")
+	c.JSON(http.StatusOK, gin.H{
+		"replication_groups": items,
+		"count":              len(items),
+		"non_compliant":      nonCompliant,
+	})
+}
+
+// GetServerlessScaling handles GET /api/elasticache/serverless-scaling
+func (h *ElastiCacheHandler) GetServerlessScaling(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for ElastiCache serverless scaling limits")
+
+	items, err := h.elastiCacheService.GetServerlessScalingReport(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to get ElastiCache serverless scaling limits")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get ElastiCache serverless scaling limits",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	nearLimit := 0
+	for _, item := range items {
+		if item.IsNearLimit {
+			nearLimit += 1
+		}
+	}
+
+	log.WithField("serverless_cache_count", len(items)).Info().Msg("Successfully checked ElastiCache serverless scaling limits")
+	c.JSON(http.StatusOK, gin.H{
+		"serverless_caches": items,
+		"count":             len(items),
+		"near_limit":        nearLimit,
+	})
+}
+
+// GetOutdatedClusters handles GET /api/elasticache/outdated
+func (h *ElastiCacheHandler) GetOutdatedClusters(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for outdated ElastiCache clusters")
+
+	clusters, err := h.elastiCacheService.GetOutdatedClusters(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to get outdated ElastiCache clusters")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get outdated ElastiCache clusters",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	eol := 0
+	for _, cluster := range clusters {
+		if cluster.IsEOL {
+			eol += 1
+		}
+	}
+
+	log.WithField("cluster_count", len(clusters)).Info().Msg("Successfully checked ElastiCache engine versions")
+	c.JSON(http.StatusOK, gin.H{
+		"clusters": clusters,
+		"count":    len(clusters),
+		"eol":      eol,
+	})
+}
+
+// GetNodeTypeRecommendations handles GET /api/elasticache/node-type-recommendations
+func (h *ElastiCacheHandler) GetNodeTypeRecommendations(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for ElastiCache node type recommendations")
+
+	recommendations, err := h.elastiCacheService.GetNodeTypeRecommendations(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to get ElastiCache node type recommendations")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get ElastiCache node type recommendations",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	var additionalCost float64
+	for _, recommendation := range recommendations {
+		additionalCost += recommendation.EstimatedAdditionalCostMonthly
+	}
+
+	log.WithField("recommendation_count", len(recommendations)).Info().Msg("Successfully checked ElastiCache node types")
+	c.JSON(http.StatusOK, gin.H{
+		"recommendations":                   recommendations,
+		"count":                             len(recommendations),
+		"estimated_additional_cost_monthly": additionalCost,
+	})
+}
+
 func (h *ElastiCacheHandler) GetElastiCachesPage(c *gin.Context) {
-	h.logger.Info().Msg("Serving ElastiCaches table page")
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Serving ElastiCaches table page")
 
 	c.HTML(http.StatusOK, "elasticaches.html", gin.H{
 		"title": "ElastiCaches - GOV.UK Reports Dashboard",
@@ -49,13 +310,14 @@ func (h *ElastiCacheHandler) GetElastiCachesPage(c *gin.Context) {
 
 // GetHealth handles GET /api/elasticache/health - checks if ElastiCache service is available
 func (h *ElastiCacheHandler) GetHealth(c *gin.Context) {
-	h.logger.Info().Msg("Handling ElastiCache health check request")
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling ElastiCache health check request")
 
 	// Try to list instances to verify AWS connectivity
 	_, err := h.elastiCacheService.GetServerlessCaches(c.Request.Context())
 
 	if err != nil {
-		h.logger.WithError(err).Error().Msg("ElastiCache health check failed")
+		log.WithError(err).Error().Msg("ElastiCache health check failed")
 		c.JSON(http.StatusServiceUnavailable, gin.H{
 			"status":  "unhealthy",
 			"service": "elasticache",
@@ -64,7 +326,7 @@ func (h *ElastiCacheHandler) GetHealth(c *gin.Context) {
 		return
 	}
 
-	h.logger.Info().Msg("ElastiCache health check passed")
+	log.Info().Msg("ElastiCache health check passed")
 	c.JSON(http.StatusOK, gin.H{
 		"status":  "healthy",
 		"service": "elasticache",
@@ -11,6 +11,7 @@ type CacheClusterEncyrptionConfig struct {
 
 type ElastiCacheCluster struct {
 	ARN                           string                                `json:"arn"`
+	AccountID                     string                                `json:"account_id,omitempty"`
 	Id                            string                                `json:"cache_cluster_id"`
 	NodeType                      string                                `json:"cache_node_type"`
 	NumCacheNodes                 int32                                 `json:"num_cache_nodes"`
@@ -19,12 +20,39 @@ type ElastiCacheCluster struct {
 	Status                        string                                `json:"status"`
 	EncryptionConfig              CacheClusterEncyrptionConfig          `json:"encryption_config"`
 	ReplicationGroup              string                                `json:"replication_group"`
+	ParameterGroup                string                                `json:"cache_parameter_group"`
 	UnappliedUpdateActionsSummary ElastiCacheUpdateActionsSummary       `json:"update_action_summary"`
 	UnappliedUpdateActions        []ElastiCacheCacheClusterUpdateAction `json:"update_actions"`
+	Application                   string                                `json:"application"`
+	Environment                   string                                `json:"environment"`
+	IsEOL                         bool                                  `json:"is_eol"`
+	EOLDate                       *time.Time                            `json:"eol_date,omitempty"`
+	LatestVersion                 string                                `json:"latest_version,omitempty"`
+	IsOutdated                    bool                                  `json:"is_outdated"`
+
+	// Metrics are only fetched when asked for, as they take a CloudWatch
+	// call per metric
+	Metrics *ElastiCacheMetrics `json:"metrics,omitempty"`
+}
+
+// ElastiCacheMetrics is a cache cluster's CloudWatch metrics over a period.
+// Utilisation is averaged, connections are the peak and the counts are
+// totals.
+type ElastiCacheMetrics struct {
+	CPUUtilization                float64   `json:"cpu_utilization"`
+	DatabaseMemoryUsagePercentage float64   `json:"database_memory_usage_percentage"`
+	CurrConnections               int32     `json:"curr_connections"`
+	EvictedKeys                   int64     `json:"evicted_keys"`
+	CacheHits                     int64     `json:"cache_hits"`
+	CacheMisses                   int64     `json:"cache_misses"`
+	NetworkBytesIn                int64     `json:"network_bytes_in"`
+	NetworkBytesOut               int64     `json:"network_bytes_out"`
+	Timestamp                     time.Time `json:"timestamp"`
 }
 
 type ElastiCacheReplicationGroup struct {
 	ARN                           string                                    `json:"arn"`
+	AccountID                     string                                    `json:"account_id,omitempty"`
 	Id                            string                                    `json:"replication_group_id"`
 	NodeType                      string                                    `json:"cache_node_type"`
 	Status                        string                                    `json:"status"`
@@ -33,18 +61,28 @@ type ElastiCacheReplicationGroup struct {
 	ClusterEnabled                bool                                      `json:"cluster_enabled"`
 	ClusterMode                   string                                    `json:"cluster_mode"`
 	Engine                        string                                    `json:"engine"`
+	EngineVersion                 string                                    `json:"engine_version"`
 	EncryptionConfig              CacheClusterEncyrptionConfig              `json:"encryption_config"`
+	SnapshotRetentionLimit        int32                                     `json:"snapshot_retention_limit"`
 	UnappliedUpdateActionsSummary ElastiCacheUpdateActionsSummary           `json:"update_action_summary"`
 	UnappliedUpdateActions        []ElastiCacheReplicationGroupUpdateAction `json:"update_actions"`
+	Application                   string                                    `json:"application"`
+	Environment                   string                                    `json:"environment"`
+	IsEOL                         bool                                      `json:"is_eol"`
+	EOLDate                       *time.Time                                `json:"eol_date,omitempty"`
+	IsOutdated                    bool                                      `json:"is_outdated"`
 }
 
 type ElastiCacheServerlessCache struct {
 	ARN                string `json:"arn"`
+	AccountID          string `json:"account_id,omitempty"`
 	Name               string `json:"serverless_cache_name"`
 	Status             string `json:"status"`
 	Engine             string `json:"engine"`
 	MajorEngineVersion string `json:"major_engine_version"`
 	FullEngineVersion  string `json:"full_engine_version"`
+	MaxDataStorageGB   int32  `json:"max_data_storage_gb,omitempty"`
+	MaxECPUPerSecond   int32  `json:"max_ecpu_per_second,omitempty"`
 }
 
 type ElastiCacheUpdateActionsSummary struct {
@@ -55,11 +93,13 @@ type ElastiCacheUpdateActionsSummary struct {
 
 type ElastiCacheReplicationGroupUpdateAction struct {
 	ReplicationGroupId string                  `json:"replication_group_id"`
+	AccountID          string                  `json:"account_id,omitempty"`
 	UpdateAction       ElastiCacheUpdateAction `json:"update_action"`
 }
 
 type ElastiCacheCacheClusterUpdateAction struct {
 	CacheClusterId string                  `json:"replication_group_id"`
+	AccountID      string                  `json:"account_id,omitempty"`
 	UpdateAction   ElastiCacheUpdateAction `json:"update_action"`
 }
 
@@ -89,18 +129,197 @@ type ElastiCacheUpdateActionCompletionStatus struct {
 }
 
 type CacheClustersSummary struct {
-	TotalClusters                 int                             `json:"total_clusters"`
-	TotalServerlessCaches         int                             `json:"total_serverless_caches"`
-	TotalNodes                    int32                           `json:"total_nodes"`
-	ValkeyCount                   int                             `json:"valkey_count"`
-	ValkeyNodesCount              int32                           `json:"valkey_nodes_count"`
-	RedisCount                    int                             `json:"redis_count"`
-	RedisNodesCount               int32                           `json:"redis_nodes_count"`
-	MemcachedCount                int                             `json:"memcached_count"`
-	MemcachedNodesCount           int32                           `json:"memcached_nodes_count"`
-	AllCacheClusters              []ElastiCacheCluster            `json:"all_cache_clusters"`
-	ReplicationGroups             []ElastiCacheReplicationGroup   `json:"replication_groups"`
-	NonReplicatedCacheClusters    []ElastiCacheCluster            `json:"non_replicated_cache_clusters"`
-	ServerlessCaches              []ElastiCacheServerlessCache    `json:"serverless_caches"`
-	UnappliedUpdateActionsSummary ElastiCacheUpdateActionsSummary `json:"unapplied_update_actions_summary"`
+	TotalClusters                 int                              `json:"total_clusters"`
+	TotalServerlessCaches         int                              `json:"total_serverless_caches"`
+	TotalNodes                    int32                            `json:"total_nodes"`
+	ValkeyCount                   int                              `json:"valkey_count"`
+	ValkeyNodesCount              int32                            `json:"valkey_nodes_count"`
+	EOLClusters                   int                              `json:"eol_clusters"`
+	OutdatedClusters              int                              `json:"outdated_clusters"`
+	RedisCount                    int                              `json:"redis_count"`
+	RedisNodesCount               int32                            `json:"redis_nodes_count"`
+	MemcachedCount                int                              `json:"memcached_count"`
+	MemcachedNodesCount           int32                            `json:"memcached_nodes_count"`
+	AllCacheClusters              []ElastiCacheCluster             `json:"all_cache_clusters"`
+	ReplicationGroups             []ElastiCacheReplicationGroup    `json:"replication_groups"`
+	NonReplicatedCacheClusters    []ElastiCacheCluster             `json:"non_replicated_cache_clusters"`
+	ServerlessCaches              []ElastiCacheServerlessCache     `json:"serverless_caches"`
+	UnappliedUpdateActionsSummary ElastiCacheUpdateActionsSummary  `json:"unapplied_update_actions_summary"`
+	ByApplication                 map[string]ApplicationCacheStats `json:"by_application"`
+}
+
+// EngineVersionInfo describes the support status of an engine's release
+// line. Unsupported versions have a newer version that should be used; they
+// become end-of-life once AWS ends standard support.
+type EngineVersionInfo struct {
+	MajorVersion  string     `json:"major_version"`
+	LatestVersion string     `json:"latest_version"`
+	IsSupported   bool       `json:"is_supported"`
+	IsEOL         bool       `json:"is_eol"`
+	EOLDate       *time.Time `json:"eol_date,omitempty"`
+}
+
+// ElastiCacheEngineEOL holds version support information for each engine,
+// keyed by major.minor version ("6.2" for Redis 6.2.6, "1.6" for Memcached
+// 1.6.22)
+type ElastiCacheEngineEOL struct {
+	Redis     map[string]EngineVersionInfo `json:"redis"`
+	Valkey    map[string]EngineVersionInfo `json:"valkey"`
+	Memcached map[string]EngineVersionInfo `json:"memcached"`
+}
+
+// ApplicationCacheStats summarises the caches attributed to one application
+type ApplicationCacheStats struct {
+	ClusterCount          int      `json:"cluster_count"`
+	ReplicationGroupCount int      `json:"replication_group_count"`
+	NodeCount             int32    `json:"node_count"`
+	Environments          []string `json:"environments"`
+}
+
+// ParameterGroupComplianceItem is the result of checking one cache parameter
+// group's memory and eviction settings
+type ParameterGroupComplianceItem struct {
+	GroupName    string   `json:"group_name"`
+	AccountID    string   `json:"account_id,omitempty"`
+	ClusterCount int      `json:"cluster_count"`
+	IsCompliant  bool     `json:"is_compliant"`
+	Violations   []string `json:"violations"`
+}
+
+// MultiAZReplicationGroupItem is the result of checking whether one
+// replication group has Multi-AZ enabled. Production groups must.
+type MultiAZReplicationGroupItem struct {
+	GroupID          string `json:"group_id"`
+	AccountID        string `json:"account_id,omitempty"`
+	Engine           string `json:"engine"`
+	Application      string `json:"application"`
+	Environment      string `json:"environment"`
+	MultiAZ          string `json:"multi_az"`
+	IsMultiAZEnabled bool   `json:"is_multi_az_enabled"`
+	NodeCount        int    `json:"node_count"`
+	IsProduction     bool   `json:"is_production"`
+	IsCompliant      bool   `json:"is_compliant"`
+}
+
+// ElastiCacheBackupItem is the result of checking one replication group's
+// automatic backup retention. A SnapshotRetentionLimit of 0 means automatic
+// backups are disabled.
+type ElastiCacheBackupItem struct {
+	GroupID                string `json:"group_id"`
+	AccountID              string `json:"account_id,omitempty"`
+	SnapshotRetentionLimit int32  `json:"snapshot_retention_limit"`
+	IsProduction           bool   `json:"is_production"`
+	IsCompliant            bool   `json:"is_compliant"`
+	Application            string `json:"application"`
+	Environment            string `json:"environment"`
+}
+
+// ServerlessScalingItem compares a serverless cache's peak storage and ECPU
+// use against its configured maximums. A maximum of 0 means no limit is set.
+type ServerlessScalingItem struct {
+	Name                      string  `json:"name"`
+	AccountID                 string  `json:"account_id,omitempty"`
+	MaxStorageGB              int32   `json:"max_storage_gb"`
+	CurrentStorageGB          float64 `json:"current_storage_gb"`
+	StorageUtilizationPercent float64 `json:"storage_utilization_percent"`
+	MaxECPU                   int64   `json:"max_ecpu"`
+	PeakECPUPerSecond         float64 `json:"peak_ecpu_per_second"`
+	ECPUUtilizationPercent    float64 `json:"ecpu_utilization_percent"`
+	IsNearLimit               bool    `json:"is_near_limit"`
+}
+
+// Severity represents how urgently a recommendation should be acted on
+type Severity string
+
+const (
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+// NodeTypeRecommendation suggests a larger node type for a replication group
+// that is under memory pressure or evicting keys
+type NodeTypeRecommendation struct {
+	GroupID                        string   `json:"group_id"`
+	AccountID                      string   `json:"account_id,omitempty"`
+	CurrentNodeType                string   `json:"current_node_type"`
+	RecommendedNodeType            string   `json:"recommended_node_type"`
+	Reason                         string   `json:"reason"`
+	EstimatedAdditionalCostMonthly float64  `json:"estimated_additional_cost_monthly"`
+	Priority                       Severity `json:"priority"`
+}
+
+// replicationGroupMetrics are the CloudWatch metrics for a replication
+// group's member clusters over NodeTypeMetricsPeriod
+type replicationGroupMetrics struct {
+	MaxBytesUsedForCache float64
+	Evictions            float64
+	CacheHits            float64
+}
+
+// CloudWatch GetMetricData request and response shapes
+
+type cwGetMetricDataInput struct {
+	MetricDataQueries []cwMetricDataQuery `json:"MetricDataQueries"`
+	StartTime         int64               `json:"StartTime"`
+	EndTime           int64               `json:"EndTime"`
+	NextToken         string              `json:"NextToken,omitempty"`
+}
+
+type cwMetricDataQuery struct {
+	Id         string       `json:"Id"`
+	MetricStat cwMetricStat `json:"MetricStat"`
+}
+
+type cwMetricStat struct {
+	Metric cwMetric `json:"Metric"`
+	Period int32    `json:"Period"`
+	Stat   string   `json:"Stat"`
+}
+
+type cwMetric struct {
+	Namespace  string        `json:"Namespace"`
+	MetricName string        `json:"MetricName"`
+	Dimensions []cwDimension `json:"Dimensions"`
+}
+
+type cwDimension struct {
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
+}
+
+type cwGetMetricDataOutput struct {
+	MetricDataResults []cwMetricDataResult `json:"MetricDataResults"`
+	NextToken         string               `json:"NextToken"`
+}
+
+type cwMetricDataResult struct {
+	Id     string    `json:"Id"`
+	Values []float64 `json:"Values"`
+}
+
+// CloudWatch GetMetricStatistics request and response shapes
+
+type cwGetMetricStatisticsInput struct {
+	Namespace  string        `json:"Namespace"`
+	MetricName string        `json:"MetricName"`
+	Dimensions []cwDimension `json:"Dimensions"`
+	StartTime  int64         `json:"StartTime"`
+	EndTime    int64         `json:"EndTime"`
+	Period     int32         `json:"Period"`
+	Statistics []string      `json:"Statistics"`
+}
+
+type cwGetMetricStatisticsOutput struct {
+	Label      string        `json:"Label"`
+	Datapoints []cwDatapoint `json:"Datapoints"`
+}
+
+type cwDatapoint struct {
+	// Timestamp is in seconds since the epoch
+	Timestamp float64 `json:"Timestamp"`
+	Average   float64 `json:"Average"`
+	Maximum   float64 `json:"Maximum"`
+	Sum       float64 `json:"Sum"`
 }
@@ -2,10 +2,12 @@ package elasticache
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"govuk-reports-dashboard/internal/reports"
 	"govuk-reports-dashboard/pkg/logger"
+	"govuk-reports-dashboard/pkg/notifications"
 )
 
 type ElastiCacheReport struct {
@@ -36,13 +38,179 @@ func (e *ElastiCacheReport) GetMetadata() reports.ReportMetadata {
 }
 
 func (e *ElastiCacheReport) GenerateSummary(ctx context.Context, params reports.ReportParams) ([]reports.Summary, error) {
-	// TODO
-	return []reports.Summary{}, nil
+	e.logger.Info().Msg("Generating ElastiCache summary for dashboard")
+
+	clusters, err := e.elastiCacheService.GetAllClusters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ElastiCache clusters: %w", err)
+	}
+
+	summaries := e.generatePatchSummaries(clusters)
+
+	parameterGroups, err := e.elastiCacheService.GetParameterGroupReport(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check parameter groups: %w", err)
+	}
+
+	compliant := 0
+	for _, parameterGroup := range parameterGroups {
+		if parameterGroup.IsCompliant {
+			compliant += 1
+		}
+	}
+
+	complianceSummary := e.renderer.CreateSummaryCard(
+		"Parameter Group Compliance",
+		fmt.Sprintf("%d/%d", compliant, len(parameterGroups)),
+		"Parameter groups with safe eviction settings",
+		reports.SummaryTypeHealth,
+		nil,
+	)
+	if compliant < len(parameterGroups) {
+		complianceSummary.(*reports.BasicSummary).SetHealthy(false)
+	}
+	summaries = append(summaries, complianceSummary)
+
+	multiAZItems, err := e.elastiCacheService.GetMultiAZReport(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check Multi-AZ compliance: %w", err)
+	}
+
+	production, productionMultiAZ := 0, 0
+	for _, item := range multiAZItems {
+		if !item.IsProduction {
+			continue
+		}
+		production += 1
+		if item.IsMultiAZEnabled {
+			productionMultiAZ += 1
+		}
+	}
+
+	multiAZSummary := e.renderer.CreateSummaryCard(
+		"Multi-AZ Compliance",
+		fmt.Sprintf("%d/%d", productionMultiAZ, production),
+		"Production replication groups with Multi-AZ",
+		reports.SummaryTypeHealth,
+		nil,
+	)
+	if productionMultiAZ < production {
+		multiAZSummary.(*reports.BasicSummary).SetHealthy(false)
+	}
+	summaries = append(summaries, multiAZSummary)
+
+	backupItems, err := e.elastiCacheService.GetBackupComplianceReport(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check backup compliance: %w", err)
+	}
+
+	productionGroups, productionCompliant, backupsDisabled := 0, 0, 0
+	for _, item := range backupItems {
+		if !item.IsProduction {
+			continue
+		}
+		productionGroups += 1
+		if item.IsCompliant {
+			productionCompliant += 1
+		}
+		if item.SnapshotRetentionLimit == 0 {
+			backupsDisabled += 1
+		}
+	}
+
+	backupSummary := e.renderer.CreateSummaryCard(
+		"Backup Compliance",
+		fmt.Sprintf("%d/%d", productionCompliant, productionGroups),
+		"Production replication groups with automatic backups",
+		reports.SummaryTypeHealth,
+		nil,
+	)
+	if backupsDisabled > 0 {
+		backupSummary.(*reports.BasicSummary).SetHealthy(false)
+	}
+	summaries = append(summaries, backupSummary)
+
+	scalingItems, err := e.elastiCacheService.GetServerlessScalingReport(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check serverless scaling limits: %w", err)
+	}
+
+	nearLimit := 0
+	for _, item := range scalingItems {
+		if item.IsNearLimit {
+			nearLimit += 1
+		}
+	}
+
+	scalingSummary := e.renderer.CreateSummaryCard(
+		"Serverless Scaling",
+		fmt.Sprintf("%d/%d", nearLimit, len(scalingItems)),
+		"Serverless caches near their storage or ECPU limit",
+		reports.SummaryTypeHealth,
+		nil,
+	)
+	if nearLimit > 0 {
+		scalingSummary.(*reports.BasicSummary).SetHealthy(false)
+	}
+	summaries = append(summaries, scalingSummary)
+
+	eolSummary := e.renderer.CreateSummaryCard(
+		"EOL Clusters",
+		e.renderer.FormatNumber(clusters.EOLClusters),
+		fmt.Sprintf("End-of-life engine versions, %d more outdated", clusters.OutdatedClusters),
+		reports.SummaryTypeAlert,
+		nil,
+	)
+	if clusters.EOLClusters > 0 {
+		eolSummary.(*reports.BasicSummary).SetHealthy(false)
+	}
+	summaries = append(summaries, eolSummary)
+
+	return summaries, nil
 }
 
 func (e *ElastiCacheReport) GenerateReport(ctx context.Context, params reports.ReportParams) (reports.ReportData, error) {
-	// TODO
-	return reports.ReportData{}, nil
+	e.logger.Info().Msg("Generating detailed ElastiCache report")
+
+	data := reports.ReportData{
+		Status:      reports.StatusRunning,
+		GeneratedAt: time.Now(),
+	}
+
+	summary, err := e.elastiCacheService.GetAllClusters(ctx)
+	if err != nil {
+		data.Status = reports.StatusFailed
+		data.Errors = append(data.Errors, reports.ReportError{
+			Code:      "ELASTICACHE_FETCH_ERROR",
+			Message:   "Failed to fetch ElastiCache clusters",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+		})
+		return data, nil
+	}
+
+	data.Summary, err = e.GenerateSummary(ctx, params)
+	if err != nil {
+		data.Warnings = append(data.Warnings, reports.ReportWarning{
+			Code:      "SUMMARY_GENERATION_WARNING",
+			Message:   "Failed to generate summary data",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+		})
+	}
+
+	data.DataPoints = e.generateDataPoints(summary)
+	data.Charts = e.generateCharts(summary)
+	data.Tables = e.generateTables(summary)
+
+	data.Status = reports.StatusCompleted
+	e.logger.WithFields(map[string]interface{}{
+		"data_points": len(data.DataPoints),
+		"charts":      len(data.Charts),
+		"tables":      len(data.Tables),
+	}).Info().Msg("Generated detailed ElastiCache report")
+
+	return data, nil
 }
 
 func (e *ElastiCacheReport) IsAvailable(ctx context.Context) bool {
@@ -60,3 +228,368 @@ func (e *ElastiCacheReport) Validate(params reports.ReportParams) error {
 	// ElastiCache reports don't have specific parameter requirements currently
 	return nil
 }
+
+// generatePatchSummaries creates the cluster count and patch compliance
+// summary cards. Unapplied update counts come from the replication groups
+// and standalone clusters they apply to, so each update is counted once.
+// EOLInstances lists the clusters in a report generated by GenerateReport
+// that are end-of-life or outdated, and those with critical updates not
+// applied
+func (e *ElastiCacheReport) EOLInstances(data reports.ReportData) []notifications.EOLInstance {
+	var instances []notifications.EOLInstance
+	for _, point := range data.DataPoints {
+		if point.Labels["type"] != "cache_cluster" {
+			continue
+		}
+
+		eolDate, _ := point.Values["eol_date"].(*time.Time)
+		instance := notifications.EOLInstance{
+			ID:          point.Labels["cluster_id"],
+			Report:      e.GetMetadata().ID,
+			Engine:      point.Labels["engine"],
+			Version:     point.Labels["engine_version"],
+			Application: point.Labels["application"],
+			Environment: point.Labels["environment"],
+			EOLDate:     eolDate,
+		}
+
+		switch {
+		case point.Values["is_eol"] == true:
+			instance.Reason = notifications.EOLReasonEndOfLife
+			instances = append(instances, instance)
+		case point.Values["is_outdated"] == true:
+			instance.Reason = notifications.EOLReasonOutdated
+			instances = append(instances, instance)
+		}
+
+		if critical, _ := point.Values["unapplied_critical_updates"].(int); critical > 0 {
+			instance.Reason = notifications.EOLReasonCriticalPatch
+			instances = append(instances, instance)
+		}
+	}
+	return instances
+}
+
+func (e *ElastiCacheReport) generatePatchSummaries(summary *CacheClustersSummary) []reports.Summary {
+	var summaries []reports.Summary
+
+	summaries = append(summaries, e.renderer.CreateSummaryCard(
+		"Total Clusters",
+		e.renderer.FormatNumber(summary.TotalClusters),
+		fmt.Sprintf("%d Valkey, %d Redis, %d Memcached", summary.ValkeyCount, summary.RedisCount, summary.MemcachedCount),
+		reports.SummaryTypeCount,
+		nil,
+	))
+
+	critical := summary.UnappliedUpdateActionsSummary.TotalUnappliedCriticalUpdateCount
+	criticalSummary := e.renderer.CreateSummaryCard(
+		"Critical Patches",
+		e.renderer.FormatNumber(critical),
+		"Unapplied critical service updates",
+		reports.SummaryTypeAlert,
+		nil,
+	)
+	if critical > 0 {
+		criticalSummary.(*reports.BasicSummary).SetHealthy(false)
+	}
+	summaries = append(summaries, criticalSummary)
+
+	important := summary.UnappliedUpdateActionsSummary.TotalUnappliedImportantUpdateCount
+	importantSummary := e.renderer.CreateSummaryCard(
+		"Important Patches",
+		e.renderer.FormatNumber(important),
+		"Unapplied important service updates",
+		reports.SummaryTypeAlert,
+		nil,
+	)
+	if important > 0 {
+		importantSummary.(*reports.BasicSummary).SetHealthy(false)
+	}
+	summaries = append(summaries, importantSummary)
+
+	patched := 0
+	updateSummaries := clusterUpdateSummaries(summary)
+	for _, cluster := range summary.AllCacheClusters {
+		if updateSummaries[cluster.Id].UnappliedUpdateCount == 0 {
+			patched += 1
+		}
+	}
+
+	compliance := 100.0
+	if len(summary.AllCacheClusters) > 0 {
+		compliance = float64(patched) / float64(len(summary.AllCacheClusters)) * 100
+	}
+
+	complianceSummary := e.renderer.CreateSummaryCard(
+		"Patch Compliance",
+		e.renderer.FormatPercentage(compliance, 0),
+		fmt.Sprintf("%d/%d clusters with no unapplied updates", patched, len(summary.AllCacheClusters)),
+		reports.SummaryTypeHealth,
+		nil,
+	)
+	if patched < len(summary.AllCacheClusters) {
+		complianceSummary.(*reports.BasicSummary).SetHealthy(false)
+	}
+	summaries = append(summaries, complianceSummary)
+
+	return summaries
+}
+
+func (e *ElastiCacheReport) generateDataPoints(summary *CacheClustersSummary) []reports.DataPoint {
+	var dataPoints []reports.DataPoint
+	now := time.Now()
+
+	updateSummaries := clusterUpdateSummaries(summary)
+	for _, cluster := range summary.AllCacheClusters {
+		updates := updateSummaries[cluster.Id]
+		dataPoints = append(dataPoints, reports.DataPoint{
+			Timestamp: now,
+			Labels: map[string]string{
+				"type":              "cache_cluster",
+				"cluster_id":        cluster.Id,
+				"engine":            cluster.Engine,
+				"engine_version":    cluster.EngineVersion,
+				"replication_group": cluster.ReplicationGroup,
+				"application":       cluster.Application,
+				"environment":       cluster.Environment,
+			},
+			Values: map[string]interface{}{
+				"num_cache_nodes":             cluster.NumCacheNodes,
+				"unapplied_updates":           updates.UnappliedUpdateCount,
+				"unapplied_critical_updates":  updates.TotalUnappliedCriticalUpdateCount,
+				"unapplied_important_updates": updates.TotalUnappliedImportantUpdateCount,
+				"is_eol":                      cluster.IsEOL,
+				"eol_date":                    cluster.EOLDate,
+				"is_outdated":                 cluster.IsOutdated,
+			},
+		})
+	}
+
+	for _, replicationGroup := range summary.ReplicationGroups {
+		dataPoints = append(dataPoints, reports.DataPoint{
+			Timestamp: now,
+			Labels: map[string]string{
+				"type":                 "replication_group",
+				"replication_group_id": replicationGroup.Id,
+				"engine":               replicationGroup.Engine,
+				"multi_az":             replicationGroup.MultiAZ,
+				"application":          replicationGroup.Application,
+				"environment":          replicationGroup.Environment,
+			},
+			Values: map[string]interface{}{
+				"member_clusters":             len(replicationGroup.MemberClusters),
+				"snapshot_retention_limit":    replicationGroup.SnapshotRetentionLimit,
+				"unapplied_updates":           replicationGroup.UnappliedUpdateActionsSummary.UnappliedUpdateCount,
+				"unapplied_critical_updates":  replicationGroup.UnappliedUpdateActionsSummary.TotalUnappliedCriticalUpdateCount,
+				"unapplied_important_updates": replicationGroup.UnappliedUpdateActionsSummary.TotalUnappliedImportantUpdateCount,
+			},
+		})
+	}
+
+	return dataPoints
+}
+
+func (e *ElastiCacheReport) generateCharts(summary *CacheClustersSummary) []reports.ChartData {
+	var charts []reports.ChartData
+
+	if summary.TotalClusters > 0 {
+		engineChart := reports.ChartData{
+			Title: "Engine Distribution",
+			Type:  "pie",
+			XAxis: "engine",
+			YAxis: "count",
+		}
+
+		var series reports.ChartSeries
+		series.Name = "Cluster Count"
+		for _, engine := range []struct {
+			label string
+			count int
+		}{
+			{"Valkey", summary.ValkeyCount},
+			{"Redis", summary.RedisCount},
+			{"Memcached", summary.MemcachedCount},
+		} {
+			if engine.count == 0 {
+				continue
+			}
+			series.Data = append(series.Data, reports.ChartPoint{X: engine.label, Y: engine.count})
+		}
+		engineChart.Series = append(engineChart.Series, series)
+		charts = append(charts, engineChart)
+	}
+
+	updates := summary.UnappliedUpdateActionsSummary
+	severityChart := reports.ChartData{
+		Title: "Unapplied Updates by Severity",
+		Type:  "bar",
+		XAxis: "severity",
+		YAxis: "count",
+	}
+
+	var severitySeries reports.ChartSeries
+	severitySeries.Name = "Updates"
+	severitySeries.Data = []reports.ChartPoint{
+		{X: "Critical", Y: updates.TotalUnappliedCriticalUpdateCount},
+		{X: "Important", Y: updates.TotalUnappliedImportantUpdateCount},
+		{X: "Other", Y: updates.UnappliedUpdateCount - updates.TotalUnappliedCriticalUpdateCount - updates.TotalUnappliedImportantUpdateCount},
+	}
+	severityChart.Series = append(severityChart.Series, severitySeries)
+	charts = append(charts, severityChart)
+
+	return charts
+}
+
+func (e *ElastiCacheReport) generateTables(summary *CacheClustersSummary) []reports.TableData {
+	var tables []reports.TableData
+
+	clustersTable := reports.TableData{
+		Title: "Cache Clusters",
+		Headers: []reports.TableHeader{
+			{Key: "cluster_id", Label: "Cluster ID", Type: "string", Sortable: true, Filterable: true},
+			{Key: "application", Label: "Application", Type: "string", Sortable: true, Filterable: true},
+			{Key: "environment", Label: "Environment", Type: "string", Sortable: true, Filterable: true},
+			{Key: "engine", Label: "Engine", Type: "string", Sortable: true, Filterable: true},
+			{Key: "engine_version", Label: "Engine Version", Type: "string", Sortable: true, Filterable: true},
+			{Key: "node_type", Label: "Node Type", Type: "string", Sortable: true, Filterable: true},
+			{Key: "replication_group", Label: "Replication Group", Type: "string", Sortable: true, Filterable: true},
+			{Key: "status", Label: "Status", Type: "string", Sortable: true, Filterable: true},
+			{Key: "encryption", Label: "Encryption", Type: "string", Sortable: true, Filterable: true},
+			{Key: "unapplied_updates", Label: "Unapplied Updates", Type: "number", Sortable: true, Filterable: false},
+			{Key: "patch_status", Label: "Patch Status", Type: "string", Sortable: true, Filterable: true},
+		},
+	}
+
+	updateSummaries := clusterUpdateSummaries(summary)
+	for _, cluster := range summary.AllCacheClusters {
+		updates := updateSummaries[cluster.Id]
+		row := map[string]interface{}{
+			"cluster_id":        cluster.Id,
+			"application":       cluster.Application,
+			"environment":       cluster.Environment,
+			"engine":            cluster.Engine,
+			"engine_version":    cluster.EngineVersion,
+			"node_type":         cluster.NodeType,
+			"replication_group": cluster.ReplicationGroup,
+			"status":            cluster.Status,
+			"encryption":        encryptionStatus(cluster.EncryptionConfig),
+			"unapplied_updates": updates.UnappliedUpdateCount,
+			"patch_status":      patchStatus(updates),
+		}
+		clustersTable.Rows = append(clustersTable.Rows, row)
+	}
+
+	tables = append(tables, clustersTable)
+
+	replicationGroupsTable := reports.TableData{
+		Title: "Replication Groups",
+		Headers: []reports.TableHeader{
+			{Key: "replication_group_id", Label: "Replication Group ID", Type: "string", Sortable: true, Filterable: true},
+			{Key: "application", Label: "Application", Type: "string", Sortable: true, Filterable: true},
+			{Key: "environment", Label: "Environment", Type: "string", Sortable: true, Filterable: true},
+			{Key: "engine", Label: "Engine", Type: "string", Sortable: true, Filterable: true},
+			{Key: "member_clusters", Label: "Member Clusters", Type: "number", Sortable: true, Filterable: false},
+			{Key: "unapplied_updates", Label: "Unapplied Updates", Type: "number", Sortable: true, Filterable: false},
+		},
+	}
+
+	for _, replicationGroup := range summary.ReplicationGroups {
+		row := map[string]interface{}{
+			"replication_group_id": replicationGroup.Id,
+			"application":          replicationGroup.Application,
+			"environment":          replicationGroup.Environment,
+			"engine":               replicationGroup.Engine,
+			"member_clusters":      len(replicationGroup.MemberClusters),
+			"unapplied_updates":    replicationGroup.UnappliedUpdateActionsSummary.UnappliedUpdateCount,
+		}
+		replicationGroupsTable.Rows = append(replicationGroupsTable.Rows, row)
+	}
+
+	tables = append(tables, replicationGroupsTable)
+
+	versionComplianceTable := reports.TableData{
+		Title: "Version Compliance",
+		Headers: []reports.TableHeader{
+			{Key: "cluster_id", Label: "Cluster ID", Type: "string", Sortable: true, Filterable: true},
+			{Key: "application", Label: "Application", Type: "string", Sortable: true, Filterable: true},
+			{Key: "engine", Label: "Engine", Type: "string", Sortable: true, Filterable: true},
+			{Key: "engine_version", Label: "Engine Version", Type: "string", Sortable: true, Filterable: true},
+			{Key: "latest_version", Label: "Latest Version", Type: "string", Sortable: true, Filterable: false},
+			{Key: "status", Label: "Status", Type: "string", Sortable: true, Filterable: true},
+			{Key: "eol_date", Label: "EOL Date", Type: "date", Sortable: true, Filterable: false},
+		},
+	}
+
+	for _, cluster := range summary.AllCacheClusters {
+		status := "Current"
+		if cluster.IsEOL {
+			status = "EOL"
+		} else if cluster.IsOutdated {
+			status = "Outdated"
+		}
+
+		row := map[string]interface{}{
+			"cluster_id":     cluster.Id,
+			"application":    cluster.Application,
+			"engine":         cluster.Engine,
+			"engine_version": cluster.EngineVersion,
+			"latest_version": cluster.LatestVersion,
+			"status":         status,
+			"eol_date":       cluster.EOLDate,
+		}
+		versionComplianceTable.Rows = append(versionComplianceTable.Rows, row)
+	}
+
+	tables = append(tables, versionComplianceTable)
+
+	return tables
+}
+
+// clusterUpdateSummaries returns the unapplied updates for each cluster by
+// ID. Updates to a replication group apply to all of its member clusters.
+func clusterUpdateSummaries(summary *CacheClustersSummary) map[string]ElastiCacheUpdateActionsSummary {
+	replicationGroupUpdates := make(map[string]ElastiCacheUpdateActionsSummary, len(summary.ReplicationGroups))
+	for _, replicationGroup := range summary.ReplicationGroups {
+		replicationGroupUpdates[replicationGroup.Id] = replicationGroup.UnappliedUpdateActionsSummary
+	}
+
+	updates := make(map[string]ElastiCacheUpdateActionsSummary, len(summary.AllCacheClusters))
+	for _, cluster := range summary.AllCacheClusters {
+		clusterUpdates := cluster.UnappliedUpdateActionsSummary
+		if groupUpdates, exists := replicationGroupUpdates[cluster.ReplicationGroup]; exists {
+			clusterUpdates.UnappliedUpdateCount += groupUpdates.UnappliedUpdateCount
+			clusterUpdates.TotalUnappliedCriticalUpdateCount += groupUpdates.TotalUnappliedCriticalUpdateCount
+			clusterUpdates.TotalUnappliedImportantUpdateCount += groupUpdates.TotalUnappliedImportantUpdateCount
+		}
+		updates[cluster.Id] = clusterUpdates
+	}
+	return updates
+}
+
+// patchStatus describes the most severe unapplied update
+func patchStatus(updates ElastiCacheUpdateActionsSummary) string {
+	switch {
+	case updates.TotalUnappliedCriticalUpdateCount > 0:
+		return "Critical updates pending"
+	case updates.TotalUnappliedImportantUpdateCount > 0:
+		return "Important updates pending"
+	case updates.UnappliedUpdateCount > 0:
+		return "Updates pending"
+	default:
+		return "Up to date"
+	}
+}
+
+// encryptionStatus describes which encryption a cluster has enabled
+func encryptionStatus(config CacheClusterEncyrptionConfig) string {
+	switch {
+	case config.AtRest && config.InTransit:
+		return "At rest and in transit"
+	case config.AtRest:
+		return "At rest only"
+	case config.InTransit:
+		return "In transit only"
+	default:
+		return "None"
+	}
+}
@@ -0,0 +1,217 @@
+package elasticache
+
+import (
+	"testing"
+	"time"
+
+	"govuk-reports-dashboard/internal/reports"
+	"govuk-reports-dashboard/pkg/logger"
+	"govuk-reports-dashboard/pkg/notifications"
+)
+
+func TestElastiCacheReport_PatchSummariesAndCharts(t *testing.T) {
+	critical := ElastiCacheUpdateActionsSummary{UnappliedUpdateCount: 1, TotalUnappliedCriticalUpdateCount: 1}
+
+	tests := []struct {
+		name             string
+		summary          *CacheClustersSummary
+		wantTotal        string
+		wantCritical     string
+		wantImportant    string
+		wantCompliance   string
+		wantHealthy      bool
+		wantEngines      map[string]int
+		wantSeverity     map[string]int
+		wantPatchStatus  map[string]string
+		wantReplicatedDP int
+	}{
+		{
+			name:            "no clusters",
+			summary:         &CacheClustersSummary{},
+			wantTotal:       "0",
+			wantCritical:    "0",
+			wantImportant:   "0",
+			wantCompliance:  "100%",
+			wantHealthy:     true,
+			wantSeverity:    map[string]int{"Critical": 0, "Important": 0, "Other": 0},
+			wantPatchStatus: map[string]string{},
+		},
+		{
+			name: "mixed engines",
+			summary: &CacheClustersSummary{
+				TotalClusters:  4,
+				ValkeyCount:    2,
+				RedisCount:     1,
+				MemcachedCount: 1,
+				AllCacheClusters: []ElastiCacheCluster{
+					{Id: "sessions-001", Engine: "valkey", ReplicationGroup: "sessions"},
+					{Id: "sessions-002", Engine: "valkey", ReplicationGroup: "sessions"},
+					{Id: "locks-001", Engine: "redis"},
+					{Id: "memcached-001", Engine: "memcached", UnappliedUpdateActionsSummary: ElastiCacheUpdateActionsSummary{UnappliedUpdateCount: 1}},
+				},
+				ReplicationGroups: []ElastiCacheReplicationGroup{
+					{Id: "sessions", Engine: "valkey", UnappliedUpdateActionsSummary: ElastiCacheUpdateActionsSummary{UnappliedUpdateCount: 1, TotalUnappliedImportantUpdateCount: 1}},
+				},
+				UnappliedUpdateActionsSummary: ElastiCacheUpdateActionsSummary{UnappliedUpdateCount: 2, TotalUnappliedImportantUpdateCount: 1},
+			},
+			wantTotal:      "4",
+			wantCritical:   "0",
+			wantImportant:  "1",
+			wantCompliance: "25%",
+			wantHealthy:    false,
+			wantEngines:    map[string]int{"Valkey": 2, "Redis": 1, "Memcached": 1},
+			wantSeverity:   map[string]int{"Critical": 0, "Important": 1, "Other": 1},
+			wantPatchStatus: map[string]string{
+				"sessions-001":  "Important updates pending",
+				"sessions-002":  "Important updates pending",
+				"locks-001":     "Up to date",
+				"memcached-001": "Updates pending",
+			},
+			wantReplicatedDP: 1,
+		},
+		{
+			name: "every cluster has a critical update",
+			summary: &CacheClustersSummary{
+				TotalClusters: 2,
+				RedisCount:    2,
+				AllCacheClusters: []ElastiCacheCluster{
+					{Id: "cache-001", Engine: "redis", UnappliedUpdateActionsSummary: critical},
+					{Id: "cache-002", Engine: "redis", UnappliedUpdateActionsSummary: critical},
+				},
+				UnappliedUpdateActionsSummary: ElastiCacheUpdateActionsSummary{UnappliedUpdateCount: 2, TotalUnappliedCriticalUpdateCount: 2},
+			},
+			wantTotal:      "2",
+			wantCritical:   "2",
+			wantImportant:  "0",
+			wantCompliance: "0%",
+			wantHealthy:    false,
+			wantEngines:    map[string]int{"Redis": 2},
+			wantSeverity:   map[string]int{"Critical": 2, "Important": 0, "Other": 0},
+			wantPatchStatus: map[string]string{
+				"cache-001": "Critical updates pending",
+				"cache-002": "Critical updates pending",
+			},
+		},
+	}
+
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	report := NewElastiCacheReport(&ElastiCacheService{eolData: getElastiCacheVersionData(), logger: log}, log)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summaries := report.generatePatchSummaries(tt.summary)
+			if len(summaries) != 4 {
+				t.Fatalf("Expected 4 summary cards, got %d", len(summaries))
+			}
+			cards := make(map[string]reports.Summary)
+			for _, summary := range summaries {
+				cards[summary.GetTitle()] = summary
+			}
+			for title, want := range map[string]string{
+				"Total Clusters":    tt.wantTotal,
+				"Critical Patches":  tt.wantCritical,
+				"Important Patches": tt.wantImportant,
+				"Patch Compliance":  tt.wantCompliance,
+			} {
+				if got := cards[title].GetValue(); got != want {
+					t.Errorf("Expected %s %q, got %q", title, want, got)
+				}
+			}
+			if got := cards["Patch Compliance"].IsHealthy(); got != tt.wantHealthy {
+				t.Errorf("Expected Patch Compliance healthy %v, got %v", tt.wantHealthy, got)
+			}
+			if got, want := cards["Critical Patches"].IsHealthy(), tt.wantCritical == "0"; got != want {
+				t.Errorf("Expected Critical Patches healthy %v, got %v", want, got)
+			}
+
+			charts := report.generateCharts(tt.summary)
+			chartsByTitle := make(map[string]reports.ChartData)
+			for _, chart := range charts {
+				chartsByTitle[chart.Title] = chart
+			}
+
+			engineChart, found := chartsByTitle["Engine Distribution"]
+			if found != (tt.wantEngines != nil) {
+				t.Fatalf("Expected engine chart %v, got %v", tt.wantEngines != nil, found)
+			}
+			if found {
+				assertChartPoints(t, engineChart, tt.wantEngines)
+			}
+			assertChartPoints(t, chartsByTitle["Unapplied Updates by Severity"], tt.wantSeverity)
+
+			tables := report.generateTables(tt.summary)
+			for _, row := range tables[0].Rows {
+				clusterID := row["cluster_id"].(string)
+				if row["patch_status"] != tt.wantPatchStatus[clusterID] {
+					t.Errorf("Expected %s patch status %q, got %q", clusterID, tt.wantPatchStatus[clusterID], row["patch_status"])
+				}
+			}
+
+			replicationGroupPoints := 0
+			for _, point := range report.generateDataPoints(tt.summary) {
+				if point.Labels["type"] == "replication_group" {
+					replicationGroupPoints += 1
+				}
+			}
+			if replicationGroupPoints != tt.wantReplicatedDP {
+				t.Errorf("Expected %d replication group data points, got %d", tt.wantReplicatedDP, replicationGroupPoints)
+			}
+		})
+	}
+}
+
+func TestElastiCacheReport_EOLInstances(t *testing.T) {
+	eolDate := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	summary := &CacheClustersSummary{
+		AllCacheClusters: []ElastiCacheCluster{
+			{Id: "sessions-001", Engine: "redis", EngineVersion: "5.0.6", IsEOL: true, EOLDate: &eolDate},
+			{Id: "locks-001", Engine: "redis", EngineVersion: "6.2.6", IsOutdated: true,
+				UnappliedUpdateActionsSummary: ElastiCacheUpdateActionsSummary{UnappliedUpdateCount: 1, TotalUnappliedCriticalUpdateCount: 1}},
+			{Id: "cache-001", Engine: "valkey", EngineVersion: "8.0.1"},
+		},
+	}
+
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	report := NewElastiCacheReport(&ElastiCacheService{eolData: getElastiCacheVersionData(), logger: log}, log)
+	instances := report.EOLInstances(reports.ReportData{DataPoints: report.generateDataPoints(summary)})
+
+	got := make(map[string]bool)
+	for _, instance := range instances {
+		got[instance.ID+"/"+instance.Reason] = true
+		if instance.ID == "sessions-001" && (instance.EOLDate == nil || !instance.EOLDate.Equal(eolDate) || instance.Version != "5.0.6") {
+			t.Errorf("Unexpected EOL instance %+v", instance)
+		}
+	}
+	for _, want := range []string{
+		"sessions-001/" + notifications.EOLReasonEndOfLife,
+		"locks-001/" + notifications.EOLReasonOutdated,
+		"locks-001/" + notifications.EOLReasonCriticalPatch,
+	} {
+		if !got[want] {
+			t.Errorf("Expected %s in %v", want, got)
+		}
+	}
+	if len(instances) != 3 {
+		t.Errorf("Expected 3 instances, got %d", len(instances))
+	}
+}
+
+func assertChartPoints(t *testing.T, chart reports.ChartData, want map[string]int) {
+	t.Helper()
+
+	if len(chart.Series) != 1 {
+		t.Fatalf("Expected one series in %q, got %d", chart.Title, len(chart.Series))
+	}
+	got := make(map[string]int)
+	for _, point := range chart.Series[0].Data {
+		got[point.X.(string)] = point.Y.(int)
+	}
+	if len(got) != len(want) {
+		t.Errorf("Expected %q points %v, got %v", chart.Title, want, got)
+	}
+	for label, count := range want {
+		if got[label] != count {
+			t.Errorf("Expected %q %s = %d, got %d", chart.Title, label, count, got[label])
+		}
+	}
+}
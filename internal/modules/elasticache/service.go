@@ -2,38 +2,189 @@ package elasticache
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"govuk-reports-dashboard/internal/config"
+	awsclient "govuk-reports-dashboard/pkg/aws"
+	"govuk-reports-dashboard/pkg/govuk"
 	"govuk-reports-dashboard/pkg/logger"
+	"govuk-reports-dashboard/pkg/tracing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/elasticache"
 	"github.com/aws/aws-sdk-go-v2/service/elasticache/types"
 )
 
+// NodeTypeMetricsPeriod is how far back CloudWatch metrics are checked when
+// recommending node type upgrades
+const NodeTypeMetricsPeriod = 24 * time.Hour
+
+// ServerlessScalingThreshold is the fraction of a serverless cache's storage
+// or ECPU maximum above which it is reported as near its limit
+const ServerlessScalingThreshold = 0.8
+
+// ServerlessMetricsPeriod is how far back CloudWatch metrics are checked for
+// serverless cache scaling
+const ServerlessMetricsPeriod = 24 * time.Hour
+
+// DefaultClusterMetricsPeriod is how far back cluster metrics are fetched
+// when no period is asked for
+const DefaultClusterMetricsPeriod = time.Hour
+
+// MaxClusterMetricsPeriod is the longest period cluster metrics can be
+// fetched over. CloudWatch keeps one-minute data for 15 days.
+const MaxClusterMetricsPeriod = 14 * 24 * time.Hour
+
+// clusterMetricsWorkers is how many clusters' metrics are fetched at once
+const clusterMetricsWorkers = 5
+
+// ErrNoMetricData is returned by GetClusterMetrics when CloudWatch has no
+// data points for the cluster in the period, as for a cluster that doesn't
+// exist or was only just created
+var ErrNoMetricData = errors.New("no CloudWatch data points for the requested period")
+
+// ErrInvalidMetricsPeriod is returned by GetClusterMetrics for a period that
+// isn't a whole number of minutes up to MaxClusterMetricsPeriod
+var ErrInvalidMetricsPeriod = fmt.Errorf("metrics period must be a whole number of minutes between 1m and %s", MaxClusterMetricsPeriod)
+
+// MemoryPressureThreshold is the fraction of a node's memory in use above
+// which a larger node type is recommended
+const MemoryPressureThreshold = 0.8
+
+// nodeTypeSpec is the memory and approximate on-demand price in GBP per hour
+// in eu-west-2 of a cache node type
+type nodeTypeSpec struct {
+	MemoryGiB     float64
+	HourlyCostGBP float64
+}
+
+// hoursPerMonth is the average number of hours in a month, as used by AWS
+// pricing
+const hoursPerMonth = 730
+
+var nodeTypeSpecs = map[string]nodeTypeSpec{
+	"cache.t3.micro":    {MemoryGiB: 0.5, HourlyCostGBP: 0.015},
+	"cache.t3.small":    {MemoryGiB: 1.37, HourlyCostGBP: 0.029},
+	"cache.t3.medium":   {MemoryGiB: 3.09, HourlyCostGBP: 0.058},
+	"cache.t4g.micro":   {MemoryGiB: 0.5, HourlyCostGBP: 0.014},
+	"cache.t4g.small":   {MemoryGiB: 1.37, HourlyCostGBP: 0.027},
+	"cache.t4g.medium":  {MemoryGiB: 3.09, HourlyCostGBP: 0.054},
+	"cache.m6g.large":   {MemoryGiB: 6.38, HourlyCostGBP: 0.125},
+	"cache.r6g.large":   {MemoryGiB: 13.07, HourlyCostGBP: 0.18},
+	"cache.r6g.xlarge":  {MemoryGiB: 26.32, HourlyCostGBP: 0.36},
+	"cache.r6g.2xlarge": {MemoryGiB: 52.82, HourlyCostGBP: 0.72},
+	"cache.r6g.4xlarge": {MemoryGiB: 105.81, HourlyCostGBP: 1.44},
+}
+
+// nodeTypeUpgradePath maps each node type to the next size up. Burstable
+// types move to memory optimised r6g nodes once they outgrow medium.
+var nodeTypeUpgradePath = map[string]string{
+	"cache.t3.micro":    "cache.t3.small",
+	"cache.t3.small":    "cache.t3.medium",
+	"cache.t3.medium":   "cache.r6g.large",
+	"cache.t4g.micro":   "cache.t4g.small",
+	"cache.t4g.small":   "cache.t4g.medium",
+	"cache.t4g.medium":  "cache.r6g.large",
+	"cache.m6g.large":   "cache.r6g.large",
+	"cache.r6g.large":   "cache.r6g.xlarge",
+	"cache.r6g.xlarge":  "cache.r6g.2xlarge",
+	"cache.r6g.2xlarge": "cache.r6g.4xlarge",
+}
+
 type ElastiCacheService struct {
-	client *elasticache.Client
-	config *config.Config
-	logger *logger.Logger
+	client           *elasticache.Client
+	cloudWatchClient *awsclient.JSONAPIClient
+	accounts         []elastiCacheAccount
+	govukClient      govuk.ApplicationsClient
+	eolData          ElastiCacheEngineEOL
+	config           *config.Config
+	logger           *logger.Logger
 }
 
-// NewElastiCacheService creates a new ElastiCache service instance
-func NewElastiCacheService(awsConfig aws.Config, config *config.Config, logger *logger.Logger) *ElastiCacheService {
-	client := elasticache.NewFromConfig(awsConfig)
+// elastiCacheAccount is an AWS account caches are discovered in
+type elastiCacheAccount struct {
+	id               string
+	client           *elasticache.Client
+	cloudWatchClient *awsclient.JSONAPIClient
+}
+
+// NewElastiCacheService creates a new ElastiCache service instance using the
+// AWS client's shared ElastiCache and CloudWatch clients
+func NewElastiCacheService(awsClient *awsclient.Client, config *config.Config, logger *logger.Logger) *ElastiCacheService {
+	return NewMultiAccountElastiCacheService([]*awsclient.Client{awsClient}, config, logger)
+}
+
+// NewMultiAccountElastiCacheService creates an ElastiCache service that
+// discovers caches in the account of each client, such as those from
+// awsclient.NewMultiAccountClient
+func NewMultiAccountElastiCacheService(awsClients []*awsclient.Client, config *config.Config, logger *logger.Logger) *ElastiCacheService {
+	accounts := make([]elastiCacheAccount, len(awsClients))
+	for i, awsClient := range awsClients {
+		accounts[i] = elastiCacheAccount{
+			id:               awsClient.AccountID(),
+			client:           awsClient.NewServiceClient(awsclient.ServiceElastiCache).(*elasticache.Client),
+			cloudWatchClient: awsClient.NewServiceClient(awsclient.ServiceCloudWatch).(*awsclient.JSONAPIClient),
+		}
+	}
 
 	return &ElastiCacheService{
-		client: client,
-		config: config,
-		logger: logger,
+		client:           accounts[0].client,
+		cloudWatchClient: accounts[0].cloudWatchClient,
+		accounts:         accounts,
+		eolData:          getElastiCacheVersionData(),
+		config:           config,
+		logger:           logger,
+	}
+}
+
+// account returns the account with the given ID, or the first account if
+// there is none, as for caches discovered without account IDs
+func (s *ElastiCacheService) account(accountID string) elastiCacheAccount {
+	for _, account := range s.accounts {
+		if account.id == accountID {
+			return account
+		}
+	}
+	return s.accounts[0]
+}
+
+// inAccount describes an account in error messages, or returns "" for an
+// account without an ID
+func inAccount(accountID string) string {
+	if accountID == "" {
+		return ""
 	}
+	return " in account " + accountID
+}
+
+// SetApplicationsClient enables treating replication groups without an
+// environment tag as production when their system tag names a GOV.UK
+// application that is hosted in production
+func (s *ElastiCacheService) SetApplicationsClient(govukClient govuk.ApplicationsClient) {
+	s.govukClient = govukClient
 }
 
 func (s *ElastiCacheService) GetAllClusters(ctx context.Context) (*CacheClustersSummary, error) {
-	s.logger.Info().Msg("Discovering ElastiCache instances")
+	ctx, span := tracing.Start(ctx, "elasticache.get_all_clusters")
+	defer span.End()
+
+	return s.GetClustersForApplication(ctx, "")
+}
+
+// GetClustersForApplication returns clusters and replication groups whose
+// system tag matches application. Serverless caches are not tagged, so they
+// are only included when application is empty.
+func (s *ElastiCacheService) GetClustersForApplication(ctx context.Context, application string) (*CacheClustersSummary, error) {
+	ctx, span := tracing.Start(ctx, "elasticache.get_clusters_for_application")
+	defer span.End()
+
+	s.logger.ForContext(ctx).WithField("application", application).Info().Msg("Discovering ElastiCache instances")
 
 	cacheClusters, err := s.getCacheClusters(ctx)
 	if err != nil {
@@ -45,9 +196,19 @@ func (s *ElastiCacheService) GetAllClusters(ctx context.Context) (*CacheClusters
 		return nil, err
 	}
 
-	serverlessCaches, err := s.GetServerlessCaches(ctx)
-	if err != nil {
-		return nil, err
+	var serverlessCaches []ElastiCacheServerlessCache
+	if application == "" {
+		serverlessCaches, err = s.GetServerlessCaches(ctx)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		cacheClusters = slices.DeleteFunc(cacheClusters, func(cacheCluster ElastiCacheCluster) bool {
+			return cacheCluster.Application != application
+		})
+		replicationGroups = slices.DeleteFunc(replicationGroups, func(replicationGroup ElastiCacheReplicationGroup) bool {
+			return replicationGroup.Application != application
+		})
 	}
 
 	summary, err := s.generateCacheClustersSummary(&replicationGroups, &cacheClusters, &serverlessCaches, ctx)
@@ -58,38 +219,96 @@ func (s *ElastiCacheService) GetAllClusters(ctx context.Context) (*CacheClusters
 	return summary, nil
 }
 
+// GetOutdatedClusters returns the cache clusters that need upgrading because
+// their engine version is end-of-life or outdated, sorted by cluster ID
+func (s *ElastiCacheService) GetOutdatedClusters(ctx context.Context) ([]ElastiCacheCluster, error) {
+	ctx, span := tracing.Start(ctx, "elasticache.get_outdated_clusters")
+	defer span.End()
+
+	s.logger.ForContext(ctx).Info().Msg("Checking ElastiCache engine versions")
+
+	cacheClusters, err := s.getCacheClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return outdatedClusters(cacheClusters), nil
+}
+
+// outdatedClusters returns the clusters that are end-of-life or outdated,
+// sorted by cluster ID
+func outdatedClusters(cacheClusters []ElastiCacheCluster) []ElastiCacheCluster {
+	outdated := []ElastiCacheCluster{}
+	for _, cacheCluster := range cacheClusters {
+		if cacheCluster.IsEOL || cacheCluster.IsOutdated {
+			outdated = append(outdated, cacheCluster)
+		}
+	}
+
+	slices.SortFunc(outdated, func(a, b ElastiCacheCluster) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+
+	return outdated
+}
+
+// getCacheClusters returns the cache clusters in every account
 func (s *ElastiCacheService) getCacheClusters(ctx context.Context) ([]ElastiCacheCluster, error) {
-	s.logger.Info().Msg("Discovering ElastiCache Cache Clusters")
+	ctx, span := tracing.Start(ctx, "elasticache.get_clusters")
+	defer span.End()
+
+	log := s.logger.ForContext(ctx)
+	log.Info().Msg("Discovering ElastiCache Cache Clusters")
 	var cacheClusters []ElastiCacheCluster
 
-	paginator := elasticache.NewDescribeCacheClustersPaginator(s.client, &elasticache.DescribeCacheClustersInput{})
+	for _, account := range s.accounts {
+		paginator := elasticache.NewDescribeCacheClustersPaginator(account.client, &elasticache.DescribeCacheClustersInput{})
 
-	for paginator.HasMorePages() {
-		page, err := paginator.NextPage(ctx)
-		if err != nil {
-			s.logger.WithError(err).Error().Msg("Failed to describe ElastiCache Clusters")
-			return nil, fmt.Errorf("failed to describe ElastiCache clusters: %w", err)
-		}
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				log.WithError(err).WithField("account_id", account.id).Error().Msg("Failed to describe ElastiCache Clusters")
+				return nil, fmt.Errorf("failed to describe ElastiCache clusters%s: %w", inAccount(account.id), err)
+			}
 
-		for _, cacheCluster := range page.CacheClusters {
-			cacheClusters = append(cacheClusters, s.convertToElastiCacheCluster(cacheCluster))
+			for _, cacheCluster := range page.CacheClusters {
+				converted := s.convertToElastiCacheCluster(cacheCluster)
+				converted.AccountID = account.id
+				s.applyTags(ctx, account.client, converted.ARN, &converted.Application, &converted.Environment)
+				cacheClusters = append(cacheClusters, converted)
+			}
 		}
-
 	}
 
 	return cacheClusters, nil
 }
 
 func (s *ElastiCacheService) getUpdateActionsSummaryAndPopulateUpdates(replicationGroups *[]ElastiCacheReplicationGroup, cacheClusters *[]ElastiCacheCluster, ctx context.Context) (*ElastiCacheUpdateActionsSummary, error) {
-	s.logger.Info().Msg("Discovering ElastiCache Unapplied Update Actions")
-
-	var unappliedUpdateCount, unappliedImportantUpdateCount, unappliedCriticalUpdateCount int = 0, 0, 0
+	s.logger.ForContext(ctx).Info().Msg("Discovering ElastiCache Unapplied Update Actions")
 
 	replicationGroupUpdateActions, err := s.getReplicationGroupUpdateActions(*replicationGroups, ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	cacheClusterUpdateActions, err := s.getCacheClusterUpdateActions(*cacheClusters, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return summariseUpdateActions(replicationGroups, cacheClusters, replicationGroupUpdateActions, cacheClusterUpdateActions), nil
+}
+
+// summariseUpdateActions attaches unapplied update actions to their
+// replication groups and cache clusters and counts them. An update applied
+// to a replication group is counted once, not again for each member cluster.
+func summariseUpdateActions(replicationGroups *[]ElastiCacheReplicationGroup, cacheClusters *[]ElastiCacheCluster, replicationGroupUpdateActions []ElastiCacheReplicationGroupUpdateAction, cacheClusterUpdateActions []ElastiCacheCacheClusterUpdateAction) *ElastiCacheUpdateActionsSummary {
+	var unappliedUpdateCount, unappliedImportantUpdateCount, unappliedCriticalUpdateCount int = 0, 0, 0
+
+	// Service updates already counted for a replication group, keyed by
+	// account ID, replication group ID and service update name
+	processedUpdateActionIDs := make(map[string]bool)
+
 	for _, replicationGroupUpdateAction := range replicationGroupUpdateActions {
 		if replicationGroupUpdateAction.UpdateAction.ServiceUpdate.Status != "available" {
 			continue
@@ -100,10 +319,11 @@ func (s *ElastiCacheService) getUpdateActionsSummaryAndPopulateUpdates(replicati
 		}
 
 		replicationGroupIndex := slices.IndexFunc(*replicationGroups, func(replicationGroup ElastiCacheReplicationGroup) bool {
-			return replicationGroupUpdateAction.ReplicationGroupId == replicationGroup.Id
+			return replicationGroupUpdateAction.AccountID == replicationGroup.AccountID && replicationGroupUpdateAction.ReplicationGroupId == replicationGroup.Id
 		})
 		replicationGroup := &(*replicationGroups)[replicationGroupIndex]
 		replicationGroup.UnappliedUpdateActions = append(replicationGroup.UnappliedUpdateActions, replicationGroupUpdateAction)
+		processedUpdateActionIDs[replicationGroup.AccountID+"/"+replicationGroup.Id+"/"+replicationGroupUpdateAction.UpdateAction.ServiceUpdate.Name] = true
 
 		unappliedUpdateCount += 1
 		replicationGroup.UnappliedUpdateActionsSummary.UnappliedUpdateCount += 1
@@ -117,11 +337,6 @@ func (s *ElastiCacheService) getUpdateActionsSummaryAndPopulateUpdates(replicati
 		}
 	}
 
-	cacheClusterUpdateActions, err := s.getCacheClusterUpdateActions(*cacheClusters, ctx)
-	if err != nil {
-		return nil, err
-	}
-
 	for _, cacheClusterUpdateAction := range cacheClusterUpdateActions {
 		if cacheClusterUpdateAction.UpdateAction.ServiceUpdate.Status != "available" {
 			continue
@@ -132,9 +347,14 @@ func (s *ElastiCacheService) getUpdateActionsSummaryAndPopulateUpdates(replicati
 		}
 
 		cacheClusterIndex := slices.IndexFunc(*cacheClusters, func(cacheCluster ElastiCacheCluster) bool {
-			return cacheClusterUpdateAction.CacheClusterId == cacheCluster.Id
+			return cacheClusterUpdateAction.AccountID == cacheCluster.AccountID && cacheClusterUpdateAction.CacheClusterId == cacheCluster.Id
 		})
 		cacheCluster := &(*cacheClusters)[cacheClusterIndex]
+
+		if cacheCluster.ReplicationGroup != "" && processedUpdateActionIDs[cacheCluster.AccountID+"/"+cacheCluster.ReplicationGroup+"/"+cacheClusterUpdateAction.UpdateAction.ServiceUpdate.Name] {
+			continue
+		}
+
 		cacheCluster.UnappliedUpdateActions = append(cacheCluster.UnappliedUpdateActions, cacheClusterUpdateAction)
 
 		unappliedUpdateCount += 1
@@ -153,57 +373,123 @@ func (s *ElastiCacheService) getUpdateActionsSummaryAndPopulateUpdates(replicati
 		TotalUnappliedCriticalUpdateCount:  unappliedCriticalUpdateCount,
 		TotalUnappliedImportantUpdateCount: unappliedImportantUpdateCount,
 		UnappliedUpdateCount:               unappliedUpdateCount,
-	}, nil
+	}
 }
 
+// getReplicationGroups returns the replication groups in every account, with
+// their members from cacheClusters
 func (s *ElastiCacheService) getReplicationGroups(cacheClusters []ElastiCacheCluster, ctx context.Context) ([]ElastiCacheReplicationGroup, error) {
-	s.logger.Info().Msg("Discovering ElastiCache Replication Groups")
+	ctx, span := tracing.Start(ctx, "elasticache.get_replication_groups")
+	defer span.End()
+
+	log := s.logger.ForContext(ctx)
+	log.Info().Msg("Discovering ElastiCache Replication Groups")
 
 	var replicationGroups []ElastiCacheReplicationGroup
 
-	paginator := elasticache.NewDescribeReplicationGroupsPaginator(s.client, &elasticache.DescribeReplicationGroupsInput{})
+	for _, account := range s.accounts {
+		// Replication group IDs are only unique within an account
+		accountClusters := slices.DeleteFunc(slices.Clone(cacheClusters), func(cacheCluster ElastiCacheCluster) bool {
+			return cacheCluster.AccountID != account.id
+		})
 
-	for paginator.HasMorePages() {
-		page, err := paginator.NextPage(ctx)
-		if err != nil {
-			s.logger.WithError(err).Error().Msg("Failed to describe ElastiCache replication groups")
-			return nil, fmt.Errorf("failed to describe ElastiCache replication groups: %w", err)
-		}
+		paginator := elasticache.NewDescribeReplicationGroupsPaginator(account.client, &elasticache.DescribeReplicationGroupsInput{})
+
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				log.WithError(err).WithField("account_id", account.id).Error().Msg("Failed to describe ElastiCache replication groups")
+				return nil, fmt.Errorf("failed to describe ElastiCache replication groups%s: %w", inAccount(account.id), err)
+			}
 
-		for _, replicationGroup := range page.ReplicationGroups {
-			replicationGroups = append(replicationGroups, s.convertToElastiCacheReplicationGroup(replicationGroup, cacheClusters))
+			for _, replicationGroup := range page.ReplicationGroups {
+				converted := s.convertToElastiCacheReplicationGroup(replicationGroup, accountClusters)
+				converted.AccountID = account.id
+				s.applyTags(ctx, account.client, converted.ARN, &converted.Application, &converted.Environment)
+				replicationGroups = append(replicationGroups, converted)
+			}
 		}
 	}
 
 	return replicationGroups, nil
 }
 
-func (s *ElastiCacheService) getReplicationGroupUpdateActions(replicationGroups []ElastiCacheReplicationGroup, ctx context.Context) ([]ElastiCacheReplicationGroupUpdateAction, error) {
-	var replicationGroupIds []string = make([]string, len(replicationGroups))
-	for i, replicationGroup := range replicationGroups {
-		replicationGroupIds[i] = replicationGroup.Id
-	}
+// GetTagsForCluster returns the tags on an ElastiCache cluster or replication group
+func (s *ElastiCacheService) GetTagsForCluster(ctx context.Context, arn string) (map[string]string, error) {
+	ctx, span := tracing.Start(ctx, "elasticache.get_tags_for_cluster")
+	defer span.End()
 
-	var replicationGroupUpdateActions []ElastiCacheReplicationGroupUpdateAction
+	return listTags(ctx, s.client, arn)
+}
 
-	paginator := elasticache.NewDescribeUpdateActionsPaginator(s.client, &elasticache.DescribeUpdateActionsInput{
-		ReplicationGroupIds: replicationGroupIds,
+// listTags returns the tags on a resource in the account of client
+func listTags(ctx context.Context, client *elasticache.Client, arn string) (map[string]string, error) {
+	output, err := client.ListTagsForResource(ctx, &elasticache.ListTagsForResourceInput{
+		ResourceName: aws.String(arn),
 	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags for %s: %w", arn, err)
+	}
 
-	for paginator.HasMorePages() {
-		page, err := paginator.NextPage(ctx)
-		if err != nil {
-			s.logger.WithError(err).Error().Msg("Failed to describe update actions for replication groups")
-			return nil, fmt.Errorf("failed to describe update actions for replication groups: %w", err)
+	tags := make(map[string]string, len(output.TagList))
+	for _, tag := range output.TagList {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+
+	return tags, nil
+}
+
+// applyTags sets application and environment from the resource's system and
+// environment tags. Tag lookup failures are logged and leave both unset.
+func (s *ElastiCacheService) applyTags(ctx context.Context, client *elasticache.Client, arn string, application, environment *string) {
+	if arn == "" {
+		return
+	}
+
+	tags, err := listTags(ctx, client, arn)
+	if err != nil {
+		s.logger.ForContext(ctx).WithError(err).WithField("arn", arn).Warn().Msg("Failed to get ElastiCache tags")
+		return
+	}
+
+	*application = tags["system"]
+	*environment = tags["environment"]
+}
+
+func (s *ElastiCacheService) getReplicationGroupUpdateActions(replicationGroups []ElastiCacheReplicationGroup, ctx context.Context) ([]ElastiCacheReplicationGroupUpdateAction, error) {
+	var replicationGroupUpdateActions []ElastiCacheReplicationGroupUpdateAction
+
+	for _, account := range s.accounts {
+		var replicationGroupIds []string
+		for _, replicationGroup := range replicationGroups {
+			if replicationGroup.AccountID == account.id {
+				replicationGroupIds = append(replicationGroupIds, replicationGroup.Id)
+			}
+		}
+		if len(replicationGroupIds) == 0 {
+			continue
 		}
 
-		for _, updateAction := range page.UpdateActions {
-			replicationGroupUpdateAction, err := s.convertToReplicationGroupUpdateAction(updateAction)
+		paginator := elasticache.NewDescribeUpdateActionsPaginator(account.client, &elasticache.DescribeUpdateActionsInput{
+			ReplicationGroupIds: replicationGroupIds,
+		})
+
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
 			if err != nil {
-				return nil, err
+				s.logger.ForContext(ctx).WithError(err).WithField("account_id", account.id).Error().Msg("Failed to describe update actions for replication groups")
+				return nil, fmt.Errorf("failed to describe update actions for replication groups%s: %w", inAccount(account.id), err)
 			}
 
-			replicationGroupUpdateActions = append(replicationGroupUpdateActions, *replicationGroupUpdateAction)
+			for _, updateAction := range page.UpdateActions {
+				replicationGroupUpdateAction, err := s.convertToReplicationGroupUpdateAction(updateAction)
+				if err != nil {
+					return nil, err
+				}
+
+				replicationGroupUpdateAction.AccountID = account.id
+				replicationGroupUpdateActions = append(replicationGroupUpdateActions, *replicationGroupUpdateAction)
+			}
 		}
 	}
 
@@ -211,51 +497,744 @@ func (s *ElastiCacheService) getReplicationGroupUpdateActions(replicationGroups
 }
 
 func (s *ElastiCacheService) getCacheClusterUpdateActions(cacheClusters []ElastiCacheCluster, ctx context.Context) ([]ElastiCacheCacheClusterUpdateAction, error) {
-	var cacheClusterIds []string = make([]string, len(cacheClusters))
-	for i, cacheCluster := range cacheClusters {
-		cacheClusterIds[i] = cacheCluster.Id
+	var cacheClusterUpdateActions []ElastiCacheCacheClusterUpdateAction
+
+	for _, account := range s.accounts {
+		var cacheClusterIds []string
+		for _, cacheCluster := range cacheClusters {
+			if cacheCluster.AccountID == account.id {
+				cacheClusterIds = append(cacheClusterIds, cacheCluster.Id)
+			}
+		}
+		if len(cacheClusterIds) == 0 {
+			continue
+		}
+
+		paginator := elasticache.NewDescribeUpdateActionsPaginator(account.client, &elasticache.DescribeUpdateActionsInput{
+			CacheClusterIds: cacheClusterIds,
+		})
+
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				s.logger.ForContext(ctx).WithError(err).WithField("account_id", account.id).Error().Msg("Failed to describe update actions for cache clusters")
+				return nil, fmt.Errorf("failed to describe update actions for cache clusters%s: %w", inAccount(account.id), err)
+			}
+
+			for _, updateAction := range page.UpdateActions {
+				cacheClusterUpdateAction, err := s.convertToCacheClusterUpdateAction(updateAction)
+				if err != nil {
+					return nil, err
+				}
+
+				cacheClusterUpdateAction.AccountID = account.id
+				cacheClusterUpdateActions = append(cacheClusterUpdateActions, *cacheClusterUpdateAction)
+			}
+		}
 	}
 
-	var cacheClusterUpdateActions []ElastiCacheCacheClusterUpdateAction
+	return cacheClusterUpdateActions, nil
+}
+
+// MinParameterGroupHz is the lowest acceptable value of the hz parameter,
+// which controls how often Redis and Valkey run background tasks such as
+// expiring keys
+const MinParameterGroupHz = 10
 
-	paginator := elasticache.NewDescribeUpdateActionsPaginator(s.client, &elasticache.DescribeUpdateActionsInput{
-		CacheClusterIds: cacheClusterIds,
+// GetParameterGroupReport checks the memory and eviction settings of every
+// Redis and Valkey parameter group in use by a cache cluster
+func (s *ElastiCacheService) GetParameterGroupReport(ctx context.Context) ([]ParameterGroupComplianceItem, error) {
+	ctx, span := tracing.Start(ctx, "elasticache.get_parameter_group_report")
+	defer span.End()
+
+	s.logger.ForContext(ctx).Info().Msg("Checking ElastiCache parameter group compliance")
+
+	cacheClusters, err := s.getCacheClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parameter group names are only unique within an account
+	type parameterGroup struct {
+		accountID, name string
+	}
+	clusterCounts := make(map[parameterGroup]int)
+	for _, cacheCluster := range cacheClusters {
+		if cacheCluster.ParameterGroup == "" || cacheCluster.Engine == "memcached" {
+			continue
+		}
+		clusterCounts[parameterGroup{cacheCluster.AccountID, cacheCluster.ParameterGroup}] += 1
+	}
+
+	groups := make([]parameterGroup, 0, len(clusterCounts))
+	for group := range clusterCounts {
+		groups = append(groups, group)
+	}
+	slices.SortFunc(groups, func(a, b parameterGroup) int {
+		if c := strings.Compare(a.name, b.name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.accountID, b.accountID)
+	})
+
+	items := make([]ParameterGroupComplianceItem, 0, len(groups))
+	for _, group := range groups {
+		parameters, err := s.getCacheParameters(ctx, s.account(group.accountID).client, group.name)
+		if err != nil {
+			return nil, err
+		}
+
+		violations := checkParameterCompliance(parameters)
+		items = append(items, ParameterGroupComplianceItem{
+			GroupName:    group.name,
+			AccountID:    group.accountID,
+			ClusterCount: clusterCounts[group],
+			IsCompliant:  len(violations) == 0,
+			Violations:   violations,
+		})
+	}
+
+	return items, nil
+}
+
+func (s *ElastiCacheService) getCacheParameters(ctx context.Context, client *elasticache.Client, groupName string) (map[string]string, error) {
+	parameters := make(map[string]string)
+
+	paginator := elasticache.NewDescribeCacheParametersPaginator(client, &elasticache.DescribeCacheParametersInput{
+		CacheParameterGroupName: aws.String(groupName),
 	})
 
 	for paginator.HasMorePages() {
 		page, err := paginator.NextPage(ctx)
 		if err != nil {
-			s.logger.WithError(err).Error().Msg("Failed to describe update actions for cache clusters")
-			return nil, fmt.Errorf("failed to describe update actions for cache clusters: %w", err)
+			s.logger.ForContext(ctx).WithError(err).WithField("parameter_group", groupName).Error().Msg("Failed to describe ElastiCache cache parameters")
+			return nil, fmt.Errorf("failed to describe cache parameters for %s: %w", groupName, err)
 		}
 
-		for _, updateAction := range page.UpdateActions {
-			cacheClusterUpdateAction, err := s.convertToCacheClusterUpdateAction(updateAction)
+		for _, parameter := range page.Parameters {
+			parameters[aws.ToString(parameter.ParameterName)] = aws.ToString(parameter.ParameterValue)
+		}
+	}
+
+	return parameters, nil
+}
+
+// checkParameterCompliance returns the memory and eviction settings that
+// break policy. Parameters missing from the group are not checked.
+func checkParameterCompliance(parameters map[string]string) []string {
+	violations := []string{}
+
+	if policy, ok := parameters["maxmemory-policy"]; ok && policy == "noeviction" {
+		violations = append(violations, "maxmemory-policy is noeviction, so writes fail when memory is full")
+	}
+
+	if value, ok := parameters["hz"]; ok {
+		if hz, err := strconv.Atoi(value); err != nil || hz < MinParameterGroupHz {
+			violations = append(violations, fmt.Sprintf("hz is %s, should be at least %d", value, MinParameterGroupHz))
+		}
+	}
+
+	if value, ok := parameters["lazyfree-lazy-eviction"]; ok && value != "yes" {
+		violations = append(violations, fmt.Sprintf("lazyfree-lazy-eviction is %s, should be yes", value))
+	}
+
+	return violations
+}
+
+func parameterGroupName(status *types.CacheParameterGroupStatus) string {
+	if status == nil {
+		return ""
+	}
+	return aws.ToString(status.CacheParameterGroupName)
+}
+
+// GetMultiAZReport checks whether each replication group has Multi-AZ
+// enabled. Only production replication groups are required to.
+func (s *ElastiCacheService) GetMultiAZReport(ctx context.Context) ([]MultiAZReplicationGroupItem, error) {
+	ctx, span := tracing.Start(ctx, "elasticache.get_multi_a_z_report")
+	defer span.End()
+
+	s.logger.ForContext(ctx).Info().Msg("Checking ElastiCache Multi-AZ compliance")
+
+	cacheClusters, err := s.getCacheClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	replicationGroups, err := s.getReplicationGroups(cacheClusters, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return checkMultiAZ(replicationGroups, s.getProductionApplications(ctx, replicationGroups)), nil
+}
+
+// checkMultiAZ builds a Multi-AZ compliance item for each replication group,
+// sorted by group ID. Groups are production if their environment tag says so,
+// or if they have no environment tag and their application is in
+// productionApps.
+func checkMultiAZ(replicationGroups []ElastiCacheReplicationGroup, productionApps map[string]bool) []MultiAZReplicationGroupItem {
+	items := make([]MultiAZReplicationGroupItem, 0, len(replicationGroups))
+	for _, replicationGroup := range replicationGroups {
+		isProduction := replicationGroup.Environment == "production" ||
+			(replicationGroup.Environment == "" && productionApps[replicationGroup.Application])
+		isMultiAZEnabled := replicationGroup.MultiAZ == "enabled"
+
+		items = append(items, MultiAZReplicationGroupItem{
+			GroupID:          replicationGroup.Id,
+			AccountID:        replicationGroup.AccountID,
+			Engine:           replicationGroup.Engine,
+			Application:      replicationGroup.Application,
+			Environment:      replicationGroup.Environment,
+			MultiAZ:          replicationGroup.MultiAZ,
+			IsMultiAZEnabled: isMultiAZEnabled,
+			NodeCount:        len(replicationGroup.MemberClusters),
+			IsProduction:     isProduction,
+			IsCompliant:      isMultiAZEnabled || !isProduction,
+		})
+	}
+
+	slices.SortFunc(items, func(a, b MultiAZReplicationGroupItem) int {
+		return strings.Compare(a.GroupID, b.GroupID)
+	})
+
+	return items
+}
+
+// GetBackupComplianceReport checks each replication group's automatic backup
+// retention against config.AWSConfig.MinElastiCacheSnapshotRetention. Only
+// production replication groups are required to meet it. Serverless caches
+// are not replication groups, so they are not checked.
+func (s *ElastiCacheService) GetBackupComplianceReport(ctx context.Context) ([]ElastiCacheBackupItem, error) {
+	ctx, span := tracing.Start(ctx, "elasticache.get_backup_compliance_report")
+	defer span.End()
+
+	s.logger.ForContext(ctx).Info().Msg("Checking ElastiCache backup compliance")
+
+	cacheClusters, err := s.getCacheClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	replicationGroups, err := s.getReplicationGroups(cacheClusters, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	productionApps := s.getProductionApplications(ctx, replicationGroups)
+	return checkBackupRetention(replicationGroups, productionApps, int32(s.config.AWS.MinElastiCacheSnapshotRetention)), nil
+}
+
+// checkBackupRetention builds a backup compliance item for each replication
+// group, sorted by group ID. Production is decided as in checkMultiAZ.
+func checkBackupRetention(replicationGroups []ElastiCacheReplicationGroup, productionApps map[string]bool, minRetention int32) []ElastiCacheBackupItem {
+	items := make([]ElastiCacheBackupItem, 0, len(replicationGroups))
+	for _, replicationGroup := range replicationGroups {
+		isProduction := replicationGroup.Environment == "production" ||
+			(replicationGroup.Environment == "" && productionApps[replicationGroup.Application])
+
+		items = append(items, ElastiCacheBackupItem{
+			GroupID:                replicationGroup.Id,
+			AccountID:              replicationGroup.AccountID,
+			SnapshotRetentionLimit: replicationGroup.SnapshotRetentionLimit,
+			IsProduction:           isProduction,
+			IsCompliant:            replicationGroup.SnapshotRetentionLimit >= minRetention || !isProduction,
+			Application:            replicationGroup.Application,
+			Environment:            replicationGroup.Environment,
+		})
+	}
+
+	slices.SortFunc(items, func(a, b ElastiCacheBackupItem) int {
+		return strings.Compare(a.GroupID, b.GroupID)
+	})
+
+	return items
+}
+
+// getProductionApplications returns the system tags of replication groups
+// without an environment tag that name a GOV.UK application hosted in
+// production. It is empty if no applications client is set or the GOV.UK
+// API cannot be reached.
+func (s *ElastiCacheService) getProductionApplications(ctx context.Context, replicationGroups []ElastiCacheReplicationGroup) map[string]bool {
+	productionApps := make(map[string]bool)
+	if s.govukClient == nil {
+		return productionApps
+	}
+
+	var names []string
+	for _, replicationGroup := range replicationGroups {
+		if replicationGroup.Environment == "" && replicationGroup.Application != "" {
+			names = appendUnique(names, replicationGroup.Application)
+		}
+	}
+	if len(names) == 0 {
+		return productionApps
+	}
+
+	found, _, err := s.govukClient.BulkGetApplicationsByName(ctx, names)
+	if err != nil {
+		s.logger.ForContext(ctx).WithError(err).Warn().Msg("Failed to fetch GOV.UK applications, untagged replication groups will not be treated as production")
+		return productionApps
+	}
+
+	for name, app := range found {
+		if app.ProductionHostedOn != "" {
+			productionApps[name] = true
+		}
+	}
+
+	return productionApps
+}
+
+// GetServerlessScalingReport compares each serverless cache's peak
+// BytesUsedForCache and ElastiCacheProcessingUnits over
+// ServerlessMetricsPeriod against its configured maximums. Caches using more
+// than ServerlessScalingThreshold of either will soon be throttled.
+func (s *ElastiCacheService) GetServerlessScalingReport(ctx context.Context) ([]ServerlessScalingItem, error) {
+	ctx, span := tracing.Start(ctx, "elasticache.get_serverless_scaling_report")
+	defer span.End()
+
+	log := s.logger.ForContext(ctx)
+	log.Info().Msg("Checking ElastiCache serverless scaling limits")
+
+	serverlessCaches, err := s.GetServerlessCaches(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]ServerlessScalingItem, 0, len(serverlessCaches))
+	for _, serverlessCache := range serverlessCaches {
+		// ECPUs are summed per minute, then divided by 60 to get the peak
+		// per-second rate
+		results, err := s.getMetricData(ctx, s.account(serverlessCache.AccountID).cloudWatchClient, []cwMetricDataQuery{
+			newMetricDataQuery("bytes", "BytesUsedForCache", "clusterId", serverlessCache.Name, "Maximum", time.Minute),
+			newMetricDataQuery("ecpu", "ElastiCacheProcessingUnits", "clusterId", serverlessCache.Name, "Sum", time.Minute),
+		}, ServerlessMetricsPeriod)
+		if err != nil {
+			log.WithError(err).WithField("serverless_cache", serverlessCache.Name).Error().Msg("Failed to get ElastiCache serverless CloudWatch metrics")
+			return nil, fmt.Errorf("failed to get CloudWatch metrics for %s: %w", serverlessCache.Name, err)
+		}
+
+		var peakBytes, peakECPUPerMinute float64
+		for _, value := range results["bytes"] {
+			peakBytes = max(peakBytes, value)
+		}
+		for _, value := range results["ecpu"] {
+			peakECPUPerMinute = max(peakECPUPerMinute, value)
+		}
+
+		items = append(items, checkServerlessScaling(serverlessCache, peakBytes, peakECPUPerMinute/60))
+	}
+
+	slices.SortFunc(items, func(a, b ServerlessScalingItem) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return items, nil
+}
+
+// checkServerlessScaling compares peak storage in bytes and ECPUs per second
+// against a serverless cache's maximums
+func checkServerlessScaling(serverlessCache ElastiCacheServerlessCache, peakBytes, peakECPUPerSecond float64) ServerlessScalingItem {
+	item := ServerlessScalingItem{
+		Name:              serverlessCache.Name,
+		AccountID:         serverlessCache.AccountID,
+		MaxStorageGB:      serverlessCache.MaxDataStorageGB,
+		CurrentStorageGB:  peakBytes / (1000 * 1000 * 1000),
+		MaxECPU:           int64(serverlessCache.MaxECPUPerSecond),
+		PeakECPUPerSecond: peakECPUPerSecond,
+	}
+
+	if item.MaxStorageGB > 0 {
+		item.StorageUtilizationPercent = item.CurrentStorageGB / float64(item.MaxStorageGB) * 100
+	}
+	if item.MaxECPU > 0 {
+		item.ECPUUtilizationPercent = item.PeakECPUPerSecond / float64(item.MaxECPU) * 100
+	}
+
+	item.IsNearLimit = item.StorageUtilizationPercent > ServerlessScalingThreshold*100 ||
+		item.ECPUUtilizationPercent > ServerlessScalingThreshold*100
+
+	return item
+}
+
+// GetNodeTypeRecommendations checks each replication group's memory use and
+// evictions in CloudWatch over NodeTypeMetricsPeriod, and recommends the next
+// node type up for groups using more than MemoryPressureThreshold of their
+// memory or evicting keys
+func (s *ElastiCacheService) GetNodeTypeRecommendations(ctx context.Context) ([]NodeTypeRecommendation, error) {
+	ctx, span := tracing.Start(ctx, "elasticache.get_node_type_recommendations")
+	defer span.End()
+
+	log := s.logger.ForContext(ctx)
+	log.Info().Msg("Checking ElastiCache node types")
+
+	cacheClusters, err := s.getCacheClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	replicationGroups, err := s.getReplicationGroups(cacheClusters, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	recommendations := []NodeTypeRecommendation{}
+	for _, replicationGroup := range replicationGroups {
+		metrics, err := s.getReplicationGroupMetrics(ctx, replicationGroup)
+		if err != nil {
+			return nil, err
+		}
+
+		if recommendation := recommendNodeType(replicationGroup, metrics); recommendation != nil {
+			recommendations = append(recommendations, *recommendation)
+		}
+	}
+
+	slices.SortFunc(recommendations, func(a, b NodeTypeRecommendation) int {
+		if a.Priority != b.Priority {
+			return severityRank(b.Priority) - severityRank(a.Priority)
+		}
+		return strings.Compare(a.GroupID, b.GroupID)
+	})
+
+	log.WithFields(map[string]interface{}{
+		"replication_groups": len(replicationGroups),
+		"recommendations":    len(recommendations),
+	}).Info().Msg("ElastiCache node type check complete")
+
+	return recommendations, nil
+}
+
+// getReplicationGroupMetrics fetches BytesUsedForCache, Evictions and
+// CacheHits for every member cluster of a replication group
+func (s *ElastiCacheService) getReplicationGroupMetrics(ctx context.Context, replicationGroup ElastiCacheReplicationGroup) (replicationGroupMetrics, error) {
+	var metrics replicationGroupMetrics
+	if len(replicationGroup.MemberClusters) == 0 {
+		return metrics, nil
+	}
+
+	stats := map[string]string{
+		"BytesUsedForCache": "Maximum",
+		"Evictions":         "Sum",
+		"CacheHits":         "Sum",
+	}
+	metricNames := make(map[string]string)
+	var queries []cwMetricDataQuery
+	for i, member := range replicationGroup.MemberClusters {
+		for metricName, stat := range stats {
+			id := fmt.Sprintf("%s_%d", strings.ToLower(metricName), i)
+			metricNames[id] = metricName
+			queries = append(queries, newMetricDataQuery(id, metricName, "CacheClusterId", member.Id, stat, NodeTypeMetricsPeriod))
+		}
+	}
+
+	results, err := s.getMetricData(ctx, s.account(replicationGroup.AccountID).cloudWatchClient, queries, NodeTypeMetricsPeriod)
+	if err != nil {
+		s.logger.ForContext(ctx).WithError(err).WithField("replication_group", replicationGroup.Id).Error().Msg("Failed to get ElastiCache CloudWatch metrics")
+		return metrics, fmt.Errorf("failed to get CloudWatch metrics for %s: %w", replicationGroup.Id, err)
+	}
+
+	for id, values := range results {
+		for _, value := range values {
+			switch metricNames[id] {
+			case "BytesUsedForCache":
+				metrics.MaxBytesUsedForCache = max(metrics.MaxBytesUsedForCache, value)
+			case "Evictions":
+				metrics.Evictions += value
+			case "CacheHits":
+				metrics.CacheHits += value
+			}
+		}
+	}
+
+	return metrics, nil
+}
+
+// newMetricDataQuery builds a query for an AWS/ElastiCache metric with a
+// single dimension, aggregated over period
+func newMetricDataQuery(id, metricName, dimensionName, dimensionValue, stat string, period time.Duration) cwMetricDataQuery {
+	return cwMetricDataQuery{
+		Id: id,
+		MetricStat: cwMetricStat{
+			Metric: cwMetric{
+				Namespace:  "AWS/ElastiCache",
+				MetricName: metricName,
+				Dimensions: []cwDimension{{Name: dimensionName, Value: dimensionValue}},
+			},
+			Period: int32(period.Seconds()),
+			Stat:   stat,
+		},
+	}
+}
+
+// getMetricData runs CloudWatch queries in the account of cloudWatchClient
+// over the last lookback period, following pagination, and returns each
+// query's values by query ID
+func (s *ElastiCacheService) getMetricData(ctx context.Context, cloudWatchClient *awsclient.JSONAPIClient, queries []cwMetricDataQuery, lookback time.Duration) (map[string][]float64, error) {
+	endTime := time.Now()
+	input := cwGetMetricDataInput{
+		MetricDataQueries: queries,
+		StartTime:         endTime.Add(-lookback).Unix(),
+		EndTime:           endTime.Unix(),
+	}
+
+	results := make(map[string][]float64)
+	for {
+		var output cwGetMetricDataOutput
+		if err := cloudWatchClient.Call(ctx, "GetMetricData", input, &output); err != nil {
+			return nil, err
+		}
+
+		for _, result := range output.MetricDataResults {
+			results[result.Id] = append(results[result.Id], result.Values...)
+		}
+
+		if output.NextToken == "" {
+			return results, nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// clusterMetricStatistic is a CloudWatch metric fetched for a cluster, the
+// statistic it's aggregated with and where it's recorded
+type clusterMetricStatistic struct {
+	metricName string
+	statistic  string
+	record     func(metrics *ElastiCacheMetrics, value float64)
+}
+
+var clusterMetricStatistics = []clusterMetricStatistic{
+	{"CPUUtilization", "Average", func(m *ElastiCacheMetrics, v float64) { m.CPUUtilization = v }},
+	{"DatabaseMemoryUsagePercentage", "Average", func(m *ElastiCacheMetrics, v float64) { m.DatabaseMemoryUsagePercentage = v }},
+	{"CurrConnections", "Maximum", func(m *ElastiCacheMetrics, v float64) { m.CurrConnections = int32(v) }},
+	{"Evictions", "Sum", func(m *ElastiCacheMetrics, v float64) { m.EvictedKeys = int64(v) }},
+	{"CacheHits", "Sum", func(m *ElastiCacheMetrics, v float64) { m.CacheHits = int64(v) }},
+	{"CacheMisses", "Sum", func(m *ElastiCacheMetrics, v float64) { m.CacheMisses = int64(v) }},
+	{"NetworkBytesIn", "Sum", func(m *ElastiCacheMetrics, v float64) { m.NetworkBytesIn = int64(v) }},
+	{"NetworkBytesOut", "Sum", func(m *ElastiCacheMetrics, v float64) { m.NetworkBytesOut = int64(v) }},
+}
+
+// GetClusterMetrics returns a cache cluster's CPU, memory, connection, hit
+// and network metrics over the last period, which must be a whole number of
+// minutes up to MaxClusterMetricsPeriod. Clusters are looked up in the first
+// account. It returns ErrNoMetricData if CloudWatch has no data for any of
+// the metrics.
+func (s *ElastiCacheService) GetClusterMetrics(ctx context.Context, clusterID string, period time.Duration) (*ElastiCacheMetrics, error) {
+	ctx, span := tracing.Start(ctx, "elasticache.get_cluster_metrics")
+	defer span.End()
+
+	return s.getClusterMetrics(ctx, s.cloudWatchClient, clusterID, period)
+}
+
+func (s *ElastiCacheService) getClusterMetrics(ctx context.Context, cloudWatchClient *awsclient.JSONAPIClient, clusterID string, period time.Duration) (*ElastiCacheMetrics, error) {
+	if !validMetricsPeriod(period) {
+		return nil, ErrInvalidMetricsPeriod
+	}
+
+	endTime := time.Now()
+	input := cwGetMetricStatisticsInput{
+		Namespace:  "AWS/ElastiCache",
+		Dimensions: []cwDimension{{Name: "CacheClusterId", Value: clusterID}},
+		StartTime:  endTime.Add(-period).Unix(),
+		EndTime:    endTime.Unix(),
+		Period:     int32(period.Seconds()),
+	}
+
+	metrics := &ElastiCacheMetrics{}
+	found := false
+	for _, metricStatistic := range clusterMetricStatistics {
+		input.MetricName = metricStatistic.metricName
+		input.Statistics = []string{metricStatistic.statistic}
+
+		var output cwGetMetricStatisticsOutput
+		if err := cloudWatchClient.Call(ctx, "GetMetricStatistics", input, &output); err != nil {
+			s.logger.ForContext(ctx).WithError(err).WithFields(map[string]interface{}{
+				"cache_cluster_id": clusterID,
+				"metric":           metricStatistic.metricName,
+			}).Error().Msg("Failed to get ElastiCache cluster metric statistics")
+			return nil, fmt.Errorf("failed to get %s for %s: %w", metricStatistic.metricName, clusterID, err)
+		}
+		if len(output.Datapoints) == 0 {
+			continue
+		}
+		found = true
+
+		// The period can span two of CloudWatch's aligned periods, so the
+		// data points are combined with the metric's own statistic
+		var value float64
+		for i, datapoint := range output.Datapoints {
+			switch metricStatistic.statistic {
+			case "Average":
+				value += datapoint.Average / float64(len(output.Datapoints))
+			case "Maximum":
+				if i == 0 || datapoint.Maximum > value {
+					value = datapoint.Maximum
+				}
+			case "Sum":
+				value += datapoint.Sum
+			}
+
+			timestamp := time.Unix(0, int64(datapoint.Timestamp*float64(time.Second))).UTC()
+			if timestamp.After(metrics.Timestamp) {
+				metrics.Timestamp = timestamp
+			}
+		}
+		metricStatistic.record(metrics, value)
+	}
+
+	if !found {
+		return nil, ErrNoMetricData
+	}
+	return metrics, nil
+}
+
+// validMetricsPeriod reports whether cluster metrics can be fetched over
+// period. CloudWatch periods are whole minutes.
+func validMetricsPeriod(period time.Duration) bool {
+	return period >= time.Minute && period <= MaxClusterMetricsPeriod && period%time.Minute == 0
+}
+
+// PopulateClusterMetrics sets the Metrics of every cluster in a summary,
+// including replication group members, to their metrics over period.
+// Clusters whose metrics can't be fetched are logged and left without
+// them, so one cluster doesn't stop the rest being reported.
+func (s *ElastiCacheService) PopulateClusterMetrics(ctx context.Context, summary *CacheClustersSummary, period time.Duration) {
+	ctx, span := tracing.Start(ctx, "elasticache.populate_cluster_metrics")
+	defer span.End()
+
+	log := s.logger.ForContext(ctx)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	clusterMetrics := make(map[string]*ElastiCacheMetrics)
+	sem := make(chan struct{}, clusterMetricsWorkers)
+	for _, cacheCluster := range summary.AllCacheClusters {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			metrics, err := s.getClusterMetrics(ctx, s.account(cacheCluster.AccountID).cloudWatchClient, cacheCluster.Id, period)
 			if err != nil {
-				return nil, err
+				log.WithError(err).WithField("cache_cluster_id", cacheCluster.Id).Warn().Msg("Listing ElastiCache cluster without metrics")
+				return
 			}
 
-			cacheClusterUpdateActions = append(cacheClusterUpdateActions, *cacheClusterUpdateAction)
+			mu.Lock()
+			clusterMetrics[cacheCluster.AccountID+"/"+cacheCluster.Id] = metrics
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	setMetrics := func(cacheClusters []ElastiCacheCluster) {
+		for i := range cacheClusters {
+			cacheClusters[i].Metrics = clusterMetrics[cacheClusters[i].AccountID+"/"+cacheClusters[i].Id]
 		}
 	}
+	setMetrics(summary.AllCacheClusters)
+	setMetrics(summary.NonReplicatedCacheClusters)
+	for i := range summary.ReplicationGroups {
+		setMetrics(summary.ReplicationGroups[i].MemberClusters)
+	}
+}
+
+// recommendNodeType applies the memory pressure and eviction heuristics to a
+// replication group, returning nil if no upgrade is needed. Groups on node
+// types without a known upgrade path are still reported, with an empty
+// RecommendedNodeType.
+func recommendNodeType(replicationGroup ElastiCacheReplicationGroup, metrics replicationGroupMetrics) *NodeTypeRecommendation {
+	var reasons []string
+	priority := SeverityLow
+
+	current, known := nodeTypeSpecs[replicationGroup.NodeType]
+	memoryUsed := 0.0
+	if known && current.MemoryGiB > 0 {
+		memoryUsed = metrics.MaxBytesUsedForCache / (current.MemoryGiB * 1024 * 1024 * 1024)
+	}
 
-	return cacheClusterUpdateActions, nil
+	if memoryUsed > MemoryPressureThreshold {
+		reasons = append(reasons, fmt.Sprintf("memory use peaked at %.0f%% of %s", memoryUsed*100, replicationGroup.NodeType))
+		priority = SeverityMedium
+		if memoryUsed > 0.95 {
+			priority = SeverityHigh
+		}
+	}
+
+	if metrics.Evictions > 0 {
+		reasons = append(reasons, fmt.Sprintf("%.0f keys evicted alongside %.0f cache hits", metrics.Evictions, metrics.CacheHits))
+		if priority == SeverityLow {
+			priority = SeverityMedium
+		}
+		// Evictions are most costly when the cache is being read from heavily
+		if metrics.CacheHits > 0 && metrics.Evictions/metrics.CacheHits > 0.01 {
+			priority = SeverityHigh
+		}
+	}
+
+	if len(reasons) == 0 {
+		return nil
+	}
+
+	recommendation := &NodeTypeRecommendation{
+		GroupID:         replicationGroup.Id,
+		AccountID:       replicationGroup.AccountID,
+		CurrentNodeType: replicationGroup.NodeType,
+		Priority:        priority,
+	}
+
+	next, hasUpgrade := nodeTypeUpgradePath[replicationGroup.NodeType]
+	if !hasUpgrade {
+		reasons = append(reasons, "no upgrade path is known for this node type")
+	} else {
+		recommendation.RecommendedNodeType = next
+		if known {
+			nodes := max(len(replicationGroup.MemberClusters), 1)
+			recommendation.EstimatedAdditionalCostMonthly = (nodeTypeSpecs[next].HourlyCostGBP - current.HourlyCostGBP) * hoursPerMonth * float64(nodes)
+		}
+	}
+
+	recommendation.Reason = strings.Join(reasons, "; ")
+	return recommendation
+}
+
+func severityRank(severity Severity) int {
+	switch severity {
+	case SeverityCritical:
+		return 3
+	case SeverityHigh:
+		return 2
+	case SeverityMedium:
+		return 1
+	}
+	return 0
 }
 
+// GetServerlessCaches returns the serverless caches in every account
 func (s *ElastiCacheService) GetServerlessCaches(ctx context.Context) ([]ElastiCacheServerlessCache, error) {
+	ctx, span := tracing.Start(ctx, "elasticache.get_serverless_caches")
+	defer span.End()
+
 	var serverlessCaches []ElastiCacheServerlessCache
 
-	paginator := elasticache.NewDescribeServerlessCachesPaginator(s.client, &elasticache.DescribeServerlessCachesInput{})
+	for _, account := range s.accounts {
+		paginator := elasticache.NewDescribeServerlessCachesPaginator(account.client, &elasticache.DescribeServerlessCachesInput{})
 
-	for paginator.HasMorePages() {
-		page, err := paginator.NextPage(ctx)
-		if err != nil {
-			s.logger.WithError(err).Error().Msg("Failed to describe ElastiCache serverless caches")
-			return nil, fmt.Errorf("failed to describe ElastiCache serverless caches: %w", err)
-		}
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				s.logger.ForContext(ctx).WithError(err).WithField("account_id", account.id).Error().Msg("Failed to describe ElastiCache serverless caches")
+				return nil, fmt.Errorf("failed to describe ElastiCache serverless caches%s: %w", inAccount(account.id), err)
+			}
 
-		for _, serverlessCache := range page.ServerlessCaches {
-			serverlessCaches = append(serverlessCaches, s.convertToServerlessElastiCache(serverlessCache))
+			for _, serverlessCache := range page.ServerlessCaches {
+				converted := s.convertToServerlessElastiCache(serverlessCache)
+				converted.AccountID = account.id
+				serverlessCaches = append(serverlessCaches, converted)
+			}
 		}
 	}
 
@@ -263,7 +1242,7 @@ func (s *ElastiCacheService) GetServerlessCaches(ctx context.Context) ([]ElastiC
 }
 
 func (s *ElastiCacheService) convertToElastiCacheCluster(cacheCluster types.CacheCluster) ElastiCacheCluster {
-	return ElastiCacheCluster{
+	cluster := ElastiCacheCluster{
 		ARN:           aws.ToString(cacheCluster.ARN),
 		Id:            aws.ToString(cacheCluster.CacheClusterId),
 		NodeType:      aws.ToString(cacheCluster.CacheNodeType),
@@ -276,13 +1255,22 @@ func (s *ElastiCacheService) convertToElastiCacheCluster(cacheCluster types.Cach
 			InTransit: aws.ToBool(cacheCluster.TransitEncryptionEnabled),
 		},
 		ReplicationGroup:              aws.ToString(cacheCluster.ReplicationGroupId),
+		ParameterGroup:                parameterGroupName(cacheCluster.CacheParameterGroup),
 		UnappliedUpdateActionsSummary: ElastiCacheUpdateActionsSummary{},
 		UnappliedUpdateActions:        []ElastiCacheCacheClusterUpdateAction{},
 	}
+
+	versionInfo, isOutdated := s.enrichWithVersionInfo(cluster.Engine, cluster.EngineVersion)
+	cluster.IsEOL = versionInfo.IsEOL
+	cluster.EOLDate = versionInfo.EOLDate
+	cluster.LatestVersion = versionInfo.LatestVersion
+	cluster.IsOutdated = isOutdated
+
+	return cluster
 }
 
 func (s *ElastiCacheService) convertToServerlessElastiCache(serverlessCache types.ServerlessCache) ElastiCacheServerlessCache {
-	return ElastiCacheServerlessCache{
+	converted := ElastiCacheServerlessCache{
 		ARN:                aws.ToString(serverlessCache.ARN),
 		Name:               aws.ToString(serverlessCache.ServerlessCacheName),
 		Status:             aws.ToString(serverlessCache.Status),
@@ -290,6 +1278,17 @@ func (s *ElastiCacheService) convertToServerlessElastiCache(serverlessCache type
 		MajorEngineVersion: aws.ToString(serverlessCache.MajorEngineVersion),
 		FullEngineVersion:  aws.ToString(serverlessCache.FullEngineVersion),
 	}
+
+	if limits := serverlessCache.CacheUsageLimits; limits != nil {
+		if limits.DataStorage != nil {
+			converted.MaxDataStorageGB = aws.ToInt32(limits.DataStorage.Maximum)
+		}
+		if limits.ECPUPerSecond != nil {
+			converted.MaxECPUPerSecond = aws.ToInt32(limits.ECPUPerSecond.Maximum)
+		}
+	}
+
+	return converted
 }
 
 func (s *ElastiCacheService) convertToElastiCacheReplicationGroup(replicationGroup types.ReplicationGroup, cacheClusters []ElastiCacheCluster) ElastiCacheReplicationGroup {
@@ -303,7 +1302,14 @@ func (s *ElastiCacheService) convertToElastiCacheReplicationGroup(replicationGro
 		}
 	}
 
-	return ElastiCacheReplicationGroup{
+	// Replication groups don't report an engine version, but all their
+	// members run the same one
+	var engineVersion string
+	if len(memberClusters) > 0 {
+		engineVersion = memberClusters[0].EngineVersion
+	}
+
+	converted := ElastiCacheReplicationGroup{
 		ARN:            aws.ToString(replicationGroup.ARN),
 		Id:             replicationGroupId,
 		NodeType:       aws.ToString(replicationGroup.CacheNodeType),
@@ -313,13 +1319,24 @@ func (s *ElastiCacheService) convertToElastiCacheReplicationGroup(replicationGro
 		ClusterEnabled: aws.ToBool(replicationGroup.ClusterEnabled),
 		ClusterMode:    aws.ToString((*string)(&replicationGroup.ClusterMode)),
 		Engine:         aws.ToString(replicationGroup.Engine),
+		EngineVersion:  engineVersion,
 		EncryptionConfig: CacheClusterEncyrptionConfig{
 			AtRest:    aws.ToBool(replicationGroup.AtRestEncryptionEnabled),
 			InTransit: aws.ToBool(replicationGroup.TransitEncryptionEnabled),
 		},
+		SnapshotRetentionLimit:        aws.ToInt32(replicationGroup.SnapshotRetentionLimit),
 		UnappliedUpdateActionsSummary: ElastiCacheUpdateActionsSummary{},
 		UnappliedUpdateActions:        []ElastiCacheReplicationGroupUpdateAction{},
 	}
+
+	if engineVersion != "" {
+		versionInfo, isOutdated := s.enrichWithVersionInfo(converted.Engine, engineVersion)
+		converted.IsEOL = versionInfo.IsEOL
+		converted.EOLDate = versionInfo.EOLDate
+		converted.IsOutdated = isOutdated
+	}
+
+	return converted
 }
 
 func (s *ElastiCacheService) convertToReplicationGroupUpdateAction(updateAction types.UpdateAction) (*ElastiCacheReplicationGroupUpdateAction, error) {
@@ -419,6 +1436,15 @@ func (s *ElastiCacheService) generateCacheClustersSummary(replicationGroups *[]E
 		}
 	}
 
+	var eolClusters, outdatedClusters int = 0, 0
+	for _, cacheCluster := range *cacheClusters {
+		if cacheCluster.IsEOL {
+			eolClusters += 1
+		} else if cacheCluster.IsOutdated {
+			outdatedClusters += 1
+		}
+	}
+
 	var nonReplicatedCacheClusters []ElastiCacheCluster
 	for _, cacheCluster := range *cacheClusters {
 		if cacheCluster.ReplicationGroup == "" {
@@ -431,6 +1457,21 @@ func (s *ElastiCacheService) generateCacheClustersSummary(replicationGroups *[]E
 		return nil, err
 	}
 
+	byApplication := make(map[string]ApplicationCacheStats)
+	for _, cacheCluster := range *cacheClusters {
+		stats := byApplication[applicationKey(cacheCluster.Application)]
+		stats.ClusterCount += 1
+		stats.NodeCount += cacheCluster.NumCacheNodes
+		stats.Environments = appendUnique(stats.Environments, cacheCluster.Environment)
+		byApplication[applicationKey(cacheCluster.Application)] = stats
+	}
+	for _, replicationGroup := range *replicationGroups {
+		stats := byApplication[applicationKey(replicationGroup.Application)]
+		stats.ReplicationGroupCount += 1
+		stats.Environments = appendUnique(stats.Environments, replicationGroup.Environment)
+		byApplication[applicationKey(replicationGroup.Application)] = stats
+	}
+
 	return &CacheClustersSummary{
 		TotalClusters:                 len(*cacheClusters),
 		TotalServerlessCaches:         len(*serverlessCaches),
@@ -441,10 +1482,28 @@ func (s *ElastiCacheService) generateCacheClustersSummary(replicationGroups *[]E
 		RedisNodesCount:               redisNodeCount,
 		ValkeyCount:                   valkeyCount,
 		ValkeyNodesCount:              valkeyNodeCount,
+		EOLClusters:                   eolClusters,
+		OutdatedClusters:              outdatedClusters,
 		AllCacheClusters:              *cacheClusters,
 		ReplicationGroups:             *replicationGroups,
 		NonReplicatedCacheClusters:    nonReplicatedCacheClusters,
 		ServerlessCaches:              *serverlessCaches,
 		UnappliedUpdateActionsSummary: *updateActionsSummary,
+		ByApplication:                 byApplication,
 	}, nil
 }
+
+// applicationKey groups untagged resources under "unknown"
+func applicationKey(application string) string {
+	if application == "" {
+		return "unknown"
+	}
+	return application
+}
+
+func appendUnique(values []string, value string) []string {
+	if value == "" || slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
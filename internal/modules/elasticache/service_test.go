@@ -0,0 +1,633 @@
+package elasticache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"govuk-reports-dashboard/internal/config"
+	awsclient "govuk-reports-dashboard/pkg/aws"
+	"govuk-reports-dashboard/pkg/govuk"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+)
+
+func TestSummariseUpdateActions_ReplicatedClustersNotDoubleCounted(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	s := &ElastiCacheService{logger: log}
+
+	updateAction := func(replicationGroupID, cacheClusterID, serviceUpdateName string) types.UpdateAction {
+		action := types.UpdateAction{
+			ServiceUpdateName:     aws.String(serviceUpdateName),
+			ServiceUpdateSeverity: types.ServiceUpdateSeverityCritical,
+			ServiceUpdateStatus:   types.ServiceUpdateStatusAvailable,
+			UpdateActionStatus:    types.UpdateActionStatusNotApplied,
+			NodesUpdated:          aws.String("0/2"),
+		}
+		if replicationGroupID != "" {
+			action.ReplicationGroupId = aws.String(replicationGroupID)
+		}
+		if cacheClusterID != "" {
+			action.CacheClusterId = aws.String(cacheClusterID)
+			action.UpdateActionStatus = types.UpdateActionStatusNotApplicable
+		}
+		return action
+	}
+
+	replicationGroups := []ElastiCacheReplicationGroup{{Id: "sessions"}}
+	cacheClusters := []ElastiCacheCluster{
+		{Id: "sessions-001", ReplicationGroup: "sessions"},
+		{Id: "sessions-002", ReplicationGroup: "sessions"},
+		{Id: "memcached-001"},
+	}
+
+	replicationGroupAction, err := s.convertToReplicationGroupUpdateAction(updateAction("sessions", "", "elasticache-20240101-001"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cacheClusterActions []ElastiCacheCacheClusterUpdateAction
+	for _, cacheClusterID := range []string{"sessions-001", "sessions-002", "memcached-001"} {
+		action, err := s.convertToCacheClusterUpdateAction(updateAction("", cacheClusterID, "elasticache-20240101-001"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		cacheClusterActions = append(cacheClusterActions, *action)
+	}
+
+	summary := summariseUpdateActions(&replicationGroups, &cacheClusters, []ElastiCacheReplicationGroupUpdateAction{*replicationGroupAction}, cacheClusterActions)
+
+	// One for the replication group and one for the standalone cluster
+	if summary.UnappliedUpdateCount != 2 {
+		t.Errorf("Expected 2 unapplied updates, got %d", summary.UnappliedUpdateCount)
+	}
+	if summary.TotalUnappliedCriticalUpdateCount != 2 {
+		t.Errorf("Expected 2 critical updates, got %d", summary.TotalUnappliedCriticalUpdateCount)
+	}
+	if replicationGroups[0].UnappliedUpdateActionsSummary.UnappliedUpdateCount != 1 {
+		t.Errorf("Expected replication group to have 1 update, got %d", replicationGroups[0].UnappliedUpdateActionsSummary.UnappliedUpdateCount)
+	}
+	if len(cacheClusters[0].UnappliedUpdateActions) != 0 || len(cacheClusters[1].UnappliedUpdateActions) != 0 {
+		t.Error("Expected replication group members not to have their own update actions")
+	}
+	if len(cacheClusters[2].UnappliedUpdateActions) != 1 {
+		t.Errorf("Expected standalone cluster to have 1 update action, got %d", len(cacheClusters[2].UnappliedUpdateActions))
+	}
+}
+
+func TestSummariseUpdateActions_MatchesAccounts(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	s := &ElastiCacheService{logger: log}
+
+	// The same cluster ID in two accounts
+	cacheClusters := []ElastiCacheCluster{
+		{Id: "sessions-001", AccountID: "111111111111"},
+		{Id: "sessions-001", AccountID: "222222222222"},
+	}
+
+	action, err := s.convertToCacheClusterUpdateAction(types.UpdateAction{
+		CacheClusterId:        aws.String("sessions-001"),
+		ServiceUpdateName:     aws.String("elasticache-20240101-001"),
+		ServiceUpdateSeverity: types.ServiceUpdateSeverityImportant,
+		ServiceUpdateStatus:   types.ServiceUpdateStatusAvailable,
+		UpdateActionStatus:    types.UpdateActionStatusNotApplicable,
+		NodesUpdated:          aws.String("0/1"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	action.AccountID = "222222222222"
+
+	summariseUpdateActions(&[]ElastiCacheReplicationGroup{}, &cacheClusters, nil, []ElastiCacheCacheClusterUpdateAction{*action})
+
+	if len(cacheClusters[0].UnappliedUpdateActions) != 0 {
+		t.Error("Expected the cluster in the other account not to have the update action")
+	}
+	if len(cacheClusters[1].UnappliedUpdateActions) != 1 {
+		t.Errorf("Expected the cluster in the action's account to have 1 update action, got %d", len(cacheClusters[1].UnappliedUpdateActions))
+	}
+}
+
+// newAccountClient returns a client for a fake account with one serverless
+// cache, named cacheName
+func newAccountClient(t *testing.T, log *logger.Logger, accountID, cacheName string) *awsclient.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch r.Form.Get("Action") {
+		case "GetCallerIdentity":
+			w.Write([]byte(`<GetCallerIdentityResponse><GetCallerIdentityResult>
+				<Account>` + accountID + `</Account>
+			</GetCallerIdentityResult></GetCallerIdentityResponse>`))
+		case "DescribeServerlessCaches":
+			w.Write([]byte(`<DescribeServerlessCachesResponse><DescribeServerlessCachesResult><ServerlessCaches>
+				<member>
+					<ServerlessCacheName>` + cacheName + `</ServerlessCacheName>
+					<Engine>valkey</Engine>
+					<Status>available</Status>
+				</member>
+			</ServerlessCaches></DescribeServerlessCachesResult></DescribeServerlessCachesResponse>`))
+		default:
+			t.Errorf("Unexpected action %q", r.Form.Get("Action"))
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := awsclient.NewClientWithCostExplorer(aws.Config{
+		Region:       "eu-west-2",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}, nil, log)
+	client.NewServiceClient(awsclient.ServiceSTS).(*awsclient.QueryAPIClient).WithEndpoint(server.URL)
+	if err := client.ResolveAccountID(context.Background()); err != nil {
+		t.Fatalf("ResolveAccountID failed: %v", err)
+	}
+	return client
+}
+
+func TestMultiAccountElastiCacheService_GetServerlessCaches(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	s := NewMultiAccountElastiCacheService([]*awsclient.Client{
+		newAccountClient(t, log, "111111111111", "router-sessions"),
+		newAccountClient(t, log, "222222222222", "search-cache"),
+	}, &config.Config{}, log)
+
+	serverlessCaches, err := s.GetServerlessCaches(context.Background())
+	if err != nil {
+		t.Fatalf("GetServerlessCaches failed: %v", err)
+	}
+
+	if len(serverlessCaches) != 2 {
+		t.Fatalf("Expected serverless caches from both accounts, got %d", len(serverlessCaches))
+	}
+	accountIDs := make(map[string]string)
+	for _, serverlessCache := range serverlessCaches {
+		accountIDs[serverlessCache.Name] = serverlessCache.AccountID
+	}
+	if accountIDs["router-sessions"] != "111111111111" || accountIDs["search-cache"] != "222222222222" {
+		t.Errorf("Expected serverless caches tagged with their accounts, got %v", accountIDs)
+	}
+}
+
+func TestCheckParameterCompliance(t *testing.T) {
+	tests := []struct {
+		name           string
+		parameters     map[string]string
+		wantViolations int
+	}{
+		{
+			name:           "compliant",
+			parameters:     map[string]string{"maxmemory-policy": "allkeys-lru", "hz": "10", "lazyfree-lazy-eviction": "yes"},
+			wantViolations: 0,
+		},
+		{
+			name:           "every setting wrong",
+			parameters:     map[string]string{"maxmemory-policy": "noeviction", "hz": "1", "lazyfree-lazy-eviction": "no"},
+			wantViolations: 3,
+		},
+		{
+			name:           "missing parameters are not checked",
+			parameters:     map[string]string{"maxmemory-policy": "volatile-lru"},
+			wantViolations: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if violations := checkParameterCompliance(tt.parameters); len(violations) != tt.wantViolations {
+				t.Errorf("Expected %d violations, got %v", tt.wantViolations, violations)
+			}
+		})
+	}
+}
+
+func TestRecommendNodeType(t *testing.T) {
+	const gib = 1024 * 1024 * 1024
+	group := func(nodeType string) ElastiCacheReplicationGroup {
+		return ElastiCacheReplicationGroup{
+			Id:             "sessions",
+			NodeType:       nodeType,
+			MemberClusters: []ElastiCacheCluster{{Id: "sessions-001"}, {Id: "sessions-002"}},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		nodeType     string
+		metrics      replicationGroupMetrics
+		wantNil      bool
+		wantNodeType string
+		wantPriority Severity
+	}{
+		{
+			name:     "healthy",
+			nodeType: "cache.t3.small",
+			metrics:  replicationGroupMetrics{MaxBytesUsedForCache: 0.5 * gib, CacheHits: 1000},
+			wantNil:  true,
+		},
+		{
+			name:         "memory pressure",
+			nodeType:     "cache.t3.small",
+			metrics:      replicationGroupMetrics{MaxBytesUsedForCache: 1.2 * gib},
+			wantNodeType: "cache.t3.medium",
+			wantPriority: SeverityMedium,
+		},
+		{
+			name:         "evictions under heavy reads",
+			nodeType:     "cache.r6g.large",
+			metrics:      replicationGroupMetrics{MaxBytesUsedForCache: 2 * gib, Evictions: 500, CacheHits: 1000},
+			wantNodeType: "cache.r6g.xlarge",
+			wantPriority: SeverityHigh,
+		},
+		{
+			name:         "no upgrade path",
+			nodeType:     "cache.r6g.4xlarge",
+			metrics:      replicationGroupMetrics{Evictions: 1, CacheHits: 1000000},
+			wantNodeType: "",
+			wantPriority: SeverityMedium,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recommendation := recommendNodeType(group(tt.nodeType), tt.metrics)
+			if tt.wantNil {
+				if recommendation != nil {
+					t.Errorf("Expected no recommendation, got %+v", recommendation)
+				}
+				return
+			}
+			if recommendation == nil {
+				t.Fatal("Expected a recommendation")
+			}
+			if recommendation.RecommendedNodeType != tt.wantNodeType {
+				t.Errorf("Expected %q, got %q", tt.wantNodeType, recommendation.RecommendedNodeType)
+			}
+			if recommendation.Priority != tt.wantPriority {
+				t.Errorf("Expected priority %s, got %s", tt.wantPriority, recommendation.Priority)
+			}
+			if tt.wantNodeType != "" && recommendation.EstimatedAdditionalCostMonthly <= 0 {
+				t.Errorf("Expected a positive additional cost, got %f", recommendation.EstimatedAdditionalCostMonthly)
+			}
+		})
+	}
+}
+
+func TestCheckMultiAZ(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	s := &ElastiCacheService{
+		logger: log,
+		govukClient: &govuk.MockApplicationsClient{
+			BulkGetApplicationsByNameResult: map[string]*govuk.Application{
+				"publishing-api": {AppName: "Publishing API", ProductionHostedOn: "eks"},
+				"retired-app":    {AppName: "Retired App"},
+			},
+		},
+	}
+
+	replicationGroups := []ElastiCacheReplicationGroup{
+		{Id: "sessions", Environment: "production", MultiAZ: "disabled", MemberClusters: []ElastiCacheCluster{{Id: "sessions-001"}}},
+		{Id: "publishing-api", Application: "publishing-api", MultiAZ: "disabled"},
+		{Id: "retired-app", Application: "retired-app", MultiAZ: "disabled"},
+		{Id: "frontend", Environment: "production", MultiAZ: "enabled"},
+		{Id: "staging", Environment: "staging", Application: "publishing-api", MultiAZ: "disabled"},
+	}
+
+	items := checkMultiAZ(replicationGroups, s.getProductionApplications(context.Background(), replicationGroups))
+
+	compliant := make(map[string]bool)
+	for _, item := range items {
+		compliant[item.GroupID] = item.IsCompliant
+	}
+	expected := map[string]bool{
+		"sessions":       false,
+		"publishing-api": false,
+		"retired-app":    true,
+		"frontend":       true,
+		"staging":        true,
+	}
+	for groupID, want := range expected {
+		if compliant[groupID] != want {
+			t.Errorf("Expected %s compliant=%v, got %v", groupID, want, compliant[groupID])
+		}
+	}
+	if items[0].GroupID != "frontend" {
+		t.Errorf("Expected items sorted by group ID, got %+v", items)
+	}
+}
+
+func TestCheckBackupRetention(t *testing.T) {
+	replicationGroups := []ElastiCacheReplicationGroup{
+		{Id: "sessions", Environment: "production", SnapshotRetentionLimit: 0},
+		{Id: "publishing-api", Application: "publishing-api", SnapshotRetentionLimit: 1},
+		{Id: "frontend", Environment: "production", SnapshotRetentionLimit: 7},
+		{Id: "staging", Environment: "staging", SnapshotRetentionLimit: 0},
+	}
+	productionApps := map[string]bool{"publishing-api": true}
+
+	items := checkBackupRetention(replicationGroups, productionApps, 3)
+
+	compliant := make(map[string]bool)
+	for _, item := range items {
+		compliant[item.GroupID] = item.IsCompliant
+	}
+	expected := map[string]bool{
+		"sessions":       false,
+		"publishing-api": false,
+		"frontend":       true,
+		"staging":        true,
+	}
+	for groupID, want := range expected {
+		if compliant[groupID] != want {
+			t.Errorf("Expected %s compliant=%v, got %v", groupID, want, compliant[groupID])
+		}
+	}
+	if items[0].GroupID != "frontend" || !items[1].IsProduction {
+		t.Errorf("Expected items sorted by group ID with publishing-api production, got %+v", items)
+	}
+}
+
+func TestCheckServerlessScaling(t *testing.T) {
+	serverlessCache := ElastiCacheServerlessCache{Name: "sessions", MaxDataStorageGB: 10, MaxECPUPerSecond: 1000}
+
+	tests := []struct {
+		name              string
+		serverlessCache   ElastiCacheServerlessCache
+		peakBytes         float64
+		peakECPUPerSecond float64
+		wantNearLimit     bool
+	}{
+		{"within limits", serverlessCache, 5e9, 500, false},
+		{"storage near limit", serverlessCache, 9e9, 100, true},
+		{"ECPU near limit", serverlessCache, 1e9, 900, true},
+		{"no limits set", ElastiCacheServerlessCache{Name: "unlimited"}, 9e12, 1e6, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := checkServerlessScaling(tt.serverlessCache, tt.peakBytes, tt.peakECPUPerSecond)
+			if item.IsNearLimit != tt.wantNearLimit {
+				t.Errorf("Expected IsNearLimit=%v, got %+v", tt.wantNearLimit, item)
+			}
+		})
+	}
+
+	item := checkServerlessScaling(serverlessCache, 9e9, 100)
+	if item.CurrentStorageGB != 9 || item.StorageUtilizationPercent != 90 || item.ECPUUtilizationPercent != 10 {
+		t.Errorf("Unexpected utilisation: %+v", item)
+	}
+}
+
+func TestConvertToElastiCacheCluster_EngineVersions(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	eolDate := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	s := &ElastiCacheService{logger: log, eolData: ElastiCacheEngineEOL{
+		Redis: map[string]EngineVersionInfo{
+			"7.1": {MajorVersion: "7.1", LatestVersion: "7.1.0", IsSupported: true},
+			"6.2": {MajorVersion: "6.2", LatestVersion: "6.2.6", IsSupported: false},
+			"5.0": {MajorVersion: "5.0", LatestVersion: "5.0.6", IsEOL: true, EOLDate: &eolDate},
+		},
+		Memcached: map[string]EngineVersionInfo{
+			"1.6": {MajorVersion: "1.6", LatestVersion: "1.6.22", IsSupported: true},
+		},
+	}}
+
+	tests := []struct {
+		engine, version string
+		eol, outdated   bool
+		latestVersion   string
+	}{
+		{"redis", "7.1.0", false, false, "7.1.0"},
+		{"redis", "6.2.6", false, true, "6.2.6"},
+		{"redis", "5.0.6", true, false, "5.0.6"},
+		{"memcached", "1.6.17", false, false, "1.6.22"},
+		{"memcached", "1.4.34", false, true, ""},
+		{"valkey", "7.2", false, true, ""},
+	}
+
+	var clusters []ElastiCacheCluster
+	for _, tt := range tests {
+		cluster := s.convertToElastiCacheCluster(types.CacheCluster{
+			CacheClusterId: aws.String(tt.engine + "-" + tt.version),
+			Engine:         aws.String(tt.engine),
+			EngineVersion:  aws.String(tt.version),
+		})
+		clusters = append(clusters, cluster)
+
+		if cluster.IsEOL != tt.eol || cluster.IsOutdated != tt.outdated || cluster.LatestVersion != tt.latestVersion {
+			t.Errorf("%s %s: expected EOL %v, outdated %v, latest %q, got %v, %v, %q",
+				tt.engine, tt.version, tt.eol, tt.outdated, tt.latestVersion, cluster.IsEOL, cluster.IsOutdated, cluster.LatestVersion)
+		}
+	}
+
+	if clusters[2].EOLDate == nil || !clusters[2].EOLDate.Equal(eolDate) {
+		t.Errorf("Expected EOL date %v, got %v", eolDate, clusters[2].EOLDate)
+	}
+
+	outdated := outdatedClusters(clusters)
+	var outdatedIDs []string
+	for _, cluster := range outdated {
+		outdatedIDs = append(outdatedIDs, cluster.Id)
+	}
+	if want := []string{"memcached-1.4.34", "redis-5.0.6", "redis-6.2.6", "valkey-7.2"}; !slices.Equal(outdatedIDs, want) {
+		t.Errorf("Expected outdated clusters %v, got %v", want, outdatedIDs)
+	}
+}
+
+func TestGetElastiCacheVersionData(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	s := &ElastiCacheService{logger: log, eolData: getElastiCacheVersionData()}
+
+	tests := []struct {
+		engine, version string
+		eol, outdated   bool
+	}{
+		{"redis", "6.0.5", true, false},
+		{"redis", "6.2.6", false, true},
+		{"redis", "7.0.7", false, false},
+		{"redis", "7.2.4", false, false},
+		{"valkey", "8.0.1", false, false},
+		{"memcached", "1.6.22", false, false},
+		{"memcached", "1.5.16", false, true},
+	}
+
+	for _, tt := range tests {
+		cluster := s.convertToElastiCacheCluster(types.CacheCluster{
+			Engine:        aws.String(tt.engine),
+			EngineVersion: aws.String(tt.version),
+		})
+		if cluster.IsEOL != tt.eol || cluster.IsOutdated != tt.outdated {
+			t.Errorf("%s %s: expected EOL %v, outdated %v, got %v, %v",
+				tt.engine, tt.version, tt.eol, tt.outdated, cluster.IsEOL, cluster.IsOutdated)
+		}
+	}
+}
+
+func TestConvertToElastiCacheReplicationGroup_EngineVersion(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	s := &ElastiCacheService{logger: log, eolData: getElastiCacheVersionData()}
+
+	clusters := []ElastiCacheCluster{
+		{Id: "sessions-001", Engine: "redis", EngineVersion: "6.0.5", ReplicationGroup: "sessions"},
+		{Id: "locks-001", Engine: "redis", EngineVersion: "7.2.4", ReplicationGroup: "locks"},
+	}
+
+	sessions := s.convertToElastiCacheReplicationGroup(types.ReplicationGroup{
+		ReplicationGroupId: aws.String("sessions"),
+		Engine:             aws.String("redis"),
+	}, clusters)
+	if sessions.EngineVersion != "6.0.5" || !sessions.IsEOL || sessions.EOLDate == nil || sessions.IsOutdated {
+		t.Errorf("Expected sessions to be EOL on 6.0.5, got %+v", sessions)
+	}
+
+	locks := s.convertToElastiCacheReplicationGroup(types.ReplicationGroup{
+		ReplicationGroupId: aws.String("locks"),
+		Engine:             aws.String("redis"),
+	}, clusters)
+	if locks.IsEOL || locks.IsOutdated {
+		t.Errorf("Expected locks to be current on 7.2.4, got %+v", locks)
+	}
+
+	empty := s.convertToElastiCacheReplicationGroup(types.ReplicationGroup{
+		ReplicationGroupId: aws.String("empty"),
+		Engine:             aws.String("redis"),
+	}, clusters)
+	if empty.IsEOL || empty.IsOutdated {
+		t.Errorf("Expected a group without members not to be flagged, got %+v", empty)
+	}
+}
+
+// newMetricsService returns a service whose CloudWatch client answers
+// GetMetricStatistics with the given data points for each metric name,
+// and records the requests it gets
+func newMetricsService(t *testing.T, datapoints map[string]string) (*ElastiCacheService, *[]cwGetMetricStatisticsInput) {
+	t.Helper()
+
+	var requests []cwGetMetricStatisticsInput
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if target := r.Header.Get("X-Amz-Target"); target != "GraniteServiceVersion20100801.GetMetricStatistics" {
+			t.Errorf("Unexpected CloudWatch operation %q", target)
+		}
+
+		var input cwGetMetricStatisticsInput
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		requests = append(requests, input)
+
+		points, ok := datapoints[input.MetricName]
+		if !ok {
+			points = "[]"
+		}
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		fmt.Fprintf(w, `{"Label": %q, "Datapoints": %s}`, input.MetricName, points)
+	}))
+	t.Cleanup(server.Close)
+
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	client := awsclient.NewClientWithCostExplorer(aws.Config{
+		Region:      "eu-west-2",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}, nil, log)
+	client.NewServiceClient(awsclient.ServiceCloudWatch).(*awsclient.JSONAPIClient).WithEndpoint(server.URL)
+
+	return NewElastiCacheService(client, &config.Config{}, log), &requests
+}
+
+func TestElastiCacheService_GetClusterMetrics(t *testing.T) {
+	s, requests := newMetricsService(t, map[string]string{
+		// The period spans two of CloudWatch's aligned periods
+		"CPUUtilization":                `[{"Timestamp": 1760000000, "Average": 20}, {"Timestamp": 1760003600, "Average": 40}]`,
+		"DatabaseMemoryUsagePercentage": `[{"Timestamp": 1760003600, "Average": 72.5}]`,
+		"CurrConnections":               `[{"Timestamp": 1760000000, "Maximum": 180}, {"Timestamp": 1760003600, "Maximum": 150}]`,
+		"Evictions":                     `[{"Timestamp": 1760000000, "Sum": 12}, {"Timestamp": 1760003600, "Sum": 30}]`,
+		"CacheHits":                     `[{"Timestamp": 1760003600, "Sum": 98000}]`,
+		"CacheMisses":                   `[{"Timestamp": 1760003600, "Sum": 2000}]`,
+		"NetworkBytesIn":                `[{"Timestamp": 1760003600, "Sum": 5000000}]`,
+		"NetworkBytesOut":               `[{"Timestamp": 1760003600, "Sum": 9000000}]`,
+	})
+
+	metrics, err := s.GetClusterMetrics(context.Background(), "router-sessions-001", 2*time.Hour)
+	if err != nil {
+		t.Fatalf("GetClusterMetrics failed: %v", err)
+	}
+
+	want := ElastiCacheMetrics{
+		CPUUtilization:                30,
+		DatabaseMemoryUsagePercentage: 72.5,
+		CurrConnections:               180,
+		EvictedKeys:                   42,
+		CacheHits:                     98000,
+		CacheMisses:                   2000,
+		NetworkBytesIn:                5000000,
+		NetworkBytesOut:               9000000,
+		Timestamp:                     time.Unix(1760003600, 0).UTC(),
+	}
+	if *metrics != want {
+		t.Errorf("Expected metrics %+v, got %+v", want, *metrics)
+	}
+
+	if len(*requests) != len(clusterMetricStatistics) {
+		t.Fatalf("Expected a request per metric, got %d", len(*requests))
+	}
+	for _, request := range *requests {
+		if request.Namespace != "AWS/ElastiCache" || request.Period != 7200 || request.EndTime-request.StartTime != 7200 {
+			t.Errorf("Unexpected request %+v", request)
+		}
+		if len(request.Dimensions) != 1 || request.Dimensions[0] != (cwDimension{Name: "CacheClusterId", Value: "router-sessions-001"}) {
+			t.Errorf("Expected the CacheClusterId dimension, got %+v", request.Dimensions)
+		}
+	}
+}
+
+func TestElastiCacheService_GetClusterMetrics_NoData(t *testing.T) {
+	s, _ := newMetricsService(t, map[string]string{})
+
+	metrics, err := s.GetClusterMetrics(context.Background(), "router-sessions-001", time.Hour)
+	if !errors.Is(err, ErrNoMetricData) {
+		t.Errorf("Expected ErrNoMetricData, got %v and %+v", err, metrics)
+	}
+}
+
+func TestElastiCacheService_GetClusterMetrics_InvalidPeriod(t *testing.T) {
+	s, requests := newMetricsService(t, map[string]string{})
+
+	for _, period := range []time.Duration{0, 30 * time.Second, 90 * time.Second, MaxClusterMetricsPeriod + time.Hour} {
+		if _, err := s.GetClusterMetrics(context.Background(), "router-sessions-001", period); !errors.Is(err, ErrInvalidMetricsPeriod) {
+			t.Errorf("Expected ErrInvalidMetricsPeriod for %s, got %v", period, err)
+		}
+	}
+	if len(*requests) != 0 {
+		t.Errorf("Expected no CloudWatch requests, got %d", len(*requests))
+	}
+}
+
+func TestElastiCacheService_PopulateClusterMetrics(t *testing.T) {
+	s, _ := newMetricsService(t, map[string]string{
+		"CPUUtilization": `[{"Timestamp": 1760003600, "Average": 55}]`,
+	})
+
+	member := ElastiCacheCluster{Id: "router-sessions-001"}
+	summary := &CacheClustersSummary{
+		AllCacheClusters:  []ElastiCacheCluster{member},
+		ReplicationGroups: []ElastiCacheReplicationGroup{{Id: "router-sessions", MemberClusters: []ElastiCacheCluster{member}}},
+	}
+	s.PopulateClusterMetrics(context.Background(), summary, time.Hour)
+
+	for _, cluster := range []ElastiCacheCluster{summary.AllCacheClusters[0], summary.ReplicationGroups[0].MemberClusters[0]} {
+		if cluster.Metrics == nil || cluster.Metrics.CPUUtilization != 55 {
+			t.Errorf("Expected CPU utilisation of 55, got %+v", cluster.Metrics)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package elasticache
+
+import (
+	"strings"
+	"time"
+)
+
+// getElastiCacheVersionData returns engine version support data. AWS
+// announces the end of standard support for ElastiCache engine versions
+// ahead of time; update this when new versions are released or deprecated.
+// Reference: https://docs.aws.amazon.com/AmazonElastiCache/latest/dg/engine-versions.html
+func getElastiCacheVersionData() ElastiCacheEngineEOL {
+	now := time.Now()
+
+	// Only the Redis LTS releases (6.2, 7.0 and 7.2) and ElastiCache's own
+	// 7.1 are tracked as current. 6.0 stopped receiving upstream fixes when
+	// 7.0 was released, so it is treated as end-of-life even though
+	// ElastiCache still accepts it.
+	eolData := ElastiCacheEngineEOL{
+		Redis: map[string]EngineVersionInfo{
+			"7.2": {MajorVersion: "7.2", LatestVersion: "7.2.4", IsSupported: true},
+			"7.1": {MajorVersion: "7.1", LatestVersion: "7.1.0", IsSupported: true},
+			"7.0": {MajorVersion: "7.0", LatestVersion: "7.0.7", IsSupported: true},
+			"6.2": {
+				MajorVersion:  "6.2",
+				LatestVersion: "6.2.6",
+				IsSupported:   false,
+				EOLDate:       timePtr(time.Date(2027, 1, 31, 0, 0, 0, 0, time.UTC)),
+			},
+			"6.0": {
+				MajorVersion:  "6.0",
+				LatestVersion: "6.0.5",
+				IsSupported:   false,
+				EOLDate:       timePtr(time.Date(2022, 4, 27, 0, 0, 0, 0, time.UTC)),
+			},
+			"5.0": {
+				MajorVersion:  "5.0",
+				LatestVersion: "5.0.6",
+				IsSupported:   false,
+				EOLDate:       timePtr(time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)),
+			},
+			"4.0": {
+				MajorVersion:  "4.0",
+				LatestVersion: "4.0.10",
+				IsSupported:   false,
+				EOLDate:       timePtr(time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		// AWS hasn't announced an end of standard support for any Valkey
+		// version yet
+		Valkey: map[string]EngineVersionInfo{
+			"8.1": {MajorVersion: "8.1", LatestVersion: "8.1", IsSupported: true},
+			"8.0": {MajorVersion: "8.0", LatestVersion: "8.0", IsSupported: true},
+			"7.2": {MajorVersion: "7.2", LatestVersion: "7.2", IsSupported: true},
+		},
+		Memcached: map[string]EngineVersionInfo{
+			"1.6": {MajorVersion: "1.6", LatestVersion: "1.6.22", IsSupported: true},
+			"1.5": {MajorVersion: "1.5", LatestVersion: "1.5.16", IsSupported: false},
+		},
+	}
+
+	// Update IsEOL based on current date
+	for _, versions := range []map[string]EngineVersionInfo{eolData.Redis, eolData.Valkey, eolData.Memcached} {
+		for version, info := range versions {
+			if info.EOLDate != nil && now.After(*info.EOLDate) {
+				info.IsEOL = true
+				info.IsSupported = false
+				versions[version] = info
+			}
+		}
+	}
+
+	return eolData
+}
+
+// enrichWithVersionInfo returns the support information for an engine
+// version, and whether it is outdated: it has a newer supported version, or
+// is not one we know about, but is not yet end-of-life. Unknown versions
+// return an empty EngineVersionInfo.
+func (s *ElastiCacheService) enrichWithVersionInfo(engine, engineVersion string) (EngineVersionInfo, bool) {
+	versionInfo, exists := s.eolData.lookup(engine, engineVersion)
+	if !exists {
+		return EngineVersionInfo{}, true
+	}
+
+	return versionInfo, !versionInfo.IsEOL && !versionInfo.IsSupported
+}
+
+// lookup returns support information for an engine version
+func (e ElastiCacheEngineEOL) lookup(engine, version string) (EngineVersionInfo, bool) {
+	var versions map[string]EngineVersionInfo
+	switch engine {
+	case "redis":
+		versions = e.Redis
+	case "valkey":
+		versions = e.Valkey
+	case "memcached":
+		versions = e.Memcached
+	}
+
+	versionInfo, exists := versions[engineReleaseLine(version)]
+	return versionInfo, exists
+}
+
+// engineReleaseLine returns the major.minor part of an engine version that
+// ElastiCacheEngineEOL is keyed by
+func engineReleaseLine(version string) string {
+	parts := strings.Split(version, ".")
+	if len(parts) > 1 {
+		return parts[0] + "." + parts[1]
+	}
+	return parts[0]
+}
+
+// timePtr returns a pointer to a time.Time
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,92 @@
+package lambda
+
+import (
+	"net/http"
+
+	"govuk-reports-dashboard/internal/handlers"
+	"govuk-reports-dashboard/internal/models"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LambdaHandler struct {
+	lambdaService *LambdaService
+	logger        *logger.Logger
+}
+
+func NewLambdaHandler(lambdaService *LambdaService, logger *logger.Logger) *LambdaHandler {
+	return &LambdaHandler{
+		lambdaService: lambdaService,
+		logger:        logger,
+	}
+}
+
+// GetFunctions handles GET /api/lambda/functions
+func (h *LambdaHandler) GetFunctions(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for Lambda functions")
+
+	summary, err := h.lambdaService.GetAllFunctions(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to get Lambda functions")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get Lambda functions",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	log.WithField("function_count", summary.TotalFunctions).Info().Msg("Successfully fetched Lambda functions")
+	c.JSON(http.StatusOK, gin.H{
+		"functions": summary.Functions,
+		"count":     summary.TotalFunctions,
+	})
+}
+
+// GetOutdatedFunctions handles GET /api/lambda/outdated
+func (h *LambdaHandler) GetOutdatedFunctions(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for Lambda functions on outdated runtimes")
+
+	outdated, err := h.lambdaService.GetOutdatedFunctions(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to get outdated Lambda functions")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get outdated Lambda functions",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	log.WithField("outdated_count", outdated.Count).Info().Msg("Successfully checked Lambda runtimes")
+	c.JSON(http.StatusOK, outdated)
+}
+
+// GetSummary handles GET /api/lambda/summary
+func (h *LambdaHandler) GetSummary(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for Lambda summary")
+
+	summary, err := h.lambdaService.GetAllFunctions(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to get Lambda summary")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get Lambda summary",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	log.WithField("function_count", summary.TotalFunctions).Info().Msg("Successfully summarised Lambda functions")
+	c.JSON(http.StatusOK, gin.H{
+		"total_functions":       summary.TotalFunctions,
+		"eol_functions":         summary.EOLFunctions,
+		"compliance_percentage": summary.CompliancePercentage,
+		"runtime_summary":       summary.RuntimeSummary,
+		"last_updated":          summary.LastUpdated,
+	})
+}
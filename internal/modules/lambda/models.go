@@ -0,0 +1,72 @@
+package lambda
+
+import (
+	"time"
+)
+
+// LambdaFunction is a Lambda function with its runtime's support status
+type LambdaFunction struct {
+	FunctionName string            `json:"function_name"`
+	FunctionARN  string            `json:"function_arn"`
+	Runtime      string            `json:"runtime,omitempty"`
+	Handler      string            `json:"handler,omitempty"`
+	PackageType  string            `json:"package_type"`
+	MemorySize   int32             `json:"memory_size_mb"`
+	Timeout      int32             `json:"timeout_seconds"`
+	LastModified *time.Time        `json:"last_modified,omitempty"`
+	Tags         map[string]string `json:"tags"`
+	Application  string            `json:"application"`
+
+	// IsEOL is whether the function's runtime is deprecated and no longer
+	// receives security patches. Container image functions bring their own
+	// runtime, so are never EOL here.
+	IsEOL   bool       `json:"is_eol"`
+	EOLDate *time.Time `json:"eol_date,omitempty"`
+}
+
+// RuntimeInfo is a Lambda runtime's deprecation schedule
+type RuntimeInfo struct {
+	Runtime     string     `json:"runtime"`
+	Language    string     `json:"language"`
+	IsSupported bool       `json:"is_supported"`
+	IsEOL       bool       `json:"is_eol"`
+	EOLDate     *time.Time `json:"eol_date,omitempty"`
+}
+
+// RuntimeVersions contains deprecation information for Lambda runtimes
+type RuntimeVersions struct {
+	Runtimes map[string]RuntimeInfo `json:"runtimes"`
+	EOL      []string               `json:"eol_runtimes"`
+}
+
+// FunctionsSummary represents a summary of Lambda functions
+type FunctionsSummary struct {
+	TotalFunctions int `json:"total_functions"`
+	EOLFunctions   int `json:"eol_functions"`
+
+	// CompliancePercentage is the percentage of functions not on an EOL
+	// runtime, or 100 if there are no functions
+	CompliancePercentage float64              `json:"compliance_percentage"`
+	Functions            []LambdaFunction     `json:"functions"`
+	RuntimeSummary       []RuntimeSummaryItem `json:"runtime_summary"`
+	LastUpdated          time.Time            `json:"last_updated"`
+}
+
+// RuntimeSummaryItem represents a summary for a specific runtime
+type RuntimeSummaryItem struct {
+	Runtime string     `json:"runtime"`
+	Count   int        `json:"count"`
+	IsEOL   bool       `json:"is_eol"`
+	EOLDate *time.Time `json:"eol_date,omitempty"`
+}
+
+// OutdatedFunctionsResponse represents functions that need runtime upgrades
+type OutdatedFunctionsResponse struct {
+	EOLFunctions []LambdaFunction `json:"eol_functions"`
+
+	// ApproachingEOLFunctions are on runtimes deprecated within
+	// ApproachingEOLWindow, so should be upgraded soon
+	ApproachingEOLFunctions []LambdaFunction `json:"approaching_eol_functions"`
+	Count                   int              `json:"count"`
+	LastChecked             time.Time        `json:"last_checked"`
+}
@@ -0,0 +1,189 @@
+package lambda
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"govuk-reports-dashboard/internal/reports"
+	"govuk-reports-dashboard/pkg/logger"
+)
+
+type LambdaReport struct {
+	lambdaService *LambdaService
+	renderer      *reports.Renderer
+	logger        *logger.Logger
+}
+
+func NewLambdaReport(lambdaService *LambdaService, logger *logger.Logger) *LambdaReport {
+	return &LambdaReport{
+		lambdaService: lambdaService,
+		renderer:      reports.NewRenderer(),
+		logger:        logger,
+	}
+}
+
+func (r *LambdaReport) GetMetadata() reports.ReportMetadata {
+	return reports.ReportMetadata{
+		ID:          "lambda",
+		Name:        "Lambda runtime report",
+		Description: "Lambda functions running deprecated runtimes that no longer receive security patches",
+		Type:        reports.ReportTypeHealth,
+		Version:     "1.0.0",
+		Author:      "GOV.UK Platform Team",
+		Tags:        []string{"lambda", "serverless", "runtime", "eol"},
+		Priority:    reports.PriorityHigh,
+	}
+}
+
+func (r *LambdaReport) GenerateSummary(ctx context.Context, params reports.ReportParams) ([]reports.Summary, error) {
+	r.logger.Info().Msg("Generating Lambda summary for dashboard")
+
+	summary, err := r.lambdaService.GetAllFunctions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Lambda functions: %w", err)
+	}
+
+	return r.generateSummaries(summary), nil
+}
+
+func (r *LambdaReport) GenerateReport(ctx context.Context, params reports.ReportParams) (reports.ReportData, error) {
+	r.logger.Info().Msg("Generating detailed Lambda report")
+
+	data := reports.ReportData{
+		Status:      reports.StatusRunning,
+		GeneratedAt: time.Now(),
+	}
+
+	summary, err := r.lambdaService.GetAllFunctions(ctx)
+	if err != nil {
+		data.Status = reports.StatusFailed
+		data.Errors = append(data.Errors, reports.ReportError{
+			Code:      "LAMBDA_FETCH_ERROR",
+			Message:   "Failed to fetch Lambda functions",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+		})
+		return data, nil
+	}
+
+	data.Summary = r.generateSummaries(summary)
+	data.DataPoints = r.generateDataPoints(summary)
+	data.Tables = r.generateTables(summary)
+
+	data.Status = reports.StatusCompleted
+	r.logger.WithFields(map[string]interface{}{
+		"data_points": len(data.DataPoints),
+		"tables":      len(data.Tables),
+	}).Info().Msg("Generated detailed Lambda report")
+
+	return data, nil
+}
+
+func (r *LambdaReport) IsAvailable(ctx context.Context) bool {
+	return r.lambdaService.IsAvailable(ctx) == nil
+}
+
+// GetRefreshInterval returns how often this report should be refreshed
+func (r *LambdaReport) GetRefreshInterval() time.Duration {
+	return 1 * time.Hour // Runtimes only change when functions are deployed
+}
+
+// Validate checks if the provided parameters are valid for this report
+func (r *LambdaReport) Validate(params reports.ReportParams) error {
+	// Lambda reports don't have specific parameter requirements currently
+	return nil
+}
+
+// generateSummaries creates the function count, EOL and compliance cards
+func (r *LambdaReport) generateSummaries(summary *FunctionsSummary) []reports.Summary {
+	var summaries []reports.Summary
+
+	summaries = append(summaries, r.renderer.CreateSummaryCard(
+		"Lambda Functions",
+		r.renderer.FormatNumber(summary.TotalFunctions),
+		"Total functions",
+		reports.SummaryTypeCount,
+		nil,
+	))
+
+	eolSummary := r.renderer.CreateSummaryCard(
+		"EOL Runtime Functions",
+		r.renderer.FormatNumber(summary.EOLFunctions),
+		"Deprecated runtimes without security patches",
+		reports.SummaryTypeAlert,
+		nil,
+	)
+	if summary.EOLFunctions > 0 {
+		eolSummary.(*reports.BasicSummary).SetHealthy(false)
+	}
+	summaries = append(summaries, eolSummary)
+
+	complianceSummary := r.renderer.CreateSummaryCard(
+		"Runtime Compliance",
+		r.renderer.FormatPercentage(summary.CompliancePercentage, 1),
+		"Functions on supported runtimes",
+		reports.SummaryTypeHealth,
+		nil,
+	)
+	if summary.CompliancePercentage < 90 {
+		complianceSummary.(*reports.BasicSummary).SetHealthy(false)
+	}
+	summaries = append(summaries, complianceSummary)
+
+	return summaries
+}
+
+func (r *LambdaReport) generateDataPoints(summary *FunctionsSummary) []reports.DataPoint {
+	var dataPoints []reports.DataPoint
+	now := time.Now()
+
+	for _, runtimeSummary := range summary.RuntimeSummary {
+		dataPoints = append(dataPoints, reports.DataPoint{
+			Timestamp: now,
+			Labels: map[string]string{
+				"type":    "lambda_runtime",
+				"runtime": runtimeSummary.Runtime,
+			},
+			Values: map[string]interface{}{
+				"count":  runtimeSummary.Count,
+				"is_eol": runtimeSummary.IsEOL,
+			},
+		})
+	}
+
+	return dataPoints
+}
+
+func (r *LambdaReport) generateTables(summary *FunctionsSummary) []reports.TableData {
+	functionsTable := reports.TableData{
+		Title: "Lambda Functions",
+		Headers: []reports.TableHeader{
+			{Key: "function_name", Label: "Function", Type: "string", Sortable: true, Filterable: true},
+			{Key: "application", Label: "Application", Type: "string", Sortable: true, Filterable: true},
+			{Key: "runtime", Label: "Runtime", Type: "string", Sortable: true, Filterable: true},
+			{Key: "is_eol", Label: "EOL", Type: "boolean", Sortable: true, Filterable: true},
+			{Key: "eol_date", Label: "EOL Date", Type: "date", Sortable: true, Filterable: false},
+			{Key: "memory_size_mb", Label: "Memory (MB)", Type: "number", Sortable: true, Filterable: false},
+			{Key: "last_modified", Label: "Last Modified", Type: "date", Sortable: true, Filterable: false},
+		},
+	}
+
+	for _, function := range summary.Functions {
+		runtime := function.Runtime
+		if runtime == "" {
+			runtime = "Container image"
+		}
+		functionsTable.Rows = append(functionsTable.Rows, map[string]interface{}{
+			"function_name":  function.FunctionName,
+			"application":    function.Application,
+			"runtime":        runtime,
+			"is_eol":         function.IsEOL,
+			"eol_date":       function.EOLDate,
+			"memory_size_mb": function.MemorySize,
+			"last_modified":  function.LastModified,
+		})
+	}
+
+	return []reports.TableData{functionsTable}
+}
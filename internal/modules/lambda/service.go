@@ -0,0 +1,318 @@
+package lambda
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"govuk-reports-dashboard/internal/config"
+	awsclient "govuk-reports-dashboard/pkg/aws"
+	"govuk-reports-dashboard/pkg/logger"
+	"govuk-reports-dashboard/pkg/tracing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// TagWorkers is how many functions have their tags fetched at once
+const TagWorkers = 10
+
+// ApproachingEOLWindow is how long before a runtime's deprecation its
+// functions are reported as needing an upgrade
+const ApproachingEOLWindow = 180 * 24 * time.Hour
+
+// lastModifiedLayout is the format of FunctionConfiguration.LastModified
+const lastModifiedLayout = "2006-01-02T15:04:05.000-0700"
+
+type LambdaService struct {
+	client  *lambda.Client
+	config  *config.Config
+	logger  *logger.Logger
+	eolData RuntimeVersions
+}
+
+// NewLambdaService creates a new Lambda service instance using the AWS
+// client's shared Lambda client
+func NewLambdaService(awsClient *awsclient.Client, config *config.Config, logger *logger.Logger) *LambdaService {
+	return &LambdaService{
+		client:  awsClient.NewServiceClient(awsclient.ServiceLambda).(*lambda.Client),
+		config:  config,
+		logger:  logger,
+		eolData: getRuntimeEOLData(),
+	}
+}
+
+// GetAllFunctions lists every Lambda function in the region with its tags
+// and whether its runtime is end-of-life
+func (s *LambdaService) GetAllFunctions(ctx context.Context) (*FunctionsSummary, error) {
+	ctx, span := tracing.Start(ctx, "lambda.get_all_functions")
+	defer span.End()
+
+	log := s.logger.ForContext(ctx)
+	log.Info().Msg("Discovering Lambda functions")
+
+	var configurations []types.FunctionConfiguration
+	paginator := lambda.NewListFunctionsPaginator(s.client, &lambda.ListFunctionsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list Lambda functions: %w", err)
+		}
+		configurations = append(configurations, page.Functions...)
+	}
+
+	functions := make([]LambdaFunction, len(configurations))
+	for i, configuration := range configurations {
+		functions[i] = s.enrichWithRuntimeInfo(convertFunction(configuration))
+	}
+	s.addTags(ctx, functions)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(functions, func(i, j int) bool {
+		return functions[i].FunctionName < functions[j].FunctionName
+	})
+
+	summary := s.generateFunctionsSummary(functions)
+
+	log.WithFields(map[string]interface{}{
+		"total_functions": summary.TotalFunctions,
+		"eol_functions":   summary.EOLFunctions,
+	}).Info().Msg("Lambda function discovery completed")
+
+	return summary, nil
+}
+
+// GetOutdatedFunctions returns the functions on EOL runtimes and those on
+// runtimes deprecated within ApproachingEOLWindow
+func (s *LambdaService) GetOutdatedFunctions(ctx context.Context) (*OutdatedFunctionsResponse, error) {
+	ctx, span := tracing.Start(ctx, "lambda.get_outdated_functions")
+	defer span.End()
+
+	s.logger.ForContext(ctx).Info().Msg("Checking for Lambda functions on outdated runtimes")
+
+	summary, err := s.GetAllFunctions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &OutdatedFunctionsResponse{
+		EOLFunctions:            []LambdaFunction{},
+		ApproachingEOLFunctions: []LambdaFunction{},
+		LastChecked:             time.Now(),
+	}
+	approachingBefore := time.Now().Add(ApproachingEOLWindow)
+	for _, function := range summary.Functions {
+		switch {
+		case function.IsEOL:
+			response.EOLFunctions = append(response.EOLFunctions, function)
+		case function.EOLDate != nil && function.EOLDate.Before(approachingBefore):
+			response.ApproachingEOLFunctions = append(response.ApproachingEOLFunctions, function)
+		}
+	}
+	response.Count = len(response.EOLFunctions) + len(response.ApproachingEOLFunctions)
+
+	return response, nil
+}
+
+// IsAvailable checks the functions can be listed
+func (s *LambdaService) IsAvailable(ctx context.Context) error {
+	_, err := s.client.ListFunctions(ctx, &lambda.ListFunctionsInput{MaxItems: aws.Int32(1)})
+	return err
+}
+
+// convertFunction converts a function configuration from ListFunctions
+func convertFunction(configuration types.FunctionConfiguration) LambdaFunction {
+	function := LambdaFunction{
+		FunctionName: aws.ToString(configuration.FunctionName),
+		FunctionARN:  aws.ToString(configuration.FunctionArn),
+		Runtime:      string(configuration.Runtime),
+		Handler:      aws.ToString(configuration.Handler),
+		PackageType:  string(configuration.PackageType),
+		MemorySize:   aws.ToInt32(configuration.MemorySize),
+		Timeout:      aws.ToInt32(configuration.Timeout),
+		Tags:         make(map[string]string),
+	}
+	if function.PackageType == "" {
+		function.PackageType = string(types.PackageTypeZip)
+	}
+	if lastModified, err := time.Parse(lastModifiedLayout, aws.ToString(configuration.LastModified)); err == nil {
+		function.LastModified = &lastModified
+	}
+	return function
+}
+
+// enrichWithRuntimeInfo adds EOL information for the function's runtime.
+// Runtimes missing from the deprecation data are newer than it, so are
+// treated as supported.
+func (s *LambdaService) enrichWithRuntimeInfo(function LambdaFunction) LambdaFunction {
+	if runtimeInfo, exists := s.eolData.Runtimes[function.Runtime]; exists {
+		function.IsEOL = runtimeInfo.IsEOL
+		function.EOLDate = runtimeInfo.EOLDate
+	}
+	return function
+}
+
+// addTags fetches each function's tags, as ListFunctions doesn't return
+// them. Functions are still returned if their tags can't be fetched.
+func (s *LambdaService) addTags(ctx context.Context, functions []LambdaFunction) {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < min(TagWorkers, len(functions)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				function := &functions[index]
+				result, err := s.client.ListTags(ctx, &lambda.ListTagsInput{Resource: aws.String(function.FunctionARN)})
+				if err != nil {
+					s.logger.ForContext(ctx).WithError(err).WithField("function_name", function.FunctionName).Warn().Msg("Failed to get Lambda function tags")
+					continue
+				}
+				for key, value := range result.Tags {
+					function.Tags[key] = value
+				}
+				function.Application = function.Tags["system"]
+			}
+		}()
+	}
+	for i := range functions {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// generateFunctionsSummary counts functions by runtime and EOL status
+func (s *LambdaService) generateFunctionsSummary(functions []LambdaFunction) *FunctionsSummary {
+	summary := &FunctionsSummary{
+		TotalFunctions:       len(functions),
+		CompliancePercentage: 100,
+		Functions:            functions,
+		LastUpdated:          time.Now(),
+	}
+
+	runtimeCounts := make(map[string]*RuntimeSummaryItem)
+	for _, function := range functions {
+		if function.IsEOL {
+			summary.EOLFunctions += 1
+		}
+
+		runtime := function.Runtime
+		if runtime == "" {
+			runtime = "container image"
+		}
+		if item, exists := runtimeCounts[runtime]; exists {
+			item.Count += 1
+		} else {
+			runtimeCounts[runtime] = &RuntimeSummaryItem{
+				Runtime: runtime,
+				Count:   1,
+				IsEOL:   function.IsEOL,
+				EOLDate: function.EOLDate,
+			}
+		}
+	}
+
+	if summary.TotalFunctions > 0 {
+		summary.CompliancePercentage = float64(summary.TotalFunctions-summary.EOLFunctions) / float64(summary.TotalFunctions) * 100
+	}
+
+	for _, item := range runtimeCounts {
+		summary.RuntimeSummary = append(summary.RuntimeSummary, *item)
+	}
+	sort.Slice(summary.RuntimeSummary, func(i, j int) bool {
+		return summary.RuntimeSummary[i].Runtime < summary.RuntimeSummary[j].Runtime
+	})
+
+	return summary
+}
+
+// getRuntimeEOLData returns Lambda runtime deprecation data. Runtimes without
+// an EOL date have no deprecation scheduled.
+func getRuntimeEOLData() RuntimeVersions {
+	now := time.Now()
+
+	// Lambda runtime deprecation dates, after which runtimes receive no
+	// security patches
+	// Reference: https://docs.aws.amazon.com/lambda/latest/dg/lambda-runtimes.html
+	deprecations := []struct {
+		runtime  string
+		language string
+		eolDate  *time.Time
+	}{
+		{"nodejs", "Node.js", date(2016, 10, 31)},
+		{"nodejs4.3", "Node.js", date(2020, 3, 5)},
+		{"nodejs4.3-edge", "Node.js", date(2019, 4, 30)},
+		{"nodejs6.10", "Node.js", date(2019, 8, 12)},
+		{"nodejs8.10", "Node.js", date(2020, 3, 6)},
+		{"nodejs10.x", "Node.js", date(2021, 7, 30)},
+		{"nodejs12.x", "Node.js", date(2023, 3, 31)},
+		{"nodejs14.x", "Node.js", date(2023, 12, 4)},
+		{"nodejs16.x", "Node.js", date(2024, 6, 12)},
+		{"nodejs18.x", "Node.js", date(2025, 9, 1)},
+		{"nodejs20.x", "Node.js", date(2026, 4, 30)},
+		{"nodejs22.x", "Node.js", date(2027, 4, 30)},
+		{"python2.7", "Python", date(2021, 7, 15)},
+		{"python3.6", "Python", date(2022, 7, 18)},
+		{"python3.7", "Python", date(2023, 12, 4)},
+		{"python3.8", "Python", date(2024, 10, 14)},
+		{"python3.9", "Python", date(2025, 12, 15)},
+		{"python3.10", "Python", date(2026, 6, 30)},
+		{"python3.11", "Python", date(2027, 6, 30)},
+		{"python3.12", "Python", date(2028, 10, 31)},
+		{"python3.13", "Python", date(2029, 6, 30)},
+		{"java8", "Java", date(2024, 1, 8)},
+		{"java8.al2", "Java", date(2026, 6, 30)},
+		{"java11", "Java", date(2026, 6, 30)},
+		{"java17", "Java", date(2026, 6, 30)},
+		{"java21", "Java", date(2029, 6, 30)},
+		{"dotnetcore1.0", ".NET", date(2019, 7, 30)},
+		{"dotnetcore2.0", ".NET", date(2019, 5, 30)},
+		{"dotnetcore2.1", ".NET", date(2022, 1, 5)},
+		{"dotnetcore3.1", ".NET", date(2023, 4, 3)},
+		{"dotnet5.0", ".NET", date(2022, 5, 10)},
+		{"dotnet6", ".NET", date(2024, 12, 20)},
+		{"dotnet7", ".NET", date(2024, 5, 14)},
+		{"dotnet8", ".NET", date(2026, 11, 10)},
+		{"ruby2.5", "Ruby", date(2022, 7, 30)},
+		{"ruby2.7", "Ruby", date(2023, 12, 7)},
+		{"ruby3.2", "Ruby", date(2026, 3, 31)},
+		{"ruby3.3", "Ruby", date(2027, 3, 31)},
+		{"ruby3.4", "Ruby", date(2028, 3, 31)},
+		{"go1.x", "Go", date(2024, 1, 8)},
+		{"provided", "OS-only", date(2024, 1, 8)},
+		{"provided.al2", "OS-only", date(2026, 6, 30)},
+		{"provided.al2023", "OS-only", nil},
+	}
+
+	versions := RuntimeVersions{Runtimes: make(map[string]RuntimeInfo)}
+	for _, deprecation := range deprecations {
+		info := RuntimeInfo{
+			Runtime:     deprecation.runtime,
+			Language:    deprecation.language,
+			IsSupported: true,
+			EOLDate:     deprecation.eolDate,
+		}
+		// Update IsEOL based on current date
+		if info.EOLDate != nil && now.After(*info.EOLDate) {
+			info.IsEOL = true
+			info.IsSupported = false
+			versions.EOL = append(versions.EOL, info.Runtime)
+		}
+		versions.Runtimes[info.Runtime] = info
+	}
+
+	return versions
+}
+
+// date returns a pointer to midnight UTC on the given day
+func date(year int, month time.Month, day int) *time.Time {
+	t := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &t
+}
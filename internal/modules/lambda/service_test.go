@@ -0,0 +1,160 @@
+package lambda
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"govuk-reports-dashboard/internal/config"
+	awsclient "govuk-reports-dashboard/pkg/aws"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+)
+
+// functionPages are the ListFunctions responses, keyed by the marker that
+// requests them
+var functionPages = map[string]string{
+	"": `{"NextMarker": "page-2", "Functions": [
+		{"FunctionName": "publishing-api-worker", "FunctionArn": "arn:aws:lambda:eu-west-2:123456789012:function:publishing-api-worker", "Runtime": "nodejs14.x", "Handler": "index.handler", "MemorySize": 256, "Timeout": 30, "LastModified": "2023-05-10T09:30:00.000+0000", "PackageType": "Zip"},
+		{"FunctionName": "asset-virus-scan", "FunctionArn": "arn:aws:lambda:eu-west-2:123456789012:function:asset-virus-scan", "PackageType": "Image", "MemorySize": 2048, "Timeout": 300}
+	]}`,
+	"page-2": `{"Functions": [
+		{"FunctionName": "search-indexer", "FunctionArn": "arn:aws:lambda:eu-west-2:123456789012:function:search-indexer", "Runtime": "python3.13", "Handler": "app.handler", "MemorySize": 512, "Timeout": 60, "LastModified": "2025-02-01T12:00:00.000+0000", "PackageType": "Zip"}
+	]}`,
+}
+
+func newTestLambdaService(t *testing.T) (*LambdaService, *[]string) {
+	t.Helper()
+
+	var markers []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/2015-03-31/functions"):
+			marker := r.URL.Query().Get("Marker")
+			markers = append(markers, marker)
+			w.Write([]byte(functionPages[marker]))
+		case strings.HasSuffix(r.URL.Path, "function:search-indexer"):
+			w.Write([]byte(`{"Tags": {"system": "search-api"}}`))
+		case strings.HasPrefix(r.URL.Path, "/2017-03-31/tags/"):
+			w.Write([]byte(`{"Tags": {}}`))
+		default:
+			t.Errorf("Unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	awsClient := awsclient.NewClientWithCostExplorer(aws.Config{
+		Region:       "eu-west-2",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}, nil, log)
+
+	return NewLambdaService(awsClient, &config.Config{}, log), &markers
+}
+
+func TestLambdaService_GetAllFunctions(t *testing.T) {
+	service, markers := newTestLambdaService(t)
+
+	summary, err := service.GetAllFunctions(context.Background())
+	if err != nil {
+		t.Fatalf("GetAllFunctions failed: %v", err)
+	}
+
+	// Both pages are fetched, the second using the first's marker
+	if len(*markers) != 2 || (*markers)[1] != "page-2" {
+		t.Errorf("Expected two pages of functions, got markers %q", *markers)
+	}
+	if summary.TotalFunctions != 3 {
+		t.Fatalf("Expected 3 functions, got %d", summary.TotalFunctions)
+	}
+
+	scan, worker, indexer := summary.Functions[0], summary.Functions[1], summary.Functions[2]
+	if scan.FunctionName != "asset-virus-scan" || worker.FunctionName != "publishing-api-worker" || indexer.FunctionName != "search-indexer" {
+		t.Fatalf("Expected functions sorted by name, got %s, %s, %s", scan.FunctionName, worker.FunctionName, indexer.FunctionName)
+	}
+
+	if !worker.IsEOL || worker.EOLDate == nil {
+		t.Errorf("Expected nodejs14.x to be EOL with a date, got %+v", worker)
+	}
+	if worker.MemorySize != 256 || worker.Timeout != 30 || worker.Handler != "index.handler" {
+		t.Errorf("Unexpected function configuration %+v", worker)
+	}
+	if worker.LastModified == nil || !worker.LastModified.Equal(time.Date(2023, 5, 10, 9, 30, 0, 0, time.UTC)) {
+		t.Errorf("Expected last modified 2023-05-10 09:30 UTC, got %v", worker.LastModified)
+	}
+	if scan.IsEOL || scan.PackageType != "Image" {
+		t.Errorf("Expected a container image function not to be EOL, got %+v", scan)
+	}
+	if indexer.IsEOL || indexer.Application != "search-api" {
+		t.Errorf("Expected a supported search-api function, got %+v", indexer)
+	}
+
+	if summary.EOLFunctions != 1 {
+		t.Errorf("Expected 1 EOL function, got %d", summary.EOLFunctions)
+	}
+	if summary.CompliancePercentage < 66.6 || summary.CompliancePercentage > 66.7 {
+		t.Errorf("Expected 2 of 3 functions compliant, got %.2f%%", summary.CompliancePercentage)
+	}
+	if len(summary.RuntimeSummary) != 3 {
+		t.Errorf("Expected 3 runtimes in the summary, got %+v", summary.RuntimeSummary)
+	}
+}
+
+func TestLambdaService_EnrichWithRuntimeInfo(t *testing.T) {
+	service := &LambdaService{eolData: getRuntimeEOLData()}
+
+	tests := []struct {
+		runtime    string
+		isEOL      bool
+		hasEOLDate bool
+	}{
+		{"nodejs14.x", true, true},
+		{"python3.8", true, true},
+		{"go1.x", true, true},
+		{"python3.13", false, true},
+		{"provided.al2023", false, false},
+		// Runtimes newer than the deprecation data are supported
+		{"nodejs99.x", false, false},
+		// Container images have no managed runtime
+		{"", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.runtime, func(t *testing.T) {
+			function := service.enrichWithRuntimeInfo(LambdaFunction{Runtime: tt.runtime})
+			if function.IsEOL != tt.isEOL {
+				t.Errorf("Expected IsEOL %v for %q, got %v", tt.isEOL, tt.runtime, function.IsEOL)
+			}
+			if (function.EOLDate != nil) != tt.hasEOLDate {
+				t.Errorf("Expected an EOL date %v for %q, got %v", tt.hasEOLDate, tt.runtime, function.EOLDate)
+			}
+		})
+	}
+}
+
+func TestGetRuntimeEOLData(t *testing.T) {
+	data := getRuntimeEOLData()
+
+	for _, runtime := range data.EOL {
+		info := data.Runtimes[runtime]
+		if !info.IsEOL || info.IsSupported || info.EOLDate == nil || info.EOLDate.After(time.Now()) {
+			t.Errorf("Expected %s to be EOL with a past date, got %+v", runtime, info)
+		}
+	}
+	for runtime, info := range data.Runtimes {
+		if info.Runtime != runtime || info.Language == "" {
+			t.Errorf("Expected runtime and language for %s, got %+v", runtime, info)
+		}
+		if info.EOLDate != nil && info.EOLDate.After(time.Now()) && info.IsEOL {
+			t.Errorf("Expected %s not to be EOL before %v", runtime, info.EOLDate)
+		}
+	}
+}
@@ -1,9 +1,13 @@
 package rds
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 
+	"govuk-reports-dashboard/internal/handlers"
 	"govuk-reports-dashboard/internal/models"
 	"govuk-reports-dashboard/pkg/logger"
 
@@ -26,11 +30,12 @@ func NewRDSHandler(rdsService *RDSService, logger *logger.Logger) *RDSHandler {
 
 // GetInstances handles GET /api/rds/instances
 func (h *RDSHandler) GetInstances(c *gin.Context) {
-	h.logger.Info().Msg("Handling request for RDS instances")
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for RDS instances")
 
 	summary, err := h.rdsService.GetAllInstances(c.Request.Context())
 	if err != nil {
-		h.logger.WithError(err).Error().Msg("Failed to get RDS instances")
+		log.WithError(err).Error().Msg("Failed to get RDS instances")
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "internal_server_error",
 			Message: "Failed to get RDS instances",
@@ -39,12 +44,13 @@ func (h *RDSHandler) GetInstances(c *gin.Context) {
 		return
 	}
 
-	h.logger.WithField("instance_count", summary.TotalInstances).Info().Msg("Successfully fetched RDS instances")
+	log.WithField("instance_count", summary.TotalInstances).Info().Msg("Successfully fetched RDS instances")
 	c.JSON(http.StatusOK, summary)
 }
 
 // GetInstance handles GET /api/rds/instances/{id}
 func (h *RDSHandler) GetInstance(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
 	instanceID := c.Param("id")
 	if instanceID == "" {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -55,7 +61,7 @@ func (h *RDSHandler) GetInstance(c *gin.Context) {
 		return
 	}
 
-	h.logger.WithField("instance_id", instanceID).Info().Msg("Handling request for specific RDS instance")
+	log.WithField("instance_id", instanceID).Info().Msg("Handling request for specific RDS instance")
 
 	instance, err := h.rdsService.GetInstanceByID(c.Request.Context(), instanceID)
 	if err != nil {
@@ -68,7 +74,7 @@ func (h *RDSHandler) GetInstance(c *gin.Context) {
 			return
 		}
 
-		h.logger.WithError(err).Error().Msg("Failed to get RDS instance")
+		log.WithError(err).Error().Msg("Failed to get RDS instance")
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "internal_server_error",
 			Message: "Failed to get RDS instance",
@@ -77,17 +83,79 @@ func (h *RDSHandler) GetInstance(c *gin.Context) {
 		return
 	}
 
-	h.logger.WithField("instance_id", instanceID).Info().Msg("Successfully fetched RDS instance")
+	log.WithField("instance_id", instanceID).Info().Msg("Successfully fetched RDS instance")
 	c.JSON(http.StatusOK, instance)
 }
 
+// GetSlowQueries handles GET /api/rds/instances/{id}/slow-queries
+func (h *RDSHandler) GetSlowQueries(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	instanceID := c.Param("id")
+	if instanceID == "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "bad_request",
+			Message: "Instance ID is required",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	hours, err := strconv.Atoi(c.DefaultQuery("hours", "24"))
+	if err != nil || hours < 1 || hours > 168 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "bad_request",
+			Message: "hours must be a number between 1 and 168",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	log.WithField("instance_id", instanceID).Info().Msg("Handling request for RDS slow queries")
+
+	report, err := h.rdsService.GetSlowQueryReport(c.Request.Context(), instanceID, hours)
+	if err != nil {
+		if errors.Is(err, ErrPerformanceInsightsDisabled) {
+			c.JSON(http.StatusConflict, models.ErrorResponse{
+				Error:   "performance_insights_disabled",
+				Message: "Performance Insights is not enabled on this instance",
+				Code:    http.StatusConflict,
+			})
+			return
+		}
+
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error:   "not_found",
+				Message: "RDS instance not found",
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+
+		log.WithError(err).Error().Msg("Failed to get RDS slow queries")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get slow queries",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	log.WithFields(map[string]interface{}{
+		"instance_id": instanceID,
+		"query_count": len(report.TopSlowQueries),
+	}).Info().Msg("Successfully fetched RDS slow queries")
+	c.JSON(http.StatusOK, report)
+}
+
 // GetVersions handles GET /api/rds/versions
 func (h *RDSHandler) GetVersions(c *gin.Context) {
-	h.logger.Info().Msg("Handling request for PostgreSQL version information")
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for PostgreSQL version information")
 
 	results, err := h.rdsService.GetVersionCheckResults(c.Request.Context())
 	if err != nil {
-		h.logger.WithError(err).Error().Msg("Failed to get version check results")
+		log.WithError(err).Error().Msg("Failed to get version check results")
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "internal_server_error",
 			Message: "Failed to get version check results",
@@ -101,17 +169,18 @@ func (h *RDSHandler) GetVersions(c *gin.Context) {
 		"count":          len(results),
 	}
 
-	h.logger.WithField("check_count", len(results)).Info().Msg("Successfully fetched version check results")
+	log.WithField("check_count", len(results)).Info().Msg("Successfully fetched version check results")
 	c.JSON(http.StatusOK, response)
 }
 
 // GetOutdated handles GET /api/rds/outdated
 func (h *RDSHandler) GetOutdated(c *gin.Context) {
-	h.logger.Info().Msg("Handling request for outdated RDS instances")
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for outdated RDS instances")
 
 	outdated, err := h.rdsService.GetOutdatedInstances(c.Request.Context())
 	if err != nil {
-		h.logger.WithError(err).Error().Msg("Failed to get outdated instances")
+		log.WithError(err).Error().Msg("Failed to get outdated instances")
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "internal_server_error",
 			Message: "Failed to get outdated instances",
@@ -120,7 +189,7 @@ func (h *RDSHandler) GetOutdated(c *gin.Context) {
 		return
 	}
 
-	h.logger.WithFields(map[string]interface{}{
+	log.WithFields(map[string]interface{}{
 		"outdated_count": len(outdated.OutdatedInstances),
 		"eol_count":      len(outdated.EOLInstances),
 		"total_count":    outdated.Count,
@@ -129,15 +198,255 @@ func (h *RDSHandler) GetOutdated(c *gin.Context) {
 	c.JSON(http.StatusOK, outdated)
 }
 
+// GetSnapshotCosts handles GET /api/rds/snapshot-costs
+func (h *RDSHandler) GetSnapshotCosts(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for RDS snapshot costs")
+
+	report, err := h.rdsService.GetSnapshotCosts(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to get RDS snapshot costs")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get RDS snapshot costs",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	log.WithFields(map[string]interface{}{
+		"total_snapshots":    report.TotalSnapshots,
+		"orphaned_snapshots": len(report.OrphanedSnapshots),
+	}).Info().Msg("Successfully fetched RDS snapshot costs")
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetCrossRegionCompliance handles GET /api/rds/cross-region-compliance
+func (h *RDSHandler) GetCrossRegionCompliance(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for RDS cross-region replication compliance")
+
+	items, err := h.rdsService.GetCrossRegionReplicationReport(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to get RDS cross-region replication compliance")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get RDS cross-region replication compliance",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	log.WithField("checked_count", len(items)).Info().Msg("Successfully checked RDS cross-region replication")
+	c.JSON(http.StatusOK, gin.H{
+		"items": items,
+		"count": len(items),
+	})
+}
+
+// GetTaggingAudit handles GET /api/rds/tagging-audit
+func (h *RDSHandler) GetTaggingAudit(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for RDS tagging audit")
+
+	items, err := h.rdsService.GetTaggingAuditReport(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to get RDS tagging audit")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get RDS tagging audit",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	untagged := 0
+	for _, item := range items {
+		if !item.IsCompliant {
+			untagged++
+		}
+	}
+
+	log.WithField("checked_count", len(items)).Info().Msg("Successfully audited RDS instance tags")
+	c.JSON(http.StatusOK, gin.H{
+		"items":          items,
+		"count":          len(items),
+		"untagged_count": untagged,
+	})
+}
+
+// GetAlarmCompliance handles GET /api/rds/alarm-compliance
+func (h *RDSHandler) GetAlarmCompliance(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for RDS CloudWatch alarm compliance")
+
+	items, err := h.rdsService.GetAlarmComplianceReport(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to get RDS CloudWatch alarm compliance")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get RDS CloudWatch alarm compliance",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	log.WithField("checked_count", len(items)).Info().Msg("Successfully checked RDS CloudWatch alarms")
+	c.JSON(http.StatusOK, gin.H{
+		"items": items,
+		"count": len(items),
+	})
+}
+
+// GetEncryptionCompliance handles GET /api/rds/encryption-compliance
+func (h *RDSHandler) GetEncryptionCompliance(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for RDS encryption compliance")
+
+	items, err := h.rdsService.GetEncryptionComplianceReport(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to get RDS encryption compliance")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get RDS encryption compliance",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	log.WithField("checked_count", len(items)).Info().Msg("Successfully checked RDS encryption compliance")
+	c.JSON(http.StatusOK, gin.H{
+		"items": items,
+		"count": len(items),
+	})
+}
+
+// GetBackupCompliance handles GET /api/rds/compliance
+func (h *RDSHandler) GetBackupCompliance(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for RDS backup compliance")
+
+	instances, err := h.rdsService.GetBackupComplianceReport(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to get RDS backup compliance")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get RDS backup compliance",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	nonCompliant := 0
+	for _, instance := range instances {
+		if !instance.BackupCompliance.IsCompliant {
+			nonCompliant++
+		}
+	}
+
+	log.WithField("checked_count", len(instances)).Info().Msg("Successfully checked RDS backup compliance")
+	c.JSON(http.StatusOK, gin.H{
+		"instances":           instances,
+		"count":               len(instances),
+		"non_compliant_count": nonCompliant,
+	})
+}
+
+// GetAuroraClusters handles GET /api/rds/aurora
+func (h *RDSHandler) GetAuroraClusters(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for Aurora clusters")
+
+	clusters, err := h.rdsService.GetAuroraClusters(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to get Aurora clusters")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get Aurora clusters",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	log.WithField("cluster_count", len(clusters)).Info().Msg("Successfully retrieved Aurora clusters")
+	c.JSON(http.StatusOK, gin.H{
+		"clusters": clusters,
+		"count":    len(clusters),
+	})
+}
+
+// GetConnectionPoolingRecommendations handles GET /api/rds/connection-pooling-recommendations
+func (h *RDSHandler) GetConnectionPoolingRecommendations(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for RDS connection pooling recommendations")
+
+	recommendations, err := h.rdsService.GetConnectionPoolingRecommendations(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to get RDS connection pooling recommendations")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get RDS connection pooling recommendations",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	pgBouncerCount := 0
+	for _, recommendation := range recommendations {
+		if recommendation.RecommendsPgBouncer {
+			pgBouncerCount++
+		}
+	}
+
+	log.WithField("checked_count", len(recommendations)).Info().Msg("Successfully checked RDS connection utilisation")
+	c.JSON(http.StatusOK, gin.H{
+		"recommendations":       recommendations,
+		"count":                 len(recommendations),
+		"pgbouncer_recommended": pgBouncerCount,
+	})
+}
+
+// GetRightSizingRecommendations handles GET /api/rds/recommendations
+func (h *RDSHandler) GetRightSizingRecommendations(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for RDS right-sizing recommendations")
+
+	summary, err := h.rdsService.GetAllInstances(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to get RDS right-sizing recommendations")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get RDS right-sizing recommendations",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	recommendations := h.rdsService.GenerateRightSizingRecommendations(summary.Instances)
+	totalSavings := 0.0
+	for _, recommendation := range recommendations {
+		totalSavings += recommendation.EstimatedMonthlySavingsGBP
+	}
+
+	log.WithField("recommendation_count", len(recommendations)).Info().Msg("Successfully generated RDS right-sizing recommendations")
+	c.JSON(http.StatusOK, gin.H{
+		"recommendations":                     recommendations,
+		"count":                               len(recommendations),
+		"total_estimated_monthly_savings_gbp": math.Round(totalSavings*100) / 100,
+	})
+}
+
 // GetHealth handles GET /api/rds/health - checks if RDS service is available
 func (h *RDSHandler) GetHealth(c *gin.Context) {
-	h.logger.Info().Msg("Handling RDS health check request")
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling RDS health check request")
 
 	// Try to list instances to verify AWS connectivity
 	_, err := h.rdsService.GetAllInstances(c.Request.Context())
 	
 	if err != nil {
-		h.logger.WithError(err).Error().Msg("RDS health check failed")
+		log.WithError(err).Error().Msg("RDS health check failed")
 		c.JSON(http.StatusServiceUnavailable, gin.H{
 			"status":  "unhealthy",
 			"service": "rds",
@@ -146,7 +455,7 @@ func (h *RDSHandler) GetHealth(c *gin.Context) {
 		return
 	}
 
-	h.logger.Info().Msg("RDS health check passed")
+	log.Info().Msg("RDS health check passed")
 	c.JSON(http.StatusOK, gin.H{
 		"status":  "healthy",
 		"service": "rds",
@@ -156,11 +465,12 @@ func (h *RDSHandler) GetHealth(c *gin.Context) {
 
 // GetSummary handles GET /api/rds/summary - returns summary statistics
 func (h *RDSHandler) GetSummary(c *gin.Context) {
-	h.logger.Info().Msg("Handling request for RDS summary")
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for RDS summary")
 
 	summary, err := h.rdsService.GetAllInstances(c.Request.Context())
 	if err != nil {
-		h.logger.WithError(err).Error().Msg("Failed to get RDS summary")
+		log.WithError(err).Error().Msg("Failed to get RDS summary")
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "internal_server_error",
 			Message: "Failed to get RDS summary",
@@ -179,7 +489,7 @@ func (h *RDSHandler) GetSummary(c *gin.Context) {
 		"last_updated":       summary.LastUpdated,
 	}
 
-	h.logger.WithFields(map[string]interface{}{
+	log.WithFields(map[string]interface{}{
 		"total_instances":    summary.TotalInstances,
 		"eol_instances":      summary.EOLInstances,
 		"outdated_instances": summary.OutdatedInstances,
@@ -190,7 +500,8 @@ func (h *RDSHandler) GetSummary(c *gin.Context) {
 
 // GetInstancesPage handles GET /rds - serves the RDS instances table page
 func (h *RDSHandler) GetInstancesPage(c *gin.Context) {
-	h.logger.Info().Msg("Serving RDS instances table page")
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Serving RDS instances table page")
 	
 	c.HTML(http.StatusOK, "rds-instances.html", gin.H{
 		"title": "PostgreSQL Instances - GOV.UK Reports Dashboard",
@@ -199,6 +510,7 @@ func (h *RDSHandler) GetInstancesPage(c *gin.Context) {
 
 // GetInstancePage handles GET /rds/{id} - serves individual instance detail page
 func (h *RDSHandler) GetInstancePage(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
 	instanceID := c.Param("id")
 	if instanceID == "" {
 		c.HTML(http.StatusBadRequest, "error.html", gin.H{
@@ -208,7 +520,7 @@ func (h *RDSHandler) GetInstancePage(c *gin.Context) {
 		return
 	}
 
-	h.logger.WithField("instance_id", instanceID).Info().Msg("Serving RDS instance detail page")
+	log.WithField("instance_id", instanceID).Info().Msg("Serving RDS instance detail page")
 	
 	c.HTML(http.StatusOK, "rds-detail.html", gin.H{
 		"title":       "PostgreSQL Instance - " + instanceID,
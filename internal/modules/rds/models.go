@@ -6,25 +6,76 @@ import (
 
 // PostgreSQLInstance represents a PostgreSQL RDS instance
 type PostgreSQLInstance struct {
-	InstanceID         string    `json:"instance_id"`
-	Name               string    `json:"name"`
-	Version            string    `json:"version"`
-	MajorVersion       string    `json:"major_version"`
-	Status             string    `json:"status"`
-	IsEOL              bool      `json:"is_eol"`
+	InstanceID                 string     `json:"instance_id"`
+	AccountID                  string     `json:"account_id,omitempty"`
+	ARN                        string     `json:"arn"`
+	Name                       string     `json:"name"`
+	Version                    string     `json:"version"`
+	MajorVersion               string     `json:"major_version"`
+	Status                     string     `json:"status"`
+	IsEOL                      bool       `json:"is_eol"`
+	EOLDate                    *time.Time `json:"eol_date,omitempty"`
+	Application                string     `json:"application,omitempty"`
+	Environment                string     `json:"environment,omitempty"`
+	Engine                     string     `json:"engine"`
+	InstanceClass              string     `json:"instance_class"`
+	Endpoint                   string     `json:"endpoint,omitempty"`
+	AllocatedStorage           int32      `json:"allocated_storage"`
+	StorageType                string     `json:"storage_type"`
+	MultiAZ                    bool       `json:"multi_az"`
+	PubliclyAccessible         bool       `json:"publicly_accessible"`
+	PerformanceInsightsEnabled bool       `json:"performance_insights_enabled"`
+	IAMAuthEnabled             bool       `json:"iam_auth_enabled"`
+	StorageEncrypted           bool       `json:"storage_encrypted"`
+	KMSKeyID                   string     `json:"kms_key_id,omitempty"`
+	ClusterID                  string     `json:"cluster_id,omitempty"` // Set for Aurora cluster members
+	Region                     string     `json:"region"`
+	AvailabilityZone           string     `json:"availability_zone"`
+	CreatedAt                  time.Time  `json:"created_at"`
+	LastModified               time.Time  `json:"last_modified"`
+	BackupRetentionDays        int32      `json:"backup_retention_days"`
+	BackupWindow               string     `json:"backup_window,omitempty"`
+	DeletionProtection         bool       `json:"deletion_protection"`
+	AutoMinorVersionUpgrade    bool       `json:"auto_minor_version_upgrade"`
+
+	BackupCompliance BackupComplianceResult `json:"backup_compliance"`
+}
+
+// MinBackupRetentionDays is the shortest automated backup retention period
+// that is compliant
+const MinBackupRetentionDays = 7
+
+// BackupComplianceResult is the outcome of checking an instance's backup
+// and upgrade settings. Severity is that of the most serious issue, or empty
+// if the instance is compliant.
+type BackupComplianceResult struct {
+	IsCompliant bool     `json:"is_compliant"`
+	Issues      []string `json:"issues"`
+	Severity    Severity `json:"severity,omitempty"`
+}
+
+// AuroraCluster is an Aurora PostgreSQL cluster. Its member instances are
+// also listed as PostgreSQLInstances with a ClusterID.
+type AuroraCluster struct {
+	ClusterID          string     `json:"cluster_id"`
+	AccountID          string     `json:"account_id,omitempty"`
+	ARN                string     `json:"arn"`
+	Engine             string     `json:"engine"`
+	Version            string     `json:"version"`
+	MajorVersion       string     `json:"major_version"`
+	Status             string     `json:"status"`
+	IsEOL              bool       `json:"is_eol"`
 	EOLDate            *time.Time `json:"eol_date,omitempty"`
-	Application        string    `json:"application,omitempty"`
-	Environment        string    `json:"environment,omitempty"`
-	Engine             string    `json:"engine"`
-	InstanceClass      string    `json:"instance_class"`
-	AllocatedStorage   int32     `json:"allocated_storage"`
-	StorageType        string    `json:"storage_type"`
-	MultiAZ            bool      `json:"multi_az"`
-	PubliclyAccessible bool      `json:"publicly_accessible"`
-	Region             string    `json:"region"`
-	AvailabilityZone   string    `json:"availability_zone"`
-	CreatedAt          time.Time `json:"created_at"`
-	LastModified       time.Time `json:"last_modified"`
+	Application        string     `json:"application,omitempty"`
+	Environment        string     `json:"environment,omitempty"`
+	MultiAZ            bool       `json:"multi_az"`
+	WriterEndpoint     string     `json:"writer_endpoint,omitempty"`
+	ReaderEndpoint     string     `json:"reader_endpoint,omitempty"`
+	WriterInstanceID   string     `json:"writer_instance_id,omitempty"`
+	MemberInstanceIDs  []string   `json:"member_instance_ids"`
+	DeletionProtection bool       `json:"deletion_protection"`
+	StorageEncrypted   bool       `json:"storage_encrypted"`
+	CreatedAt          time.Time  `json:"created_at"`
 }
 
 // VersionInfo represents PostgreSQL version information
@@ -46,6 +97,8 @@ type InstancesSummary struct {
 	OutdatedInstances int                   `json:"outdated_instances"`
 	Instances         []PostgreSQLInstance  `json:"instances"`
 	VersionSummary    []VersionSummaryItem  `json:"version_summary"`
+	AuroraCount       int                   `json:"aurora_count"`
+	AuroraClusters    []AuroraCluster       `json:"aurora_clusters"`
 	LastUpdated       time.Time             `json:"last_updated"`
 }
 
@@ -67,14 +120,18 @@ type OutdatedInstancesResponse struct {
 
 // VersionCheckResult represents the result of checking instance versions
 type VersionCheckResult struct {
-	InstanceID       string     `json:"instance_id"`
-	CurrentVersion   string     `json:"current_version"`
-	MajorVersion     string     `json:"major_version"`
-	IsEOL            bool       `json:"is_eol"`
-	IsOutdated       bool       `json:"is_outdated"`
-	RecommendedAction string    `json:"recommended_action"`
-	EOLDate          *time.Time `json:"eol_date,omitempty"`
-	LatestInMajor    string     `json:"latest_in_major,omitempty"`
+	InstanceID         string     `json:"instance_id"`
+	CurrentVersion     string     `json:"current_version"`
+	MajorVersion       string     `json:"major_version"`
+	IsEOL              bool       `json:"is_eol"`
+	IsOutdated         bool       `json:"is_outdated"`
+	RecommendedAction  string     `json:"recommended_action"`
+	EOLDate            *time.Time `json:"eol_date,omitempty"`
+	LatestInMajor      string     `json:"latest_in_major,omitempty"`
+	IAMAuthEnabled     bool       `json:"iam_auth_enabled"`
+	IAMAuthRecommended bool       `json:"iam_auth_recommended"`
+
+	BackupCompliance BackupComplianceResult `json:"backup_compliance"`
 }
 
 // PostgreSQLVersions contains EOL and support information for PostgreSQL versions
@@ -126,4 +183,168 @@ type RDSMetrics struct {
 	ReadLatency          float64   `json:"read_latency"`
 	WriteLatency         float64   `json:"write_latency"`
 	Timestamp            time.Time `json:"timestamp"`
-}
\ No newline at end of file
+}
+
+// SlowQueryReport represents the slowest queries for an instance from Performance Insights
+type SlowQueryReport struct {
+	InstanceID               string      `json:"instance_id"`
+	TopSlowQueries           []SlowQuery `json:"top_slow_queries"`
+	AverageActiveConnections float64     `json:"average_active_connections"`
+	PeriodHours              int         `json:"period_hours"`
+	GeneratedAt              time.Time   `json:"generated_at"`
+}
+
+// SlowQuery represents a single SQL statement observed by Performance Insights
+type SlowQuery struct {
+	SQLText      string  `json:"sql_text"`
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+	TotalTimeMs  float64 `json:"total_time_ms"`
+}
+
+// SnapshotCostReport estimates the monthly storage cost of RDS snapshots
+type SnapshotCostReport struct {
+	TotalSnapshots       int                    `json:"total_snapshots"`
+	TotalStorageGB       int32                  `json:"total_storage_gb"`
+	EstimatedMonthlyCost float64                `json:"estimated_monthly_cost"`
+	Currency             string                 `json:"currency"`
+	ByInstance           []InstanceSnapshotStat `json:"by_instance"`
+	OrphanedSnapshots    []SnapshotItem         `json:"orphaned_snapshots"`
+	GeneratedAt          time.Time              `json:"generated_at"`
+}
+
+// InstanceSnapshotStat totals the snapshots taken from one instance or cluster
+type InstanceSnapshotStat struct {
+	SourceID             string  `json:"source_id"`
+	IsCluster            bool    `json:"is_cluster"`
+	SnapshotCount        int     `json:"snapshot_count"`
+	TotalStorageGB       int32   `json:"total_storage_gb"`
+	EstimatedMonthlyCost float64 `json:"estimated_monthly_cost"`
+	SourceExists         bool    `json:"source_exists"`
+}
+
+// SnapshotItem represents a single DB instance or Aurora cluster snapshot
+type SnapshotItem struct {
+	SnapshotID           string     `json:"snapshot_id"`
+	SourceID             string     `json:"source_id"`
+	IsCluster            bool       `json:"is_cluster"`
+	SnapshotType         string     `json:"snapshot_type"`
+	Engine               string     `json:"engine"`
+	AllocatedStorageGB   int32      `json:"allocated_storage_gb"`
+	EstimatedMonthlyCost float64    `json:"estimated_monthly_cost"`
+	CreatedAt            *time.Time `json:"created_at,omitempty"`
+}
+
+// CrossRegionItem is the replication compliance of a production Aurora
+// cluster, or of a production instance, which can only be checked for Multi-AZ
+type CrossRegionItem struct {
+	ClusterID             string   `json:"cluster_id"`
+	Engine                string   `json:"engine"`
+	IsCluster             bool     `json:"is_cluster"`
+	PrimaryRegion         string   `json:"primary_region"`
+	HasCrossRegionReplica bool     `json:"has_cross_region_replica"`
+	ReplicaRegions        []string `json:"replica_regions"`
+	MultiAZ               bool     `json:"multi_az"`
+	IsCompliant           bool     `json:"is_compliant"`
+	Note                  string   `json:"note,omitempty"`
+}
+
+// TaggingAuditItem lists the tags on an instance and any required tags it is
+// missing
+type TaggingAuditItem struct {
+	InstanceID          string            `json:"instance_id"`
+	AccountID           string            `json:"account_id,omitempty"`
+	ARN                 string            `json:"arn"`
+	PresentTags         map[string]string `json:"present_tags"`
+	MissingRequiredTags []string          `json:"missing_required_tags"`
+	IsCompliant         bool              `json:"is_compliant"`
+}
+
+// AlarmComplianceItem lists which of the required CloudWatch alarm metrics a
+// production instance has alarms on
+type AlarmComplianceItem struct {
+	InstanceID       string   `json:"instance_id"`
+	AccountID        string   `json:"account_id,omitempty"`
+	ConfiguredAlarms []string `json:"configured_alarms"`
+	MissingAlarms    []string `json:"missing_alarms"`
+	IsCompliant      bool     `json:"is_compliant"`
+}
+
+// RDSEncryptionItem records whether an instance's storage is encrypted.
+// Production instances must be encrypted to be compliant.
+type RDSEncryptionItem struct {
+	InstanceID       string `json:"instance_id"`
+	AccountID        string `json:"account_id,omitempty"`
+	StorageEncrypted bool   `json:"storage_encrypted"`
+	KMSKeyID         string `json:"kms_key_id,omitempty"`
+	Application      string `json:"application,omitempty"`
+	Environment      string `json:"environment,omitempty"`
+	IsCompliant      bool   `json:"is_compliant"`
+}
+
+// ConnectionPoolingRecommendation compares an instance's peak connections
+// over the last week with the max_connections default for its instance
+// class. Instances using most of their connections should put PgBouncer in
+// front of the database.
+type ConnectionPoolingRecommendation struct {
+	InstanceID              string  `json:"instance_id"`
+	AccountID               string  `json:"account_id,omitempty"`
+	InstanceClass           string  `json:"instance_class"`
+	PeakConnections         int32   `json:"peak_connections"`
+	MaxConnections          int32   `json:"max_connections"`
+	UtilizationPercent      float64 `json:"utilization_percent"`
+	RecommendsPgBouncer     bool    `json:"recommends_pgbouncer"`
+	EstimatedConfigTemplate string  `json:"estimated_config_template,omitempty"`
+}
+
+// RightSizingRecommendation suggests a cheaper instance class for an
+// instance that looks over-provisioned from its class and environment.
+// Utilisation isn't checked, so it should be confirmed against the
+// instance's metrics before resizing.
+type RightSizingRecommendation struct {
+	InstanceID                 string  `json:"instance_id"`
+	AccountID                  string  `json:"account_id,omitempty"`
+	Environment                string  `json:"environment,omitempty"`
+	CurrentClass               string  `json:"current_class"`
+	RecommendedClass           string  `json:"recommended_class"`
+	EstimatedMonthlySavingsGBP float64 `json:"estimated_monthly_savings_gbp"`
+	Reason                     string  `json:"reason"`
+	Confidence                 string  `json:"confidence"` // "medium" or "low"
+}
+
+// Performance Insights GetResourceMetrics request and response shapes
+
+type piGetResourceMetricsInput struct {
+	ServiceType     string          `json:"ServiceType"`
+	Identifier      string          `json:"Identifier"`
+	StartTime       int64           `json:"StartTime"`
+	EndTime         int64           `json:"EndTime"`
+	PeriodInSeconds int32           `json:"PeriodInSeconds"`
+	MetricQueries   []piMetricQuery `json:"MetricQueries"`
+}
+
+type piMetricQuery struct {
+	Metric  string            `json:"Metric"`
+	GroupBy *piDimensionGroup `json:"GroupBy,omitempty"`
+}
+
+type piDimensionGroup struct {
+	Group string `json:"Group"`
+	Limit int32  `json:"Limit,omitempty"`
+}
+
+type piGetResourceMetricsOutput struct {
+	MetricList []piMetricKeyDataPoints `json:"MetricList"`
+}
+
+type piMetricKeyDataPoints struct {
+	Key struct {
+		Metric     string            `json:"Metric"`
+		Dimensions map[string]string `json:"Dimensions"`
+	} `json:"Key"`
+	DataPoints []piDataPoint `json:"DataPoints"`
+}
+
+type piDataPoint struct {
+	Timestamp float64 `json:"Timestamp"`
+	Value     float64 `json:"Value"`
+}
@@ -3,10 +3,13 @@ package rds
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"govuk-reports-dashboard/internal/reports"
 	"govuk-reports-dashboard/pkg/logger"
+	"govuk-reports-dashboard/pkg/notifications"
 )
 
 // RDSReport implements the reports.Report interface for PostgreSQL version checking
@@ -14,6 +17,10 @@ type RDSReport struct {
 	rdsService *RDSService
 	renderer   *reports.Renderer
 	logger     *logger.Logger
+
+	notifier    notifications.Notifier
+	alertMu     sync.Mutex
+	eolAlerting bool
 }
 
 // NewRDSReport creates a new RDS report instance
@@ -25,6 +32,11 @@ func NewRDSReport(rdsService *RDSService, logger *logger.Logger) *RDSReport {
 	}
 }
 
+// SetNotifier enables alerting on end-of-life instances when reports are generated
+func (r *RDSReport) SetNotifier(notifier notifications.Notifier) {
+	r.notifier = notifier
+}
+
 // GetMetadata returns metadata about this report module
 func (r *RDSReport) GetMetadata() reports.ReportMetadata {
 	return reports.ReportMetadata{
@@ -106,6 +118,98 @@ func (r *RDSReport) GenerateSummary(ctx context.Context, params reports.ReportPa
 	}
 	summaries = append(summaries, complianceSummary)
 
+	// Cross-region replication isn't essential to the summary, so a failure
+	// only drops the card
+	replication, err := r.rdsService.GetCrossRegionReplicationReport(ctx)
+	if err != nil {
+		r.logger.WithError(err).Warn().Msg("Failed to check cross-region replication")
+	} else {
+		compliant := 0
+		for _, item := range replication {
+			if item.IsCompliant {
+				compliant++
+			}
+		}
+
+		replicationSummary := r.renderer.CreateSummaryCard(
+			"Cross-Region Replication",
+			fmt.Sprintf("%d/%d", compliant, len(replication)),
+			"Production databases replicated (Multi-AZ for non-Aurora)",
+			reports.SummaryTypeHealth,
+			nil,
+		)
+		if compliant < len(replication) {
+			replicationSummary.(*reports.BasicSummary).SetHealthy(false)
+		}
+		summaries = append(summaries, replicationSummary)
+	}
+
+	tagging, err := r.rdsService.GetTaggingAuditReport(ctx)
+	if err != nil {
+		r.logger.WithError(err).Warn().Msg("Failed to audit RDS instance tags")
+	} else {
+		untagged := 0
+		for _, item := range tagging {
+			if !item.IsCompliant {
+				untagged++
+			}
+		}
+
+		taggingSummary := r.renderer.CreateSummaryCard(
+			"Tagging Audit",
+			r.renderer.FormatNumber(untagged),
+			"Instances missing required tags",
+			reports.SummaryTypeAlert,
+			nil,
+		)
+		if untagged > 0 {
+			taggingSummary.(*reports.BasicSummary).SetHealthy(false)
+		}
+		summaries = append(summaries, taggingSummary)
+	}
+
+	encryption, err := r.rdsService.GetEncryptionComplianceReport(ctx)
+	if err != nil {
+		r.logger.WithError(err).Warn().Msg("Failed to check RDS storage encryption")
+	} else {
+		unencrypted := 0
+		for _, item := range encryption {
+			if !item.IsCompliant {
+				unencrypted++
+			}
+		}
+
+		encryptionSummary := r.renderer.CreateSummaryCard(
+			"Encryption Compliance",
+			r.renderer.FormatNumber(unencrypted),
+			"Unencrypted production instances",
+			reports.SummaryTypeAlert,
+			nil,
+		)
+		if unencrypted > 0 {
+			encryptionSummary.(*reports.BasicSummary).SetHealthy(false)
+		}
+		summaries = append(summaries, encryptionSummary)
+	}
+
+	backupNonCompliant := 0
+	for _, instance := range summary.Instances {
+		if !instance.BackupCompliance.IsCompliant {
+			backupNonCompliant++
+		}
+	}
+	backupSummary := r.renderer.CreateSummaryCard(
+		"Backup Compliance",
+		r.renderer.FormatNumber(backupNonCompliant),
+		"Instances with backup or upgrade issues",
+		reports.SummaryTypeAlert,
+		nil,
+	)
+	if backupNonCompliant > 0 {
+		backupSummary.(*reports.BasicSummary).SetHealthy(false)
+	}
+	summaries = append(summaries, backupSummary)
+
 	r.logger.WithField("summary_count", len(summaries)).Info().Msg("Generated RDS summaries")
 	return summaries, nil
 }
@@ -143,9 +247,37 @@ func (r *RDSReport) GenerateReport(ctx context.Context, params reports.ReportPar
 		})
 	}
 
+	// Raise or clear the EOL alert
+	r.notifyEOL(ctx, summary)
+
 	// Generate data points
 	data.DataPoints = r.generateDataPoints(summary, versionChecks)
 
+	// Snapshot costs are reported alongside instances but aren't essential
+	snapshotCosts, err := r.rdsService.GetSnapshotCosts(ctx)
+	if err != nil {
+		data.Warnings = append(data.Warnings, reports.ReportWarning{
+			Code:      "SNAPSHOT_COSTS_WARNING",
+			Message:   "Failed to calculate snapshot costs",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+		})
+	} else {
+		data.DataPoints = append(data.DataPoints, r.generateSnapshotCostDataPoint(snapshotCosts))
+	}
+
+	poolingRecommendations, err := r.rdsService.GetConnectionPoolingRecommendations(ctx)
+	if err != nil {
+		data.Warnings = append(data.Warnings, reports.ReportWarning{
+			Code:      "CONNECTION_POOLING_WARNING",
+			Message:   "Failed to check connection utilisation",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+		})
+	} else {
+		data.DataPoints = append(data.DataPoints, r.generateConnectionPoolingDataPoint(poolingRecommendations))
+	}
+
 	// Generate summary data
 	data.Summary, err = r.GenerateSummary(ctx, params)
 	if err != nil {
@@ -191,8 +323,90 @@ func (r *RDSReport) Validate(params reports.ReportParams) error {
 	return nil
 }
 
+// EOLInstances lists the end-of-life and outdated instances in a report
+// generated by GenerateReport
+func (r *RDSReport) EOLInstances(data reports.ReportData) []notifications.EOLInstance {
+	var instances []notifications.EOLInstance
+	for _, point := range data.DataPoints {
+		if point.Labels["type"] != "rds_instance" {
+			continue
+		}
+
+		var reason string
+		switch {
+		case point.Values["is_eol"] == true:
+			reason = notifications.EOLReasonEndOfLife
+		case point.Values["is_outdated"] == true:
+			reason = notifications.EOLReasonOutdated
+		default:
+			continue
+		}
+
+		eolDate, _ := point.Values["eol_date"].(*time.Time)
+		instances = append(instances, notifications.EOLInstance{
+			ID:          point.Labels["instance_id"],
+			Report:      r.GetMetadata().ID,
+			Engine:      point.Labels["engine"],
+			Version:     point.Labels["version"],
+			Application: point.Labels["application"],
+			Environment: point.Labels["environment"],
+			Region:      point.Labels["region"],
+			EOLDate:     eolDate,
+			Reason:      reason,
+		})
+	}
+	return instances
+}
+
 // Helper methods
 
+// notifyEOL triggers an incident while any instance is end-of-life, and
+// resolves it once a later run finds none. Failures are logged, not returned.
+func (r *RDSReport) notifyEOL(ctx context.Context, summary *InstancesSummary) {
+	if r.notifier == nil {
+		return
+	}
+
+	r.alertMu.Lock()
+	defer r.alertMu.Unlock()
+
+	dedupKey := "rds-eol-" + r.GetMetadata().ID
+
+	if summary.EOLInstances > 0 {
+		var eolInstances []string
+		for _, instance := range summary.Instances {
+			if instance.IsEOL {
+				eolInstances = append(eolInstances, instance.InstanceID)
+			}
+		}
+
+		err := r.notifier.Trigger(ctx, notifications.Alert{
+			DedupKey: dedupKey,
+			Summary:  fmt.Sprintf("%d PostgreSQL RDS instance(s) running end-of-life versions", summary.EOLInstances),
+			Severity: notifications.SeverityCritical,
+			Source:   "govuk-reports-dashboard",
+			Details: map[string]interface{}{
+				"eol_instances": eolInstances,
+				"eol_count":     summary.EOLInstances,
+			},
+		})
+		if err != nil {
+			r.logger.WithError(err).Error().Msg("Failed to trigger RDS EOL alert")
+			return
+		}
+		r.eolAlerting = true
+		return
+	}
+
+	if r.eolAlerting {
+		if err := r.notifier.Resolve(ctx, dedupKey); err != nil {
+			r.logger.WithError(err).Error().Msg("Failed to resolve RDS EOL alert")
+			return
+		}
+		r.eolAlerting = false
+	}
+}
+
 func (r *RDSReport) generateDataPoints(summary *InstancesSummary, versionChecks []VersionCheckResult) []reports.DataPoint {
 	var dataPoints []reports.DataPoint
 	now := time.Now()
@@ -220,6 +434,7 @@ func (r *RDSReport) generateDataPoints(summary *InstancesSummary, versionChecks
 			Labels: map[string]string{
 				"type":         "rds_instance",
 				"instance_id":  instance.InstanceID,
+				"engine":       instance.Engine,
 				"application":  instance.Application,
 				"environment":  instance.Environment,
 				"region":       instance.Region,
@@ -228,6 +443,7 @@ func (r *RDSReport) generateDataPoints(summary *InstancesSummary, versionChecks
 			},
 			Values: map[string]interface{}{
 				"is_eol":              instance.IsEOL,
+				"eol_date":            instance.EOLDate,
 				"is_outdated":         r.isInstanceOutdated(instance),
 				"instance_class":      instance.InstanceClass,
 				"allocated_storage":   instance.AllocatedStorage,
@@ -258,6 +474,56 @@ func (r *RDSReport) generateDataPoints(summary *InstancesSummary, versionChecks
 	return dataPoints
 }
 
+// generateSnapshotCostDataPoint summarises snapshot storage and its estimated cost
+func (r *RDSReport) generateSnapshotCostDataPoint(snapshotCosts *SnapshotCostReport) reports.DataPoint {
+	return reports.DataPoint{
+		Timestamp: snapshotCosts.GeneratedAt,
+		Labels: map[string]string{
+			"type":   "snapshot_costs",
+			"name":   "Snapshot Costs",
+			"source": "aws_rds",
+		},
+		Values: map[string]interface{}{
+			"total_snapshots":        snapshotCosts.TotalSnapshots,
+			"total_storage_gb":       snapshotCosts.TotalStorageGB,
+			"estimated_monthly_cost": snapshotCosts.EstimatedMonthlyCost,
+			"orphaned_snapshots":     len(snapshotCosts.OrphanedSnapshots),
+			"currency":               snapshotCosts.Currency,
+		},
+	}
+}
+
+func (r *RDSReport) generateConnectionPoolingDataPoint(recommendations []ConnectionPoolingRecommendation) reports.DataPoint {
+	pgBouncerRecommended := 0
+	var instances []string
+	peakUtilization := 0.0
+	for _, recommendation := range recommendations {
+		if recommendation.RecommendsPgBouncer {
+			pgBouncerRecommended++
+			instances = append(instances, recommendation.InstanceID)
+		}
+		peakUtilization = max(peakUtilization, recommendation.UtilizationPercent)
+	}
+
+	return reports.DataPoint{
+		Timestamp: time.Now(),
+		Labels: map[string]string{
+			"type":   "connection_pooling",
+			"name":   "Connection Pooling",
+			"source": "aws_cloudwatch",
+		},
+		Values: map[string]interface{}{
+			"instances_checked":        len(recommendations),
+			"pgbouncer_recommended":    pgBouncerRecommended,
+			"peak_utilization_percent": peakUtilization,
+			"utilization_threshold":    ConnectionPoolingThreshold,
+		},
+		Metadata: map[string]interface{}{
+			"pgbouncer_instances": instances,
+		},
+	}
+}
+
 func (r *RDSReport) generateCharts(summary *InstancesSummary, versionChecks []VersionCheckResult) []reports.ChartData {
 	var charts []reports.ChartData
 
@@ -325,7 +591,10 @@ func (r *RDSReport) generateTables(summary *InstancesSummary, versionChecks []Ve
 			{Key: "status", Label: "Status", Type: "string", Sortable: true, Filterable: true},
 			{Key: "compliance", Label: "Compliance", Type: "string", Sortable: true, Filterable: true},
 			{Key: "instance_class", Label: "Instance Class", Type: "string", Sortable: true, Filterable: true},
+			{Key: "cluster_id", Label: "Aurora Cluster", Type: "string", Sortable: true, Filterable: true},
 			{Key: "region", Label: "Region", Type: "string", Sortable: true, Filterable: true},
+			{Key: "encrypted", Label: "Encrypted", Type: "boolean", Sortable: true, Filterable: true},
+			{Key: "remediation", Label: "Remediation", Type: "string", Sortable: false, Filterable: false},
 		},
 	}
 
@@ -345,13 +614,122 @@ func (r *RDSReport) generateTables(summary *InstancesSummary, versionChecks []Ve
 			"status":         instance.Status,
 			"compliance":     compliance,
 			"instance_class": instance.InstanceClass,
+			"cluster_id":     instance.ClusterID,
 			"region":         instance.Region,
+			"encrypted":      instance.StorageEncrypted,
+			"remediation":    "",
+		}
+		if !instance.IAMAuthEnabled && supportsIAMAuth(instance.Version) {
+			row["remediation"] = r.rdsService.GetIAMRemediation(instance)
 		}
 		instancesTable.Rows = append(instancesTable.Rows, row)
 	}
 
 	tables = append(tables, instancesTable)
 
+	if len(summary.AuroraClusters) > 0 {
+		auroraTable := reports.TableData{
+			Title: "Aurora Clusters",
+			Headers: []reports.TableHeader{
+				{Key: "cluster_id", Label: "Cluster ID", Type: "string", Sortable: true, Filterable: true},
+				{Key: "application", Label: "Application", Type: "string", Sortable: true, Filterable: true},
+				{Key: "environment", Label: "Environment", Type: "string", Sortable: true, Filterable: true},
+				{Key: "version", Label: "Version", Type: "string", Sortable: true, Filterable: true},
+				{Key: "status", Label: "Status", Type: "string", Sortable: true, Filterable: true},
+				{Key: "compliance", Label: "Compliance", Type: "string", Sortable: true, Filterable: true},
+				{Key: "members", Label: "Members", Type: "number", Sortable: true, Filterable: false},
+				{Key: "writer", Label: "Writer Instance", Type: "string", Sortable: true, Filterable: true},
+				{Key: "multi_az", Label: "Multi-AZ", Type: "boolean", Sortable: true, Filterable: true},
+				{Key: "deletion_protection", Label: "Deletion Protection", Type: "boolean", Sortable: true, Filterable: true},
+			},
+		}
+
+		for _, cluster := range summary.AuroraClusters {
+			compliance := "Compliant"
+			if cluster.IsEOL {
+				compliance = "End-of-Life"
+			} else if r.rdsService.isVersionOutdated(cluster.MajorVersion) {
+				compliance = "Outdated"
+			}
+
+			auroraTable.Rows = append(auroraTable.Rows, map[string]interface{}{
+				"cluster_id":          cluster.ClusterID,
+				"application":         cluster.Application,
+				"environment":         cluster.Environment,
+				"version":             cluster.Version,
+				"status":              cluster.Status,
+				"compliance":          compliance,
+				"members":             len(cluster.MemberInstanceIDs),
+				"writer":              cluster.WriterInstanceID,
+				"multi_az":            cluster.MultiAZ,
+				"deletion_protection": cluster.DeletionProtection,
+			})
+		}
+
+		tables = append(tables, auroraTable)
+	}
+
+	backupTable := reports.TableData{
+		Title: "Backup Compliance",
+		Headers: []reports.TableHeader{
+			{Key: "instance_id", Label: "Instance ID", Type: "string", Sortable: true, Filterable: true},
+			{Key: "environment", Label: "Environment", Type: "string", Sortable: true, Filterable: true},
+			{Key: "backup_retention_days", Label: "Backup Retention (days)", Type: "number", Sortable: true, Filterable: false},
+			{Key: "backup_window", Label: "Backup Window", Type: "string", Sortable: false, Filterable: false},
+			{Key: "deletion_protection", Label: "Deletion Protection", Type: "boolean", Sortable: true, Filterable: true},
+			{Key: "auto_minor_version_upgrade", Label: "Auto Minor Upgrades", Type: "boolean", Sortable: true, Filterable: true},
+			{Key: "severity", Label: "Severity", Type: "string", Sortable: true, Filterable: true},
+			{Key: "issues", Label: "Issues", Type: "string", Sortable: false, Filterable: false},
+		},
+	}
+
+	for _, instance := range summary.Instances {
+		severity := "compliant"
+		if !instance.BackupCompliance.IsCompliant {
+			severity = string(instance.BackupCompliance.Severity)
+		}
+
+		backupTable.Rows = append(backupTable.Rows, map[string]interface{}{
+			"instance_id":                instance.InstanceID,
+			"environment":                instance.Environment,
+			"backup_retention_days":      instance.BackupRetentionDays,
+			"backup_window":              instance.BackupWindow,
+			"deletion_protection":        instance.DeletionProtection,
+			"auto_minor_version_upgrade": instance.AutoMinorVersionUpgrade,
+			"severity":                   severity,
+			"issues":                     strings.Join(instance.BackupCompliance.Issues, "; "),
+		})
+	}
+
+	tables = append(tables, backupTable)
+
+	rightSizingTable := reports.TableData{
+		Title: "Right-Sizing Recommendations",
+		Headers: []reports.TableHeader{
+			{Key: "instance_id", Label: "Instance ID", Type: "string", Sortable: true, Filterable: true},
+			{Key: "environment", Label: "Environment", Type: "string", Sortable: true, Filterable: true},
+			{Key: "current_class", Label: "Current Class", Type: "string", Sortable: true, Filterable: true},
+			{Key: "recommended_class", Label: "Recommended Class", Type: "string", Sortable: true, Filterable: true},
+			{Key: "estimated_monthly_savings", Label: "Estimated Monthly Savings", Type: "currency", Sortable: true, Filterable: false},
+			{Key: "confidence", Label: "Confidence", Type: "string", Sortable: true, Filterable: true},
+			{Key: "reason", Label: "Reason", Type: "string", Sortable: false, Filterable: false},
+		},
+	}
+
+	for _, recommendation := range r.rdsService.GenerateRightSizingRecommendations(summary.Instances) {
+		rightSizingTable.Rows = append(rightSizingTable.Rows, map[string]interface{}{
+			"instance_id":               recommendation.InstanceID,
+			"environment":               recommendation.Environment,
+			"current_class":             recommendation.CurrentClass,
+			"recommended_class":         recommendation.RecommendedClass,
+			"estimated_monthly_savings": recommendation.EstimatedMonthlySavingsGBP,
+			"confidence":                recommendation.Confidence,
+			"reason":                    recommendation.Reason,
+		})
+	}
+
+	tables = append(tables, rightSizingTable)
+
 	// Version summary table
 	versionTable := reports.TableData{
 		Title: "Version Summary",
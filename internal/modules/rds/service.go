@@ -2,57 +2,200 @@ package rds
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
 	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"govuk-reports-dashboard/internal/config"
+	awsclient "govuk-reports-dashboard/pkg/aws"
 	"govuk-reports-dashboard/pkg/logger"
+	"govuk-reports-dashboard/pkg/tracing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
 	"github.com/aws/aws-sdk-go-v2/service/rds/types"
 )
 
+// SnapshotCostPerGBMonth is the estimated snapshot storage price in GBP per
+// GB-month for eu-west-2
+const SnapshotCostPerGBMonth = 0.019
+
+// RequiredAlarmMetrics are the metrics every production instance should have
+// a CloudWatch alarm on
+var RequiredAlarmMetrics = []string{"CPUUtilization", "FreeStorageSpace", "DatabaseConnections"}
+
+const (
+	// ConnectionPoolingThreshold is the percentage of max_connections in use
+	// at peak above which PgBouncer is recommended
+	ConnectionPoolingThreshold = 70.0
+
+	ConnectionMetricsLookback = 7 * 24 * time.Hour
+	ConnectionMetricsPeriod   = time.Hour
+)
+
+// instanceClassMemoryGiB is the memory of the large size of each instance
+// family. Other sizes scale from it by instanceSizeMultipliers.
+var instanceClassMemoryGiB = map[string]float64{
+	"db.t3":  8,
+	"db.t4g": 8,
+	"db.m5":  8,
+	"db.m6g": 8,
+	"db.m6i": 8,
+	"db.m7g": 8,
+	"db.r5":  16,
+	"db.r6g": 16,
+	"db.r6i": 16,
+	"db.r7g": 16,
+}
+
+// Right-sizing thresholds. Non-production instances larger than
+// RightSizingMaxNonProductionClass are flagged as over-provisioned, and
+// single-AZ instances in burstableCandidateFamilies as candidates for
+// BurstableInstanceFamily.
+const (
+	RightSizingMaxNonProductionClass = "db.r5.2xlarge"
+	BurstableInstanceFamily          = "db.t4g"
+
+	// burstableMaxSize is the largest size of BurstableInstanceFamily
+	burstableMaxSize = "2xlarge"
+)
+
+var burstableCandidateFamilies = []string{"db.r5", "db.r6g"}
+
+var instanceSizeMultipliers = map[string]float64{
+	"micro":    0.125,
+	"small":    0.25,
+	"medium":   0.5,
+	"large":    1,
+	"xlarge":   2,
+	"2xlarge":  4,
+	"4xlarge":  8,
+	"8xlarge":  16,
+	"12xlarge": 24,
+	"16xlarge": 32,
+	"24xlarge": 48,
+}
+
+// ErrPerformanceInsightsDisabled is returned when slow query data is requested
+// for an instance that does not have Performance Insights enabled
+var ErrPerformanceInsightsDisabled = errors.New("performance insights is not enabled")
+
 // RDSService handles PostgreSQL instance discovery and version checking
 type RDSService struct {
-	client  *rds.Client
-	config  *config.Config
-	logger  *logger.Logger
-	eolData PostgreSQLVersions
+	client    *rds.Client
+	piClient  *awsclient.JSONAPIClient
+	awsClient *awsclient.Client
+	accounts  []rdsAccount
+	config    *config.Config
+	logger    *logger.Logger
+	eolData   PostgreSQLVersions
 }
 
-// NewRDSService creates a new RDS service instance
-func NewRDSService(awsConfig aws.Config, cfg *config.Config, log *logger.Logger) *RDSService {
-	client := rds.NewFromConfig(awsConfig)
-	
-	service := &RDSService{
-		client: client,
-		config: cfg,
-		logger: log,
-		eolData: getPostgreSQLVersionData(),
+// rdsAccount is an AWS account instances are discovered in
+type rdsAccount struct {
+	id        string
+	client    *rds.Client
+	awsClient *awsclient.Client
+}
+
+// NewRDSService creates a new RDS service instance using the AWS client's
+// shared RDS client
+func NewRDSService(awsClient *awsclient.Client, cfg *config.Config, log *logger.Logger) *RDSService {
+	return NewMultiAccountRDSService([]*awsclient.Client{awsClient}, cfg, log)
+}
+
+// NewMultiAccountRDSService creates an RDS service that discovers instances
+// in the account of each client, such as those from
+// awsclient.NewMultiAccountClient. Lookups of a single instance by ID,
+// snapshots and Performance Insights use the first client's account.
+func NewMultiAccountRDSService(awsClients []*awsclient.Client, cfg *config.Config, log *logger.Logger) *RDSService {
+	accounts := make([]rdsAccount, len(awsClients))
+	for i, awsClient := range awsClients {
+		accounts[i] = rdsAccount{
+			id:        awsClient.AccountID(),
+			client:    awsClient.NewServiceClient(awsclient.ServiceRDS).(*rds.Client),
+			awsClient: awsClient,
+		}
 	}
-	
-	return service
+
+	return &RDSService{
+		client:    accounts[0].client,
+		piClient:  awsclient.NewJSONAPIClient(awsClients[0].GetConfig(), "pi", "PerformanceInsightsv20180227"),
+		awsClient: awsClients[0],
+		accounts:  accounts,
+		config:    cfg,
+		logger:    log,
+		eolData:   getPostgreSQLVersionData(),
+	}
+}
+
+// account returns the account with the given ID, or the first account if
+// there is none, as for instances discovered without account IDs
+func (s *RDSService) account(accountID string) rdsAccount {
+	for _, account := range s.accounts {
+		if account.id == accountID {
+			return account
+		}
+	}
+	return s.accounts[0]
 }
 
-// GetAllInstances discovers all PostgreSQL RDS instances
+// GetAllInstances discovers all PostgreSQL RDS instances and Aurora
+// PostgreSQL clusters in every account. Aurora cluster members are included
+// in the instances, with their ClusterID set.
 func (s *RDSService) GetAllInstances(ctx context.Context) (*InstancesSummary, error) {
-	s.logger.Info().Msg("Discovering PostgreSQL RDS instances")
+	ctx, span := tracing.Start(ctx, "rds.get_all_instances")
+	defer span.End()
+
+	log := s.logger.ForContext(ctx)
+	log.Info().Msg("Discovering PostgreSQL RDS instances")
 
-	// Get all DB instances
-	input := &rds.DescribeDBInstancesInput{}
-	
 	var allInstances []PostgreSQLInstance
-	paginator := rds.NewDescribeDBInstancesPaginator(s.client, input)
+	var auroraClusters []AuroraCluster
+	for _, account := range s.accounts {
+		instances, clusters, err := s.discoverAccount(ctx, account)
+		if err != nil {
+			return nil, err
+		}
+		allInstances = append(allInstances, instances...)
+		auroraClusters = append(auroraClusters, clusters...)
+	}
+
+	// Generate summary
+	summary := s.generateInstancesSummary(allInstances, auroraClusters)
 	
+	log.WithFields(map[string]interface{}{
+		"total_instances":    summary.TotalInstances,
+		"postgresql_count":   summary.PostgreSQLCount,
+		"eol_instances":      summary.EOLInstances,
+		"outdated_instances": summary.OutdatedInstances,
+		"aurora_clusters":    summary.AuroraCount,
+	}).Info().Msg("PostgreSQL instances discovered")
+
+	return summary, nil
+}
+
+// discoverAccount returns the PostgreSQL instances and Aurora PostgreSQL
+// clusters in an account, tagged with its ID
+func (s *RDSService) discoverAccount(ctx context.Context, account rdsAccount) ([]PostgreSQLInstance, []AuroraCluster, error) {
+	log := s.logger
+	if account.id != "" {
+		log = log.WithField("account_id", account.id)
+	}
+
+	var instances []PostgreSQLInstance
+	paginator := rds.NewDescribeDBInstancesPaginator(account.client, &rds.DescribeDBInstancesInput{})
 	for paginator.HasMorePages() {
 		page, err := paginator.NextPage(ctx)
 		if err != nil {
-			s.logger.WithError(err).Error().Msg("Failed to describe RDS instances")
-			return nil, fmt.Errorf("failed to describe RDS instances: %w", err)
+			log.WithError(err).Error().Msg("Failed to describe RDS instances")
+			return nil, nil, fmt.Errorf("failed to describe RDS instances%s: %w", inAccount(account.id), err)
 		}
 
 		// Filter and process PostgreSQL instances
@@ -60,27 +203,61 @@ func (s *RDSService) GetAllInstances(ctx context.Context) (*InstancesSummary, er
 			if s.isPostgreSQL(dbInstance) {
 				instance := s.convertToPostgreSQLInstance(dbInstance)
 				instance = s.enrichWithVersionInfo(instance)
-				allInstances = append(allInstances, instance)
+				instance.BackupCompliance = s.CheckBackupCompliance(instance)
+				instance.AccountID = account.id
+				instances = append(instances, instance)
 			}
 		}
 	}
 
-	// Generate summary
-	summary := s.generateInstancesSummary(allInstances)
-	
-	s.logger.WithFields(map[string]interface{}{
-		"total_instances":    summary.TotalInstances,
-		"postgresql_count":   summary.PostgreSQLCount,
-		"eol_instances":      summary.EOLInstances,
-		"outdated_instances": summary.OutdatedInstances,
-	}).Info().Msg("PostgreSQL instances discovered")
+	var clusters []AuroraCluster
+	clusterPaginator := rds.NewDescribeDBClustersPaginator(account.client, &rds.DescribeDBClustersInput{})
+	for clusterPaginator.HasMorePages() {
+		page, err := clusterPaginator.NextPage(ctx)
+		if err != nil {
+			log.WithError(err).Error().Msg("Failed to describe RDS clusters")
+			return nil, nil, fmt.Errorf("failed to describe RDS clusters%s: %w", inAccount(account.id), err)
+		}
 
-	return summary, nil
+		for _, dbCluster := range page.DBClusters {
+			if isAuroraPostgreSQL(aws.ToString(dbCluster.Engine)) {
+				cluster := s.convertToAuroraCluster(dbCluster)
+				cluster.AccountID = account.id
+				clusters = append(clusters, cluster)
+			}
+		}
+	}
+
+	return instances, clusters, nil
+}
+
+// inAccount describes an account in error messages, or returns "" for an
+// account without an ID
+func inAccount(accountID string) string {
+	if accountID == "" {
+		return ""
+	}
+	return " in account " + accountID
+}
+
+// GetAuroraClusters returns the Aurora PostgreSQL clusters
+func (s *RDSService) GetAuroraClusters(ctx context.Context) ([]AuroraCluster, error) {
+	ctx, span := tracing.Start(ctx, "rds.get_aurora_clusters")
+	defer span.End()
+
+	summary, err := s.GetAllInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return summary.AuroraClusters, nil
 }
 
 // GetOutdatedInstances returns instances that need version updates
 func (s *RDSService) GetOutdatedInstances(ctx context.Context) (*OutdatedInstancesResponse, error) {
-	s.logger.Info().Msg("Checking for outdated PostgreSQL instances")
+	ctx, span := tracing.Start(ctx, "rds.get_outdated_instances")
+	defer span.End()
+
+	s.logger.ForContext(ctx).Info().Msg("Checking for outdated PostgreSQL instances")
 
 	summary, err := s.GetAllInstances(ctx)
 	if err != nil {
@@ -110,6 +287,9 @@ func (s *RDSService) GetOutdatedInstances(ctx context.Context) (*OutdatedInstanc
 
 // GetVersionCheckResults performs version checking for all instances
 func (s *RDSService) GetVersionCheckResults(ctx context.Context) ([]VersionCheckResult, error) {
+	ctx, span := tracing.Start(ctx, "rds.get_version_check_results")
+	defer span.End()
+
 	summary, err := s.GetAllInstances(ctx)
 	if err != nil {
 		return nil, err
@@ -126,7 +306,11 @@ func (s *RDSService) GetVersionCheckResults(ctx context.Context) ([]VersionCheck
 
 // GetInstanceByID retrieves a specific PostgreSQL instance
 func (s *RDSService) GetInstanceByID(ctx context.Context, instanceID string) (*PostgreSQLInstance, error) {
-	s.logger.WithField("instance_id", instanceID).Info().Msg("Getting PostgreSQL instance details")
+	ctx, span := tracing.Start(ctx, "rds.get_instance_by_i_d")
+	defer span.End()
+
+	log := s.logger.ForContext(ctx)
+	log.WithField("instance_id", instanceID).Info().Msg("Getting PostgreSQL instance details")
 
 	input := &rds.DescribeDBInstancesInput{
 		DBInstanceIdentifier: aws.String(instanceID),
@@ -134,7 +318,7 @@ func (s *RDSService) GetInstanceByID(ctx context.Context, instanceID string) (*P
 
 	result, err := s.client.DescribeDBInstances(ctx, input)
 	if err != nil {
-		s.logger.WithError(err).Error().Msg("Failed to describe RDS instance")
+		log.WithError(err).Error().Msg("Failed to describe RDS instance")
 		return nil, fmt.Errorf("failed to describe RDS instance: %w", err)
 	}
 
@@ -149,24 +333,807 @@ func (s *RDSService) GetInstanceByID(ctx context.Context, instanceID string) (*P
 
 	instance := s.convertToPostgreSQLInstance(dbInstance)
 	instance = s.enrichWithVersionInfo(instance)
+	instance.BackupCompliance = s.CheckBackupCompliance(instance)
 
 	return &instance, nil
 }
 
+// GetSlowQueryReport returns the top SQL statements by load for an instance over
+// the last given number of hours, using Performance Insights
+func (s *RDSService) GetSlowQueryReport(ctx context.Context, instanceID string, hours int) (*SlowQueryReport, error) {
+	ctx, span := tracing.Start(ctx, "rds.get_slow_query_report")
+	defer span.End()
+
+	log := s.logger.ForContext(ctx)
+	log.WithFields(map[string]interface{}{
+		"instance_id": instanceID,
+		"hours":       hours,
+	}).Info().Msg("Fetching slow queries from Performance Insights")
+
+	result, err := s.client.DescribeDBInstances(ctx, &rds.DescribeDBInstancesInput{
+		DBInstanceIdentifier: aws.String(instanceID),
+	})
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to describe RDS instance")
+		return nil, fmt.Errorf("failed to describe RDS instance: %w", err)
+	}
+
+	if len(result.DBInstances) == 0 {
+		return nil, fmt.Errorf("instance not found: %s", instanceID)
+	}
+
+	dbInstance := result.DBInstances[0]
+	if !aws.ToBool(dbInstance.PerformanceInsightsEnabled) {
+		return nil, fmt.Errorf("%w on instance %s", ErrPerformanceInsightsDisabled, instanceID)
+	}
+
+	endTime := time.Now()
+	startTime := endTime.Add(-time.Duration(hours) * time.Hour)
+	period := piPeriodForHours(hours)
+
+	input := piGetResourceMetricsInput{
+		ServiceType:     "RDS",
+		Identifier:      aws.ToString(dbInstance.DbiResourceId),
+		StartTime:       startTime.Unix(),
+		EndTime:         endTime.Unix(),
+		PeriodInSeconds: period,
+		MetricQueries: []piMetricQuery{
+			{Metric: "db.load.avg"},
+			{Metric: "db.load.avg", GroupBy: &piDimensionGroup{Group: "db.sql_tokenized", Limit: 10}},
+			{Metric: "db.sql.avg_latency_ioread", GroupBy: &piDimensionGroup{Group: "db.sql_tokenized", Limit: 10}},
+		},
+	}
+
+	var output piGetResourceMetricsOutput
+	if err := s.piClient.Call(ctx, "GetResourceMetrics", input, &output); err != nil {
+		log.WithError(err).Error().Msg("Failed to get Performance Insights metrics")
+		return nil, fmt.Errorf("failed to get performance insights metrics: %w", err)
+	}
+
+	report := &SlowQueryReport{
+		InstanceID:  instanceID,
+		PeriodHours: hours,
+		GeneratedAt: time.Now(),
+	}
+
+	queries := make(map[string]*SlowQuery)
+	var order []string
+	for _, metric := range output.MetricList {
+		statement := metric.Key.Dimensions["db.sql_tokenized.statement"]
+
+		if statement == "" {
+			if metric.Key.Metric == "db.load.avg" {
+				report.AverageActiveConnections = averageDataPoints(metric.DataPoints)
+			}
+			continue
+		}
+
+		query, exists := queries[statement]
+		if !exists {
+			query = &SlowQuery{SQLText: statement}
+			queries[statement] = query
+			order = append(order, statement)
+		}
+
+		switch metric.Key.Metric {
+		case "db.load.avg":
+			// Load is measured in average active sessions, so load multiplied
+			// by the period length gives the time spent in the statement
+			for _, point := range metric.DataPoints {
+				query.TotalTimeMs += point.Value * float64(period) * 1000
+			}
+		case "db.sql.avg_latency_ioread":
+			query.AvgLatencyMs = averageDataPoints(metric.DataPoints)
+		}
+	}
+
+	for _, statement := range order {
+		report.TopSlowQueries = append(report.TopSlowQueries, *queries[statement])
+	}
+
+	sort.Slice(report.TopSlowQueries, func(i, j int) bool {
+		return report.TopSlowQueries[i].TotalTimeMs > report.TopSlowQueries[j].TotalTimeMs
+	})
+
+	log.WithFields(map[string]interface{}{
+		"instance_id":  instanceID,
+		"query_count":  len(report.TopSlowQueries),
+		"average_load": report.AverageActiveConnections,
+	}).Info().Msg("Slow query report generated")
+
+	return report, nil
+}
+
+// GetSnapshotCosts estimates the storage cost of manual DB instance snapshots
+// and all Aurora cluster snapshots, grouped by source. Automated instance
+// snapshots are left out, as their storage is included up to the size of the
+// instance. Snapshots whose source instance or cluster no longer exists are
+// listed as orphaned.
+func (s *RDSService) GetSnapshotCosts(ctx context.Context) (*SnapshotCostReport, error) {
+	ctx, span := tracing.Start(ctx, "rds.get_snapshot_costs")
+	defer span.End()
+
+	log := s.logger.ForContext(ctx)
+	log.Info().Msg("Calculating RDS snapshot costs")
+
+	var snapshots []SnapshotItem
+
+	snapshotPaginator := rds.NewDescribeDBSnapshotsPaginator(s.client, &rds.DescribeDBSnapshotsInput{
+		SnapshotType: aws.String("manual"),
+	})
+	for snapshotPaginator.HasMorePages() {
+		page, err := snapshotPaginator.NextPage(ctx)
+		if err != nil {
+			log.WithError(err).Error().Msg("Failed to describe RDS snapshots")
+			return nil, fmt.Errorf("failed to describe RDS snapshots: %w", err)
+		}
+
+		for _, snapshot := range page.DBSnapshots {
+			snapshots = append(snapshots, SnapshotItem{
+				SnapshotID:         aws.ToString(snapshot.DBSnapshotIdentifier),
+				SourceID:           aws.ToString(snapshot.DBInstanceIdentifier),
+				SnapshotType:       aws.ToString(snapshot.SnapshotType),
+				Engine:             aws.ToString(snapshot.Engine),
+				AllocatedStorageGB: aws.ToInt32(snapshot.AllocatedStorage),
+				CreatedAt:          snapshot.SnapshotCreateTime,
+			})
+		}
+	}
+
+	clusterSnapshotPaginator := rds.NewDescribeDBClusterSnapshotsPaginator(s.client, &rds.DescribeDBClusterSnapshotsInput{})
+	for clusterSnapshotPaginator.HasMorePages() {
+		page, err := clusterSnapshotPaginator.NextPage(ctx)
+		if err != nil {
+			log.WithError(err).Error().Msg("Failed to describe RDS cluster snapshots")
+			return nil, fmt.Errorf("failed to describe RDS cluster snapshots: %w", err)
+		}
+
+		for _, snapshot := range page.DBClusterSnapshots {
+			snapshots = append(snapshots, SnapshotItem{
+				SnapshotID:         aws.ToString(snapshot.DBClusterSnapshotIdentifier),
+				SourceID:           aws.ToString(snapshot.DBClusterIdentifier),
+				IsCluster:          true,
+				SnapshotType:       aws.ToString(snapshot.SnapshotType),
+				Engine:             aws.ToString(snapshot.Engine),
+				AllocatedStorageGB: aws.ToInt32(snapshot.AllocatedStorage),
+				CreatedAt:          snapshot.SnapshotCreateTime,
+			})
+		}
+	}
+
+	existingSources, err := s.getSnapshotSources(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SnapshotCostReport{
+		TotalSnapshots: len(snapshots),
+		Currency:       "GBP",
+		GeneratedAt:    time.Now(),
+	}
+
+	statsBySource := make(map[string]*InstanceSnapshotStat)
+	for _, snapshot := range snapshots {
+		snapshot.EstimatedMonthlyCost = float64(snapshot.AllocatedStorageGB) * SnapshotCostPerGBMonth
+		report.TotalStorageGB += snapshot.AllocatedStorageGB
+		report.EstimatedMonthlyCost += snapshot.EstimatedMonthlyCost
+
+		sourceKey := snapshotSourceKey(snapshot.SourceID, snapshot.IsCluster)
+		stat, ok := statsBySource[sourceKey]
+		if !ok {
+			stat = &InstanceSnapshotStat{
+				SourceID:     snapshot.SourceID,
+				IsCluster:    snapshot.IsCluster,
+				SourceExists: existingSources[sourceKey],
+			}
+			statsBySource[sourceKey] = stat
+		}
+		stat.SnapshotCount++
+		stat.TotalStorageGB += snapshot.AllocatedStorageGB
+		stat.EstimatedMonthlyCost += snapshot.EstimatedMonthlyCost
+
+		if !stat.SourceExists {
+			report.OrphanedSnapshots = append(report.OrphanedSnapshots, snapshot)
+		}
+	}
+
+	for _, stat := range statsBySource {
+		report.ByInstance = append(report.ByInstance, *stat)
+	}
+	sort.Slice(report.ByInstance, func(i, j int) bool {
+		return report.ByInstance[i].EstimatedMonthlyCost > report.ByInstance[j].EstimatedMonthlyCost
+	})
+	sort.Slice(report.OrphanedSnapshots, func(i, j int) bool {
+		return report.OrphanedSnapshots[i].EstimatedMonthlyCost > report.OrphanedSnapshots[j].EstimatedMonthlyCost
+	})
+
+	log.WithFields(map[string]interface{}{
+		"total_snapshots":    report.TotalSnapshots,
+		"total_storage_gb":   report.TotalStorageGB,
+		"orphaned_snapshots": len(report.OrphanedSnapshots),
+		"estimated_cost":     report.EstimatedMonthlyCost,
+	}).Info().Msg("RDS snapshot costs calculated")
+
+	return report, nil
+}
+
+// GetCrossRegionReplicationReport checks whether production Aurora clusters
+// have a replica in another AWS region, either as a cross-region read replica
+// or as a member of a global database. Cross-region replication requires
+// Aurora, so production PostgreSQL instances are checked for Multi-AZ
+// instead as a proxy for basic high availability.
+func (s *RDSService) GetCrossRegionReplicationReport(ctx context.Context) ([]CrossRegionItem, error) {
+	ctx, span := tracing.Start(ctx, "rds.get_cross_region_replication_report")
+	defer span.End()
+
+	log := s.logger.ForContext(ctx)
+	log.Info().Msg("Checking RDS cross-region replication")
+
+	region := s.client.Options().Region
+	var items []CrossRegionItem
+
+	globalRegions := make(map[string][]string)
+	globalPaginator := rds.NewDescribeGlobalClustersPaginator(s.client, &rds.DescribeGlobalClustersInput{})
+	for globalPaginator.HasMorePages() {
+		page, err := globalPaginator.NextPage(ctx)
+		if err != nil {
+			log.WithError(err).Error().Msg("Failed to describe RDS global clusters")
+			return nil, fmt.Errorf("failed to describe RDS global clusters: %w", err)
+		}
+		for _, global := range page.GlobalClusters {
+			var regions []string
+			for _, member := range global.GlobalClusterMembers {
+				regions = append(regions, regionFromARN(aws.ToString(member.DBClusterArn)))
+			}
+			globalRegions[aws.ToString(global.GlobalClusterIdentifier)] = regions
+		}
+	}
+
+	clusterPaginator := rds.NewDescribeDBClustersPaginator(s.client, &rds.DescribeDBClustersInput{})
+	for clusterPaginator.HasMorePages() {
+		page, err := clusterPaginator.NextPage(ctx)
+		if err != nil {
+			log.WithError(err).Error().Msg("Failed to describe RDS clusters")
+			return nil, fmt.Errorf("failed to describe RDS clusters: %w", err)
+		}
+
+		for _, cluster := range page.DBClusters {
+			if !strings.HasPrefix(aws.ToString(cluster.Engine), "aurora") {
+				continue
+			}
+
+			clusterID := aws.ToString(cluster.DBClusterIdentifier)
+			_, environment := s.extractApplicationInfo(clusterID)
+			for _, tag := range cluster.TagList {
+				if aws.ToString(tag.Key) == "environment" {
+					environment = aws.ToString(tag.Value)
+				}
+			}
+			if environment != "production" {
+				continue
+			}
+
+			replicaRegions := make(map[string]bool)
+			for _, replicaARN := range cluster.ReadReplicaIdentifiers {
+				replicaRegions[regionFromARN(replicaARN)] = true
+			}
+			if globalID := aws.ToString(cluster.GlobalClusterIdentifier); globalID != "" {
+				for _, memberRegion := range globalRegions[globalID] {
+					replicaRegions[memberRegion] = true
+				}
+			}
+
+			item := CrossRegionItem{
+				ClusterID:     clusterID,
+				Engine:        aws.ToString(cluster.Engine),
+				IsCluster:     true,
+				PrimaryRegion: region,
+				MultiAZ:       aws.ToBool(cluster.MultiAZ),
+			}
+			for replicaRegion := range replicaRegions {
+				if replicaRegion != "" && replicaRegion != region {
+					item.ReplicaRegions = append(item.ReplicaRegions, replicaRegion)
+				}
+			}
+			sort.Strings(item.ReplicaRegions)
+			item.HasCrossRegionReplica = len(item.ReplicaRegions) > 0
+			item.IsCompliant = item.HasCrossRegionReplica
+			items = append(items, item)
+		}
+	}
+
+	summary, err := s.GetAllInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, instance := range summary.Instances {
+		if instance.Environment != "production" {
+			continue
+		}
+
+		items = append(items, CrossRegionItem{
+			ClusterID:     instance.InstanceID,
+			Engine:        instance.Engine,
+			PrimaryRegion: instance.Region,
+			MultiAZ:       instance.MultiAZ,
+			IsCompliant:   instance.MultiAZ,
+			Note:          "Cross-region replication requires Aurora; Multi-AZ checked instead",
+		})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].IsCompliant != items[j].IsCompliant {
+			return !items[i].IsCompliant
+		}
+		return items[i].ClusterID < items[j].ClusterID
+	})
+
+	log.WithField("checked", len(items)).Info().Msg("RDS cross-region replication checked")
+	return items, nil
+}
+
+// GetTaggingAuditReport checks every PostgreSQL instance for the tags in
+// config.AWSConfig.RequiredRDSTags. A tag with an empty value counts as
+// missing.
+func (s *RDSService) GetTaggingAuditReport(ctx context.Context) ([]TaggingAuditItem, error) {
+	ctx, span := tracing.Start(ctx, "rds.get_tagging_audit_report")
+	defer span.End()
+
+	log := s.logger.ForContext(ctx)
+	log.Info().Msg("Auditing RDS instance tags")
+
+	summary, err := s.GetAllInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]TaggingAuditItem, 0, len(summary.Instances))
+	for _, instance := range summary.Instances {
+		output, err := s.account(instance.AccountID).client.ListTagsForResource(ctx, &rds.ListTagsForResourceInput{
+			ResourceName: aws.String(instance.ARN),
+		})
+		if err != nil {
+			log.WithError(err).WithField("instance_id", instance.InstanceID).Error().Msg("Failed to list RDS instance tags")
+			return nil, fmt.Errorf("failed to list tags for RDS instance %s: %w", instance.InstanceID, err)
+		}
+
+		tags := make(map[string]string, len(output.TagList))
+		for _, tag := range output.TagList {
+			tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+
+		item := TaggingAuditItem{
+			InstanceID:          instance.InstanceID,
+			AccountID:           instance.AccountID,
+			ARN:                 instance.ARN,
+			PresentTags:         tags,
+			MissingRequiredTags: []string{},
+		}
+		for _, required := range s.config.AWS.RequiredRDSTags {
+			if tags[required] == "" {
+				item.MissingRequiredTags = append(item.MissingRequiredTags, required)
+			}
+		}
+		item.IsCompliant = len(item.MissingRequiredTags) == 0
+		items = append(items, item)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].IsCompliant != items[j].IsCompliant {
+			return !items[i].IsCompliant
+		}
+		return items[i].InstanceID < items[j].InstanceID
+	})
+
+	log.WithField("checked", len(items)).Info().Msg("RDS instance tags audited")
+	return items, nil
+}
+
+// GetAlarmComplianceReport checks that every production PostgreSQL instance
+// has CloudWatch alarms on each of RequiredAlarmMetrics
+func (s *RDSService) GetAlarmComplianceReport(ctx context.Context) ([]AlarmComplianceItem, error) {
+	ctx, span := tracing.Start(ctx, "rds.get_alarm_compliance_report")
+	defer span.End()
+
+	log := s.logger.ForContext(ctx)
+	log.Info().Msg("Checking RDS CloudWatch alarm compliance")
+
+	summary, err := s.GetAllInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []AlarmComplianceItem{}
+	for _, instance := range summary.Instances {
+		if instance.Environment != "production" {
+			continue
+		}
+
+		alarms, err := s.account(instance.AccountID).awsClient.GetCloudWatchAlarms(ctx, "AWS/RDS", "DBInstanceIdentifier", instance.InstanceID)
+		if err != nil {
+			return nil, err
+		}
+
+		alarmed := make(map[string]bool)
+		for _, alarm := range alarms {
+			alarmed[alarm.MetricName] = true
+		}
+
+		item := AlarmComplianceItem{
+			InstanceID:       instance.InstanceID,
+			AccountID:        instance.AccountID,
+			ConfiguredAlarms: []string{},
+			MissingAlarms:    []string{},
+		}
+		for _, metric := range RequiredAlarmMetrics {
+			if alarmed[metric] {
+				item.ConfiguredAlarms = append(item.ConfiguredAlarms, metric)
+			} else {
+				item.MissingAlarms = append(item.MissingAlarms, metric)
+			}
+		}
+		item.IsCompliant = len(item.MissingAlarms) == 0
+		items = append(items, item)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].IsCompliant != items[j].IsCompliant {
+			return !items[i].IsCompliant
+		}
+		return items[i].InstanceID < items[j].InstanceID
+	})
+
+	log.WithField("checked", len(items)).Info().Msg("RDS CloudWatch alarm compliance checked")
+	return items, nil
+}
+
+// GetEncryptionComplianceReport checks storage encryption on every
+// PostgreSQL instance. Unencrypted production instances are not compliant.
+func (s *RDSService) GetEncryptionComplianceReport(ctx context.Context) ([]RDSEncryptionItem, error) {
+	ctx, span := tracing.Start(ctx, "rds.get_encryption_compliance_report")
+	defer span.End()
+
+	log := s.logger.ForContext(ctx)
+	log.Info().Msg("Checking RDS storage encryption compliance")
+
+	summary, err := s.GetAllInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]RDSEncryptionItem, 0, len(summary.Instances))
+	for _, instance := range summary.Instances {
+		items = append(items, RDSEncryptionItem{
+			InstanceID:       instance.InstanceID,
+			AccountID:        instance.AccountID,
+			StorageEncrypted: instance.StorageEncrypted,
+			KMSKeyID:         instance.KMSKeyID,
+			Application:      instance.Application,
+			Environment:      instance.Environment,
+			IsCompliant:      instance.StorageEncrypted || instance.Environment != "production",
+		})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].IsCompliant != items[j].IsCompliant {
+			return !items[i].IsCompliant
+		}
+		return items[i].InstanceID < items[j].InstanceID
+	})
+
+	log.WithField("checked", len(items)).Info().Msg("RDS storage encryption compliance checked")
+	return items, nil
+}
+
+// GetConnectionPoolingRecommendations compares each instance's peak
+// DatabaseConnections over the last week with the default max_connections
+// for its instance class, recommending PgBouncer above
+// ConnectionPoolingThreshold. Instances on unknown instance classes or
+// without connection metrics are skipped.
+func (s *RDSService) GetConnectionPoolingRecommendations(ctx context.Context) ([]ConnectionPoolingRecommendation, error) {
+	ctx, span := tracing.Start(ctx, "rds.get_connection_pooling_recommendations")
+	defer span.End()
+
+	log := s.logger.ForContext(ctx)
+	log.Info().Msg("Checking RDS connection utilisation")
+
+	summary, err := s.GetAllInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	recommendations := []ConnectionPoolingRecommendation{}
+	for _, instance := range summary.Instances {
+		maxConnections := defaultMaxConnections(instance.InstanceClass)
+		if maxConnections == 0 {
+			log.WithFields(map[string]interface{}{
+				"instance_id":    instance.InstanceID,
+				"instance_class": instance.InstanceClass,
+			}).Debug().Msg("Unknown instance class, skipping connection pooling check")
+			continue
+		}
+
+		peak, found, err := s.account(instance.AccountID).awsClient.GetMetricMaximum(ctx, "AWS/RDS", "DatabaseConnections", "DBInstanceIdentifier", instance.InstanceID, ConnectionMetricsLookback, ConnectionMetricsPeriod)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+
+		recommendations = append(recommendations, recommendConnectionPooling(instance, int32(peak), maxConnections))
+	}
+
+	sort.Slice(recommendations, func(i, j int) bool {
+		return recommendations[i].UtilizationPercent > recommendations[j].UtilizationPercent
+	})
+
+	log.WithField("checked", len(recommendations)).Info().Msg("RDS connection utilisation checked")
+	return recommendations, nil
+}
+
+// recommendConnectionPooling builds the recommendation for an instance from
+// its peak connections, including a PgBouncer config to start from when
+// pooling is recommended
+func recommendConnectionPooling(instance PostgreSQLInstance, peakConnections, maxConnections int32) ConnectionPoolingRecommendation {
+	recommendation := ConnectionPoolingRecommendation{
+		InstanceID:         instance.InstanceID,
+		AccountID:          instance.AccountID,
+		InstanceClass:      instance.InstanceClass,
+		PeakConnections:    peakConnections,
+		MaxConnections:     maxConnections,
+		UtilizationPercent: math.Round(float64(peakConnections)/float64(maxConnections)*1000) / 10,
+	}
+	recommendation.RecommendsPgBouncer = recommendation.UtilizationPercent > ConnectionPoolingThreshold
+
+	if recommendation.RecommendsPgBouncer {
+		host := instance.Endpoint
+		if host == "" {
+			host = "<" + instance.InstanceID + " endpoint>"
+		}
+
+		// Clients can keep their current number of connections while
+		// PgBouncer holds at most half of max_connections open to PostgreSQL
+		recommendation.EstimatedConfigTemplate = fmt.Sprintf(`[databases]
+* = host=%s port=5432
+
+[pgbouncer]
+pool_mode = transaction
+max_client_conn = %d
+default_pool_size = %d
+reserve_pool_size = 5
+server_idle_timeout = 300
+`, host, max(peakConnections*2, 100), max(maxConnections/2, 10))
+	}
+
+	return recommendation
+}
+
+// defaultMaxConnections estimates max_connections for an instance class
+// using the default parameter group formula,
+// LEAST(DBInstanceClassMemory/9531392, 5000). It returns 0 for instance
+// classes that aren't known. Instances with a custom max_connections in
+// their parameter group will differ.
+func defaultMaxConnections(instanceClass string) int32 {
+	memory, known := instanceClassMemory(instanceClass)
+	if !known {
+		return 0
+	}
+
+	memoryBytes := memory * 1024 * 1024 * 1024
+	return int32(min(memoryBytes/9531392, 5000))
+}
+
+// GenerateRightSizingRecommendations suggests cheaper instance classes from
+// each instance's class and environment, with savings estimated from
+// awsclient.RDSPricingMap. Instances with no environment aren't assumed to
+// be non-production. Aurora cluster members and instances on unknown
+// classes are skipped. The largest savings come first.
+func (s *RDSService) GenerateRightSizingRecommendations(instances []PostgreSQLInstance) []RightSizingRecommendation {
+	recommendations := []RightSizingRecommendation{}
+	for _, instance := range instances {
+		if instance.ClusterID != "" {
+			continue
+		}
+		if recommendation, found := recommendRightSizing(instance); found {
+			recommendations = append(recommendations, recommendation)
+		}
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		return recommendations[i].EstimatedMonthlySavingsGBP > recommendations[j].EstimatedMonthlySavingsGBP
+	})
+	return recommendations
+}
+
+// recommendRightSizing returns the right-sizing recommendation for an
+// instance, or false if it looks right-sized
+func recommendRightSizing(instance PostgreSQLInstance) (RightSizingRecommendation, bool) {
+	family, size, _ := splitInstanceClass(instance.InstanceClass)
+	memory, known := instanceClassMemory(instance.InstanceClass)
+	if !known {
+		return RightSizingRecommendation{}, false
+	}
+	maxNonProductionMemory, _ := instanceClassMemory(RightSizingMaxNonProductionClass)
+
+	recommendation := RightSizingRecommendation{
+		InstanceID:   instance.InstanceID,
+		AccountID:    instance.AccountID,
+		Environment:  instance.Environment,
+		CurrentClass: instance.InstanceClass,
+	}
+	switch {
+	case instance.Environment != "" && instance.Environment != "production" && memory > maxNonProductionMemory:
+		recommendation.RecommendedClass = largestClassWithin(family, maxNonProductionMemory)
+		recommendation.Reason = fmt.Sprintf("Non-production instance is larger than %s", RightSizingMaxNonProductionClass)
+		recommendation.Confidence = "medium"
+	case !instance.MultiAZ && slices.Contains(burstableCandidateFamilies, family):
+		if instanceSizeMultipliers[size] > instanceSizeMultipliers[burstableMaxSize] {
+			size = burstableMaxSize
+		}
+		recommendation.RecommendedClass = BurstableInstanceFamily + "." + size
+		recommendation.Reason = fmt.Sprintf("Single-AZ %s instance could run on burstable %s if its load is not sustained", family, BurstableInstanceFamily)
+		recommendation.Confidence = "low"
+	default:
+		return RightSizingRecommendation{}, false
+	}
+
+	current, currentPriced := awsclient.RDSMonthlyCost(recommendation.CurrentClass, instance.MultiAZ)
+	recommended, recommendedPriced := awsclient.RDSMonthlyCost(recommendation.RecommendedClass, instance.MultiAZ)
+	if currentPriced && recommendedPriced {
+		recommendation.EstimatedMonthlySavingsGBP = math.Round((current-recommended)*100) / 100
+	}
+	return recommendation, true
+}
+
+// largestClassWithin returns the largest instance class in family with at
+// most maxMemory GiB
+func largestClassWithin(family string, maxMemory float64) string {
+	best := ""
+	for size, multiplier := range instanceSizeMultipliers {
+		if instanceClassMemoryGiB[family]*multiplier <= maxMemory && (best == "" || multiplier > instanceSizeMultipliers[best]) {
+			best = size
+		}
+	}
+	return family + "." + best
+}
+
+// instanceClassMemory returns the memory of an instance class in GiB, or
+// false if the class isn't known
+func instanceClassMemory(instanceClass string) (float64, bool) {
+	family, size, ok := splitInstanceClass(instanceClass)
+	familyMemory, knownFamily := instanceClassMemoryGiB[family]
+	multiplier, knownSize := instanceSizeMultipliers[size]
+	if !ok || !knownFamily || !knownSize {
+		return 0, false
+	}
+	return familyMemory * multiplier, true
+}
+
+// splitInstanceClass splits an instance class such as db.r5.2xlarge into
+// its family, db.r5, and size, 2xlarge
+func splitInstanceClass(instanceClass string) (string, string, bool) {
+	lastDot := strings.LastIndex(instanceClass, ".")
+	if lastDot < 0 {
+		return "", "", false
+	}
+	return instanceClass[:lastDot], instanceClass[lastDot+1:], true
+}
+
 // Helper methods
 
-// isPostgreSQL checks if the DB instance is PostgreSQL
+// regionFromARN returns the region of an ARN such as
+// arn:aws:rds:eu-west-1:123456789012:cluster:name, or "" if it has none
+func regionFromARN(arn string) string {
+	parts := strings.SplitN(arn, ":", 5)
+	if len(parts) < 5 {
+		return ""
+	}
+	return parts[3]
+}
+
+// getSnapshotSources returns the keys of every DB instance and cluster that
+// currently exists, of any engine, for matching against snapshot sources
+func (s *RDSService) getSnapshotSources(ctx context.Context) (map[string]bool, error) {
+	sources := make(map[string]bool)
+
+	instancePaginator := rds.NewDescribeDBInstancesPaginator(s.client, &rds.DescribeDBInstancesInput{})
+	for instancePaginator.HasMorePages() {
+		page, err := instancePaginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe RDS instances: %w", err)
+		}
+		for _, dbInstance := range page.DBInstances {
+			sources[snapshotSourceKey(aws.ToString(dbInstance.DBInstanceIdentifier), false)] = true
+		}
+	}
+
+	clusterPaginator := rds.NewDescribeDBClustersPaginator(s.client, &rds.DescribeDBClustersInput{})
+	for clusterPaginator.HasMorePages() {
+		page, err := clusterPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe RDS clusters: %w", err)
+		}
+		for _, cluster := range page.DBClusters {
+			sources[snapshotSourceKey(aws.ToString(cluster.DBClusterIdentifier), true)] = true
+		}
+	}
+
+	return sources, nil
+}
+
+// snapshotSourceKey distinguishes instances and clusters that share a name
+func snapshotSourceKey(sourceID string, isCluster bool) string {
+	if isCluster {
+		return "cluster:" + sourceID
+	}
+	return "instance:" + sourceID
+}
+
+// isPostgreSQL checks if the DB instance is PostgreSQL, including Aurora
+// PostgreSQL cluster members
 func (s *RDSService) isPostgreSQL(dbInstance types.DBInstance) bool {
 	if dbInstance.Engine == nil {
 		return false
 	}
-	return strings.HasPrefix(strings.ToLower(*dbInstance.Engine), "postgres")
+	engine := strings.ToLower(*dbInstance.Engine)
+	return strings.HasPrefix(engine, "postgres") || isAuroraPostgreSQL(engine)
+}
+
+// isAuroraPostgreSQL checks if an engine is Aurora PostgreSQL
+func isAuroraPostgreSQL(engine string) bool {
+	return strings.ToLower(engine) == "aurora-postgresql"
+}
+
+// convertToAuroraCluster converts an AWS RDS cluster to our model
+func (s *RDSService) convertToAuroraCluster(dbCluster types.DBCluster) AuroraCluster {
+	cluster := AuroraCluster{
+		ClusterID:          aws.ToString(dbCluster.DBClusterIdentifier),
+		ARN:                aws.ToString(dbCluster.DBClusterArn),
+		Engine:             aws.ToString(dbCluster.Engine),
+		Version:            aws.ToString(dbCluster.EngineVersion),
+		Status:             aws.ToString(dbCluster.Status),
+		MultiAZ:            aws.ToBool(dbCluster.MultiAZ),
+		WriterEndpoint:     aws.ToString(dbCluster.Endpoint),
+		ReaderEndpoint:     aws.ToString(dbCluster.ReaderEndpoint),
+		MemberInstanceIDs:  []string{},
+		DeletionProtection: aws.ToBool(dbCluster.DeletionProtection),
+		StorageEncrypted:   aws.ToBool(dbCluster.StorageEncrypted),
+	}
+
+	cluster.MajorVersion = s.extractMajorVersion(cluster.Version)
+	cluster.IsEOL, cluster.EOLDate = s.versionEOL(cluster.MajorVersion)
+
+	if dbCluster.ClusterCreateTime != nil {
+		cluster.CreatedAt = *dbCluster.ClusterCreateTime
+	}
+
+	for _, member := range dbCluster.DBClusterMembers {
+		instanceID := aws.ToString(member.DBInstanceIdentifier)
+		cluster.MemberInstanceIDs = append(cluster.MemberInstanceIDs, instanceID)
+		if aws.ToBool(member.IsClusterWriter) {
+			cluster.WriterInstanceID = instanceID
+		}
+	}
+
+	cluster.Application, cluster.Environment = s.extractApplicationInfo(cluster.ClusterID)
+	for _, tag := range dbCluster.TagList {
+		switch aws.ToString(tag.Key) {
+		case "system":
+			cluster.Application = aws.ToString(tag.Value)
+		case "environment":
+			cluster.Environment = aws.ToString(tag.Value)
+		}
+	}
+
+	return cluster
 }
 
 // convertToPostgreSQLInstance converts AWS RDS instance to our model
 func (s *RDSService) convertToPostgreSQLInstance(dbInstance types.DBInstance) PostgreSQLInstance {
 	instance := PostgreSQLInstance{
 		InstanceID:       aws.ToString(dbInstance.DBInstanceIdentifier),
+		ARN:              aws.ToString(dbInstance.DBInstanceArn),
 		Name:             aws.ToString(dbInstance.DBName),
 		Version:          aws.ToString(dbInstance.EngineVersion),
 		Engine:           aws.ToString(dbInstance.Engine),
@@ -200,6 +1167,14 @@ func (s *RDSService) convertToPostgreSQLInstance(dbInstance types.DBInstance) Po
 
 	// Try to extract application and environment from tags or instance name
 	instance.Application, instance.Environment = s.extractApplicationInfo(instance.InstanceID)
+	for _, tag := range dbInstance.TagList {
+		switch aws.ToString(tag.Key) {
+		case "system":
+			instance.Application = aws.ToString(tag.Value)
+		case "environment":
+			instance.Environment = aws.ToString(tag.Value)
+		}
+	}
 
 	// Set other fields
 	if dbInstance.AllocatedStorage != nil {
@@ -211,6 +1186,18 @@ func (s *RDSService) convertToPostgreSQLInstance(dbInstance types.DBInstance) Po
 	if dbInstance.PubliclyAccessible != nil {
 		instance.PubliclyAccessible = *dbInstance.PubliclyAccessible
 	}
+	instance.PerformanceInsightsEnabled = aws.ToBool(dbInstance.PerformanceInsightsEnabled)
+	instance.IAMAuthEnabled = aws.ToBool(dbInstance.IAMDatabaseAuthenticationEnabled)
+	instance.StorageEncrypted = aws.ToBool(dbInstance.StorageEncrypted)
+	instance.KMSKeyID = aws.ToString(dbInstance.KmsKeyId)
+	instance.ClusterID = aws.ToString(dbInstance.DBClusterIdentifier)
+	instance.BackupRetentionDays = aws.ToInt32(dbInstance.BackupRetentionPeriod)
+	instance.BackupWindow = aws.ToString(dbInstance.PreferredBackupWindow)
+	instance.DeletionProtection = aws.ToBool(dbInstance.DeletionProtection)
+	instance.AutoMinorVersionUpgrade = aws.ToBool(dbInstance.AutoMinorVersionUpgrade)
+	if dbInstance.Endpoint != nil {
+		instance.Endpoint = aws.ToString(dbInstance.Endpoint.Address)
+	}
 
 	instance.LastModified = time.Now()
 
@@ -219,19 +1206,21 @@ func (s *RDSService) convertToPostgreSQLInstance(dbInstance types.DBInstance) Po
 
 // enrichWithVersionInfo adds EOL and version information
 func (s *RDSService) enrichWithVersionInfo(instance PostgreSQLInstance) PostgreSQLInstance {
-	versionInfo, exists := s.eolData.Versions[instance.MajorVersion]
+	instance.IsEOL, instance.EOLDate = s.versionEOL(instance.MajorVersion)
+	return instance
+}
+
+// versionEOL returns whether a PostgreSQL major version, which Aurora
+// PostgreSQL shares, is end-of-life, and when
+func (s *RDSService) versionEOL(majorVersion string) (bool, *time.Time) {
+	versionInfo, exists := s.eolData.Versions[majorVersion]
 	if exists {
-		instance.IsEOL = versionInfo.IsEOL
-		instance.EOLDate = versionInfo.EOLDate
-	} else {
-		// If version not in our data, consider it potentially EOL if very old
-		majorVersionNum, err := strconv.Atoi(instance.MajorVersion)
-		if err == nil && majorVersionNum < 12 {
-			instance.IsEOL = true
-		}
+		return versionInfo.IsEOL, versionInfo.EOLDate
 	}
 
-	return instance
+	// If version not in our data, consider it potentially EOL if very old
+	majorVersionNum, err := strconv.Atoi(majorVersion)
+	return err == nil && majorVersionNum < 12, nil
 }
 
 // extractMajorVersion extracts major version from full version string
@@ -289,12 +1278,19 @@ func (s *RDSService) extractApplicationInfo(instanceID string) (string, string)
 	return application, environment
 }
 
-// generateInstancesSummary creates a summary of all instances
-func (s *RDSService) generateInstancesSummary(instances []PostgreSQLInstance) *InstancesSummary {
+// generateInstancesSummary creates a summary of all instances and Aurora
+// clusters
+func (s *RDSService) generateInstancesSummary(instances []PostgreSQLInstance, auroraClusters []AuroraCluster) *InstancesSummary {
+	if auroraClusters == nil {
+		auroraClusters = []AuroraCluster{}
+	}
+
 	summary := &InstancesSummary{
 		TotalInstances:  len(instances),
 		PostgreSQLCount: len(instances),
 		Instances:       instances,
+		AuroraCount:     len(auroraClusters),
+		AuroraClusters:  auroraClusters,
 		LastUpdated:     time.Now(),
 	}
 
@@ -334,23 +1330,31 @@ func (s *RDSService) isOutdated(instance PostgreSQLInstance) bool {
 		return false // EOL is handled separately
 	}
 	
-	versionInfo, exists := s.eolData.Versions[instance.MajorVersion]
+	return s.isVersionOutdated(instance.MajorVersion)
+}
+
+// isVersionOutdated checks if a major version is unsupported or unknown
+func (s *RDSService) isVersionOutdated(majorVersion string) bool {
+	versionInfo, exists := s.eolData.Versions[majorVersion]
 	if !exists {
 		return true // Unknown version, consider outdated
 	}
-	
+
 	return !versionInfo.IsSupported
 }
 
 // checkInstanceVersion performs version checking for a single instance
 func (s *RDSService) checkInstanceVersion(instance PostgreSQLInstance) VersionCheckResult {
 	result := VersionCheckResult{
-		InstanceID:     instance.InstanceID,
-		CurrentVersion: instance.Version,
-		MajorVersion:   instance.MajorVersion,
-		IsEOL:          instance.IsEOL,
-		IsOutdated:     s.isOutdated(instance),
-		EOLDate:        instance.EOLDate,
+		InstanceID:         instance.InstanceID,
+		CurrentVersion:     instance.Version,
+		MajorVersion:       instance.MajorVersion,
+		IsEOL:              instance.IsEOL,
+		IsOutdated:         s.isOutdated(instance),
+		EOLDate:            instance.EOLDate,
+		IAMAuthEnabled:     instance.IAMAuthEnabled,
+		IAMAuthRecommended: supportsIAMAuth(instance.Version),
+		BackupCompliance:   instance.BackupCompliance,
 	}
 
 	// Determine recommended action
@@ -370,6 +1374,101 @@ func (s *RDSService) checkInstanceVersion(instance PostgreSQLInstance) VersionCh
 	return result
 }
 
+// CheckBackupCompliance checks an instance's automated backups and upgrade
+// settings. Retention under MinBackupRetentionDays is critical, deletion
+// protection being off is high and automatic minor version upgrades being
+// off is medium. Aurora cluster members are protected from deletion by their
+// cluster, so their own setting isn't checked.
+func (s *RDSService) CheckBackupCompliance(instance PostgreSQLInstance) BackupComplianceResult {
+	result := BackupComplianceResult{Issues: []string{}}
+	raise := func(severity Severity, issue string) {
+		result.Issues = append(result.Issues, issue)
+		if result.Severity == "" || severityRank[severity] > severityRank[result.Severity] {
+			result.Severity = severity
+		}
+	}
+
+	if instance.BackupRetentionDays < MinBackupRetentionDays {
+		if instance.BackupRetentionDays == 0 {
+			raise(SeverityCritical, "Automated backups are disabled")
+		} else {
+			raise(SeverityCritical, fmt.Sprintf("Backups are kept for %d days, fewer than %d", instance.BackupRetentionDays, MinBackupRetentionDays))
+		}
+	}
+	if !instance.DeletionProtection && instance.ClusterID == "" {
+		raise(SeverityHigh, "Deletion protection is disabled")
+	}
+	if !instance.AutoMinorVersionUpgrade {
+		raise(SeverityMedium, "Automatic minor version upgrades are disabled")
+	}
+
+	result.IsCompliant = len(result.Issues) == 0
+	return result
+}
+
+// severityRank orders severities from least to most serious
+var severityRank = map[Severity]int{
+	SeverityLow:      1,
+	SeverityMedium:   2,
+	SeverityHigh:     3,
+	SeverityCritical: 4,
+}
+
+// GetBackupComplianceReport returns every PostgreSQL instance with its
+// backup compliance, non-compliant instances first, most serious first
+func (s *RDSService) GetBackupComplianceReport(ctx context.Context) ([]PostgreSQLInstance, error) {
+	ctx, span := tracing.Start(ctx, "rds.get_backup_compliance_report")
+	defer span.End()
+
+	log := s.logger.ForContext(ctx)
+	log.Info().Msg("Checking RDS backup compliance")
+
+	summary, err := s.GetAllInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	instances := append([]PostgreSQLInstance{}, summary.Instances...)
+	sort.SliceStable(instances, func(i, j int) bool {
+		a, b := instances[i].BackupCompliance, instances[j].BackupCompliance
+		if severityRank[a.Severity] != severityRank[b.Severity] {
+			return severityRank[a.Severity] > severityRank[b.Severity]
+		}
+		return instances[i].InstanceID < instances[j].InstanceID
+	})
+
+	log.WithField("checked", len(instances)).Info().Msg("RDS backup compliance checked")
+	return instances, nil
+}
+
+// GetIAMRemediation returns the AWS CLI command that enables IAM database
+// authentication on an instance
+func (s *RDSService) GetIAMRemediation(instance PostgreSQLInstance) string {
+	command := fmt.Sprintf("aws rds modify-db-instance --db-instance-identifier %s --enable-iam-database-authentication --apply-immediately", instance.InstanceID)
+	if instance.Region != "" {
+		command += " --region " + instance.Region
+	}
+	return command
+}
+
+// supportsIAMAuth reports whether a PostgreSQL version supports IAM database
+// authentication, which RDS added in 9.6
+func supportsIAMAuth(version string) bool {
+	parts := strings.SplitN(version, ".", 3)
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	if major != 9 {
+		return major > 9
+	}
+	if len(parts) < 2 {
+		return false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	return err == nil && minor >= 6
+}
+
 // getPostgreSQLVersionData returns PostgreSQL version EOL data
 func getPostgreSQLVersionData() PostgreSQLVersions {
 	now := time.Now()
@@ -462,4 +1561,32 @@ func getPostgreSQLVersionData() PostgreSQLVersions {
 // timePtr returns a pointer to a time.Time
 func timePtr(t time.Time) *time.Time {
 	return &t
-}
\ No newline at end of file
+}
+
+// piPeriodForHours picks a Performance Insights aggregation period that keeps
+// the number of data points per query reasonable
+func piPeriodForHours(hours int) int32 {
+	switch {
+	case hours <= 1:
+		return 60
+	case hours <= 6:
+		return 300
+	case hours <= 24:
+		return 3600
+	default:
+		return 86400
+	}
+}
+
+// averageDataPoints returns the mean value of the given data points
+func averageDataPoints(points []piDataPoint) float64 {
+	if len(points) == 0 {
+		return 0
+	}
+
+	total := 0.0
+	for _, point := range points {
+		total += point.Value
+	}
+	return total / float64(len(points))
+}
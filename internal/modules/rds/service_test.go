@@ -0,0 +1,449 @@
+package rds
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"govuk-reports-dashboard/internal/config"
+	awsclient "govuk-reports-dashboard/pkg/aws"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+func TestDefaultMaxConnections(t *testing.T) {
+	tests := []struct {
+		instanceClass string
+		want          int32
+	}{
+		{"db.t3.large", 901},
+		{"db.r6g.large", 1802},
+		{"db.r6g.xlarge", 3604},
+		{"db.r6g.4xlarge", 5000},
+		{"db.unknown.large", 0},
+		{"db.r6g.enormous", 0},
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		if got := defaultMaxConnections(tt.instanceClass); got != tt.want {
+			t.Errorf("defaultMaxConnections(%q) = %d, want %d", tt.instanceClass, got, tt.want)
+		}
+	}
+}
+
+func TestRecommendConnectionPooling(t *testing.T) {
+	instance := PostgreSQLInstance{
+		InstanceID:    "content-store-postgres",
+		InstanceClass: "db.r6g.large",
+		Endpoint:      "content-store-postgres.abc123.eu-west-1.rds.amazonaws.com",
+	}
+
+	below := recommendConnectionPooling(instance, 1200, 1802)
+	if below.RecommendsPgBouncer {
+		t.Errorf("expected no recommendation at %.1f%% utilisation", below.UtilizationPercent)
+	}
+	if below.EstimatedConfigTemplate != "" {
+		t.Error("expected no config template when PgBouncer is not recommended")
+	}
+
+	above := recommendConnectionPooling(instance, 1500, 1802)
+	if !above.RecommendsPgBouncer {
+		t.Fatalf("expected PgBouncer recommendation at %.1f%% utilisation", above.UtilizationPercent)
+	}
+	if above.UtilizationPercent != 83.2 {
+		t.Errorf("UtilizationPercent = %v, want 83.2", above.UtilizationPercent)
+	}
+	for _, want := range []string{
+		"host=" + instance.Endpoint,
+		"pool_mode = transaction",
+		"max_client_conn = 3000",
+		"default_pool_size = 901",
+	} {
+		if !strings.Contains(above.EstimatedConfigTemplate, want) {
+			t.Errorf("config template missing %q:\n%s", want, above.EstimatedConfigTemplate)
+		}
+	}
+}
+
+func TestGenerateRightSizingRecommendations(t *testing.T) {
+	service := &RDSService{}
+	instances := []PostgreSQLInstance{
+		{InstanceID: "publisher-staging", Environment: "staging", InstanceClass: "db.r5.8xlarge", MultiAZ: true},
+		{InstanceID: "signon-production", Environment: "production", InstanceClass: "db.r6g.4xlarge"},
+		{InstanceID: "search-integration", Environment: "integration", InstanceClass: "db.r5.large"},
+		// Right-sized: production Multi-AZ, non-production within the limit,
+		// already burstable, unknown environment and class, and Aurora
+		{InstanceID: "content-store-production", Environment: "production", InstanceClass: "db.r5.8xlarge", MultiAZ: true},
+		{InstanceID: "whitehall-staging", Environment: "staging", InstanceClass: "db.r5.2xlarge", MultiAZ: true},
+		{InstanceID: "router-staging", Environment: "staging", InstanceClass: "db.t4g.medium"},
+		{InstanceID: "unknown-environment", InstanceClass: "db.m5.12xlarge", MultiAZ: true},
+		{InstanceID: "unknown-class", Environment: "staging", InstanceClass: "db.x2g.16xlarge"},
+		{InstanceID: "aurora-member", Environment: "staging", InstanceClass: "db.r6g.large", ClusterID: "aurora"},
+	}
+
+	recommendations := service.GenerateRightSizingRecommendations(instances)
+	if len(recommendations) != 3 {
+		t.Fatalf("Expected 3 recommendations, got %+v", recommendations)
+	}
+
+	// Environment-based flag, doubled for Multi-AZ
+	staging := recommendations[0]
+	if staging.InstanceID != "publisher-staging" || staging.RecommendedClass != "db.r5.2xlarge" || staging.Confidence != "medium" {
+		t.Errorf("Expected publisher-staging down to db.r5.2xlarge, got %+v", staging)
+	}
+	if want := 3876.3; staging.EstimatedMonthlySavingsGBP != want {
+		t.Errorf("EstimatedMonthlySavingsGBP = %v, want %v", staging.EstimatedMonthlySavingsGBP, want)
+	}
+
+	// Class downgrade, capped at the largest burstable size
+	signon := recommendations[1]
+	if signon.InstanceID != "signon-production" || signon.RecommendedClass != "db.t4g.2xlarge" || signon.Confidence != "low" {
+		t.Errorf("Expected signon-production on db.t4g.2xlarge, got %+v", signon)
+	}
+	search := recommendations[2]
+	if search.InstanceID != "search-integration" || search.RecommendedClass != "db.t4g.large" {
+		t.Errorf("Expected search-integration on db.t4g.large, got %+v", search)
+	}
+	if want := 78.84; search.EstimatedMonthlySavingsGBP != want {
+		t.Errorf("EstimatedMonthlySavingsGBP = %v, want %v", search.EstimatedMonthlySavingsGBP, want)
+	}
+}
+
+func TestConvertToPostgreSQLInstance_StorageEncryption(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	s := &RDSService{logger: log}
+
+	encrypted := s.convertToPostgreSQLInstance(types.DBInstance{
+		DBInstanceIdentifier: aws.String("content-store-postgres"),
+		EngineVersion:        aws.String("16.3"),
+		StorageEncrypted:     aws.Bool(true),
+		KmsKeyId:             aws.String("arn:aws:kms:eu-west-2:123456789012:key/abcd-1234"),
+	})
+	if !encrypted.StorageEncrypted || encrypted.KMSKeyID != "arn:aws:kms:eu-west-2:123456789012:key/abcd-1234" {
+		t.Errorf("Expected encrypted instance with KMS key, got encrypted=%v key=%q", encrypted.StorageEncrypted, encrypted.KMSKeyID)
+	}
+
+	unencrypted := s.convertToPostgreSQLInstance(types.DBInstance{
+		DBInstanceIdentifier: aws.String("legacy-postgres"),
+		EngineVersion:        aws.String("13.4"),
+	})
+	if unencrypted.StorageEncrypted || unencrypted.KMSKeyID != "" {
+		t.Errorf("Expected unencrypted instance without KMS key, got encrypted=%v key=%q", unencrypted.StorageEncrypted, unencrypted.KMSKeyID)
+	}
+}
+
+func TestConvertToPostgreSQLInstance_BackupSettings(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	s := &RDSService{logger: log}
+
+	instance := s.convertToPostgreSQLInstance(types.DBInstance{
+		DBInstanceIdentifier:    aws.String("publishing-api-postgres"),
+		EngineVersion:           aws.String("16.3"),
+		BackupRetentionPeriod:   aws.Int32(14),
+		PreferredBackupWindow:   aws.String("02:00-03:00"),
+		DeletionProtection:      aws.Bool(true),
+		AutoMinorVersionUpgrade: aws.Bool(true),
+	})
+	if instance.BackupRetentionDays != 14 || instance.BackupWindow != "02:00-03:00" || !instance.DeletionProtection || !instance.AutoMinorVersionUpgrade {
+		t.Errorf("Expected backup settings from the DB instance, got %+v", instance)
+	}
+}
+
+func TestCheckBackupCompliance(t *testing.T) {
+	s := &RDSService{}
+	compliant := PostgreSQLInstance{
+		BackupRetentionDays:     MinBackupRetentionDays,
+		DeletionProtection:      true,
+		AutoMinorVersionUpgrade: true,
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(*PostgreSQLInstance)
+		severity Severity
+		issues   int
+	}{
+		{"compliant at minimum retention", func(i *PostgreSQLInstance) {}, "", 0},
+		{"retention one day short", func(i *PostgreSQLInstance) { i.BackupRetentionDays = MinBackupRetentionDays - 1 }, SeverityCritical, 1},
+		{"backups disabled", func(i *PostgreSQLInstance) { i.BackupRetentionDays = 0 }, SeverityCritical, 1},
+		{"long retention", func(i *PostgreSQLInstance) { i.BackupRetentionDays = 35 }, "", 0},
+		{"no deletion protection", func(i *PostgreSQLInstance) { i.DeletionProtection = false }, SeverityHigh, 1},
+		{"aurora member without deletion protection", func(i *PostgreSQLInstance) {
+			i.DeletionProtection = false
+			i.ClusterID = "publishing-api-aurora"
+		}, "", 0},
+		{"no automatic minor upgrades", func(i *PostgreSQLInstance) { i.AutoMinorVersionUpgrade = false }, SeverityMedium, 1},
+		{"most serious issue wins", func(i *PostgreSQLInstance) {
+			i.AutoMinorVersionUpgrade = false
+			i.DeletionProtection = false
+		}, SeverityHigh, 2},
+		{"all issues", func(i *PostgreSQLInstance) { *i = PostgreSQLInstance{BackupRetentionDays: 1} }, SeverityCritical, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := compliant
+			tt.modify(&instance)
+
+			result := s.CheckBackupCompliance(instance)
+			if result.IsCompliant != (tt.issues == 0) {
+				t.Errorf("Expected compliant=%v, got %v", tt.issues == 0, result.IsCompliant)
+			}
+			if result.Severity != tt.severity {
+				t.Errorf("Expected severity %q, got %q", tt.severity, result.Severity)
+			}
+			if len(result.Issues) != tt.issues {
+				t.Errorf("Expected %d issues, got %v", tt.issues, result.Issues)
+			}
+		})
+	}
+}
+
+func TestConvertToAuroraCluster(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	s := &RDSService{logger: log, eolData: getPostgreSQLVersionData()}
+
+	tests := []struct {
+		name         string
+		cluster      types.DBCluster
+		wantMembers  []string
+		wantWriter   string
+		wantEOL      bool
+		wantOutdated bool
+	}{
+		{
+			name: "no members",
+			cluster: types.DBCluster{
+				DBClusterIdentifier: aws.String("publishing-api-aurora-staging"),
+				Engine:              aws.String("aurora-postgresql"),
+				EngineVersion:       aws.String("16.2"),
+			},
+			wantMembers: []string{},
+		},
+		{
+			name: "writer and reader",
+			cluster: types.DBCluster{
+				DBClusterIdentifier: aws.String("content-store-aurora"),
+				Engine:              aws.String("aurora-postgresql"),
+				EngineVersion:       aws.String("15.4"),
+				DBClusterMembers: []types.DBClusterMember{
+					{DBInstanceIdentifier: aws.String("content-store-aurora-1"), IsClusterWriter: aws.Bool(true)},
+					{DBInstanceIdentifier: aws.String("content-store-aurora-2"), IsClusterWriter: aws.Bool(false)},
+				},
+			},
+			wantMembers: []string{"content-store-aurora-1", "content-store-aurora-2"},
+			wantWriter:  "content-store-aurora-1",
+		},
+		{
+			name: "old version missing from EOL data",
+			cluster: types.DBCluster{
+				DBClusterIdentifier: aws.String("legacy-aurora"),
+				Engine:              aws.String("aurora-postgresql"),
+				EngineVersion:       aws.String("9.6.22"),
+			},
+			wantMembers: []string{},
+			wantEOL:     true,
+		},
+		{
+			name: "new version missing from EOL data",
+			cluster: types.DBCluster{
+				DBClusterIdentifier: aws.String("search-aurora"),
+				Engine:              aws.String("aurora-postgresql"),
+				EngineVersion:       aws.String("99.1"),
+			},
+			wantMembers:  []string{},
+			wantOutdated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := s.convertToAuroraCluster(tt.cluster)
+
+			if cluster.ClusterID != aws.ToString(tt.cluster.DBClusterIdentifier) {
+				t.Errorf("Expected cluster ID %s, got %s", aws.ToString(tt.cluster.DBClusterIdentifier), cluster.ClusterID)
+			}
+			if strings.Join(cluster.MemberInstanceIDs, ",") != strings.Join(tt.wantMembers, ",") || cluster.MemberInstanceIDs == nil {
+				t.Errorf("Expected members %v, got %v", tt.wantMembers, cluster.MemberInstanceIDs)
+			}
+			if cluster.WriterInstanceID != tt.wantWriter {
+				t.Errorf("Expected writer %q, got %q", tt.wantWriter, cluster.WriterInstanceID)
+			}
+			if cluster.IsEOL != tt.wantEOL {
+				t.Errorf("Expected IsEOL %v, got %v", tt.wantEOL, cluster.IsEOL)
+			}
+			if outdated := !cluster.IsEOL && s.isVersionOutdated(cluster.MajorVersion); outdated != tt.wantOutdated {
+				t.Errorf("Expected outdated %v, got %v", tt.wantOutdated, outdated)
+			}
+		})
+	}
+}
+
+func TestGenerateInstancesSummary_StandaloneAndClusterInstances(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	s := &RDSService{logger: log, eolData: getPostgreSQLVersionData()}
+
+	dbInstances := []types.DBInstance{
+		{DBInstanceIdentifier: aws.String("signon-postgres"), Engine: aws.String("postgres"), EngineVersion: aws.String("16.3")},
+		{DBInstanceIdentifier: aws.String("content-store-aurora-1"), Engine: aws.String("aurora-postgresql"), EngineVersion: aws.String("15.4"), DBClusterIdentifier: aws.String("content-store-aurora")},
+		{DBInstanceIdentifier: aws.String("content-store-aurora-2"), Engine: aws.String("aurora-postgresql"), EngineVersion: aws.String("15.4"), DBClusterIdentifier: aws.String("content-store-aurora")},
+		{DBInstanceIdentifier: aws.String("whitehall-mysql"), Engine: aws.String("mysql"), EngineVersion: aws.String("8.0.35")},
+	}
+
+	var instances []PostgreSQLInstance
+	for _, dbInstance := range dbInstances {
+		if s.isPostgreSQL(dbInstance) {
+			instances = append(instances, s.enrichWithVersionInfo(s.convertToPostgreSQLInstance(dbInstance)))
+		}
+	}
+	cluster := s.convertToAuroraCluster(types.DBCluster{
+		DBClusterIdentifier: aws.String("content-store-aurora"),
+		Engine:              aws.String("aurora-postgresql"),
+		EngineVersion:       aws.String("15.4"),
+	})
+
+	summary := s.generateInstancesSummary(instances, []AuroraCluster{cluster})
+
+	if summary.TotalInstances != 3 || summary.AuroraCount != 1 {
+		t.Fatalf("Expected 3 instances and 1 Aurora cluster, got %d and %d", summary.TotalInstances, summary.AuroraCount)
+	}
+
+	clusterIDs := make(map[string]string)
+	for _, instance := range summary.Instances {
+		clusterIDs[instance.InstanceID] = instance.ClusterID
+	}
+	if clusterIDs["signon-postgres"] != "" {
+		t.Errorf("Expected standalone instance without a cluster, got %q", clusterIDs["signon-postgres"])
+	}
+	for _, instanceID := range []string{"content-store-aurora-1", "content-store-aurora-2"} {
+		if clusterIDs[instanceID] != "content-store-aurora" {
+			t.Errorf("Expected %s linked to content-store-aurora, got %q", instanceID, clusterIDs[instanceID])
+		}
+	}
+
+	if empty := s.generateInstancesSummary(nil, nil); empty.AuroraClusters == nil || empty.AuroraCount != 0 {
+		t.Errorf("Expected an empty Aurora cluster list, got %+v", empty.AuroraClusters)
+	}
+}
+
+// newAccountClient returns a client for a fake account with one PostgreSQL
+// instance, named instanceID, and no clusters
+func newAccountClient(t *testing.T, log *logger.Logger, accountID, instanceID string) *awsclient.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch r.Form.Get("Action") {
+		case "GetCallerIdentity":
+			w.Write([]byte(`<GetCallerIdentityResponse><GetCallerIdentityResult>
+				<Account>` + accountID + `</Account>
+			</GetCallerIdentityResult></GetCallerIdentityResponse>`))
+		case "DescribeDBInstances":
+			w.Write([]byte(`<DescribeDBInstancesResponse><DescribeDBInstancesResult><DBInstances>
+				<DBInstance>
+					<DBInstanceIdentifier>` + instanceID + `</DBInstanceIdentifier>
+					<Engine>postgres</Engine>
+					<EngineVersion>16.3</EngineVersion>
+					<DBInstanceClass>db.t3.medium</DBInstanceClass>
+					<DBInstanceStatus>available</DBInstanceStatus>
+				</DBInstance>
+			</DBInstances></DescribeDBInstancesResult></DescribeDBInstancesResponse>`))
+		case "DescribeDBClusters":
+			w.Write([]byte(`<DescribeDBClustersResponse><DescribeDBClustersResult><DBClusters/></DescribeDBClustersResult></DescribeDBClustersResponse>`))
+		default:
+			t.Errorf("Unexpected action %q", r.Form.Get("Action"))
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := awsclient.NewClientWithCostExplorer(aws.Config{
+		Region:       "eu-west-2",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}, nil, log)
+	client.NewServiceClient(awsclient.ServiceSTS).(*awsclient.QueryAPIClient).WithEndpoint(server.URL)
+	if err := client.ResolveAccountID(context.Background()); err != nil {
+		t.Fatalf("ResolveAccountID failed: %v", err)
+	}
+	return client
+}
+
+func TestMultiAccountRDSService_GetAllInstances(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	s := NewMultiAccountRDSService([]*awsclient.Client{
+		newAccountClient(t, log, "111111111111", "publishing-api-postgres"),
+		newAccountClient(t, log, "222222222222", "content-store-postgres"),
+	}, &config.Config{}, log)
+
+	summary, err := s.GetAllInstances(context.Background())
+	if err != nil {
+		t.Fatalf("GetAllInstances failed: %v", err)
+	}
+
+	if summary.TotalInstances != 2 {
+		t.Fatalf("Expected instances from both accounts, got %d", summary.TotalInstances)
+	}
+	accountIDs := make(map[string]string)
+	for _, instance := range summary.Instances {
+		accountIDs[instance.InstanceID] = instance.AccountID
+	}
+	if accountIDs["publishing-api-postgres"] != "111111111111" || accountIDs["content-store-postgres"] != "222222222222" {
+		t.Errorf("Expected instances tagged with their accounts, got %v", accountIDs)
+	}
+}
+
+func TestGetSnapshotCosts_ManualInstanceSnapshots(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+
+	var snapshotType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch r.Form.Get("Action") {
+		case "DescribeDBSnapshots":
+			snapshotType = r.Form.Get("SnapshotType")
+			w.Write([]byte(`<DescribeDBSnapshotsResponse><DescribeDBSnapshotsResult><DBSnapshots>
+				<DBSnapshot>
+					<DBSnapshotIdentifier>before-upgrade</DBSnapshotIdentifier>
+					<DBInstanceIdentifier>retired-postgres</DBInstanceIdentifier>
+					<SnapshotType>manual</SnapshotType>
+					<AllocatedStorage>100</AllocatedStorage>
+				</DBSnapshot>
+			</DBSnapshots></DescribeDBSnapshotsResult></DescribeDBSnapshotsResponse>`))
+		case "DescribeDBClusterSnapshots":
+			w.Write([]byte(`<DescribeDBClusterSnapshotsResponse><DescribeDBClusterSnapshotsResult><DBClusterSnapshots/></DescribeDBClusterSnapshotsResult></DescribeDBClusterSnapshotsResponse>`))
+		case "DescribeDBInstances":
+			w.Write([]byte(`<DescribeDBInstancesResponse><DescribeDBInstancesResult><DBInstances/></DescribeDBInstancesResult></DescribeDBInstancesResponse>`))
+		case "DescribeDBClusters":
+			w.Write([]byte(`<DescribeDBClustersResponse><DescribeDBClustersResult><DBClusters/></DescribeDBClustersResult></DescribeDBClustersResponse>`))
+		default:
+			t.Errorf("Unexpected action %q", r.Form.Get("Action"))
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client := awsclient.NewClientWithCostExplorer(aws.Config{
+		Region:       "eu-west-2",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}, nil, log)
+
+	report, err := NewRDSService(client, &config.Config{}, log).GetSnapshotCosts(context.Background())
+	if err != nil {
+		t.Fatalf("GetSnapshotCosts failed: %v", err)
+	}
+
+	if snapshotType != "manual" {
+		t.Errorf("Expected only manual instance snapshots to be requested, got SnapshotType %q", snapshotType)
+	}
+	if report.TotalSnapshots != 1 || report.TotalStorageGB != 100 || len(report.OrphanedSnapshots) != 1 {
+		t.Errorf("Expected one orphaned 100GB snapshot, got %+v", report)
+	}
+}
@@ -0,0 +1,89 @@
+package s3
+
+import (
+	"net/http"
+
+	"govuk-reports-dashboard/internal/handlers"
+	"govuk-reports-dashboard/internal/models"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type S3Handler struct {
+	s3Service *S3Service
+	logger    *logger.Logger
+}
+
+func NewS3Handler(s3Service *S3Service, logger *logger.Logger) *S3Handler {
+	return &S3Handler{
+		s3Service: s3Service,
+		logger:    logger,
+	}
+}
+
+// GetBuckets handles GET /api/s3/buckets
+func (h *S3Handler) GetBuckets(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for S3 buckets")
+
+	buckets, err := h.s3Service.GetAllBuckets(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to get S3 buckets")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get S3 buckets",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	log.WithField("bucket_count", len(buckets)).Info().Msg("Successfully fetched S3 buckets")
+	c.JSON(http.StatusOK, gin.H{
+		"buckets": buckets,
+		"count":   len(buckets),
+	})
+}
+
+// GetSummary handles GET /api/s3/summary
+func (h *S3Handler) GetSummary(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for S3 summary")
+
+	summary, err := h.s3Service.GetSummary(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to get S3 summary")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get S3 summary",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	log.WithField("bucket_count", summary.TotalBuckets).Info().Msg("Successfully summarised S3 buckets")
+	c.JSON(http.StatusOK, summary)
+}
+
+// GetCompliance handles GET /api/s3/compliance
+func (h *S3Handler) GetCompliance(c *gin.Context) {
+	log := h.logger.WithRequestID(handlers.GetRequestID(c))
+	log.Info().Msg("Handling request for S3 bucket compliance")
+
+	items, err := h.s3Service.GetComplianceReport(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error().Msg("Failed to get S3 bucket compliance")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get S3 bucket compliance",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	log.WithField("non_compliant_count", len(items)).Info().Msg("Successfully checked S3 bucket compliance")
+	c.JSON(http.StatusOK, gin.H{
+		"buckets":       items,
+		"non_compliant": len(items),
+	})
+}
@@ -0,0 +1,45 @@
+package s3
+
+import (
+	"time"
+)
+
+// BucketSummary is an S3 bucket's storage settings and estimated cost
+type BucketSummary struct {
+	Name               string            `json:"name"`
+	Region             string            `json:"region"`
+	CreationDate       *time.Time        `json:"creation_date,omitempty"`
+	EncryptionEnabled  bool              `json:"encryption_enabled"`
+	EncryptionType     string            `json:"encryption_type,omitempty"`
+	VersioningEnabled  bool              `json:"versioning_enabled"`
+	LoggingEnabled     bool              `json:"logging_enabled"`
+	HasLifecyclePolicy bool              `json:"has_lifecycle_policy"`
+	Tags               map[string]string `json:"tags"`
+	Application        string            `json:"application"`
+
+	// EstimatedMonthlyCost is last month's S3 cost for the bucket's system
+	// tag, shared equally between the buckets with that tag
+	EstimatedMonthlyCost float64 `json:"estimated_monthly_cost"`
+	Currency             string  `json:"currency,omitempty"`
+
+	IsCompliant bool     `json:"is_compliant"`
+	Issues      []string `json:"issues,omitempty"`
+
+	// CheckErrors are the settings that couldn't be read, such as when the
+	// bucket policy denies access. They aren't counted as issues.
+	CheckErrors []string `json:"check_errors,omitempty"`
+}
+
+// BucketsSummary counts buckets missing each storage setting
+type BucketsSummary struct {
+	TotalBuckets              int       `json:"total_buckets"`
+	CompliantBuckets          int       `json:"compliant_buckets"`
+	UnencryptedBuckets        int       `json:"unencrypted_buckets"`
+	WithoutVersioning         int       `json:"without_versioning"`
+	WithoutLogging            int       `json:"without_logging"`
+	WithoutLifecyclePolicy    int       `json:"without_lifecycle_policy"`
+	UncheckedBuckets          int       `json:"unchecked_buckets"`
+	TotalEstimatedMonthlyCost float64   `json:"total_estimated_monthly_cost"`
+	Currency                  string    `json:"currency,omitempty"`
+	LastUpdated               time.Time `json:"last_updated"`
+}
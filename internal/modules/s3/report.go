@@ -0,0 +1,212 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"govuk-reports-dashboard/internal/reports"
+	"govuk-reports-dashboard/pkg/logger"
+)
+
+type S3Report struct {
+	s3Service *S3Service
+	renderer  *reports.Renderer
+	logger    *logger.Logger
+}
+
+func NewS3Report(s3Service *S3Service, logger *logger.Logger) *S3Report {
+	return &S3Report{
+		s3Service: s3Service,
+		renderer:  reports.NewRenderer(),
+		logger:    logger,
+	}
+}
+
+func (r *S3Report) GetMetadata() reports.ReportMetadata {
+	return reports.ReportMetadata{
+		ID:          "s3",
+		Name:        "S3 bucket report",
+		Description: "S3 bucket storage costs and encryption, versioning, logging and lifecycle compliance",
+		Type:        reports.ReportTypeHealth,
+		Version:     "1.0.0",
+		Author:      "GOV.UK Platform Team",
+		Tags:        []string{"s3", "storage", "compliance", "costs"},
+		Priority:    reports.PriorityMedium,
+	}
+}
+
+func (r *S3Report) GenerateSummary(ctx context.Context, params reports.ReportParams) ([]reports.Summary, error) {
+	r.logger.Info().Msg("Generating S3 summary for dashboard")
+
+	buckets, err := r.s3Service.GetAllBuckets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get S3 buckets: %w", err)
+	}
+
+	return r.generateSummaries(summariseBuckets(buckets)), nil
+}
+
+func (r *S3Report) GenerateReport(ctx context.Context, params reports.ReportParams) (reports.ReportData, error) {
+	r.logger.Info().Msg("Generating detailed S3 report")
+
+	data := reports.ReportData{
+		Status:      reports.StatusRunning,
+		GeneratedAt: time.Now(),
+	}
+
+	buckets, err := r.s3Service.GetAllBuckets(ctx)
+	if err != nil {
+		data.Status = reports.StatusFailed
+		data.Errors = append(data.Errors, reports.ReportError{
+			Code:      "S3_FETCH_ERROR",
+			Message:   "Failed to fetch S3 buckets",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+		})
+		return data, nil
+	}
+
+	summary := summariseBuckets(buckets)
+	data.Summary = r.generateSummaries(summary)
+	data.DataPoints = r.generateDataPoints(buckets)
+	data.Tables = r.generateTables(buckets)
+
+	if summary.UncheckedBuckets > 0 {
+		data.Warnings = append(data.Warnings, reports.ReportWarning{
+			Code:      "S3_CHECK_WARNING",
+			Message:   fmt.Sprintf("Settings of %d buckets couldn't be read", summary.UncheckedBuckets),
+			Details:   "See check_errors on each bucket from /api/s3/buckets",
+			Timestamp: time.Now(),
+		})
+	}
+
+	data.Status = reports.StatusCompleted
+	r.logger.WithFields(map[string]interface{}{
+		"data_points": len(data.DataPoints),
+		"tables":      len(data.Tables),
+	}).Info().Msg("Generated detailed S3 report")
+
+	return data, nil
+}
+
+func (r *S3Report) IsAvailable(ctx context.Context) bool {
+	return r.s3Service.IsAvailable(ctx) == nil
+}
+
+// GetRefreshInterval returns how often this report should be refreshed
+func (r *S3Report) GetRefreshInterval() time.Duration {
+	return 1 * time.Hour // Bucket settings rarely change and each bucket takes five API calls
+}
+
+// Validate checks if the provided parameters are valid for this report
+func (r *S3Report) Validate(params reports.ReportParams) error {
+	// S3 reports don't have specific parameter requirements currently
+	return nil
+}
+
+// generateSummaries creates the bucket count and compliance summary cards
+func (r *S3Report) generateSummaries(summary *BucketsSummary) []reports.Summary {
+	var summaries []reports.Summary
+
+	totalSubtitle := "Estimated monthly cost unavailable"
+	if summary.Currency != "" {
+		totalSubtitle = "Estimated monthly cost " + r.renderer.FormatCurrency(summary.TotalEstimatedMonthlyCost, summary.Currency)
+	}
+	summaries = append(summaries, r.renderer.CreateSummaryCard(
+		"Total Buckets",
+		r.renderer.FormatNumber(summary.TotalBuckets),
+		totalSubtitle,
+		reports.SummaryTypeCount,
+		nil,
+	))
+
+	for _, card := range []struct {
+		title    string
+		count    int
+		subtitle string
+	}{
+		{"Unencrypted Buckets", summary.UnencryptedBuckets, "Buckets without default encryption"},
+		{"Buckets Without Versioning", summary.WithoutVersioning, "Buckets that can't recover overwritten objects"},
+		{"Buckets Without Lifecycle Policies", summary.WithoutLifecyclePolicy, "Buckets keeping every object indefinitely"},
+	} {
+		cardSummary := r.renderer.CreateSummaryCard(
+			card.title,
+			r.renderer.FormatNumber(card.count),
+			card.subtitle,
+			reports.SummaryTypeAlert,
+			nil,
+		)
+		if card.count > 0 {
+			cardSummary.(*reports.BasicSummary).SetHealthy(false)
+		}
+		summaries = append(summaries, cardSummary)
+	}
+
+	return summaries
+}
+
+func (r *S3Report) generateDataPoints(buckets []BucketSummary) []reports.DataPoint {
+	var dataPoints []reports.DataPoint
+	now := time.Now()
+
+	for _, bucket := range buckets {
+		dataPoints = append(dataPoints, reports.DataPoint{
+			Timestamp: now,
+			Labels: map[string]string{
+				"type":        "s3_bucket",
+				"bucket":      bucket.Name,
+				"region":      bucket.Region,
+				"application": bucket.Application,
+			},
+			Values: map[string]interface{}{
+				"encryption_enabled":     bucket.EncryptionEnabled,
+				"versioning_enabled":     bucket.VersioningEnabled,
+				"logging_enabled":        bucket.LoggingEnabled,
+				"has_lifecycle_policy":   bucket.HasLifecyclePolicy,
+				"estimated_monthly_cost": bucket.EstimatedMonthlyCost,
+				"is_compliant":           bucket.IsCompliant,
+			},
+		})
+	}
+
+	return dataPoints
+}
+
+func (r *S3Report) generateTables(buckets []BucketSummary) []reports.TableData {
+	bucketsTable := reports.TableData{
+		Title: "S3 Buckets",
+		Headers: []reports.TableHeader{
+			{Key: "name", Label: "Bucket", Type: "string", Sortable: true, Filterable: true},
+			{Key: "region", Label: "Region", Type: "string", Sortable: true, Filterable: true},
+			{Key: "application", Label: "Application", Type: "string", Sortable: true, Filterable: true},
+			{Key: "encryption", Label: "Encryption", Type: "string", Sortable: true, Filterable: true},
+			{Key: "versioning", Label: "Versioning", Type: "boolean", Sortable: true, Filterable: true},
+			{Key: "logging", Label: "Access Logging", Type: "boolean", Sortable: true, Filterable: true},
+			{Key: "lifecycle_policy", Label: "Lifecycle Policy", Type: "boolean", Sortable: true, Filterable: true},
+			{Key: "estimated_monthly_cost", Label: "Estimated Monthly Cost", Type: "currency", Sortable: true, Filterable: false},
+			{Key: "issues", Label: "Issues", Type: "string", Sortable: false, Filterable: true},
+		},
+	}
+
+	for _, bucket := range buckets {
+		encryption := bucket.EncryptionType
+		if !bucket.EncryptionEnabled {
+			encryption = "None"
+		}
+		bucketsTable.Rows = append(bucketsTable.Rows, map[string]interface{}{
+			"name":                   bucket.Name,
+			"region":                 bucket.Region,
+			"application":            bucket.Application,
+			"encryption":             encryption,
+			"versioning":             bucket.VersioningEnabled,
+			"logging":                bucket.LoggingEnabled,
+			"lifecycle_policy":       bucket.HasLifecyclePolicy,
+			"estimated_monthly_cost": bucket.EstimatedMonthlyCost,
+			"issues":                 strings.Join(bucket.Issues, "; "),
+		})
+	}
+
+	return []reports.TableData{bucketsTable}
+}
@@ -0,0 +1,44 @@
+package s3
+
+import (
+	"testing"
+
+	"govuk-reports-dashboard/pkg/logger"
+)
+
+func TestS3Report_GenerateSummaries(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	report := NewS3Report(nil, log)
+
+	summaries := report.generateSummaries(&BucketsSummary{
+		TotalBuckets:              4,
+		UnencryptedBuckets:        1,
+		WithoutLifecyclePolicy:    2,
+		TotalEstimatedMonthlyCost: 150,
+		Currency:                  "GBP",
+	})
+
+	expected := []struct {
+		title   string
+		value   string
+		healthy bool
+	}{
+		{"Total Buckets", "4", true},
+		{"Unencrypted Buckets", "1", false},
+		{"Buckets Without Versioning", "0", true},
+		{"Buckets Without Lifecycle Policies", "2", false},
+	}
+	if len(summaries) != len(expected) {
+		t.Fatalf("Expected %d summaries, got %d", len(expected), len(summaries))
+	}
+	for i, want := range expected {
+		summary := summaries[i]
+		if summary.GetTitle() != want.title || summary.GetValue() != want.value || summary.IsHealthy() != want.healthy {
+			t.Errorf("Expected %s %s (healthy %v), got %s %s (healthy %v)",
+				want.title, want.value, want.healthy, summary.GetTitle(), summary.GetValue(), summary.IsHealthy())
+		}
+	}
+	if summaries[0].GetSubtitle() == "Estimated monthly cost unavailable" {
+		t.Error("Expected the estimated monthly cost in the total buckets subtitle")
+	}
+}
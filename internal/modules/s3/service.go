@@ -0,0 +1,331 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"govuk-reports-dashboard/internal/config"
+	awsclient "govuk-reports-dashboard/pkg/aws"
+	"govuk-reports-dashboard/pkg/logger"
+	"govuk-reports-dashboard/pkg/tracing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+// BucketWorkers is how many buckets have their settings read at once. Each
+// bucket takes five API calls.
+const BucketWorkers = 10
+
+// Issues reported for buckets missing a storage setting
+const (
+	IssueNotEncrypted      = "Default encryption is not enabled"
+	IssueNoVersioning      = "Versioning is not enabled"
+	IssueNoLogging         = "Server access logging is not enabled"
+	IssueNoLifecyclePolicy = "No lifecycle policy"
+)
+
+// Error codes S3 returns when a bucket has no configuration of a kind
+var notConfiguredErrorCodes = map[string]bool{
+	"ServerSideEncryptionConfigurationNotFoundError": true,
+	"NoSuchLifecycleConfiguration":                   true,
+	"NoSuchTagSet":                                   true,
+}
+
+type S3Service struct {
+	client    *s3.Client
+	awsClient *awsclient.Client
+	config    *config.Config
+	logger    *logger.Logger
+}
+
+// NewS3Service creates a new S3 service instance using the AWS client's
+// shared S3 client
+func NewS3Service(awsClient *awsclient.Client, config *config.Config, logger *logger.Logger) *S3Service {
+	return &S3Service{
+		client:    awsClient.NewServiceClient(awsclient.ServiceS3).(*s3.Client),
+		awsClient: awsClient,
+		config:    config,
+		logger:    logger,
+	}
+}
+
+// GetAllBuckets lists every bucket in the account with its encryption,
+// versioning, logging, lifecycle and tag settings, and an estimated cost
+func (s *S3Service) GetAllBuckets(ctx context.Context) ([]BucketSummary, error) {
+	ctx, span := tracing.Start(ctx, "s3.get_all_buckets")
+	defer span.End()
+
+	s.logger.Info().Msg("Discovering S3 buckets")
+
+	var buckets []types.Bucket
+	// Bucket regions are only returned when a parameter is set
+	paginator := s3.NewListBucketsPaginator(s.client, &s3.ListBucketsInput{MaxBuckets: aws.Int32(1000)})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list S3 buckets: %w", err)
+		}
+		buckets = append(buckets, page.Buckets...)
+	}
+
+	summaries := make([]BucketSummary, len(buckets))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < min(BucketWorkers, len(buckets)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				summaries[index] = s.inspectBucket(ctx, buckets[index])
+			}
+		}()
+	}
+	for i := range buckets {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.addEstimatedCosts(ctx, summaries)
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Name < summaries[j].Name
+	})
+
+	s.logger.WithField("bucket_count", len(summaries)).Info().Msg("S3 buckets discovered")
+
+	return summaries, nil
+}
+
+// GetSummary counts the buckets missing each storage setting
+func (s *S3Service) GetSummary(ctx context.Context) (*BucketsSummary, error) {
+	ctx, span := tracing.Start(ctx, "s3.get_summary")
+	defer span.End()
+
+	buckets, err := s.GetAllBuckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return summariseBuckets(buckets), nil
+}
+
+// GetComplianceReport returns the buckets missing encryption, versioning,
+// access logging or a lifecycle policy
+func (s *S3Service) GetComplianceReport(ctx context.Context) ([]BucketSummary, error) {
+	ctx, span := tracing.Start(ctx, "s3.get_compliance_report")
+	defer span.End()
+
+	s.logger.Info().Msg("Checking S3 bucket compliance")
+
+	buckets, err := s.GetAllBuckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []BucketSummary
+	for _, bucket := range buckets {
+		if !bucket.IsCompliant {
+			items = append(items, bucket)
+		}
+	}
+
+	return items, nil
+}
+
+// IsAvailable checks the buckets can be listed
+func (s *S3Service) IsAvailable(ctx context.Context) error {
+	_, err := s.client.ListBuckets(ctx, &s3.ListBucketsInput{MaxBuckets: aws.Int32(1)})
+	return err
+}
+
+// inspectBucket reads a bucket's settings and lists those it is missing.
+// Settings that can't be read are recorded in CheckErrors rather than
+// failing the whole listing, and aren't reported as missing.
+func (s *S3Service) inspectBucket(ctx context.Context, bucket types.Bucket) BucketSummary {
+	summary := BucketSummary{
+		Name:         aws.ToString(bucket.Name),
+		Region:       aws.ToString(bucket.BucketRegion),
+		CreationDate: bucket.CreationDate,
+		Tags:         make(map[string]string),
+	}
+	if summary.Region == "" {
+		summary.Region = s.client.Options().Region
+	}
+
+	// Requests must be sent to the bucket's own region
+	inRegion := func(o *s3.Options) {
+		o.Region = summary.Region
+	}
+	bucketName := aws.String(summary.Name)
+
+	checkFailed := func(setting string, err error) {
+		s.logger.WithError(err).WithFields(map[string]interface{}{
+			"bucket":  summary.Name,
+			"setting": setting,
+		}).Warn().Msg("Failed to read S3 bucket setting")
+		summary.CheckErrors = append(summary.CheckErrors, fmt.Sprintf("%s: %v", setting, err))
+	}
+
+	encryption, err := s.client.GetBucketEncryption(ctx, &s3.GetBucketEncryptionInput{Bucket: bucketName}, inRegion)
+	switch {
+	case err == nil:
+		if encryption.ServerSideEncryptionConfiguration != nil {
+			for _, rule := range encryption.ServerSideEncryptionConfiguration.Rules {
+				if rule.ApplyServerSideEncryptionByDefault != nil {
+					summary.EncryptionEnabled = true
+					summary.EncryptionType = string(rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm)
+					break
+				}
+			}
+		}
+		if !summary.EncryptionEnabled {
+			summary.Issues = append(summary.Issues, IssueNotEncrypted)
+		}
+	case isNotConfigured(err):
+		summary.Issues = append(summary.Issues, IssueNotEncrypted)
+	default:
+		checkFailed("encryption", err)
+	}
+
+	versioning, err := s.client.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{Bucket: bucketName}, inRegion)
+	if err != nil {
+		checkFailed("versioning", err)
+	} else {
+		summary.VersioningEnabled = versioning.Status == types.BucketVersioningStatusEnabled
+		if !summary.VersioningEnabled {
+			summary.Issues = append(summary.Issues, IssueNoVersioning)
+		}
+	}
+
+	logging, err := s.client.GetBucketLogging(ctx, &s3.GetBucketLoggingInput{Bucket: bucketName}, inRegion)
+	if err != nil {
+		checkFailed("logging", err)
+	} else {
+		summary.LoggingEnabled = logging.LoggingEnabled != nil
+		if !summary.LoggingEnabled {
+			summary.Issues = append(summary.Issues, IssueNoLogging)
+		}
+	}
+
+	lifecycle, err := s.client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{Bucket: bucketName}, inRegion)
+	switch {
+	case err == nil:
+		for _, rule := range lifecycle.Rules {
+			if rule.Status == types.ExpirationStatusEnabled {
+				summary.HasLifecyclePolicy = true
+				break
+			}
+		}
+		if !summary.HasLifecyclePolicy {
+			summary.Issues = append(summary.Issues, IssueNoLifecyclePolicy)
+		}
+	case isNotConfigured(err):
+		summary.Issues = append(summary.Issues, IssueNoLifecyclePolicy)
+	default:
+		checkFailed("lifecycle", err)
+	}
+
+	tagging, err := s.client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{Bucket: bucketName}, inRegion)
+	switch {
+	case err == nil:
+		for _, tag := range tagging.TagSet {
+			summary.Tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+		summary.Application = summary.Tags["system"]
+	case !isNotConfigured(err):
+		checkFailed("tags", err)
+	}
+
+	summary.IsCompliant = len(summary.Issues) == 0
+	return summary
+}
+
+// addEstimatedCosts shares last month's S3 cost for each system tag equally
+// between the buckets with that tag. Buckets without a system tag have no
+// estimate. Buckets are still returned if costs can't be fetched.
+func (s *S3Service) addEstimatedCosts(ctx context.Context, buckets []BucketSummary) {
+	endDate := time.Now()
+	startDate := endDate.AddDate(0, -1, 0)
+
+	costData, err := s.awsClient.GetS3CostsBySystemTag(ctx, startDate, endDate)
+	if err != nil {
+		s.logger.WithError(err).Warn().Msg("Failed to get S3 costs, buckets will have no cost estimates")
+		return
+	}
+
+	costBySystem := make(map[string]float64)
+	for _, cost := range costData {
+		costBySystem[cost.Service] += cost.Amount
+	}
+
+	bucketsBySystem := make(map[string]int)
+	for _, bucket := range buckets {
+		if bucket.Application != "" {
+			bucketsBySystem[bucket.Application] += 1
+		}
+	}
+
+	currency := s.awsClient.ReportingCurrency()
+	for i := range buckets {
+		system := buckets[i].Application
+		if system == "" {
+			continue
+		}
+		buckets[i].EstimatedMonthlyCost = costBySystem[system] / float64(bucketsBySystem[system])
+		buckets[i].Currency = currency
+	}
+}
+
+// summariseBuckets counts the buckets missing each storage setting
+func summariseBuckets(buckets []BucketSummary) *BucketsSummary {
+	summary := &BucketsSummary{
+		TotalBuckets: len(buckets),
+		LastUpdated:  time.Now(),
+	}
+
+	for _, bucket := range buckets {
+		if bucket.IsCompliant {
+			summary.CompliantBuckets += 1
+		}
+		if len(bucket.CheckErrors) > 0 {
+			summary.UncheckedBuckets += 1
+		}
+		for _, issue := range bucket.Issues {
+			switch issue {
+			case IssueNotEncrypted:
+				summary.UnencryptedBuckets += 1
+			case IssueNoVersioning:
+				summary.WithoutVersioning += 1
+			case IssueNoLogging:
+				summary.WithoutLogging += 1
+			case IssueNoLifecyclePolicy:
+				summary.WithoutLifecyclePolicy += 1
+			}
+		}
+		summary.TotalEstimatedMonthlyCost += bucket.EstimatedMonthlyCost
+		if bucket.Currency != "" {
+			summary.Currency = bucket.Currency
+		}
+	}
+
+	return summary
+}
+
+// isNotConfigured reports whether err means the bucket has no configuration
+// of the kind requested
+func isNotConfigured(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && notConfiguredErrorCodes[apiErr.ErrorCode()]
+}
@@ -0,0 +1,198 @@
+package s3
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"govuk-reports-dashboard/internal/config"
+	awsclient "govuk-reports-dashboard/pkg/aws"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+)
+
+// stubCostExplorer returns a month of S3 costs by system tag
+type stubCostExplorer struct {
+	awsclient.CostExplorerAPI
+}
+
+func (s *stubCostExplorer) GetCostAndUsage(ctx context.Context, params *costexplorer.GetCostAndUsageInput, optFns ...func(*costexplorer.Options)) (*costexplorer.GetCostAndUsageOutput, error) {
+	return &costexplorer.GetCostAndUsageOutput{
+		ResultsByTime: []types.ResultByTime{
+			{
+				TimePeriod: params.TimePeriod,
+				Groups: []types.Group{
+					{
+						Keys:    []string{"system$govuk-asset-manager"},
+						Metrics: map[string]types.MetricValue{"BlendedCost": {Amount: aws.String("90"), Unit: aws.String("GBP")}},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// bucketResponses are the S3 API responses for each bucket, keyed by the
+// configuration requested. Errors are given as a status and S3 error code.
+var bucketResponses = map[string]map[string]string{
+	"govuk-assets": {
+		"encryption": `<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>aws:kms</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`,
+		"versioning": `<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>`,
+		"logging":    `<BucketLoggingStatus><LoggingEnabled><TargetBucket>govuk-logs</TargetBucket><TargetPrefix>assets/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`,
+		"lifecycle":  `<LifecycleConfiguration><Rule><ID>expire</ID><Status>Enabled</Status><Filter><Prefix></Prefix></Filter><Expiration><Days>30</Days></Expiration></Rule></LifecycleConfiguration>`,
+		"tagging":    `<Tagging><TagSet><Tag><Key>system</Key><Value>govuk-asset-manager</Value></Tag></TagSet></Tagging>`,
+	},
+	"govuk-attachments": {
+		"encryption": "404 ServerSideEncryptionConfigurationNotFoundError",
+		"versioning": `<VersioningConfiguration><Status>Suspended</Status></VersioningConfiguration>`,
+		"logging":    `<BucketLoggingStatus></BucketLoggingStatus>`,
+		"lifecycle":  "404 NoSuchLifecycleConfiguration",
+		"tagging":    `<Tagging><TagSet><Tag><Key>system</Key><Value>govuk-asset-manager</Value></Tag></TagSet></Tagging>`,
+	},
+	"govuk-private": {
+		"encryption": "403 AccessDenied",
+		"versioning": `<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>`,
+		"logging":    `<BucketLoggingStatus></BucketLoggingStatus>`,
+		"lifecycle":  "404 NoSuchLifecycleConfiguration",
+		"tagging":    "404 NoSuchTagSet",
+	},
+}
+
+func newTestS3Service(t *testing.T) (*S3Service, *int64) {
+	t.Helper()
+
+	var requests int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&requests, 1)
+		bucket := strings.Trim(r.URL.Path, "/")
+		if bucket == "" {
+			w.Write([]byte(`<ListAllMyBucketsResult><Buckets>
+				<Bucket><Name>govuk-private</Name><BucketRegion>eu-west-1</BucketRegion></Bucket>
+				<Bucket><Name>govuk-assets</Name><BucketRegion>eu-west-2</BucketRegion></Bucket>
+				<Bucket><Name>govuk-attachments</Name><BucketRegion>eu-west-2</BucketRegion></Bucket>
+			</Buckets></ListAllMyBucketsResult>`))
+			return
+		}
+
+		for configuration, response := range bucketResponses[bucket] {
+			if !r.URL.Query().Has(configuration) {
+				continue
+			}
+			if !strings.HasPrefix(response, "<") {
+				status, code, _ := strings.Cut(response, " ")
+				statusCode, _ := strconv.Atoi(status)
+				w.WriteHeader(statusCode)
+				w.Write([]byte(`<Error><Code>` + code + `</Code><Message>` + code + `</Message></Error>`))
+				return
+			}
+			w.Write([]byte(response))
+			return
+		}
+
+		t.Errorf("Unexpected request %s", r.URL)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	t.Cleanup(server.Close)
+
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	awsClient := awsclient.NewClientWithCostExplorer(aws.Config{
+		Region:       "eu-west-2",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}, &stubCostExplorer{}, log)
+
+	return NewS3Service(awsClient, &config.Config{}, log), &requests
+}
+
+func TestS3Service_GetAllBuckets(t *testing.T) {
+	service, requests := newTestS3Service(t)
+
+	buckets, err := service.GetAllBuckets(context.Background())
+	if err != nil {
+		t.Fatalf("GetAllBuckets failed: %v", err)
+	}
+
+	if len(buckets) != 3 {
+		t.Fatalf("Expected 3 buckets, got %d", len(buckets))
+	}
+	// One listing and five configuration requests per bucket
+	if *requests != 16 {
+		t.Errorf("Expected 16 requests, got %d", *requests)
+	}
+
+	assets, attachments, private := buckets[0], buckets[1], buckets[2]
+	if assets.Name != "govuk-assets" || attachments.Name != "govuk-attachments" || private.Name != "govuk-private" {
+		t.Fatalf("Expected buckets sorted by name, got %s, %s, %s", assets.Name, attachments.Name, private.Name)
+	}
+
+	if !assets.EncryptionEnabled || assets.EncryptionType != "aws:kms" || !assets.VersioningEnabled || !assets.LoggingEnabled || !assets.HasLifecyclePolicy {
+		t.Errorf("Expected every setting enabled on govuk-assets, got %+v", assets)
+	}
+	if !assets.IsCompliant || len(assets.Issues) != 0 {
+		t.Errorf("Expected govuk-assets to be compliant, got issues %v", assets.Issues)
+	}
+
+	if attachments.IsCompliant {
+		t.Error("Expected govuk-attachments not to be compliant")
+	}
+	for _, issue := range []string{IssueNotEncrypted, IssueNoVersioning, IssueNoLogging, IssueNoLifecyclePolicy} {
+		if !slices.Contains(attachments.Issues, issue) {
+			t.Errorf("Expected issue %q on govuk-attachments, got %v", issue, attachments.Issues)
+		}
+	}
+
+	// The asset manager's S3 cost is shared between its two buckets
+	if assets.EstimatedMonthlyCost != 45 || attachments.EstimatedMonthlyCost != 45 {
+		t.Errorf("Expected 45 each for the asset manager buckets, got %v and %v", assets.EstimatedMonthlyCost, attachments.EstimatedMonthlyCost)
+	}
+	if assets.Currency != "GBP" || assets.Application != "govuk-asset-manager" {
+		t.Errorf("Expected GBP costs for govuk-asset-manager, got %q for %q", assets.Currency, assets.Application)
+	}
+	if private.EstimatedMonthlyCost != 0 {
+		t.Errorf("Expected no cost estimate for an untagged bucket, got %v", private.EstimatedMonthlyCost)
+	}
+
+	// Encryption couldn't be checked, so it isn't reported as missing
+	if private.Region != "eu-west-1" {
+		t.Errorf("Expected region eu-west-1, got %q", private.Region)
+	}
+	if len(private.CheckErrors) != 1 || !strings.HasPrefix(private.CheckErrors[0], "encryption:") {
+		t.Errorf("Expected an encryption check error, got %v", private.CheckErrors)
+	}
+	if slices.Contains(private.Issues, IssueNotEncrypted) {
+		t.Errorf("Expected unreadable encryption not to be an issue, got %v", private.Issues)
+	}
+	if !slices.Equal(private.Issues, []string{IssueNoLogging, IssueNoLifecyclePolicy}) {
+		t.Errorf("Expected logging and lifecycle issues, got %v", private.Issues)
+	}
+}
+
+func TestSummariseBuckets(t *testing.T) {
+	summary := summariseBuckets([]BucketSummary{
+		{Name: "compliant", IsCompliant: true, EstimatedMonthlyCost: 10, Currency: "GBP"},
+		{Name: "unencrypted", Issues: []string{IssueNotEncrypted, IssueNoLifecyclePolicy}, EstimatedMonthlyCost: 5, Currency: "GBP"},
+		{Name: "unchecked", Issues: []string{IssueNoVersioning}, CheckErrors: []string{"encryption: AccessDenied"}},
+	})
+
+	if summary.TotalBuckets != 3 || summary.CompliantBuckets != 1 {
+		t.Errorf("Expected 1 of 3 buckets compliant, got %d of %d", summary.CompliantBuckets, summary.TotalBuckets)
+	}
+	if summary.UnencryptedBuckets != 1 || summary.WithoutVersioning != 1 || summary.WithoutLifecyclePolicy != 1 || summary.WithoutLogging != 0 {
+		t.Errorf("Unexpected issue counts %+v", summary)
+	}
+	if summary.UncheckedBuckets != 1 {
+		t.Errorf("Expected 1 unchecked bucket, got %d", summary.UncheckedBuckets)
+	}
+	if summary.TotalEstimatedMonthlyCost != 15 || summary.Currency != "GBP" {
+		t.Errorf("Expected a total of GBP 15, got %s %v", summary.Currency, summary.TotalEstimatedMonthlyCost)
+	}
+}
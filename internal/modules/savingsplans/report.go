@@ -0,0 +1,241 @@
+package savingsplans
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"govuk-reports-dashboard/internal/reports"
+	"govuk-reports-dashboard/pkg/aws"
+	"govuk-reports-dashboard/pkg/logger"
+)
+
+const (
+	// MinUtilizationPercentage is the utilization below which committed
+	// spend is considered wasted
+	MinUtilizationPercentage = 90.0
+	// ExpiryWarningDays is how far ahead of expiry a plan is flagged for renewal
+	ExpiryWarningDays = 30
+)
+
+// SavingsPlansReport implements the reports.Report interface for Savings Plans
+// utilization, coverage and upcoming expiries
+type SavingsPlansReport struct {
+	awsClient *aws.Client
+	renderer  *reports.Renderer
+	logger    *logger.Logger
+}
+
+// NewSavingsPlansReport creates a new Savings Plans report instance
+func NewSavingsPlansReport(awsClient *aws.Client, logger *logger.Logger) *SavingsPlansReport {
+	return &SavingsPlansReport{
+		awsClient: awsClient,
+		renderer:  reports.NewRenderer(),
+		logger:    logger,
+	}
+}
+
+// GetMetadata returns metadata about this report module
+func (s *SavingsPlansReport) GetMetadata() reports.ReportMetadata {
+	return reports.ReportMetadata{
+		ID:          "savings-plans",
+		Name:        "Savings Plans",
+		Description: "Savings Plans utilization, coverage and upcoming expiries",
+		Type:        reports.ReportTypeCost,
+		Version:     "1.0.0",
+		Author:      "GOV.UK Platform Team",
+		Tags:        []string{"savings-plans", "costs", "utilization", "coverage"},
+		Priority:    reports.PriorityMedium,
+	}
+}
+
+// GenerateSummary creates summary data for dashboard display
+func (s *SavingsPlansReport) GenerateSummary(ctx context.Context, params reports.ReportParams) ([]reports.Summary, error) {
+	s.logger.Info().Msg("Generating Savings Plans summary for dashboard")
+
+	coverage, err := s.awsClient.GetSavingsPlansCoverage(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Savings Plans coverage: %w", err)
+	}
+
+	plans, err := s.awsClient.GetSavingsPlansInventory(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Savings Plans inventory: %w", err)
+	}
+
+	var summaries []reports.Summary
+
+	utilizationSummary := s.renderer.CreateSummaryCard(
+		"Savings Plans Utilization",
+		s.renderer.FormatPercentage(coverage.UtilizationPercentage, 1),
+		fmt.Sprintf("Last %d days", aws.SavingsPlansLookbackDays),
+		reports.SummaryTypeHealth,
+		nil,
+	)
+	expiring := expiringPlans(plans, time.Now())
+	if (len(plans) > 0 && coverage.UtilizationPercentage < MinUtilizationPercentage) || len(expiring) > 0 {
+		utilizationSummary.(*reports.BasicSummary).SetHealthy(false)
+	}
+	summaries = append(summaries, utilizationSummary)
+
+	uncoveredSummary := s.renderer.CreateSummaryCard(
+		"Uncovered Spend",
+		s.renderer.FormatCurrency(coverage.UncoveredCost, coverage.Currency),
+		fmt.Sprintf("%s coverage", s.renderer.FormatPercentage(coverage.CoveragePercentage, 1)),
+		reports.SummaryTypeCurrency,
+		nil,
+	)
+	summaries = append(summaries, uncoveredSummary)
+
+	s.logger.WithField("summary_count", len(summaries)).Info().Msg("Generated Savings Plans summaries")
+	return summaries, nil
+}
+
+// GenerateReport creates detailed report data
+func (s *SavingsPlansReport) GenerateReport(ctx context.Context, params reports.ReportParams) (reports.ReportData, error) {
+	s.logger.Info().Msg("Generating detailed Savings Plans report")
+
+	data := reports.ReportData{
+		Status:      reports.StatusRunning,
+		GeneratedAt: time.Now(),
+	}
+
+	coverage, err := s.awsClient.GetSavingsPlansCoverage(ctx)
+	if err != nil {
+		data.Status = reports.StatusFailed
+		data.Errors = append(data.Errors, reports.ReportError{
+			Code:      "SAVINGS_PLANS_COVERAGE_ERROR",
+			Message:   "Failed to fetch Savings Plans coverage",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+		})
+		return data, nil
+	}
+
+	plans, err := s.awsClient.GetSavingsPlansInventory(ctx)
+	if err != nil {
+		data.Warnings = append(data.Warnings, reports.ReportWarning{
+			Code:      "SAVINGS_PLANS_INVENTORY_WARNING",
+			Message:   "Failed to fetch Savings Plans inventory",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+		})
+	}
+
+	data.Summary, err = s.GenerateSummary(ctx, params)
+	if err != nil {
+		data.Warnings = append(data.Warnings, reports.ReportWarning{
+			Code:      "SUMMARY_GENERATION_WARNING",
+			Message:   "Failed to generate summary data",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+		})
+	}
+
+	now := time.Now()
+	data.DataPoints = append(data.DataPoints, reports.DataPoint{
+		Timestamp: now,
+		Labels: map[string]string{
+			"type":   "savings_plans_summary",
+			"source": "aws_cost_explorer",
+		},
+		Values: map[string]interface{}{
+			"utilization_percentage": coverage.UtilizationPercentage,
+			"coverage_percentage":    coverage.CoveragePercentage,
+			"uncovered_cost":         coverage.UncoveredCost,
+			"unused_commitment":      coverage.UnusedCommitment,
+			"active_plans":           len(plans),
+			"expiring_plans":         len(expiringPlans(plans, now)),
+		},
+	})
+
+	data.Tables = s.generateTables(coverage, plans, now)
+
+	data.Status = reports.StatusCompleted
+	s.logger.WithFields(map[string]interface{}{
+		"data_points": len(data.DataPoints),
+		"tables":      len(data.Tables),
+	}).Info().Msg("Generated detailed Savings Plans report")
+
+	return data, nil
+}
+
+// IsAvailable checks if this report can run with current configuration
+func (s *SavingsPlansReport) IsAvailable(ctx context.Context) bool {
+	_, err := s.awsClient.GetSavingsPlansInventory(ctx)
+	return err == nil
+}
+
+// GetRefreshInterval returns how often this report should be refreshed
+func (s *SavingsPlansReport) GetRefreshInterval() time.Duration {
+	return 6 * time.Hour // Cost Explorer data is only updated a few times a day
+}
+
+// Validate checks if the provided parameters are valid for this report
+func (s *SavingsPlansReport) Validate(params reports.ReportParams) error {
+	// Savings Plans reports don't have specific parameter requirements currently
+	return nil
+}
+
+// expiringPlans returns the plans that end within ExpiryWarningDays
+func expiringPlans(plans []aws.SavingsPlan, now time.Time) []aws.SavingsPlan {
+	var expiring []aws.SavingsPlan
+	for _, plan := range plans {
+		if plan.DaysUntilExpiry(now) <= ExpiryWarningDays {
+			expiring = append(expiring, plan)
+		}
+	}
+	return expiring
+}
+
+func (s *SavingsPlansReport) generateTables(coverage aws.SavingsPlansCoverage, plans []aws.SavingsPlan, now time.Time) []reports.TableData {
+	var tables []reports.TableData
+
+	plansTable := reports.TableData{
+		Title: "Active Savings Plans",
+		Headers: []reports.TableHeader{
+			{Key: "id", Label: "Plan ID", Type: "string", Sortable: true, Filterable: true},
+			{Key: "type", Label: "Type", Type: "string", Sortable: true, Filterable: true},
+			{Key: "payment_option", Label: "Payment Option", Type: "string", Sortable: true, Filterable: true},
+			{Key: "commitment", Label: "Hourly Commitment", Type: "currency", Sortable: true, Filterable: false},
+			{Key: "end", Label: "Expires", Type: "date", Sortable: true, Filterable: false},
+			{Key: "days_until_expiry", Label: "Days Until Expiry", Type: "number", Sortable: true, Filterable: false},
+		},
+	}
+
+	for _, plan := range plans {
+		plansTable.Rows = append(plansTable.Rows, map[string]interface{}{
+			"id":                plan.ID,
+			"type":              plan.Type,
+			"payment_option":    plan.PaymentOption,
+			"commitment":        s.renderer.FormatCurrency(plan.Commitment, plan.Currency),
+			"end":               plan.End.Format("2006-01-02"),
+			"days_until_expiry": plan.DaysUntilExpiry(now),
+		})
+	}
+
+	tables = append(tables, plansTable)
+
+	coverageTable := reports.TableData{
+		Title: "Coverage by Service",
+		Headers: []reports.TableHeader{
+			{Key: "service", Label: "Service", Type: "string", Sortable: true, Filterable: true},
+			{Key: "coverage", Label: "Coverage", Type: "percentage", Sortable: true, Filterable: false},
+			{Key: "covered_cost", Label: "Covered Spend", Type: "currency", Sortable: true, Filterable: false},
+			{Key: "uncovered_cost", Label: "On-Demand Spend", Type: "currency", Sortable: true, Filterable: false},
+		},
+	}
+
+	for _, service := range coverage.ByService {
+		coverageTable.Rows = append(coverageTable.Rows, map[string]interface{}{
+			"service":        service.Service,
+			"coverage":       s.renderer.FormatPercentage(service.CoveragePercentage, 1),
+			"covered_cost":   s.renderer.FormatCurrency(service.CoveredCost, coverage.Currency),
+			"uncovered_cost": s.renderer.FormatCurrency(service.UncoveredCost, coverage.Currency),
+		})
+	}
+
+	tables = append(tables, coverageTable)
+
+	return tables
+}
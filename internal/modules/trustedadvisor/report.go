@@ -0,0 +1,222 @@
+package trustedadvisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"govuk-reports-dashboard/internal/reports"
+	"govuk-reports-dashboard/pkg/aws"
+	"govuk-reports-dashboard/pkg/logger"
+)
+
+// TrustedAdvisorReport implements the reports.Report interface for AWS
+// Trusted Advisor cost optimisation recommendations
+type TrustedAdvisorReport struct {
+	awsClient *aws.Client
+	renderer  *reports.Renderer
+	logger    *logger.Logger
+}
+
+// NewTrustedAdvisorReport creates a new Trusted Advisor report instance
+func NewTrustedAdvisorReport(awsClient *aws.Client, logger *logger.Logger) *TrustedAdvisorReport {
+	return &TrustedAdvisorReport{
+		awsClient: awsClient,
+		renderer:  reports.NewRenderer(),
+		logger:    logger,
+	}
+}
+
+// GetMetadata returns metadata about this report module
+func (t *TrustedAdvisorReport) GetMetadata() reports.ReportMetadata {
+	return reports.ReportMetadata{
+		ID:          "trusted-advisor",
+		Name:        "Trusted Advisor Recommendations",
+		Description: "AWS Trusted Advisor cost optimisation checks and flagged resources",
+		Type:        reports.ReportTypeCost,
+		Version:     "1.0.0",
+		Author:      "GOV.UK Platform Team",
+		Tags:        []string{"trusted-advisor", "costs", "recommendations", "optimisation"},
+		Priority:    reports.PriorityLow,
+	}
+}
+
+// GenerateSummary creates summary data for dashboard display
+func (t *TrustedAdvisorReport) GenerateSummary(ctx context.Context, params reports.ReportParams) ([]reports.Summary, error) {
+	t.logger.Info().Msg("Generating Trusted Advisor summary for dashboard")
+
+	recommendations, err := t.awsClient.GetTrustedAdvisorRecommendations(ctx, aws.TrustedAdvisorCostOptimizing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Trusted Advisor recommendations: %w", err)
+	}
+
+	flaggedBySeverity := countFlaggedBySeverity(recommendations)
+	savings := 0.0
+	for _, recommendation := range recommendations {
+		savings += recommendation.EstimatedMonthlySavings
+	}
+
+	var summaries []reports.Summary
+
+	// Action recommended (red)
+	errorSummary := t.renderer.CreateSummaryCard(
+		"Action Recommended",
+		t.renderer.FormatNumber(flaggedBySeverity["error"]),
+		"Flagged resources",
+		reports.SummaryTypeAlert,
+		nil,
+	)
+	if flaggedBySeverity["error"] > 0 {
+		errorSummary.(*reports.BasicSummary).SetHealthy(false)
+	}
+	summaries = append(summaries, errorSummary)
+
+	// Investigation recommended (yellow)
+	warningSummary := t.renderer.CreateSummaryCard(
+		"Investigation Recommended",
+		t.renderer.FormatNumber(flaggedBySeverity["warning"]),
+		"Flagged resources",
+		reports.SummaryTypeAlert,
+		nil,
+	)
+	if flaggedBySeverity["warning"] > 0 {
+		warningSummary.(*reports.BasicSummary).SetHealthy(false)
+	}
+	summaries = append(summaries, warningSummary)
+
+	savingsSummary := t.renderer.CreateSummaryCard(
+		"Estimated Savings",
+		t.renderer.FormatCurrency(savings, "USD"),
+		"Per month",
+		reports.SummaryTypeCurrency,
+		nil,
+	)
+	summaries = append(summaries, savingsSummary)
+
+	t.logger.WithField("summary_count", len(summaries)).Info().Msg("Generated Trusted Advisor summaries")
+	return summaries, nil
+}
+
+// GenerateReport creates detailed report data
+func (t *TrustedAdvisorReport) GenerateReport(ctx context.Context, params reports.ReportParams) (reports.ReportData, error) {
+	t.logger.Info().Msg("Generating detailed Trusted Advisor report")
+
+	data := reports.ReportData{
+		Status:      reports.StatusRunning,
+		GeneratedAt: time.Now(),
+	}
+
+	recommendations, err := t.awsClient.GetTrustedAdvisorRecommendations(ctx, aws.TrustedAdvisorCostOptimizing)
+	if err != nil {
+		data.Status = reports.StatusFailed
+		data.Errors = append(data.Errors, reports.ReportError{
+			Code:      "TRUSTED_ADVISOR_FETCH_ERROR",
+			Message:   "Failed to fetch Trusted Advisor recommendations",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+		})
+		return data, nil
+	}
+
+	data.Summary, err = t.GenerateSummary(ctx, params)
+	if err != nil {
+		data.Warnings = append(data.Warnings, reports.ReportWarning{
+			Code:      "SUMMARY_GENERATION_WARNING",
+			Message:   "Failed to generate summary data",
+			Details:   err.Error(),
+			Timestamp: time.Now(),
+		})
+	}
+
+	data.Tables = t.generateTables(recommendations)
+
+	data.Status = reports.StatusCompleted
+	t.logger.WithField("tables", len(data.Tables)).Info().Msg("Generated detailed Trusted Advisor report")
+
+	return data, nil
+}
+
+// IsAvailable checks if this report can run with current configuration.
+// Trusted Advisor checks need a Business or Enterprise Support plan.
+func (t *TrustedAdvisorReport) IsAvailable(ctx context.Context) bool {
+	err := t.awsClient.CheckTrustedAdvisorAccess(ctx)
+	if errors.Is(err, aws.ErrSupportSubscriptionRequired) {
+		t.logger.Info().Msg("Trusted Advisor report unavailable without a Business or Enterprise Support plan")
+	}
+	return err == nil
+}
+
+// GetRefreshInterval returns how often this report should be refreshed
+func (t *TrustedAdvisorReport) GetRefreshInterval() time.Duration {
+	return 24 * time.Hour // Trusted Advisor checks refresh at most daily
+}
+
+// Validate checks if the provided parameters are valid for this report
+func (t *TrustedAdvisorReport) Validate(params reports.ReportParams) error {
+	// Trusted Advisor reports don't have specific parameter requirements currently
+	return nil
+}
+
+// countFlaggedBySeverity counts flagged resources by their status ("error",
+// "warning" or "ok")
+func countFlaggedBySeverity(recommendations []aws.TrustedAdvisorRecommendation) map[string]int {
+	counts := make(map[string]int)
+	for _, recommendation := range recommendations {
+		for _, resource := range recommendation.FlaggedResources {
+			counts[resource.Status]++
+		}
+	}
+	return counts
+}
+
+func (t *TrustedAdvisorReport) generateTables(recommendations []aws.TrustedAdvisorRecommendation) []reports.TableData {
+	var tables []reports.TableData
+
+	sort.Slice(recommendations, func(i, j int) bool {
+		return recommendations[i].EstimatedMonthlySavings > recommendations[j].EstimatedMonthlySavings
+	})
+
+	checksTable := reports.TableData{
+		Title: "Cost Optimisation Checks",
+		Headers: []reports.TableHeader{
+			{Key: "check", Label: "Check", Type: "string", Sortable: true, Filterable: true},
+			{Key: "status", Label: "Status", Type: "string", Sortable: true, Filterable: true},
+			{Key: "resources_flagged", Label: "Resources Flagged", Type: "number", Sortable: true, Filterable: false},
+			{Key: "estimated_savings", Label: "Estimated Monthly Savings", Type: "currency", Sortable: true, Filterable: false},
+		},
+	}
+
+	resourcesTable := reports.TableData{
+		Title: "Flagged Resources",
+		Headers: []reports.TableHeader{
+			{Key: "check", Label: "Check", Type: "string", Sortable: true, Filterable: true},
+			{Key: "resource_id", Label: "Resource", Type: "string", Sortable: true, Filterable: true},
+			{Key: "region", Label: "Region", Type: "string", Sortable: true, Filterable: true},
+			{Key: "status", Label: "Status", Type: "string", Sortable: true, Filterable: true},
+		},
+	}
+
+	for _, recommendation := range recommendations {
+		checksTable.Rows = append(checksTable.Rows, map[string]interface{}{
+			"check":             recommendation.CheckName,
+			"status":            recommendation.Status,
+			"resources_flagged": recommendation.ResourcesSummary.ResourcesFlagged,
+			"estimated_savings": t.renderer.FormatCurrency(recommendation.EstimatedMonthlySavings, "USD"),
+		})
+
+		for _, resource := range recommendation.FlaggedResources {
+			resourcesTable.Rows = append(resourcesTable.Rows, map[string]interface{}{
+				"check":       recommendation.CheckName,
+				"resource_id": resource.ResourceID,
+				"region":      resource.Region,
+				"status":      resource.Status,
+			})
+		}
+	}
+
+	tables = append(tables, checksTable, resourcesTable)
+
+	return tables
+}
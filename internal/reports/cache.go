@@ -1,42 +1,143 @@
 package reports
 
 import (
+	"compress/gzip"
+	"container/list"
 	"crypto/md5"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// DefaultCacheMaxEntries is how many summaries and reports a Manager's cache
+// holds unless WithCacheMaxEntries says otherwise
+const DefaultCacheMaxEntries = 1000
+
 // CacheEntry represents a cached item with expiration
 type CacheEntry struct {
 	Data      interface{}
+	CreatedAt time.Time
 	ExpiresAt time.Time
+
+	// element is the entry's place in the cache's LRU list
+	element *list.Element
+}
+
+// lruItem identifies a cache entry from the LRU list
+type lruItem struct {
+	dataType string
+	key      string
 }
 
-// ReportCache provides caching for report data and summaries
+// ReportCache provides caching for report data and summaries. Once it holds
+// maxEntries summaries and reports together, adding another evicts the
+// least recently used.
 type ReportCache struct {
-	summaries map[string]*CacheEntry
-	reports   map[string]*CacheEntry
-	stats     CacheStats
-	mu        sync.RWMutex
+	summaries  map[string]*CacheEntry
+	reports    map[string]*CacheEntry
+	lru        *list.List
+	maxEntries int
+	stats      CacheStats
+	mu         sync.RWMutex
 }
 
-// CacheStats provides statistics about cache usage
+// CacheStats provides statistics about cache usage. CurrentSize is the
+// number of entries counted towards the cache's limit, and Evictions how
+// many have been removed to stay within it.
 type CacheStats struct {
-	SummaryHits   int64 `json:"summary_hits"`
-	SummaryMisses int64 `json:"summary_misses"`
-	ReportHits    int64 `json:"report_hits"`
-	ReportMisses  int64 `json:"report_misses"`
-	TotalEntries  int   `json:"total_entries"`
-	LastCleanup   time.Time `json:"last_cleanup"`
+	SummaryHits    int64     `json:"summary_hits"`
+	SummaryMisses  int64     `json:"summary_misses"`
+	ReportHits     int64     `json:"report_hits"`
+	ReportMisses   int64     `json:"report_misses"`
+	TotalEntries   int       `json:"total_entries"`
+	SummaryEntries int       `json:"summary_entries"`
+	ReportEntries  int       `json:"report_entries"`
+	OldestEntry    time.Time `json:"oldest_entry"`
+	NewestEntry    time.Time `json:"newest_entry"`
+	LastCleanup    time.Time `json:"last_cleanup"`
+	Evictions      int64     `json:"evictions"`
+	CurrentSize    int       `json:"current_size"`
+
+	// SingleflightActiveCount is the number of generations the Manager is
+	// running on behalf of concurrent identical requests. ReportCache
+	// leaves it at zero; Manager.GetCacheStats fills it in.
+	SingleflightActiveCount int64 `json:"singleflight_active_count"`
+}
+
+// persistedCache is the on-disk form of a ReportCache. Summary is an
+// interface, so summaries are stored as persistedSummaryCard values and
+// restored as BasicSummary.
+type persistedCache struct {
+	SavedAt   time.Time                   `json:"saved_at"`
+	Summaries map[string]persistedSummary `json:"summaries"`
+	Reports   map[string]persistedReport  `json:"reports"`
+}
+
+type persistedSummary struct {
+	Cards     []persistedSummaryCard `json:"cards"`
+	CreatedAt time.Time              `json:"created_at"`
+	ExpiresAt time.Time              `json:"expires_at"`
 }
 
-// NewReportCache creates a new report cache
-func NewReportCache() *ReportCache {
+type persistedReport struct {
+	Data      ReportData             `json:"data"`
+	Cards     []persistedSummaryCard `json:"cards"`
+	CreatedAt time.Time              `json:"created_at"`
+	ExpiresAt time.Time              `json:"expires_at"`
+}
+
+type persistedSummaryCard struct {
+	Title    string      `json:"title"`
+	Value    string      `json:"value"`
+	Subtitle string      `json:"subtitle"`
+	Type     SummaryType `json:"type"`
+	Trend    *TrendData  `json:"trend,omitempty"`
+	Healthy  bool        `json:"healthy"`
+}
+
+func toSummaryCards(summaries []Summary) []persistedSummaryCard {
+	cards := make([]persistedSummaryCard, 0, len(summaries))
+	for _, summary := range summaries {
+		cards = append(cards, persistedSummaryCard{
+			Title:    summary.GetTitle(),
+			Value:    summary.GetValue(),
+			Subtitle: summary.GetSubtitle(),
+			Type:     summary.GetType(),
+			Trend:    summary.GetTrend(),
+			Healthy:  summary.IsHealthy(),
+		})
+	}
+	return cards
+}
+
+func fromSummaryCards(cards []persistedSummaryCard) []Summary {
+	summaries := make([]Summary, 0, len(cards))
+	for _, card := range cards {
+		summaries = append(summaries, &BasicSummary{
+			title:       card.Title,
+			value:       card.Value,
+			subtitle:    card.Subtitle,
+			summaryType: card.Type,
+			trend:       card.Trend,
+			healthy:     card.Healthy,
+		})
+	}
+	return summaries
+}
+
+// NewReportCache creates a new report cache holding at most maxEntries
+// summaries and reports. A limit of zero or less means no limit.
+func NewReportCache(maxEntries int) *ReportCache {
 	cache := &ReportCache{
-		summaries: make(map[string]*CacheEntry),
-		reports:   make(map[string]*CacheEntry),
+		summaries:  make(map[string]*CacheEntry),
+		reports:    make(map[string]*CacheEntry),
+		lru:        list.New(),
+		maxEntries: max(maxEntries, 0),
 	}
 	
 	// Start background cleanup routine
@@ -45,20 +146,21 @@ func NewReportCache() *ReportCache {
 	return cache
 }
 
-// GetSummary retrieves cached summary data
+// GetSummary retrieves cached summary data, marking it as recently used
 func (c *ReportCache) GetSummary(reportID string, params ReportParams) []Summary {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
 	key := c.generateKey(reportID, "summary", params)
 	entry, exists := c.summaries[key]
 	
 	if !exists || time.Now().After(entry.ExpiresAt) {
-		c.stats.SummaryMisses++
+		atomic.AddInt64(&c.stats.SummaryMisses, 1)
 		return nil
 	}
 
-	c.stats.SummaryHits++
+	atomic.AddInt64(&c.stats.SummaryHits, 1)
+	c.lru.MoveToFront(entry.element)
 	
 	if summaries, ok := entry.Data.([]Summary); ok {
 		return summaries
@@ -73,26 +175,29 @@ func (c *ReportCache) SetSummary(reportID string, params ReportParams, summaries
 	defer c.mu.Unlock()
 
 	key := c.generateKey(reportID, "summary", params)
-	c.summaries[key] = &CacheEntry{
+	now := time.Now()
+	c.insert("summary", key, &CacheEntry{
 		Data:      summaries,
-		ExpiresAt: time.Now().Add(ttl),
-	}
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	})
 }
 
-// GetReport retrieves cached report data
+// GetReport retrieves cached report data, marking it as recently used
 func (c *ReportCache) GetReport(reportID string, params ReportParams) *ReportData {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
 	key := c.generateKey(reportID, "report", params)
 	entry, exists := c.reports[key]
 	
 	if !exists || time.Now().After(entry.ExpiresAt) {
-		c.stats.ReportMisses++
+		atomic.AddInt64(&c.stats.ReportMisses, 1)
 		return nil
 	}
 
-	c.stats.ReportHits++
+	atomic.AddInt64(&c.stats.ReportHits, 1)
+	c.lru.MoveToFront(entry.element)
 	
 	if report, ok := entry.Data.(*ReportData); ok {
 		return report
@@ -107,9 +212,46 @@ func (c *ReportCache) SetReport(reportID string, params ReportParams, report *Re
 	defer c.mu.Unlock()
 
 	key := c.generateKey(reportID, "report", params)
-	c.reports[key] = &CacheEntry{
+	now := time.Now()
+	c.insert("report", key, &CacheEntry{
 		Data:      report,
-		ExpiresAt: time.Now().Add(ttl),
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	})
+}
+
+// entries returns the map holding entries of dataType
+func (c *ReportCache) entries(dataType string) map[string]*CacheEntry {
+	if dataType == "summary" {
+		return c.summaries
+	}
+	return c.reports
+}
+
+// insert adds or replaces an entry as the most recently used, first
+// evicting the least recently used entries if the cache is full. The caller
+// must hold the write lock.
+func (c *ReportCache) insert(dataType, key string, entry *CacheEntry) {
+	c.remove(dataType, key)
+
+	if c.maxEntries > 0 {
+		for c.lru.Len() >= c.maxEntries {
+			oldest := c.lru.Back().Value.(lruItem)
+			c.remove(oldest.dataType, oldest.key)
+			c.stats.Evictions++
+		}
+	}
+
+	entry.element = c.lru.PushFront(lruItem{dataType: dataType, key: key})
+	c.entries(dataType)[key] = entry
+}
+
+// remove deletes an entry, if present. The caller must hold the write lock.
+func (c *ReportCache) remove(dataType, key string) {
+	entries := c.entries(dataType)
+	if entry, exists := entries[key]; exists {
+		c.lru.Remove(entry.element)
+		delete(entries, key)
 	}
 }
 
@@ -121,13 +263,13 @@ func (c *ReportCache) Invalidate(reportID string) {
 	// Remove all entries that start with the report ID
 	for key := range c.summaries {
 		if isKeyForReport(key, reportID) {
-			delete(c.summaries, key)
+			c.remove("summary", key)
 		}
 	}
 	
 	for key := range c.reports {
 		if isKeyForReport(key, reportID) {
-			delete(c.reports, key)
+			c.remove("report", key)
 		}
 	}
 }
@@ -139,19 +281,156 @@ func (c *ReportCache) Clear() {
 
 	c.summaries = make(map[string]*CacheEntry)
 	c.reports = make(map[string]*CacheEntry)
+	c.lru.Init()
 	c.stats.LastCleanup = time.Now()
 }
 
-// GetStats returns cache statistics
+// GetStats returns cache statistics. Entry counts and ages include entries
+// that have expired but not yet been cleaned up.
 func (c *ReportCache) GetStats() CacheStats {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	stats := c.stats
-	stats.TotalEntries = len(c.summaries) + len(c.reports)
+	stats := CacheStats{
+		SummaryHits:    atomic.LoadInt64(&c.stats.SummaryHits),
+		SummaryMisses:  atomic.LoadInt64(&c.stats.SummaryMisses),
+		ReportHits:     atomic.LoadInt64(&c.stats.ReportHits),
+		ReportMisses:   atomic.LoadInt64(&c.stats.ReportMisses),
+		SummaryEntries: len(c.summaries),
+		ReportEntries:  len(c.reports),
+		LastCleanup:    c.stats.LastCleanup,
+		Evictions:      c.stats.Evictions,
+		CurrentSize:    c.lru.Len(),
+	}
+	stats.TotalEntries = stats.SummaryEntries + stats.ReportEntries
+
+	for _, entries := range []map[string]*CacheEntry{c.summaries, c.reports} {
+		for _, entry := range entries {
+			if stats.OldestEntry.IsZero() || entry.CreatedAt.Before(stats.OldestEntry) {
+				stats.OldestEntry = entry.CreatedAt
+			}
+			if entry.CreatedAt.After(stats.NewestEntry) {
+				stats.NewestEntry = entry.CreatedAt
+			}
+		}
+	}
+
 	return stats
 }
 
+// SaveToFile writes all unexpired entries to a gzip-compressed JSON file.
+// The file is written to a temporary path first so a failed save never
+// replaces a good one.
+func (c *ReportCache) SaveToFile(path string) error {
+	c.mu.RLock()
+	now := time.Now()
+	snapshot := persistedCache{
+		SavedAt:   now,
+		Summaries: make(map[string]persistedSummary),
+		Reports:   make(map[string]persistedReport),
+	}
+	for key, entry := range c.summaries {
+		if summaries, ok := entry.Data.([]Summary); ok && now.Before(entry.ExpiresAt) {
+			snapshot.Summaries[key] = persistedSummary{Cards: toSummaryCards(summaries), CreatedAt: entry.CreatedAt, ExpiresAt: entry.ExpiresAt}
+		}
+	}
+	for key, entry := range c.reports {
+		if report, ok := entry.Data.(*ReportData); ok && report != nil && now.Before(entry.ExpiresAt) {
+			data := *report
+			data.Summary = nil
+			snapshot.Reports[key] = persistedReport{Data: data, Cards: toSummaryCards(report.Summary), CreatedAt: entry.CreatedAt, ExpiresAt: entry.ExpiresAt}
+		}
+	}
+	c.mu.RUnlock()
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create cache file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	gz := gzip.NewWriter(tmp)
+	if err := json.NewEncoder(gz).Encode(snapshot); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to encode cache: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to compress cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace cache file: %w", err)
+	}
+
+	return nil
+}
+
+// LoadFromFile restores entries saved by SaveToFile, skipping any that have
+// expired since. Existing entries with the same key are replaced.
+func (c *ReportCache) LoadFromFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open cache file: %w", err)
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("failed to decompress cache file: %w", err)
+	}
+	defer gz.Close()
+
+	var snapshot persistedCache
+	if err := json.NewDecoder(gz).Decode(&snapshot); err != nil {
+		return fmt.Errorf("failed to decode cache file: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Files saved before creation times were recorded use the save time
+	createdAt := func(t time.Time) time.Time {
+		if t.IsZero() {
+			return snapshot.SavedAt
+		}
+		return t
+	}
+
+	// Insert the oldest entries first, so the newest are the most recently
+	// used if the file holds more than maxEntries
+	type loadedEntry struct {
+		dataType string
+		key      string
+		entry    *CacheEntry
+	}
+	var loaded []loadedEntry
+	now := time.Now()
+	for key, entry := range snapshot.Summaries {
+		if now.Before(entry.ExpiresAt) {
+			loaded = append(loaded, loadedEntry{"summary", key, &CacheEntry{Data: fromSummaryCards(entry.Cards), CreatedAt: createdAt(entry.CreatedAt), ExpiresAt: entry.ExpiresAt}})
+		}
+	}
+	for key, entry := range snapshot.Reports {
+		if now.Before(entry.ExpiresAt) {
+			report := entry.Data
+			report.Summary = fromSummaryCards(entry.Cards)
+			loaded = append(loaded, loadedEntry{"report", key, &CacheEntry{Data: &report, CreatedAt: createdAt(entry.CreatedAt), ExpiresAt: entry.ExpiresAt}})
+		}
+	}
+	sort.SliceStable(loaded, func(i, j int) bool {
+		return loaded[i].entry.CreatedAt.Before(loaded[j].entry.CreatedAt)
+	})
+	for _, l := range loaded {
+		c.insert(l.dataType, l.key, l.entry)
+	}
+
+	return nil
+}
+
 // generateKey creates a cache key from report ID, type, and parameters
 func (c *ReportCache) generateKey(reportID, dataType string, params ReportParams) string {
 	// Create a deterministic key based on reportID, type, and relevant parameters
@@ -169,7 +448,6 @@ func (c *ReportCache) generateKey(reportID, dataType string, params ReportParams
 		SortOrder    string
 		Limit        int
 		Offset       int
-		Format       string
 	}{
 		ReportID:     reportID,
 		DataType:     dataType,
@@ -184,7 +462,6 @@ func (c *ReportCache) generateKey(reportID, dataType string, params ReportParams
 		SortOrder:    params.SortOrder,
 		Limit:        params.Limit,
 		Offset:       params.Offset,
-		Format:       params.Format,
 	}
 
 	jsonData, _ := json.Marshal(keyData)
@@ -219,14 +496,14 @@ func (c *ReportCache) cleanup() {
 	// Clean expired summaries
 	for key, entry := range c.summaries {
 		if now.After(entry.ExpiresAt) {
-			delete(c.summaries, key)
+			c.remove("summary", key)
 		}
 	}
 	
 	// Clean expired reports
 	for key, entry := range c.reports {
 		if now.After(entry.ExpiresAt) {
-			delete(c.reports, key)
+			c.remove("report", key)
 		}
 	}
 
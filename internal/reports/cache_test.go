@@ -0,0 +1,196 @@
+package reports
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReportCache_SaveAndLoadFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report-cache.json.gz")
+	params := ReportParams{UseCache: true}
+
+	renderer := NewRenderer()
+	summaries := []Summary{renderer.CreateSummaryCard("Total Cost", "£100", "Last 30 days", SummaryTypeCurrency, nil)}
+
+	cache := NewReportCache(0)
+	cache.SetSummary("costs", params, summaries, time.Hour)
+	cache.SetReport("costs", params, &ReportData{Status: StatusCompleted, Summary: summaries}, time.Hour)
+	cache.SetReport("rds", params, &ReportData{Status: StatusCompleted}, -time.Minute)
+
+	if err := cache.SaveToFile(path); err != nil {
+		t.Fatalf("SaveToFile failed: %v", err)
+	}
+
+	restored := NewReportCache(0)
+	if err := restored.LoadFromFile(path); err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+
+	if got := restored.GetStats().TotalEntries; got != 2 {
+		t.Errorf("Expected 2 restored entries, got %d", got)
+	}
+
+	restoredSummaries := restored.GetSummary("costs", params)
+	if len(restoredSummaries) != 1 || restoredSummaries[0].GetValue() != "£100" || restoredSummaries[0].GetType() != SummaryTypeCurrency {
+		t.Errorf("Unexpected restored summaries: %+v", restoredSummaries)
+	}
+
+	report := restored.GetReport("costs", params)
+	if report == nil || report.Status != StatusCompleted {
+		t.Fatalf("Unexpected restored report: %+v", report)
+	}
+	if len(report.Summary) != 1 || report.Summary[0].GetTitle() != "Total Cost" {
+		t.Errorf("Unexpected restored report summary: %+v", report.Summary)
+	}
+
+	if restored.GetReport("rds", params) != nil {
+		t.Error("Expected expired report not to be restored")
+	}
+}
+
+func TestReportCache_LoadFromFile_Missing(t *testing.T) {
+	cache := NewReportCache(0)
+	if err := cache.LoadFromFile(filepath.Join(t.TempDir(), "missing.json.gz")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
+
+func TestReportCache_GetStats(t *testing.T) {
+	params := ReportParams{UseCache: true}
+	cache := NewReportCache(0)
+
+	cache.SetSummary("costs", params, []Summary{}, time.Hour)
+	first := cache.GetStats().NewestEntry
+	time.Sleep(time.Millisecond)
+	cache.SetReport("costs", params, &ReportData{Status: StatusCompleted}, time.Hour)
+	cache.SetReport("rds", ReportParams{Limit: 10}, &ReportData{Status: StatusCompleted}, time.Hour)
+
+	cache.GetSummary("costs", params)
+	cache.GetReport("costs", params)
+	cache.GetReport("elasticache", params)
+
+	stats := cache.GetStats()
+	if stats.SummaryEntries != 1 || stats.ReportEntries != 2 || stats.TotalEntries != 3 {
+		t.Errorf("Expected 1 summary and 2 report entries, got %+v", stats)
+	}
+	if stats.SummaryHits != 1 || stats.ReportHits != 1 || stats.ReportMisses != 1 {
+		t.Errorf("Unexpected hit and miss counts: %+v", stats)
+	}
+	if !stats.OldestEntry.Equal(first) || !stats.NewestEntry.After(stats.OldestEntry) {
+		t.Errorf("Expected oldest entry %v before newest %v", stats.OldestEntry, stats.NewestEntry)
+	}
+
+	cache.Clear()
+	if stats := cache.GetStats(); stats.TotalEntries != 0 || !stats.OldestEntry.IsZero() {
+		t.Errorf("Expected an empty cache after Clear, got %+v", stats)
+	}
+}
+
+func TestReportCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	const maxEntries = 3
+	cache := NewReportCache(maxEntries)
+	params := func(i int) ReportParams { return ReportParams{Limit: i} }
+
+	for i := range maxEntries {
+		cache.SetReport("costs", params(i), &ReportData{Status: StatusCompleted}, time.Hour)
+	}
+	cache.SetReport("costs", params(maxEntries), &ReportData{Status: StatusCompleted}, time.Hour)
+
+	stats := cache.GetStats()
+	if stats.Evictions != 1 || stats.CurrentSize != maxEntries {
+		t.Fatalf("Expected 1 eviction and %d entries, got %+v", maxEntries, stats)
+	}
+	if cache.GetReport("costs", params(0)) != nil {
+		t.Error("Expected the oldest entry to be evicted")
+	}
+	for i := 1; i <= maxEntries; i++ {
+		if cache.GetReport("costs", params(i)) == nil {
+			t.Errorf("Expected entry %d to be kept", i)
+		}
+	}
+}
+
+func TestReportCache_GetMarksEntriesAsRecentlyUsed(t *testing.T) {
+	cache := NewReportCache(2)
+	params := ReportParams{UseCache: true}
+
+	cache.SetSummary("costs", params, []Summary{}, time.Hour)
+	cache.SetReport("costs", params, &ReportData{Status: StatusCompleted}, time.Hour)
+
+	// Reading the summary makes the report the least recently used
+	cache.GetSummary("costs", params)
+	cache.SetReport("rds", params, &ReportData{Status: StatusCompleted}, time.Hour)
+
+	if cache.GetReport("costs", params) != nil {
+		t.Error("Expected the least recently used report to be evicted")
+	}
+	if cache.GetSummary("costs", params) == nil {
+		t.Error("Expected the recently read summary to be kept")
+	}
+
+	// Replacing an entry doesn't count towards the limit
+	cache.SetReport("rds", params, &ReportData{Status: StatusCompleted}, time.Hour)
+	if stats := cache.GetStats(); stats.Evictions != 1 || stats.CurrentSize != 2 {
+		t.Errorf("Expected 1 eviction and 2 entries, got %+v", stats)
+	}
+}
+
+func TestReportCache_LoadFromFile_KeepsNewestWithinLimit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report-cache.json.gz")
+	cache := NewReportCache(0)
+	for i := range 3 {
+		cache.SetReport("costs", ReportParams{Limit: i}, &ReportData{Status: StatusCompleted}, time.Hour)
+		time.Sleep(time.Millisecond)
+	}
+	if err := cache.SaveToFile(path); err != nil {
+		t.Fatalf("SaveToFile failed: %v", err)
+	}
+
+	restored := NewReportCache(2)
+	if err := restored.LoadFromFile(path); err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+
+	if restored.GetReport("costs", ReportParams{Limit: 0}) != nil {
+		t.Error("Expected the oldest entry not to be restored")
+	}
+	if restored.GetReport("costs", ReportParams{Limit: 2}) == nil {
+		t.Error("Expected the newest entry to be restored")
+	}
+}
+
+func TestReportCache_ConcurrentAccess(t *testing.T) {
+	const maxEntries = 20
+	cache := NewReportCache(maxEntries)
+
+	var wg sync.WaitGroup
+	for g := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				params := ReportParams{Limit: (g + i) % 40}
+				if i%2 == 0 {
+					cache.SetReport(fmt.Sprintf("report-%d", g%5), params, &ReportData{Status: StatusCompleted}, time.Hour)
+					cache.SetSummary("costs", params, []Summary{}, time.Hour)
+				} else {
+					cache.GetReport(fmt.Sprintf("report-%d", g%5), params)
+					cache.GetSummary("costs", params)
+					cache.GetStats()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := cache.GetStats()
+	if stats.CurrentSize > maxEntries || stats.TotalEntries != stats.CurrentSize {
+		t.Errorf("Expected at most %d entries, all in the LRU list, got %+v", maxEntries, stats)
+	}
+	if stats.Evictions == 0 {
+		t.Error("Expected entries to be evicted")
+	}
+}
@@ -0,0 +1,141 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// StartEOLChecks generates the enabled reports that implement EOLReport
+// every interval until ctx is cancelled, so the instances they find are
+// notified even if nobody views them. Cached reports are used until they
+// expire. It does nothing without a notifier or with an interval of zero or
+// less.
+func (m *Manager) StartEOLChecks(ctx context.Context, interval time.Duration) {
+	if m.notifier == nil || interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			m.checkEOL(ctx)
+		}
+	}()
+}
+
+// checkEOL generates each enabled report that implements EOLReport, which
+// notifies any new instances they find
+func (m *Manager) checkEOL(ctx context.Context) {
+	m.mu.RLock()
+	var reportIDs []string
+	for reportID, report := range m.reports {
+		if _, ok := report.(EOLReport); ok && m.IsEnabled(reportID) {
+			reportIDs = append(reportIDs, reportID)
+		}
+	}
+	m.mu.RUnlock()
+	sort.Strings(reportIDs)
+
+	for _, reportID := range reportIDs {
+		if _, err := m.GenerateReport(ctx, reportID, ReportParams{UseCache: true}); err != nil && ctx.Err() == nil {
+			m.logger.WithError(err).WithField("report_id", reportID).Warn().Msg("Scheduled EOL check failed")
+		}
+	}
+}
+
+// loadNotified restores the instances already notified from notifiedPath.
+// A missing or unreadable file is logged and leaves nothing notified.
+func (m *Manager) loadNotified() {
+	if m.notifiedPath == "" {
+		return
+	}
+
+	content, err := os.ReadFile(m.notifiedPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	}
+	var saved map[string][]string
+	if err == nil {
+		err = json.Unmarshal(content, &saved)
+	}
+	if err != nil {
+		m.logger.WithError(err).WithField("path", m.notifiedPath).Warn().Msg("Failed to restore notified EOL instances")
+		return
+	}
+
+	m.notifiedMu.Lock()
+	defer m.notifiedMu.Unlock()
+	for reportID, keys := range saved {
+		notified := make(map[string]bool, len(keys))
+		for _, key := range keys {
+			notified[key] = true
+		}
+		m.notified[reportID] = notified
+	}
+}
+
+// saveNotified writes the instances already notified to notifiedPath,
+// logging any failure
+func (m *Manager) saveNotified() {
+	if m.notifiedPath == "" {
+		return
+	}
+
+	// Held while writing, so an older state can't replace a newer one
+	m.notifiedMu.Lock()
+	defer m.notifiedMu.Unlock()
+
+	saved := make(map[string][]string, len(m.notified))
+	for reportID, notified := range m.notified {
+		keys := make([]string, 0, len(notified))
+		for key := range notified {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		saved[reportID] = keys
+	}
+
+	if err := writeFileAtomic(m.notifiedPath, saved); err != nil {
+		m.logger.WithError(err).WithField("path", m.notifiedPath).Error().Msg("Failed to save notified EOL instances")
+	}
+}
+
+// writeFileAtomic writes value as JSON to a temporary file and renames it
+// to path, so readers never see a partly written file
+func writeFileAtomic(path string, value interface{}) error {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create state file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace state file: %w", err)
+	}
+	return nil
+}
@@ -2,29 +2,181 @@ package reports
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"govuk-reports-dashboard/pkg/flight"
 	"govuk-reports-dashboard/pkg/logger"
+	"govuk-reports-dashboard/pkg/notifications"
+)
+
+// ErrReportNotFound is returned for a report ID that is not registered
+var ErrReportNotFound = errors.New("report not found")
+
+// ErrReportDisabled is returned when generating a report that an operator
+// has disabled
+var ErrReportDisabled = errors.New("report is disabled")
+
+// ErrInvalidParams is returned when a report rejects its parameters
+var ErrInvalidParams = errors.New("invalid parameters")
+
+// EventPublisher is notified when reports are generated, such as
+// notifications.WebhookDispatcher
+type EventPublisher interface {
+	Publish(event string, data map[string]interface{})
+}
+
+// GenerationRecorder records how long reports take to generate, such as
+// metrics.Registry
+type GenerationRecorder interface {
+	ObserveReportGeneration(reportID string, duration time.Duration)
+}
+
+// Report events sent to the EventPublisher
+const (
+	EventReportCompleted = "report.completed"
+	EventReportFailed    = "report.failed"
 )
 
 // Manager handles registration and execution of report modules
 type Manager struct {
-	reports map[string]Report
-	cache   *ReportCache
-	logger  *logger.Logger
-	mu      sync.RWMutex
+	reports   map[string]Report
+	cache     *ReportCache
+	logger    *logger.Logger
+	mu        sync.RWMutex
+	publisher EventPublisher
+	recorder  GenerationRecorder
+
+	// lastStatus maps report IDs to the status of their last generation
+	lastStatus sync.Map
+
+	lastSummaryMu          sync.Mutex
+	lastSummaryGeneratedAt time.Time
+
+	// enabled maps report IDs to whether they are enabled. Reports without
+	// an entry are enabled.
+	enabled sync.Map
+
+	// cacheMaxEntries is the size of the report cache, set before it is
+	// created by NewManager
+	cacheMaxEntries int
+
+	// concurrencyLimit caps how many reports run at once, whatever
+	// ReportParams.MaxConcurrency asks for. Zero means no limit.
+	concurrencyLimit int
+
+	// group deduplicates concurrent generation of the same summary or
+	// report with the same parameters, so only one of them misses the cache
+	// and calls AWS. activeFlights counts generations in progress in group,
+	// and flightWaiters the callers waiting on them.
+	group         flight.Group
+	activeFlights atomic.Int64
+	flightWaiters atomic.Int64
+
+	// notifier is told about instances in EOLReport reports that policy
+	// selects. notifiedMu guards notified, which maps report IDs to the
+	// instance keys already notified, so unchanged state isn't re-sent.
+	// notified is saved to notifiedPath, when set, to survive restarts.
+	notifier     notifications.EOLNotifier
+	policy       notifications.EOLAlertPolicy
+	notifiedMu   sync.Mutex
+	notified     map[string]map[string]bool
+	notifiedPath string
+}
+
+// ManagerOption configures a Manager created by NewManager
+type ManagerOption func(*Manager)
+
+// WithConcurrencyLimit caps how many reports the manager generates at once.
+// A limit of zero or less means no limit.
+func WithConcurrencyLimit(limit int) ManagerOption {
+	return func(m *Manager) {
+		m.concurrencyLimit = max(limit, 0)
+	}
+}
+
+// WithCacheMaxEntries sets how many summaries and reports the manager
+// caches before evicting the least recently used. A limit of zero or less
+// means no limit.
+func WithCacheMaxEntries(maxEntries int) ManagerOption {
+	return func(m *Manager) {
+		m.cacheMaxEntries = maxEntries
+	}
+}
+
+// WithMetrics records the time taken by each report generation, including
+// failed ones, with recorder
+func WithMetrics(recorder GenerationRecorder) ManagerOption {
+	return func(m *Manager) {
+		m.recorder = recorder
+	}
+}
+
+// WithNotifier sends notifications about instances found by reports that
+// implement EOLReport, as selected by policy. Each instance is notified
+// when it first appears, and again only if it disappears and comes back or
+// is found for a different reason.
+func WithNotifier(notifier notifications.EOLNotifier, policy notifications.EOLAlertPolicy) ManagerOption {
+	return func(m *Manager) {
+		m.notifier = notifier
+		m.policy = policy
+	}
+}
+
+// WithNotificationState saves the instances notified by WithNotifier to the
+// file at path, and restores them from it, so they aren't notified again
+// after a restart
+func WithNotificationState(path string) ManagerOption {
+	return func(m *Manager) {
+		m.notifiedPath = path
+	}
 }
 
 // NewManager creates a new report manager
-func NewManager(logger *logger.Logger) *Manager {
-	return &Manager{
-		reports: make(map[string]Report),
-		cache:   NewReportCache(),
-		logger:  logger,
+func NewManager(logger *logger.Logger, opts ...ManagerOption) *Manager {
+	m := &Manager{
+		reports:         make(map[string]Report),
+		logger:          logger,
+		notified:        make(map[string]map[string]bool),
+		cacheMaxEntries: DefaultCacheMaxEntries,
+	}
+	for _, opt := range opts {
+		opt(m)
 	}
+	m.cache = NewReportCache(m.cacheMaxEntries)
+	m.loadNotified()
+	return m
+}
+
+// SetEventPublisher enables publishing report.completed and report.failed
+// events when GenerateReport generates a report
+func (m *Manager) SetEventPublisher(publisher EventPublisher) {
+	m.publisher = publisher
+}
+
+// publish sends an event about a report if a publisher is set
+func (m *Manager) publish(event, reportID string, data ReportData, err error) {
+	if m.publisher == nil {
+		return
+	}
+
+	eventData := map[string]interface{}{
+		"report_id": reportID,
+		"status":    data.Status,
+	}
+	if err != nil {
+		eventData["status"] = StatusFailed
+		eventData["error"] = err.Error()
+	} else {
+		eventData["generated_at"] = data.GeneratedAt
+		eventData["errors"] = len(data.Errors)
+		eventData["warnings"] = len(data.Warnings)
+	}
+	m.publisher.Publish(event, eventData)
 }
 
 // Register adds a new report module to the manager
@@ -57,6 +209,7 @@ func (m *Manager) Unregister(reportID string) error {
 	}
 
 	delete(m.reports, reportID)
+	m.enabled.Delete(reportID)
 	m.cache.Invalidate(reportID)
 	m.logger.WithField("report_id", reportID).Info().Msg("Report module unregistered")
 	
@@ -70,20 +223,57 @@ func (m *Manager) GetReport(reportID string) (Report, error) {
 
 	report, exists := m.reports[reportID]
 	if !exists {
-		return nil, fmt.Errorf("report with ID %s not found", reportID)
+		return nil, fmt.Errorf("report with ID %s: %w", reportID, ErrReportNotFound)
 	}
 
 	return report, nil
 }
 
-// ListReports returns all registered reports
+// SetEnabled enables or disables a registered report. Disabled reports stay
+// registered but are left out of summaries and cannot be generated.
+func (m *Manager) SetEnabled(reportID string, enabled bool) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if _, exists := m.reports[reportID]; !exists {
+		return fmt.Errorf("report with ID %s: %w", reportID, ErrReportNotFound)
+	}
+
+	m.enabled.Store(reportID, enabled)
+	if !enabled {
+		m.cache.Invalidate(reportID)
+	}
+	m.logger.WithFields(map[string]interface{}{
+		"report_id": reportID,
+		"enabled":   enabled,
+	}).Info().Msg("Report module enabled state changed")
+
+	return nil
+}
+
+// IsEnabled reports whether a report has not been disabled
+func (m *Manager) IsEnabled(reportID string) bool {
+	if enabled, ok := m.enabled.Load(reportID); ok {
+		return enabled.(bool)
+	}
+	return true
+}
+
+// metadata returns a report's metadata with its enabled state filled in
+func (m *Manager) metadata(report Report) ReportMetadata {
+	metadata := report.GetMetadata()
+	metadata.Enabled = m.IsEnabled(metadata.ID)
+	return metadata
+}
+
+// ListReports returns all registered reports, including disabled ones
 func (m *Manager) ListReports() []ReportMetadata {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	var reports []ReportMetadata
 	for _, report := range m.reports {
-		reports = append(reports, report.GetMetadata())
+		reports = append(reports, m.metadata(report))
 	}
 
 	// Sort by priority (highest first), then by name
@@ -97,15 +287,16 @@ func (m *Manager) ListReports() []ReportMetadata {
 	return reports
 }
 
-// GetAvailableReports returns only reports that are currently available
+// GetAvailableReports returns only reports that are enabled and currently
+// available
 func (m *Manager) GetAvailableReports(ctx context.Context) []ReportMetadata {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	var available []ReportMetadata
 	for _, report := range m.reports {
-		if report.IsAvailable(ctx) {
-			available = append(available, report.GetMetadata())
+		if m.IsEnabled(report.GetMetadata().ID) && report.IsAvailable(ctx) {
+			available = append(available, m.metadata(report))
 		}
 	}
 
@@ -120,53 +311,142 @@ func (m *Manager) GetAvailableReports(ctx context.Context) []ReportMetadata {
 	return available
 }
 
-// GenerateSummary generates summary data for all available reports
+// GenerateSummary generates summary data for all available reports. Reports
+// that fail are logged and skipped; an error is only returned if all of them
+// fail. Use GenerateSummaryWithErrors to find out which reports failed.
 func (m *Manager) GenerateSummary(ctx context.Context, params ReportParams) ([]Summary, error) {
+	response, err := m.GenerateSummaryWithErrors(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return response.Summaries, nil
+}
+
+// GenerateSummaryWithErrors generates summary data for all available reports,
+// recording a ReportError for each report whose summary fails. An error is
+// returned, alongside the response, only if every report failed.
+//
+// Summaries are generated concurrently, started in priority order as
+// described by runByPriority, and returned highest priority first.
+func (m *Manager) GenerateSummaryWithErrors(ctx context.Context, params ReportParams) (ManagerSummaryResponse, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	var allSummaries []Summary
+	response := ManagerSummaryResponse{
+		Summaries: []Summary{},
+		Errors:    []ReportError{},
+	}
 	var errors []string
 
+	queue := NewPriorityQueue()
 	for _, report := range m.reports {
-		if !report.IsAvailable(ctx) {
-			continue
+		if m.IsEnabled(report.GetMetadata().ID) && report.IsAvailable(ctx) {
+			queue.Push(report)
 		}
+	}
+
+	// Results are indexed by start order so summaries stay in priority order
+	type summaryResult struct {
+		metadata  ReportMetadata
+		summaries []Summary
+		err       error
+	}
+	results := make([]summaryResult, queue.Len())
 
+	m.runByPriority(ctx, queue, params, func(i int, report Report, err error) {
 		metadata := report.GetMetadata()
-		
+		results[i].metadata = metadata
+		if err != nil {
+			results[i].err = err
+			return
+		}
+
+		results[i].summaries, results[i].err = m.generateSummary(ctx, report, metadata.ID, params)
+	})
+
+	for _, result := range results {
+		if result.err != nil {
+			m.logger.WithFields(map[string]interface{}{
+				"report_id": result.metadata.ID,
+				"error":     result.err.Error(),
+			}).Error().Msg("Failed to generate summary")
+			errors = append(errors, fmt.Sprintf("%s: %v", result.metadata.Name, result.err))
+			response.Errors = append(response.Errors, ReportError{
+				Code:      "SUMMARY_GENERATION_ERROR",
+				Message:   fmt.Sprintf("Failed to generate %s summary", result.metadata.Name),
+				Details:   result.err.Error(),
+				Timestamp: time.Now(),
+			})
+			continue
+		}
+		response.Summaries = append(response.Summaries, result.summaries...)
+	}
+
+	if len(errors) > 0 && len(response.Summaries) == 0 {
+		return response, fmt.Errorf("all reports failed: %v", errors)
+	}
+	response.PartialSuccess = len(errors) > 0
+
+	m.lastSummaryMu.Lock()
+	m.lastSummaryGeneratedAt = time.Now()
+	m.lastSummaryMu.Unlock()
+
+	return response, nil
+}
+
+// generateSummary returns a report's summary from the cache, or generates
+// and caches it. Concurrent calls for the same report and parameters share
+// one generation, unless params.ForceRefresh is set.
+func (m *Manager) generateSummary(ctx context.Context, report Report, reportID string, params ReportParams) ([]Summary, error) {
+	generate := func(ctx context.Context) (interface{}, error) {
 		// Check cache first
 		if !params.ForceRefresh && params.UseCache {
-			if cached := m.cache.GetSummary(metadata.ID, params); cached != nil {
-				allSummaries = append(allSummaries, cached...)
-				continue
+			if cached := m.cache.GetSummary(reportID, params); cached != nil {
+				return cached, nil
 			}
 		}
 
 		// Generate fresh summary
 		summaries, err := report.GenerateSummary(ctx, params)
 		if err != nil {
-			m.logger.WithFields(map[string]interface{}{
-				"report_id": metadata.ID,
-				"error":     err.Error(),
-			}).Error().Msg("Failed to generate summary")
-			errors = append(errors, fmt.Sprintf("%s: %v", metadata.Name, err))
-			continue
+			return nil, err
 		}
 
 		// Cache the result
 		if params.UseCache {
-			m.cache.SetSummary(metadata.ID, params, summaries, report.GetRefreshInterval())
+			m.cache.SetSummary(reportID, params, summaries, report.GetRefreshInterval())
 		}
 
-		allSummaries = append(allSummaries, summaries...)
+		return summaries, nil
 	}
 
-	if len(errors) > 0 && len(allSummaries) == 0 {
-		return nil, fmt.Errorf("all reports failed: %v", errors)
+	var result interface{}
+	var err error
+	if params.ForceRefresh {
+		result, err = generate(ctx)
+	} else {
+		key := fmt.Sprintf("summary/%s/%t", m.cache.generateKey(reportID, "summary", params), params.UseCache)
+		result, err = m.do(ctx, key, generate)
 	}
+	if err != nil {
+		return nil, err
+	}
+	return result.([]Summary), nil
+}
 
-	return allSummaries, nil
+// do runs fn in the manager's flight group, counting it as active while it
+// runs. The shared generation is cancelled once every caller waiting on it
+// has gone away, so abandoned requests don't keep calling AWS; each caller
+// stops waiting when its own ctx is done.
+func (m *Manager) do(ctx context.Context, key string, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	m.flightWaiters.Add(1)
+	defer m.flightWaiters.Add(-1)
+
+	return m.group.Do(ctx, key, func(ctx context.Context) (interface{}, error) {
+		m.activeFlights.Add(1)
+		defer m.activeFlights.Add(-1)
+		return fn(ctx)
+	})
 }
 
 // GenerateReport generates a detailed report for a specific report module
@@ -176,17 +456,43 @@ func (m *Manager) GenerateReport(ctx context.Context, reportID string, params Re
 		return ReportData{}, err
 	}
 
+	if !m.IsEnabled(reportID) {
+		return ReportData{}, fmt.Errorf("report %s: %w", reportID, ErrReportDisabled)
+	}
+
 	if !report.IsAvailable(ctx) {
 		return ReportData{}, fmt.Errorf("report %s is not currently available", reportID)
 	}
 
 	// Validate parameters
 	if err := report.Validate(params); err != nil {
-		return ReportData{}, fmt.Errorf("invalid parameters: %w", err)
+		return ReportData{}, fmt.Errorf("%w: %w", ErrInvalidParams, err)
 	}
 
-	metadata := report.GetMetadata()
+	metadata := m.metadata(report)
+
+	// Callers forcing a refresh always get their own generation
+	if params.ForceRefresh {
+		return m.generateReport(ctx, report, reportID, metadata, params)
+	}
+
+	key := fmt.Sprintf("report/%s/%t", m.cache.generateKey(reportID, "report", params), params.UseCache)
+	result, err := m.do(ctx, key, func(ctx context.Context) (interface{}, error) {
+		data, err := m.generateReport(ctx, report, reportID, metadata, params)
+		if err != nil {
+			return nil, err
+		}
+		return &data, nil
+	})
+	if err != nil {
+		return ReportData{}, err
+	}
+	return *result.(*ReportData), nil
+}
 
+// generateReport returns a report from the cache, or generates, validates
+// and caches it
+func (m *Manager) generateReport(ctx context.Context, report Report, reportID string, metadata ReportMetadata, params ReportParams) (ReportData, error) {
 	// Check cache first
 	if !params.ForceRefresh && params.UseCache {
 		if cached := m.cache.GetReport(reportID, params); cached != nil {
@@ -197,12 +503,18 @@ func (m *Manager) GenerateReport(ctx context.Context, reportID string, params Re
 	// Generate fresh report
 	m.logger.WithField("report_id", reportID).Info().Msg("Generating report")
 	
+	start := time.Now()
 	data, err := report.GenerateReport(ctx, params)
+	if m.recorder != nil {
+		m.recorder.ObserveReportGeneration(reportID, time.Since(start))
+	}
 	if err != nil {
 		m.logger.WithFields(map[string]interface{}{
 			"report_id": reportID,
 			"error":     err.Error(),
 		}).Error().Msg("Failed to generate report")
+		m.lastStatus.Store(reportID, StatusFailed)
+		m.publish(EventReportFailed, reportID, ReportData{}, err)
 		return ReportData{}, fmt.Errorf("failed to generate report: %w", err)
 	}
 
@@ -210,6 +522,24 @@ func (m *Manager) GenerateReport(ctx context.Context, reportID string, params Re
 	data.Metadata = metadata
 	data.GeneratedAt = time.Now()
 
+	// Flag data that clients may not be able to render, without failing
+	// the report
+	issues := ValidateReportData(data)
+	for _, issue := range issues {
+		data.Warnings = append(data.Warnings, ReportWarning{
+			Code:      "INVALID_REPORT_DATA",
+			Message:   fmt.Sprintf("Invalid report data in %s", issue.Field),
+			Details:   issue.Issue,
+			Timestamp: time.Now(),
+		})
+	}
+	if len(issues) > 0 {
+		m.logger.WithFields(map[string]interface{}{
+			"report_id": reportID,
+			"issues":    len(issues),
+		}).Warn().Msg("Report data failed validation")
+	}
+
 	// Cache the result
 	if params.UseCache {
 		m.cache.SetReport(reportID, params, &data, report.GetRefreshInterval())
@@ -222,9 +552,327 @@ func (m *Manager) GenerateReport(ctx context.Context, reportID string, params Re
 		"tables":       len(data.Tables),
 	}).Info().Msg("Report generated successfully")
 
+	m.lastStatus.Store(reportID, data.Status)
+
+	if data.Status != StatusFailed {
+		m.notifyEOL(ctx, report, reportID, data)
+	}
+
+	event := EventReportCompleted
+	if data.Status == StatusFailed {
+		event = EventReportFailed
+	}
+	m.publish(event, reportID, data, nil)
+
 	return data, nil
 }
 
+// notifyEOL sends the instances in a generated report that weren't found
+// by the previous generation. Failures are logged, not returned, and the
+// instances are sent again after the next generation. The instances
+// notified are saved once they are sent.
+func (m *Manager) notifyEOL(ctx context.Context, report Report, reportID string, data ReportData) {
+	eolReport, ok := report.(EOLReport)
+	if m.notifier == nil || !ok {
+		return
+	}
+
+	instances := m.policy.Apply(eolReport.EOLInstances(data), time.Now())
+	current := make(map[string]bool, len(instances))
+	var fresh []notifications.EOLInstance
+
+	m.notifiedMu.Lock()
+	previous := m.notified[reportID]
+	for _, instance := range instances {
+		key := instance.ID + "/" + instance.Reason
+		current[key] = true
+		if !previous[key] {
+			fresh = append(fresh, instance)
+		}
+	}
+	m.notified[reportID] = current
+	changed := len(fresh) > 0 || len(current) != len(previous)
+	m.notifiedMu.Unlock()
+
+	if len(fresh) > 0 {
+		if err := m.notifier.NotifyEOLInstances(ctx, fresh); err != nil {
+			m.logger.WithError(err).WithField("report_id", reportID).Error().Msg("Failed to send EOL notification")
+
+			m.notifiedMu.Lock()
+			for _, instance := range fresh {
+				delete(m.notified[reportID], instance.ID+"/"+instance.Reason)
+			}
+			m.notifiedMu.Unlock()
+		}
+	}
+
+	if changed {
+		m.saveNotified()
+	}
+}
+
+// GenerateReportStream generates a report as a stream of chunks. Reports that
+// implement StreamingReport stream natively unless the report is cached, and
+// the report in their done chunk is cached as GenerateReport would. Others
+// are generated with GenerateReport and split into chunks once complete.
+// Unknown, disabled and unavailable reports and invalid parameters are
+// returned as errors rather than streamed.
+func (m *Manager) GenerateReportStream(ctx context.Context, reportID string, params ReportParams) (ReportStream, error) {
+	report, err := m.GetReport(reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !m.IsEnabled(reportID) {
+		return nil, fmt.Errorf("report %s: %w", reportID, ErrReportDisabled)
+	}
+
+	if !report.IsAvailable(ctx) {
+		return nil, fmt.Errorf("report %s is not currently available", reportID)
+	}
+
+	if err := report.Validate(params); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidParams, err)
+	}
+
+	cached := params.UseCache && !params.ForceRefresh && m.cache.GetReport(reportID, params) != nil
+	if streaming, ok := report.(StreamingReport); ok && !cached {
+		m.logger.WithField("report_id", reportID).Info().Msg("Streaming report")
+		chunks, err := streaming.GenerateReportStream(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stream report: %w", err)
+		}
+		return m.cacheStreamedReport(ctx, report, reportID, params, chunks), nil
+	}
+
+	chunks := make(chan ReportChunk)
+	go func() {
+		defer close(chunks)
+		send := NewChunkSender(ctx, chunks)
+
+		data, err := m.GenerateReport(ctx, reportID, params)
+		if err != nil {
+			send(ChunkTypeError, err.Error())
+			return
+		}
+
+		_ = send(ChunkTypeSummary, data.Summary) &&
+			send(ChunkTypeDataPoints, data.DataPoints) &&
+			send(ChunkTypeCharts, data.Charts) &&
+			send(ChunkTypeTables, data.Tables) &&
+			send(ChunkTypeDone, data)
+	}()
+
+	return chunks, nil
+}
+
+// cacheStreamedReport forwards chunks from a StreamingReport, setting the
+// metadata of the completed report in its done chunk and caching it
+func (m *Manager) cacheStreamedReport(ctx context.Context, report Report, reportID string, params ReportParams, chunks <-chan ReportChunk) ReportStream {
+	forwarded := make(chan ReportChunk)
+	go func() {
+		defer close(forwarded)
+		for chunk := range chunks {
+			if data, ok := chunk.Payload.(ReportData); ok && chunk.ChunkType == ChunkTypeDone {
+				data.Metadata = m.metadata(report)
+				chunk.Payload = data
+				m.lastStatus.Store(reportID, data.Status)
+				if params.UseCache && data.Status == StatusCompleted {
+					m.cache.SetReport(reportID, params, &data, report.GetRefreshInterval())
+				}
+			}
+
+			select {
+			case forwarded <- chunk:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return forwarded
+}
+
+// GenerateReports generates several reports concurrently, started in
+// priority order as described by runByPriority. Failures are recorded per
+// report ID rather than failing the whole request.
+func (m *Manager) GenerateReports(ctx context.Context, reportIDs []string, params ReportParams) BulkReportResponse {
+	response := BulkReportResponse{
+		Results: make(map[string]ReportData),
+		Errors:  make(map[string]string),
+	}
+
+	// Skip duplicate IDs so each report is only generated once
+	seen := make(map[string]bool)
+	var uniqueIDs []string
+	queue := NewPriorityQueue()
+	for _, reportID := range reportIDs {
+		if seen[reportID] {
+			continue
+		}
+		seen[reportID] = true
+		uniqueIDs = append(uniqueIDs, reportID)
+
+		report, err := m.GetReport(reportID)
+		if err != nil {
+			response.Errors[reportID] = err.Error()
+			continue
+		}
+		queue.Push(report)
+	}
+
+	var resultsMu sync.Mutex
+	m.runByPriority(ctx, queue, params, func(i int, report Report, err error) {
+		reportID := report.GetMetadata().ID
+		if err == nil {
+			var data ReportData
+			data, err = m.GenerateReport(ctx, reportID, params)
+			if err == nil {
+				resultsMu.Lock()
+				response.Results[reportID] = data
+				resultsMu.Unlock()
+				return
+			}
+		}
+
+		resultsMu.Lock()
+		response.Errors[reportID] = err.Error()
+		resultsMu.Unlock()
+	})
+
+	response.GeneratedAt = time.Now()
+
+	m.logger.WithFields(map[string]interface{}{
+		"requested": len(uniqueIDs),
+		"succeeded": len(response.Results),
+		"failed":    len(response.Errors),
+	}).Info().Msg("Bulk report generation complete")
+
+	return response
+}
+
+// runByPriority starts run for each queued report in priority order, capping
+// how many run at once and how many of those are PriorityLow. Reports still
+// queued when ctx is cancelled get ctx's error instead of running, and it
+// returns once every run has returned.
+func (m *Manager) runByPriority(ctx context.Context, queue *PriorityQueue, params ReportParams, run func(i int, report Report, err error)) {
+	concurrency := params.MaxConcurrency
+	if m.concurrencyLimit > 0 && (concurrency <= 0 || concurrency > m.concurrencyLimit) {
+		concurrency = m.concurrencyLimit
+	}
+	if concurrency <= 0 || concurrency > queue.Len() {
+		concurrency = queue.Len()
+	}
+	lowConcurrency := params.MaxConcurrentLowPriority
+	if lowConcurrency <= 0 {
+		lowConcurrency = DefaultMaxConcurrentLowPriority
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	lowSem := make(chan struct{}, lowConcurrency)
+
+	for i := 0; queue.Len() > 0; i++ {
+		report, _ := queue.Pop()
+		low := report.GetMetadata().Priority == PriorityLow
+
+		// The queue is in priority order, so blocking here on a low
+		// priority slot only holds back other low priority reports
+		if low && !acquire(ctx, lowSem) {
+			run(i, report, ctx.Err())
+			continue
+		}
+		if !acquire(ctx, sem) {
+			if low {
+				<-lowSem
+			}
+			run(i, report, ctx.Err())
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				<-sem
+				if low {
+					<-lowSem
+				}
+			}()
+			run(i, report, nil)
+		}()
+	}
+
+	wg.Wait()
+}
+
+// acquire takes a slot from sem, returning false without one if ctx is
+// cancelled first
+func acquire(ctx context.Context, sem chan struct{}) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	select {
+	case sem <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Overall health statuses of the reports framework
+const (
+	HealthStatusHealthy   = "healthy"
+	HealthStatusDegraded  = "degraded"
+	HealthStatusUnhealthy = "unhealthy"
+)
+
+// GetHealth checks whether each enabled report is available. A report is
+// unavailable if IsAvailable returns false or its last generation failed.
+// The framework is degraded when up to half of the enabled reports are
+// unavailable, and unhealthy when more than half are. Disabled reports are
+// counted as registered but not checked.
+func (m *Manager) GetHealth(ctx context.Context) ManagerHealth {
+	m.mu.RLock()
+	health := ManagerHealth{
+		RegisteredReports:  len(m.reports),
+		UnavailableReports: []string{},
+	}
+	enabled := 0
+	for id, report := range m.reports {
+		if !m.IsEnabled(id) {
+			continue
+		}
+		enabled++
+
+		lastStatus, _ := m.lastStatus.Load(id)
+		if !report.IsAvailable(ctx) || lastStatus == StatusFailed {
+			health.UnavailableReports = append(health.UnavailableReports, id)
+		} else {
+			health.AvailableReports++
+		}
+	}
+	m.mu.RUnlock()
+
+	sort.Strings(health.UnavailableReports)
+	health.CacheStats = m.GetCacheStats()
+
+	m.lastSummaryMu.Lock()
+	health.LastSummaryGeneratedAt = m.lastSummaryGeneratedAt
+	m.lastSummaryMu.Unlock()
+
+	unavailable := len(health.UnavailableReports)
+	switch {
+	case unavailable == 0:
+		health.OverallStatus = HealthStatusHealthy
+	case unavailable*2 <= enabled:
+		health.OverallStatus = HealthStatusDegraded
+	default:
+		health.OverallStatus = HealthStatusUnhealthy
+	}
+
+	return health
+}
+
 // GetReportsByType returns all reports of a specific type
 func (m *Manager) GetReportsByType(reportType ReportType) []ReportMetadata {
 	m.mu.RLock()
@@ -232,7 +880,7 @@ func (m *Manager) GetReportsByType(reportType ReportType) []ReportMetadata {
 
 	var filtered []ReportMetadata
 	for _, report := range m.reports {
-		metadata := report.GetMetadata()
+		metadata := m.metadata(report)
 		if metadata.Type == reportType {
 			filtered = append(filtered, metadata)
 		}
@@ -255,9 +903,17 @@ func (m *Manager) RefreshCache() {
 	m.logger.Info().Msg("Report cache cleared")
 }
 
-// GetCacheStats returns cache statistics
+// GetCache returns the manager's report cache
+func (m *Manager) GetCache() *ReportCache {
+	return m.cache
+}
+
+// GetCacheStats returns cache statistics, including how many summary and
+// report generations are currently in flight
 func (m *Manager) GetCacheStats() CacheStats {
-	return m.cache.GetStats()
+	stats := m.cache.GetStats()
+	stats.SingleflightActiveCount = m.activeFlights.Load()
+	return stats
 }
 
 // Shutdown gracefully shuts down the manager
@@ -0,0 +1,942 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"govuk-reports-dashboard/pkg/logger"
+	"govuk-reports-dashboard/pkg/notifications"
+)
+
+// stubReport returns a fixed summary, or summaryErr if set, and a completed
+// report, or reportErr if set
+type stubReport struct {
+	id          string
+	priority    Priority
+	summaryErr  error
+	reportErr   error
+	unavailable bool
+
+	// onGenerate, if set, is called whenever a summary or report is generated
+	onGenerate func(id string)
+
+	// wait, if set, is called with the generation's context after
+	// onGenerate, and a non-nil error it returns fails the generation
+	wait func(ctx context.Context) error
+}
+
+func (r *stubReport) GetMetadata() ReportMetadata {
+	return ReportMetadata{ID: r.id, Name: r.id, Priority: r.priority}
+}
+
+func (r *stubReport) GenerateSummary(ctx context.Context, params ReportParams) ([]Summary, error) {
+	if r.onGenerate != nil {
+		r.onGenerate(r.id)
+	}
+	if r.wait != nil {
+		if err := r.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if r.summaryErr != nil {
+		return nil, r.summaryErr
+	}
+	return []Summary{NewRenderer().CreateSummaryCard(r.id, "1", "", SummaryTypeCount, nil)}, nil
+}
+
+func (r *stubReport) GenerateReport(ctx context.Context, params ReportParams) (ReportData, error) {
+	if r.onGenerate != nil {
+		r.onGenerate(r.id)
+	}
+	if r.wait != nil {
+		if err := r.wait(ctx); err != nil {
+			return ReportData{}, err
+		}
+	}
+	if r.reportErr != nil {
+		return ReportData{}, r.reportErr
+	}
+	return ReportData{Status: StatusCompleted}, nil
+}
+
+func (r *stubReport) IsAvailable(ctx context.Context) bool { return !r.unavailable }
+
+func (r *stubReport) GetRefreshInterval() time.Duration { return time.Minute }
+
+func (r *stubReport) Validate(params ReportParams) error { return nil }
+
+func newTestManager(t *testing.T, reports ...Report) *Manager {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	manager := NewManager(log)
+	for _, report := range reports {
+		if err := manager.Register(report); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+	return manager
+}
+
+func TestManager_GenerateSummaryWithErrors_PartialSuccess(t *testing.T) {
+	manager := newTestManager(t,
+		&stubReport{id: "costs"},
+		&stubReport{id: "rds", summaryErr: errors.New("access denied")},
+	)
+
+	response, err := manager.GenerateSummaryWithErrors(context.Background(), ReportParams{})
+	if err != nil {
+		t.Fatalf("Expected partial success, got error: %v", err)
+	}
+
+	if len(response.Summaries) != 1 || response.Summaries[0].GetTitle() != "costs" {
+		t.Errorf("Expected the costs summary, got %+v", response.Summaries)
+	}
+	if len(response.Errors) != 1 || response.Errors[0].Details != "access denied" {
+		t.Errorf("Expected one error for rds, got %+v", response.Errors)
+	}
+	if !response.PartialSuccess {
+		t.Error("Expected PartialSuccess to be set")
+	}
+
+	summaries, err := manager.GenerateSummary(context.Background(), ReportParams{})
+	if err != nil || len(summaries) != 1 {
+		t.Errorf("Expected GenerateSummary to return 1 summary, got %d (err %v)", len(summaries), err)
+	}
+}
+
+func TestManager_GenerateSummaryWithErrors_AllFailed(t *testing.T) {
+	manager := newTestManager(t, &stubReport{id: "rds", summaryErr: errors.New("access denied")})
+
+	response, err := manager.GenerateSummaryWithErrors(context.Background(), ReportParams{})
+	if err == nil {
+		t.Fatal("Expected error when every report fails")
+	}
+	if len(response.Errors) != 1 {
+		t.Errorf("Expected the failure to be recorded, got %+v", response.Errors)
+	}
+	if response.PartialSuccess {
+		t.Error("Expected PartialSuccess to be false when nothing succeeded")
+	}
+}
+
+func TestManager_GenerateSummaryWithErrors_RunsConcurrently(t *testing.T) {
+	slow := func(id string) { time.Sleep(50 * time.Millisecond) }
+	manager := newTestManager(t,
+		&stubReport{id: "costs", priority: PriorityHigh, onGenerate: slow},
+		&stubReport{id: "rds", priority: PriorityHigh, onGenerate: slow, summaryErr: errors.New("access denied")},
+		&stubReport{id: "elasticache", priority: PriorityHigh, onGenerate: slow},
+	)
+
+	start := time.Now()
+	response, err := manager.GenerateSummaryWithErrors(context.Background(), ReportParams{})
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatalf("Expected partial success, got error: %v", err)
+	}
+
+	// Run one after another, the three reports would take 150ms
+	if elapsed >= 120*time.Millisecond {
+		t.Errorf("Expected summaries to be generated concurrently in about 50ms, took %v", elapsed)
+	}
+	if len(response.Summaries) != 2 || len(response.Errors) != 1 {
+		t.Errorf("Expected 2 summaries and 1 error, got %d summaries and %+v", len(response.Summaries), response.Errors)
+	}
+}
+
+func TestManager_WithConcurrencyLimit(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	track := func(id string) {
+		n := running.Add(1)
+		for {
+			current := maxRunning.Load()
+			if n <= current || maxRunning.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		running.Add(-1)
+	}
+
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	manager := NewManager(log, WithConcurrencyLimit(2))
+	for _, id := range []string{"costs", "rds", "elasticache", "eks"} {
+		manager.Register(&stubReport{id: id, priority: PriorityHigh, onGenerate: track})
+	}
+
+	if _, err := manager.GenerateSummary(context.Background(), ReportParams{MaxConcurrency: 10}); err != nil {
+		t.Fatalf("GenerateSummary failed: %v", err)
+	}
+	if got := maxRunning.Load(); got != 2 {
+		t.Errorf("Expected at most 2 reports at once, got %d", got)
+	}
+}
+
+// waitForFlightWaiters blocks until n callers are waiting on the manager's
+// shared generations
+func waitForFlightWaiters(tb testing.TB, manager *Manager, n int64) {
+	tb.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for manager.flightWaiters.Load() < n {
+		if time.Now().After(deadline) {
+			tb.Fatalf("Expected %d callers waiting, got %d", n, manager.flightWaiters.Load())
+		}
+		runtime.Gosched()
+	}
+}
+
+// waitForNoActiveFlights waits for cancelled generations to finish, so they
+// don't outlive the test
+func waitForNoActiveFlights(tb testing.TB, manager *Manager) {
+	tb.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for manager.activeFlights.Load() > 0 {
+		if time.Now().After(deadline) {
+			tb.Fatalf("Expected no active flights, got %d", manager.activeFlights.Load())
+		}
+		runtime.Gosched()
+	}
+}
+
+func TestManager_GenerateSummary_DeduplicatesConcurrentCacheMisses(t *testing.T) {
+	var generated atomic.Int32
+	release := make(chan struct{})
+	manager := newTestManager(t, &stubReport{id: "costs", onGenerate: func(id string) {
+		generated.Add(1)
+		<-release
+	}})
+
+	params := ReportParams{UseCache: true}
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := manager.GenerateSummary(context.Background(), params); err != nil {
+				t.Errorf("GenerateSummary failed: %v", err)
+			}
+		}()
+	}
+
+	waitForFlightWaiters(t, manager, 5)
+	close(release)
+	wg.Wait()
+
+	if got := generated.Load(); got != 1 {
+		t.Errorf("Expected the summary to be generated once, got %d", got)
+	}
+}
+
+func TestManager_GenerateReport_DeduplicatesConcurrentRequests(t *testing.T) {
+	var generated atomic.Int32
+	release := make(chan struct{})
+	manager := newTestManager(t, &stubReport{id: "costs", onGenerate: func(id string) {
+		generated.Add(1)
+		<-release
+	}})
+
+	var wg sync.WaitGroup
+	for range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := manager.GenerateReport(context.Background(), "costs", ReportParams{}); err != nil {
+				t.Errorf("GenerateReport failed: %v", err)
+			}
+		}()
+	}
+
+	waitForFlightWaiters(t, manager, 100)
+	if got := manager.GetCacheStats().SingleflightActiveCount; got != 1 {
+		t.Errorf("Expected 1 active flight, got %d", got)
+	}
+	close(release)
+	wg.Wait()
+
+	if got := generated.Load(); got != 1 {
+		t.Errorf("Expected the report to be generated once, got %d", got)
+	}
+	if got := manager.GetCacheStats().SingleflightActiveCount; got != 0 {
+		t.Errorf("Expected no active flights once generation finished, got %d", got)
+	}
+}
+
+func TestManager_GenerateReport_CancelledCallerDoesNotFailOthers(t *testing.T) {
+	release := make(chan struct{})
+	manager := newTestManager(t, &stubReport{id: "costs", onGenerate: func(id string) {
+		<-release
+	}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := manager.GenerateReport(ctx, "costs", ReportParams{})
+		firstErr <- err
+	}()
+	waitForFlightWaiters(t, manager, 1)
+
+	secondErr := make(chan error, 1)
+	go func() {
+		_, err := manager.GenerateReport(context.Background(), "costs", ReportParams{})
+		secondErr <- err
+	}()
+	waitForFlightWaiters(t, manager, 2)
+
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the cancelled caller to get context.Canceled, got %v", err)
+	}
+	close(release)
+	if err := <-secondErr; err != nil {
+		t.Errorf("Expected the other caller to get the report, got %v", err)
+	}
+}
+
+func TestManager_GenerateReport_CancelledCallersStopGeneration(t *testing.T) {
+	stopped := make(chan error, 1)
+	manager := newTestManager(t, &stubReport{id: "costs", wait: func(ctx context.Context) error {
+		<-ctx.Done()
+		stopped <- ctx.Err()
+		return ctx.Err()
+	}})
+
+	var wg sync.WaitGroup
+	ctx, cancel := context.WithCancel(context.Background())
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := manager.GenerateReport(ctx, "costs", ReportParams{}); !errors.Is(err, context.Canceled) {
+				t.Errorf("Expected context.Canceled, got %v", err)
+			}
+		}()
+	}
+	waitForFlightWaiters(t, manager, 2)
+
+	cancel()
+	wg.Wait()
+	select {
+	case err := <-stopped:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected the generation to be cancelled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the generation to stop once every caller had gone")
+	}
+	waitForNoActiveFlights(t, manager)
+}
+
+func TestManager_GenerateSummary_CancelledCallerStopsProducers(t *testing.T) {
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+	var queuedGenerated atomic.Bool
+	manager := newTestManager(t,
+		&stubReport{id: "costs", priority: PriorityHigh, wait: func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			close(stopped)
+			return ctx.Err()
+		}},
+		&stubReport{id: "rds", priority: PriorityLow, onGenerate: func(id string) {
+			queuedGenerated.Store(true)
+		}},
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		manager.GenerateSummaryWithErrors(ctx, ReportParams{UseCache: true, MaxConcurrency: 1})
+	}()
+
+	<-started
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the running summary to stop once its caller had gone")
+	}
+	<-done
+	waitForNoActiveFlights(t, manager)
+
+	if queuedGenerated.Load() {
+		t.Error("Expected the queued summary not to be generated after cancellation")
+	}
+}
+
+func TestManager_GenerateReport_ForceRefreshBypassesDeduplication(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	manager := newTestManager(t, &stubReport{id: "costs", onGenerate: func(id string) {
+		started <- struct{}{}
+		<-release
+	}})
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := manager.GenerateReport(context.Background(), "costs", ReportParams{ForceRefresh: true}); err != nil {
+				t.Errorf("GenerateReport failed: %v", err)
+			}
+		}()
+	}
+
+	// Each forced refresh must start its own generation while the others
+	// are still held, or this blocks
+	for range 3 {
+		select {
+		case <-started:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected each forced refresh to generate the report")
+		}
+	}
+	close(release)
+	wg.Wait()
+}
+
+func BenchmarkManager_GenerateReport_Concurrent(b *testing.B) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	for range b.N {
+		var generated atomic.Int32
+		release := make(chan struct{})
+		manager := NewManager(log)
+		if err := manager.Register(&stubReport{id: "costs", onGenerate: func(id string) {
+			generated.Add(1)
+			<-release
+		}}); err != nil {
+			b.Fatalf("Register failed: %v", err)
+		}
+
+		var wg sync.WaitGroup
+		for range 100 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				manager.GenerateReport(context.Background(), "costs", ReportParams{})
+			}()
+		}
+		waitForFlightWaiters(b, manager, 100)
+		close(release)
+		wg.Wait()
+
+		if got := generated.Load(); got != 1 {
+			b.Fatalf("Expected 100 concurrent calls to generate the report once, got %d", got)
+		}
+	}
+}
+
+func TestManager_SetEnabled(t *testing.T) {
+	manager := newTestManager(t, &stubReport{id: "costs"}, &stubReport{id: "rds"})
+	ctx := context.Background()
+
+	if err := manager.SetEnabled("rds", false); err != nil {
+		t.Fatalf("SetEnabled failed: %v", err)
+	}
+
+	available := manager.GetAvailableReports(ctx)
+	if len(available) != 1 || available[0].ID != "costs" || !available[0].Enabled {
+		t.Errorf("Expected only costs to be available, got %+v", available)
+	}
+	if listed := manager.ListReports(); len(listed) != 2 {
+		t.Errorf("Expected disabled reports to stay listed, got %+v", listed)
+	}
+
+	if _, err := manager.GenerateReport(ctx, "rds", ReportParams{}); !errors.Is(err, ErrReportDisabled) {
+		t.Errorf("Expected ErrReportDisabled, got %v", err)
+	}
+	response, _ := manager.GenerateSummaryWithErrors(ctx, ReportParams{})
+	if len(response.Summaries) != 1 || response.Summaries[0].GetTitle() != "costs" {
+		t.Errorf("Expected disabled reports to be left out of the summary, got %+v", response.Summaries)
+	}
+
+	if err := manager.SetEnabled("rds", true); err != nil {
+		t.Fatalf("SetEnabled failed: %v", err)
+	}
+	if _, err := manager.GenerateReport(ctx, "rds", ReportParams{}); err != nil {
+		t.Errorf("Expected re-enabled report to generate, got %v", err)
+	}
+
+	if err := manager.SetEnabled("missing", false); !errors.Is(err, ErrReportNotFound) {
+		t.Errorf("Expected ErrReportNotFound, got %v", err)
+	}
+}
+
+// recordingPublisher records published event names
+type recordingPublisher struct {
+	events []string
+}
+
+func (p *recordingPublisher) Publish(event string, data map[string]interface{}) {
+	p.events = append(p.events, event+":"+data["report_id"].(string))
+}
+
+func TestManager_PublishesReportEvents(t *testing.T) {
+	manager := newTestManager(t, &stubReport{id: "costs"})
+	publisher := &recordingPublisher{}
+	manager.SetEventPublisher(publisher)
+
+	params := ReportParams{UseCache: true}
+	if _, err := manager.GenerateReport(context.Background(), "costs", params); err != nil {
+		t.Fatalf("GenerateReport failed: %v", err)
+	}
+	// Cached reports are not published again
+	if _, err := manager.GenerateReport(context.Background(), "costs", params); err != nil {
+		t.Fatalf("GenerateReport failed: %v", err)
+	}
+
+	if len(publisher.events) != 1 || publisher.events[0] != EventReportCompleted+":costs" {
+		t.Errorf("Expected one report.completed event, got %v", publisher.events)
+	}
+}
+
+// recordingGenerations records the report IDs passed to ObserveReportGeneration
+type recordingGenerations struct {
+	reportIDs []string
+}
+
+func (r *recordingGenerations) ObserveReportGeneration(reportID string, duration time.Duration) {
+	r.reportIDs = append(r.reportIDs, reportID)
+}
+
+func TestManager_WithMetrics(t *testing.T) {
+	recorder := &recordingGenerations{}
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	manager := NewManager(log, WithMetrics(recorder))
+	manager.Register(&stubReport{id: "costs"})
+	manager.Register(&stubReport{id: "rds", reportErr: errors.New("access denied")})
+
+	params := ReportParams{UseCache: true}
+	manager.GenerateReport(context.Background(), "costs", params)
+	// Cached reports are not timed again, but failures are
+	manager.GenerateReport(context.Background(), "costs", params)
+	manager.GenerateReport(context.Background(), "rds", params)
+
+	if expected := []string{"costs", "rds"}; !reflect.DeepEqual(recorder.reportIDs, expected) {
+		t.Errorf("Expected generations %v, got %v", expected, recorder.reportIDs)
+	}
+}
+
+// eolStubReport is a stubReport whose generated reports contain instances
+type eolStubReport struct {
+	stubReport
+	mu        sync.Mutex
+	instances []notifications.EOLInstance
+}
+
+func (r *eolStubReport) setInstances(instances ...notifications.EOLInstance) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.instances = instances
+}
+
+func (r *eolStubReport) EOLInstances(data ReportData) []notifications.EOLInstance {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.instances
+}
+
+func TestManager_WithNotifier(t *testing.T) {
+	var payloads []notifications.WebhookPayload
+	var payloadsMu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload notifications.WebhookPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("Failed to decode payload: %v", err)
+		}
+		payloadsMu.Lock()
+		payloads = append(payloads, payload)
+		payloadsMu.Unlock()
+	}))
+	defer server.Close()
+
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	manager := NewManager(log, WithNotifier(notifications.NewEOLWebhookNotifier(server.URL, log), notifications.EOLAlertPolicy{NotifyOnEOL: true}))
+	report := &eolStubReport{stubReport: stubReport{id: "rds"}}
+	if err := manager.Register(report); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	generate := func() {
+		t.Helper()
+		if _, err := manager.GenerateReport(context.Background(), "rds", ReportParams{ForceRefresh: true}); err != nil {
+			t.Fatalf("GenerateReport failed: %v", err)
+		}
+	}
+	instanceIDs := func(payload notifications.WebhookPayload) []string {
+		var ids []string
+		for _, instance := range payload.Data["instances"].([]interface{}) {
+			ids = append(ids, instance.(map[string]interface{})["id"].(string))
+		}
+		return ids
+	}
+
+	report.setInstances(
+		notifications.EOLInstance{ID: "db-1", Report: "rds", Reason: notifications.EOLReasonEndOfLife},
+		notifications.EOLInstance{ID: "db-2", Report: "rds", Reason: notifications.EOLReasonOutdated},
+	)
+	generate()
+	generate()
+
+	report.setInstances(
+		notifications.EOLInstance{ID: "db-1", Report: "rds", Reason: notifications.EOLReasonEndOfLife},
+		notifications.EOLInstance{ID: "db-3", Report: "rds", Reason: notifications.EOLReasonEndOfLife},
+	)
+	generate()
+
+	payloadsMu.Lock()
+	defer payloadsMu.Unlock()
+	if len(payloads) != 2 {
+		t.Fatalf("Expected 2 notifications, got %d", len(payloads))
+	}
+	if payloads[0].Event != notifications.EventEOLInstances || payloads[0].Data["count"] != float64(1) {
+		t.Errorf("Unexpected first payload %+v", payloads[0])
+	}
+	if ids := instanceIDs(payloads[0]); !reflect.DeepEqual(ids, []string{"db-1"}) {
+		t.Errorf("Expected only the EOL instance to be notified, got %v", ids)
+	}
+	if ids := instanceIDs(payloads[1]); !reflect.DeepEqual(ids, []string{"db-3"}) {
+		t.Errorf("Expected only the new EOL instance to be notified, got %v", ids)
+	}
+}
+
+// countingEOLNotifier records the IDs of the instances it is sent
+type countingEOLNotifier struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+func (n *countingEOLNotifier) NotifyEOLInstances(ctx context.Context, instances []notifications.EOLInstance) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, instance := range instances {
+		n.ids = append(n.ids, instance.ID)
+	}
+	return nil
+}
+
+func (n *countingEOLNotifier) notified() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]string(nil), n.ids...)
+}
+
+func TestManager_WithNotificationState(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	path := filepath.Join(t.TempDir(), "notified.json")
+	policy := notifications.EOLAlertPolicy{NotifyOnEOL: true}
+
+	// generate runs the report on a new manager, as after a restart, and
+	// returns the instances it notified
+	generate := func(instances ...notifications.EOLInstance) []string {
+		t.Helper()
+		notifier := &countingEOLNotifier{}
+		manager := NewManager(log, WithNotifier(notifier, policy), WithNotificationState(path))
+		report := &eolStubReport{stubReport: stubReport{id: "rds"}}
+		report.setInstances(instances...)
+		if err := manager.Register(report); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+		if _, err := manager.GenerateReport(context.Background(), "rds", ReportParams{}); err != nil {
+			t.Fatalf("GenerateReport failed: %v", err)
+		}
+		return notifier.notified()
+	}
+
+	db1 := notifications.EOLInstance{ID: "db-1", Report: "rds", Reason: notifications.EOLReasonEndOfLife}
+	db2 := notifications.EOLInstance{ID: "db-2", Report: "rds", Reason: notifications.EOLReasonEndOfLife}
+
+	if ids := generate(db1); !reflect.DeepEqual(ids, []string{"db-1"}) {
+		t.Errorf("Expected db-1 to be notified, got %v", ids)
+	}
+	if ids := generate(db1, db2); !reflect.DeepEqual(ids, []string{"db-2"}) {
+		t.Errorf("Expected only db-2 to be notified after a restart, got %v", ids)
+	}
+}
+
+func TestManager_StartEOLChecks(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	notifier := &countingEOLNotifier{}
+	manager := NewManager(log, WithNotifier(notifier, notifications.EOLAlertPolicy{NotifyOnEOL: true}))
+	report := &eolStubReport{stubReport: stubReport{id: "rds"}}
+	report.setInstances(notifications.EOLInstance{ID: "db-1", Report: "rds", Reason: notifications.EOLReasonEndOfLife})
+	if err := manager.Register(report); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	manager.StartEOLChecks(ctx, 10*time.Millisecond)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(notifier.notified()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if ids := notifier.notified(); !reflect.DeepEqual(ids, []string{"db-1"}) {
+		t.Errorf("Expected the scheduled check to notify db-1 once, got %v", ids)
+	}
+}
+
+func TestManager_GetHealth(t *testing.T) {
+	manager := newTestManager(t,
+		&stubReport{id: "costs"},
+		&stubReport{id: "rds"},
+		&stubReport{id: "elasticache", unavailable: true},
+		&stubReport{id: "savings-plans", reportErr: errors.New("throttled")},
+	)
+	ctx := context.Background()
+
+	health := manager.GetHealth(ctx)
+	if health.OverallStatus != HealthStatusDegraded || health.RegisteredReports != 4 || health.AvailableReports != 3 {
+		t.Errorf("Expected degraded with 3 of 4 available, got %+v", health)
+	}
+	if !health.LastSummaryGeneratedAt.IsZero() {
+		t.Errorf("Expected no summary time before a summary, got %v", health.LastSummaryGeneratedAt)
+	}
+
+	// A failed generation makes a report unavailable, tipping over half
+	if _, err := manager.GenerateReport(ctx, "savings-plans", ReportParams{}); err == nil {
+		t.Fatal("Expected GenerateReport to fail")
+	}
+	if _, err := manager.GenerateSummaryWithErrors(ctx, ReportParams{}); err != nil {
+		t.Fatalf("GenerateSummaryWithErrors failed: %v", err)
+	}
+
+	health = manager.GetHealth(ctx)
+	if health.OverallStatus != HealthStatusDegraded || len(health.UnavailableReports) != 2 {
+		t.Errorf("Expected degraded with 2 unavailable, got %+v", health)
+	}
+	if health.UnavailableReports[0] != "elasticache" || health.UnavailableReports[1] != "savings-plans" {
+		t.Errorf("Expected sorted unavailable reports, got %v", health.UnavailableReports)
+	}
+	if health.LastSummaryGeneratedAt.IsZero() {
+		t.Error("Expected LastSummaryGeneratedAt to be set after a summary")
+	}
+
+	if err := manager.SetEnabled("costs", false); err != nil {
+		t.Fatalf("SetEnabled failed: %v", err)
+	}
+	if health = manager.GetHealth(ctx); health.OverallStatus != HealthStatusUnhealthy {
+		t.Errorf("Expected unhealthy with 2 of 3 enabled reports unavailable, got %+v", health)
+	}
+}
+
+func TestReportDataValidation(t *testing.T) {
+	valid := func() ReportData {
+		return ReportData{
+			Status:      StatusCompleted,
+			GeneratedAt: time.Now(),
+			DataPoints: []DataPoint{
+				{Labels: map[string]string{"application": "whitehall"}},
+			},
+			Tables: []TableData{{
+				Headers: []TableHeader{{Key: "name", Label: "Name"}, {Key: "cost", Label: "Cost"}},
+				Rows:    []map[string]interface{}{{"name": "whitehall", "cost": 10.0}},
+			}},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		modify     func(data *ReportData)
+		wantFields []string
+	}{
+		{
+			name:   "valid",
+			modify: func(data *ReportData) {},
+		},
+		{
+			name:       "unknown status",
+			modify:     func(data *ReportData) { data.Status = "done" },
+			wantFields: []string{"status"},
+		},
+		{
+			name:       "zero generation time",
+			modify:     func(data *ReportData) { data.GeneratedAt = time.Time{} },
+			wantFields: []string{"generated_at"},
+		},
+		{
+			name:       "empty label value",
+			modify:     func(data *ReportData) { data.DataPoints[0].Labels["team"] = "" },
+			wantFields: []string{"data_points[0].labels.team"},
+		},
+		{
+			name: "header without key or label",
+			modify: func(data *ReportData) {
+				data.Tables[0].Headers = append(data.Tables[0].Headers, TableHeader{})
+			},
+			wantFields: []string{"tables[0].headers[2].key", "tables[0].headers[2].label"},
+		},
+		{
+			name:       "row missing a column",
+			modify:     func(data *ReportData) { delete(data.Tables[0].Rows[0], "cost") },
+			wantFields: []string{"tables[0].rows[0].cost"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := valid()
+			tt.modify(&data)
+
+			issues := ValidateReportData(data)
+			if len(issues) != len(tt.wantFields) {
+				t.Fatalf("Expected %d issues, got %+v", len(tt.wantFields), issues)
+			}
+			for i, field := range tt.wantFields {
+				if issues[i].Field != field || issues[i].Issue == "" {
+					t.Errorf("Expected issue for %s, got %+v", field, issues[i])
+				}
+			}
+		})
+	}
+}
+
+func TestManager_GenerateSummary_LimitsLowPriorityConcurrency(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	lowPriority := func(id string) {
+		n := running.Add(1)
+		for {
+			current := maxRunning.Load()
+			if n <= current || maxRunning.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		running.Add(-1)
+	}
+
+	manager := newTestManager(t,
+		&stubReport{id: "trusted-advisor", priority: PriorityLow, onGenerate: lowPriority},
+		&stubReport{id: "savings-plans", priority: PriorityLow, onGenerate: lowPriority},
+		&stubReport{id: "eks", priority: PriorityLow, onGenerate: lowPriority},
+		&stubReport{id: "costs", priority: PriorityCritical},
+	)
+
+	summaries, err := manager.GenerateSummary(context.Background(), ReportParams{})
+	if err != nil {
+		t.Fatalf("GenerateSummary failed: %v", err)
+	}
+
+	if got := maxRunning.Load(); got != DefaultMaxConcurrentLowPriority {
+		t.Errorf("expected at most %d low priority reports at once, got %d", DefaultMaxConcurrentLowPriority, got)
+	}
+
+	var titles []string
+	for _, summary := range summaries {
+		titles = append(titles, summary.GetTitle())
+	}
+	want := []string{"costs", "eks", "savings-plans", "trusted-advisor"}
+	if !reflect.DeepEqual(titles, want) {
+		t.Errorf("expected summaries in priority order %v, got %v", want, titles)
+	}
+}
+
+func TestManager_GenerateReports_PriorityOrder(t *testing.T) {
+	var mu sync.Mutex
+	var started []string
+	record := func(id string) {
+		mu.Lock()
+		started = append(started, id)
+		mu.Unlock()
+	}
+
+	manager := newTestManager(t,
+		&stubReport{id: "trusted-advisor", priority: PriorityLow, onGenerate: record},
+		&stubReport{id: "rds", priority: PriorityMedium, onGenerate: record},
+		&stubReport{id: "costs", priority: PriorityCritical, onGenerate: record},
+		&stubReport{id: "elasticache", priority: PriorityHigh, onGenerate: record},
+	)
+
+	response := manager.GenerateReports(context.Background(),
+		[]string{"trusted-advisor", "rds", "missing", "costs", "elasticache"},
+		ReportParams{MaxConcurrency: 1})
+
+	want := []string{"costs", "elasticache", "rds", "trusted-advisor"}
+	if !reflect.DeepEqual(started, want) {
+		t.Errorf("expected reports to start in priority order %v, got %v", want, started)
+	}
+	if len(response.Results) != 4 {
+		t.Errorf("expected 4 results, got %d", len(response.Results))
+	}
+	if _, ok := response.Errors["missing"]; !ok {
+		t.Error("expected an error for the unregistered report")
+	}
+}
+
+// streamingStubReport streams a summary chunk and then the report, counting
+// how many streams it has started
+type streamingStubReport struct {
+	stubReport
+	streams atomic.Int32
+}
+
+func (r *streamingStubReport) GenerateReportStream(ctx context.Context, params ReportParams) (<-chan ReportChunk, error) {
+	r.streams.Add(1)
+	chunks := make(chan ReportChunk)
+	go func() {
+		defer close(chunks)
+		send := NewChunkSender(ctx, chunks)
+		_ = send(ChunkTypeSummary, []Summary{}) &&
+			send(ChunkTypeDone, ReportData{Status: StatusCompleted})
+	}()
+	return chunks, nil
+}
+
+func TestManager_GenerateReportStream_StreamsAndCaches(t *testing.T) {
+	report := &streamingStubReport{stubReport: stubReport{id: "costs"}}
+	manager := newTestManager(t, report)
+	params := ReportParams{UseCache: true}
+
+	for i := 0; i < 2; i++ {
+		stream, err := manager.GenerateReportStream(context.Background(), "costs", params)
+		if err != nil {
+			t.Fatalf("GenerateReportStream failed: %v", err)
+		}
+
+		var types []ChunkType
+		var done ReportChunk
+		for chunk := range stream {
+			types = append(types, chunk.ChunkType)
+			done = chunk
+		}
+		if len(types) == 0 || types[0] != ChunkTypeSummary || done.ChunkType != ChunkTypeDone {
+			t.Fatalf("Expected summary first and done last, got %v", types)
+		}
+		if data, ok := done.Payload.(ReportData); !ok || data.Metadata.ID != "costs" {
+			t.Errorf("Expected the done chunk to carry the report with metadata, got %+v", done.Payload)
+		}
+	}
+
+	// The second request is served from the report cached by the first
+	if got := report.streams.Load(); got != 1 {
+		t.Errorf("Expected 1 native stream, got %d", got)
+	}
+}
+
+func TestManager_GenerateReportStream_Errors(t *testing.T) {
+	manager := newTestManager(t, &stubReport{id: "costs"}, &stubReport{id: "rds", unavailable: true})
+
+	if _, err := manager.GenerateReportStream(context.Background(), "missing", ReportParams{}); !errors.Is(err, ErrReportNotFound) {
+		t.Errorf("Expected ErrReportNotFound, got %v", err)
+	}
+	if _, err := manager.GenerateReportStream(context.Background(), "rds", ReportParams{}); err == nil {
+		t.Error("Expected an error for an unavailable report")
+	}
+
+	if err := manager.SetEnabled("costs", false); err != nil {
+		t.Fatalf("SetEnabled failed: %v", err)
+	}
+	if _, err := manager.GenerateReportStream(context.Background(), "costs", ReportParams{}); !errors.Is(err, ErrReportDisabled) {
+		t.Errorf("Expected ErrReportDisabled, got %v", err)
+	}
+}
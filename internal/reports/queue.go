@@ -0,0 +1,76 @@
+package reports
+
+import "container/heap"
+
+// DefaultMaxConcurrentLowPriority is how many PriorityLow reports the
+// manager runs at once when ReportParams.MaxConcurrentLowPriority is unset
+const DefaultMaxConcurrentLowPriority = 1
+
+// PriorityQueue orders reports by ReportMetadata.Priority, highest first.
+// Reports with the same priority are ordered by name.
+type PriorityQueue struct {
+	items priorityHeap
+}
+
+// NewPriorityQueue creates a queue holding the given reports
+func NewPriorityQueue(reports ...Report) *PriorityQueue {
+	q := &PriorityQueue{items: make(priorityHeap, 0, len(reports))}
+	for _, report := range reports {
+		q.items = append(q.items, queuedReport{report: report, metadata: report.GetMetadata()})
+	}
+	heap.Init(&q.items)
+	return q
+}
+
+// Push adds a report to the queue
+func (q *PriorityQueue) Push(report Report) {
+	heap.Push(&q.items, queuedReport{report: report, metadata: report.GetMetadata()})
+}
+
+// Pop removes and returns the highest priority report, or false if the
+// queue is empty
+func (q *PriorityQueue) Pop() (Report, bool) {
+	if q.items.Len() == 0 {
+		return nil, false
+	}
+	return heap.Pop(&q.items).(queuedReport).report, true
+}
+
+// Len returns the number of reports in the queue
+func (q *PriorityQueue) Len() int {
+	return q.items.Len()
+}
+
+// queuedReport caches a report's metadata so it is only read once
+type queuedReport struct {
+	report   Report
+	metadata ReportMetadata
+}
+
+// priorityHeap is a max-heap of reports by priority, implementing
+// heap.Interface
+type priorityHeap []queuedReport
+
+func (h priorityHeap) Len() int { return len(h) }
+
+func (h priorityHeap) Less(i, j int) bool {
+	if h[i].metadata.Priority != h[j].metadata.Priority {
+		return h[i].metadata.Priority > h[j].metadata.Priority
+	}
+	return h[i].metadata.Name < h[j].metadata.Name
+}
+
+func (h priorityHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *priorityHeap) Push(x interface{}) {
+	*h = append(*h, x.(queuedReport))
+}
+
+func (h *priorityHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = queuedReport{}
+	*h = old[:n-1]
+	return item
+}
@@ -0,0 +1,31 @@
+package reports
+
+import "testing"
+
+func TestPriorityQueue_PopsHighestPriorityFirst(t *testing.T) {
+	queue := NewPriorityQueue(
+		&stubReport{id: "trusted-advisor", priority: PriorityLow},
+		&stubReport{id: "rds", priority: PriorityMedium},
+		&stubReport{id: "costs", priority: PriorityCritical},
+	)
+	queue.Push(&stubReport{id: "elasticache", priority: PriorityHigh})
+	queue.Push(&stubReport{id: "eks", priority: PriorityMedium})
+
+	want := []string{"costs", "elasticache", "eks", "rds", "trusted-advisor"}
+	if queue.Len() != len(want) {
+		t.Fatalf("expected %d queued reports, got %d", len(want), queue.Len())
+	}
+	for _, id := range want {
+		report, ok := queue.Pop()
+		if !ok {
+			t.Fatalf("queue empty, expected %s", id)
+		}
+		if got := report.GetMetadata().ID; got != id {
+			t.Errorf("expected %s, got %s", id, got)
+		}
+	}
+
+	if _, ok := queue.Pop(); ok {
+		t.Error("expected queue to be empty")
+	}
+}
@@ -1,6 +1,8 @@
 package reports
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"html/template"
@@ -8,6 +10,10 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/xuri/excelize/v2"
+	"gopkg.in/yaml.v3"
 )
 
 // Renderer provides common utilities for rendering report data
@@ -259,6 +265,98 @@ func (r *Renderer) GenerateTableData(title string, dataPoints []DataPoint, colum
 	return table
 }
 
+// PivotTotalKey is the key of the row totals column, and the row field
+// value of the column totals row, in tables from GeneratePivotTable
+const PivotTotalKey = "total"
+
+// pivotMissingValue labels data points without a row or column field value
+const pivotMissingValue = "unknown"
+
+// GeneratePivotTable cross-tabulates data points, with a row for each value
+// of rowField and a column for each value of colField, both read from labels
+// or values. Each cell is the sum of valueField over the matching points, or
+// 0 if none match. A final column holds row totals and a final row holds
+// column totals. Values that aren't numeric are skipped.
+func (r *Renderer) GeneratePivotTable(title string, dataPoints []DataPoint, rowField, colField, valueField string) TableData {
+	table := TableData{
+		Title: title,
+	}
+
+	cells := make(map[string]map[string]float64)
+	columnSet := make(map[string]bool)
+	for _, point := range dataPoints {
+		value, ok := r.toFloat(r.extractValue(point, valueField))
+		if !ok {
+			continue
+		}
+
+		rowValue := r.pivotKey(point, rowField)
+		colValue := r.pivotKey(point, colField)
+		if cells[rowValue] == nil {
+			cells[rowValue] = make(map[string]float64)
+		}
+		cells[rowValue][colValue] += value
+		columnSet[colValue] = true
+	}
+
+	var rowValues, colValues []string
+	for rowValue := range cells {
+		rowValues = append(rowValues, rowValue)
+	}
+	for colValue := range columnSet {
+		colValues = append(colValues, colValue)
+	}
+	sort.Strings(rowValues)
+	sort.Strings(colValues)
+
+	table.Headers = append(table.Headers, TableHeader{
+		Key:        rowField,
+		Label:      r.formatColumnName(rowField),
+		Type:       "string",
+		Sortable:   true,
+		Filterable: true,
+	})
+	for _, colValue := range colValues {
+		table.Headers = append(table.Headers, TableHeader{
+			Key:      colValue,
+			Label:    colValue,
+			Type:     "number",
+			Sortable: true,
+		})
+	}
+	table.Headers = append(table.Headers, TableHeader{
+		Key:      PivotTotalKey,
+		Label:    "Total",
+		Type:     "number",
+		Sortable: true,
+	})
+
+	columnTotals := make(map[string]float64, len(colValues))
+	grandTotal := 0.0
+	for _, rowValue := range rowValues {
+		row := map[string]interface{}{rowField: rowValue}
+		rowTotal := 0.0
+		for _, colValue := range colValues {
+			cell := cells[rowValue][colValue]
+			row[colValue] = cell
+			rowTotal += cell
+			columnTotals[colValue] += cell
+		}
+		row[PivotTotalKey] = rowTotal
+		grandTotal += rowTotal
+		table.Rows = append(table.Rows, row)
+	}
+
+	totalsRow := map[string]interface{}{rowField: "Total"}
+	for _, colValue := range colValues {
+		totalsRow[colValue] = columnTotals[colValue]
+	}
+	totalsRow[PivotTotalKey] = grandTotal
+	table.Rows = append(table.Rows, totalsRow)
+
+	return table
+}
+
 // CreateSummaryCard creates a basic summary implementation
 func (r *Renderer) CreateSummaryCard(title, value, subtitle string, summaryType SummaryType, trend *TrendData) Summary {
 	return &BasicSummary{
@@ -280,6 +378,73 @@ func (r *Renderer) ToJSON(data interface{}) (string, error) {
 	return string(bytes), nil
 }
 
+// ToYAML converts data to a YAML string. Field names match the JSON output.
+func (r *Renderer) ToYAML(data interface{}) (string, error) {
+	generic, err := toGeneric(data)
+	if err != nil {
+		return "", err
+	}
+
+	bytes, err := yaml.Marshal(generic)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// ToTOML converts data to a TOML string. Field names match the JSON output,
+// and null values are left out as TOML cannot represent them.
+func (r *Renderer) ToTOML(data interface{}) (string, error) {
+	generic, err := toGeneric(data)
+	if err != nil {
+		return "", err
+	}
+
+	bytes, err := toml.Marshal(dropNulls(generic))
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// toGeneric round-trips data through JSON so other encoders use the same
+// field names and omissions as the JSON API
+func toGeneric(data interface{}) (interface{}, error) {
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(bytes, &generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+// dropNulls removes null values from decoded JSON objects and arrays
+func dropNulls(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, item := range typed {
+			if item == nil {
+				delete(typed, key)
+			} else {
+				typed[key] = dropNulls(item)
+			}
+		}
+	case []interface{}:
+		kept := typed[:0]
+		for _, item := range typed {
+			if item != nil {
+				kept = append(kept, dropNulls(item))
+			}
+		}
+		return kept
+	}
+	return value
+}
+
 // ToHTML renders data as HTML table
 func (r *Renderer) ToHTML(data TableData) (template.HTML, error) {
 	var html strings.Builder
@@ -308,6 +473,153 @@ func (r *Renderer) ToHTML(data TableData) (template.HTML, error) {
 	return template.HTML(html.String()), nil
 }
 
+// ToCSV renders data as CSV, with a row of header labels followed by a row
+// for each table row
+func (r *Renderer) ToCSV(data TableData) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	record := make([]string, len(data.Headers))
+	for i, header := range data.Headers {
+		record[i] = header.Label
+	}
+	if err := writer.Write(record); err != nil {
+		return nil, err
+	}
+
+	for _, row := range data.Rows {
+		for i, header := range data.Headers {
+			record[i] = cellString(row[header.Key])
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// EndOfLifeMarker is the text highlighted in red in XLSX exports
+const EndOfLifeMarker = "End-of-Life"
+
+// ToXLSX renders data as an Excel workbook with one sheet, named after the
+// table title. The header row is bold, columns are sized to their contents
+// and cells containing EndOfLifeMarker are highlighted in red.
+func (r *Renderer) ToXLSX(data TableData) ([]byte, error) {
+	workbook := excelize.NewFile()
+	defer workbook.Close()
+
+	sheet := xlsxSheetName(data.Title)
+	if err := workbook.SetSheetName(workbook.GetSheetName(0), sheet); err != nil {
+		return nil, err
+	}
+
+	headerStyle, err := workbook.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return nil, err
+	}
+
+	widths := make([]int, len(data.Headers))
+	for i, header := range data.Headers {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		if err := workbook.SetCellValue(sheet, cell, header.Label); err != nil {
+			return nil, err
+		}
+		widths[i] = len(header.Label)
+	}
+	if len(data.Headers) > 0 {
+		last, _ := excelize.CoordinatesToCellName(len(data.Headers), 1)
+		if err := workbook.SetCellStyle(sheet, "A1", last, headerStyle); err != nil {
+			return nil, err
+		}
+	}
+
+	for rowIndex, row := range data.Rows {
+		for i, header := range data.Headers {
+			cell, _ := excelize.CoordinatesToCellName(i+1, rowIndex+2)
+			value := row[header.Key]
+			if err := workbook.SetCellValue(sheet, cell, value); err != nil {
+				return nil, err
+			}
+			widths[i] = max(widths[i], len(cellString(value)))
+		}
+	}
+
+	for i, width := range widths {
+		column, _ := excelize.ColumnNumberToName(i + 1)
+		// A little padding, capped so long text doesn't make huge columns
+		if err := workbook.SetColWidth(sheet, column, column, float64(min(width+2, 80))); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(data.Headers) > 0 && len(data.Rows) > 0 {
+		endOfLifeStyle, err := workbook.NewConditionalStyle(&excelize.Style{
+			Font: &excelize.Font{Color: "9C0006"},
+			Fill: excelize.Fill{Type: "pattern", Color: []string{"FFC7CE"}, Pattern: 1},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		last, _ := excelize.CoordinatesToCellName(len(data.Headers), len(data.Rows)+1)
+		if err := workbook.SetConditionalFormat(sheet, "A2:"+last, []excelize.ConditionalFormatOptions{{
+			Type:     "text",
+			Criteria: "containing",
+			Value:    EndOfLifeMarker,
+			Format:   &endOfLifeStyle,
+		}}); err != nil {
+			return nil, err
+		}
+	}
+
+	buf, err := workbook.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// xlsxSheetName makes title a valid sheet name: at most 31 characters and
+// none of : \ / ? * [ ]
+func xlsxSheetName(title string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`:\/?*[]`, r) {
+			return '-'
+		}
+		return r
+	}, strings.TrimSpace(title))
+
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	if name == "" {
+		return "Report"
+	}
+	return name
+}
+
+// cellString formats a table cell for text output, writing floats without
+// exponents and nil as an empty cell
+func cellString(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(typed), 'f', -1, 32)
+	default:
+		return fmt.Sprintf("%v", typed)
+	}
+}
+
 // Helper functions
 
 func (r *Renderer) extractValue(point DataPoint, field string) interface{} {
@@ -323,6 +635,50 @@ func (r *Renderer) extractValue(point DataPoint, field string) interface{} {
 	return nil
 }
 
+// pivotKey returns a data point's field value as a pivot table row or
+// column key
+func (r *Renderer) pivotKey(point DataPoint, field string) string {
+	value := r.extractValue(point, field)
+	if value == nil || value == "" {
+		return pivotMissingValue
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// toFloat converts a numeric value, or a string holding a number, to float64
+func (r *Renderer) toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		parsed, err := strconv.ParseFloat(v, 64)
+		return parsed, err == nil
+	}
+	return 0, false
+}
+
 func (r *Renderer) isNumeric(value interface{}) bool {
 	switch value.(type) {
 	case int, int8, int16, int32, int64:
@@ -0,0 +1,174 @@
+package reports
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestRendererFormats(t *testing.T) {
+	data := ReportData{
+		Metadata:    ReportMetadata{ID: "costs", Name: "Costs"},
+		Status:      StatusCompleted,
+		GeneratedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		DataPoints: []DataPoint{{
+			Labels: map[string]string{"service": "rds"},
+			Values: map[string]interface{}{"cost": 12.5, "note": nil},
+		}},
+	}
+	renderer := NewRenderer()
+
+	yamlOut, err := renderer.ToYAML(data)
+	if err != nil {
+		t.Fatalf("ToYAML failed: %v", err)
+	}
+	for _, want := range []string{"status: completed", "generated_at: \"2024-01-02T03:04:05Z\"", "service: rds"} {
+		if !strings.Contains(yamlOut, want) {
+			t.Errorf("YAML output missing %q:\n%s", want, yamlOut)
+		}
+	}
+
+	tomlOut, err := renderer.ToTOML(data)
+	if err != nil {
+		t.Fatalf("ToTOML failed: %v", err)
+	}
+	for _, want := range []string{"status = 'completed'", "service = 'rds'", "cost = 12.5"} {
+		if !strings.Contains(tomlOut, want) {
+			t.Errorf("TOML output missing %q:\n%s", want, tomlOut)
+		}
+	}
+	if strings.Contains(tomlOut, "note") {
+		t.Errorf("TOML output should drop null values:\n%s", tomlOut)
+	}
+}
+
+func TestGeneratePivotTable(t *testing.T) {
+	point := func(team, hosting string, cost interface{}) DataPoint {
+		return DataPoint{
+			Labels: map[string]string{"team": team, "hosting": hosting},
+			Values: map[string]interface{}{"cost": cost},
+		}
+	}
+	dataPoints := []DataPoint{
+		point("publishing", "eks", 100.0),
+		point("publishing", "eks", 50),
+		point("search", "aws", "25.5"),
+		point("publishing", "aws", 10.0),
+		point("search", "", 4.5),
+		point("search", "eks", "n/a"),
+	}
+
+	table := NewRenderer().GeneratePivotTable("Cost by Team and Platform", dataPoints, "team", "hosting", "cost")
+
+	var headerKeys []string
+	for _, header := range table.Headers {
+		headerKeys = append(headerKeys, header.Key)
+	}
+	if want := "team,aws,eks,unknown,total"; strings.Join(headerKeys, ",") != want {
+		t.Fatalf("Expected headers %s, got %v", want, headerKeys)
+	}
+
+	if len(table.Rows) != 3 {
+		t.Fatalf("Expected 2 team rows and a totals row, got %+v", table.Rows)
+	}
+	publishing, search, totals := table.Rows[0], table.Rows[1], table.Rows[2]
+
+	if publishing["team"] != "publishing" || publishing["eks"] != 150.0 || publishing["aws"] != 10.0 || publishing["unknown"] != 0.0 || publishing["total"] != 160.0 {
+		t.Errorf("Unexpected publishing row %+v", publishing)
+	}
+	if search["team"] != "search" || search["eks"] != 0.0 || search["aws"] != 25.5 || search["unknown"] != 4.5 || search["total"] != 30.0 {
+		t.Errorf("Unexpected search row %+v", search)
+	}
+	if totals["team"] != "Total" || totals["eks"] != 150.0 || totals["aws"] != 35.5 || totals["unknown"] != 4.5 || totals["total"] != 190.0 {
+		t.Errorf("Unexpected totals row %+v", totals)
+	}
+}
+
+// exportTable is a table with a comma, a quote, a float and a missing value
+func exportTable() TableData {
+	return TableData{
+		Title: "RDS: end-of-life instances",
+		Headers: []TableHeader{
+			{Key: "name", Label: "Name"},
+			{Key: "version", Label: "Version"},
+			{Key: "status", Label: "Status"},
+			{Key: "cost", Label: "Monthly Cost"},
+		},
+		Rows: []map[string]interface{}{
+			{"name": "publishing-api, primary", "version": "11.22", "status": "End-of-Life", "cost": 1234567.5},
+			{"name": `content "store"`, "version": "16.3", "status": "Current"},
+		},
+	}
+}
+
+func TestToCSV(t *testing.T) {
+	out, err := NewRenderer().ToCSV(exportTable())
+	if err != nil {
+		t.Fatalf("ToCSV failed: %v", err)
+	}
+
+	expected := "Name,Version,Status,Monthly Cost\n" +
+		"\"publishing-api, primary\",11.22,End-of-Life,1234567.5\n" +
+		"\"content \"\"store\"\"\",16.3,Current,\n"
+	if string(out) != expected {
+		t.Errorf("Expected CSV:\n%s\ngot:\n%s", expected, out)
+	}
+}
+
+func TestToXLSX(t *testing.T) {
+	out, err := NewRenderer().ToXLSX(exportTable())
+	if err != nil {
+		t.Fatalf("ToXLSX failed: %v", err)
+	}
+
+	workbook, err := excelize.OpenReader(bytes.NewReader(out))
+	if err != nil {
+		t.Fatalf("Failed to open workbook: %v", err)
+	}
+	defer workbook.Close()
+
+	// Colons aren't allowed in sheet names, and they are cut to 31 characters
+	sheets := workbook.GetSheetList()
+	if len(sheets) != 1 || sheets[0] != "RDS- end-of-life instances" {
+		t.Fatalf("Expected one sheet named after the title, got %v", sheets)
+	}
+
+	rows, err := workbook.GetRows(sheets[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || rows[0][0] != "Name" || rows[1][2] != "End-of-Life" {
+		t.Errorf("Expected a header row and 2 rows, got %v", rows)
+	}
+
+	styleID, _ := workbook.GetCellStyle(sheets[0], "A1")
+	style, err := workbook.GetStyle(styleID)
+	if err != nil || style.Font == nil || !style.Font.Bold {
+		t.Errorf("Expected a bold header row, got %+v", style)
+	}
+
+	formats, err := workbook.GetConditionalFormats(sheets[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if options := formats["A2:D3"]; len(options) != 1 || options[0].Value != EndOfLifeMarker {
+		t.Errorf("Expected End-of-Life cells to be highlighted, got %+v", formats)
+	}
+}
+
+func TestXLSXSheetName(t *testing.T) {
+	tests := map[string]string{
+		"Costs":                                "Costs",
+		"":                                     "Report",
+		"a/b\\c?d*e[f]g":                       "a-b-c-d-e-f-g",
+		"An extremely long report table title": "An extremely long report table ",
+	}
+	for title, expected := range tests {
+		if name := xlsxSheetName(title); name != expected {
+			t.Errorf("xlsxSheetName(%q) = %q, expected %q", title, name, expected)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package reports
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ValidationIssue describes one way in which report data does not match the
+// shape clients expect
+type ValidationIssue struct {
+	Field string `json:"field"`
+	Issue string `json:"issue"`
+}
+
+var validStatuses = map[ReportStatus]bool{
+	StatusPending:   true,
+	StatusRunning:   true,
+	StatusCompleted: true,
+	StatusFailed:    true,
+	StatusCached:    true,
+}
+
+// ValidateReportData checks report data for problems that would break
+// clients rendering it: an unknown status, a missing generation time, empty
+// data point labels, table headers without a key or label, and table rows
+// missing a header's key. It returns nil if the data is valid.
+func ValidateReportData(data ReportData) []ValidationIssue {
+	var issues []ValidationIssue
+
+	if !validStatuses[data.Status] {
+		issues = append(issues, ValidationIssue{
+			Field: "status",
+			Issue: fmt.Sprintf("unknown status %q", data.Status),
+		})
+	}
+
+	if data.GeneratedAt.IsZero() {
+		issues = append(issues, ValidationIssue{
+			Field: "generated_at",
+			Issue: "generation time is not set",
+		})
+	}
+
+	for i, point := range data.DataPoints {
+		// Sort label names so issues are reported in a stable order
+		names := make([]string, 0, len(point.Labels))
+		for name := range point.Labels {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if point.Labels[name] == "" {
+				issues = append(issues, ValidationIssue{
+					Field: fmt.Sprintf("data_points[%d].labels.%s", i, name),
+					Issue: "label value is empty",
+				})
+			}
+		}
+	}
+
+	for i, table := range data.Tables {
+		for j, header := range table.Headers {
+			if header.Key == "" {
+				issues = append(issues, ValidationIssue{
+					Field: fmt.Sprintf("tables[%d].headers[%d].key", i, j),
+					Issue: "header key is empty",
+				})
+			}
+			if header.Label == "" {
+				issues = append(issues, ValidationIssue{
+					Field: fmt.Sprintf("tables[%d].headers[%d].label", i, j),
+					Issue: "header label is empty",
+				})
+			}
+		}
+
+		for j, row := range table.Rows {
+			for _, header := range table.Headers {
+				if header.Key == "" {
+					continue
+				}
+				if _, exists := row[header.Key]; !exists {
+					issues = append(issues, ValidationIssue{
+						Field: fmt.Sprintf("tables[%d].rows[%d].%s", i, j, header.Key),
+						Issue: "row is missing a value for this column",
+					})
+				}
+			}
+		}
+	}
+
+	return issues
+}
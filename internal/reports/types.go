@@ -3,6 +3,8 @@ package reports
 import (
 	"context"
 	"time"
+
+	"govuk-reports-dashboard/pkg/notifications"
 )
 
 // ReportType defines the category of report
@@ -106,6 +108,58 @@ type Report interface {
 	Validate(params ReportParams) error
 }
 
+// StreamingReport is an optional extension to Report for modules that can
+// deliver parts of a report as they become available. The returned channel
+// must be closed after a "done" or "error" chunk, or once ctx is done. The
+// "done" chunk carries the whole ReportData.
+type StreamingReport interface {
+	GenerateReportStream(ctx context.Context, params ReportParams) (<-chan ReportChunk, error)
+}
+
+// EOLReport is an optional extension to Report for modules that track
+// engine versions. EOLInstances lists the instances in a report generated by
+// the module that are end-of-life, outdated or missing critical patches.
+type EOLReport interface {
+	EOLInstances(data ReportData) []notifications.EOLInstance
+}
+
+// ChunkType identifies the part of a report carried by a ReportChunk
+type ChunkType string
+
+const (
+	ChunkTypeSummary    ChunkType = "summary"
+	ChunkTypeDataPoints ChunkType = "datapoints"
+	ChunkTypeCharts     ChunkType = "charts"
+	ChunkTypeTables     ChunkType = "tables"
+	ChunkTypeDone       ChunkType = "done"
+	ChunkTypeError      ChunkType = "error"
+)
+
+// ReportChunk is one part of a streamed report
+type ReportChunk struct {
+	ChunkType      ChunkType   `json:"chunk_type"`
+	Payload        interface{} `json:"payload,omitempty"`
+	SequenceNumber int         `json:"sequence_number"`
+}
+
+// ReportStream delivers report chunks in sequence order
+type ReportStream <-chan ReportChunk
+
+// NewChunkSender returns a function that sends chunks on chunks, numbering
+// them from 1. It returns false, without sending, once ctx is done.
+func NewChunkSender(ctx context.Context, chunks chan<- ReportChunk) func(chunkType ChunkType, payload interface{}) bool {
+	sequence := 0
+	return func(chunkType ChunkType, payload interface{}) bool {
+		sequence++
+		select {
+		case chunks <- ReportChunk{ChunkType: chunkType, Payload: payload, SequenceNumber: sequence}:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
 // ReportMetadata contains information about a report module
 type ReportMetadata struct {
 	ID          string     `json:"id"`
@@ -116,6 +170,9 @@ type ReportMetadata struct {
 	Author      string     `json:"author"`
 	Tags        []string   `json:"tags"`
 	Priority    Priority   `json:"priority"`
+
+	// Enabled is filled in by the Manager; report modules leave it unset
+	Enabled bool `json:"enabled"`
 }
 
 // ReportParams contains parameters for report generation
@@ -146,6 +203,40 @@ type ReportParams struct {
 	UseCache    bool          `json:"use_cache,omitempty"`
 	CacheTTL    time.Duration `json:"cache_ttl,omitempty"`
 	ForceRefresh bool         `json:"force_refresh,omitempty"`
+	
+	// Concurrent generation. Reports start in priority order, with at most
+	// MaxConcurrentLowPriority PriorityLow reports running at once
+	// (DefaultMaxConcurrentLowPriority if unset).
+	MaxConcurrency           int `json:"max_concurrency,omitempty"`
+	MaxConcurrentLowPriority int `json:"max_concurrent_low_priority,omitempty"`
+}
+
+// BulkReportResponse aggregates the results of generating several reports
+type BulkReportResponse struct {
+	Results     map[string]ReportData `json:"results"`
+	Errors      map[string]string     `json:"errors,omitempty"`
+	GeneratedAt time.Time             `json:"generated_at"`
+}
+
+// ManagerSummaryResponse holds the dashboard summaries of every available
+// report, along with an error for each report whose summary failed.
+// PartialSuccess is set when some reports succeeded and others failed.
+type ManagerSummaryResponse struct {
+	Summaries      []Summary     `json:"summaries"`
+	Errors         []ReportError `json:"errors"`
+	PartialSuccess bool          `json:"partial_success"`
+}
+
+// ManagerHealth is the health of the reports framework, as opposed to the
+// infrastructure checked by /api/health. OverallStatus is one of the
+// HealthStatus constants.
+type ManagerHealth struct {
+	OverallStatus          string     `json:"overall_status"`
+	RegisteredReports      int        `json:"registered_reports"`
+	AvailableReports       int        `json:"available_reports"`
+	UnavailableReports     []string   `json:"unavailable_reports"`
+	CacheStats             CacheStats `json:"cache_stats"`
+	LastSummaryGeneratedAt time.Time  `json:"last_summary_generated_at"`
 }
 
 // ReportData represents the output of a report generation
@@ -0,0 +1,103 @@
+// Package api describes the versions of the dashboard's HTTP API. Every
+// route is served under a version prefix, such as /api/v1/rds/instances,
+// and at its original unversioned path under /api.
+//
+// Deprecation timeline of the unversioned routes:
+//
+//   - 2026-10-16: /api/v1 introduced. Unversioned routes are deprecated and
+//     answer with Deprecation and successor-version Link headers, but are
+//     otherwise unchanged.
+//   - No removal date: unversioned routes stay as permanent aliases of v1,
+//     as dashboards and scripts outside this repository call them. They
+//     never gain the breaking changes of a later version.
+package api
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// API versions
+const (
+	V1 = "v1"
+
+	// Unversioned is the version key of the routes served under /api
+	// without a version prefix, which behave as V1
+	Unversioned = ""
+)
+
+// Latest is the newest API version
+const Latest = V1
+
+// Supported are the versions the API serves, oldest first
+var Supported = []string{V1}
+
+// Prefix is the path every API route is served under
+const Prefix = "/api"
+
+// MediaTypePrefix and MediaTypeSuffix wrap the version in the media type
+// clients can request a version with, such as
+// "Accept: application/vnd.govuk.v1+json"
+const (
+	MediaTypePrefix = "application/vnd.govuk."
+	MediaTypeSuffix = "+json"
+)
+
+// DeprecatedAt is when each deprecated version, or Unversioned for the
+// routes without a version prefix, was deprecated. Versions missing from it
+// are current.
+var DeprecatedAt = map[string]time.Time{
+	Unversioned: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+}
+
+// IsSupported reports whether version is one the API serves
+func IsSupported(version string) bool {
+	return slices.Contains(Supported, version)
+}
+
+// VersionedPrefix returns the path prefix of a version's routes, such as
+// "/api/v1"
+func VersionedPrefix(version string) string {
+	return Prefix + "/" + version
+}
+
+// PathVersion returns the version in a request path's prefix, or
+// Unversioned if it has none. /api/v1/rds/instances is V1.
+func PathVersion(path string) string {
+	for _, version := range Supported {
+		prefix := VersionedPrefix(version)
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return version
+		}
+	}
+	return Unversioned
+}
+
+// UnversionedPath strips the version from a request path, so
+// /api/v1/reports/costs becomes /api/reports/costs. Paths without a version
+// are returned unchanged. Middleware that treats paths differently should
+// match against it, so versioned routes behave as their aliases do.
+func UnversionedPath(path string) string {
+	version := PathVersion(path)
+	if version == Unversioned {
+		return path
+	}
+	return Prefix + strings.TrimPrefix(path, VersionedPrefix(version))
+}
+
+// MediaTypeVersion returns the version requested by an Accept header such
+// as "application/vnd.govuk.v1+json", or "" if it doesn't request one. The
+// version isn't checked against Supported.
+func MediaTypeVersion(accept string) string {
+	for _, mediaType := range strings.Split(accept, ",") {
+		mediaType, _, _ = strings.Cut(mediaType, ";")
+		mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+		if version, ok := strings.CutPrefix(mediaType, MediaTypePrefix); ok {
+			if version, ok := strings.CutSuffix(version, MediaTypeSuffix); ok && version != "" {
+				return version
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,35 @@
+package api
+
+import "testing"
+
+func TestUnversionedPath(t *testing.T) {
+	tests := map[string]string{
+		"/api/v1/reports/costs": "/api/reports/costs",
+		"/api/v1":               "/api",
+		"/api/reports/costs":    "/api/reports/costs",
+		"/api/v1beta/reports":   "/api/v1beta/reports",
+		"/metrics":              "/metrics",
+	}
+
+	for path, want := range tests {
+		if got := UnversionedPath(path); got != want {
+			t.Errorf("UnversionedPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestMediaTypeVersion(t *testing.T) {
+	tests := map[string]string{
+		"application/vnd.govuk.v1+json":                  "v1",
+		"text/html, Application/VND.govuk.v2+json;q=0.5": "v2",
+		"application/json":                               "",
+		"application/vnd.govuk.+json":                    "",
+		"":                                               "",
+	}
+
+	for accept, want := range tests {
+		if got := MediaTypeVersion(accept); got != want {
+			t.Errorf("MediaTypeVersion(%q) = %q, want %q", accept, got, want)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"govuk-reports-dashboard/internal/config"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+const (
+	// DefaultRoleSessionName identifies the dashboard in CloudTrail when a
+	// role is assumed without a configured session name
+	DefaultRoleSessionName = "govuk-reports-dashboard"
+
+	// accountIDTimeout bounds the GetCallerIdentity call made when a client
+	// is created
+	accountIDTimeout = 10 * time.Second
+)
+
+// NewClientForRole creates a client for another AWS account by assuming
+// roleConfig.RoleARN with the default credentials. The account ID is looked
+// up when the client is created, which also checks the role can be assumed.
+func NewClientForRole(cfg *config.Config, roleConfig config.AssumeRoleConfig, log *logger.Logger) (*Client, error) {
+	awsCfg, err := loadConfig(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+	awsCfg.Credentials = assumeRoleCredentials(awsCfg, roleConfig)
+
+	client := newClient(cfg, awsCfg, log.WithField("role_arn", roleConfig.RoleARN))
+
+	ctx, cancel := context.WithTimeout(context.Background(), accountIDTimeout)
+	defer cancel()
+	if err := client.ResolveAccountID(ctx); err != nil {
+		return nil, fmt.Errorf("failed to assume role %s: %w", roleConfig.RoleARN, err)
+	}
+
+	return client, nil
+}
+
+// NewMultiAccountClient creates a client for the default credentials,
+// followed by one for each of cfg.AWS.AssumeRoles, with their account IDs
+// looked up
+func NewMultiAccountClient(cfg *config.Config, log *logger.Logger) ([]*Client, error) {
+	defaultClient, err := NewClient(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), accountIDTimeout)
+	defer cancel()
+	if err := defaultClient.ResolveAccountID(ctx); err != nil {
+		return nil, err
+	}
+
+	clients := []*Client{defaultClient}
+	for _, roleConfig := range cfg.AWS.AssumeRoles {
+		client, err := NewClientForRole(cfg, roleConfig, log)
+		if err != nil {
+			return nil, err
+		}
+
+		log.WithFields(map[string]interface{}{
+			"role_arn":   roleConfig.RoleARN,
+			"account_id": client.AccountID(),
+		}).Info().Msg("Assumed AWS role")
+		clients = append(clients, client)
+	}
+
+	return clients, nil
+}
+
+// assumeRoleCredentials returns cached credentials for roleConfig, assumed
+// with the credentials in awsCfg. An MFA token is prompted for, or read from
+// AWS_MFA_TOKEN, when the role needs MFA.
+func assumeRoleCredentials(awsCfg aws.Config, roleConfig config.AssumeRoleConfig) aws.CredentialsProvider {
+	sessionName := roleConfig.SessionName
+	if sessionName == "" {
+		sessionName = DefaultRoleSessionName
+	}
+
+	provider := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(awsCfg), roleConfig.RoleARN, func(options *stscreds.AssumeRoleOptions) {
+		options.RoleSessionName = sessionName
+		if roleConfig.ExternalID != "" {
+			options.ExternalID = aws.String(roleConfig.ExternalID)
+		}
+		if roleConfig.MFASerial != "" {
+			options.SerialNumber = aws.String(roleConfig.MFASerial)
+			options.TokenProvider = mfaTokenProvider
+		}
+	})
+
+	return aws.NewCredentialsCache(provider)
+}
+
+// ResolveAccountID looks up the ID of the account the client's credentials
+// belong to with sts:GetCallerIdentity, and caches it for AccountID
+func (c *Client) ResolveAccountID(ctx context.Context) error {
+	var identity getCallerIdentityOutput
+	if err := c.NewServiceClient(ServiceSTS).(*QueryAPIClient).Call(ctx, "GetCallerIdentity", nil, &identity); err != nil {
+		return fmt.Errorf("failed to get caller identity: %w", err)
+	}
+
+	c.accountID = identity.Account
+	return nil
+}
+
+// AccountID returns the AWS account ID of the client's credentials, or "" if
+// it hasn't been looked up. Clients from NewClientForRole and
+// NewMultiAccountClient always have one.
+func (c *Client) AccountID() string {
+	return c.accountID
+}
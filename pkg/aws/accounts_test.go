@@ -0,0 +1,103 @@
+package aws
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"govuk-reports-dashboard/internal/config"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+)
+
+// newSTSServer answers AssumeRole and GetCallerIdentity, recording the
+// AssumeRole parameters
+func newSTSServer(t *testing.T, accountID string, assumeRoleParams map[string]string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch r.Form.Get("Action") {
+		case "AssumeRole":
+			for _, key := range []string{"RoleArn", "RoleSessionName", "ExternalId"} {
+				assumeRoleParams[key] = r.Form.Get(key)
+			}
+			w.Write([]byte(`<AssumeRoleResponse><AssumeRoleResult>
+				<Credentials>
+					<AccessKeyId>ASIAROLE</AccessKeyId>
+					<SecretAccessKey>role-secret</SecretAccessKey>
+					<SessionToken>role-token</SessionToken>
+					<Expiration>2099-01-01T00:00:00Z</Expiration>
+				</Credentials>
+			</AssumeRoleResult></AssumeRoleResponse>`))
+		case "GetCallerIdentity":
+			w.Write([]byte(`<GetCallerIdentityResponse><GetCallerIdentityResult>
+				<Arn>arn:aws:iam::` + accountID + `:user/dashboard</Arn>
+				<Account>` + accountID + `</Account>
+			</GetCallerIdentityResult></GetCallerIdentityResponse>`))
+		default:
+			t.Errorf("Unexpected STS action %q", r.Form.Get("Action"))
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestAssumeRoleCredentials(t *testing.T) {
+	params := make(map[string]string)
+	server := newSTSServer(t, "210987654321", params)
+
+	awsCfg := aws.Config{
+		Region:       "eu-west-2",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}
+	provider := assumeRoleCredentials(awsCfg, config.AssumeRoleConfig{
+		RoleARN:    "arn:aws:iam::210987654321:role/govuk-reports-readonly",
+		ExternalID: "govuk-reports",
+	})
+
+	creds, err := provider.Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("Retrieve failed: %v", err)
+	}
+	if creds.AccessKeyID != "ASIAROLE" || creds.SessionToken != "role-token" {
+		t.Errorf("Expected the assumed role's credentials, got %+v", creds)
+	}
+
+	expected := map[string]string{
+		"RoleArn":         "arn:aws:iam::210987654321:role/govuk-reports-readonly",
+		"RoleSessionName": DefaultRoleSessionName,
+		"ExternalId":      "govuk-reports",
+	}
+	for key, value := range expected {
+		if params[key] != value {
+			t.Errorf("Expected AssumeRole %s %q, got %q", key, value, params[key])
+		}
+	}
+}
+
+func TestClient_ResolveAccountID(t *testing.T) {
+	server := newSTSServer(t, "123456789012", make(map[string]string))
+
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	client := NewClientWithCostExplorer(aws.Config{
+		Region:      "eu-west-2",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}, nil, log)
+	client.NewServiceClient(ServiceSTS).(*QueryAPIClient).WithEndpoint(server.URL)
+
+	if client.AccountID() != "" {
+		t.Errorf("Expected no account ID before it is resolved, got %q", client.AccountID())
+	}
+	if err := client.ResolveAccountID(context.Background()); err != nil {
+		t.Fatalf("ResolveAccountID failed: %v", err)
+	}
+	if client.AccountID() != "123456789012" {
+		t.Errorf("Expected account ID 123456789012, got %q", client.AccountID())
+	}
+}
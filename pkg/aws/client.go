@@ -3,26 +3,86 @@ package aws
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"govuk-reports-dashboard/internal/config"
 	"govuk-reports-dashboard/pkg/logger"
 	"govuk-reports-dashboard/pkg/common"
+	"govuk-reports-dashboard/pkg/tracing"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
 	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+	"github.com/aws/smithy-go/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// ForecastPredictionIntervalLevel is the confidence, as a percentage, of
+// the bounds returned with cost forecasts
+const ForecastPredictionIntervalLevel = 80
+
+// ErrInsufficientForecastData is returned by GetCostForecast when Cost
+// Explorer doesn't have enough cost history to make a forecast
+var ErrInsufficientForecastData = errors.New("insufficient cost data for a forecast")
+
+const (
+	EKSServiceName = "Amazon Elastic Container Service for Kubernetes"
+	EKSClusterTag  = "aws:eks:cluster-name"
+	S3ServiceName  = "Amazon Simple Storage Service"
+)
+
+// CostExplorerAPI is the subset of the Cost Explorer client used here, so it
+// can be replaced in tests
+type CostExplorerAPI interface {
+	GetCostAndUsage(ctx context.Context, params *costexplorer.GetCostAndUsageInput, optFns ...func(*costexplorer.Options)) (*costexplorer.GetCostAndUsageOutput, error)
+	GetSavingsPlansCoverage(ctx context.Context, params *costexplorer.GetSavingsPlansCoverageInput, optFns ...func(*costexplorer.Options)) (*costexplorer.GetSavingsPlansCoverageOutput, error)
+	GetSavingsPlansUtilization(ctx context.Context, params *costexplorer.GetSavingsPlansUtilizationInput, optFns ...func(*costexplorer.Options)) (*costexplorer.GetSavingsPlansUtilizationOutput, error)
+	GetCostForecast(ctx context.Context, params *costexplorer.GetCostForecastInput, optFns ...func(*costexplorer.Options)) (*costexplorer.GetCostForecastOutput, error)
+}
+
+// CostDataClient is the cost data API of Client used by the cost services, so
+// they can use MockCostDataClient in tests
+type CostDataClient interface {
+	GetCostData(ctx context.Context) ([]common.CostData, error)
+	GetCostDataBySystemTag(ctx context.Context) ([]common.CostData, error)
+	GetCostDataForApplication(ctx context.Context, appName string, lookbackMonths int) ([]common.CostData, error)
+	GetCostDataForServices(ctx context.Context, services []string, startDate, endDate time.Time) ([]common.CostData, error)
+	GetCostsBySystemTag(ctx context.Context, startDate, endDate time.Time) ([]common.CostData, error)
+	GetCostForecast(ctx context.Context, startDate, endDate time.Time) (*common.CostForecast, error)
+	ReportingCurrency() string
+	GetConfig() aws.Config
+}
+
+var _ CostDataClient = (*Client)(nil)
+
+// APICallRecorder counts AWS API calls, such as metrics.Registry
+type APICallRecorder interface {
+	RecordAWSAPICall(service, operation string, success bool)
+}
+
 type Client struct {
-	costExplorer *costexplorer.Client
-	config       aws.Config
-	logger       *logger.Logger
+	costExplorer      CostExplorerAPI
+	savingsPlans      *JSONAPIClient
+	support           *JSONAPIClient
+	config            aws.Config
+	converter         *CurrencyConverter
+	reportingCurrency string
+	logger            *logger.Logger
+	apiCalls          APICallRecorder
+	accountID         string
+
+	// Clients for other services, created by NewServiceClient
+	serviceClients   map[string]interface{}
+	serviceClientsMu sync.Mutex
 }
 
 // mfaTokenProvider prompts for MFA token input or reads from environment
@@ -43,6 +103,18 @@ func mfaTokenProvider() (string, error) {
 }
 
 func NewClient(cfg *config.Config, log *logger.Logger) (*Client, error) {
+	awsCfg, err := loadConfig(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+
+	return newClient(cfg, awsCfg, log), nil
+}
+
+// loadConfig loads the AWS config for the default credentials: explicit
+// access keys if configured, otherwise the profile or default credential
+// chain
+func loadConfig(cfg *config.Config, log *logger.Logger) (aws.Config, error) {
 	var configOptions []func(*awsconfig.LoadOptions) error
 
 	// Set region
@@ -75,16 +147,110 @@ func NewClient(cfg *config.Config, log *logger.Logger) (*Client, error) {
 		log.Info().Msg("Using AWS default credential chain (profile, environment, EC2 role)")
 	}
 
-	awsCfg, err := awsconfig.LoadDefaultConfig(context.TODO(), configOptions...)
-	if err != nil {
-		return nil, err
+	return awsconfig.LoadDefaultConfig(context.TODO(), configOptions...)
+}
+
+// newClient creates a client using awsCfg
+func newClient(cfg *config.Config, awsCfg aws.Config, log *logger.Logger) *Client {
+	client := &Client{
+		savingsPlans:      NewRESTJSONAPIClient(awsCfg, "savingsplans", SavingsPlansEndpoint, "us-east-1"),
+		support:           newSupportClient(awsCfg),
+		converter:         NewCurrencyConverter(log),
+		reportingCurrency: cfg.AWS.ReportingCurrency,
+		logger:            log,
 	}
+	client.config = client.withAPICallMiddleware(awsCfg)
+	client.costExplorer = costexplorer.NewFromConfig(client.config)
+	return client
+}
 
-	return &Client{
-		costExplorer: costexplorer.NewFromConfig(awsCfg),
-		config:       awsCfg,
+// NewClientWithCostExplorer creates a client from an existing AWS config and
+// Cost Explorer implementation, such as a stub in tests. Amounts are not
+// converted to a reporting currency.
+func NewClientWithCostExplorer(awsCfg aws.Config, costExplorer CostExplorerAPI, log *logger.Logger) *Client {
+	client := &Client{
+		costExplorer: costExplorer,
+		savingsPlans: NewRESTJSONAPIClient(awsCfg, "savingsplans", SavingsPlansEndpoint, "us-east-1"),
+		support:      newSupportClient(awsCfg),
 		logger:       log,
-	}, nil
+	}
+	client.config = client.withAPICallMiddleware(awsCfg)
+	return client
+}
+
+// SetAPICallRecorder counts every AWS API call the client makes with
+// recorder. It should be called before the client is used.
+func (c *Client) SetAPICallRecorder(recorder APICallRecorder) {
+	c.apiCalls = recorder
+	c.savingsPlans.WithAPICallRecorder(recorder)
+	c.support.WithAPICallRecorder(recorder)
+
+	c.serviceClientsMu.Lock()
+	defer c.serviceClientsMu.Unlock()
+	for _, client := range c.serviceClients {
+		setAPICallRecorder(client, recorder)
+	}
+}
+
+// setAPICallRecorder sets the recorder of a JSON or Query API client. SDK
+// clients record calls through the middleware added by withAPICallMiddleware.
+func setAPICallRecorder(client interface{}, recorder APICallRecorder) {
+	switch client := client.(type) {
+	case *JSONAPIClient:
+		client.WithAPICallRecorder(recorder)
+	case *QueryAPIClient:
+		client.WithAPICallRecorder(recorder)
+	}
+}
+
+// withAPICallMiddleware returns a copy of awsCfg whose SDK clients report
+// each call to the client's APICallRecorder, once set. The middleware runs
+// at the initialize step so retries count as a single call.
+func (c *Client) withAPICallMiddleware(awsCfg aws.Config) aws.Config {
+	awsCfg = awsCfg.Copy()
+	awsCfg.APIOptions = append(awsCfg.APIOptions, func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("RecordAPICall", func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+			service := sdkServiceName(awsmiddleware.GetServiceID(ctx))
+			operation := awsmiddleware.GetOperationName(ctx)
+			ctx, span := startAPICallSpan(ctx, service, operation)
+			defer span.End()
+
+			out, metadata, err := next.HandleInitialize(ctx, in)
+			tracing.RecordError(span, err)
+			if c.apiCalls != nil {
+				c.apiCalls.RecordAWSAPICall(service, operation, err == nil)
+			}
+			return out, metadata, err
+		}), middleware.After)
+	})
+	return awsCfg
+}
+
+// startAPICallSpan starts a span for a call to an AWS API, such as
+// "aws.rds.DescribeDBInstances"
+func startAPICallSpan(ctx context.Context, service, operation string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, "aws."+service+"."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("rpc.system", "aws-api"),
+			attribute.String("rpc.service", service),
+			attribute.String("rpc.method", operation),
+		),
+	)
+}
+
+// sdkServiceName converts an SDK service ID such as "Cost Explorer" to the
+// lower case form used by the signing names of the other clients
+func sdkServiceName(serviceID string) string {
+	return strings.ToLower(strings.ReplaceAll(serviceID, " ", ""))
+}
+
+// ReportingCurrency returns the currency cost data is normalised to
+func (c *Client) ReportingCurrency() string {
+	if c.reportingCurrency == "" {
+		return "GBP"
+	}
+	return c.reportingCurrency
 }
 
 // GetConfig returns the AWS config for use by other services
@@ -92,7 +258,10 @@ func (c *Client) GetConfig() aws.Config {
 	return c.config
 }
 
-func (c *Client) GetCostData() ([]common.CostData, error) {
+func (c *Client) GetCostData(ctx context.Context) ([]common.CostData, error) {
+	ctx, span := tracing.Start(ctx, "aws.get_cost_data")
+	defer span.End()
+
 	endTime := time.Now()
 	startTime := endTime.AddDate(0, -1, 0)
 
@@ -111,7 +280,7 @@ func (c *Client) GetCostData() ([]common.CostData, error) {
 		},
 	}
 
-	result, err := c.costExplorer.GetCostAndUsage(context.TODO(), input)
+	result, err := c.costExplorer.GetCostAndUsage(ctx, input)
 	if err != nil {
 		c.logger.WithError(err).Error().Msg("Failed to get cost and usage data from AWS")
 		return nil, err
@@ -140,10 +309,17 @@ func (c *Client) GetCostData() ([]common.CostData, error) {
 		}
 	}
 
+	if err := c.normaliseCurrency(ctx, costData); err != nil {
+		return nil, err
+	}
+
 	return costData, nil
 }
 
-func (c *Client) GetCostDataBySystemTag() ([]common.CostData, error) {
+func (c *Client) GetCostDataBySystemTag(ctx context.Context) ([]common.CostData, error) {
+	ctx, span := tracing.Start(ctx, "aws.get_cost_data_by_system_tag")
+	defer span.End()
+
 	endTime := time.Now()
 	startTime := endTime.AddDate(0, -1, 0)
 
@@ -162,7 +338,7 @@ func (c *Client) GetCostDataBySystemTag() ([]common.CostData, error) {
 		},
 	}
 
-	result, err := c.costExplorer.GetCostAndUsage(context.TODO(), input)
+	result, err := c.costExplorer.GetCostAndUsage(ctx, input)
 	if err != nil {
 		c.logger.WithError(err).Error().Msg("Failed to get cost and usage data by system tag from AWS")
 		return nil, err
@@ -200,12 +376,25 @@ func (c *Client) GetCostDataBySystemTag() ([]common.CostData, error) {
 		}
 	}
 
+	if err := c.normaliseCurrency(ctx, costData); err != nil {
+		return nil, err
+	}
+
 	return costData, nil
 }
 
-func (c *Client) GetCostDataForApplication(appName string) ([]common.CostData, error) {
+// GetCostDataForApplication fetches monthly costs for an application's system
+// tag, looking back the given number of months
+func (c *Client) GetCostDataForApplication(ctx context.Context, appName string, lookbackMonths int) ([]common.CostData, error) {
+	ctx, span := tracing.Start(ctx, "aws.get_cost_data_for_application")
+	defer span.End()
+
+	if lookbackMonths < 1 {
+		lookbackMonths = 1
+	}
+
 	endTime := time.Now()
-	startTime := endTime.AddDate(0, -1, 0)
+	startTime := endTime.AddDate(0, -lookbackMonths, 0)
 	tagPrefix := getTagPrefix()
 	targetTag := tagPrefix + appName
 
@@ -230,7 +419,7 @@ func (c *Client) GetCostDataForApplication(appName string) ([]common.CostData, e
 		},
 	}
 
-	result, err := c.costExplorer.GetCostAndUsage(context.TODO(), input)
+	result, err := c.costExplorer.GetCostAndUsage(ctx, input)
 	if err != nil {
 		c.logger.WithError(err).Error().Msgf("Failed to get cost data for application %s from AWS", appName)
 		return nil, err
@@ -259,9 +448,277 @@ func (c *Client) GetCostDataForApplication(appName string) ([]common.CostData, e
 		}
 	}
 
+	if err := c.normaliseCurrency(ctx, costData); err != nil {
+		return nil, err
+	}
+
 	return costData, nil
 }
 
+// GetCostDataForServices fetches costs for only the given AWS services over the
+// supplied date range, rather than every service in the account
+func (c *Client) GetCostDataForServices(ctx context.Context, services []string, startDate, endDate time.Time) ([]common.CostData, error) {
+	ctx, span := tracing.Start(ctx, "aws.get_cost_data_for_services")
+	defer span.End()
+
+	if len(services) == 0 {
+		return nil, fmt.Errorf("at least one service is required")
+	}
+
+	input := &costexplorer.GetCostAndUsageInput{
+		TimePeriod: &types.DateInterval{
+			Start: aws.String(startDate.Format("2006-01-02")),
+			End:   aws.String(endDate.Format("2006-01-02")),
+		},
+		Granularity: types.GranularityMonthly,
+		Metrics:     []string{"BlendedCost"},
+		GroupBy: []types.GroupDefinition{
+			{
+				Type: types.GroupDefinitionTypeDimension,
+				Key:  aws.String("SERVICE"),
+			},
+		},
+		Filter: &types.Expression{
+			Or: []types.Expression{
+				{
+					Dimensions: &types.DimensionValues{
+						Key:    types.DimensionService,
+						Values: services,
+					},
+				},
+			},
+		},
+	}
+
+	result, err := c.costExplorer.GetCostAndUsage(ctx, input)
+	if err != nil {
+		c.logger.WithError(err).Error().Msgf("Failed to get cost data for %d services from AWS", len(services))
+		return nil, err
+	}
+
+	var costData []common.CostData
+	for _, resultByTime := range result.ResultsByTime {
+		for _, group := range resultByTime.Groups {
+			if len(group.Keys) > 0 && len(group.Metrics) > 0 {
+				if blendedCost, ok := group.Metrics["BlendedCost"]; ok {
+					amount := 0.0
+					if blendedCost.Amount != nil {
+						amount = parseFloat(*blendedCost.Amount)
+					}
+
+					costData = append(costData, common.CostData{
+						Service:     group.Keys[0],
+						Amount:      amount,
+						Currency:    getStringValue(blendedCost.Unit),
+						StartDate:   parseDate(*resultByTime.TimePeriod.Start),
+						EndDate:     parseDate(*resultByTime.TimePeriod.End),
+						Granularity: "MONTHLY",
+					})
+				}
+			}
+		}
+	}
+
+	if err := c.normaliseCurrency(ctx, costData); err != nil {
+		return nil, err
+	}
+
+	return costData, nil
+}
+
+// GetCostForecast forecasts the unblended cost from startDate up to endDate,
+// converted to the reporting currency. It returns ErrInsufficientForecastData
+// if Cost Explorer has too little history to forecast from.
+func (c *Client) GetCostForecast(ctx context.Context, startDate, endDate time.Time) (*common.CostForecast, error) {
+	ctx, span := tracing.Start(ctx, "aws.get_cost_forecast")
+	defer span.End()
+
+	input := &costexplorer.GetCostForecastInput{
+		TimePeriod: &types.DateInterval{
+			Start: aws.String(startDate.Format("2006-01-02")),
+			End:   aws.String(endDate.Format("2006-01-02")),
+		},
+		Granularity:             types.GranularityMonthly,
+		Metric:                  types.MetricUnblendedCost,
+		PredictionIntervalLevel: aws.Int32(ForecastPredictionIntervalLevel),
+	}
+
+	result, err := c.costExplorer.GetCostForecast(ctx, input)
+	var unavailable *types.DataUnavailableException
+	if errors.As(err, &unavailable) {
+		return nil, fmt.Errorf("%w: %s", ErrInsufficientForecastData, unavailable.ErrorMessage())
+	}
+	if err != nil {
+		c.logger.WithError(err).Error().Msg("Failed to get cost forecast from AWS")
+		return nil, err
+	}
+	if result.Total == nil || result.Total.Amount == nil {
+		return nil, ErrInsufficientForecastData
+	}
+
+	forecast := &common.CostForecast{
+		MeanCost: parseFloat(*result.Total.Amount),
+		Currency: getStringValue(result.Total.Unit),
+		ForecastPeriod: common.DateRange{
+			Start: startDate,
+			End:   endDate,
+		},
+	}
+	for _, period := range result.ForecastResultsByTime {
+		forecast.LowerBound += parseFloat(getStringValue(period.PredictionIntervalLowerBound))
+		forecast.UpperBound += parseFloat(getStringValue(period.PredictionIntervalUpperBound))
+	}
+
+	if c.converter != nil && c.reportingCurrency != "" && forecast.Currency != "" && !strings.EqualFold(forecast.Currency, c.reportingCurrency) {
+		rate, err := c.converter.Rate(ctx, forecast.Currency, c.reportingCurrency, startDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert cost forecast from %s to %s: %w", forecast.Currency, c.reportingCurrency, err)
+		}
+		forecast.MeanCost *= rate
+		forecast.LowerBound *= rate
+		forecast.UpperBound *= rate
+		forecast.Currency = c.reportingCurrency
+	}
+
+	return forecast, nil
+}
+
+// GetEKSCostsBySystemTag fetches EKS costs grouped by the system tag. When
+// clusterName is set, results are restricted to that cluster's resources.
+// The Service field of each result holds the system tag value.
+func (c *Client) GetEKSCostsBySystemTag(ctx context.Context, clusterName string, startDate, endDate time.Time) ([]common.CostData, error) {
+	ctx, span := tracing.Start(ctx, "aws.get_eks_costs_by_system_tag")
+	defer span.End()
+
+	filter := &types.Expression{
+		Dimensions: &types.DimensionValues{
+			Key:    types.DimensionService,
+			Values: []string{EKSServiceName},
+		},
+	}
+	if clusterName != "" {
+		filter = &types.Expression{
+			And: []types.Expression{
+				*filter,
+				{
+					Tags: &types.TagValues{
+						Key:    aws.String(EKSClusterTag),
+						Values: []string{clusterName},
+					},
+				},
+			},
+		}
+	}
+
+	costData, err := c.getCostsBySystemTag(ctx, filter, startDate, endDate)
+	if err != nil {
+		c.logger.WithError(err).Error().Msg("Failed to get EKS cost data by system tag from AWS")
+		return nil, err
+	}
+	return costData, nil
+}
+
+// GetCostsBySystemTag fetches costs for the supplied date range grouped by
+// the system tag, in a single request. The Service field of each result holds
+// the system tag value.
+func (c *Client) GetCostsBySystemTag(ctx context.Context, startDate, endDate time.Time) ([]common.CostData, error) {
+	ctx, span := tracing.Start(ctx, "aws.get_costs_by_system_tag")
+	defer span.End()
+
+	costData, err := c.getCostsBySystemTag(ctx, nil, startDate, endDate)
+	if err != nil {
+		c.logger.WithError(err).Error().Msg("Failed to get cost data by system tag from AWS")
+		return nil, err
+	}
+	return costData, nil
+}
+
+// GetS3CostsBySystemTag fetches S3 costs grouped by the system tag. The
+// Service field of each result holds the system tag value.
+func (c *Client) GetS3CostsBySystemTag(ctx context.Context, startDate, endDate time.Time) ([]common.CostData, error) {
+	ctx, span := tracing.Start(ctx, "aws.get_s3_costs_by_system_tag")
+	defer span.End()
+
+	filter := &types.Expression{
+		Dimensions: &types.DimensionValues{
+			Key:    types.DimensionService,
+			Values: []string{S3ServiceName},
+		},
+	}
+
+	costData, err := c.getCostsBySystemTag(ctx, filter, startDate, endDate)
+	if err != nil {
+		c.logger.WithError(err).Error().Msg("Failed to get S3 cost data by system tag from AWS")
+		return nil, err
+	}
+	return costData, nil
+}
+
+// getCostsBySystemTag fetches costs matching filter, grouped by the system
+// tag. Costs without a system tag are left out.
+func (c *Client) getCostsBySystemTag(ctx context.Context, filter *types.Expression, startDate, endDate time.Time) ([]common.CostData, error) {
+	input := &costexplorer.GetCostAndUsageInput{
+		TimePeriod: &types.DateInterval{
+			Start: aws.String(startDate.Format("2006-01-02")),
+			End:   aws.String(endDate.Format("2006-01-02")),
+		},
+		Granularity: types.GranularityMonthly,
+		Metrics:     []string{"BlendedCost"},
+		GroupBy: []types.GroupDefinition{
+			{
+				Type: types.GroupDefinitionTypeTag,
+				Key:  aws.String("system"),
+			},
+		},
+		Filter: filter,
+	}
+
+	result, err := c.costExplorer.GetCostAndUsage(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	var costData []common.CostData
+	for _, resultByTime := range result.ResultsByTime {
+		for _, group := range resultByTime.Groups {
+			if len(group.Keys) > 0 && len(group.Metrics) > 0 {
+				// Tag group keys are returned as "system$value"
+				tagValue := strings.TrimPrefix(group.Keys[0], "system$")
+				if tagValue == "" {
+					continue
+				}
+
+				if blendedCost, ok := group.Metrics["BlendedCost"]; ok {
+					amount := 0.0
+					if blendedCost.Amount != nil {
+						amount = parseFloat(*blendedCost.Amount)
+					}
+
+					costData = append(costData, common.CostData{
+						Service:     tagValue,
+						Amount:      amount,
+						Currency:    getStringValue(blendedCost.Unit),
+						StartDate:   parseDate(*resultByTime.TimePeriod.Start),
+						EndDate:     parseDate(*resultByTime.TimePeriod.End),
+						Granularity: "MONTHLY",
+					})
+				}
+			}
+		}
+	}
+
+	if err := c.normaliseCurrency(ctx, costData); err != nil {
+		return nil, err
+	}
+
+	return costData, nil
+}
+
+// TagPrefix returns the prefix applied to application names in system tags
+func TagPrefix() string {
+	return getTagPrefix()
+}
+
 func getTagPrefix() string {
 	prefix := os.Getenv("GOVUK_APP_TAG_PREFIX")
 	if prefix == "" {
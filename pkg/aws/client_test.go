@@ -1,8 +1,18 @@
 package aws
 
 import (
+	"context"
+	"errors"
 	"os"
 	"testing"
+	"time"
+
+	"govuk-reports-dashboard/pkg/common"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
 )
 
 func TestGetTagPrefix_Default(t *testing.T) {
@@ -69,4 +79,320 @@ func TestTagMappingPatterns(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+// mockCostExplorer records the last request and returns a canned response
+type mockCostExplorer struct {
+	input  *costexplorer.GetCostAndUsageInput
+	output *costexplorer.GetCostAndUsageOutput
+
+	coverageOutput    *costexplorer.GetSavingsPlansCoverageOutput
+	utilizationOutput *costexplorer.GetSavingsPlansUtilizationOutput
+	utilizationErr    error
+
+	forecastInput  *costexplorer.GetCostForecastInput
+	forecastOutput *costexplorer.GetCostForecastOutput
+	forecastErr    error
+}
+
+func (m *mockCostExplorer) GetCostAndUsage(ctx context.Context, params *costexplorer.GetCostAndUsageInput, optFns ...func(*costexplorer.Options)) (*costexplorer.GetCostAndUsageOutput, error) {
+	m.input = params
+	return m.output, nil
+}
+
+func (m *mockCostExplorer) GetSavingsPlansCoverage(ctx context.Context, params *costexplorer.GetSavingsPlansCoverageInput, optFns ...func(*costexplorer.Options)) (*costexplorer.GetSavingsPlansCoverageOutput, error) {
+	return m.coverageOutput, nil
+}
+
+func (m *mockCostExplorer) GetSavingsPlansUtilization(ctx context.Context, params *costexplorer.GetSavingsPlansUtilizationInput, optFns ...func(*costexplorer.Options)) (*costexplorer.GetSavingsPlansUtilizationOutput, error) {
+	return m.utilizationOutput, m.utilizationErr
+}
+
+func (m *mockCostExplorer) GetCostForecast(ctx context.Context, params *costexplorer.GetCostForecastInput, optFns ...func(*costexplorer.Options)) (*costexplorer.GetCostForecastOutput, error) {
+	m.forecastInput = params
+	return m.forecastOutput, m.forecastErr
+}
+
+func TestGetCostDataForServices(t *testing.T) {
+	services := []string{"Amazon EC2", "Amazon RDS"}
+	period := &types.DateInterval{Start: aws.String("2025-01-01"), End: aws.String("2025-02-01")}
+
+	mock := &mockCostExplorer{
+		output: &costexplorer.GetCostAndUsageOutput{
+			ResultsByTime: []types.ResultByTime{
+				{
+					TimePeriod: period,
+					Groups: []types.Group{
+						{
+							Keys:    []string{"Amazon EC2"},
+							Metrics: map[string]types.MetricValue{"BlendedCost": {Amount: aws.String("120.50"), Unit: aws.String("USD")}},
+						},
+						{
+							Keys:    []string{"Amazon RDS"},
+							Metrics: map[string]types.MetricValue{"BlendedCost": {Amount: aws.String("45.25"), Unit: aws.String("USD")}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	client := &Client{costExplorer: mock, logger: log}
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	costData, err := client.GetCostDataForServices(context.Background(), services, start, end)
+	if err != nil {
+		t.Fatalf("GetCostDataForServices failed: %v", err)
+	}
+
+	input := mock.input
+	if *input.TimePeriod.Start != "2025-01-01" || *input.TimePeriod.End != "2025-02-01" {
+		t.Errorf("Unexpected time period %s - %s", *input.TimePeriod.Start, *input.TimePeriod.End)
+	}
+	if input.Filter == nil || len(input.Filter.Or) != 1 {
+		t.Fatalf("Expected filter with 1 Or expression, got %+v", input.Filter)
+	}
+	dimensions := input.Filter.Or[0].Dimensions
+	if dimensions == nil || dimensions.Key != types.DimensionService {
+		t.Fatalf("Expected SERVICE dimension, got %+v", dimensions)
+	}
+	if len(dimensions.Values) != len(services) || dimensions.Values[0] != services[0] || dimensions.Values[1] != services[1] {
+		t.Errorf("Expected services %v, got %v", services, dimensions.Values)
+	}
+
+	if len(costData) != 2 {
+		t.Fatalf("Expected 2 cost entries, got %d", len(costData))
+	}
+	if costData[0].Service != "Amazon EC2" || costData[0].Amount != 120.50 {
+		t.Errorf("Unexpected first entry: %+v", costData[0])
+	}
+	if costData[1].Service != "Amazon RDS" || costData[1].Currency != "USD" {
+		t.Errorf("Unexpected second entry: %+v", costData[1])
+	}
+}
+
+func TestGetCostDataForServices_NoServices(t *testing.T) {
+	client := &Client{}
+	if _, err := client.GetCostDataForServices(context.Background(), nil, time.Now(), time.Now()); err == nil {
+		t.Error("Expected error when no services are given")
+	}
+}
+
+func TestGetEKSCostsBySystemTag(t *testing.T) {
+	mock := &mockCostExplorer{
+		output: &costexplorer.GetCostAndUsageOutput{
+			ResultsByTime: []types.ResultByTime{
+				{
+					TimePeriod: &types.DateInterval{Start: aws.String("2025-01-01"), End: aws.String("2025-02-01")},
+					Groups: []types.Group{
+						{
+							Keys:    []string{"system$govuk-frontend"},
+							Metrics: map[string]types.MetricValue{"BlendedCost": {Amount: aws.String("80"), Unit: aws.String("USD")}},
+						},
+						{
+							Keys:    []string{"system$"},
+							Metrics: map[string]types.MetricValue{"BlendedCost": {Amount: aws.String("5"), Unit: aws.String("USD")}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	client := &Client{costExplorer: mock, logger: log}
+
+	costData, err := client.GetEKSCostsBySystemTag(context.Background(), "govuk", time.Now().AddDate(0, -1, 0), time.Now())
+	if err != nil {
+		t.Fatalf("GetEKSCostsBySystemTag failed: %v", err)
+	}
+
+	filter := mock.input.Filter
+	if filter == nil || len(filter.And) != 2 {
+		t.Fatalf("Expected And filter with service and cluster, got %+v", filter)
+	}
+	if filter.And[0].Dimensions == nil || filter.And[0].Dimensions.Values[0] != EKSServiceName {
+		t.Errorf("Expected EKS service filter, got %+v", filter.And[0].Dimensions)
+	}
+	if filter.And[1].Tags == nil || *filter.And[1].Tags.Key != EKSClusterTag || filter.And[1].Tags.Values[0] != "govuk" {
+		t.Errorf("Expected cluster tag filter, got %+v", filter.And[1].Tags)
+	}
+
+	// Untagged costs are skipped
+	if len(costData) != 1 {
+		t.Fatalf("Expected 1 cost entry, got %d", len(costData))
+	}
+	if costData[0].Service != "govuk-frontend" {
+		t.Errorf("Expected tag value 'govuk-frontend', got '%s'", costData[0].Service)
+	}
+}
+
+func TestGetCostsBySystemTag(t *testing.T) {
+	mock := &mockCostExplorer{
+		output: &costexplorer.GetCostAndUsageOutput{
+			ResultsByTime: []types.ResultByTime{
+				{
+					TimePeriod: &types.DateInterval{Start: aws.String("2025-01-01"), End: aws.String("2025-02-01")},
+					Groups: []types.Group{
+						{
+							Keys:    []string{"system$govuk-whitehall"},
+							Metrics: map[string]types.MetricValue{"BlendedCost": {Amount: aws.String("80"), Unit: aws.String("USD")}},
+						},
+						{
+							Keys:    []string{"system$"},
+							Metrics: map[string]types.MetricValue{"BlendedCost": {Amount: aws.String("500"), Unit: aws.String("USD")}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	client := &Client{costExplorer: mock, logger: log}
+
+	costData, err := client.GetCostsBySystemTag(context.Background(), time.Now().AddDate(0, -2, 0), time.Now().AddDate(0, -1, 0))
+	if err != nil {
+		t.Fatalf("GetCostsBySystemTag failed: %v", err)
+	}
+
+	if mock.input.Filter != nil {
+		t.Errorf("Expected no filter, got %+v", mock.input.Filter)
+	}
+	if len(costData) != 1 || costData[0].Service != "govuk-whitehall" || costData[0].Amount != 80 {
+		t.Errorf("Expected 80 for govuk-whitehall only, got %+v", costData)
+	}
+}
+
+func TestGetS3CostsBySystemTag(t *testing.T) {
+	mock := &mockCostExplorer{
+		output: &costexplorer.GetCostAndUsageOutput{
+			ResultsByTime: []types.ResultByTime{
+				{
+					TimePeriod: &types.DateInterval{Start: aws.String("2025-01-01"), End: aws.String("2025-02-01")},
+					Groups: []types.Group{
+						{
+							Keys:    []string{"system$govuk-asset-manager"},
+							Metrics: map[string]types.MetricValue{"BlendedCost": {Amount: aws.String("120"), Unit: aws.String("USD")}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	client := &Client{costExplorer: mock, logger: log}
+
+	costData, err := client.GetS3CostsBySystemTag(context.Background(), time.Now().AddDate(0, -1, 0), time.Now())
+	if err != nil {
+		t.Fatalf("GetS3CostsBySystemTag failed: %v", err)
+	}
+
+	filter := mock.input.Filter
+	if filter == nil || filter.Dimensions == nil || filter.Dimensions.Values[0] != S3ServiceName {
+		t.Errorf("Expected S3 service filter, got %+v", filter)
+	}
+	if len(costData) != 1 || costData[0].Service != "govuk-asset-manager" || costData[0].Amount != 120 {
+		t.Errorf("Expected 120 for govuk-asset-manager, got %+v", costData)
+	}
+}
+
+// blockingCostExplorer blocks GetCostAndUsage until the request context is done
+type blockingCostExplorer struct {
+	mockCostExplorer
+	started chan struct{}
+}
+
+func (m *blockingCostExplorer) GetCostAndUsage(ctx context.Context, params *costexplorer.GetCostAndUsageInput, optFns ...func(*costexplorer.Options)) (*costexplorer.GetCostAndUsageOutput, error) {
+	close(m.started)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestGetCostData_ContextCancelled(t *testing.T) {
+	mock := &blockingCostExplorer{started: make(chan struct{})}
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	client := &Client{costExplorer: mock, logger: log}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-mock.started
+		cancel()
+	}()
+
+	_, err := client.GetCostData(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestMockCostDataClient(t *testing.T) {
+	var client CostDataClient = &MockCostDataClient{
+		GetCostDataForApplicationResult: []common.CostData{{Service: "Amazon Relational Database Service", Amount: 10}},
+		Currency:                        "USD",
+	}
+
+	costData, err := client.GetCostDataForApplication(context.Background(), "whitehall", 1)
+	if err != nil || len(costData) != 1 {
+		t.Errorf("Unexpected GetCostDataForApplication result: %v, %v", costData, err)
+	}
+	if client.ReportingCurrency() != "USD" {
+		t.Errorf("Expected USD, got %s", client.ReportingCurrency())
+	}
+	if calls := client.(*MockCostDataClient).Calls("GetCostDataForApplication"); calls != 1 {
+		t.Errorf("Expected 1 call, got %d", calls)
+	}
+}
+
+func TestGetCostForecast(t *testing.T) {
+	mock := &mockCostExplorer{
+		forecastOutput: &costexplorer.GetCostForecastOutput{
+			Total: &types.MetricValue{Amount: aws.String("1500.50"), Unit: aws.String("USD")},
+			ForecastResultsByTime: []types.ForecastResult{
+				{
+					MeanValue:                    aws.String("1500.50"),
+					PredictionIntervalLowerBound: aws.String("1200"),
+					PredictionIntervalUpperBound: aws.String("1800"),
+				},
+			},
+		},
+	}
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	client := &Client{costExplorer: mock, logger: log}
+
+	start := time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	forecast, err := client.GetCostForecast(context.Background(), start, end)
+	if err != nil {
+		t.Fatalf("GetCostForecast failed: %v", err)
+	}
+
+	if mock.forecastInput.Granularity != types.GranularityMonthly {
+		t.Errorf("Expected MONTHLY granularity, got %s", mock.forecastInput.Granularity)
+	}
+	if *mock.forecastInput.TimePeriod.Start != "2025-06-10" || *mock.forecastInput.TimePeriod.End != "2025-07-01" {
+		t.Errorf("Unexpected forecast period %+v", mock.forecastInput.TimePeriod)
+	}
+	if forecast.MeanCost != 1500.50 || forecast.LowerBound != 1200 || forecast.UpperBound != 1800 {
+		t.Errorf("Unexpected forecast %+v", forecast)
+	}
+	if forecast.Currency != "USD" || !forecast.ForecastPeriod.End.Equal(end) {
+		t.Errorf("Unexpected forecast currency or period %+v", forecast)
+	}
+}
+
+func TestGetCostForecast_InsufficientData(t *testing.T) {
+	mock := &mockCostExplorer{
+		forecastErr: &types.DataUnavailableException{Message: aws.String("Insufficient amount of historical data")},
+	}
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	client := &Client{costExplorer: mock, logger: log}
+
+	_, err := client.GetCostForecast(context.Background(), time.Now(), time.Now().AddDate(0, 0, 7))
+	if !errors.Is(err, ErrInsufficientForecastData) {
+		t.Errorf("Expected ErrInsufficientForecastData, got %v", err)
+	}
+}
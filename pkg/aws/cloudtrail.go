@@ -0,0 +1,137 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// InfrastructureChangeEventNames are the CloudTrail events returned by
+// GetRecentInfrastructureChanges
+var InfrastructureChangeEventNames = []string{
+	"CreateDBInstance",
+	"ModifyDBInstance",
+	"DeleteDBInstance",
+	"CreateReplicationGroup",
+	"ModifyReplicationGroup",
+	"RunInstances",
+	"TerminateInstances",
+}
+
+// CloudTrailEvent is a management event recorded by CloudTrail
+type CloudTrailEvent struct {
+	EventID      string    `json:"event_id"`
+	EventName    string    `json:"event_name"`
+	EventTime    time.Time `json:"event_time"`
+	Username     string    `json:"username"`
+	ResourceName string    `json:"resource_name"`
+	ResourceType string    `json:"resource_type"`
+	AWSRegion    string    `json:"aws_region"`
+}
+
+type lookupEventsInput struct {
+	LookupAttributes []lookupAttribute `json:"LookupAttributes"`
+	StartTime        int64             `json:"StartTime"`
+	EndTime          int64             `json:"EndTime"`
+	MaxResults       int32             `json:"MaxResults"`
+	NextToken        string            `json:"NextToken,omitempty"`
+}
+
+type lookupAttribute struct {
+	AttributeKey   string `json:"AttributeKey"`
+	AttributeValue string `json:"AttributeValue"`
+}
+
+type lookupEventsOutput struct {
+	Events []struct {
+		EventId   string  `json:"EventId"`
+		EventName string  `json:"EventName"`
+		EventTime float64 `json:"EventTime"`
+		Username  string  `json:"Username"`
+		Resources []struct {
+			ResourceType string `json:"ResourceType"`
+			ResourceName string `json:"ResourceName"`
+		} `json:"Resources"`
+		CloudTrailEvent string `json:"CloudTrailEvent"`
+	} `json:"Events"`
+	NextToken string `json:"NextToken"`
+}
+
+// GetRecentInfrastructureChanges returns the CloudTrail events in
+// InfrastructureChangeEventNames from the last hours, newest first.
+// LookupEvents only filters on one event name at a time, so each name is
+// looked up separately.
+func (c *Client) GetRecentInfrastructureChanges(ctx context.Context, hours int) ([]CloudTrailEvent, error) {
+	if hours < 1 {
+		return nil, fmt.Errorf("hours must be positive, got %d", hours)
+	}
+
+	cloudTrail := c.NewServiceClient(ServiceCloudTrail).(*JSONAPIClient)
+
+	endTime := time.Now()
+	startTime := endTime.Add(-time.Duration(hours) * time.Hour)
+
+	events := []CloudTrailEvent{}
+	for _, eventName := range InfrastructureChangeEventNames {
+		input := lookupEventsInput{
+			LookupAttributes: []lookupAttribute{{AttributeKey: "EventName", AttributeValue: eventName}},
+			StartTime:        startTime.Unix(),
+			EndTime:          endTime.Unix(),
+			MaxResults:       50,
+		}
+		for {
+			var output lookupEventsOutput
+			if err := cloudTrail.Call(ctx, "LookupEvents", input, &output); err != nil {
+				c.logger.WithError(err).WithField("event_name", eventName).Error().Msg("Failed to look up CloudTrail events")
+				return nil, fmt.Errorf("failed to look up cloudtrail %s events: %w", eventName, err)
+			}
+
+			for _, event := range output.Events {
+				seconds, fraction := math.Modf(event.EventTime)
+				trailEvent := CloudTrailEvent{
+					EventID:   event.EventId,
+					EventName: event.EventName,
+					EventTime: time.Unix(int64(seconds), int64(fraction*1e9)).UTC(),
+					Username:  event.Username,
+					AWSRegion: cloudTrailEventRegion(event.CloudTrailEvent, c.config.Region),
+				}
+				if len(event.Resources) > 0 {
+					trailEvent.ResourceName = event.Resources[0].ResourceName
+					trailEvent.ResourceType = event.Resources[0].ResourceType
+				}
+				events = append(events, trailEvent)
+			}
+
+			if output.NextToken == "" {
+				break
+			}
+			input.NextToken = output.NextToken
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].EventTime.After(events[j].EventTime)
+	})
+
+	c.logger.WithFields(map[string]interface{}{
+		"hours":  hours,
+		"events": len(events),
+	}).Info().Msg("Retrieved recent infrastructure changes from CloudTrail")
+
+	return events, nil
+}
+
+// cloudTrailEventRegion returns the awsRegion of a raw CloudTrail event
+// record, or fallback if the record can't be read
+func cloudTrailEventRegion(record, fallback string) string {
+	var parsed struct {
+		AWSRegion string `json:"awsRegion"`
+	}
+	if err := json.Unmarshal([]byte(record), &parsed); err != nil || parsed.AWSRegion == "" {
+		return fallback
+	}
+	return parsed.AWSRegion
+}
@@ -0,0 +1,97 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+)
+
+func TestGetRecentInfrastructureChanges(t *testing.T) {
+	var eventNames []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if target := r.Header.Get("X-Amz-Target"); target != "com.amazonaws.cloudtrail.v20131101.CloudTrail_20131101.LookupEvents" {
+			t.Errorf("Unexpected target %s", target)
+		}
+
+		var input lookupEventsInput
+		json.NewDecoder(r.Body).Decode(&input)
+		if len(input.LookupAttributes) != 1 || input.LookupAttributes[0].AttributeKey != "EventName" {
+			t.Errorf("Expected a single EventName lookup attribute, got %+v", input.LookupAttributes)
+		}
+		if input.EndTime-input.StartTime != 24*3600 {
+			t.Errorf("Expected a 24 hour window, got %d seconds", input.EndTime-input.StartTime)
+		}
+		eventName := input.LookupAttributes[0].AttributeValue
+		eventNames = append(eventNames, eventName)
+
+		switch {
+		case eventName == "ModifyDBInstance" && input.NextToken == "":
+			w.Write([]byte(`{"Events":[{"EventId":"e1","EventName":"ModifyDBInstance","EventTime":1700000000,
+				"Username":"alice","Resources":[{"ResourceType":"AWS::RDS::DBInstance","ResourceName":"publishing-api-postgres"}],
+				"CloudTrailEvent":"{\"awsRegion\":\"eu-west-1\"}"}],"NextToken":"page-2"}`))
+		case eventName == "ModifyDBInstance":
+			w.Write([]byte(`{"Events":[{"EventId":"e2","EventName":"ModifyDBInstance","EventTime":1700000100.5,
+				"Username":"bob","Resources":[],"CloudTrailEvent":"not json"}]}`))
+		case eventName == "TerminateInstances":
+			w.Write([]byte(`{"Events":[{"EventId":"e3","EventName":"TerminateInstances","EventTime":1700000050,
+				"Username":"carol","Resources":[{"ResourceType":"AWS::EC2::Instance","ResourceName":"i-123"}]}]}`))
+		default:
+			w.Write([]byte(`{"Events":[]}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := aws.Config{
+		Region:      "eu-west-2",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	client := &Client{
+		config: cfg,
+		logger: log,
+		serviceClients: map[string]interface{}{
+			ServiceCloudTrail: newCloudTrailClient(cfg).WithEndpoint(server.URL),
+		},
+	}
+
+	events, err := client.GetRecentInfrastructureChanges(context.Background(), 24)
+	if err != nil {
+		t.Fatalf("GetRecentInfrastructureChanges failed: %v", err)
+	}
+
+	if len(eventNames) != len(InfrastructureChangeEventNames)+1 {
+		t.Errorf("Expected one lookup per event name plus a second page, got %v", eventNames)
+	}
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events, got %+v", events)
+	}
+
+	if events[0].EventID != "e2" || events[1].EventID != "e3" || events[2].EventID != "e1" {
+		t.Errorf("Expected events newest first, got %s, %s, %s", events[0].EventID, events[1].EventID, events[2].EventID)
+	}
+	if events[0].EventTime.UnixMilli() != 1700000100500 {
+		t.Errorf("Expected fractional event time to be kept, got %v", events[0].EventTime)
+	}
+	if events[0].AWSRegion != "eu-west-2" || events[0].ResourceName != "" {
+		t.Errorf("Expected client region and no resource for e2, got %+v", events[0])
+	}
+	if events[2].AWSRegion != "eu-west-1" || events[2].ResourceName != "publishing-api-postgres" || events[2].ResourceType != "AWS::RDS::DBInstance" {
+		t.Errorf("Unexpected e1 details %+v", events[2])
+	}
+}
+
+func TestGetRecentInfrastructureChangesRejectsInvalidHours(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	client := &Client{logger: log}
+
+	if _, err := client.GetRecentInfrastructureChanges(context.Background(), 0); err == nil {
+		t.Error("Expected an error for zero hours")
+	}
+}
@@ -0,0 +1,168 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Alarm states reported by CloudWatch
+const (
+	AlarmStateOK               = "OK"
+	AlarmStateAlarm            = "ALARM"
+	AlarmStateInsufficientData = "INSUFFICIENT_DATA"
+)
+
+// AlarmSummary is a CloudWatch metric alarm
+type AlarmSummary struct {
+	AlarmName  string  `json:"alarm_name"`
+	MetricName string  `json:"metric_name"`
+	Threshold  float64 `json:"threshold"`
+	State      string  `json:"state"` // "OK", "ALARM", "INSUFFICIENT_DATA"
+}
+
+type describeAlarmsInput struct {
+	AlarmTypes []string `json:"AlarmTypes"`
+	MaxRecords int32    `json:"MaxRecords"`
+	NextToken  string   `json:"NextToken,omitempty"`
+}
+
+type describeAlarmsOutput struct {
+	MetricAlarms []struct {
+		AlarmName  string  `json:"AlarmName"`
+		MetricName string  `json:"MetricName"`
+		Namespace  string  `json:"Namespace"`
+		Threshold  float64 `json:"Threshold"`
+		StateValue string  `json:"StateValue"`
+		Dimensions []struct {
+			Name  string `json:"Name"`
+			Value string `json:"Value"`
+		} `json:"Dimensions"`
+	} `json:"MetricAlarms"`
+	NextToken string `json:"NextToken"`
+}
+
+type getMetricDataInput struct {
+	MetricDataQueries []metricDataQuery `json:"MetricDataQueries"`
+	StartTime         int64             `json:"StartTime"`
+	EndTime           int64             `json:"EndTime"`
+	NextToken         string            `json:"NextToken,omitempty"`
+}
+
+type metricDataQuery struct {
+	Id         string     `json:"Id"`
+	MetricStat metricStat `json:"MetricStat"`
+}
+
+type metricStat struct {
+	Metric metric `json:"Metric"`
+	Period int32  `json:"Period"`
+	Stat   string `json:"Stat"`
+}
+
+type metric struct {
+	Namespace  string            `json:"Namespace"`
+	MetricName string            `json:"MetricName"`
+	Dimensions []metricDimension `json:"Dimensions"`
+}
+
+type metricDimension struct {
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
+}
+
+type getMetricDataOutput struct {
+	MetricDataResults []struct {
+		Id     string    `json:"Id"`
+		Values []float64 `json:"Values"`
+	} `json:"MetricDataResults"`
+	NextToken string `json:"NextToken"`
+}
+
+// GetCloudWatchAlarms returns the metric alarms in a namespace, such as
+// AWS/RDS or AWS/ElastiCache, on metrics with the given dimension value,
+// such as DBInstanceIdentifier and an instance ID. DescribeAlarms cannot
+// filter by namespace or dimension, so every alarm is fetched and filtered.
+func (c *Client) GetCloudWatchAlarms(ctx context.Context, namespace, dimension, dimensionValue string) ([]AlarmSummary, error) {
+	cloudWatch := c.NewServiceClient(ServiceCloudWatch).(*JSONAPIClient)
+
+	alarms := []AlarmSummary{}
+	input := describeAlarmsInput{AlarmTypes: []string{"MetricAlarm"}, MaxRecords: 100}
+	for {
+		var output describeAlarmsOutput
+		if err := cloudWatch.Call(ctx, "DescribeAlarms", input, &output); err != nil {
+			c.logger.WithError(err).Error().Msg("Failed to describe CloudWatch alarms")
+			return nil, fmt.Errorf("failed to describe cloudwatch alarms: %w", err)
+		}
+
+		for _, alarm := range output.MetricAlarms {
+			if alarm.Namespace != namespace {
+				continue
+			}
+			for _, dim := range alarm.Dimensions {
+				if dim.Name == dimension && dim.Value == dimensionValue {
+					alarms = append(alarms, AlarmSummary{
+						AlarmName:  alarm.AlarmName,
+						MetricName: alarm.MetricName,
+						Threshold:  alarm.Threshold,
+						State:      alarm.StateValue,
+					})
+					break
+				}
+			}
+		}
+
+		if output.NextToken == "" {
+			return alarms, nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// GetMetricMaximum returns the highest value of a metric with the given
+// dimension value, such as DatabaseConnections in AWS/RDS for an instance,
+// over the lookback period. found is false if CloudWatch has no data.
+func (c *Client) GetMetricMaximum(ctx context.Context, namespace, metricName, dimension, dimensionValue string, lookback, period time.Duration) (maximum float64, found bool, err error) {
+	cloudWatch := c.NewServiceClient(ServiceCloudWatch).(*JSONAPIClient)
+
+	query := metricDataQuery{
+		Id: "m1",
+		MetricStat: metricStat{
+			Metric: metric{
+				Namespace:  namespace,
+				MetricName: metricName,
+				Dimensions: []metricDimension{{Name: dimension, Value: dimensionValue}},
+			},
+			Period: int32(period.Seconds()),
+			Stat:   "Maximum",
+		},
+	}
+
+	endTime := time.Now()
+	input := getMetricDataInput{
+		MetricDataQueries: []metricDataQuery{query},
+		StartTime:         endTime.Add(-lookback).Unix(),
+		EndTime:           endTime.Unix(),
+	}
+	for {
+		var output getMetricDataOutput
+		if err := cloudWatch.Call(ctx, "GetMetricData", input, &output); err != nil {
+			c.logger.WithError(err).WithField("metric", metricName).Error().Msg("Failed to get CloudWatch metric data")
+			return 0, false, fmt.Errorf("failed to get cloudwatch metric data: %w", err)
+		}
+
+		for _, result := range output.MetricDataResults {
+			for _, value := range result.Values {
+				if !found || value > maximum {
+					maximum = value
+				}
+				found = true
+			}
+		}
+
+		if output.NextToken == "" {
+			return maximum, found, nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
@@ -0,0 +1,110 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+)
+
+func TestGetCloudWatchAlarms(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if target := r.Header.Get("X-Amz-Target"); target != "GraniteServiceVersion20100801.DescribeAlarms" {
+			t.Errorf("Unexpected target %s", target)
+		}
+
+		var input describeAlarmsInput
+		json.NewDecoder(r.Body).Decode(&input)
+		if input.NextToken == "" {
+			w.Write([]byte(`{"MetricAlarms":[
+				{"AlarmName":"db-1-cpu","MetricName":"CPUUtilization","Namespace":"AWS/RDS","Threshold":80,"StateValue":"OK",
+				 "Dimensions":[{"Name":"DBInstanceIdentifier","Value":"db-1"}]},
+				{"AlarmName":"db-2-cpu","MetricName":"CPUUtilization","Namespace":"AWS/RDS","Threshold":80,"StateValue":"OK",
+				 "Dimensions":[{"Name":"DBInstanceIdentifier","Value":"db-2"}]}
+			],"NextToken":"page-2"}`))
+			return
+		}
+		w.Write([]byte(`{"MetricAlarms":[
+			{"AlarmName":"db-1-storage","MetricName":"FreeStorageSpace","Namespace":"AWS/RDS","Threshold":1e10,"StateValue":"ALARM",
+			 "Dimensions":[{"Name":"DBInstanceIdentifier","Value":"db-1"}]},
+			{"AlarmName":"cache-cpu","MetricName":"CPUUtilization","Namespace":"AWS/ElastiCache","Threshold":80,"StateValue":"OK",
+			 "Dimensions":[{"Name":"DBInstanceIdentifier","Value":"db-1"}]}
+		]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := aws.Config{
+		Region:      "eu-west-2",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	client := &Client{
+		config: cfg,
+		logger: log,
+		serviceClients: map[string]interface{}{
+			ServiceCloudWatch: newCloudWatchClient(cfg).WithEndpoint(server.URL),
+		},
+	}
+
+	alarms, err := client.GetCloudWatchAlarms(context.Background(), "AWS/RDS", "DBInstanceIdentifier", "db-1")
+	if err != nil {
+		t.Fatalf("GetCloudWatchAlarms failed: %v", err)
+	}
+
+	if len(alarms) != 2 {
+		t.Fatalf("Expected 2 alarms for db-1 across both pages, got %+v", alarms)
+	}
+	if alarms[0].AlarmName != "db-1-cpu" || alarms[1].MetricName != "FreeStorageSpace" || alarms[1].State != AlarmStateAlarm {
+		t.Errorf("Unexpected alarms: %+v", alarms)
+	}
+}
+
+func TestGetMetricMaximum(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if target := r.Header.Get("X-Amz-Target"); target != "GraniteServiceVersion20100801.GetMetricData" {
+			t.Errorf("Unexpected target %s", target)
+		}
+
+		var input getMetricDataInput
+		json.NewDecoder(r.Body).Decode(&input)
+		stat := input.MetricDataQueries[0].MetricStat
+		if stat.Stat != "Maximum" || stat.Period != 3600 || stat.Metric.Dimensions[0].Value != "db-1" {
+			t.Errorf("Unexpected query: %+v", stat)
+		}
+
+		if input.NextToken == "" {
+			w.Write([]byte(`{"MetricDataResults":[{"Id":"m1","Values":[12,85]}],"NextToken":"page-2"}`))
+			return
+		}
+		w.Write([]byte(`{"MetricDataResults":[{"Id":"m1","Values":[40]}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := aws.Config{
+		Region:      "eu-west-2",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	client := &Client{
+		config: cfg,
+		logger: log,
+		serviceClients: map[string]interface{}{
+			ServiceCloudWatch: newCloudWatchClient(cfg).WithEndpoint(server.URL),
+		},
+	}
+
+	maximum, found, err := client.GetMetricMaximum(context.Background(), "AWS/RDS", "DatabaseConnections", "DBInstanceIdentifier", "db-1", 7*24*time.Hour, time.Hour)
+	if err != nil {
+		t.Fatalf("GetMetricMaximum failed: %v", err)
+	}
+	if !found || maximum != 85 {
+		t.Errorf("Expected maximum 85 across both pages, got %v (found %v)", maximum, found)
+	}
+}
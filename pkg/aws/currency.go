@@ -0,0 +1,196 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"govuk-reports-dashboard/pkg/common"
+	"govuk-reports-dashboard/pkg/logger"
+)
+
+const (
+	DefaultExchangeRateURL = "https://open.er-api.com/v6/latest"
+	ExchangeRateCacheTTL   = 24 * time.Hour
+
+	// ExchangeRateFailureBackoff is how long a failed fetch is cached for
+	// before the API is tried again
+	ExchangeRateFailureBackoff = 5 * time.Minute
+)
+
+// ErrExchangeRatesUnavailable is returned when exchange rates couldn't be
+// fetched, now or within the last ExchangeRateFailureBackoff
+var ErrExchangeRatesUnavailable = errors.New("exchange rates unavailable")
+
+// CurrencyConverter converts amounts between currencies using daily exchange
+// rates from the open.er-api.com API
+type CurrencyConverter struct {
+	baseURL    string
+	httpClient *http.Client
+	logger     *logger.Logger
+	cache      map[string]exchangeRates
+	mu         sync.Mutex
+}
+
+// exchangeRates are the cached rates for a base currency, or the error
+// fetching them failed with
+type exchangeRates struct {
+	rates     map[string]float64
+	err       error
+	expiresAt time.Time
+}
+
+type exchangeRateResponse struct {
+	Result   string             `json:"result"`
+	BaseCode string             `json:"base_code"`
+	Rates    map[string]float64 `json:"rates"`
+}
+
+// NewCurrencyConverter creates a converter that fetches rates from the
+// public exchange rate API
+func NewCurrencyConverter(log *logger.Logger) *CurrencyConverter {
+	return &CurrencyConverter{
+		baseURL:    DefaultExchangeRateURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		logger:     log,
+		cache:      make(map[string]exchangeRates),
+	}
+}
+
+// WithBaseURL overrides the exchange rate API, e.g. for testing
+func (c *CurrencyConverter) WithBaseURL(baseURL string) *CurrencyConverter {
+	c.baseURL = strings.TrimSuffix(baseURL, "/")
+	return c
+}
+
+// Convert converts amount from one currency to another. The API only
+// provides latest rates, so date is currently informational.
+func (c *CurrencyConverter) Convert(ctx context.Context, amount float64, from, to string, date time.Time) (float64, error) {
+	rate, err := c.Rate(ctx, from, to, date)
+	if err != nil {
+		return 0, err
+	}
+	return amount * rate, nil
+}
+
+// Rate returns the exchange rate from one currency to another
+func (c *CurrencyConverter) Rate(ctx context.Context, from, to string, date time.Time) (float64, error) {
+	from = strings.ToUpper(from)
+	to = strings.ToUpper(to)
+	if from == to {
+		return 1, nil
+	}
+
+	rates, err := c.getRates(ctx, from)
+	if err != nil {
+		return 0, err
+	}
+
+	rate, ok := rates[to]
+	if !ok {
+		return 0, fmt.Errorf("no exchange rate from %s to %s", from, to)
+	}
+	return rate, nil
+}
+
+// getRates returns the cached rates for a base currency, fetching them if
+// they are missing or older than ExchangeRateCacheTTL. A failed fetch is
+// logged and cached for ExchangeRateFailureBackoff, unless ctx was done.
+func (c *CurrencyConverter) getRates(ctx context.Context, base string) (map[string]float64, error) {
+	c.mu.Lock()
+	cached, ok := c.cache[base]
+	c.mu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.rates, cached.err
+	}
+
+	rates, err := c.fetchRates(ctx, base)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		err = fmt.Errorf("%w: %w", ErrExchangeRatesUnavailable, err)
+		c.logger.WithError(err).WithField("base", base).Warn().Msg("Failed to fetch exchange rates, retrying after backoff")
+		c.store(base, exchangeRates{err: err, expiresAt: time.Now().Add(ExchangeRateFailureBackoff)})
+		return nil, err
+	}
+
+	c.store(base, exchangeRates{rates: rates, expiresAt: time.Now().Add(ExchangeRateCacheTTL)})
+	return rates, nil
+}
+
+func (c *CurrencyConverter) store(base string, rates exchangeRates) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache[base] = rates
+}
+
+// fetchRates fetches the latest rates for a base currency
+func (c *CurrencyConverter) fetchRates(ctx context.Context, base string) (map[string]float64, error) {
+	url := fmt.Sprintf("%s/%s", c.baseURL, base)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create exchange rate request: %w", err)
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.logger.LogAPICall("exchange-rates", url, time.Since(start), false)
+		return nil, fmt.Errorf("failed to fetch exchange rates: %w", err)
+	}
+	defer resp.Body.Close()
+	c.logger.LogAPICall("exchange-rates", url, time.Since(start), resp.StatusCode == http.StatusOK)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("exchange rate API returned status %d", resp.StatusCode)
+	}
+
+	var body exchangeRateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode exchange rates: %w", err)
+	}
+	if body.Result != "success" {
+		return nil, fmt.Errorf("exchange rate API returned result %q", body.Result)
+	}
+
+	return body.Rates, nil
+}
+
+// normaliseCurrency converts cost data to the client's reporting currency,
+// recording the original currency and rate used. Each currency's rate is
+// looked up once. If any item can't be converted an error is returned, as
+// the data would otherwise mix currencies under one total.
+func (c *Client) normaliseCurrency(ctx context.Context, costData []common.CostData) error {
+	rates := make(map[string]float64)
+	for i := range costData {
+		item := &costData[i]
+		item.OriginalCurrency = item.Currency
+		item.ExchangeRate = 1
+
+		if c.converter == nil || c.reportingCurrency == "" || item.Currency == "" || strings.EqualFold(item.Currency, c.reportingCurrency) {
+			continue
+		}
+
+		currency := strings.ToUpper(item.Currency)
+		rate, looked := rates[currency]
+		if !looked {
+			var err error
+			rate, err = c.converter.Rate(ctx, currency, c.reportingCurrency, item.StartDate)
+			if err != nil {
+				return fmt.Errorf("failed to convert costs from %s to %s: %w", currency, c.reportingCurrency, err)
+			}
+			rates[currency] = rate
+		}
+
+		item.Amount *= rate
+		item.Currency = c.reportingCurrency
+		item.ExchangeRate = rate
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"govuk-reports-dashboard/pkg/common"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+)
+
+func newTestConverter(t *testing.T) (*CurrencyConverter, *int32) {
+	t.Helper()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path != "/USD" {
+			w.Write([]byte(`{"result":"error","error-type":"unsupported-code"}`))
+			return
+		}
+		w.Write([]byte(`{"result":"success","base_code":"USD","rates":{"USD":1,"GBP":0.8}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	return NewCurrencyConverter(log).WithBaseURL(server.URL), &requests
+}
+
+func TestCurrencyConverter_Convert(t *testing.T) {
+	converter, requests := newTestConverter(t)
+
+	for i := 0; i < 2; i++ {
+		amount, err := converter.Convert(context.Background(), 100, "usd", "GBP", time.Now())
+		if err != nil {
+			t.Fatalf("Convert failed: %v", err)
+		}
+		if amount != 80 {
+			t.Errorf("Expected 80, got %f", amount)
+		}
+	}
+
+	if got := atomic.LoadInt32(requests); got != 1 {
+		t.Errorf("Expected rates to be cached after 1 request, got %d", got)
+	}
+
+	if amount, err := converter.Convert(context.Background(), 100, "GBP", "GBP", time.Now()); err != nil || amount != 100 {
+		t.Errorf("Expected same-currency conversion to be a no-op, got %f, %v", amount, err)
+	}
+
+	if _, err := converter.Convert(context.Background(), 100, "XYZ", "GBP", time.Now()); err == nil {
+		t.Error("Expected error for unsupported currency")
+	}
+}
+
+func TestGetCostData_NormalisesCurrency(t *testing.T) {
+	converter, _ := newTestConverter(t)
+	mock := &mockCostExplorer{
+		output: &costexplorer.GetCostAndUsageOutput{
+			ResultsByTime: []types.ResultByTime{
+				{
+					TimePeriod: &types.DateInterval{Start: aws.String("2025-01-01"), End: aws.String("2025-02-01")},
+					Groups: []types.Group{
+						{
+							Keys:    []string{"Amazon EC2"},
+							Metrics: map[string]types.MetricValue{"BlendedCost": {Amount: aws.String("120.50"), Unit: aws.String("USD")}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	client := &Client{costExplorer: mock, converter: converter, reportingCurrency: "GBP", logger: log}
+
+	costData, err := client.GetCostDataForServices(context.Background(), []string{"Amazon EC2"}, time.Now().AddDate(0, -1, 0), time.Now())
+	if err != nil {
+		t.Fatalf("GetCostDataForServices failed: %v", err)
+	}
+
+	if len(costData) != 1 {
+		t.Fatalf("Expected 1 cost item, got %d", len(costData))
+	}
+	item := costData[0]
+	if item.Currency != "GBP" || item.OriginalCurrency != "USD" || item.ExchangeRate != 0.8 {
+		t.Errorf("Unexpected currency fields: %+v", item)
+	}
+	if item.Amount < 96.39 || item.Amount > 96.41 {
+		t.Errorf("Expected converted amount 96.40, got %f", item.Amount)
+	}
+}
+
+func TestCurrencyConverter_CachesFailures(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	converter := NewCurrencyConverter(log).WithBaseURL(server.URL)
+
+	// A cancelled request isn't cached as a failure
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := converter.Rate(ctx, "USD", "GBP", time.Now()); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the context's error, got %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := converter.Rate(context.Background(), "USD", "GBP", time.Now()); !errors.Is(err, ErrExchangeRatesUnavailable) {
+			t.Fatalf("Expected ErrExchangeRatesUnavailable, got %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("Expected the failure to be cached after 1 request, got %d", got)
+	}
+}
+
+func TestNormaliseCurrency_FetchesRatesOnce(t *testing.T) {
+	converter, requests := newTestConverter(t)
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	client := &Client{converter: converter, reportingCurrency: "GBP", logger: log}
+
+	costData := []common.CostData{
+		{Service: "Amazon EC2", Amount: 100, Currency: "USD"},
+		{Service: "Amazon RDS", Amount: 50, Currency: "usd"},
+		{Service: "Amazon S3", Amount: 10, Currency: "GBP"},
+	}
+	if err := client.normaliseCurrency(context.Background(), costData); err != nil {
+		t.Fatalf("normaliseCurrency failed: %v", err)
+	}
+
+	if got := atomic.LoadInt32(requests); got != 1 {
+		t.Errorf("Expected 1 rate request, got %d", got)
+	}
+	if costData[1].Amount != 40 || costData[1].Currency != "GBP" {
+		t.Errorf("Expected converted RDS cost, got %+v", costData[1])
+	}
+}
+
+func TestNormaliseCurrency_UnconvertibleCurrency(t *testing.T) {
+	converter, _ := newTestConverter(t)
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	client := &Client{converter: converter, reportingCurrency: "GBP", logger: log}
+
+	// Summing these would label XYZ amounts as GBP
+	costData := []common.CostData{
+		{Service: "Amazon EC2", Amount: 100, Currency: "USD"},
+		{Service: "Unknown", Amount: 10, Currency: "XYZ"},
+	}
+	if err := client.normaliseCurrency(context.Background(), costData); !errors.Is(err, ErrExchangeRatesUnavailable) {
+		t.Errorf("Expected ErrExchangeRatesUnavailable, got %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package aws
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"net/url"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed ec2_pricing.yaml
+var defaultEC2PricingYAML []byte
+
+// EC2PricingMap maps instance types to their estimated hourly cost. It is
+// loaded from the embedded ec2_pricing.yaml and can be replaced to use
+// different prices.
+var EC2PricingMap = loadEC2Pricing()
+
+func loadEC2Pricing() map[string]float64 {
+	pricing := make(map[string]float64)
+	if err := yaml.Unmarshal(defaultEC2PricingYAML, &pricing); err != nil {
+		panic(fmt.Sprintf("invalid embedded EC2 pricing: %v", err))
+	}
+	return pricing
+}
+
+// EC2Instance is a running EC2 instance. Application and Environment come
+// from its system and environment tags.
+type EC2Instance struct {
+	InstanceID          string            `json:"instance_id"`
+	InstanceType        string            `json:"instance_type"`
+	LaunchTime          time.Time         `json:"launch_time"`
+	Tags                map[string]string `json:"tags"`
+	Application         string            `json:"application,omitempty"`
+	Environment         string            `json:"environment,omitempty"`
+	AvailabilityZone    string            `json:"availability_zone"`
+	EstimatedHourlyCost float64           `json:"estimated_hourly_cost"`
+}
+
+type describeInstancesOutput struct {
+	Reservations []struct {
+		Instances []struct {
+			InstanceID   string    `xml:"instanceId"`
+			InstanceType string    `xml:"instanceType"`
+			LaunchTime   time.Time `xml:"launchTime"`
+			Placement    struct {
+				AvailabilityZone string `xml:"availabilityZone"`
+			} `xml:"placement"`
+			Tags []struct {
+				Key   string `xml:"key"`
+				Value string `xml:"value"`
+			} `xml:"tagSet>item"`
+		} `xml:"instancesSet>item"`
+	} `xml:"reservationSet>item"`
+	NextToken string `xml:"nextToken"`
+}
+
+// GetEC2Instances returns every running EC2 instance in the client's region
+func (c *Client) GetEC2Instances(ctx context.Context) ([]EC2Instance, error) {
+	ec2 := c.NewServiceClient(ServiceEC2).(*QueryAPIClient)
+
+	instances := []EC2Instance{}
+	params := url.Values{
+		"Filter.1.Name":    {"instance-state-name"},
+		"Filter.1.Value.1": {"running"},
+		"MaxResults":       {"1000"},
+	}
+	for {
+		var output describeInstancesOutput
+		if err := ec2.Call(ctx, "DescribeInstances", params, &output); err != nil {
+			c.logger.WithError(err).Error().Msg("Failed to describe EC2 instances")
+			return nil, fmt.Errorf("failed to describe ec2 instances: %w", err)
+		}
+
+		for _, reservation := range output.Reservations {
+			for _, described := range reservation.Instances {
+				instance := EC2Instance{
+					InstanceID:          described.InstanceID,
+					InstanceType:        described.InstanceType,
+					LaunchTime:          described.LaunchTime,
+					Tags:                make(map[string]string, len(described.Tags)),
+					AvailabilityZone:    described.Placement.AvailabilityZone,
+					EstimatedHourlyCost: EC2PricingMap[described.InstanceType],
+				}
+				for _, tag := range described.Tags {
+					instance.Tags[tag.Key] = tag.Value
+				}
+				instance.Application = instance.Tags["system"]
+				instance.Environment = instance.Tags["environment"]
+				instances = append(instances, instance)
+			}
+		}
+
+		if output.NextToken == "" {
+			return instances, nil
+		}
+		params.Set("NextToken", output.NextToken)
+	}
+}
@@ -0,0 +1,92 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+)
+
+func newTestEC2Client(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	cfg := aws.Config{
+		Region:      "eu-west-2",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	return &Client{
+		config: cfg,
+		logger: log,
+		serviceClients: map[string]interface{}{
+			ServiceEC2: newEC2Client(cfg).WithEndpoint(server.URL),
+		},
+	}
+}
+
+func TestGetEC2Instances(t *testing.T) {
+	client := newTestEC2Client(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.PostForm.Get("Action") != "DescribeInstances" || r.PostForm.Get("Filter.1.Value.1") != "running" {
+			t.Errorf("Unexpected request: %v", r.PostForm)
+		}
+
+		if r.PostForm.Get("NextToken") == "" {
+			w.Write([]byte(`<DescribeInstancesResponse><reservationSet><item><instancesSet>
+				<item>
+					<instanceId>i-1</instanceId><instanceType>m5.large</instanceType>
+					<launchTime>2024-01-02T03:04:05.000Z</launchTime>
+					<placement><availabilityZone>eu-west-2a</availabilityZone></placement>
+					<tagSet>
+						<item><key>system</key><value>publishing-api</value></item>
+						<item><key>environment</key><value>production</value></item>
+					</tagSet>
+				</item>
+			</instancesSet></item></reservationSet><nextToken>page-2</nextToken></DescribeInstancesResponse>`))
+			return
+		}
+		w.Write([]byte(`<DescribeInstancesResponse><reservationSet><item><instancesSet>
+			<item><instanceId>i-2</instanceId><instanceType>x9.huge</instanceType></item>
+		</instancesSet></item></reservationSet></DescribeInstancesResponse>`))
+	})
+
+	instances, err := client.GetEC2Instances(context.Background())
+	if err != nil {
+		t.Fatalf("GetEC2Instances failed: %v", err)
+	}
+
+	if len(instances) != 2 {
+		t.Fatalf("Expected 2 instances across both pages, got %+v", instances)
+	}
+	first := instances[0]
+	if first.Application != "publishing-api" || first.Environment != "production" || first.AvailabilityZone != "eu-west-2a" {
+		t.Errorf("Unexpected instance: %+v", first)
+	}
+	if first.EstimatedHourlyCost != EC2PricingMap["m5.large"] || first.LaunchTime.Year() != 2024 {
+		t.Errorf("Unexpected cost or launch time: %+v", first)
+	}
+	// Unknown instance types are estimated at zero
+	if instances[1].EstimatedHourlyCost != 0 {
+		t.Errorf("Expected zero cost for an unknown type, got %v", instances[1].EstimatedHourlyCost)
+	}
+}
+
+func TestGetEC2Instances_Error(t *testing.T) {
+	client := newTestEC2Client(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`<Response><Errors><Error><Code>UnauthorizedOperation</Code><Message>denied</Message></Error></Errors></Response>`))
+	})
+
+	_, err := client.GetEC2Instances(context.Background())
+	var apiErr *QueryAPIError
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode() != "UnauthorizedOperation" {
+		t.Errorf("Expected an UnauthorizedOperation QueryAPIError, got %v", err)
+	}
+}
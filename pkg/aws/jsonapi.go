@@ -0,0 +1,193 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"govuk-reports-dashboard/pkg/tracing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// JSONAPIClient is a minimal client for AWS services that speak the
+// awsJson1.0 or awsJson1.1 protocol, or restJson1 with one POST route per
+// operation. It is used for services that do not have an SDK client vendored
+// in this module (e.g. Performance Insights, Savings Plans, CloudWatch).
+type JSONAPIClient struct {
+	config        aws.Config
+	service       string
+	targetPrefix  string
+	endpoint      string
+	signingRegion string
+	restJSON      bool
+	jsonVersion   string
+	signer        *v4.Signer
+	apiCalls      APICallRecorder
+}
+
+// NewJSONAPIClient creates a client for the given service signing name and
+// X-Amz-Target prefix, using the region and credentials from the AWS config
+func NewJSONAPIClient(cfg aws.Config, service, targetPrefix string) *JSONAPIClient {
+	return &JSONAPIClient{
+		config:        cfg,
+		service:       service,
+		targetPrefix:  targetPrefix,
+		endpoint:      fmt.Sprintf("https://%s.%s.amazonaws.com/", service, cfg.Region),
+		signingRegion: cfg.Region,
+		jsonVersion:   "1.1",
+		signer:        v4.NewSigner(),
+	}
+}
+
+// NewRESTJSONAPIClient creates a client for a restJson1 service where each
+// operation is a POST to /<Operation>. Global services such as Savings Plans
+// have a single endpoint and sign requests for a fixed region.
+func NewRESTJSONAPIClient(cfg aws.Config, service, endpoint, signingRegion string) *JSONAPIClient {
+	return &JSONAPIClient{
+		config:        cfg,
+		service:       service,
+		endpoint:      endpoint,
+		signingRegion: signingRegion,
+		restJSON:      true,
+		signer:        v4.NewSigner(),
+	}
+}
+
+// WithEndpoint overrides the service endpoint, mainly for testing
+func (c *JSONAPIClient) WithEndpoint(endpoint string) *JSONAPIClient {
+	c.endpoint = endpoint
+	return c
+}
+
+// WithJSONVersion sets the awsJson protocol version, for services such as
+// CloudWatch that use awsJson1.0 rather than the default 1.1
+func (c *JSONAPIClient) WithJSONVersion(version string) *JSONAPIClient {
+	c.jsonVersion = version
+	return c
+}
+
+// WithAPICallRecorder counts every call the client makes with recorder
+func (c *JSONAPIClient) WithAPICallRecorder(recorder APICallRecorder) *JSONAPIClient {
+	c.apiCalls = recorder
+	return c
+}
+
+// Call invokes the given operation, marshalling input and unmarshalling the
+// response into output
+func (c *JSONAPIClient) Call(ctx context.Context, operation string, input, output interface{}) error {
+	ctx, span := startAPICallSpan(ctx, c.service, operation)
+	defer span.End()
+
+	err := c.call(ctx, operation, input, output)
+	if c.apiCalls != nil {
+		c.apiCalls.RecordAWSAPICall(c.service, operation, err == nil)
+	}
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (c *JSONAPIClient) call(ctx context.Context, operation string, input, output interface{}) error {
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s input: %w", operation, err)
+	}
+
+	url := c.endpoint
+	if c.restJSON {
+		url = strings.TrimSuffix(c.endpoint, "/") + "/" + operation
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", operation, err)
+	}
+	if c.restJSON {
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		req.Header.Set("Content-Type", "application/x-amz-json-"+c.jsonVersion)
+		req.Header.Set("X-Amz-Target", c.targetPrefix+"."+operation)
+	}
+
+	if c.config.Credentials == nil {
+		return fmt.Errorf("no AWS credentials configured for %s", c.service)
+	}
+	creds, err := c.config.Credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+
+	hash := sha256.Sum256(payload)
+	if err := c.signer.SignHTTP(ctx, creds, req, hex.EncodeToString(hash[:]), c.service, c.signingRegion, time.Now()); err != nil {
+		return fmt.Errorf("failed to sign %s request: %w", operation, err)
+	}
+
+	var httpClient aws.HTTPClient = http.DefaultClient
+	if c.config.HTTPClient != nil {
+		httpClient = c.config.HTTPClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %w", operation, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", operation, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &JSONAPIError{
+			StatusCode: resp.StatusCode,
+			Operation:  operation,
+			Body:       string(body),
+		}
+	}
+
+	if output == nil || len(body) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(body, output); err != nil {
+		return fmt.Errorf("failed to unmarshal %s response: %w", operation, err)
+	}
+
+	return nil
+}
+
+// JSONAPIError represents a non-2xx response from an AWS JSON API
+type JSONAPIError struct {
+	StatusCode int
+	Operation  string
+	Body       string
+}
+
+func (e *JSONAPIError) Error() string {
+	return fmt.Sprintf("%s failed with status %d: %s", e.Operation, e.StatusCode, e.Body)
+}
+
+// ErrorCode returns the AWS error type from the response body, e.g.
+// "SubscriptionRequiredException", or "" if the body has none
+func (e *JSONAPIError) ErrorCode() string {
+	var body struct {
+		Type string `json:"__type"`
+	}
+	if err := json.Unmarshal([]byte(e.Body), &body); err != nil {
+		return ""
+	}
+	// Types may be namespaced, e.g. "com.amazonaws.support#SubscriptionRequiredException"
+	if i := strings.LastIndex(body.Type, "#"); i >= 0 {
+		return body.Type[i+1:]
+	}
+	return body.Type
+}
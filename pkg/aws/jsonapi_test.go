@@ -0,0 +1,78 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+)
+
+func TestJSONAPIClient_Call(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Amz-Target") != "TestService.DoThing" {
+			t.Errorf("Expected target TestService.DoThing, got %s", r.Header.Get("X-Amz-Target"))
+		}
+		if r.Header.Get("Content-Type") != "application/x-amz-json-1.1" {
+			t.Errorf("Expected awsJson1.1 content type, got %s", r.Header.Get("Content-Type"))
+		}
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256") {
+			t.Errorf("Expected SigV4 Authorization header, got %s", r.Header.Get("Authorization"))
+		}
+
+		var input map[string]string
+		json.NewDecoder(r.Body).Decode(&input)
+		if input["Name"] != "test" {
+			t.Errorf("Expected Name 'test', got '%s'", input["Name"])
+		}
+
+		w.Write([]byte(`{"Result":"ok"}`))
+	}))
+	defer server.Close()
+
+	cfg := aws.Config{
+		Region:      "eu-west-2",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}
+	client := NewJSONAPIClient(cfg, "test", "TestService").WithEndpoint(server.URL)
+
+	var output struct{ Result string }
+	if err := client.Call(context.Background(), "DoThing", map[string]string{"Name": "test"}, &output); err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+
+	if output.Result != "ok" {
+		t.Errorf("Expected result 'ok', got '%s'", output.Result)
+	}
+}
+
+func TestJSONAPIClient_ErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"__type":"InvalidArgumentException"}`))
+	}))
+	defer server.Close()
+
+	cfg := aws.Config{
+		Region:      "eu-west-2",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}
+	client := NewJSONAPIClient(cfg, "test", "TestService").WithEndpoint(server.URL)
+
+	err := client.Call(context.Background(), "DoThing", map[string]string{}, nil)
+	apiErr, ok := err.(*JSONAPIError)
+	if !ok {
+		t.Fatalf("Expected *JSONAPIError, got %v", err)
+	}
+
+	if apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", apiErr.StatusCode)
+	}
+	if apiErr.ErrorCode() != "InvalidArgumentException" {
+		t.Errorf("Expected error code InvalidArgumentException, got %s", apiErr.ErrorCode())
+	}
+}
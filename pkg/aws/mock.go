@@ -0,0 +1,94 @@
+package aws
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"govuk-reports-dashboard/pkg/common"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// MockCostDataClient is a CostDataClient that returns preset values, for
+// testing cost services without AWS credentials. Each method returns its
+// Result and Err fields; calls are counted in Calls.
+type MockCostDataClient struct {
+	GetCostDataResult               []common.CostData
+	GetCostDataErr                  error
+	GetCostDataBySystemTagResult    []common.CostData
+	GetCostDataBySystemTagErr       error
+	GetCostDataForApplicationResult []common.CostData
+	GetCostDataForApplicationErr    error
+	GetCostDataForServicesResult    []common.CostData
+	GetCostDataForServicesErr       error
+	GetCostsBySystemTagResult       []common.CostData
+	GetCostsBySystemTagErr          error
+	GetCostForecastResult           *common.CostForecast
+	GetCostForecastErr              error
+	Currency                        string
+	Config                          aws.Config
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+var _ CostDataClient = (*MockCostDataClient)(nil)
+
+func (m *MockCostDataClient) record(method string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.calls == nil {
+		m.calls = make(map[string]int)
+	}
+	m.calls[method]++
+}
+
+// Calls returns how many times the named method has been called
+func (m *MockCostDataClient) Calls(method string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls[method]
+}
+
+func (m *MockCostDataClient) GetCostData(ctx context.Context) ([]common.CostData, error) {
+	m.record("GetCostData")
+	return m.GetCostDataResult, m.GetCostDataErr
+}
+
+func (m *MockCostDataClient) GetCostDataBySystemTag(ctx context.Context) ([]common.CostData, error) {
+	m.record("GetCostDataBySystemTag")
+	return m.GetCostDataBySystemTagResult, m.GetCostDataBySystemTagErr
+}
+
+func (m *MockCostDataClient) GetCostDataForApplication(ctx context.Context, appName string, lookbackMonths int) ([]common.CostData, error) {
+	m.record("GetCostDataForApplication")
+	return m.GetCostDataForApplicationResult, m.GetCostDataForApplicationErr
+}
+
+func (m *MockCostDataClient) GetCostDataForServices(ctx context.Context, services []string, startDate, endDate time.Time) ([]common.CostData, error) {
+	m.record("GetCostDataForServices")
+	return m.GetCostDataForServicesResult, m.GetCostDataForServicesErr
+}
+
+func (m *MockCostDataClient) GetCostsBySystemTag(ctx context.Context, startDate, endDate time.Time) ([]common.CostData, error) {
+	m.record("GetCostsBySystemTag")
+	return m.GetCostsBySystemTagResult, m.GetCostsBySystemTagErr
+}
+
+func (m *MockCostDataClient) GetCostForecast(ctx context.Context, startDate, endDate time.Time) (*common.CostForecast, error) {
+	m.record("GetCostForecast")
+	return m.GetCostForecastResult, m.GetCostForecastErr
+}
+
+// ReportingCurrency returns Currency, or GBP if it is not set
+func (m *MockCostDataClient) ReportingCurrency() string {
+	if m.Currency == "" {
+		return "GBP"
+	}
+	return m.Currency
+}
+
+func (m *MockCostDataClient) GetConfig() aws.Config {
+	return m.Config
+}
@@ -0,0 +1,207 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// PermissionError describes an IAM permission the dashboard needs but could
+// not use
+type PermissionError struct {
+	Permission string `json:"permission"`
+	Service    string `json:"service"`
+	ErrorCode  string `json:"error_code"`
+	Suggestion string `json:"suggestion"`
+	Err        error  `json:"-"`
+}
+
+func (e PermissionError) Error() string {
+	return fmt.Sprintf("%s check failed (%s): %v", e.Permission, e.ErrorCode, e.Err)
+}
+
+// ValidatePermissions probes each AWS API the dashboard depends on with a
+// cheap read-only call and returns an error for every call that fails
+func (c *Client) ValidatePermissions(ctx context.Context) []PermissionError {
+	var permissionErrors []PermissionError
+
+	now := time.Now()
+	_, err := c.costExplorer.GetCostAndUsage(ctx, &costexplorer.GetCostAndUsageInput{
+		TimePeriod: &types.DateInterval{
+			Start: aws.String(now.AddDate(0, 0, -1).Format("2006-01-02")),
+			End:   aws.String(now.Format("2006-01-02")),
+		},
+		Granularity: types.GranularityDaily,
+		Metrics:     []string{"BlendedCost"},
+	})
+	if err != nil {
+		permissionErrors = append(permissionErrors, newPermissionError("ce:GetCostAndUsage", "costexplorer", err))
+	}
+
+	_, err = rds.NewFromConfig(c.config).DescribeDBInstances(ctx, &rds.DescribeDBInstancesInput{
+		MaxRecords: aws.Int32(20), // RDS rejects values below 20
+	})
+	if err != nil {
+		permissionErrors = append(permissionErrors, newPermissionError("rds:DescribeDBInstances", "rds", err))
+	}
+
+	_, err = elasticache.NewFromConfig(c.config).DescribeCacheClusters(ctx, &elasticache.DescribeCacheClustersInput{
+		MaxRecords: aws.Int32(20), // ElastiCache rejects values below 20
+	})
+	if err != nil {
+		permissionErrors = append(permissionErrors, newPermissionError("elasticache:DescribeCacheClusters", "elasticache", err))
+	}
+
+	_, err = c.NewServiceClient(ServiceS3).(*s3.Client).ListBuckets(ctx, &s3.ListBucketsInput{
+		MaxBuckets: aws.Int32(1),
+	})
+	if err != nil {
+		permissionErrors = append(permissionErrors, newPermissionError("s3:ListAllMyBuckets", "s3", err))
+	}
+
+	_, err = c.NewServiceClient(ServiceLambda).(*lambda.Client).ListFunctions(ctx, &lambda.ListFunctionsInput{
+		MaxItems: aws.Int32(1),
+	})
+	if err != nil {
+		permissionErrors = append(permissionErrors, newPermissionError("lambda:ListFunctions", "lambda", err))
+	}
+
+	_, err = c.NewServiceClient(ServiceECS).(*ecs.Client).ListClusters(ctx, &ecs.ListClustersInput{
+		MaxResults: aws.Int32(1),
+	})
+	if err != nil {
+		permissionErrors = append(permissionErrors, newPermissionError("ecs:ListClusters", "ecs", err))
+	}
+
+	var events lookupEventsOutput
+	err = c.NewServiceClient(ServiceCloudTrail).(*JSONAPIClient).Call(ctx, "LookupEvents", lookupEventsInput{
+		LookupAttributes: []lookupAttribute{},
+		StartTime:        now.Add(-time.Hour).Unix(),
+		EndTime:          now.Unix(),
+		MaxResults:       1,
+	}, &events)
+	if err != nil {
+		permissionErrors = append(permissionErrors, newPermissionError("cloudtrail:LookupEvents", "cloudtrail", err))
+	}
+
+	for _, permissionError := range permissionErrors {
+		c.logger.WithFields(map[string]interface{}{
+			"permission": permissionError.Permission,
+			"error_code": permissionError.ErrorCode,
+			"suggestion": permissionError.Suggestion,
+		}).Warn().Msg("AWS permission check failed")
+	}
+
+	return permissionErrors
+}
+
+type getCallerIdentityOutput struct {
+	Arn     string `xml:"GetCallerIdentityResult>Arn"`
+	Account string `xml:"GetCallerIdentityResult>Account"`
+}
+
+type simulatePrincipalPolicyOutput struct {
+	EvaluationResults []struct {
+		EvalActionName string `xml:"EvalActionName"`
+		EvalDecision   string `xml:"EvalDecision"`
+	} `xml:"SimulatePrincipalPolicyResult>EvaluationResults>member"`
+}
+
+// PermissionCheck asks IAM whether the dashboard's identity may perform an
+// action, such as "tag:TagResources", before it is attempted. It returns nil
+// if the action is allowed, or a PermissionError if it is denied or the
+// check itself fails. The check needs iam:SimulatePrincipalPolicy.
+func (c *Client) PermissionCheck(ctx context.Context, action string) error {
+	service, _, _ := strings.Cut(action, ":")
+
+	var identity getCallerIdentityOutput
+	if err := c.NewServiceClient(ServiceSTS).(*QueryAPIClient).Call(ctx, "GetCallerIdentity", nil, &identity); err != nil {
+		return newPermissionError(action, service, fmt.Errorf("failed to get caller identity: %w", err))
+	}
+
+	params := url.Values{}
+	params.Set("PolicySourceArn", principalARN(identity.Arn))
+	params.Set("ActionNames.member.1", action)
+
+	var simulation simulatePrincipalPolicyOutput
+	if err := c.NewServiceClient(ServiceIAM).(*QueryAPIClient).Call(ctx, "SimulatePrincipalPolicy", params, &simulation); err != nil {
+		return newPermissionError(action, service, fmt.Errorf("failed to simulate principal policy: %w", err))
+	}
+
+	for _, result := range simulation.EvaluationResults {
+		if result.EvalActionName == action && result.EvalDecision == "allowed" {
+			return nil
+		}
+	}
+
+	decision := "implicitDeny"
+	if len(simulation.EvaluationResults) > 0 {
+		decision = simulation.EvaluationResults[0].EvalDecision
+	}
+	c.logger.WithFields(map[string]interface{}{
+		"permission": action,
+		"principal":  identity.Arn,
+		"decision":   decision,
+	}).Warn().Msg("AWS permission check failed")
+
+	return PermissionError{
+		Permission: action,
+		Service:    service,
+		ErrorCode:  "AccessDenied",
+		Suggestion: fmt.Sprintf("Grant %s to the dashboard's IAM role or user", action),
+		Err:        fmt.Errorf("simulated decision was %s", decision),
+	}
+}
+
+// principalARN converts an STS assumed-role ARN, which IAM policy simulation
+// does not accept, to the ARN of the role. Other ARNs are returned unchanged.
+// Roles with a path are not supported, as the path is not in the STS ARN.
+func principalARN(arn string) string {
+	prefix, rest, found := strings.Cut(arn, ":assumed-role/")
+	if !found {
+		return arn
+	}
+	roleName, _, _ := strings.Cut(rest, "/")
+	return strings.Replace(prefix, ":sts:", ":iam:", 1) + ":role/" + roleName
+}
+
+// newPermissionError classifies a failed probe call and suggests a fix
+func newPermissionError(permission, service string, err error) PermissionError {
+	permissionError := PermissionError{
+		Permission: permission,
+		Service:    service,
+		ErrorCode:  "Unknown",
+		Err:        err,
+	}
+
+	// Matches smithy.APIError as well as JSONAPIError and QueryAPIError
+	var apiErr interface{ ErrorCode() string }
+	if errors.As(err, &apiErr) {
+		permissionError.ErrorCode = apiErr.ErrorCode()
+	}
+
+	switch permissionError.ErrorCode {
+	case "AccessDenied", "AccessDeniedException", "UnauthorizedOperation":
+		permissionError.Suggestion = fmt.Sprintf("Grant %s to the dashboard's IAM role or user", permission)
+	case "UnrecognizedClientException", "InvalidClientTokenId", "ExpiredToken", "ExpiredTokenException":
+		permissionError.Suggestion = "Check the AWS credentials are valid and have not expired"
+	case "OptInRequired":
+		permissionError.Suggestion = fmt.Sprintf("Enable %s for this AWS account", service)
+	default:
+		permissionError.Suggestion = fmt.Sprintf("Check network access and region configuration for %s", service)
+	}
+
+	return permissionError
+}
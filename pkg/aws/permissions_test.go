@@ -0,0 +1,95 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/smithy-go"
+)
+
+func TestNewPermissionError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            error
+		expectedCode   string
+		suggestionPart string
+	}{
+		{
+			name:           "Access denied",
+			err:            &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "not authorized"},
+			expectedCode:   "AccessDeniedException",
+			suggestionPart: "Grant rds:DescribeDBInstances",
+		},
+		{
+			name:           "Expired credentials",
+			err:            &smithy.GenericAPIError{Code: "ExpiredToken", Message: "token expired"},
+			expectedCode:   "ExpiredToken",
+			suggestionPart: "credentials",
+		},
+		{
+			name:           "Non-API error",
+			err:            errors.New("dial tcp: no such host"),
+			expectedCode:   "Unknown",
+			suggestionPart: "network access",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			permissionError := newPermissionError("rds:DescribeDBInstances", "rds", tc.err)
+
+			if permissionError.ErrorCode != tc.expectedCode {
+				t.Errorf("Expected error code '%s', got '%s'", tc.expectedCode, permissionError.ErrorCode)
+			}
+			if !strings.Contains(permissionError.Suggestion, tc.suggestionPart) {
+				t.Errorf("Expected suggestion to contain '%s', got '%s'", tc.suggestionPart, permissionError.Suggestion)
+			}
+			if permissionError.Permission != "rds:DescribeDBInstances" || permissionError.Service != "rds" {
+				t.Errorf("Unexpected permission/service: %+v", permissionError)
+			}
+		})
+	}
+}
+
+func TestValidatePermissions(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"__type":"AccessDeniedException","message":"not authorized"}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithCostExplorer(aws.Config{
+		Region:       "eu-west-2",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}, &mockCostExplorer{output: &costexplorer.GetCostAndUsageOutput{}}, log)
+	client.NewServiceClient(ServiceCloudTrail).(*JSONAPIClient).WithEndpoint(server.URL)
+
+	var permissions []string
+	for _, permissionError := range client.ValidatePermissions(context.Background()) {
+		permissions = append(permissions, permissionError.Permission)
+	}
+
+	expected := []string{
+		"rds:DescribeDBInstances",
+		"elasticache:DescribeCacheClusters",
+		"s3:ListAllMyBuckets",
+		"lambda:ListFunctions",
+		"ecs:ListClusters",
+		"cloudtrail:LookupEvents",
+	}
+	if !reflect.DeepEqual(permissions, expected) {
+		t.Errorf("Expected failed checks %v, got %v", expected, permissions)
+	}
+}
@@ -0,0 +1,69 @@
+package aws
+
+// HoursPerMonth is the average number of hours in a month, as used by AWS
+// pricing
+const HoursPerMonth = 730
+
+// RDSPricingMap maps RDS instance classes to their approximate single-AZ
+// on-demand PostgreSQL price in GBP per hour in eu-west-2. Multi-AZ
+// deployments cost twice as much. It can be replaced to use different prices.
+var RDSPricingMap = map[string]float64{
+	"db.t3.micro":     0.016,
+	"db.t3.small":     0.031,
+	"db.t3.medium":    0.062,
+	"db.t3.large":     0.124,
+	"db.t3.xlarge":    0.248,
+	"db.t3.2xlarge":   0.496,
+	"db.t4g.micro":    0.014,
+	"db.t4g.small":    0.028,
+	"db.t4g.medium":   0.057,
+	"db.t4g.large":    0.113,
+	"db.t4g.xlarge":   0.226,
+	"db.t4g.2xlarge":  0.453,
+	"db.m5.large":     0.158,
+	"db.m5.xlarge":    0.316,
+	"db.m5.2xlarge":   0.632,
+	"db.m5.4xlarge":   1.264,
+	"db.m5.8xlarge":   2.528,
+	"db.m5.12xlarge":  3.792,
+	"db.m5.16xlarge":  5.056,
+	"db.m5.24xlarge":  7.584,
+	"db.m6g.large":    0.141,
+	"db.m6g.xlarge":   0.282,
+	"db.m6g.2xlarge":  0.564,
+	"db.m6g.4xlarge":  1.128,
+	"db.m6g.8xlarge":  2.256,
+	"db.r5.large":     0.221,
+	"db.r5.xlarge":    0.442,
+	"db.r5.2xlarge":   0.885,
+	"db.r5.4xlarge":   1.770,
+	"db.r5.8xlarge":   3.540,
+	"db.r5.12xlarge":  5.310,
+	"db.r5.16xlarge":  7.080,
+	"db.r5.24xlarge":  10.620,
+	"db.r6g.large":    0.198,
+	"db.r6g.xlarge":   0.396,
+	"db.r6g.2xlarge":  0.792,
+	"db.r6g.4xlarge":  1.584,
+	"db.r6g.8xlarge":  3.168,
+	"db.r6g.12xlarge": 4.752,
+	"db.r6g.16xlarge": 6.336,
+	"db.r6i.large":    0.221,
+	"db.r6i.xlarge":   0.442,
+	"db.r6i.2xlarge":  0.885,
+	"db.r6i.4xlarge":  1.770,
+	"db.r6i.8xlarge":  3.540,
+}
+
+// RDSMonthlyCost estimates the monthly on-demand cost in GBP of an instance
+// class, reporting false if the class isn't in RDSPricingMap
+func RDSMonthlyCost(instanceClass string, multiAZ bool) (float64, bool) {
+	hourly, ok := RDSPricingMap[instanceClass]
+	if !ok {
+		return 0, false
+	}
+	if multiAZ {
+		hourly *= 2
+	}
+	return hourly * HoursPerMonth, true
+}
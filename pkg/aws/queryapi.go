@@ -0,0 +1,181 @@
+package aws
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"govuk-reports-dashboard/pkg/tracing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// QueryAPIClient is a minimal client for AWS services that speak the Query
+// (or EC2 Query) protocol, taking form-encoded parameters and returning XML.
+// It is used for services that do not have an SDK client vendored in this
+// module (e.g. EC2).
+type QueryAPIClient struct {
+	config        aws.Config
+	service       string
+	version       string
+	endpoint      string
+	signingRegion string
+	signer        *v4.Signer
+	apiCalls      APICallRecorder
+}
+
+// NewQueryAPIClient creates a client for the given service signing name and
+// API version, using the region and credentials from the AWS config
+func NewQueryAPIClient(cfg aws.Config, service, version string) *QueryAPIClient {
+	return &QueryAPIClient{
+		config:        cfg,
+		service:       service,
+		version:       version,
+		endpoint:      fmt.Sprintf("https://%s.%s.amazonaws.com/", service, cfg.Region),
+		signingRegion: cfg.Region,
+		signer:        v4.NewSigner(),
+	}
+}
+
+// NewGlobalQueryAPIClient creates a client for a global service such as IAM,
+// which has a single endpoint and signs requests for a fixed region
+func NewGlobalQueryAPIClient(cfg aws.Config, service, version, endpoint, signingRegion string) *QueryAPIClient {
+	return &QueryAPIClient{
+		config:        cfg,
+		service:       service,
+		version:       version,
+		endpoint:      endpoint,
+		signingRegion: signingRegion,
+		signer:        v4.NewSigner(),
+	}
+}
+
+// WithEndpoint overrides the service endpoint, mainly for testing
+func (c *QueryAPIClient) WithEndpoint(endpoint string) *QueryAPIClient {
+	c.endpoint = endpoint
+	return c
+}
+
+// WithAPICallRecorder counts every call the client makes with recorder
+func (c *QueryAPIClient) WithAPICallRecorder(recorder APICallRecorder) *QueryAPIClient {
+	c.apiCalls = recorder
+	return c
+}
+
+// Call invokes the given action with params and unmarshals the XML response
+// into output
+func (c *QueryAPIClient) Call(ctx context.Context, action string, params url.Values, output interface{}) error {
+	ctx, span := startAPICallSpan(ctx, c.service, action)
+	defer span.End()
+
+	err := c.call(ctx, action, params, output)
+	if c.apiCalls != nil {
+		c.apiCalls.RecordAWSAPICall(c.service, action, err == nil)
+	}
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (c *QueryAPIClient) call(ctx context.Context, action string, params url.Values, output interface{}) error {
+	form := url.Values{}
+	for key, values := range params {
+		form[key] = values
+	}
+	form.Set("Action", action)
+	form.Set("Version", c.version)
+	payload := form.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, strings.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", action, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	if c.config.Credentials == nil {
+		return fmt.Errorf("no AWS credentials configured for %s", c.service)
+	}
+	creds, err := c.config.Credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+
+	hash := sha256.Sum256([]byte(payload))
+	if err := c.signer.SignHTTP(ctx, creds, req, hex.EncodeToString(hash[:]), c.service, c.signingRegion, time.Now()); err != nil {
+		return fmt.Errorf("failed to sign %s request: %w", action, err)
+	}
+
+	var httpClient aws.HTTPClient = http.DefaultClient
+	if c.config.HTTPClient != nil {
+		httpClient = c.config.HTTPClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", action, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &QueryAPIError{
+			StatusCode: resp.StatusCode,
+			Action:     action,
+			Body:       string(body),
+		}
+	}
+
+	if output == nil || len(body) == 0 {
+		return nil
+	}
+
+	if err := xml.Unmarshal(body, output); err != nil {
+		return fmt.Errorf("failed to unmarshal %s response: %w", action, err)
+	}
+
+	return nil
+}
+
+// QueryAPIError represents a non-2xx response from an AWS Query API
+type QueryAPIError struct {
+	StatusCode int
+	Action     string
+	Body       string
+}
+
+func (e *QueryAPIError) Error() string {
+	return fmt.Sprintf("%s failed with status %d: %s", e.Action, e.StatusCode, e.Body)
+}
+
+// ErrorCode returns the AWS error code from the response body, e.g.
+// "UnauthorizedOperation", or "" if the body has none
+func (e *QueryAPIError) ErrorCode() string {
+	// EC2 wraps errors in <Response><Errors><Error>, other Query services
+	// in <ErrorResponse><Error>
+	var body struct {
+		Errors []struct {
+			Code string `xml:"Code"`
+		} `xml:"Errors>Error"`
+		Error struct {
+			Code string `xml:"Code"`
+		} `xml:"Error"`
+	}
+	if err := xml.Unmarshal([]byte(e.Body), &body); err != nil {
+		return ""
+	}
+	if len(body.Errors) > 0 {
+		return body.Errors[0].Code
+	}
+	return body.Error.Code
+}
@@ -0,0 +1,219 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+)
+
+const (
+	// SavingsPlansEndpoint is the global Savings Plans API endpoint
+	SavingsPlansEndpoint = "https://savingsplans.amazonaws.com/"
+	// SavingsPlansLookbackDays is the period coverage and utilization are
+	// measured over
+	SavingsPlansLookbackDays = 30
+)
+
+// SavingsPlansCoverage describes how much eligible spend was covered by
+// Savings Plans, and how much of the committed spend was used, over the last
+// SavingsPlansLookbackDays. Amounts are in USD as reported by Cost Explorer.
+type SavingsPlansCoverage struct {
+	CoveragePercentage    float64           `json:"coverage_percentage"`
+	UtilizationPercentage float64           `json:"utilization_percentage"`
+	UncoveredCost         float64           `json:"uncovered_cost"`
+	UnusedCommitment      float64           `json:"unused_commitment"`
+	Currency              string            `json:"currency"`
+	ByService             []ServiceCoverage `json:"by_service"`
+	StartDate             time.Time         `json:"start_date"`
+	EndDate               time.Time         `json:"end_date"`
+}
+
+// ServiceCoverage is Savings Plans coverage for a single AWS service
+type ServiceCoverage struct {
+	Service            string  `json:"service"`
+	CoveragePercentage float64 `json:"coverage_percentage"`
+	CoveredCost        float64 `json:"covered_cost"`
+	UncoveredCost      float64 `json:"uncovered_cost"`
+	TotalCost          float64 `json:"total_cost"`
+}
+
+// SavingsPlan is an active Savings Plan in the account
+type SavingsPlan struct {
+	ID            string    `json:"id"`
+	ARN           string    `json:"arn"`
+	Type          string    `json:"type"`
+	PaymentOption string    `json:"payment_option"`
+	State         string    `json:"state"`
+	Region        string    `json:"region,omitempty"`
+	Commitment    float64   `json:"commitment"`
+	Currency      string    `json:"currency"`
+	Start         time.Time `json:"start"`
+	End           time.Time `json:"end"`
+}
+
+// DaysUntilExpiry returns the number of whole days until the plan ends
+func (p SavingsPlan) DaysUntilExpiry(now time.Time) int {
+	return int(p.End.Sub(now).Hours() / 24)
+}
+
+type describeSavingsPlansInput struct {
+	States     []string `json:"states,omitempty"`
+	MaxResults int32    `json:"maxResults,omitempty"`
+	NextToken  string   `json:"nextToken,omitempty"`
+}
+
+type describeSavingsPlansOutput struct {
+	SavingsPlans []struct {
+		SavingsPlanID   string `json:"savingsPlanId"`
+		SavingsPlanArn  string `json:"savingsPlanArn"`
+		SavingsPlanType string `json:"savingsPlanType"`
+		PaymentOption   string `json:"paymentOption"`
+		State           string `json:"state"`
+		Region          string `json:"region"`
+		Commitment      string `json:"commitment"`
+		Currency        string `json:"currency"`
+		Start           string `json:"start"`
+		End             string `json:"end"`
+	} `json:"savingsPlans"`
+	NextToken string `json:"nextToken"`
+}
+
+// GetSavingsPlansCoverage returns Savings Plans coverage, broken down by
+// service, and utilization for the last SavingsPlansLookbackDays. Accounts
+// without Savings Plans report zero utilization rather than an error.
+func (c *Client) GetSavingsPlansCoverage(ctx context.Context) (SavingsPlansCoverage, error) {
+	endDate := time.Now()
+	startDate := endDate.AddDate(0, 0, -SavingsPlansLookbackDays)
+	period := &types.DateInterval{
+		Start: aws.String(startDate.Format("2006-01-02")),
+		End:   aws.String(endDate.Format("2006-01-02")),
+	}
+
+	coverage := SavingsPlansCoverage{
+		Currency:  "USD",
+		StartDate: parseDate(*period.Start),
+		EndDate:   parseDate(*period.End),
+	}
+
+	var totalCovered, totalCost float64
+	var nextToken *string
+	for {
+		result, err := c.costExplorer.GetSavingsPlansCoverage(ctx, &costexplorer.GetSavingsPlansCoverageInput{
+			TimePeriod: period,
+			GroupBy: []types.GroupDefinition{
+				{
+					Type: types.GroupDefinitionTypeDimension,
+					Key:  aws.String("SERVICE"),
+				},
+			},
+			NextToken: nextToken,
+		})
+		if err != nil {
+			c.logger.WithError(err).Error().Msg("Failed to get Savings Plans coverage from AWS")
+			return SavingsPlansCoverage{}, err
+		}
+
+		for _, item := range result.SavingsPlansCoverages {
+			if item.Coverage == nil {
+				continue
+			}
+
+			service := ServiceCoverage{
+				Service:            item.Attributes["SERVICE"],
+				CoveragePercentage: parseFloat(getStringValue(item.Coverage.CoveragePercentage)),
+				CoveredCost:        parseFloat(getStringValue(item.Coverage.SpendCoveredBySavingsPlans)),
+				UncoveredCost:      parseFloat(getStringValue(item.Coverage.OnDemandCost)),
+				TotalCost:          parseFloat(getStringValue(item.Coverage.TotalCost)),
+			}
+			coverage.ByService = append(coverage.ByService, service)
+
+			totalCovered += service.CoveredCost
+			totalCost += service.TotalCost
+			coverage.UncoveredCost += service.UncoveredCost
+		}
+
+		if result.NextToken == nil || *result.NextToken == "" {
+			break
+		}
+		nextToken = result.NextToken
+	}
+
+	if totalCost > 0 {
+		coverage.CoveragePercentage = totalCovered / totalCost * 100
+	}
+
+	sort.Slice(coverage.ByService, func(i, j int) bool {
+		return coverage.ByService[i].UncoveredCost > coverage.ByService[j].UncoveredCost
+	})
+
+	utilization, err := c.costExplorer.GetSavingsPlansUtilization(ctx, &costexplorer.GetSavingsPlansUtilizationInput{
+		TimePeriod: period,
+	})
+	if err != nil {
+		// Cost Explorer has no utilization data when there are no plans
+		var unavailable *types.DataUnavailableException
+		if !errors.As(err, &unavailable) {
+			c.logger.WithError(err).Error().Msg("Failed to get Savings Plans utilization from AWS")
+			return SavingsPlansCoverage{}, err
+		}
+	} else if result := utilization.Total; result != nil && result.Utilization != nil {
+		coverage.UtilizationPercentage = parseFloat(getStringValue(result.Utilization.UtilizationPercentage))
+		coverage.UnusedCommitment = parseFloat(getStringValue(result.Utilization.UnusedCommitment))
+	}
+
+	return coverage, nil
+}
+
+// GetSavingsPlansInventory returns the active Savings Plans in the account
+func (c *Client) GetSavingsPlansInventory(ctx context.Context) ([]SavingsPlan, error) {
+	var plans []SavingsPlan
+	input := describeSavingsPlansInput{
+		States:     []string{"active"},
+		MaxResults: 100,
+	}
+
+	for {
+		var output describeSavingsPlansOutput
+		if err := c.savingsPlans.Call(ctx, "DescribeSavingsPlans", input, &output); err != nil {
+			c.logger.WithError(err).Error().Msg("Failed to describe Savings Plans")
+			return nil, fmt.Errorf("failed to describe savings plans: %w", err)
+		}
+
+		for _, item := range output.SavingsPlans {
+			plans = append(plans, SavingsPlan{
+				ID:            item.SavingsPlanID,
+				ARN:           item.SavingsPlanArn,
+				Type:          item.SavingsPlanType,
+				PaymentOption: item.PaymentOption,
+				State:         item.State,
+				Region:        item.Region,
+				Commitment:    parseFloat(item.Commitment),
+				Currency:      item.Currency,
+				Start:         parseTimestamp(item.Start),
+				End:           parseTimestamp(item.End),
+			})
+		}
+
+		if output.NextToken == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	sort.Slice(plans, func(i, j int) bool {
+		return plans[i].End.Before(plans[j].End)
+	})
+
+	return plans, nil
+}
+
+func parseTimestamp(s string) time.Time {
+	t, _ := time.Parse(time.RFC3339, s)
+	return t
+}
@@ -0,0 +1,123 @@
+package aws
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+)
+
+func TestGetSavingsPlansCoverage(t *testing.T) {
+	coverage := func(service, covered, onDemand, total string) types.SavingsPlansCoverage {
+		return types.SavingsPlansCoverage{
+			Attributes: map[string]string{"SERVICE": service},
+			Coverage: &types.SavingsPlansCoverageData{
+				SpendCoveredBySavingsPlans: aws.String(covered),
+				OnDemandCost:               aws.String(onDemand),
+				TotalCost:                  aws.String(total),
+			},
+		}
+	}
+
+	mock := &mockCostExplorer{
+		coverageOutput: &costexplorer.GetSavingsPlansCoverageOutput{
+			SavingsPlansCoverages: []types.SavingsPlansCoverage{
+				coverage("Amazon EC2", "80", "20", "100"),
+				coverage("AWS Lambda", "0", "100", "100"),
+			},
+		},
+		utilizationOutput: &costexplorer.GetSavingsPlansUtilizationOutput{
+			Total: &types.SavingsPlansUtilizationAggregates{
+				Utilization: &types.SavingsPlansUtilization{
+					UtilizationPercentage: aws.String("85.5"),
+					UnusedCommitment:      aws.String("12.5"),
+				},
+			},
+		},
+	}
+
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	client := &Client{costExplorer: mock, logger: log}
+
+	result, err := client.GetSavingsPlansCoverage(context.Background())
+	if err != nil {
+		t.Fatalf("GetSavingsPlansCoverage failed: %v", err)
+	}
+
+	if result.CoveragePercentage != 40 {
+		t.Errorf("Expected 40%% coverage, got %.2f", result.CoveragePercentage)
+	}
+	if result.UncoveredCost != 120 {
+		t.Errorf("Expected uncovered cost 120, got %.2f", result.UncoveredCost)
+	}
+	if result.UtilizationPercentage != 85.5 || result.UnusedCommitment != 12.5 {
+		t.Errorf("Unexpected utilization: %+v", result)
+	}
+	if len(result.ByService) != 2 || result.ByService[0].Service != "AWS Lambda" {
+		t.Errorf("Expected services sorted by uncovered cost, got %+v", result.ByService)
+	}
+}
+
+func TestGetSavingsPlansCoverage_NoPlans(t *testing.T) {
+	mock := &mockCostExplorer{
+		coverageOutput: &costexplorer.GetSavingsPlansCoverageOutput{},
+		utilizationErr: &types.DataUnavailableException{Message: aws.String("no data")},
+	}
+
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	client := &Client{costExplorer: mock, logger: log}
+
+	result, err := client.GetSavingsPlansCoverage(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error without Savings Plans, got %v", err)
+	}
+	if result.UtilizationPercentage != 0 || result.CoveragePercentage != 0 {
+		t.Errorf("Expected zero coverage and utilization, got %+v", result)
+	}
+}
+
+func TestGetSavingsPlansInventory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/DescribeSavingsPlans" {
+			t.Errorf("Expected path /DescribeSavingsPlans, got %s", r.URL.Path)
+		}
+		if r.Header.Get("X-Amz-Target") != "" {
+			t.Errorf("Expected no X-Amz-Target header, got %s", r.Header.Get("X-Amz-Target"))
+		}
+
+		w.Write([]byte(`{"savingsPlans":[
+			{"savingsPlanId":"sp-2","savingsPlanType":"Compute","state":"active","commitment":"2.5","currency":"USD","start":"2024-01-01T00:00:00Z","end":"2027-01-01T00:00:00Z"},
+			{"savingsPlanId":"sp-1","savingsPlanType":"EC2Instance","state":"active","commitment":"1.0","currency":"USD","start":"2023-01-01T00:00:00Z","end":"2026-01-01T00:00:00Z"}
+		]}`))
+	}))
+	defer server.Close()
+
+	cfg := aws.Config{
+		Region:      "eu-west-2",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	client := &Client{
+		savingsPlans: NewRESTJSONAPIClient(cfg, "savingsplans", server.URL, "us-east-1"),
+		logger:       log,
+	}
+
+	plans, err := client.GetSavingsPlansInventory(context.Background())
+	if err != nil {
+		t.Fatalf("GetSavingsPlansInventory failed: %v", err)
+	}
+
+	if len(plans) != 2 {
+		t.Fatalf("Expected 2 plans, got %d", len(plans))
+	}
+	if plans[0].ID != "sp-1" || plans[0].Commitment != 1.0 || plans[0].End.Year() != 2026 {
+		t.Errorf("Expected plans sorted by end date, got %+v", plans[0])
+	}
+}
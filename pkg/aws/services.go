@@ -0,0 +1,128 @@
+package aws
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// Service names accepted by NewServiceClient
+const (
+	ServiceElastiCache = "elasticache"
+	ServiceRDS         = "rds"
+	ServiceCloudWatch  = "cloudwatch"
+	ServiceEC2         = "ec2"
+	ServiceTagging     = "tagging"
+	ServiceIAM         = "iam"
+	ServiceSTS         = "sts"
+	ServiceCloudTrail  = "cloudtrail"
+	ServiceS3          = "s3"
+	ServiceLambda      = "lambda"
+	ServiceECS         = "ecs"
+)
+
+// NewServiceClient returns the client for the named service, creating it
+// from the client's AWS config on first use and sharing it after that:
+//
+//   - "elasticache" returns *elasticache.Client
+//   - "rds" returns *rds.Client
+//   - "cloudwatch" returns *JSONAPIClient, as the CloudWatch SDK client is
+//     not vendored in this module
+//   - "ec2" returns *QueryAPIClient, as the EC2 SDK client is not vendored
+//     either
+//   - "tagging" returns *JSONAPIClient for the Resource Groups Tagging API
+//   - "iam" and "sts" return *QueryAPIClient
+//   - "cloudtrail" returns *JSONAPIClient
+//   - "s3" returns *s3.Client
+//   - "lambda" returns *lambda.Client
+//   - "ecs" returns *ecs.Client
+//
+// It returns nil for services without a client.
+func (c *Client) NewServiceClient(serviceName string) interface{} {
+	c.serviceClientsMu.Lock()
+	defer c.serviceClientsMu.Unlock()
+
+	if client, exists := c.serviceClients[serviceName]; exists {
+		return client
+	}
+
+	var client interface{}
+	switch serviceName {
+	case ServiceElastiCache:
+		client = elasticache.NewFromConfig(c.config)
+	case ServiceRDS:
+		client = rds.NewFromConfig(c.config)
+	case ServiceCloudWatch:
+		client = newCloudWatchClient(c.config)
+	case ServiceEC2:
+		client = newEC2Client(c.config)
+	case ServiceTagging:
+		client = newTaggingClient(c.config)
+	case ServiceIAM:
+		client = newIAMClient(c.config)
+	case ServiceSTS:
+		client = newSTSClient(c.config)
+	case ServiceCloudTrail:
+		client = newCloudTrailClient(c.config)
+	case ServiceS3:
+		client = newS3Client(c.config)
+	case ServiceLambda:
+		client = lambda.NewFromConfig(c.config)
+	case ServiceECS:
+		client = ecs.NewFromConfig(c.config)
+	default:
+		return nil
+	}
+
+	if c.apiCalls != nil {
+		setAPICallRecorder(client, c.apiCalls)
+	}
+	if c.serviceClients == nil {
+		c.serviceClients = make(map[string]interface{})
+	}
+	c.serviceClients[serviceName] = client
+	return client
+}
+
+// newCloudWatchClient creates a CloudWatch client, which uses awsJson1.0
+func newCloudWatchClient(cfg aws.Config) *JSONAPIClient {
+	return NewJSONAPIClient(cfg, "monitoring", "GraniteServiceVersion20100801").WithJSONVersion("1.0")
+}
+
+// newEC2Client creates an EC2 client, which uses the EC2 Query protocol
+func newEC2Client(cfg aws.Config) *QueryAPIClient {
+	return NewQueryAPIClient(cfg, "ec2", "2016-11-15")
+}
+
+// newTaggingClient creates a Resource Groups Tagging API client
+func newTaggingClient(cfg aws.Config) *JSONAPIClient {
+	return NewJSONAPIClient(cfg, "tagging", "ResourceGroupsTaggingAPI_20170126")
+}
+
+// newIAMClient creates an IAM client. IAM is a global service with a single
+// endpoint that signs requests for us-east-1.
+func newIAMClient(cfg aws.Config) *QueryAPIClient {
+	return NewGlobalQueryAPIClient(cfg, "iam", "2010-05-08", "https://iam.amazonaws.com/", "us-east-1")
+}
+
+// newSTSClient creates an STS client for the regional STS endpoint
+func newSTSClient(cfg aws.Config) *QueryAPIClient {
+	return NewQueryAPIClient(cfg, "sts", "2011-06-15")
+}
+
+// newCloudTrailClient creates a CloudTrail client, which uses awsJson1.1
+func newCloudTrailClient(cfg aws.Config) *JSONAPIClient {
+	return NewJSONAPIClient(cfg, "cloudtrail", "com.amazonaws.cloudtrail.v20131101.CloudTrail_20131101")
+}
+
+// newS3Client creates an S3 client. Buckets are addressed by path rather than
+// host name when a base endpoint is set, as a local test server has no
+// per-bucket host names.
+func newS3Client(cfg aws.Config) *s3.Client {
+	return s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = cfg.BaseEndpoint != nil
+	})
+}
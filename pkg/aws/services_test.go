@@ -0,0 +1,124 @@
+package aws
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+func TestNewServiceClient(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	client := NewClientWithCostExplorer(aws.Config{Region: "eu-west-2"}, nil, log)
+
+	elastiCacheClient, ok := client.NewServiceClient(ServiceElastiCache).(*elasticache.Client)
+	if !ok {
+		t.Fatalf("Expected *elasticache.Client, got %T", client.NewServiceClient(ServiceElastiCache))
+	}
+	if client.NewServiceClient(ServiceElastiCache) != elastiCacheClient {
+		t.Error("Expected the ElastiCache client to be reused")
+	}
+
+	if _, ok := client.NewServiceClient(ServiceRDS).(*rds.Client); !ok {
+		t.Errorf("Expected *rds.Client, got %T", client.NewServiceClient(ServiceRDS))
+	}
+	if _, ok := client.NewServiceClient(ServiceCloudWatch).(*JSONAPIClient); !ok {
+		t.Errorf("Expected *JSONAPIClient, got %T", client.NewServiceClient(ServiceCloudWatch))
+	}
+
+	if _, ok := client.NewServiceClient(ServiceEC2).(*QueryAPIClient); !ok {
+		t.Errorf("Expected *QueryAPIClient, got %T", client.NewServiceClient(ServiceEC2))
+	}
+
+	if _, ok := client.NewServiceClient(ServiceTagging).(*JSONAPIClient); !ok {
+		t.Errorf("Expected *JSONAPIClient, got %T", client.NewServiceClient(ServiceTagging))
+	}
+	if _, ok := client.NewServiceClient(ServiceCloudTrail).(*JSONAPIClient); !ok {
+		t.Errorf("Expected *JSONAPIClient, got %T", client.NewServiceClient(ServiceCloudTrail))
+	}
+	if _, ok := client.NewServiceClient(ServiceS3).(*s3.Client); !ok {
+		t.Errorf("Expected *s3.Client, got %T", client.NewServiceClient(ServiceS3))
+	}
+	if _, ok := client.NewServiceClient(ServiceLambda).(*lambda.Client); !ok {
+		t.Errorf("Expected *lambda.Client, got %T", client.NewServiceClient(ServiceLambda))
+	}
+	if _, ok := client.NewServiceClient(ServiceECS).(*ecs.Client); !ok {
+		t.Errorf("Expected *ecs.Client, got %T", client.NewServiceClient(ServiceECS))
+	}
+	for _, service := range []string{ServiceIAM, ServiceSTS} {
+		if _, ok := client.NewServiceClient(service).(*QueryAPIClient); !ok {
+			t.Errorf("Expected *QueryAPIClient for %s, got %T", service, client.NewServiceClient(service))
+		}
+	}
+
+	if serviceClient := client.NewServiceClient("unknown"); serviceClient != nil {
+		t.Errorf("Expected nil for an unknown service, got %T", serviceClient)
+	}
+}
+
+// recordingAPICalls records the calls passed to RecordAWSAPICall
+type recordingAPICalls struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *recordingAPICalls) RecordAWSAPICall(service, operation string, success bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := "ok"
+	if !success {
+		result = "failed"
+	}
+	r.calls = append(r.calls, service+"/"+operation+"/"+result)
+}
+
+func TestClient_SetAPICallRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Amz-Target") != "" {
+			w.Write([]byte(`{}`))
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`<ErrorResponse><Error><Code>AccessDenied</Code></Error></ErrorResponse>`))
+	}))
+	defer server.Close()
+
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	client := NewClientWithCostExplorer(aws.Config{
+		Region:       "eu-west-2",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+		// Retries should count as a single call
+		RetryMaxAttempts: 3,
+	}, nil, log)
+	recorder := &recordingAPICalls{}
+	client.SetAPICallRecorder(recorder)
+
+	rdsClient := client.NewServiceClient(ServiceRDS).(*rds.Client)
+	if _, err := rdsClient.DescribeDBInstances(context.Background(), &rds.DescribeDBInstancesInput{}); err == nil {
+		t.Fatal("Expected DescribeDBInstances to fail")
+	}
+
+	cloudWatch := client.NewServiceClient(ServiceCloudWatch).(*JSONAPIClient).WithEndpoint(server.URL)
+	if err := cloudWatch.Call(context.Background(), "ListMetrics", map[string]string{}, nil); err != nil {
+		t.Fatalf("ListMetrics failed: %v", err)
+	}
+
+	expected := []string{"rds/DescribeDBInstances/failed", "monitoring/ListMetrics/ok"}
+	if !reflect.DeepEqual(recorder.calls, expected) {
+		t.Errorf("Expected calls %v, got %v", expected, recorder.calls)
+	}
+}
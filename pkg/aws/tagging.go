@@ -0,0 +1,118 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// maxTagResourcesARNs is the most resources TagResources accepts per call
+const maxTagResourcesARNs = 20
+
+// TaggingError describes a resource that could not be tagged
+type TaggingError struct {
+	ResourceARN  string `json:"resource_arn"`
+	ErrorCode    string `json:"error_code"`
+	ErrorMessage string `json:"error_message"`
+	StatusCode   int    `json:"status_code,omitempty"`
+}
+
+type tagResourcesInput struct {
+	ResourceARNList []string          `json:"ResourceARNList"`
+	Tags            map[string]string `json:"Tags"`
+}
+
+type tagResourcesOutput struct {
+	FailedResourcesMap map[string]struct {
+		ErrorCode    string `json:"ErrorCode"`
+		ErrorMessage string `json:"ErrorMessage"`
+		StatusCode   int    `json:"StatusCode"`
+	} `json:"FailedResourcesMap"`
+}
+
+// TagResources applies tags, keyed by resource ARN, using the Resource Groups
+// Tagging API. Resources sharing the same tags are tagged together. It
+// returns the resources that could not be tagged, including every resource
+// in a call that failed outright, and only returns an error if ctx is done.
+func (c *Client) TagResources(ctx context.Context, tags map[string]map[string]string) ([]TaggingError, error) {
+	tagging := c.NewServiceClient(ServiceTagging).(*JSONAPIClient)
+
+	// TagResources takes one set of tags for many resources, so group them
+	groups := make(map[string][]string)
+	groupTags := make(map[string]map[string]string)
+	for arn, resourceTags := range tags {
+		key := tagSetKey(resourceTags)
+		groups[key] = append(groups[key], arn)
+		groupTags[key] = resourceTags
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	failures := []TaggingError{}
+	for _, key := range keys {
+		arns := groups[key]
+		sort.Strings(arns)
+
+		for start := 0; start < len(arns); start += maxTagResourcesARNs {
+			end := min(start+maxTagResourcesARNs, len(arns))
+			batch := arns[start:end]
+
+			if err := ctx.Err(); err != nil {
+				return failures, err
+			}
+
+			var output tagResourcesOutput
+			input := tagResourcesInput{ResourceARNList: batch, Tags: groupTags[key]}
+			if err := tagging.Call(ctx, "TagResources", input, &output); err != nil {
+				c.logger.WithError(err).WithField("resource_count", len(batch)).Error().Msg("Failed to tag resources")
+
+				errorCode := "Unknown"
+				var apiErr interface{ ErrorCode() string }
+				if errors.As(err, &apiErr) && apiErr.ErrorCode() != "" {
+					errorCode = apiErr.ErrorCode()
+				}
+				for _, arn := range batch {
+					failures = append(failures, TaggingError{
+						ResourceARN:  arn,
+						ErrorCode:    errorCode,
+						ErrorMessage: err.Error(),
+					})
+				}
+				continue
+			}
+
+			for _, arn := range batch {
+				if failed, exists := output.FailedResourcesMap[arn]; exists {
+					failures = append(failures, TaggingError{
+						ResourceARN:  arn,
+						ErrorCode:    failed.ErrorCode,
+						ErrorMessage: failed.ErrorMessage,
+						StatusCode:   failed.StatusCode,
+					})
+				}
+			}
+		}
+	}
+
+	c.logger.WithFields(map[string]interface{}{
+		"resource_count": len(tags),
+		"failed_count":   len(failures),
+	}).Info().Msg("Tagged resources")
+
+	return failures, nil
+}
+
+// tagSetKey returns a key identifying a set of tags regardless of map order
+func tagSetKey(tags map[string]string) string {
+	pairs := make([]string, 0, len(tags))
+	for key, value := range tags {
+		pairs = append(pairs, key+"\x00"+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "\x01")
+}
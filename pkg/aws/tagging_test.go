@@ -0,0 +1,132 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+)
+
+func TestTagResources(t *testing.T) {
+	var calls []tagResourcesInput
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if target := r.Header.Get("X-Amz-Target"); target != "ResourceGroupsTaggingAPI_20170126.TagResources" {
+			t.Errorf("Unexpected target %s", target)
+		}
+
+		var input tagResourcesInput
+		json.NewDecoder(r.Body).Decode(&input)
+		calls = append(calls, input)
+
+		if input.Tags["system"] == "broken" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"InvalidParameterException","message":"bad tag"}`))
+			return
+		}
+		w.Write([]byte(`{"FailedResourcesMap":{"arn:aws:rds:eu-west-2:1:db:db-2":
+			{"ErrorCode":"InvalidParameterException","ErrorMessage":"denied","StatusCode":400}}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := aws.Config{
+		Region:      "eu-west-2",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	client := &Client{
+		config: cfg,
+		logger: log,
+		serviceClients: map[string]interface{}{
+			ServiceTagging: newTaggingClient(cfg).WithEndpoint(server.URL),
+		},
+	}
+
+	failures, err := client.TagResources(context.Background(), map[string]map[string]string{
+		"arn:aws:rds:eu-west-2:1:db:db-1": {"system": "govuk-frontend"},
+		"arn:aws:rds:eu-west-2:1:db:db-2": {"system": "govuk-frontend"},
+		"arn:aws:rds:eu-west-2:1:db:db-3": {"system": "broken"},
+	})
+	if err != nil {
+		t.Fatalf("TagResources failed: %v", err)
+	}
+
+	if len(calls) != 2 {
+		t.Fatalf("Expected resources grouped into 2 calls, got %d", len(calls))
+	}
+	if len(failures) != 2 {
+		t.Fatalf("Expected 2 failures, got %+v", failures)
+	}
+	if failures[0].ResourceARN != "arn:aws:rds:eu-west-2:1:db:db-3" || failures[0].ErrorCode != "InvalidParameterException" {
+		t.Errorf("Expected failed call to mark db-3 as failed, got %+v", failures[0])
+	}
+	if failures[1].ResourceARN != "arn:aws:rds:eu-west-2:1:db:db-2" || failures[1].StatusCode != 400 {
+		t.Errorf("Expected db-2 failure from FailedResourcesMap, got %+v", failures[1])
+	}
+}
+
+func TestPermissionCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		decision string
+		wantErr  bool
+	}{
+		{name: "allowed", decision: "allowed"},
+		{name: "denied", decision: "implicitDeny", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				r.ParseForm()
+				switch r.PostForm.Get("Action") {
+				case "GetCallerIdentity":
+					w.Write([]byte(`<GetCallerIdentityResponse><GetCallerIdentityResult>
+						<Arn>arn:aws:sts::123456789012:assumed-role/dashboard/session</Arn>
+					</GetCallerIdentityResult></GetCallerIdentityResponse>`))
+				case "SimulatePrincipalPolicy":
+					if source := r.PostForm.Get("PolicySourceArn"); source != "arn:aws:iam::123456789012:role/dashboard" {
+						t.Errorf("Expected role ARN, got %s", source)
+					}
+					w.Write([]byte(`<SimulatePrincipalPolicyResponse><SimulatePrincipalPolicyResult><EvaluationResults>
+						<member><EvalActionName>tag:TagResources</EvalActionName><EvalDecision>` + tt.decision + `</EvalDecision></member>
+					</EvaluationResults></SimulatePrincipalPolicyResult></SimulatePrincipalPolicyResponse>`))
+				}
+			}))
+			t.Cleanup(server.Close)
+
+			cfg := aws.Config{
+				Region:      "eu-west-2",
+				Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+			}
+			log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+			client := &Client{
+				config: cfg,
+				logger: log,
+				serviceClients: map[string]interface{}{
+					ServiceSTS: newSTSClient(cfg).WithEndpoint(server.URL),
+					ServiceIAM: newIAMClient(cfg).WithEndpoint(server.URL),
+				},
+			}
+
+			err := client.PermissionCheck(context.Background(), "tag:TagResources")
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("Expected permission to be allowed, got %v", err)
+				}
+				return
+			}
+
+			var permissionErr PermissionError
+			if !errors.As(err, &permissionErr) || permissionErr.Service != "tag" || permissionErr.ErrorCode != "AccessDenied" {
+				t.Errorf("Expected AccessDenied PermissionError for tag, got %v", err)
+			}
+		})
+	}
+}
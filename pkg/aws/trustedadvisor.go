@@ -0,0 +1,170 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// TrustedAdvisorCostOptimizing is the Trusted Advisor category for cost checks
+const TrustedAdvisorCostOptimizing = "cost_optimizing"
+
+// ErrSupportSubscriptionRequired is returned when the account does not have a
+// Business or Enterprise Support plan, which Trusted Advisor checks require
+var ErrSupportSubscriptionRequired = errors.New("a Business or Enterprise Support plan is required for Trusted Advisor")
+
+// TrustedAdvisorRecommendation is the latest result of a Trusted Advisor check
+type TrustedAdvisorRecommendation struct {
+	CheckID                 string            `json:"check_id"`
+	CheckName               string            `json:"check_name"`
+	Description             string            `json:"description"`
+	Category                string            `json:"category"`
+	Status                  string            `json:"status"` // "ok", "warning", "error"
+	ResourcesSummary        ResourcesSummary  `json:"resources_summary"`
+	EstimatedMonthlySavings float64           `json:"estimated_monthly_savings"`
+	FlaggedResources        []FlaggedResource `json:"flagged_resources"`
+}
+
+// ResourcesSummary counts the resources a Trusted Advisor check looked at
+type ResourcesSummary struct {
+	ResourcesProcessed  int `json:"resources_processed"`
+	ResourcesFlagged    int `json:"resources_flagged"`
+	ResourcesIgnored    int `json:"resources_ignored"`
+	ResourcesSuppressed int `json:"resources_suppressed"`
+}
+
+// FlaggedResource is a resource a Trusted Advisor check raised a finding for
+type FlaggedResource struct {
+	ResourceID string   `json:"resource_id"`
+	Region     string   `json:"region,omitempty"`
+	Status     string   `json:"status"` // "ok", "warning", "error"
+	Metadata   []string `json:"metadata,omitempty"`
+}
+
+type trustedAdvisorCheck struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Category    string `json:"category"`
+}
+
+type describeTrustedAdvisorChecksOutput struct {
+	Checks []trustedAdvisorCheck `json:"checks"`
+}
+
+type describeTrustedAdvisorCheckResultOutput struct {
+	Result struct {
+		Status           string `json:"status"`
+		ResourcesSummary struct {
+			ResourcesProcessed  int `json:"resourcesProcessed"`
+			ResourcesFlagged    int `json:"resourcesFlagged"`
+			ResourcesIgnored    int `json:"resourcesIgnored"`
+			ResourcesSuppressed int `json:"resourcesSuppressed"`
+		} `json:"resourcesSummary"`
+		CategorySpecificSummary struct {
+			CostOptimizing struct {
+				EstimatedMonthlySavings float64 `json:"estimatedMonthlySavings"`
+			} `json:"costOptimizing"`
+		} `json:"categorySpecificSummary"`
+		FlaggedResources []struct {
+			ResourceID   string    `json:"resourceId"`
+			Region       string    `json:"region"`
+			Status       string    `json:"status"`
+			IsSuppressed bool      `json:"isSuppressed"`
+			Metadata     []*string `json:"metadata"`
+		} `json:"flaggedResources"`
+	} `json:"result"`
+}
+
+// newSupportClient creates a client for the AWS Support API, which is only
+// served from us-east-1
+func newSupportClient(cfg aws.Config) *JSONAPIClient {
+	supportCfg := cfg.Copy()
+	supportCfg.Region = "us-east-1"
+	return NewJSONAPIClient(supportCfg, "support", "AWSSupport_20130415")
+}
+
+// GetTrustedAdvisorRecommendations returns the latest results of every
+// Trusted Advisor check in the given category, e.g.
+// TrustedAdvisorCostOptimizing. Checks whose result cannot be fetched are
+// logged and skipped. Returns ErrSupportSubscriptionRequired if the account
+// has no Business or Enterprise Support plan.
+func (c *Client) GetTrustedAdvisorRecommendations(ctx context.Context, category string) ([]TrustedAdvisorRecommendation, error) {
+	checks, err := c.describeTrustedAdvisorChecks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var recommendations []TrustedAdvisorRecommendation
+	for _, check := range checks {
+		if category != "" && check.Category != category {
+			continue
+		}
+
+		var output describeTrustedAdvisorCheckResultOutput
+		input := map[string]string{"checkId": check.ID, "language": "en"}
+		if err := c.support.Call(ctx, "DescribeTrustedAdvisorCheckResult", input, &output); err != nil {
+			c.logger.WithError(err).WithField("check", check.Name).Warn().Msg("Failed to get Trusted Advisor check result")
+			continue
+		}
+
+		result := output.Result
+		recommendation := TrustedAdvisorRecommendation{
+			CheckID:     check.ID,
+			CheckName:   check.Name,
+			Description: check.Description,
+			Category:    check.Category,
+			Status:      result.Status,
+			ResourcesSummary: ResourcesSummary{
+				ResourcesProcessed:  result.ResourcesSummary.ResourcesProcessed,
+				ResourcesFlagged:    result.ResourcesSummary.ResourcesFlagged,
+				ResourcesIgnored:    result.ResourcesSummary.ResourcesIgnored,
+				ResourcesSuppressed: result.ResourcesSummary.ResourcesSuppressed,
+			},
+			EstimatedMonthlySavings: result.CategorySpecificSummary.CostOptimizing.EstimatedMonthlySavings,
+		}
+
+		for _, resource := range result.FlaggedResources {
+			if resource.IsSuppressed {
+				continue
+			}
+
+			flagged := FlaggedResource{
+				ResourceID: resource.ResourceID,
+				Region:     resource.Region,
+				Status:     resource.Status,
+			}
+			for _, value := range resource.Metadata {
+				flagged.Metadata = append(flagged.Metadata, getStringValue(value))
+			}
+			recommendation.FlaggedResources = append(recommendation.FlaggedResources, flagged)
+		}
+
+		recommendations = append(recommendations, recommendation)
+	}
+
+	return recommendations, nil
+}
+
+// CheckTrustedAdvisorAccess makes a single cheap Support API call to confirm
+// Trusted Advisor can be used with the current account and credentials
+func (c *Client) CheckTrustedAdvisorAccess(ctx context.Context) error {
+	_, err := c.describeTrustedAdvisorChecks(ctx)
+	return err
+}
+
+func (c *Client) describeTrustedAdvisorChecks(ctx context.Context) ([]trustedAdvisorCheck, error) {
+	var output describeTrustedAdvisorChecksOutput
+	if err := c.support.Call(ctx, "DescribeTrustedAdvisorChecks", map[string]string{"language": "en"}, &output); err != nil {
+		var apiErr *JSONAPIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "SubscriptionRequiredException" {
+			return nil, ErrSupportSubscriptionRequired
+		}
+		c.logger.WithError(err).Error().Msg("Failed to describe Trusted Advisor checks")
+		return nil, fmt.Errorf("failed to describe trusted advisor checks: %w", err)
+	}
+
+	return output.Checks, nil
+}
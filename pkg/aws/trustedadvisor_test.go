@@ -0,0 +1,80 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"govuk-reports-dashboard/pkg/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+)
+
+func newTestSupportClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	cfg := aws.Config{
+		Region:      "eu-west-2",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	return &Client{
+		support: newSupportClient(cfg).WithEndpoint(server.URL),
+		logger:  log,
+	}
+}
+
+func TestGetTrustedAdvisorRecommendations(t *testing.T) {
+	client := newTestSupportClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("X-Amz-Target") {
+		case "AWSSupport_20130415.DescribeTrustedAdvisorChecks":
+			w.Write([]byte(`{"checks":[
+				{"id":"idle-rds","name":"Amazon RDS Idle DB Instances","category":"cost_optimizing"},
+				{"id":"mfa-root","name":"MFA on Root Account","category":"security"}
+			]}`))
+		case "AWSSupport_20130415.DescribeTrustedAdvisorCheckResult":
+			w.Write([]byte(`{"result":{"checkId":"idle-rds","status":"warning",
+				"resourcesSummary":{"resourcesProcessed":10,"resourcesFlagged":2},
+				"categorySpecificSummary":{"costOptimizing":{"estimatedMonthlySavings":150.5}},
+				"flaggedResources":[
+					{"resourceId":"db-1","region":"eu-west-2","status":"warning","metadata":["eu-west-2","db-1"]},
+					{"resourceId":"db-2","region":"eu-west-2","status":"warning","isSuppressed":true}
+				]}}`))
+		default:
+			t.Errorf("Unexpected target %s", r.Header.Get("X-Amz-Target"))
+		}
+	})
+
+	recommendations, err := client.GetTrustedAdvisorRecommendations(context.Background(), TrustedAdvisorCostOptimizing)
+	if err != nil {
+		t.Fatalf("GetTrustedAdvisorRecommendations failed: %v", err)
+	}
+
+	if len(recommendations) != 1 {
+		t.Fatalf("Expected 1 cost optimizing recommendation, got %d", len(recommendations))
+	}
+	recommendation := recommendations[0]
+	if recommendation.Status != "warning" || recommendation.ResourcesSummary.ResourcesFlagged != 2 || recommendation.EstimatedMonthlySavings != 150.5 {
+		t.Errorf("Unexpected recommendation: %+v", recommendation)
+	}
+	// Suppressed resources are skipped
+	if len(recommendation.FlaggedResources) != 1 || recommendation.FlaggedResources[0].ResourceID != "db-1" {
+		t.Errorf("Unexpected flagged resources: %+v", recommendation.FlaggedResources)
+	}
+}
+
+func TestGetTrustedAdvisorRecommendations_SubscriptionRequired(t *testing.T) {
+	client := newTestSupportClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"__type":"SubscriptionRequiredException","message":"AWS Premium Support Subscription is required"}`))
+	})
+
+	_, err := client.GetTrustedAdvisorRecommendations(context.Background(), TrustedAdvisorCostOptimizing)
+	if !errors.Is(err, ErrSupportSubscriptionRequired) {
+		t.Errorf("Expected ErrSupportSubscriptionRequired, got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package common
+
+import "time"
+
+// CostData represents cost information for a service
+type CostData struct {
+	Service          string    `json:"service"`
+	Amount           float64   `json:"amount"`
+	Currency         string    `json:"currency"`
+	StartDate        time.Time `json:"start_date"`
+	EndDate          time.Time `json:"end_date"`
+	Granularity      string    `json:"granularity"`
+	OriginalCurrency string    `json:"original_currency,omitempty"`
+	ExchangeRate     float64   `json:"exchange_rate,omitempty"`
+}
+
+// DateRange is the period from Start up to, but not including, End
+type DateRange struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// CostForecast is the projected cost over ForecastPeriod. LowerBound and
+// UpperBound are the limits of the forecast's prediction interval.
+type CostForecast struct {
+	MeanCost       float64   `json:"mean_cost"`
+	LowerBound     float64   `json:"lower_bound"`
+	UpperBound     float64   `json:"upper_bound"`
+	Currency       string    `json:"currency"`
+	ForecastPeriod DateRange `json:"forecast_period"`
+}
+
+// IsZero reports whether the entry has no cost
+func (c CostData) IsZero() bool {
+	return c.Amount == 0
+}
+
+// DateRange returns the start and end of the period the cost covers
+func (c CostData) DateRange() (time.Time, time.Time) {
+	return c.StartDate, c.EndDate
+}
+
+// CostDataSlice is a list of cost entries with helpers for totalling them
+type CostDataSlice []CostData
+
+// Sum returns the total amount of all entries
+func (s CostDataSlice) Sum() float64 {
+	total := 0.0
+	for _, cost := range s {
+		total += cost.Amount
+	}
+	return total
+}
+
+// ByService returns the total amount for each service
+func (s CostDataSlice) ByService() map[string]float64 {
+	totals := make(map[string]float64)
+	for _, cost := range s {
+		totals[cost.Service] += cost.Amount
+	}
+	return totals
+}
+
+// FilterByDateRange returns the entries whose period lies within start and end
+func (s CostDataSlice) FilterByDateRange(start, end time.Time) CostDataSlice {
+	var filtered CostDataSlice
+	for _, cost := range s {
+		if !cost.StartDate.Before(start) && !cost.EndDate.After(end) {
+			filtered = append(filtered, cost)
+		}
+	}
+	return filtered
+}
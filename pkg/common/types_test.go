@@ -0,0 +1,42 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCostDataSlice(t *testing.T) {
+	march := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	april := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	may := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	costs := CostDataSlice{
+		{Service: "Amazon Relational Database Service", Amount: 100, StartDate: march, EndDate: april},
+		{Service: "Amazon Relational Database Service", Amount: 50, StartDate: april, EndDate: may},
+		{Service: "Amazon ElastiCache", Amount: 25, StartDate: april, EndDate: may},
+		{Service: "AWS Lambda", Amount: 0, StartDate: april, EndDate: may},
+	}
+
+	if got := costs.Sum(); got != 175 {
+		t.Errorf("Expected sum 175, got %v", got)
+	}
+
+	byService := costs.ByService()
+	if byService["Amazon Relational Database Service"] != 150 || byService["Amazon ElastiCache"] != 25 {
+		t.Errorf("Unexpected totals by service: %v", byService)
+	}
+
+	aprilCosts := costs.FilterByDateRange(april, may)
+	if len(aprilCosts) != 3 || aprilCosts.Sum() != 75 {
+		t.Errorf("Expected 3 April entries totalling 75, got %d totalling %v", len(aprilCosts), aprilCosts.Sum())
+	}
+
+	if !costs[3].IsZero() || costs[0].IsZero() {
+		t.Error("Unexpected IsZero result")
+	}
+
+	start, end := costs[0].DateRange()
+	if !start.Equal(march) || !end.Equal(april) {
+		t.Errorf("Unexpected date range: %v - %v", start, end)
+	}
+}
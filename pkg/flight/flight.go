@@ -0,0 +1,72 @@
+// Package flight shares a call between concurrent callers, as singleflight
+// does, but cancels the call once every caller waiting on it has given up.
+package flight
+
+import (
+	"context"
+	"sync"
+)
+
+// call is a call in progress or finished. waiters is guarded by the group's
+// mutex; val and err are set before done is closed.
+type call struct {
+	done    chan struct{}
+	val     interface{}
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// Group runs calls keyed by string. The zero value is ready to use.
+type Group struct {
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+// Do calls fn once for concurrent callers with the same key and returns its
+// result to each. fn's context carries the values of the caller that started
+// it, and is cancelled once every caller's ctx is done. A caller whose ctx
+// is done returns ctx.Err() without waiting for fn.
+func (g *Group) Do(ctx context.Context, key string, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*call)
+	}
+	c, ok := g.calls[key]
+	if !ok {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		c = &call{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = c
+
+		go func() {
+			defer close(c.done)
+			defer cancel()
+			c.val, c.err = fn(callCtx)
+
+			g.mu.Lock()
+			if g.calls[key] == c {
+				delete(g.calls, key)
+			}
+			g.mu.Unlock()
+		}()
+	}
+	c.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.val, c.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		c.waiters--
+		if c.waiters == 0 {
+			c.cancel()
+			// Later callers start a new call rather than joining this one
+			if g.calls[key] == c {
+				delete(g.calls, key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,99 @@
+package flight
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroup_SharesCall(t *testing.T) {
+	var g Group
+	var calls int32
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	fn := func(ctx context.Context) (interface{}, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			close(started)
+		}
+		<-release
+		return "apps", nil
+	}
+
+	results := make(chan interface{}, 2)
+	go func() {
+		val, _ := g.Do(context.Background(), "key", fn)
+		results <- val
+	}()
+	<-started
+	go func() {
+		val, _ := g.Do(context.Background(), "key", fn)
+		results <- val
+	}()
+
+	// Give the second caller time to join the call
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	for i := 0; i < 2; i++ {
+		if val := <-results; val != "apps" {
+			t.Errorf("Expected the shared result, got %v", val)
+		}
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Expected 1 call, got %d", got)
+	}
+}
+
+func TestGroup_CancelsWhenEveryCallerLeaves(t *testing.T) {
+	var g Group
+	started := make(chan struct{})
+	stopped := make(chan error, 1)
+
+	fn := func(ctx context.Context) (interface{}, error) {
+		close(started)
+		<-ctx.Done()
+		stopped <- ctx.Err()
+		return nil, ctx.Err()
+	}
+
+	first, cancelFirst := context.WithCancel(context.Background())
+	second, cancelSecond := context.WithCancel(context.Background())
+	errs := make(chan error, 2)
+	go func() {
+		_, err := g.Do(first, "key", fn)
+		errs <- err
+	}()
+	<-started
+	go func() {
+		_, err := g.Do(second, "key", fn)
+		errs <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	// The call carries on while the second caller waits
+	cancelFirst()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the first caller's error, got %v", err)
+	}
+	select {
+	case <-stopped:
+		t.Fatal("Expected the call to continue while a caller waits")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	cancelSecond()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the second caller's error, got %v", err)
+	}
+	select {
+	case err := <-stopped:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected the call's context to be cancelled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the call to be cancelled once every caller left")
+	}
+}
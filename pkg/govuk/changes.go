@@ -0,0 +1,253 @@
+package govuk
+
+import "time"
+
+// ChangeEventsBufferSize is a buffer size for Client.ChangeEvents large
+// enough for a typical apps.json update
+const ChangeEventsBufferSize = 100
+
+// diffSubscriberBufferSize is how many diffs a SubscribeDiffs channel holds
+// before further diffs are dropped for that subscriber
+const diffSubscriberBufferSize = 4
+
+// Fields reported in ApplicationChangeEvent. FieldApplication is used when an
+// application is added, with a nil OldValue, or removed, with a nil NewValue.
+const (
+	FieldApplication        = "application"
+	FieldTeam               = "team"
+	FieldAlertsTeam         = "alerts_team"
+	FieldShortname          = "shortname"
+	FieldProductionHostedOn = "production_hosted_on"
+	FieldRepoURL            = "links.repo_url"
+	FieldSentryURL          = "links.sentry_url"
+)
+
+// ApplicationChangeEvent records a change to an application in apps.json
+// between two fetches of the application list
+type ApplicationChangeEvent struct {
+	AppName    string      `json:"app_name"`
+	Field      string      `json:"field"`
+	OldValue   interface{} `json:"old_value"`
+	NewValue   interface{} `json:"new_value"`
+	DetectedAt time.Time   `json:"detected_at"`
+}
+
+// FieldChange is a change to one field of an application
+type FieldChange struct {
+	Field    string      `json:"field"`
+	OldValue interface{} `json:"old_value"`
+	NewValue interface{} `json:"new_value"`
+}
+
+// ApplicationChange is an application whose fields changed between two
+// application lists
+type ApplicationChange struct {
+	AppName string        `json:"app_name"`
+	Changes []FieldChange `json:"changes"`
+}
+
+// ApplicationListDiff is the difference between two fetches of the
+// application list, matching applications by name. PreviousListAt is when
+// the older list was fetched.
+type ApplicationListDiff struct {
+	Added           []Application       `json:"added"`
+	Removed         []Application       `json:"removed"`
+	Modified        []ApplicationChange `json:"modified"`
+	DiffGeneratedAt time.Time           `json:"diff_generated_at"`
+	PreviousListAt  time.Time           `json:"previous_list_at"`
+}
+
+// IsEmpty reports whether the two lists were the same
+func (d ApplicationListDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// LastDiff returns the difference between the two most recent application
+// lists that differed, or false if the list hasn't changed since the client
+// was created
+func (c *Client) LastDiff() (ApplicationListDiff, bool) {
+	c.cacheMu.RLock()
+	defer c.cacheMu.RUnlock()
+
+	if c.lastDiff == nil {
+		return ApplicationListDiff{}, false
+	}
+	return *c.lastDiff, true
+}
+
+// SubscribeDiffs returns a channel that receives each new ApplicationListDiff,
+// and a function to unsubscribe. Diffs are dropped for subscribers that fall
+// behind.
+func (c *Client) SubscribeDiffs() (<-chan ApplicationListDiff, func()) {
+	ch := make(chan ApplicationListDiff, diffSubscriberBufferSize)
+
+	c.diffSubscribersMu.Lock()
+	if c.diffSubscribers == nil {
+		c.diffSubscribers = make(map[chan ApplicationListDiff]struct{})
+	}
+	c.diffSubscribers[ch] = struct{}{}
+	c.diffSubscribersMu.Unlock()
+
+	unsubscribe := func() {
+		c.diffSubscribersMu.Lock()
+		delete(c.diffSubscribers, ch)
+		c.diffSubscribersMu.Unlock()
+	}
+	return ch, unsubscribe
+}
+
+// recordApplicationChanges compares apps to the previous snapshot of the
+// application list, storing the diff for LastDiff and sending it to diff
+// subscribers, and sends an event for each difference to ChangeEvents. None
+// of the sends block. The first list fetched only becomes the snapshot.
+func (c *Client) recordApplicationChanges(apps APIResponse) {
+	now := time.Now()
+
+	c.cacheMu.Lock()
+	previous, previousAt := c.appsSnapshot, c.appsSnapshotAt
+	c.appsSnapshot, c.appsSnapshotAt = apps, now
+	c.cacheMu.Unlock()
+
+	if previous == nil {
+		return
+	}
+
+	diff := diffApplications(previous, apps)
+	if diff.IsEmpty() {
+		return
+	}
+	diff.DiffGeneratedAt = now
+	diff.PreviousListAt = previousAt
+
+	c.cacheMu.Lock()
+	c.lastDiff = &diff
+	c.cacheMu.Unlock()
+
+	c.diffSubscribersMu.Lock()
+	for subscriber := range c.diffSubscribers {
+		select {
+		case subscriber <- diff:
+		default:
+		}
+	}
+	c.diffSubscribersMu.Unlock()
+
+	c.logger.WithFields(map[string]interface{}{
+		"added":    len(diff.Added),
+		"removed":  len(diff.Removed),
+		"modified": len(diff.Modified),
+	}).Info().Msg("GOV.UK application list changed")
+
+	if c.ChangeEvents == nil {
+		return
+	}
+
+	events := diff.events()
+	dropped := 0
+	for _, event := range events {
+		select {
+		case c.ChangeEvents <- event:
+		default:
+			dropped++
+		}
+	}
+	if dropped > 0 {
+		c.logger.WithField("dropped", dropped).Warn().Msg("Application change events channel full, events dropped")
+	}
+}
+
+// events returns an ApplicationChangeEvent for each change in the diff:
+// modified fields, then added applications, then removed ones
+func (d ApplicationListDiff) events() []ApplicationChangeEvent {
+	var events []ApplicationChangeEvent
+	change := func(appName, field string, oldValue, newValue interface{}) {
+		events = append(events, ApplicationChangeEvent{
+			AppName:    appName,
+			Field:      field,
+			OldValue:   oldValue,
+			NewValue:   newValue,
+			DetectedAt: d.DiffGeneratedAt,
+		})
+	}
+
+	for _, modified := range d.Modified {
+		for _, fieldChange := range modified.Changes {
+			change(modified.AppName, fieldChange.Field, fieldChange.OldValue, fieldChange.NewValue)
+		}
+	}
+	for _, app := range d.Added {
+		change(app.AppName, FieldApplication, nil, app)
+	}
+	for _, app := range d.Removed {
+		change(app.AppName, FieldApplication, app, nil)
+	}
+
+	return events
+}
+
+// diffApplications returns the difference from previous to current,
+// matching applications by name. Added and modified applications are in
+// current's order and removed ones in previous's order.
+func diffApplications(previous, current []Application) ApplicationListDiff {
+	diff := ApplicationListDiff{
+		Added:    []Application{},
+		Removed:  []Application{},
+		Modified: []ApplicationChange{},
+	}
+
+	previousByName := make(map[string]Application, len(previous))
+	for _, app := range previous {
+		previousByName[app.AppName] = app
+	}
+
+	seen := make(map[string]bool, len(current))
+	for _, app := range current {
+		seen[app.AppName] = true
+
+		old, existed := previousByName[app.AppName]
+		if !existed {
+			diff.Added = append(diff.Added, app)
+			continue
+		}
+
+		var changes []FieldChange
+		for _, field := range []struct {
+			name     string
+			old, new string
+		}{
+			{FieldTeam, old.Team, app.Team},
+			{FieldAlertsTeam, old.AlertsTeam, app.AlertsTeam},
+			{FieldShortname, old.Shortname, app.Shortname},
+			{FieldProductionHostedOn, old.ProductionHostedOn, app.ProductionHostedOn},
+			{FieldRepoURL, old.Links.RepoURL, app.Links.RepoURL},
+		} {
+			if field.old != field.new {
+				changes = append(changes, FieldChange{Field: field.name, OldValue: field.old, NewValue: field.new})
+			}
+		}
+
+		if oldSentry, newSentry := optionalString(old.Links.SentryURL), optionalString(app.Links.SentryURL); oldSentry != newSentry {
+			changes = append(changes, FieldChange{Field: FieldSentryURL, OldValue: oldSentry, NewValue: newSentry})
+		}
+
+		if len(changes) > 0 {
+			diff.Modified = append(diff.Modified, ApplicationChange{AppName: app.AppName, Changes: changes})
+		}
+	}
+
+	for _, app := range previous {
+		if !seen[app.AppName] {
+			diff.Removed = append(diff.Removed, app)
+		}
+	}
+
+	return diff
+}
+
+// optionalString returns the value of s, or nil if s is nil
+func optionalString(s *string) interface{} {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
@@ -0,0 +1,103 @@
+package govuk
+
+import "testing"
+
+func TestClientApplicationChangeEvents(t *testing.T) {
+	client := setupTestClient(t, "")
+	client.ChangeEvents = make(chan ApplicationChangeEvent, 10)
+	key := client.getCacheKey("apps")
+
+	client.setCache(key, createMockApplications())
+	if len(client.ChangeEvents) != 0 {
+		t.Fatalf("Expected no events for the first application list, got %d", len(client.ChangeEvents))
+	}
+
+	apps := createMockApplications()
+	apps[0].ProductionHostedOn = "ec2"
+	apps[1].Links.SentryURL = stringPtr("https://sentry.io/organizations/govuk/projects/content-store/")
+	apps[2] = Application{AppName: "Search API", Team: "#search"}
+	client.setCache(key, apps)
+	close(client.ChangeEvents)
+
+	var events []ApplicationChangeEvent
+	for event := range client.ChangeEvents {
+		events = append(events, event)
+	}
+
+	want := []struct {
+		appName  string
+		field    string
+		oldValue interface{}
+		newValue interface{}
+	}{
+		{"Publishing API", FieldProductionHostedOn, "eks", "ec2"},
+		{"Content Store", FieldSentryURL, nil, "https://sentry.io/organizations/govuk/projects/content-store/"},
+		{"Search API", FieldApplication, nil, apps[2]},
+		{"Frontend", FieldApplication, createMockApplications()[2], nil},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("Expected %d events, got %d: %+v", len(want), len(events), events)
+	}
+	for i, w := range want {
+		event := events[i]
+		if event.AppName != w.appName || event.Field != w.field {
+			t.Errorf("Event %d: expected %s %s, got %s %s", i, w.appName, w.field, event.AppName, event.Field)
+		}
+		if w.field != FieldApplication && (event.OldValue != w.oldValue || event.NewValue != w.newValue) {
+			t.Errorf("Event %d: expected %v -> %v, got %v -> %v", i, w.oldValue, w.newValue, event.OldValue, event.NewValue)
+		}
+		if (w.oldValue == nil) != (event.OldValue == nil) || (w.newValue == nil) != (event.NewValue == nil) {
+			t.Errorf("Event %d: unexpected nil values %v -> %v", i, event.OldValue, event.NewValue)
+		}
+		if event.DetectedAt.IsZero() {
+			t.Errorf("Event %d: expected DetectedAt to be set", i)
+		}
+	}
+}
+
+func TestClientLastDiff(t *testing.T) {
+	client := setupTestClient(t, "")
+	key := client.getCacheKey("apps")
+
+	diffs, unsubscribe := client.SubscribeDiffs()
+	defer unsubscribe()
+
+	client.setCache(key, createMockApplications())
+	if _, found := client.LastDiff(); found {
+		t.Fatal("Expected no diff after the first application list")
+	}
+
+	apps := createMockApplications()
+	apps[2].Team = "#search"
+	apps = append(apps[:1], apps[2:]...)
+	client.setCache(key, apps)
+
+	diff, found := client.LastDiff()
+	if !found {
+		t.Fatal("Expected a diff after the application list changed")
+	}
+	if len(diff.Added) != 0 || len(diff.Removed) != 1 || diff.Removed[0].AppName != "Content Store" {
+		t.Errorf("Expected Content Store to be removed, got added %v removed %v", diff.Added, diff.Removed)
+	}
+	if len(diff.Modified) != 1 || diff.Modified[0].AppName != "Frontend" || diff.Modified[0].Changes[0].Field != FieldTeam {
+		t.Errorf("Expected Frontend team change, got %+v", diff.Modified)
+	}
+	if diff.PreviousListAt.IsZero() || diff.DiffGeneratedAt.Before(diff.PreviousListAt) {
+		t.Errorf("Unexpected diff times: previous %v generated %v", diff.PreviousListAt, diff.DiffGeneratedAt)
+	}
+
+	select {
+	case pushed := <-diffs:
+		if len(pushed.Removed) != 1 {
+			t.Errorf("Expected subscribers to receive the diff, got %+v", pushed)
+		}
+	default:
+		t.Error("Expected the diff to be sent to subscribers")
+	}
+
+	// An unchanged list keeps the last diff
+	client.setCache(key, apps)
+	if unchanged, _ := client.LastDiff(); !unchanged.DiffGeneratedAt.Equal(diff.DiffGeneratedAt) {
+		t.Error("Expected an unchanged list not to replace the last diff")
+	}
+}
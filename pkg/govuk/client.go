@@ -2,55 +2,148 @@ package govuk
 
 import (
 	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"govuk-reports-dashboard/internal/config"
 
+	"govuk-reports-dashboard/pkg/flight"
 	"govuk-reports-dashboard/pkg/logger"
+	"govuk-reports-dashboard/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
-	DefaultTimeout      = 30 * time.Second
-	DefaultCacheTTL     = 15 * time.Minute
-	DefaultRetries      = 3
-	DefaultRetryDelay   = 1 * time.Second
-	AppsJSONEndpoint    = "https://docs.publishing.service.gov.uk/apps.json"
-	UserAgent          = "govuk-reports-dashboard/1.0"
-	RateLimitSleepTime = 60 * time.Second
+	DefaultTimeout             = 30 * time.Second
+	DefaultCacheTTL            = 15 * time.Minute
+	DefaultRetries             = 3
+	DefaultRetryDelay          = 1 * time.Second
+	DefaultMaxRetryDelay       = 30 * time.Second
+	DefaultRetryJitterFraction = 0.5
+	AppsJSONEndpoint           = "https://docs.publishing.service.gov.uk/apps.json"
+	UserAgent                  = "govuk-reports-dashboard/1.0"
+	RateLimitSleepTime         = 60 * time.Second
+	StatsCacheTTL              = 5 * time.Minute
+	PingTimeout                = 5 * time.Second
+	MaxErrorBodyBytes          = 500
 )
 
 type Client struct {
-	baseURL    string
-	apiKey     string
-	httpClient *http.Client
-	logger     *logger.Logger
-	cache      map[string]*CacheEntry
-	cacheMu    sync.RWMutex
-	cacheTTL   time.Duration
-	retries    int
-	retryDelay time.Duration
+	baseURL      string
+	appsEndpoint string
+	apiKey       string
+	httpClient   *http.Client
+	logger       *logger.Logger
+	cache        map[string]*CacheEntry
+	cacheMu      sync.RWMutex
+	cacheTTL     time.Duration
+	fetchGroup   flight.Group
+	retries      int
+	retryDelay   time.Duration
+
+	maxRetryDelay       time.Duration
+	retryJitterFraction float64
+
+	conns              *connTracker
+	http2Enabled       bool
+	compressionEnabled bool
+
+	lastResponse   ResponseStats
+	lastResponseMu sync.RWMutex
+
+	stats          *HostingStats
+	statsExpiresAt time.Time
+	searchCache    map[string]searchCacheEntry
+
+	metrics clientMetrics
+	latency latencyWindow
+
+	// appsSnapshot is the last application list fetched, kept when the
+	// cache is cleared so changes are still detected. lastDiff is the
+	// most recent change to it.
+	appsSnapshot   APIResponse
+	appsSnapshotAt time.Time
+	lastDiff       *ApplicationListDiff
+
+	diffSubscribers   map[chan ApplicationListDiff]struct{}
+	diffSubscribersMu sync.Mutex
+
+	// ChangeEvents, if set, receives an ApplicationChangeEvent for each
+	// change to the application list between fetches. Events are dropped
+	// when the channel is full. Set it before the client is used.
+	ChangeEvents chan ApplicationChangeEvent
+}
+
+// ApplicationsClient is the application lookup API of Client, so packages
+// that depend on it can use MockApplicationsClient in tests
+type ApplicationsClient interface {
+	GetAllApplications(ctx context.Context) ([]Application, error)
+	GetApplicationByName(ctx context.Context, name string) (*Application, error)
+	BulkGetApplicationsByName(ctx context.Context, names []string) (map[string]*Application, []string, error)
+	GetApplicationsByTeam(ctx context.Context, team string) ([]Application, error)
+	GetApplicationsByTeams(ctx context.Context, teams []string) (map[string][]Application, error)
+	GetApplicationsByHosting(ctx context.Context, hosting string) ([]Application, error)
+	SearchApplicationsRegex(ctx context.Context, pattern string) ([]Application, error)
+	GetHostingPlatformStats(ctx context.Context) (*HostingStats, error)
+	GetAllTeams(ctx context.Context) ([]string, error)
+	ClearCache()
 }
 
+var _ ApplicationsClient = (*Client)(nil)
+
 type ClientOptions struct {
-	Timeout    time.Duration
-	CacheTTL   time.Duration
-	Retries    int
-	RetryDelay time.Duration
+	Timeout            time.Duration
+	CacheTTL           time.Duration
+	Retries            int
+	RetryDelay         time.Duration
+	EnableHTTP2        bool
+	CompressionEnabled bool
+	AppsEndpoint       string
+
+	// MaxRetryDelay caps the exponential backoff between retries, and
+	// RetryJitterFraction randomises each delay by up to that fraction in
+	// either direction so restarted instances don't retry in step. A
+	// negative RetryJitterFraction disables jitter.
+	MaxRetryDelay       time.Duration
+	RetryJitterFraction float64
 }
 
+// NewClient creates a client from configuration. Outside development the
+// API base URL is checked once so a misconfigured GOVUK_API_BASE_URL shows
+// up at startup; the client is returned even if the check fails.
 func NewClient(cfg *config.Config, log *logger.Logger) *Client {
-	return NewClientWithOptions(cfg, log, ClientOptions{
-		Timeout:    cfg.GOVUK.AppsAPITimeout,
-		CacheTTL:   cfg.GOVUK.AppsAPICacheTTL,
-		Retries:    cfg.GOVUK.AppsAPIRetries,
-		RetryDelay: DefaultRetryDelay,
+	client := NewClientWithOptions(cfg, log, ClientOptions{
+		Timeout:            cfg.GOVUK.AppsAPITimeout,
+		CacheTTL:           cfg.GOVUK.AppsAPICacheTTL,
+		Retries:            cfg.GOVUK.AppsAPIRetries,
+		RetryDelay:         DefaultRetryDelay,
+		EnableHTTP2:        cfg.GOVUK.EnableHTTP2,
+		CompressionEnabled: cfg.GOVUK.EnableCompression,
 	})
+
+	if !cfg.IsDevelopment() {
+		if err := client.Ping(context.Background()); err != nil {
+			log.WithError(err).WithFields(map[string]interface{}{
+				"base_url":             client.baseURL,
+				"dependency_available": false,
+			}).Warn().Msg("GOV.UK API is unreachable, application data may be unavailable")
+		}
+	}
+
+	return client
 }
 
 func NewClientWithOptions(cfg *config.Config, log *logger.Logger, opts ClientOptions) *Client {
@@ -66,22 +159,110 @@ func NewClientWithOptions(cfg *config.Config, log *logger.Logger, opts ClientOpt
 	if opts.RetryDelay == 0 {
 		opts.RetryDelay = DefaultRetryDelay
 	}
+	if opts.AppsEndpoint == "" {
+		opts.AppsEndpoint = AppsJSONEndpoint
+	}
+	if opts.MaxRetryDelay == 0 {
+		opts.MaxRetryDelay = DefaultMaxRetryDelay
+	}
+	if opts.RetryJitterFraction == 0 {
+		opts.RetryJitterFraction = DefaultRetryJitterFraction
+	} else if opts.RetryJitterFraction < 0 {
+		opts.RetryJitterFraction = 0
+	}
+
+	conns := &connTracker{}
+	transport, err := newTransport(conns, opts.EnableHTTP2)
+	if err != nil {
+		log.WithError(err).Warn().Msg("Failed to configure HTTP/2 transport, using HTTP/1.1")
+		transport, _ = newTransport(conns, false)
+		opts.EnableHTTP2 = false
+	}
 
 	return &Client{
-		baseURL: cfg.GOVUK.APIBaseURL,
-		apiKey:  cfg.GOVUK.APIKey,
+		baseURL:      cfg.GOVUK.APIBaseURL,
+		appsEndpoint: opts.AppsEndpoint,
+		apiKey:       cfg.GOVUK.APIKey,
 		httpClient: &http.Client{
-			Timeout: opts.Timeout,
+			Timeout:   opts.Timeout,
+			Transport: transport,
 		},
-		logger:     log,
-		cache:      make(map[string]*CacheEntry),
-		cacheTTL:   opts.CacheTTL,
-		retries:    opts.Retries,
-		retryDelay: opts.RetryDelay,
+		logger:              log,
+		cache:               make(map[string]*CacheEntry),
+		cacheTTL:            opts.CacheTTL,
+		retries:             opts.Retries,
+		retryDelay:          opts.RetryDelay,
+		maxRetryDelay:       opts.MaxRetryDelay,
+		retryJitterFraction: opts.RetryJitterFraction,
+		conns:               conns,
+		http2Enabled:        opts.EnableHTTP2,
+		compressionEnabled:  opts.CompressionEnabled,
+	}
+}
+
+// Ping checks that the API base URL is reachable with a single HEAD request
+// to apps.json
+func (c *Client) Ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, PingTimeout)
+	defer cancel()
+
+	url := strings.TrimSuffix(c.baseURL, "/") + "/apps.json"
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", UserAgent)
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.logger.LogAPICall("govuk", url, time.Since(start), false)
+		return fmt.Errorf("request failed: %w", err)
 	}
+	defer resp.Body.Close()
+	c.logger.LogAPICall("govuk", url, time.Since(start), resp.StatusCode < 400)
+
+	if resp.StatusCode >= 400 {
+		return newAPIError(resp, url, "ping failed")
+	}
+
+	return nil
+}
+
+// retryBackoff returns the delay before a retry: retryDelay doubled for each
+// attempt, randomised by retryJitterFraction and capped at maxRetryDelay.
+// The jitter comes from crypto/rand so instances started together don't
+// share a seed and retry in step.
+func (c *Client) retryBackoff(attempt int) time.Duration {
+	delay := c.retryDelay << uint(attempt)
+	if delay <= 0 || delay > c.maxRetryDelay {
+		delay = c.maxRetryDelay
+	}
+
+	if spread := int64(float64(delay) * c.retryJitterFraction); spread > 0 {
+		if n, err := rand.Int(rand.Reader, big.NewInt(2*spread+1)); err == nil {
+			delay += time.Duration(n.Int64() - spread)
+		}
+	}
+
+	if delay > c.maxRetryDelay {
+		delay = c.maxRetryDelay
+	}
+	return delay
 }
 
-func (c *Client) doRequest(ctx context.Context, url string) (*http.Response, error) {
+// doRequest makes a GET request with retries, adding any extra headers. A
+// 304 Not Modified response is returned as-is for the caller to handle.
+func (c *Client) doRequest(ctx context.Context, url string, headers map[string]string) (*http.Response, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "govuk.request",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("url.full", url)),
+	)
+	defer span.End()
+
 	var lastErr error
 	
 	for attempt := 0; attempt <= c.retries; attempt++ {
@@ -94,7 +275,7 @@ func (c *Client) doRequest(ctx context.Context, url string) (*http.Response, err
 			select {
 			case <-ctx.Done():
 				return nil, ctx.Err()
-			case <-time.After(c.retryDelay * time.Duration(attempt)):
+			case <-time.After(c.retryBackoff(attempt)):
 			}
 		}
 
@@ -106,10 +287,19 @@ func (c *Client) doRequest(ctx context.Context, url string) (*http.Response, err
 
 		req.Header.Set("User-Agent", UserAgent)
 		req.Header.Set("Accept", "application/json")
+		if c.compressionEnabled {
+			// Setting this disables the transport's transparent gzip
+			// handling, so responses are decoded in newResponseBody
+			req.Header.Set("Accept-Encoding", "gzip, deflate")
+		}
 		
 		if c.apiKey != "" {
 			req.Header.Set("Authorization", "Bearer "+c.apiKey)
 		}
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		tracing.InjectHTTPHeaders(ctx, req.Header)
 
 		c.logger.WithFields(map[string]interface{}{
 			"method":  req.Method,
@@ -117,13 +307,25 @@ func (c *Client) doRequest(ctx context.Context, url string) (*http.Response, err
 			"attempt": attempt + 1,
 		}).Debug().Msg("Making HTTP request")
 
+		start := time.Now()
+		atomic.AddInt64(&c.conns.active, 1)
 		resp, err := c.httpClient.Do(req)
+		atomic.AddInt64(&c.conns.active, -1)
 		if err != nil {
+			duration := time.Since(start)
+			c.logger.LogAPICall("govuk", url, duration, c.recordAPICall(url, duration, false))
 			lastErr = fmt.Errorf("request failed: %w", err)
 			continue
 		}
 
+		c.conns.protocol.Store(resp.Proto)
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			duration := time.Since(start)
+			c.logger.WithField("protocol", resp.Proto).LogAPICall("govuk", url, duration, c.recordAPICall(url, duration, resp.StatusCode < 400))
+		}
+
 		if resp.StatusCode == http.StatusTooManyRequests {
+			atomic.AddInt64(&c.metrics.rateLimitHits, 1)
 			resp.Body.Close()
 			c.logger.WithField("url", url).Warn().Msg("Rate limited, sleeping before retry")
 			
@@ -137,18 +339,41 @@ func (c *Client) doRequest(ctx context.Context, url string) (*http.Response, err
 			continue
 		}
 
+		if resp.StatusCode == http.StatusNotModified {
+			return resp, nil
+		}
+
 		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			// Don't hand back a response the caller is no longer waiting for
+			if err := ctx.Err(); err != nil {
+				resp.Body.Close()
+				return nil, err
+			}
+
+			// Successful calls are logged once the body has been read, so
+			// the compression ratio is known
+			protocol := resp.Proto
+			body, err := newResponseBody(resp, func(compressed, decompressed int64) {
+				stats := c.recordResponseStats(compressed, decompressed, protocol)
+				duration := time.Since(start)
+				c.logger.WithFields(map[string]interface{}{
+					"protocol":          protocol,
+					"compression_ratio": stats.CompressionRatio,
+				}).LogAPICall("govuk", url, duration, c.recordAPICall(url, duration, true))
+			})
+			if err != nil {
+				resp.Body.Close()
+				duration := time.Since(start)
+				c.logger.WithField("protocol", protocol).LogAPICall("govuk", url, duration, c.recordAPICall(url, duration, false))
+				lastErr = fmt.Errorf("failed to decompress response: %w", err)
+				break
+			}
+			resp.Body = body
 			return resp, nil
 		}
 
-		body, _ := io.ReadAll(resp.Body)
+		lastErr = newAPIError(resp, url, "API request failed")
 		resp.Body.Close()
-		
-		lastErr = &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    fmt.Sprintf("API request failed with status %d: %s", resp.StatusCode, string(body)),
-			Endpoint:   url,
-		}
 
 		if resp.StatusCode >= 500 {
 			continue
@@ -157,9 +382,44 @@ func (c *Client) doRequest(ctx context.Context, url string) (*http.Response, err
 		break
 	}
 
+	tracing.RecordError(span, lastErr)
 	return nil, lastErr
 }
 
+// newAPIError builds an APIError from an unsuccessful response, keeping the
+// start of the body and the response headers other than cookies and auth
+// challenges. The body is read but not closed.
+func newAPIError(resp *http.Response, endpoint, message string) *APIError {
+	body, _ := io.ReadAll(resp.Body)
+	if len(body) > MaxErrorBodyBytes {
+		body = body[:MaxErrorBodyBytes]
+	}
+
+	headers := make(map[string]string, len(resp.Header))
+	for name, values := range resp.Header {
+		switch http.CanonicalHeaderKey(name) {
+		case "Set-Cookie", "Cookie", "Authorization", "Www-Authenticate", "Proxy-Authenticate":
+			continue
+		}
+		headers[name] = strings.Join(values, ", ")
+	}
+
+	apiErr := &APIError{
+		StatusCode:      resp.StatusCode,
+		Message:         fmt.Sprintf("%s with status %d", message, resp.StatusCode),
+		Endpoint:        endpoint,
+		ResponseHeaders: headers,
+		ResponseBody:    string(body),
+		RequestID:       resp.Header.Get("X-Request-Id"),
+		TraceID:         resp.Header.Get("X-Trace-Id"),
+	}
+	if len(body) > 0 {
+		apiErr.Message += ": " + string(body)
+	}
+
+	return apiErr
+}
+
 func (c *Client) getCacheKey(endpoint string) string {
 	return fmt.Sprintf("govuk_api_%s", endpoint)
 }
@@ -180,13 +440,48 @@ func (c *Client) getFromCache(key string) (*CacheEntry, bool) {
 	return entry, true
 }
 
+// getFromCacheWithETag returns the cached entry for key and its ETag, even if
+// the entry has expired, so it can be revalidated with a conditional request
+func (c *Client) getFromCacheWithETag(key string) (*CacheEntry, string) {
+	c.cacheMu.RLock()
+	defer c.cacheMu.RUnlock()
+
+	entry, exists := c.cache[key]
+	if !exists {
+		return nil, ""
+	}
+
+	return entry, entry.ETag
+}
+
 func (c *Client) setCache(key string, data APIResponse) {
+	c.setCacheEntry(key, &CacheEntry{Data: data})
+}
+
+// setCacheEntry stores entry under key, expiring after the cache TTL. New
+// application lists are compared to the previous one to send ChangeEvents.
+func (c *Client) setCacheEntry(key string, entry *CacheEntry) {
+	c.cacheMu.Lock()
+	entry.ExpiresAt = time.Now().Add(c.cacheTTL)
+	c.cache[key] = entry
+	c.cacheMu.Unlock()
+
+	if key == c.getCacheKey("apps") {
+		c.recordApplicationChanges(entry.Data)
+	}
+}
+
+// refreshCache extends the expiry of the entry under key after the server
+// reported it unchanged
+func (c *Client) refreshCache(key string) {
 	c.cacheMu.Lock()
 	defer c.cacheMu.Unlock()
-	
-	c.cache[key] = &CacheEntry{
-		Data:      data,
-		ExpiresAt: time.Now().Add(c.cacheTTL),
+
+	if entry, exists := c.cache[key]; exists {
+		refreshed := *entry
+		refreshed.ExpiresAt = time.Now().Add(c.cacheTTL)
+		refreshed.ChangedSinceLastPoll = false
+		c.cache[key] = &refreshed
 	}
 }
 
@@ -196,78 +491,258 @@ func (c *Client) clearExpiredCache() {
 	
 	now := time.Now()
 	for key, entry := range c.cache {
-		if now.After(entry.ExpiresAt) {
+		// Entries with an ETag are kept for conditional requests
+		if now.After(entry.ExpiresAt) && entry.ETag == "" {
 			delete(c.cache, key)
 		}
 	}
 }
 
-// GetAllApplications fetches all applications from the GOV.UK apps.json API
-func (c *Client) GetAllApplications(ctx context.Context) ([]Application, error) {
-	c.logger.Info().Msg("Fetching all GOV.UK applications")
-	
-	cacheKey := c.getCacheKey("apps")
-	
-	// Check cache first
-	if entry, found := c.getFromCache(cacheKey); found {
-		c.logger.Debug().Msg("Returning applications from cache")
+// getOrFetch returns the cached response for key, or fetches it from url.
+// Concurrent calls for the same key share a single request, which is
+// cancelled once every caller has given up. The cache is only populated if
+// the request completes before then.
+func (c *Client) getOrFetch(ctx context.Context, key, url string) (APIResponse, error) {
+	if entry, found := c.getFromCache(key); found {
+		atomic.AddInt64(&c.metrics.cacheHits, 1)
+		c.logger.WithField("cache_key", key).Debug().Msg("Returning response from cache")
 		return entry.Data, nil
 	}
-	
+	atomic.AddInt64(&c.metrics.cacheMisses, 1)
+
+	result, err := c.fetchGroup.Do(ctx, key, func(ctx context.Context) (interface{}, error) {
+		return c.fetch(ctx, key, url)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(APIResponse), nil
+}
+
+// fetch requests url, decodes the response and stores it in the cache
+func (c *Client) fetch(ctx context.Context, key, url string) (APIResponse, error) {
 	// Clear expired cache entries periodically
 	c.clearExpiredCache()
-	
-	resp, err := c.doRequest(ctx, AppsJSONEndpoint)
+
+	var headers map[string]string
+	cached, etag := c.getFromCacheWithETag(key)
+	if etag != "" {
+		headers = map[string]string{"If-None-Match": etag}
+	}
+
+	resp, err := c.doRequest(ctx, url, headers)
 	if err != nil {
-		c.logger.WithError(err).Error().Msg("Failed to fetch applications")
 		return nil, err
 	}
 	defer resp.Body.Close()
-	
+
+	if resp.StatusCode == http.StatusNotModified {
+		if cached == nil {
+			return nil, newAPIError(resp, url, "not modified response without a cached entry")
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		c.logger.WithField("cache_key", key).Debug().Msg("Response not modified, refreshing cache entry")
+		c.refreshCache(key)
+		return cached.Data, nil
+	}
+
+	data, _, err := c.cacheResponse(ctx, key, resp)
+	return data, err
+}
+
+// cacheResponse reads and decodes a successful response and stores it under
+// key. If the body is identical to the cached one it is not decoded again;
+// the cached entry is refreshed and returned with changed set to false.
+func (c *Client) cacheResponse(ctx context.Context, key string, resp *http.Response) (APIResponse, bool, error) {
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, false, fmt.Errorf("failed to read response body: %w", err)
 	}
-	
+
 	c.logger.WithFields(map[string]interface{}{
-		"status_code":   resp.StatusCode,
+		"status_code":    resp.StatusCode,
 		"content_length": len(body),
 	}).Debug().Msg("Received API response")
-	
-	var applications APIResponse
-	if err := json.Unmarshal(body, &applications); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+
+	hash := md5.Sum(body)
+	bodyHash := hex.EncodeToString(hash[:])
+	cached, _ := c.getFromCacheWithETag(key)
+	if cached != nil && cached.BodyHash == bodyHash {
+		c.refreshCache(key)
+		return cached.Data, false, nil
 	}
-	
+
+	var data APIResponse
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
+
+	entry := &CacheEntry{
+		Data:                 data,
+		ETag:                 resp.Header.Get("ETag"),
+		BodyHash:             bodyHash,
+		ChangedSinceLastPoll: true,
+	}
+	if lastModified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		entry.LastModified = &lastModified
+	}
+	c.setCacheEntry(key, entry)
+
+	// Stats and search results are derived from the old data
+	if cached != nil {
+		c.cacheMu.Lock()
+		c.stats = nil
+		c.searchCache = nil
+		c.cacheMu.Unlock()
+	}
+
+	return data, true, nil
+}
+
+// GetAllApplications fetches all applications from the GOV.UK apps.json API
+func (c *Client) GetAllApplications(ctx context.Context) ([]Application, error) {
+	c.logger.Info().Msg("Fetching all GOV.UK applications")
+
+	applications, err := c.getOrFetch(ctx, c.getCacheKey("apps"), c.appsEndpoint)
+	if err != nil {
+		c.logger.WithError(err).Error().Msg("Failed to fetch applications")
+		return nil, err
+	}
+
 	c.logger.WithField("app_count", len(applications)).Info().Msg("Successfully fetched applications")
-	
-	// Cache the response
-	c.setCache(cacheKey, applications)
-	
+
 	return applications, nil
 }
 
+// GetApplicationsModifiedSince fetches the application list with an
+// If-Modified-Since header, unless since is zero, and updates the cache. It
+// returns (nil, false, nil) if the API responds 304 Not Modified or, for APIs
+// that ignore the header, returns a body identical to the cached one.
+func (c *Client) GetApplicationsModifiedSince(ctx context.Context, since time.Time) ([]Application, bool, error) {
+	key := c.getCacheKey("apps")
+
+	var headers map[string]string
+	if !since.IsZero() {
+		headers = map[string]string{"If-Modified-Since": since.UTC().Format(http.TimeFormat)}
+	}
+
+	resp, err := c.doRequest(ctx, c.appsEndpoint, headers)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		c.refreshCache(key)
+		return nil, false, nil
+	}
+
+	data, changed, err := c.cacheResponse(ctx, key, resp)
+	if err != nil || !changed {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+// StartBackgroundRefresh polls the application list every half cache TTL
+// until ctx is cancelled, so the cache is kept fresh and requests don't wait
+// for a fetch. Unchanged lists are not decoded again.
+func (c *Client) StartBackgroundRefresh(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(c.cacheTTL / 2)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			var since time.Time
+			if entry, _ := c.getFromCacheWithETag(c.getCacheKey("apps")); entry != nil && entry.LastModified != nil {
+				since = *entry.LastModified
+			}
+
+			apps, changed, err := c.GetApplicationsModifiedSince(ctx, since)
+			if err != nil {
+				c.logger.WithError(err).Warn().Msg("Background refresh of GOV.UK applications failed")
+				continue
+			}
+			c.logger.WithFields(map[string]interface{}{
+				"changed":   changed,
+				"app_count": len(apps),
+			}).Debug().Msg("Refreshed GOV.UK applications in the background")
+		}
+	}()
+}
+
 // GetApplicationByName fetches a specific application by name
 func (c *Client) GetApplicationByName(ctx context.Context, name string) (*Application, error) {
 	c.logger.WithField("app_name", name).Info().Msg("Fetching application by name")
-	
-	applications, err := c.GetAllApplications(ctx)
+
+	found, _, err := c.BulkGetApplicationsByName(ctx, []string{name})
 	if err != nil {
 		return nil, err
 	}
-	
-	// Search for the application by name (case-insensitive)
-	normalizedName := strings.ToLower(strings.TrimSpace(name))
-	
-	for _, app := range applications {
-		if strings.ToLower(app.AppName) == normalizedName || 
-		   strings.ToLower(app.Shortname) == normalizedName {
-			c.logger.WithField("app_name", app.AppName).Debug().Msg("Found application")
-			return &app, nil
+
+	if app, ok := found[name]; ok {
+		c.logger.WithField("app_name", app.AppName).Debug().Msg("Found application")
+		return app, nil
+	}
+
+	return nil, &APIError{
+		StatusCode: http.StatusNotFound,
+		Message:    fmt.Sprintf("application not found: %s", name),
+		Endpoint:   c.appsEndpoint,
+	}
+}
+
+// BulkGetApplicationsByName looks up several applications by name or
+// shortname (case-insensitive) from a single fetch of the application list.
+// Found applications are keyed by the name as requested, and names with no
+// matching application are returned in request order. An error is only
+// returned if the application list cannot be fetched.
+func (c *Client) BulkGetApplicationsByName(ctx context.Context, names []string) (map[string]*Application, []string, error) {
+	applications, err := c.GetAllApplications(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requested := make(map[string][]string, len(names))
+	for _, name := range names {
+		normalizedName := strings.ToLower(strings.TrimSpace(name))
+		requested[normalizedName] = append(requested[normalizedName], name)
+	}
+
+	found := make(map[string]*Application, len(names))
+	for i := range applications {
+		// A copy, as applications may be the cached list
+		app := applications[i]
+		for _, key := range []string{strings.ToLower(app.AppName), strings.ToLower(app.Shortname)} {
+			for _, name := range requested[key] {
+				if _, exists := found[name]; !exists {
+					found[name] = &app
+				}
+			}
 		}
 	}
-	
-	return nil, fmt.Errorf("application not found: %s", name)
+
+	var missing []string
+	for _, name := range names {
+		if _, exists := found[name]; !exists {
+			missing = append(missing, name)
+		}
+	}
+
+	return found, missing, nil
 }
 
 // GetApplicationsByTeam fetches all applications for a specific team
@@ -296,6 +771,52 @@ func (c *Client) GetApplicationsByTeam(ctx context.Context, team string) ([]Appl
 	return teamApps, nil
 }
 
+// GetApplicationsByTeams fetches the application list once and groups it by
+// the requested teams, matching case-insensitively. Every requested team is
+// present in the result, with an empty slice if it owns no applications.
+func (c *Client) GetApplicationsByTeams(ctx context.Context, teams []string) (map[string][]Application, error) {
+	c.logger.WithField("team_count", len(teams)).Info().Msg("Fetching applications by teams")
+
+	applications, err := c.GetAllApplications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	teamApps := make(map[string][]Application, len(teams))
+	requested := make(map[string][]string, len(teams))
+	for _, team := range teams {
+		teamApps[team] = []Application{}
+		normalizedTeam := strings.ToLower(strings.TrimSpace(team))
+		requested[normalizedTeam] = append(requested[normalizedTeam], team)
+	}
+
+	for _, app := range applications {
+		for _, team := range requested[strings.ToLower(strings.TrimSpace(app.Team))] {
+			teamApps[team] = append(teamApps[team], app)
+		}
+	}
+
+	return teamApps, nil
+}
+
+// GetAllTeams returns the sorted, de-duplicated names of all teams that own
+// at least one application
+func (c *Client) GetAllTeams(ctx context.Context) ([]string, error) {
+	applications, err := c.GetAllApplications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	teams := make(map[string]bool)
+	for _, app := range applications {
+		if app.Team != "" {
+			teams[app.Team] = true
+		}
+	}
+
+	return sortedKeys(teams), nil
+}
+
 // GetApplicationsByHosting fetches all applications hosted on a specific platform
 func (c *Client) GetApplicationsByHosting(ctx context.Context, hosting string) ([]Application, error) {
 	c.logger.WithField("hosting", hosting).Info().Msg("Fetching applications by hosting platform")
@@ -322,12 +843,113 @@ func (c *Client) GetApplicationsByHosting(ctx context.Context, hosting string) (
 	return hostingApps, nil
 }
 
+// GetHostingPlatformStats returns application counts grouped by hosting platform and team.
+// Stats are cached separately from the application list with a shorter TTL.
+func (c *Client) GetHostingPlatformStats(ctx context.Context) (*HostingStats, error) {
+	c.cacheMu.RLock()
+	if c.stats != nil && time.Now().Before(c.statsExpiresAt) {
+		stats := c.stats
+		c.cacheMu.RUnlock()
+		c.logger.Debug().Msg("Returning hosting stats from cache")
+		return stats, nil
+	}
+	c.cacheMu.RUnlock()
+
+	applications, err := c.GetAllApplications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := buildHostingStats(applications)
+
+	c.cacheMu.Lock()
+	c.stats = stats
+	c.statsExpiresAt = time.Now().Add(StatsCacheTTL)
+	c.cacheMu.Unlock()
+
+	c.logger.WithFields(map[string]interface{}{
+		"platform_count": len(stats.ByPlatform),
+		"team_count":     len(stats.ByTeam),
+	}).Debug().Msg("Calculated hosting platform stats")
+
+	return stats, nil
+}
+
+func buildHostingStats(applications []Application) *HostingStats {
+	stats := &HostingStats{
+		TotalApplications: len(applications),
+		ByPlatform:        make(map[string]PlatformStat),
+		ByTeam:            make(map[string]TeamStat),
+		GeneratedAt:       time.Now(),
+	}
+
+	platformTeams := make(map[string]map[string]bool)
+	teamPlatforms := make(map[string]map[string]bool)
+
+	for _, app := range applications {
+		platform := app.ProductionHostedOn
+		if platform == "" {
+			platform = "unknown"
+		}
+		team := app.Team
+		if team == "" {
+			team = "unknown"
+		}
+
+		platformStat := stats.ByPlatform[platform]
+		platformStat.Count++
+		platformStat.Applications = append(platformStat.Applications, app.AppName)
+		stats.ByPlatform[platform] = platformStat
+
+		teamStat := stats.ByTeam[team]
+		teamStat.Count++
+		teamStat.Applications = append(teamStat.Applications, app.AppName)
+		stats.ByTeam[team] = teamStat
+
+		if platformTeams[platform] == nil {
+			platformTeams[platform] = make(map[string]bool)
+		}
+		platformTeams[platform][team] = true
+
+		if teamPlatforms[team] == nil {
+			teamPlatforms[team] = make(map[string]bool)
+		}
+		teamPlatforms[team][platform] = true
+	}
+
+	for platform, platformStat := range stats.ByPlatform {
+		platformStat.Teams = sortedKeys(platformTeams[platform])
+		if stats.TotalApplications > 0 {
+			platformStat.EstimatedPercentage = float64(platformStat.Count) / float64(stats.TotalApplications) * 100
+		}
+		stats.ByPlatform[platform] = platformStat
+	}
+
+	for team, teamStat := range stats.ByTeam {
+		teamStat.HostingPlatforms = sortedKeys(teamPlatforms[team])
+		stats.ByTeam[team] = teamStat
+	}
+
+	return stats
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // ClearCache clears all cached data
 func (c *Client) ClearCache() {
 	c.cacheMu.Lock()
 	defer c.cacheMu.Unlock()
 	
 	c.cache = make(map[string]*CacheEntry)
+	c.stats = nil
+	c.searchCache = nil
 	c.logger.Info().Msg("Cache cleared")
 }
 
@@ -1,12 +1,15 @@
 package govuk
 
 import (
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -176,7 +179,7 @@ func TestGetAllApplications_Success(t *testing.T) {
 	ctx := context.Background()
 	
 	// Test doRequest directly first
-	resp, err := client.doRequest(ctx, server.URL+"/apps.json")
+	resp, err := client.doRequest(ctx, server.URL+"/apps.json", nil)
 	if err != nil {
 		t.Fatalf("doRequest failed: %v", err)
 	}
@@ -331,7 +334,7 @@ func TestDoRequest_RetryLogic(t *testing.T) {
 	client := setupTestClient(t, server.URL)
 	ctx := context.Background()
 	
-	resp, err := client.doRequest(ctx, server.URL)
+	resp, err := client.doRequest(ctx, server.URL, nil)
 	if err != nil {
 		t.Fatalf("doRequest failed after retry: %v", err)
 	}
@@ -344,6 +347,24 @@ func TestDoRequest_RetryLogic(t *testing.T) {
 	if resp.StatusCode != http.StatusOK {
 		t.Errorf("Expected status 200, got %d", resp.StatusCode)
 	}
+
+	// Retry delays double each attempt and are jittered by up to 50% either
+	// way, so repeated retries shouldn't all wait the same time
+	delays := make(map[time.Duration]bool)
+	for i := 0; i < 20; i++ {
+		delay := client.retryBackoff(1)
+		if delay < 100*time.Millisecond || delay > 300*time.Millisecond {
+			t.Errorf("Expected first retry delay within 100ms-300ms, got %v", delay)
+		}
+		delays[delay] = true
+	}
+	if len(delays) < 2 {
+		t.Error("Expected jitter to produce different retry delays")
+	}
+
+	if delay := client.retryBackoff(20); delay > DefaultMaxRetryDelay {
+		t.Errorf("Expected retry delay capped at %v, got %v", DefaultMaxRetryDelay, delay)
+	}
 }
 
 func TestDoRequest_RateLimit(t *testing.T) {
@@ -365,7 +386,7 @@ func TestDoRequest_RateLimit(t *testing.T) {
 	defer cancel()
 	
 	start := time.Now()
-	_, err := client.doRequest(ctx, server.URL)
+	_, err := client.doRequest(ctx, server.URL, nil)
 	duration := time.Since(start)
 	
 	// The request should timeout due to rate limiting sleep (60s is longer than our 2s timeout)
@@ -379,6 +400,193 @@ func TestDoRequest_RateLimit(t *testing.T) {
 	}
 }
 
+func TestGetOrFetch_CancelledContext(t *testing.T) {
+	requestStarted := make(chan struct{})
+	requestCancelled := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(requestStarted)
+		<-r.Context().Done()
+		close(requestCancelled)
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	client.retries = 0
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		<-requestStarted
+		cancel()
+	}()
+
+	_, err := client.getOrFetch(ctx, "apps", server.URL)
+	if err != context.Canceled {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+
+	// The only caller gave up, so the request is aborted
+	select {
+	case <-requestCancelled:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the pending request to be cancelled")
+	}
+
+	// Give the abandoned fetch a chance to finish before checking the cache
+	time.Sleep(50 * time.Millisecond)
+
+	if _, found := client.getFromCache("apps"); found {
+		t.Error("Expected cancelled fetch not to populate the cache")
+	}
+}
+
+func TestGetOrFetch_ConditionalRequest(t *testing.T) {
+	var ifNoneMatch []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifNoneMatch = append(ifNoneMatch, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Wed, 14 Oct 2026 09:00:00 GMT")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(createMockApplications())
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	ctx := context.Background()
+
+	if _, err := client.getOrFetch(ctx, "apps", server.URL); err != nil {
+		t.Fatalf("First fetch failed: %v", err)
+	}
+
+	entry, etag := client.getFromCacheWithETag("apps")
+	if etag != `"v1"` {
+		t.Errorf("Expected stored ETag \"v1\", got %q", etag)
+	}
+	if entry.LastModified == nil || entry.LastModified.Day() != 14 {
+		t.Errorf("Expected Last-Modified to be stored, got %v", entry.LastModified)
+	}
+
+	// Expire the entry so the next call revalidates it
+	entry.ExpiresAt = time.Now().Add(-time.Minute)
+	client.clearExpiredCache()
+
+	apps, err := client.getOrFetch(ctx, "apps", server.URL)
+	if err != nil {
+		t.Fatalf("Conditional fetch failed: %v", err)
+	}
+	if len(apps) != len(createMockApplications()) {
+		t.Errorf("Expected cached applications after 304, got %d", len(apps))
+	}
+
+	if len(ifNoneMatch) != 2 || ifNoneMatch[0] != "" || ifNoneMatch[1] != `"v1"` {
+		t.Errorf("Expected If-None-Match only on the second request, got %q", ifNoneMatch)
+	}
+	if _, found := client.getFromCache("apps"); !found {
+		t.Error("Expected 304 response to refresh the cache entry")
+	}
+}
+
+func TestGetApplicationsModifiedSince(t *testing.T) {
+	lastModified := "Wed, 14 Oct 2026 09:00:00 GMT"
+	apps := createMockApplications()
+	var ifModifiedSince []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifModifiedSince = append(ifModifiedSince, r.Header.Get("If-Modified-Since"))
+		if r.Header.Get("If-Modified-Since") == lastModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", lastModified)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(apps)
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	client.appsEndpoint = server.URL
+	ctx := context.Background()
+	key := client.getCacheKey("apps")
+
+	got, changed, err := client.GetApplicationsModifiedSince(ctx, time.Time{})
+	if err != nil {
+		t.Fatalf("First poll failed: %v", err)
+	}
+	if !changed || len(got) != len(apps) {
+		t.Fatalf("Expected %d changed applications, got %d (changed=%v)", len(apps), len(got), changed)
+	}
+	entry, _ := client.getFromCacheWithETag(key)
+	if entry == nil || entry.BodyHash == "" || !entry.ChangedSinceLastPoll {
+		t.Fatalf("Expected cache entry with body hash marked changed, got %+v", entry)
+	}
+
+	// Server honours If-Modified-Since
+	since, _ := http.ParseTime(lastModified)
+	got, changed, err = client.GetApplicationsModifiedSince(ctx, since)
+	if err != nil || changed || got != nil {
+		t.Errorf("Expected (nil, false, nil) on 304, got (%v, %v, %v)", got, changed, err)
+	}
+	if ifModifiedSince[1] != lastModified {
+		t.Errorf("Expected If-Modified-Since %q, got %q", lastModified, ifModifiedSince[1])
+	}
+
+	// Server ignores the header but the body is unchanged
+	got, changed, err = client.GetApplicationsModifiedSince(ctx, since.Add(-time.Hour))
+	if err != nil || changed || got != nil {
+		t.Errorf("Expected (nil, false, nil) for identical body, got (%v, %v, %v)", got, changed, err)
+	}
+	if entry, _ := client.getFromCacheWithETag(key); entry.ChangedSinceLastPoll {
+		t.Error("Expected ChangedSinceLastPoll to be false after identical body")
+	}
+
+	apps = apps[:1]
+	got, changed, err = client.GetApplicationsModifiedSince(ctx, since.Add(-time.Hour))
+	if err != nil || !changed || len(got) != 1 {
+		t.Errorf("Expected one changed application, got (%d, %v, %v)", len(got), changed, err)
+	}
+	if cached, found := client.getFromCache(key); !found || len(cached.Data) != 1 {
+		t.Error("Expected changed body to replace the cache entry")
+	}
+}
+
+func TestGetOrFetch_DeduplicatesConcurrentRequests(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		time.Sleep(50 * time.Millisecond)
+		json.NewEncoder(w).Encode(createMockApplications())
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			apps, err := client.getOrFetch(context.Background(), "apps", server.URL)
+			if err != nil {
+				t.Errorf("getOrFetch failed: %v", err)
+				return
+			}
+			if len(apps) != 3 {
+				t.Errorf("Expected 3 applications, got %d", len(apps))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if requests != 1 {
+		t.Errorf("Expected 1 upstream request, got %d", requests)
+	}
+}
+
 func TestCache_Expiration(t *testing.T) {
 	client := setupTestClient(t, "")
 	client.cacheTTL = 100 * time.Millisecond
@@ -429,6 +637,51 @@ func TestClearCache(t *testing.T) {
 	}
 }
 
+func TestGetHostingPlatformStats(t *testing.T) {
+	client := setupTestClient(t, "")
+	client.setCache(client.getCacheKey("apps"), createMockApplications())
+
+	stats, err := client.GetHostingPlatformStats(context.Background())
+	if err != nil {
+		t.Fatalf("GetHostingPlatformStats failed: %v", err)
+	}
+
+	if stats.TotalApplications != 3 {
+		t.Errorf("Expected 3 applications, got %d", stats.TotalApplications)
+	}
+
+	eks := stats.ByPlatform["eks"]
+	if eks.Count != 2 {
+		t.Errorf("Expected 2 apps on eks, got %d", eks.Count)
+	}
+
+	if len(eks.Teams) != 1 || eks.Teams[0] != "#publishing-platform" {
+		t.Errorf("Expected deduplicated teams [#publishing-platform], got %v", eks.Teams)
+	}
+
+	if eks.EstimatedPercentage < 66 || eks.EstimatedPercentage > 67 {
+		t.Errorf("Expected eks percentage ~66.7, got %f", eks.EstimatedPercentage)
+	}
+
+	if stats.ByTeam["#frontend"].Count != 1 {
+		t.Errorf("Expected 1 app for #frontend team, got %d", stats.ByTeam["#frontend"].Count)
+	}
+
+	// Stats should be served from their own cache entry
+	client.cacheMu.Lock()
+	client.cache = make(map[string]*CacheEntry)
+	client.cacheMu.Unlock()
+
+	cached, err := client.GetHostingPlatformStats(context.Background())
+	if err != nil {
+		t.Fatalf("GetHostingPlatformStats from cache failed: %v", err)
+	}
+
+	if cached != stats {
+		t.Error("Expected cached stats to be returned")
+	}
+}
+
 func TestAPIError(t *testing.T) {
 	apiErr := &APIError{
 		StatusCode: 404,
@@ -439,4 +692,335 @@ func TestAPIError(t *testing.T) {
 	if apiErr.Error() != "Not Found" {
 		t.Errorf("Expected error message 'Not Found', got %s", apiErr.Error())
 	}
-}
\ No newline at end of file
+}
+func TestAPIError_FromResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.Header().Set("X-Trace-Id", "trace-456")
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(strings.Repeat("x", MaxErrorBodyBytes+100)))
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+
+	_, err := client.doRequest(context.Background(), server.URL, nil)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected errors.Is(err, ErrNotFound), got %v", err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, got %T", err)
+	}
+	if apiErr.RequestID != "req-123" || apiErr.TraceID != "trace-456" {
+		t.Errorf("Unexpected request and trace IDs: %q, %q", apiErr.RequestID, apiErr.TraceID)
+	}
+	if len(apiErr.ResponseBody) != MaxErrorBodyBytes {
+		t.Errorf("Expected body truncated to %d bytes, got %d", MaxErrorBodyBytes, len(apiErr.ResponseBody))
+	}
+	if _, ok := apiErr.ResponseHeaders["Set-Cookie"]; ok {
+		t.Error("Expected cookies to be excluded from response headers")
+	}
+	if errors.Is(err, &APIError{StatusCode: http.StatusInternalServerError}) {
+		t.Error("Expected 404 not to match a 500 APIError")
+	}
+}
+
+func TestGetClientStats_HTTP1(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+
+	resp, err := client.doRequest(context.Background(), server.URL, nil)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	stats := client.GetClientStats()
+	if stats.HTTP2Active {
+		t.Error("Expected HTTP/2 to be inactive against an HTTP/1.1 server")
+	}
+	if stats.LastProtocol != "HTTP/1.1" {
+		t.Errorf("Expected protocol HTTP/1.1, got %s", stats.LastProtocol)
+	}
+	if stats.OpenConnections != 1 {
+		t.Errorf("Expected 1 open connection, got %d", stats.OpenConnections)
+	}
+	if stats.IdleConnections != 1 {
+		t.Errorf("Expected 1 idle connection, got %d", stats.IdleConnections)
+	}
+}
+
+func TestGetClientStats_HTTP2(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	cfg := &config.Config{GOVUK: config.GOVUKConfig{APIBaseURL: server.URL}}
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	client := NewClientWithOptions(cfg, log, ClientOptions{
+		Timeout:     5 * time.Second,
+		Retries:     1,
+		EnableHTTP2: true,
+	})
+	client.httpClient.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify = true
+
+	resp, err := client.doRequest(context.Background(), server.URL, nil)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	stats := client.GetClientStats()
+	if !stats.HTTP2Enabled || !stats.HTTP2Active {
+		t.Errorf("Expected HTTP/2 to be enabled and active, got %+v", stats)
+	}
+}
+
+func newAppsServer(t *testing.T, apps []Application) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(apps)
+	}))
+}
+
+func TestGetApplicationsByTeams(t *testing.T) {
+	server := newAppsServer(t, createMockApplications())
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	client.appsEndpoint = server.URL
+
+	teamApps, err := client.GetApplicationsByTeams(context.Background(), []string{"#PUBLISHING-PLATFORM", "#no-such-team"})
+	if err != nil {
+		t.Fatalf("GetApplicationsByTeams failed: %v", err)
+	}
+
+	if len(teamApps) != 2 {
+		t.Errorf("Expected 2 teams in result, got %d", len(teamApps))
+	}
+	if len(teamApps["#PUBLISHING-PLATFORM"]) != 2 {
+		t.Errorf("Expected 2 publishing platform apps, got %d", len(teamApps["#PUBLISHING-PLATFORM"]))
+	}
+	if apps, ok := teamApps["#no-such-team"]; !ok || apps == nil || len(apps) != 0 {
+		t.Errorf("Expected empty slice for unknown team, got %v", apps)
+	}
+}
+
+func TestBulkGetApplicationsByName(t *testing.T) {
+	server := newAppsServer(t, createMockApplications())
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	client.appsEndpoint = server.URL
+
+	found, missing, err := client.BulkGetApplicationsByName(context.Background(), []string{"Publishing API", "content-store", "no-such-app"})
+	if err != nil {
+		t.Fatalf("BulkGetApplicationsByName failed: %v", err)
+	}
+
+	if len(found) != 2 || found["Publishing API"].Shortname != "publishing-api" || found["content-store"].AppName != "Content Store" {
+		t.Errorf("Unexpected found applications: %v", found)
+	}
+	if len(missing) != 1 || missing[0] != "no-such-app" {
+		t.Errorf("Expected no-such-app to be missing, got %v", missing)
+	}
+
+	// Changing a found application must not change the cached list
+	found["Publishing API"].Team = "#changed"
+	found, _, err = client.BulkGetApplicationsByName(context.Background(), []string{"Publishing API"})
+	if err != nil || found["Publishing API"].Team == "#changed" {
+		t.Errorf("Expected the cached application to be unchanged, got %+v, %v", found["Publishing API"], err)
+	}
+}
+
+func TestSearchApplicationsRegex(t *testing.T) {
+	apps := append(createMockApplications(), Application{AppName: "Search API", Shortname: "search-api", Team: "#search"})
+	server := newAppsServer(t, apps)
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	client.appsEndpoint = server.URL
+
+	matches, err := client.SearchApplicationsRegex(context.Background(), "(?i).*api.*")
+	if err != nil {
+		t.Fatalf("SearchApplicationsRegex failed: %v", err)
+	}
+
+	var names []string
+	for _, app := range matches {
+		names = append(names, app.AppName)
+	}
+	if strings.Join(names, ",") != "Publishing API,Search API" {
+		t.Errorf("Expected the applications containing api, got %v", names)
+	}
+
+	for _, pattern := range []string{"publishing(", strings.Repeat("a", MaxSearchPatternLength+1)} {
+		if _, err := client.SearchApplicationsRegex(context.Background(), pattern); !errors.Is(err, ErrInvalidSearchPattern) {
+			t.Errorf("Expected ErrInvalidSearchPattern for %q, got %v", pattern, err)
+		}
+	}
+}
+
+func TestSearchApplicationsRegex_ContextErrors(t *testing.T) {
+	server := newAppsServer(t, createMockApplications())
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	client.appsEndpoint = server.URL
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	for ctx, want := range map[context.Context]error{cancelled: context.Canceled, expired: context.DeadlineExceeded} {
+		_, err := client.SearchApplicationsRegex(ctx, "(?i).*api.*")
+		if !errors.Is(err, want) || errors.Is(err, ErrInvalidSearchPattern) {
+			t.Errorf("Expected %v, got %v", want, err)
+		}
+	}
+}
+
+func TestSearchApplicationsRegex_CacheBounded(t *testing.T) {
+	server := newAppsServer(t, createMockApplications())
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	client.appsEndpoint = server.URL
+
+	for i := 0; i < MaxSearchCacheEntries+10; i++ {
+		if _, err := client.SearchApplicationsRegex(context.Background(), fmt.Sprintf("app-%d", i)); err != nil {
+			t.Fatalf("SearchApplicationsRegex failed: %v", err)
+		}
+	}
+
+	client.cacheMu.RLock()
+	defer client.cacheMu.RUnlock()
+	if len(client.searchCache) != MaxSearchCacheEntries {
+		t.Errorf("Expected %d cached searches, got %d", MaxSearchCacheEntries, len(client.searchCache))
+	}
+	if _, cached := client.searchCache[fmt.Sprintf("app-%d", MaxSearchCacheEntries+9)]; !cached {
+		t.Error("Expected the latest search to be cached")
+	}
+}
+
+func TestGetAllTeams(t *testing.T) {
+	server := newAppsServer(t, createMockApplications())
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	client.appsEndpoint = server.URL
+
+	teams, err := client.GetAllTeams(context.Background())
+	if err != nil {
+		t.Fatalf("GetAllTeams failed: %v", err)
+	}
+
+	expected := []string{"#frontend", "#publishing-platform"}
+	if strings.Join(teams, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected teams %v, got %v", expected, teams)
+	}
+}
+
+func TestPing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("Expected HEAD request, got %s", r.Method)
+		}
+		if r.URL.Path != "/apps.json" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	if err := setupTestClient(t, server.URL).Ping(context.Background()); err != nil {
+		t.Errorf("Expected ping to succeed, got %v", err)
+	}
+
+	if err := setupTestClient(t, server.URL+"/missing").Ping(context.Background()); err == nil {
+		t.Error("Expected ping to fail for an unknown path")
+	}
+}
+
+func TestCompressedResponse(t *testing.T) {
+	apps := createMockApplications()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			t.Errorf("Expected gzip in Accept-Encoding, got %q", r.Header.Get("Accept-Encoding"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		json.NewEncoder(gz).Encode(apps)
+		gz.Close()
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{GOVUK: config.GOVUKConfig{APIBaseURL: server.URL}}
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	client := NewClientWithOptions(cfg, log, ClientOptions{
+		Timeout:            5 * time.Second,
+		Retries:            1,
+		CompressionEnabled: true,
+		AppsEndpoint:       server.URL,
+	})
+
+	result, err := client.GetAllApplications(context.Background())
+	if err != nil {
+		t.Fatalf("GetAllApplications failed: %v", err)
+	}
+	if len(result) != len(apps) {
+		t.Errorf("Expected %d applications, got %d", len(apps), len(result))
+	}
+
+	stats := client.GetLastResponseStats()
+	if stats.CompressedBytes == 0 || stats.DecompressedBytes <= stats.CompressedBytes {
+		t.Errorf("Expected decompressed size to exceed compressed size, got %+v", stats)
+	}
+	if stats.CompressionRatio <= 1 {
+		t.Errorf("Expected compression ratio above 1, got %.2f", stats.CompressionRatio)
+	}
+	if stats.Protocol != "HTTP/1.1" {
+		t.Errorf("Expected protocol HTTP/1.1, got %s", stats.Protocol)
+	}
+
+	clientStats := client.GetClientStats()
+	if clientStats.CompressedBytes != stats.CompressedBytes || clientStats.DecompressedBytes != stats.DecompressedBytes {
+		t.Errorf("Expected client totals to match the only response, got %+v", clientStats)
+	}
+}
+
+func TestMockApplicationsClient(t *testing.T) {
+	var client ApplicationsClient = &MockApplicationsClient{
+		GetAllApplicationsResult: []Application{{AppName: "whitehall"}},
+		GetApplicationByNameErr:  fmt.Errorf("application not found: missing"),
+	}
+
+	apps, err := client.GetAllApplications(context.Background())
+	if err != nil || len(apps) != 1 || apps[0].AppName != "whitehall" {
+		t.Errorf("Unexpected GetAllApplications result: %v, %v", apps, err)
+	}
+
+	if _, err := client.GetApplicationByName(context.Background(), "missing"); err == nil {
+		t.Error("Expected preset GetApplicationByName error")
+	}
+
+	client.ClearCache()
+
+	mock := client.(*MockApplicationsClient)
+	if mock.Calls("GetAllApplications") != 1 || mock.Calls("ClearCache") != 1 || mock.Calls("GetAllTeams") != 0 {
+		t.Error("Unexpected mock call counts")
+	}
+}
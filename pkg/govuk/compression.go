@@ -0,0 +1,108 @@
+package govuk
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ResponseStats describes the size and protocol of a response body
+type ResponseStats struct {
+	CompressedBytes   int64   `json:"compressed_bytes"`
+	DecompressedBytes int64   `json:"decompressed_bytes"`
+	CompressionRatio  float64 `json:"compression_ratio"` // Decompressed size / transferred size
+	Protocol          string  `json:"protocol"`
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += int64(n)
+	return n, err
+}
+
+// responseBody decodes a gzip or deflate response body and reports the
+// transferred and decoded sizes to onClose when it is closed
+type responseBody struct {
+	io.Reader
+	raw          io.ReadCloser
+	compressed   *countingReader
+	decompressed *countingReader
+	onClose      func(compressed, decompressed int64)
+	once         sync.Once
+}
+
+func (b *responseBody) Close() error {
+	b.once.Do(func() { b.onClose(b.compressed.count, b.decompressed.count) })
+	return b.raw.Close()
+}
+
+// newResponseBody wraps resp.Body according to its Content-Encoding
+func newResponseBody(resp *http.Response, onClose func(compressed, decompressed int64)) (io.ReadCloser, error) {
+	compressed := &countingReader{reader: resp.Body}
+
+	var decoded io.Reader = compressed
+	switch strings.ToLower(resp.Header.Get("Content-Encoding")) {
+	case "gzip":
+		reader, err := gzip.NewReader(compressed)
+		if err != nil {
+			return nil, err
+		}
+		decoded = reader
+	case "deflate":
+		reader, err := zlib.NewReader(compressed)
+		if err != nil {
+			return nil, err
+		}
+		decoded = reader
+	}
+
+	decompressed := &countingReader{reader: decoded}
+	return &responseBody{
+		Reader:       decompressed,
+		raw:          resp.Body,
+		compressed:   compressed,
+		decompressed: decompressed,
+		onClose:      onClose,
+	}, nil
+}
+
+// recordResponseStats stores the sizes of the latest response and adds them
+// to the client's running totals
+func (c *Client) recordResponseStats(compressed, decompressed int64, protocol string) ResponseStats {
+	stats := ResponseStats{
+		CompressedBytes:   compressed,
+		DecompressedBytes: decompressed,
+		Protocol:          protocol,
+	}
+	if compressed > 0 {
+		stats.CompressionRatio = float64(decompressed) / float64(compressed)
+	}
+
+	atomic.AddInt64(&c.conns.compressedBytes, compressed)
+	atomic.AddInt64(&c.conns.decompressedBytes, decompressed)
+	atomic.AddInt64(&c.metrics.totalBytesReceived, compressed)
+
+	c.lastResponseMu.Lock()
+	c.lastResponse = stats
+	c.lastResponseMu.Unlock()
+
+	return stats
+}
+
+// GetLastResponseStats returns the sizes and protocol of the most recently
+// read response body
+func (c *Client) GetLastResponseStats() ResponseStats {
+	c.lastResponseMu.RLock()
+	defer c.lastResponseMu.RUnlock()
+	return c.lastResponse
+}
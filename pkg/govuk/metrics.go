@@ -0,0 +1,235 @@
+package govuk
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ClientMetrics counts the client's API calls and cache lookups since it was
+// created or last reset
+type ClientMetrics struct {
+	TotalRequests         int64   `json:"total_requests"`
+	CacheHits             int64   `json:"cache_hits"`
+	CacheMisses           int64   `json:"cache_misses"`
+	TotalErrors           int64   `json:"total_errors"`
+	RateLimitHits         int64   `json:"rate_limit_hits"`
+	TotalBytesReceived    int64   `json:"total_bytes_received"`
+	AverageResponseTimeMs float64 `json:"average_response_time_ms"`
+}
+
+// clientMetrics holds the counters behind ClientMetrics. Every field is
+// updated atomically.
+type clientMetrics struct {
+	totalRequests       int64
+	cacheHits           int64
+	cacheMisses         int64
+	totalErrors         int64
+	rateLimitHits       int64
+	totalBytesReceived  int64
+	totalResponseTimeNs int64
+}
+
+const (
+	// LatencyWindowSize is how many recent requests latency percentiles are
+	// calculated from
+	LatencyWindowSize = 100
+
+	// SlowCallTimeoutFraction is the fraction of the HTTP timeout after which
+	// a request is logged as slow
+	SlowCallTimeoutFraction = 0.8
+)
+
+// LatencyStats summarises the response times of the most recent requests
+type LatencyStats struct {
+	P50Ms      float64 `json:"p50_ms"`
+	P95Ms      float64 `json:"p95_ms"`
+	P99Ms      float64 `json:"p99_ms"`
+	MaxMs      float64 `json:"max_ms"`
+	TotalCalls int64   `json:"total_calls"`
+}
+
+// latencyWindow is a circular buffer of the last LatencyWindowSize request
+// durations
+type latencyWindow struct {
+	mu      sync.Mutex
+	samples [LatencyWindowSize]time.Duration
+	next    int
+	count   int
+}
+
+func (w *latencyWindow) add(duration time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.samples[w.next] = duration
+	w.next = (w.next + 1) % LatencyWindowSize
+	if w.count < LatencyWindowSize {
+		w.count++
+	}
+}
+
+// sorted returns the recorded durations, shortest first
+func (w *latencyWindow) sorted() []time.Duration {
+	w.mu.Lock()
+	samples := slices.Clone(w.samples[:w.count])
+	w.mu.Unlock()
+
+	slices.Sort(samples)
+	return samples
+}
+
+func (w *latencyWindow) reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.next = 0
+	w.count = 0
+}
+
+// recordAPICall counts one HTTP request and returns success, so calls can be
+// recorded and logged together. Requests taking most of the HTTP timeout are
+// logged as a warning, before they start timing out.
+func (c *Client) recordAPICall(url string, duration time.Duration, success bool) bool {
+	atomic.AddInt64(&c.metrics.totalRequests, 1)
+	atomic.AddInt64(&c.metrics.totalResponseTimeNs, int64(duration))
+	if !success {
+		atomic.AddInt64(&c.metrics.totalErrors, 1)
+	}
+	c.latency.add(duration)
+
+	if timeout := c.httpClient.Timeout; timeout > 0 && duration > time.Duration(float64(timeout)*SlowCallTimeoutFraction) {
+		c.logger.WithFields(map[string]interface{}{
+			"url":         url,
+			"duration_ms": duration.Milliseconds(),
+			"timeout_ms":  timeout.Milliseconds(),
+		}).Warn().Msg("GOV.UK API call is approaching the request timeout")
+	}
+
+	return success
+}
+
+// GetLatencyStats returns response time percentiles over the last
+// LatencyWindowSize requests, and the total number of requests made
+func (c *Client) GetLatencyStats() LatencyStats {
+	stats := LatencyStats{TotalCalls: atomic.LoadInt64(&c.metrics.totalRequests)}
+
+	samples := c.latency.sorted()
+	if len(samples) == 0 {
+		return stats
+	}
+
+	stats.P50Ms = durationMs(percentile(samples, 50))
+	stats.P95Ms = durationMs(percentile(samples, 95))
+	stats.P99Ms = durationMs(percentile(samples, 99))
+	stats.MaxMs = durationMs(samples[len(samples)-1])
+	return stats
+}
+
+// percentile returns the nearest-rank percentile of sorted samples
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// GetMetrics returns the client's API call and cache metrics
+func (c *Client) GetMetrics() ClientMetrics {
+	metrics := ClientMetrics{
+		TotalRequests:      atomic.LoadInt64(&c.metrics.totalRequests),
+		CacheHits:          atomic.LoadInt64(&c.metrics.cacheHits),
+		CacheMisses:        atomic.LoadInt64(&c.metrics.cacheMisses),
+		TotalErrors:        atomic.LoadInt64(&c.metrics.totalErrors),
+		RateLimitHits:      atomic.LoadInt64(&c.metrics.rateLimitHits),
+		TotalBytesReceived: atomic.LoadInt64(&c.metrics.totalBytesReceived),
+	}
+
+	if metrics.TotalRequests > 0 {
+		totalResponseTime := time.Duration(atomic.LoadInt64(&c.metrics.totalResponseTimeNs))
+		metrics.AverageResponseTimeMs = float64(totalResponseTime) / float64(time.Millisecond) / float64(metrics.TotalRequests)
+	}
+
+	return metrics
+}
+
+// ResetMetrics sets every metric back to zero and forgets recent latencies
+func (c *Client) ResetMetrics() {
+	c.latency.reset()
+	for _, counter := range []*int64{
+		&c.metrics.totalRequests,
+		&c.metrics.cacheHits,
+		&c.metrics.cacheMisses,
+		&c.metrics.totalErrors,
+		&c.metrics.rateLimitHits,
+		&c.metrics.totalBytesReceived,
+		&c.metrics.totalResponseTimeNs,
+	} {
+		atomic.StoreInt64(counter, 0)
+	}
+}
+
+// prometheusMetric is one client metric in the Prometheus format
+type prometheusMetric struct {
+	name, help string
+	valueType  prometheus.ValueType
+	value      float64
+}
+
+// prometheusMetrics returns metrics as Prometheus counters and gauges
+func prometheusMetrics(metrics ClientMetrics) []prometheusMetric {
+	return []prometheusMetric{
+		{"govuk_client_requests_total", "GOV.UK API requests made, including retries", prometheus.CounterValue, float64(metrics.TotalRequests)},
+		{"govuk_client_cache_hits_total", "GOV.UK API lookups served from the cache", prometheus.CounterValue, float64(metrics.CacheHits)},
+		{"govuk_client_cache_misses_total", "GOV.UK API lookups not in the cache", prometheus.CounterValue, float64(metrics.CacheMisses)},
+		{"govuk_client_errors_total", "GOV.UK API requests that failed", prometheus.CounterValue, float64(metrics.TotalErrors)},
+		{"govuk_client_rate_limit_hits_total", "GOV.UK API requests that were rate limited", prometheus.CounterValue, float64(metrics.RateLimitHits)},
+		{"govuk_client_bytes_received_total", "GOV.UK API response body bytes received", prometheus.CounterValue, float64(metrics.TotalBytesReceived)},
+		{"govuk_client_average_response_time_ms", "Average GOV.UK API response time in milliseconds", prometheus.GaugeValue, metrics.AverageResponseTimeMs},
+	}
+}
+
+// WritePrometheusMetrics writes the client metrics in the Prometheus text
+// exposition format
+func (c *Client) WritePrometheusMetrics(w io.Writer) error {
+	for _, metric := range prometheusMetrics(c.GetMetrics()) {
+		metricType := "counter"
+		if metric.valueType == prometheus.GaugeValue {
+			metricType = "gauge"
+		}
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %g\n", metric.name, metric.help, metric.name, metricType, metric.name, metric.value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PrometheusCollector returns a collector exporting the client metrics, for
+// registering with a Prometheus registry
+func (c *Client) PrometheusCollector() prometheus.Collector {
+	return clientCollector{client: c}
+}
+
+// clientCollector reads the client metrics on every scrape
+type clientCollector struct {
+	client *Client
+}
+
+func (cc clientCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(cc, ch)
+}
+
+func (cc clientCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, metric := range prometheusMetrics(cc.client.GetMetrics()) {
+		desc := prometheus.NewDesc(metric.name, metric.help, nil, nil)
+		ch <- prometheus.MustNewConstMetric(desc, metric.valueType, metric.value)
+	}
+}
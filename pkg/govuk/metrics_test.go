@@ -0,0 +1,82 @@
+package govuk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClientMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(createMockApplications())
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	client.appsEndpoint = server.URL
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetAllApplications(context.Background()); err != nil {
+			t.Fatalf("GetAllApplications failed: %v", err)
+		}
+	}
+	if _, err := client.getOrFetch(context.Background(), "missing", server.URL+"/missing"); err == nil {
+		t.Fatal("Expected an error for a missing endpoint")
+	}
+
+	metrics := client.GetMetrics()
+	if metrics.TotalRequests != 2 || metrics.TotalErrors != 1 {
+		t.Errorf("Expected 2 requests and 1 error, got %+v", metrics)
+	}
+	if metrics.CacheHits != 1 || metrics.CacheMisses != 2 {
+		t.Errorf("Expected 1 cache hit and 2 misses, got %+v", metrics)
+	}
+	if metrics.TotalBytesReceived == 0 {
+		t.Error("Expected received bytes to be counted")
+	}
+
+	var prometheus strings.Builder
+	if err := client.WritePrometheusMetrics(&prometheus); err != nil {
+		t.Fatalf("WritePrometheusMetrics failed: %v", err)
+	}
+	if !strings.Contains(prometheus.String(), "govuk_client_requests_total 2\n") {
+		t.Errorf("Expected requests counter in Prometheus output, got:\n%s", prometheus.String())
+	}
+
+	client.ResetMetrics()
+	if metrics := client.GetMetrics(); metrics != (ClientMetrics{}) {
+		t.Errorf("Expected metrics to be reset, got %+v", metrics)
+	}
+}
+
+func TestClientLatencyStats(t *testing.T) {
+	client := setupTestClient(t, "http://example.com")
+	if stats := client.GetLatencyStats(); stats != (LatencyStats{}) {
+		t.Errorf("Expected empty latency stats, got %+v", stats)
+	}
+
+	// Only the last LatencyWindowSize calls count towards the percentiles
+	client.recordAPICall("http://example.com", 500*time.Millisecond, true)
+	for i := 1; i <= LatencyWindowSize; i++ {
+		client.recordAPICall("http://example.com", time.Duration(i)*time.Millisecond, true)
+	}
+
+	stats := client.GetLatencyStats()
+	expected := LatencyStats{P50Ms: 50, P95Ms: 95, P99Ms: 99, MaxMs: 100, TotalCalls: LatencyWindowSize + 1}
+	if stats != expected {
+		t.Errorf("Expected %+v, got %+v", expected, stats)
+	}
+
+	client.ResetMetrics()
+	if stats := client.GetLatencyStats(); stats != (LatencyStats{}) {
+		t.Errorf("Expected latency stats to be reset, got %+v", stats)
+	}
+}
@@ -0,0 +1,101 @@
+package govuk
+
+import (
+	"context"
+	"sync"
+)
+
+// MockApplicationsClient is an ApplicationsClient that returns preset values,
+// for testing packages that depend on the GOV.UK applications API. Each
+// method returns its Result and Err fields; calls are counted in Calls.
+type MockApplicationsClient struct {
+	GetAllApplicationsResult         []Application
+	GetAllApplicationsErr            error
+	GetApplicationByNameResult       *Application
+	GetApplicationByNameErr          error
+	BulkGetApplicationsByNameResult  map[string]*Application
+	BulkGetApplicationsByNameMissing []string
+	BulkGetApplicationsByNameErr     error
+	GetApplicationsByTeamResult      []Application
+	GetApplicationsByTeamErr         error
+	GetApplicationsByTeamsResult     map[string][]Application
+	GetApplicationsByTeamsErr        error
+	GetApplicationsByHostingResult   []Application
+	GetApplicationsByHostingErr      error
+	SearchApplicationsRegexResult    []Application
+	SearchApplicationsRegexErr       error
+	GetHostingPlatformStatsResult    *HostingStats
+	GetHostingPlatformStatsErr       error
+	GetAllTeamsResult                []string
+	GetAllTeamsErr                   error
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+var _ ApplicationsClient = (*MockApplicationsClient)(nil)
+
+func (m *MockApplicationsClient) record(method string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.calls == nil {
+		m.calls = make(map[string]int)
+	}
+	m.calls[method]++
+}
+
+// Calls returns how many times the named method has been called
+func (m *MockApplicationsClient) Calls(method string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls[method]
+}
+
+func (m *MockApplicationsClient) GetAllApplications(ctx context.Context) ([]Application, error) {
+	m.record("GetAllApplications")
+	return m.GetAllApplicationsResult, m.GetAllApplicationsErr
+}
+
+func (m *MockApplicationsClient) GetApplicationByName(ctx context.Context, name string) (*Application, error) {
+	m.record("GetApplicationByName")
+	return m.GetApplicationByNameResult, m.GetApplicationByNameErr
+}
+
+func (m *MockApplicationsClient) BulkGetApplicationsByName(ctx context.Context, names []string) (map[string]*Application, []string, error) {
+	m.record("BulkGetApplicationsByName")
+	return m.BulkGetApplicationsByNameResult, m.BulkGetApplicationsByNameMissing, m.BulkGetApplicationsByNameErr
+}
+
+func (m *MockApplicationsClient) GetApplicationsByTeam(ctx context.Context, team string) ([]Application, error) {
+	m.record("GetApplicationsByTeam")
+	return m.GetApplicationsByTeamResult, m.GetApplicationsByTeamErr
+}
+
+func (m *MockApplicationsClient) GetApplicationsByTeams(ctx context.Context, teams []string) (map[string][]Application, error) {
+	m.record("GetApplicationsByTeams")
+	return m.GetApplicationsByTeamsResult, m.GetApplicationsByTeamsErr
+}
+
+func (m *MockApplicationsClient) GetApplicationsByHosting(ctx context.Context, hosting string) ([]Application, error) {
+	m.record("GetApplicationsByHosting")
+	return m.GetApplicationsByHostingResult, m.GetApplicationsByHostingErr
+}
+
+func (m *MockApplicationsClient) SearchApplicationsRegex(ctx context.Context, pattern string) ([]Application, error) {
+	m.record("SearchApplicationsRegex")
+	return m.SearchApplicationsRegexResult, m.SearchApplicationsRegexErr
+}
+
+func (m *MockApplicationsClient) GetHostingPlatformStats(ctx context.Context) (*HostingStats, error) {
+	m.record("GetHostingPlatformStats")
+	return m.GetHostingPlatformStatsResult, m.GetHostingPlatformStatsErr
+}
+
+func (m *MockApplicationsClient) GetAllTeams(ctx context.Context) ([]string, error) {
+	m.record("GetAllTeams")
+	return m.GetAllTeamsResult, m.GetAllTeamsErr
+}
+
+func (m *MockApplicationsClient) ClearCache() {
+	m.record("ClearCache")
+}
@@ -1,6 +1,9 @@
 package govuk
 
-import "time"
+import (
+	"net/http"
+	"time"
+)
 
 // Application represents a GOV.UK application from the apps.json API
 type Application struct {
@@ -23,19 +26,75 @@ type Links struct {
 // APIResponse represents the root response from the apps.json API
 type APIResponse []Application
 
-// CacheEntry represents a cached API response with expiration
+// CacheEntry represents a cached API response with expiration. ETag and
+// LastModified come from the response headers; entries with an ETag are
+// kept after they expire so they can be revalidated with If-None-Match.
+// BodyHash is the MD5 of the raw response, for detecting changes when the
+// API does not support conditional requests, and ChangedSinceLastPoll is
+// whether the last fetch returned different data.
 type CacheEntry struct {
-	Data      APIResponse
-	ExpiresAt time.Time
+	Data                 APIResponse
+	ExpiresAt            time.Time
+	ETag                 string
+	LastModified         *time.Time
+	BodyHash             string
+	ChangedSinceLastPoll bool
+}
+
+// HostingStats aggregates applications by hosting platform and team
+type HostingStats struct {
+	TotalApplications int                     `json:"total_applications"`
+	ByPlatform        map[string]PlatformStat `json:"by_platform"`
+	ByTeam            map[string]TeamStat     `json:"by_team"`
+	GeneratedAt       time.Time               `json:"generated_at"`
+}
+
+// PlatformStat summarises the applications hosted on a single platform
+type PlatformStat struct {
+	Count               int      `json:"count"`
+	Teams               []string `json:"teams"`
+	Applications        []string `json:"applications"`
+	EstimatedPercentage float64  `json:"estimated_percentage"`
+}
+
+// TeamStat summarises the applications owned by a single team
+type TeamStat struct {
+	Count            int      `json:"count"`
+	Applications     []string `json:"applications"`
+	HostingPlatforms []string `json:"hosting_platforms"`
+}
+
+// TeamContacts describes how to reach a team, from the team roster API
+type TeamContacts struct {
+	SlackChannel        string `json:"slack_channel"`
+	PagerDutyScheduleID string `json:"pagerduty_schedule_id"`
+	EmailAlias          string `json:"email_alias"`
+	TechLead            string `json:"tech_lead"`
+	TeamSize            int    `json:"team_size"`
 }
 
 // APIError represents an error response from the GOV.UK API
 type APIError struct {
-	StatusCode int    `json:"status_code"`
-	Message    string `json:"message"`
-	Endpoint   string `json:"endpoint"`
+	StatusCode      int               `json:"status_code"`
+	Message         string            `json:"message"`
+	Endpoint        string            `json:"endpoint"`
+	ResponseHeaders map[string]string `json:"response_headers,omitempty"`
+	ResponseBody    string            `json:"response_body,omitempty"`
+	RequestID       string            `json:"request_id,omitempty"`
+	TraceID         string            `json:"trace_id,omitempty"`
 }
 
+// ErrNotFound matches any APIError with a 404 status, including applications
+// missing from the apps list, e.g. errors.Is(err, govuk.ErrNotFound)
+var ErrNotFound = &APIError{StatusCode: http.StatusNotFound, Message: "not found"}
+
 func (e *APIError) Error() string {
 	return e.Message
+}
+
+// Is reports whether target is an APIError with the same status code, so
+// errors.Is can match on status
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	return ok && t.StatusCode == e.StatusCode
 }
\ No newline at end of file
@@ -0,0 +1,130 @@
+package govuk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+const (
+	MaxSearchPatternLength = 100
+	SearchCompileTimeout   = 1 * time.Second
+	SearchCacheTTL         = 30 * time.Second
+
+	// MaxSearchCacheEntries is the most search results cached at once. The
+	// entries closest to expiry are evicted first.
+	MaxSearchCacheEntries = 256
+)
+
+// ErrInvalidSearchPattern is returned for a search pattern that is too long,
+// does not compile, or takes too long to compile. If ctx is done first, its
+// error is returned instead.
+var ErrInvalidSearchPattern = errors.New("invalid search pattern")
+
+type searchCacheEntry struct {
+	applications []Application
+	expiresAt    time.Time
+}
+
+// SearchApplicationsRegex returns the applications whose name, shortname,
+// team or repository URL match a regular expression. Results are cached for
+// SearchCacheTTL, which is shorter than the application list's TTL so
+// searches soon reflect a refreshed list.
+func (c *Client) SearchApplicationsRegex(ctx context.Context, pattern string) ([]Application, error) {
+	c.cacheMu.RLock()
+	entry, found := c.searchCache[pattern]
+	c.cacheMu.RUnlock()
+	if found && time.Now().Before(entry.expiresAt) {
+		return entry.applications, nil
+	}
+
+	re, err := compileSearchPattern(ctx, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	applications, err := c.GetAllApplications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []Application{}
+	for _, app := range applications {
+		if re.MatchString(app.AppName) || re.MatchString(app.Shortname) ||
+			re.MatchString(app.Team) || re.MatchString(app.Links.RepoURL) {
+			matches = append(matches, app)
+		}
+	}
+
+	c.cacheMu.Lock()
+	if c.searchCache == nil {
+		c.searchCache = make(map[string]searchCacheEntry)
+	}
+	if _, cached := c.searchCache[pattern]; !cached && len(c.searchCache) >= MaxSearchCacheEntries {
+		c.evictSearchCacheEntry()
+	}
+	c.searchCache[pattern] = searchCacheEntry{applications: matches, expiresAt: time.Now().Add(SearchCacheTTL)}
+	c.cacheMu.Unlock()
+
+	c.logger.WithFields(map[string]interface{}{
+		"pattern":   pattern,
+		"app_count": len(matches),
+	}).Debug().Msg("Searched applications")
+
+	return matches, nil
+}
+
+// evictSearchCacheEntry removes expired search results, or if none have
+// expired the one closest to expiry. The caller must hold cacheMu.
+func (c *Client) evictSearchCacheEntry() {
+	now := time.Now()
+	oldest, oldestExpiry := "", time.Time{}
+	for pattern, entry := range c.searchCache {
+		if !now.Before(entry.expiresAt) {
+			delete(c.searchCache, pattern)
+			continue
+		}
+		if oldestExpiry.IsZero() || entry.expiresAt.Before(oldestExpiry) {
+			oldest, oldestExpiry = pattern, entry.expiresAt
+		}
+	}
+	if len(c.searchCache) >= MaxSearchCacheEntries {
+		delete(c.searchCache, oldest)
+	}
+}
+
+// compileSearchPattern compiles a search pattern, giving up after
+// SearchCompileTimeout
+func compileSearchPattern(ctx context.Context, pattern string) (*regexp.Regexp, error) {
+	if len(pattern) > MaxSearchPatternLength {
+		return nil, fmt.Errorf("%w: longer than %d characters", ErrInvalidSearchPattern, MaxSearchPatternLength)
+	}
+
+	compileCtx, cancel := context.WithTimeout(ctx, SearchCompileTimeout)
+	defer cancel()
+
+	type compiled struct {
+		re  *regexp.Regexp
+		err error
+	}
+	result := make(chan compiled, 1)
+	go func() {
+		re, err := regexp.Compile(pattern)
+		result <- compiled{re, err}
+	}()
+
+	select {
+	case r := <-result:
+		if r.err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSearchPattern, r.err)
+		}
+		return r.re, nil
+	case <-compileCtx.Done():
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: compilation timed out", ErrInvalidSearchPattern)
+	}
+}
@@ -0,0 +1,118 @@
+package govuk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"govuk-reports-dashboard/internal/config"
+	"govuk-reports-dashboard/pkg/logger"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// TeamRosterCacheTTL is how long team contacts are cached. Rosters change
+// far less often than the apps list, so they are cached separately.
+const TeamRosterCacheTTL = 1 * time.Hour
+
+// TeamRosterClient fetches team contact details from the team roster API
+type TeamRosterClient struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+	logger     *logger.Logger
+	cache      map[string]teamContactsEntry
+	cacheMu    sync.RWMutex
+	cacheTTL   time.Duration
+	fetchGroup singleflight.Group
+}
+
+type teamContactsEntry struct {
+	contacts  *TeamContacts
+	expiresAt time.Time
+}
+
+// NewTeamRosterClient creates a client for the configured team roster API
+func NewTeamRosterClient(cfg *config.Config, log *logger.Logger) *TeamRosterClient {
+	return &TeamRosterClient{
+		baseURL: strings.TrimSuffix(cfg.GOVUK.TeamRosterURL, "/"),
+		apiKey:  cfg.GOVUK.TeamRosterAPIKey,
+		httpClient: &http.Client{
+			Timeout: cfg.GOVUK.AppsAPITimeout,
+		},
+		logger:   log,
+		cache:    make(map[string]teamContactsEntry),
+		cacheTTL: TeamRosterCacheTTL,
+	}
+}
+
+// GetTeamContacts returns contact details for a team. The slug may be given
+// as a Slack channel name, e.g. "#publishing-platform".
+func (c *TeamRosterClient) GetTeamContacts(ctx context.Context, teamSlug string) (*TeamContacts, error) {
+	slug := strings.TrimPrefix(strings.TrimSpace(teamSlug), "#")
+	if slug == "" {
+		return nil, fmt.Errorf("team slug is required")
+	}
+
+	c.cacheMu.RLock()
+	entry, found := c.cache[slug]
+	c.cacheMu.RUnlock()
+	if found && time.Now().Before(entry.expiresAt) {
+		return entry.contacts, nil
+	}
+
+	result, err, _ := c.fetchGroup.Do(slug, func() (interface{}, error) {
+		return c.fetchTeamContacts(ctx, slug)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*TeamContacts), nil
+}
+
+func (c *TeamRosterClient) fetchTeamContacts(ctx context.Context, slug string) (*TeamContacts, error) {
+	endpoint := fmt.Sprintf("%s/%s", c.baseURL, url.PathEscape(slug))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", UserAgent)
+	req.Header.Set("Accept", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.logger.LogAPICall("team-roster", endpoint, time.Since(start), false)
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	c.logger.LogAPICall("team-roster", endpoint, time.Since(start), resp.StatusCode < 400)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, endpoint, "team roster request failed")
+	}
+
+	var contacts TeamContacts
+	if err := json.NewDecoder(resp.Body).Decode(&contacts); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal team contacts: %w", err)
+	}
+
+	c.cacheMu.Lock()
+	c.cache[slug] = teamContactsEntry{
+		contacts:  &contacts,
+		expiresAt: time.Now().Add(c.cacheTTL),
+	}
+	c.cacheMu.Unlock()
+
+	return &contacts, nil
+}
@@ -0,0 +1,99 @@
+package govuk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"govuk-reports-dashboard/internal/config"
+	"govuk-reports-dashboard/pkg/logger"
+)
+
+func TestTeamRosterClient_GetTeamContacts(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path != "/teams/publishing-platform" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(TeamContacts{
+			SlackChannel: "#publishing-platform",
+			EmailAlias:   "publishing-platform@digital.cabinet-office.gov.uk",
+			TechLead:     "A Person",
+			TeamSize:     8,
+		})
+	}))
+	defer server.Close()
+
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	client := NewTeamRosterClient(&config.Config{
+		GOVUK: config.GOVUKConfig{
+			TeamRosterURL:  server.URL + "/teams/",
+			AppsAPITimeout: 5 * time.Second,
+		},
+	}, log)
+
+	for i := 0; i < 2; i++ {
+		contacts, err := client.GetTeamContacts(context.Background(), "#publishing-platform")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if contacts.EmailAlias != "publishing-platform@digital.cabinet-office.gov.uk" || contacts.TeamSize != 8 {
+			t.Errorf("Unexpected contacts: %+v", contacts)
+		}
+	}
+
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("Expected 1 request with caching, got %d", got)
+	}
+
+	if _, err := client.GetTeamContacts(context.Background(), "unknown-team"); err == nil {
+		t.Error("Expected error for unknown team")
+	}
+}
+
+func TestTeamRosterClient_Authorization(t *testing.T) {
+	authorization := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization <- r.Header.Get("Authorization")
+		json.NewEncoder(w).Encode(TeamContacts{SlackChannel: "#publishing-platform"})
+	}))
+	defer server.Close()
+
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	tests := []struct {
+		name       string
+		rosterKey  string
+		wantHeader string
+	}{
+		{"no roster key", "", ""},
+		{"roster key", "roster-secret", "Bearer roster-secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The GOV.UK API key must never be sent to the roster
+			client := NewTeamRosterClient(&config.Config{
+				GOVUK: config.GOVUKConfig{
+					APIKey:           "govuk-secret",
+					TeamRosterURL:    server.URL,
+					TeamRosterAPIKey: tt.rosterKey,
+					AppsAPITimeout:   5 * time.Second,
+				},
+			}, log)
+
+			if _, err := client.GetTeamContacts(context.Background(), "publishing-platform"); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got := <-authorization; got != tt.wantHeader {
+				t.Errorf("Expected Authorization %q, got %q", tt.wantHeader, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package govuk
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+const (
+	DefaultIdleConnTimeout     = 90 * time.Second
+	DefaultMaxIdleConnsPerHost = 10
+	HTTP2ReadIdleTimeout       = 30 * time.Second
+	HTTP2PingTimeout           = 15 * time.Second
+	HTTP2MaxHeaderListSize     = 1 << 20
+)
+
+// ClientStats describes the state of the client's HTTP connections
+type ClientStats struct {
+	HTTP2Enabled      bool   `json:"http2_enabled"`
+	HTTP2Active       bool   `json:"http2_active"`
+	LastProtocol      string `json:"last_protocol"`
+	OpenConnections   int64  `json:"open_connections"`
+	ActiveRequests    int64  `json:"active_requests"`
+	IdleConnections   int64  `json:"idle_connections"`
+	CompressedBytes   int64  `json:"compressed_bytes"`
+	DecompressedBytes int64  `json:"decompressed_bytes"`
+}
+
+// connTracker counts open connections, in-flight requests and response bytes
+// for ClientStats
+type connTracker struct {
+	open     int64
+	active   int64
+	protocol atomic.Value
+
+	compressedBytes   int64
+	decompressedBytes int64
+}
+
+// trackedConn decrements the open connection count once when closed
+type trackedConn struct {
+	net.Conn
+	tracker *connTracker
+	once    sync.Once
+}
+
+func (c *trackedConn) Close() error {
+	c.once.Do(func() { atomic.AddInt64(&c.tracker.open, -1) })
+	return c.Conn.Close()
+}
+
+func (t *connTracker) dialContext(dial func(ctx context.Context, network, addr string) (net.Conn, error)) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		atomic.AddInt64(&t.open, 1)
+		return &trackedConn{Conn: conn, tracker: t}, nil
+	}
+}
+
+// newTransport builds the client transport with keep-alive tuning and, when
+// enableHTTP2 is set, explicitly configured HTTP/2. Servers that don't
+// negotiate h2 over ALPN fall back to HTTP/1.1.
+func newTransport(tracker *connTracker, enableHTTP2 bool) (*http.Transport, error) {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = tracker.dialContext(dialer.DialContext)
+	transport.IdleConnTimeout = DefaultIdleConnTimeout
+	transport.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+
+	if !enableHTTP2 {
+		return transport, nil
+	}
+
+	h2Transport, err := http2.ConfigureTransports(transport)
+	if err != nil {
+		return nil, err
+	}
+	h2Transport.ReadIdleTimeout = HTTP2ReadIdleTimeout
+	h2Transport.PingTimeout = HTTP2PingTimeout
+	h2Transport.MaxHeaderListSize = HTTP2MaxHeaderListSize
+
+	return transport, nil
+}
+
+// GetClientStats reports the negotiated protocol and connection counts.
+// Idle connections are estimated as open connections without an in-flight
+// request, which undercounts when HTTP/2 multiplexes requests on one connection.
+func (c *Client) GetClientStats() ClientStats {
+	stats := ClientStats{
+		HTTP2Enabled:      c.http2Enabled,
+		OpenConnections:   atomic.LoadInt64(&c.conns.open),
+		ActiveRequests:    atomic.LoadInt64(&c.conns.active),
+		CompressedBytes:   atomic.LoadInt64(&c.conns.compressedBytes),
+		DecompressedBytes: atomic.LoadInt64(&c.conns.decompressedBytes),
+	}
+
+	if protocol, ok := c.conns.protocol.Load().(string); ok {
+		stats.LastProtocol = protocol
+		stats.HTTP2Active = protocol == "HTTP/2.0"
+	}
+
+	stats.IdleConnections = stats.OpenConnections - stats.ActiveRequests
+	if stats.IdleConnections < 0 {
+		stats.IdleConnections = 0
+	}
+
+	return stats
+}
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -9,38 +10,89 @@ import (
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 // Logger wraps zerolog with additional functionality
 type Logger struct {
 	zerolog.Logger
+	sync func() error
 }
 
 // Config holds logger configuration
 type Config struct {
-	Level      string // debug, info, warn, error
-	Format     string // json, console
-	Output     string // stdout, stderr, file path
-	TimeFormat string // RFC3339, Unix, etc.
-	Colorize   bool   // Enable colors for console output
+	Level      string            // debug, info, warn, error
+	Format     string            // json, console
+	Output     string            // stdout, stderr, file path
+	TimeFormat string            // RFC3339, Unix, etc.
+	Colorize   bool              // Enable colors for console output
+	Rotation   LogRotationConfig // Only used when Output is a file path
+}
+
+// LogRotationConfig controls rotation of file output. Rotation is enabled
+// when MaxSizeMB is greater than zero.
+type LogRotationConfig struct {
+	MaxSizeMB  int
+	MaxAgeDays int
+	MaxBackups int
+	Compress   bool
 }
 
 // New creates a new logger with the given configuration
 func New(config Config) (*Logger, error) {
+	// Configure time format before any logger is created
+	if config.TimeFormat == "" {
+		config.TimeFormat = "rfc3339"
+	}
+	switch strings.ToLower(config.TimeFormat) {
+	case "unix":
+		zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
+	case "unixms":
+		zerolog.TimeFieldFormat = zerolog.TimeFormatUnixMs
+	case "unixmicro":
+		zerolog.TimeFieldFormat = zerolog.TimeFormatUnixMicro
+	case "rfc3339":
+		zerolog.TimeFieldFormat = time.RFC3339
+	case "rfc3339nano":
+		zerolog.TimeFieldFormat = time.RFC3339Nano
+	}
+
 	// Set up the output writer
 	var output io.Writer = os.Stdout
+	syncOutput := func() error { return nil }
+	isFile := false
 	if config.Output == "stderr" {
 		output = os.Stderr
 	} else if config.Output != "stdout" && config.Output != "" {
-		// TODO: Support file output if needed
-		output = os.Stdout
+		isFile = true
+		if config.Rotation.MaxSizeMB > 0 {
+			rotator := &lumberjack.Logger{
+				Filename:   config.Output,
+				MaxSize:    config.Rotation.MaxSizeMB,
+				MaxAge:     config.Rotation.MaxAgeDays,
+				MaxBackups: config.Rotation.MaxBackups,
+				Compress:   config.Rotation.Compress,
+			}
+			output = rotator
+			// lumberjack writes straight to its file, so closing it is
+			// enough to release it; the next write reopens the file
+			syncOutput = rotator.Close
+		} else {
+			file, err := os.OpenFile(config.Output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+			if err != nil {
+				return nil, fmt.Errorf("failed to open log file: %w", err)
+			}
+			output = file
+			syncOutput = file.Sync
+		}
 	}
 
 	// Configure zerolog
 	if config.Format == "console" || config.Colorize {
-		// Use pretty console output with colors
+		// Use pretty console output, without colors when writing to a file
 		output = zerolog.ConsoleWriter{
 			Out:        output,
+			NoColor:    isFile,
 			TimeFormat: "15:04:05",
 			FormatLevel: func(i interface{}) string {
 				return strings.ToUpper(fmt.Sprintf("| %-6s|", i))
@@ -64,45 +116,52 @@ func New(config Config) (*Logger, error) {
 	// Create logger
 	logger := zerolog.New(output).With().Timestamp().Logger()
 
-	// Configure time format
-	if config.TimeFormat != "" {
-		switch config.TimeFormat {
-		case "unix":
-			zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
-		case "unixms":
-			zerolog.TimeFieldFormat = zerolog.TimeFormatUnixMs
-		case "unixmicro":
-			zerolog.TimeFieldFormat = zerolog.TimeFormatUnixMicro
-		case "rfc3339":
-			zerolog.TimeFieldFormat = time.RFC3339
-		case "rfc3339nano":
-			zerolog.TimeFieldFormat = time.RFC3339Nano
-		}
+	return &Logger{Logger: logger, sync: syncOutput}, nil
+}
+
+// Sync flushes pending writes to the log file. It is a no-op for stdout
+// and stderr.
+func (l *Logger) Sync() error {
+	if l.sync == nil {
+		return nil
 	}
+	return l.sync()
+}
 
-	return &Logger{Logger: logger}, nil
+// logLevels are the zerolog levels by name
+var logLevels = map[string]zerolog.Level{
+	"trace":   zerolog.TraceLevel,
+	"debug":   zerolog.DebugLevel,
+	"info":    zerolog.InfoLevel,
+	"warn":    zerolog.WarnLevel,
+	"warning": zerolog.WarnLevel,
+	"error":   zerolog.ErrorLevel,
+	"fatal":   zerolog.FatalLevel,
+	"panic":   zerolog.PanicLevel,
 }
 
-// parseLogLevel converts string level to zerolog level
+// parseLogLevel converts string level to zerolog level, defaulting to info
 func parseLogLevel(level string) zerolog.Level {
-	switch strings.ToLower(level) {
-	case "trace":
-		return zerolog.TraceLevel
-	case "debug":
-		return zerolog.DebugLevel
-	case "info":
-		return zerolog.InfoLevel
-	case "warn", "warning":
-		return zerolog.WarnLevel
-	case "error":
-		return zerolog.ErrorLevel
-	case "fatal":
-		return zerolog.FatalLevel
-	case "panic":
-		return zerolog.PanicLevel
-	default:
-		return zerolog.InfoLevel
+	if parsed, ok := logLevels[strings.ToLower(level)]; ok {
+		return parsed
 	}
+	return zerolog.InfoLevel
+}
+
+// GetLevel returns the global log level, such as "info"
+func (l *Logger) GetLevel() string {
+	return zerolog.GlobalLevel().String()
+}
+
+// SetLevel changes the global log level, which applies to every logger. The
+// change isn't persisted, so a restart returns to the configured level.
+func (l *Logger) SetLevel(level string) error {
+	parsed, ok := logLevels[strings.ToLower(level)]
+	if !ok {
+		return fmt.Errorf("unknown log level %q", level)
+	}
+	zerolog.SetGlobalLevel(parsed)
+	return nil
 }
 
 // WithFields adds multiple fields to the logger context
@@ -111,17 +170,47 @@ func (l *Logger) WithFields(fields map[string]interface{}) *Logger {
 	for k, v := range fields {
 		event = event.Interface(k, v)
 	}
-	return &Logger{Logger: event.Logger()}
+	return &Logger{Logger: event.Logger(), sync: l.sync}
 }
 
 // WithField adds a single field to the logger context
 func (l *Logger) WithField(key string, value interface{}) *Logger {
-	return &Logger{Logger: l.Logger.With().Interface(key, value).Logger()}
+	return &Logger{Logger: l.Logger.With().Interface(key, value).Logger(), sync: l.sync}
 }
 
 // WithError adds an error field to the logger context
 func (l *Logger) WithError(err error) *Logger {
-	return &Logger{Logger: l.Logger.With().Err(err).Logger()}
+	return &Logger{Logger: l.Logger.With().Err(err).Logger(), sync: l.sync}
+}
+
+// requestIDKey is the context key for the ID of the request being served
+type requestIDKey struct{}
+
+// ContextWithRequestID returns ctx carrying the ID of the request being
+// served, so loggers from ForContext include it
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID in ctx, or "" if there is none
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// WithRequestID adds a request_id field to the logger context, so all the
+// log lines for a request can be found together. An empty ID adds nothing.
+func (l *Logger) WithRequestID(id string) *Logger {
+	if id == "" {
+		return l
+	}
+	return l.WithField("request_id", id)
+}
+
+// ForContext returns the logger with the request ID in ctx, if any, for
+// services logging on behalf of a request
+func (l *Logger) ForContext(ctx context.Context) *Logger {
+	return l.WithRequestID(RequestIDFromContext(ctx))
 }
 
 // HTTP request logging helpers
@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNew_FileOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dashboard.log")
+	if err := os.WriteFile(path, []byte("existing\n"), 0644); err != nil {
+		t.Fatalf("Failed to create log file: %v", err)
+	}
+
+	log, err := New(Config{Level: "info", Format: "json", Output: path})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	log.WithField("component", "test").Info().Msg("written to file")
+	if err := log.Sync(); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	if !strings.HasPrefix(string(contents), "existing\n") {
+		t.Error("Expected log file to be appended to, not truncated")
+	}
+	if !strings.Contains(string(contents), "written to file") {
+		t.Errorf("Expected log message in file, got %q", contents)
+	}
+}
+
+func TestNew_RotatingFileOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "dashboard.log")
+
+	log, err := New(Config{
+		Level:    "info",
+		Format:   "json",
+		Output:   path,
+		Rotation: LogRotationConfig{MaxSizeMB: 1, MaxBackups: 2},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	log.Info().Msg("rotating output")
+	if err := log.Sync(); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	if !strings.Contains(string(contents), "rotating output") {
+		t.Errorf("Expected log message in file, got %q", contents)
+	}
+}
+
+func TestLogger_ForContext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dashboard.log")
+	log, err := New(Config{Level: "info", Format: "json", Output: path})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx := ContextWithRequestID(context.Background(), "req-123")
+	log.ForContext(ctx).Info().Msg("with request")
+	log.ForContext(context.Background()).Info().Msg("without request")
+	log.Sync()
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(contents)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 log lines, got %q", contents)
+	}
+	if !strings.Contains(lines[0], `"request_id":"req-123"`) {
+		t.Errorf("Expected the request ID in %s", lines[0])
+	}
+	if strings.Contains(lines[1], "request_id") {
+		t.Errorf("Expected no request ID without one in the context, got %s", lines[1])
+	}
+}
+
+func TestLogger_SetLevel(t *testing.T) {
+	log, err := New(Config{Level: "info", Format: "json"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	t.Cleanup(func() { log.SetLevel("info") })
+
+	if err := log.SetLevel("WARNING"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if level := log.GetLevel(); level != "warn" {
+		t.Errorf("Expected level warn, got %q", level)
+	}
+
+	if err := log.SetLevel("verbose"); err == nil {
+		t.Error("Expected an error for an unknown level")
+	}
+	if level := log.GetLevel(); level != "warn" {
+		t.Errorf("Expected the level to be unchanged, got %q", level)
+	}
+}
@@ -0,0 +1,152 @@
+// Package metrics exports application metrics in the Prometheus format.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry holds the application's Prometheus metrics. Each Registry is
+// independent of the others and of the Prometheus default registry, so tests
+// can create their own. The methods of a nil Registry do nothing, so callers
+// don't need to check whether metrics are enabled.
+type Registry struct {
+	registry *prometheus.Registry
+
+	httpRequests     *prometheus.CounterVec
+	httpDuration     *prometheus.HistogramVec
+	reportGeneration *prometheus.HistogramVec
+	awsAPICalls      *prometheus.CounterVec
+	caches           *cacheCollector
+}
+
+// NewRegistry creates a registry with the application metrics and the
+// standard Go runtime and process metrics
+func NewRegistry() *Registry {
+	r := &Registry{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "govuk_http_requests_total",
+			Help: "HTTP requests handled, by method, route and status code",
+		}, []string{"method", "path", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "govuk_http_request_duration_seconds",
+			Help:    "Time taken to handle HTTP requests, by method and route",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "path"}),
+		// Reports can take tens of seconds when AWS is slow, beyond the
+		// default buckets
+		reportGeneration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "govuk_report_generation_duration_seconds",
+			Help:    "Time taken to generate reports, by report ID",
+			Buckets: []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+		}, []string{"report_id"}),
+		awsAPICalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "govuk_aws_api_calls_total",
+			Help: "AWS API calls made, by service, operation and whether they succeeded",
+		}, []string{"service", "operation", "success"}),
+		caches: &cacheCollector{stats: make(map[string]CacheStatsFunc)},
+	}
+
+	r.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		r.httpRequests,
+		r.httpDuration,
+		r.reportGeneration,
+		r.awsAPICalls,
+		r.caches,
+	)
+	return r
+}
+
+// ObserveHTTPRequest records a handled HTTP request. path should be the
+// route pattern rather than the request path, to keep the number of label
+// values bounded.
+func (r *Registry) ObserveHTTPRequest(method, path string, status int, duration time.Duration) {
+	if r == nil {
+		return
+	}
+	r.httpRequests.WithLabelValues(method, path, strconv.Itoa(status)).Inc()
+	r.httpDuration.WithLabelValues(method, path).Observe(duration.Seconds())
+}
+
+// ObserveReportGeneration records how long a report took to generate
+func (r *Registry) ObserveReportGeneration(reportID string, duration time.Duration) {
+	if r == nil {
+		return
+	}
+	r.reportGeneration.WithLabelValues(reportID).Observe(duration.Seconds())
+}
+
+// RecordAWSAPICall counts one call to an AWS API operation
+func (r *Registry) RecordAWSAPICall(service, operation string, success bool) {
+	if r == nil {
+		return
+	}
+	r.awsAPICalls.WithLabelValues(service, operation, strconv.FormatBool(success)).Inc()
+}
+
+// CacheStatsFunc returns the number of hits and misses a cache has had
+type CacheStatsFunc func() (hits, misses int64)
+
+// RegisterCache exports the hits and misses of a cache as
+// govuk_cache_operations_total with the given type label. stats is called on
+// every scrape, so caches keep their own counts rather than reporting each
+// lookup. Registering a type again replaces its stats function.
+func (r *Registry) RegisterCache(cacheType string, stats CacheStatsFunc) {
+	if r == nil {
+		return
+	}
+	r.caches.mu.Lock()
+	defer r.caches.mu.Unlock()
+	r.caches.stats[cacheType] = stats
+}
+
+// MustRegister registers additional collectors, panicking if any can't be
+// registered
+func (r *Registry) MustRegister(cs ...prometheus.Collector) {
+	if r == nil {
+		return
+	}
+	r.registry.MustRegister(cs...)
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func (r *Registry) Handler() http.Handler {
+	return promhttp.HandlerFor(r.registry, promhttp.HandlerOpts{})
+}
+
+// cacheCollector reports govuk_cache_operations_total from the stats
+// functions of registered caches
+type cacheCollector struct {
+	mu    sync.Mutex
+	stats map[string]CacheStatsFunc
+}
+
+var cacheOperationsDesc = prometheus.NewDesc(
+	"govuk_cache_operations_total",
+	"Cache lookups, by cache type and whether they were hits",
+	[]string{"type", "hit"}, nil,
+)
+
+func (c *cacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- cacheOperationsDesc
+}
+
+func (c *cacheCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for cacheType, stats := range c.stats {
+		hits, misses := stats()
+		ch <- prometheus.MustNewConstMetric(cacheOperationsDesc, prometheus.CounterValue, float64(hits), cacheType, "true")
+		ch <- prometheus.MustNewConstMetric(cacheOperationsDesc, prometheus.CounterValue, float64(misses), cacheType, "false")
+	}
+}
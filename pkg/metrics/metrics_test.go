@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// scrape returns the text exposition of registry
+func scrape(t *testing.T, registry *Registry) string {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	return w.Body.String()
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+
+	registry.ObserveHTTPRequest("GET", "/api/reports/:id", http.StatusOK, 20*time.Millisecond)
+	registry.ObserveHTTPRequest("GET", "/api/reports/:id", http.StatusOK, 30*time.Millisecond)
+	registry.ObserveHTTPRequest("GET", "unmatched", http.StatusNotFound, time.Millisecond)
+	registry.ObserveReportGeneration("costs", 2*time.Second)
+	registry.RecordAWSAPICall("rds", "DescribeDBInstances", true)
+	registry.RecordAWSAPICall("rds", "DescribeDBInstances", false)
+	registry.RegisterCache("report", func() (int64, int64) { return 5, 2 })
+
+	body := scrape(t, registry)
+	for _, expected := range []string{
+		`govuk_http_requests_total{method="GET",path="/api/reports/:id",status="200"} 2`,
+		`govuk_http_requests_total{method="GET",path="unmatched",status="404"} 1`,
+		`govuk_http_request_duration_seconds_count{method="GET",path="/api/reports/:id"} 2`,
+		`govuk_report_generation_duration_seconds_sum{report_id="costs"} 2`,
+		`govuk_aws_api_calls_total{operation="DescribeDBInstances",service="rds",success="true"} 1`,
+		`govuk_aws_api_calls_total{operation="DescribeDBInstances",service="rds",success="false"} 1`,
+		`govuk_cache_operations_total{hit="true",type="report"} 5`,
+		`govuk_cache_operations_total{hit="false",type="report"} 2`,
+		"go_goroutines ",
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Expected %q in metrics, got:\n%s", expected, body)
+		}
+	}
+
+	// Registries are independent of each other
+	if body := scrape(t, NewRegistry()); strings.Contains(body, "govuk_http_requests_total{") {
+		t.Errorf("Expected a new registry to have no requests, got:\n%s", body)
+	}
+}
+
+func TestRegistry_Nil(t *testing.T) {
+	var registry *Registry
+
+	// None of these should panic
+	registry.ObserveHTTPRequest("GET", "/", http.StatusOK, time.Millisecond)
+	registry.ObserveReportGeneration("costs", time.Second)
+	registry.RecordAWSAPICall("rds", "DescribeDBInstances", true)
+	registry.RegisterCache("report", func() (int64, int64) { return 0, 0 })
+	registry.MustRegister()
+}
@@ -0,0 +1,153 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"govuk-reports-dashboard/pkg/logger"
+)
+
+// EventEOLInstances is the event sent by EOLWebhookNotifier
+const EventEOLInstances = "eol.instances"
+
+// Reasons an EOLInstance is reported
+const (
+	EOLReasonEndOfLife      = "end_of_life"
+	EOLReasonApproachingEOL = "approaching_eol"
+	EOLReasonOutdated       = "outdated"
+	EOLReasonCriticalPatch  = "critical_patch"
+)
+
+// EOLInstance is a database or cache instance that needs upgrading. Report
+// is the ID of the report that found it.
+type EOLInstance struct {
+	ID          string     `json:"id"`
+	Report      string     `json:"report"`
+	Engine      string     `json:"engine"`
+	Version     string     `json:"version"`
+	Application string     `json:"application,omitempty"`
+	Environment string     `json:"environment,omitempty"`
+	Region      string     `json:"region,omitempty"`
+	EOLDate     *time.Time `json:"eol_date,omitempty"`
+	Reason      string     `json:"reason"`
+}
+
+// EOLAlertPolicy decides which instances are worth notifying about.
+//
+// End-of-life instances are notified when NotifyOnEOL is set, as are
+// outdated instances whose version reaches end-of-life within
+// EOLWarningDays, which are reported as approaching end-of-life. Other
+// outdated instances are notified when NotifyOnOutdated is set and, if
+// OutdatedWarningDays is positive, their version reaches end-of-life within
+// that many days.
+type EOLAlertPolicy struct {
+	NotifyOnEOL           bool
+	NotifyOnOutdated      bool
+	NotifyOnCriticalPatch bool
+	EOLWarningDays        int
+	OutdatedWarningDays   int
+}
+
+// Apply returns the instances the policy notifies about as of now, with
+// outdated instances nearing end-of-life given EOLReasonApproachingEOL
+func (p EOLAlertPolicy) Apply(instances []EOLInstance, now time.Time) []EOLInstance {
+	within := func(instance EOLInstance, days int) bool {
+		return instance.EOLDate != nil && instance.EOLDate.Before(now.AddDate(0, 0, days))
+	}
+
+	var selected []EOLInstance
+	for _, instance := range instances {
+		switch instance.Reason {
+		case EOLReasonEndOfLife:
+			if p.NotifyOnEOL {
+				selected = append(selected, instance)
+			}
+		case EOLReasonOutdated:
+			if p.NotifyOnEOL && p.EOLWarningDays > 0 && within(instance, p.EOLWarningDays) {
+				instance.Reason = EOLReasonApproachingEOL
+				selected = append(selected, instance)
+			} else if p.NotifyOnOutdated && (p.OutdatedWarningDays <= 0 || within(instance, p.OutdatedWarningDays)) {
+				selected = append(selected, instance)
+			}
+		case EOLReasonCriticalPatch:
+			if p.NotifyOnCriticalPatch {
+				selected = append(selected, instance)
+			}
+		}
+	}
+	return selected
+}
+
+// EOLWebhookNotifier posts EOL notifications to a URL as a JSON
+// WebhookPayload with the eol.instances event
+type EOLWebhookNotifier struct {
+	url        string
+	httpClient *http.Client
+	logger     *logger.Logger
+}
+
+var _ EOLNotifier = (*EOLWebhookNotifier)(nil)
+
+// NewEOLWebhookNotifier creates a notifier posting to webhookURL
+func NewEOLWebhookNotifier(webhookURL string, log *logger.Logger) *EOLWebhookNotifier {
+	return &EOLWebhookNotifier{
+		url:        webhookURL,
+		httpClient: &http.Client{Timeout: WebhookTimeout},
+		logger:     log,
+	}
+}
+
+// NotifyEOLInstances posts the instances, doing nothing if there are none
+func (n *EOLWebhookNotifier) NotifyEOLInstances(ctx context.Context, instances []EOLInstance) error {
+	if len(instances) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(WebhookPayload{
+		Event:     EventEOLInstances,
+		Timestamp: time.Now(),
+		Data: map[string]interface{}{
+			"count":     len(instances),
+			"instances": instances,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal EOL notification: %w", err)
+	}
+
+	if err := postJSON(ctx, n.httpClient, n.logger, "webhook", n.url, body); err != nil {
+		return err
+	}
+
+	n.logger.WithField("instances", len(instances)).Info().Msg("Sent EOL webhook notification")
+	return nil
+}
+
+// postJSON posts body to url, returning an error for non-2xx responses
+func postJSON(ctx context.Context, client *http.Client, log *logger.Logger, service, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", service, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	log.LogAPICall(service, EventEOLInstances, time.Since(start), err == nil && resp.StatusCode < 300)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %w", service, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s returned status %d: %s", service, resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
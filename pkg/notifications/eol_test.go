@@ -0,0 +1,132 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"govuk-reports-dashboard/pkg/logger"
+)
+
+func TestEOLWebhookNotifier_NotifyEOLInstances(t *testing.T) {
+	var received struct {
+		Event string `json:"event"`
+		Data  struct {
+			Count     int           `json:"count"`
+			Instances []EOLInstance `json:"instances"`
+		} `json:"data"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected JSON content type, got %q", r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Fatalf("Failed to decode payload: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	eolDate := time.Date(2025, 11, 13, 0, 0, 0, 0, time.UTC)
+	err := NewEOLWebhookNotifier(server.URL, log).NotifyEOLInstances(context.Background(), []EOLInstance{
+		{ID: "content-store-db", Report: "rds", Engine: "postgres", Version: "12.19", EOLDate: &eolDate, Reason: EOLReasonEndOfLife},
+	})
+	if err != nil {
+		t.Fatalf("NotifyEOLInstances failed: %v", err)
+	}
+
+	if received.Event != EventEOLInstances {
+		t.Errorf("Expected event %q, got %q", EventEOLInstances, received.Event)
+	}
+	if received.Data.Count != 1 || len(received.Data.Instances) != 1 {
+		t.Fatalf("Expected 1 instance, got %+v", received.Data)
+	}
+	instance := received.Data.Instances[0]
+	if instance.ID != "content-store-db" || instance.Reason != EOLReasonEndOfLife || instance.EOLDate == nil || !instance.EOLDate.Equal(eolDate) {
+		t.Errorf("Unexpected instance %+v", instance)
+	}
+}
+
+func TestEOLWebhookNotifier_ErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	err := NewEOLWebhookNotifier(server.URL, log).NotifyEOLInstances(context.Background(), []EOLInstance{{ID: "db", Reason: EOLReasonEndOfLife}})
+	if err == nil {
+		t.Error("Expected error for 500 response")
+	}
+}
+
+func TestEOLAlertPolicy_Apply(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	soon := now.AddDate(0, 0, 10)
+	later := now.AddDate(0, 0, 100)
+	instances := []EOLInstance{
+		{ID: "eol", Reason: EOLReasonEndOfLife},
+		{ID: "outdated-soon", Reason: EOLReasonOutdated, EOLDate: &soon},
+		{ID: "outdated-later", Reason: EOLReasonOutdated, EOLDate: &later},
+		{ID: "patch", Reason: EOLReasonCriticalPatch},
+	}
+
+	tests := []struct {
+		name     string
+		policy   EOLAlertPolicy
+		expected map[string]string
+	}{
+		{
+			name:     "EOL only",
+			policy:   EOLAlertPolicy{NotifyOnEOL: true},
+			expected: map[string]string{"eol": EOLReasonEndOfLife},
+		},
+		{
+			name:   "EOL with warning",
+			policy: EOLAlertPolicy{NotifyOnEOL: true, EOLWarningDays: 30},
+			expected: map[string]string{
+				"eol":           EOLReasonEndOfLife,
+				"outdated-soon": EOLReasonApproachingEOL,
+			},
+		},
+		{
+			name:   "all outdated",
+			policy: EOLAlertPolicy{NotifyOnOutdated: true},
+			expected: map[string]string{
+				"outdated-soon":  EOLReasonOutdated,
+				"outdated-later": EOLReasonOutdated,
+			},
+		},
+		{
+			name:     "outdated within threshold",
+			policy:   EOLAlertPolicy{NotifyOnOutdated: true, OutdatedWarningDays: 30},
+			expected: map[string]string{"outdated-soon": EOLReasonOutdated},
+		},
+		{
+			name:     "critical patches",
+			policy:   EOLAlertPolicy{NotifyOnCriticalPatch: true},
+			expected: map[string]string{"patch": EOLReasonCriticalPatch},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected := tt.policy.Apply(instances, now)
+			got := make(map[string]string)
+			for _, instance := range selected {
+				got[instance.ID] = instance.Reason
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, got)
+			}
+			for id, reason := range tt.expected {
+				if got[id] != reason {
+					t.Errorf("Expected %s to be %s, got %q", id, reason, got[id])
+				}
+			}
+		})
+	}
+}
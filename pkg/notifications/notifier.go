@@ -0,0 +1,77 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+)
+
+// Severity levels understood by alerting backends
+const (
+	SeverityCritical = "critical"
+	SeverityError    = "error"
+	SeverityWarning  = "warning"
+)
+
+// Alert describes a condition that should be raised with an on-call service.
+// Team is the GOV.UK team responsible, if known, and OnCall who is on call
+// for it.
+type Alert struct {
+	DedupKey string
+	Summary  string
+	Severity string
+	Source   string
+	Details  map[string]interface{}
+	Team     string
+	OnCall   *OnCallContact
+}
+
+// OnCallContact is the person on call for an alert's team
+type OnCallContact struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// Notifier raises and clears alerts. Alerts sharing a DedupKey are treated as
+// the same incident, so repeated triggers do not open duplicates.
+type Notifier interface {
+	Trigger(ctx context.Context, alert Alert) error
+	Resolve(ctx context.Context, dedupKey string) error
+}
+
+// MultiNotifier sends alerts to each of several notifiers, returning the
+// errors from any that fail
+type MultiNotifier []Notifier
+
+func (m MultiNotifier) Trigger(ctx context.Context, alert Alert) error {
+	var errs []error
+	for _, notifier := range m {
+		errs = append(errs, notifier.Trigger(ctx, alert))
+	}
+	return errors.Join(errs...)
+}
+
+func (m MultiNotifier) Resolve(ctx context.Context, dedupKey string) error {
+	var errs []error
+	for _, notifier := range m {
+		errs = append(errs, notifier.Resolve(ctx, dedupKey))
+	}
+	return errors.Join(errs...)
+}
+
+// EOLNotifier tells operators about instances newly found running
+// end-of-life or outdated engine versions, or missing critical patches
+type EOLNotifier interface {
+	NotifyEOLInstances(ctx context.Context, instances []EOLInstance) error
+}
+
+// MultiEOLNotifier sends EOL notifications to each of several notifiers,
+// returning the errors from any that fail
+type MultiEOLNotifier []EOLNotifier
+
+func (m MultiEOLNotifier) NotifyEOLInstances(ctx context.Context, instances []EOLInstance) error {
+	var errs []error
+	for _, notifier := range m {
+		errs = append(errs, notifier.NotifyEOLInstances(ctx, instances))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,52 @@
+package notifications
+
+import (
+	"context"
+
+	"govuk-reports-dashboard/pkg/logger"
+)
+
+// OnCallLookup returns who is on call for a GOV.UK team
+type OnCallLookup func(ctx context.Context, team string) (*OnCallContact, error)
+
+// OnCallNotifier adds the on-call person for an alert's team before passing
+// it to another notifier. Alerts without a team are attributed to the
+// default team, if set. Alerts are still sent when the lookup fails.
+type OnCallNotifier struct {
+	notifier    Notifier
+	lookup      OnCallLookup
+	defaultTeam string
+	logger      *logger.Logger
+}
+
+// NewOnCallNotifier creates a notifier that adds on-call details to alerts
+// sent to notifier
+func NewOnCallNotifier(notifier Notifier, lookup OnCallLookup, defaultTeam string, log *logger.Logger) *OnCallNotifier {
+	return &OnCallNotifier{
+		notifier:    notifier,
+		lookup:      lookup,
+		defaultTeam: defaultTeam,
+		logger:      log,
+	}
+}
+
+func (n *OnCallNotifier) Trigger(ctx context.Context, alert Alert) error {
+	if alert.Team == "" {
+		alert.Team = n.defaultTeam
+	}
+
+	if alert.Team != "" && alert.OnCall == nil {
+		onCall, err := n.lookup(ctx, alert.Team)
+		if err != nil {
+			n.logger.WithError(err).WithField("team", alert.Team).Warn().Msg("Failed to look up on-call person for alert")
+		} else {
+			alert.OnCall = onCall
+		}
+	}
+
+	return n.notifier.Trigger(ctx, alert)
+}
+
+func (n *OnCallNotifier) Resolve(ctx context.Context, dedupKey string) error {
+	return n.notifier.Resolve(ctx, dedupKey)
+}
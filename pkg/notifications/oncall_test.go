@@ -0,0 +1,55 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"govuk-reports-dashboard/pkg/logger"
+)
+
+// recordingNotifier keeps the alerts it is sent
+type recordingNotifier struct {
+	alerts []Alert
+}
+
+func (n *recordingNotifier) Trigger(ctx context.Context, alert Alert) error {
+	n.alerts = append(n.alerts, alert)
+	return nil
+}
+
+func (n *recordingNotifier) Resolve(ctx context.Context, dedupKey string) error { return nil }
+
+func TestOnCallNotifier_Trigger(t *testing.T) {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	lookup := func(ctx context.Context, team string) (*OnCallContact, error) {
+		if team != "publishing-platform" {
+			return nil, errors.New("team has no pagerduty schedule")
+		}
+		return &OnCallContact{Name: "First Line", Email: "first@digital.cabinet-office.gov.uk"}, nil
+	}
+
+	recorder := &recordingNotifier{}
+	notifier := NewOnCallNotifier(recorder, lookup, "publishing-platform", log)
+
+	if err := notifier.Trigger(context.Background(), Alert{DedupKey: "rds-eol"}); err != nil {
+		t.Fatalf("Trigger failed: %v", err)
+	}
+	if err := notifier.Trigger(context.Background(), Alert{DedupKey: "search", Team: "search"}); err != nil {
+		t.Fatalf("Trigger failed: %v", err)
+	}
+
+	if len(recorder.alerts) != 2 {
+		t.Fatalf("Expected both alerts to be sent, got %d", len(recorder.alerts))
+	}
+
+	first := recorder.alerts[0]
+	if first.Team != "publishing-platform" || first.OnCall == nil || first.OnCall.Name != "First Line" {
+		t.Errorf("Expected default team's on-call person, got team %q on-call %+v", first.Team, first.OnCall)
+	}
+
+	second := recorder.alerts[1]
+	if second.Team != "search" || second.OnCall != nil {
+		t.Errorf("Expected alert without on-call person when lookup fails, got team %q on-call %+v", second.Team, second.OnCall)
+	}
+}
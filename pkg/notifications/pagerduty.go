@@ -0,0 +1,123 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"govuk-reports-dashboard/pkg/logger"
+)
+
+const (
+	PagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+	PagerDutyTimeout   = 10 * time.Second
+)
+
+// PagerDuty event actions
+const (
+	EventActionTrigger     = "trigger"
+	EventActionAcknowledge = "acknowledge"
+	EventActionResolve     = "resolve"
+)
+
+// PagerDutyEvent is a PagerDuty Events API v2 request body
+type PagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key,omitempty"`
+	Payload     *PagerDutyPayload `json:"payload,omitempty"`
+}
+
+// PagerDutyPayload holds the incident details for trigger events
+type PagerDutyPayload struct {
+	Summary       string                 `json:"summary"`
+	Severity      string                 `json:"severity"`
+	Source        string                 `json:"source"`
+	CustomDetails map[string]interface{} `json:"custom_details,omitempty"`
+}
+
+// PagerDutyNotifier sends alerts to PagerDuty via the Events API v2
+type PagerDutyNotifier struct {
+	routingKey string
+	endpoint   string
+	httpClient *http.Client
+	logger     *logger.Logger
+}
+
+// NewPagerDutyNotifier creates a notifier for the given integration routing key
+func NewPagerDutyNotifier(routingKey string, log *logger.Logger) *PagerDutyNotifier {
+	return &PagerDutyNotifier{
+		routingKey: routingKey,
+		endpoint:   PagerDutyEventsURL,
+		httpClient: &http.Client{Timeout: PagerDutyTimeout},
+		logger:     log,
+	}
+}
+
+// WithEndpoint overrides the Events API endpoint, mainly for testing
+func (n *PagerDutyNotifier) WithEndpoint(endpoint string) *PagerDutyNotifier {
+	n.endpoint = endpoint
+	return n
+}
+
+// Trigger opens or updates the incident identified by the alert's DedupKey
+func (n *PagerDutyNotifier) Trigger(ctx context.Context, alert Alert) error {
+	return n.Send(ctx, PagerDutyEvent{
+		RoutingKey:  n.routingKey,
+		EventAction: EventActionTrigger,
+		DedupKey:    alert.DedupKey,
+		Payload: &PagerDutyPayload{
+			Summary:       alert.Summary,
+			Severity:      alert.Severity,
+			Source:        alert.Source,
+			CustomDetails: alert.Details,
+		},
+	})
+}
+
+// Resolve closes the incident identified by dedupKey
+func (n *PagerDutyNotifier) Resolve(ctx context.Context, dedupKey string) error {
+	return n.Send(ctx, PagerDutyEvent{
+		RoutingKey:  n.routingKey,
+		EventAction: EventActionResolve,
+		DedupKey:    dedupKey,
+	})
+}
+
+// Send posts a raw event to the Events API
+func (n *PagerDutyNotifier) Send(ctx context.Context, event PagerDutyEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal PagerDuty event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create PagerDuty request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	start := time.Now()
+	resp, err := n.httpClient.Do(req)
+	n.logger.LogAPICall("pagerduty", event.EventAction, time.Since(start), err == nil && resp.StatusCode == http.StatusAccepted)
+	if err != nil {
+		return fmt.Errorf("PagerDuty request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("PagerDuty returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	n.logger.WithFields(map[string]interface{}{
+		"event_action": event.EventAction,
+		"dedup_key":    event.DedupKey,
+	}).Info().Msg("Sent PagerDuty event")
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"govuk-reports-dashboard/pkg/logger"
+)
+
+func newTestNotifier(t *testing.T, handler http.HandlerFunc) (*PagerDutyNotifier, func()) {
+	server := httptest.NewServer(handler)
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	return NewPagerDutyNotifier("test-routing-key", log).WithEndpoint(server.URL), server.Close
+}
+
+func TestPagerDutyNotifier_Trigger(t *testing.T) {
+	var received PagerDutyEvent
+	notifier, cleanup := newTestNotifier(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Fatalf("Failed to decode event: %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+	defer cleanup()
+
+	err := notifier.Trigger(context.Background(), Alert{
+		DedupKey: "rds-eol-rds",
+		Summary:  "2 RDS instances are end-of-life",
+		Severity: SeverityCritical,
+		Source:   "govuk-reports-dashboard",
+		Details:  map[string]interface{}{"eol_instances": 2},
+	})
+	if err != nil {
+		t.Fatalf("Trigger failed: %v", err)
+	}
+
+	if received.RoutingKey != "test-routing-key" {
+		t.Errorf("Expected routing key 'test-routing-key', got '%s'", received.RoutingKey)
+	}
+	if received.EventAction != EventActionTrigger {
+		t.Errorf("Expected action 'trigger', got '%s'", received.EventAction)
+	}
+	if received.DedupKey != "rds-eol-rds" {
+		t.Errorf("Expected dedup key 'rds-eol-rds', got '%s'", received.DedupKey)
+	}
+	if received.Payload == nil || received.Payload.Severity != SeverityCritical {
+		t.Fatalf("Expected critical payload, got %+v", received.Payload)
+	}
+	if received.Payload.CustomDetails["eol_instances"] != float64(2) {
+		t.Errorf("Expected eol_instances 2, got %v", received.Payload.CustomDetails["eol_instances"])
+	}
+}
+
+func TestPagerDutyNotifier_Resolve(t *testing.T) {
+	var received PagerDutyEvent
+	notifier, cleanup := newTestNotifier(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusAccepted)
+	})
+	defer cleanup()
+
+	if err := notifier.Resolve(context.Background(), "rds-eol-rds"); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	if received.EventAction != EventActionResolve {
+		t.Errorf("Expected action 'resolve', got '%s'", received.EventAction)
+	}
+	if received.Payload != nil {
+		t.Errorf("Expected no payload on resolve, got %+v", received.Payload)
+	}
+}
+
+func TestPagerDutyNotifier_ErrorResponse(t *testing.T) {
+	notifier, cleanup := newTestNotifier(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status":"invalid event"}`))
+	})
+	defer cleanup()
+
+	if err := notifier.Resolve(context.Background(), "rds-eol-rds"); err == nil {
+		t.Error("Expected error for non-202 response")
+	}
+}
@@ -0,0 +1,129 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"govuk-reports-dashboard/pkg/logger"
+)
+
+// SlackMaxListedInstances is the most instances listed in one Slack message;
+// any more are counted in a closing line
+const SlackMaxListedInstances = 20
+
+// SlackMessage is a Slack incoming webhook message using Block Kit. Text is
+// shown in notifications and by clients that can't render blocks.
+type SlackMessage struct {
+	Text   string       `json:"text"`
+	Blocks []SlackBlock `json:"blocks"`
+}
+
+// SlackBlock is a Block Kit layout block
+type SlackBlock struct {
+	Type     string      `json:"type"`
+	Text     *SlackText  `json:"text,omitempty"`
+	Elements []SlackText `json:"elements,omitempty"`
+}
+
+// SlackText is a Block Kit text object, of type "plain_text" or "mrkdwn"
+type SlackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// SlackNotifier posts EOL notifications to a Slack incoming webhook
+type SlackNotifier struct {
+	webhookURL string
+	httpClient *http.Client
+	logger     *logger.Logger
+}
+
+var _ EOLNotifier = (*SlackNotifier)(nil)
+
+// NewSlackNotifier creates a notifier posting to a Slack incoming webhook URL
+func NewSlackNotifier(webhookURL string, log *logger.Logger) *SlackNotifier {
+	return &SlackNotifier{
+		webhookURL: webhookURL,
+		httpClient: &http.Client{Timeout: WebhookTimeout},
+		logger:     log,
+	}
+}
+
+// NotifyEOLInstances posts a message listing the instances, doing nothing if
+// there are none
+func (n *SlackNotifier) NotifyEOLInstances(ctx context.Context, instances []EOLInstance) error {
+	if len(instances) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(NewEOLSlackMessage(instances))
+	if err != nil {
+		return fmt.Errorf("failed to marshal Slack message: %w", err)
+	}
+
+	if err := postJSON(ctx, n.httpClient, n.logger, "slack", n.webhookURL, body); err != nil {
+		return err
+	}
+
+	n.logger.WithField("instances", len(instances)).Info().Msg("Sent EOL Slack notification")
+	return nil
+}
+
+// NewEOLSlackMessage builds a message with a header, one section per
+// instance up to SlackMaxListedInstances, and a count of any left out
+func NewEOLSlackMessage(instances []EOLInstance) SlackMessage {
+	title := fmt.Sprintf("%d instance(s) need upgrading", len(instances))
+	message := SlackMessage{
+		Text: title,
+		Blocks: []SlackBlock{
+			{Type: "header", Text: &SlackText{Type: "plain_text", Text: title}},
+		},
+	}
+
+	for i, instance := range instances {
+		if i == SlackMaxListedInstances {
+			message.Blocks = append(message.Blocks, SlackBlock{
+				Type:     "context",
+				Elements: []SlackText{{Type: "mrkdwn", Text: fmt.Sprintf("and %d more", len(instances)-i)}},
+			})
+			break
+		}
+		message.Blocks = append(message.Blocks, SlackBlock{
+			Type: "section",
+			Text: &SlackText{Type: "mrkdwn", Text: slackInstanceText(instance)},
+		})
+	}
+
+	return message
+}
+
+// slackInstanceText describes an instance in Slack mrkdwn
+func slackInstanceText(instance EOLInstance) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "*%s* (%s %s): %s", instance.ID, instance.Engine, instance.Version, eolReasonText(instance.Reason))
+	if instance.EOLDate != nil {
+		fmt.Fprintf(&text, ", end-of-life %s", instance.EOLDate.Format("2 January 2006"))
+	}
+	if instance.Application != "" || instance.Environment != "" {
+		fmt.Fprintf(&text, "\n%s %s", instance.Application, instance.Environment)
+	}
+	return text.String()
+}
+
+func eolReasonText(reason string) string {
+	switch reason {
+	case EOLReasonEndOfLife:
+		return "end-of-life version"
+	case EOLReasonApproachingEOL:
+		return "version approaching end-of-life"
+	case EOLReasonOutdated:
+		return "outdated version"
+	case EOLReasonCriticalPatch:
+		return "critical patches not applied"
+	default:
+		return reason
+	}
+}
@@ -0,0 +1,60 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"govuk-reports-dashboard/pkg/logger"
+)
+
+func TestSlackNotifier_NotifyEOLInstances(t *testing.T) {
+	var received SlackMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Fatalf("Failed to decode message: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	err := NewSlackNotifier(server.URL, log).NotifyEOLInstances(context.Background(), []EOLInstance{
+		{ID: "content-store-db", Engine: "postgres", Version: "12.19", Application: "content-store", Environment: "production", Reason: EOLReasonEndOfLife},
+	})
+	if err != nil {
+		t.Fatalf("NotifyEOLInstances failed: %v", err)
+	}
+
+	if received.Text != "1 instance(s) need upgrading" {
+		t.Errorf("Unexpected fallback text %q", received.Text)
+	}
+	if len(received.Blocks) != 2 || received.Blocks[0].Type != "header" || received.Blocks[1].Type != "section" {
+		t.Fatalf("Expected header and section blocks, got %+v", received.Blocks)
+	}
+	section := received.Blocks[1].Text
+	if section.Type != "mrkdwn" || !strings.Contains(section.Text, "*content-store-db*") || !strings.Contains(section.Text, "end-of-life version") {
+		t.Errorf("Unexpected section %+v", section)
+	}
+}
+
+func TestNewEOLSlackMessage_LimitsListedInstances(t *testing.T) {
+	var instances []EOLInstance
+	for i := range SlackMaxListedInstances + 5 {
+		instances = append(instances, EOLInstance{ID: fmt.Sprintf("db-%d", i), Reason: EOLReasonOutdated})
+	}
+
+	message := NewEOLSlackMessage(instances)
+
+	// Header, listed instances and the closing count
+	if len(message.Blocks) != SlackMaxListedInstances+2 {
+		t.Fatalf("Expected %d blocks, got %d", SlackMaxListedInstances+2, len(message.Blocks))
+	}
+	last := message.Blocks[len(message.Blocks)-1]
+	if last.Type != "context" || len(last.Elements) != 1 || last.Elements[0].Text != "and 5 more" {
+		t.Errorf("Unexpected closing block %+v", last)
+	}
+}
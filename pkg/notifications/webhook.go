@@ -0,0 +1,309 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"govuk-reports-dashboard/pkg/logger"
+)
+
+// Webhook events
+const (
+	EventReportCompleted = "report.completed"
+	EventReportFailed    = "report.failed"
+	EventAlertCritical   = "alert.critical"
+)
+
+// WebhookEvents are the events webhooks can be registered for
+var WebhookEvents = []string{EventReportCompleted, EventReportFailed, EventAlertCritical}
+
+const (
+	// WebhookSignatureHeader carries the hex HMAC-SHA256 of the request
+	// body, keyed with the registration's secret, as "sha256=<hex>"
+	WebhookSignatureHeader = "X-Govuk-Signature"
+	WebhookEventHeader     = "X-Govuk-Event"
+
+	WebhookTimeout        = 10 * time.Second
+	WebhookMaxAttempts    = 3
+	WebhookInitialBackoff = 1 * time.Second
+)
+
+// ErrInvalidWebhook is returned when registering a webhook with a bad URL or
+// unknown events
+var ErrInvalidWebhook = errors.New("invalid webhook")
+
+// WebhookRegistration is an external URL to notify of events
+type WebhookRegistration struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Secret    string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WebhookPayload is the JSON body sent to webhooks
+type WebhookPayload struct {
+	Event     string                 `json:"event"`
+	Timestamp time.Time              `json:"timestamp"`
+	Data      map[string]interface{} `json:"data"`
+}
+
+// WebhookDelivery is one event being sent to one registration
+type WebhookDelivery struct {
+	Registration WebhookRegistration
+	Event        string
+	Body         []byte
+	Attempts     int
+}
+
+// WebhookDispatcher keeps webhook registrations in memory and delivers
+// events to them in the background, retrying failed deliveries with
+// exponential backoff. It also implements Notifier, sending critical alerts
+// as alert.critical events.
+type WebhookDispatcher struct {
+	registrations  sync.Map // registration ID -> WebhookRegistration
+	httpClient     *http.Client
+	initialBackoff time.Duration
+	logger         *logger.Logger
+	wg             sync.WaitGroup
+}
+
+var _ Notifier = (*WebhookDispatcher)(nil)
+
+// NewWebhookDispatcher creates a dispatcher with no registrations
+func NewWebhookDispatcher(log *logger.Logger) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		httpClient:     &http.Client{Timeout: WebhookTimeout},
+		initialBackoff: WebhookInitialBackoff,
+		logger:         log,
+	}
+}
+
+// WithInitialBackoff overrides the delay before the first retry, mainly for
+// testing
+func (d *WebhookDispatcher) WithInitialBackoff(backoff time.Duration) *WebhookDispatcher {
+	d.initialBackoff = backoff
+	return d
+}
+
+// Register adds a webhook for the given events. The URL must be http or
+// https and every event must be one of WebhookEvents.
+func (d *WebhookDispatcher) Register(webhookURL string, events []string, secret string) (WebhookRegistration, error) {
+	parsed, err := url.Parse(webhookURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return WebhookRegistration{}, fmt.Errorf("%w: URL must be an absolute http or https URL", ErrInvalidWebhook)
+	}
+	if len(events) == 0 {
+		return WebhookRegistration{}, fmt.Errorf("%w: at least one event is required", ErrInvalidWebhook)
+	}
+	for _, event := range events {
+		if !slices.Contains(WebhookEvents, event) {
+			return WebhookRegistration{}, fmt.Errorf("%w: unknown event %q", ErrInvalidWebhook, event)
+		}
+	}
+
+	id, err := newUUID()
+	if err != nil {
+		return WebhookRegistration{}, fmt.Errorf("failed to generate webhook ID: %w", err)
+	}
+
+	registration := WebhookRegistration{
+		ID:        id,
+		URL:       webhookURL,
+		Events:    events,
+		Secret:    secret,
+		CreatedAt: time.Now(),
+	}
+	d.registrations.Store(id, registration)
+
+	d.logger.WithFields(map[string]interface{}{
+		"webhook_id": id,
+		"events":     events,
+	}).Info().Msg("Webhook registered")
+
+	return registration, nil
+}
+
+// List returns the registrations, oldest first
+func (d *WebhookDispatcher) List() []WebhookRegistration {
+	registrations := []WebhookRegistration{}
+	d.registrations.Range(func(_, value interface{}) bool {
+		registrations = append(registrations, value.(WebhookRegistration))
+		return true
+	})
+
+	sort.Slice(registrations, func(i, j int) bool {
+		return registrations[i].CreatedAt.Before(registrations[j].CreatedAt)
+	})
+	return registrations
+}
+
+// Delete removes a registration, returning false if it does not exist
+func (d *WebhookDispatcher) Delete(id string) bool {
+	_, existed := d.registrations.LoadAndDelete(id)
+	if existed {
+		d.logger.WithField("webhook_id", id).Info().Msg("Webhook deleted")
+	}
+	return existed
+}
+
+// Publish sends an event to every registration subscribed to it. Deliveries
+// run in the background, so Publish does not block on slow receivers.
+func (d *WebhookDispatcher) Publish(event string, data map[string]interface{}) {
+	body, err := json.Marshal(WebhookPayload{
+		Event:     event,
+		Timestamp: time.Now(),
+		Data:      data,
+	})
+	if err != nil {
+		d.logger.WithError(err).WithField("event", event).Error().Msg("Failed to marshal webhook payload")
+		return
+	}
+
+	d.registrations.Range(func(_, value interface{}) bool {
+		registration := value.(WebhookRegistration)
+		if slices.Contains(registration.Events, event) {
+			delivery := &WebhookDelivery{Registration: registration, Event: event, Body: body}
+			d.wg.Add(1)
+			go func() {
+				defer d.wg.Done()
+				d.deliver(delivery)
+			}()
+		}
+		return true
+	})
+}
+
+// Wait blocks until deliveries in progress have finished or ctx is done
+func (d *WebhookDispatcher) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Trigger sends critical alerts as alert.critical events, including the
+// alert's team and on-call person when known so that chat integrations can
+// mention them. Alerts of other severities are not sent.
+func (d *WebhookDispatcher) Trigger(ctx context.Context, alert Alert) error {
+	if alert.Severity != SeverityCritical {
+		return nil
+	}
+
+	data := map[string]interface{}{
+		"dedup_key": alert.DedupKey,
+		"summary":   alert.Summary,
+		"source":    alert.Source,
+		"details":   alert.Details,
+	}
+	if alert.Team != "" {
+		data["team"] = alert.Team
+	}
+	if alert.OnCall != nil {
+		data["on_call"] = alert.OnCall
+	}
+
+	d.Publish(EventAlertCritical, data)
+	return nil
+}
+
+// Resolve does nothing, as there is no webhook event for resolved alerts
+func (d *WebhookDispatcher) Resolve(ctx context.Context, dedupKey string) error {
+	return nil
+}
+
+// deliver sends a delivery, retrying up to WebhookMaxAttempts times with the
+// delay doubling after each failure
+func (d *WebhookDispatcher) deliver(delivery *WebhookDelivery) {
+	backoff := d.initialBackoff
+	var err error
+	for delivery.Attempts < WebhookMaxAttempts {
+		if delivery.Attempts > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		delivery.Attempts++
+
+		if err = d.send(delivery); err == nil {
+			d.logger.WithFields(map[string]interface{}{
+				"webhook_id": delivery.Registration.ID,
+				"event":      delivery.Event,
+				"attempts":   delivery.Attempts,
+			}).Info().Msg("Delivered webhook")
+			return
+		}
+	}
+
+	d.logger.WithError(err).WithFields(map[string]interface{}{
+		"webhook_id": delivery.Registration.ID,
+		"event":      delivery.Event,
+		"attempts":   delivery.Attempts,
+	}).Error().Msg("Failed to deliver webhook")
+}
+
+// send makes one delivery attempt
+func (d *WebhookDispatcher) send(delivery *WebhookDelivery) error {
+	req, err := http.NewRequest(http.MethodPost, delivery.Registration.URL, bytes.NewReader(delivery.Body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, delivery.Event)
+	req.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhookPayload(delivery.Registration.Secret, delivery.Body))
+
+	start := time.Now()
+	resp, err := d.httpClient.Do(req)
+	d.logger.LogAPICall("webhook", delivery.Event, time.Since(start), err == nil && resp.StatusCode < 300)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
+
+// SignWebhookPayload returns the hex HMAC-SHA256 of body keyed with secret,
+// for receivers to compare with the X-Govuk-Signature header
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// newUUID returns a random version 4 UUID
+func newUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
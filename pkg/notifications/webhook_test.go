@@ -0,0 +1,139 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"govuk-reports-dashboard/pkg/logger"
+)
+
+func newTestDispatcher() *WebhookDispatcher {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	return NewWebhookDispatcher(log).WithInitialBackoff(time.Millisecond)
+}
+
+func TestWebhookDispatcher_Register(t *testing.T) {
+	dispatcher := newTestDispatcher()
+
+	tests := []struct {
+		name   string
+		url    string
+		events []string
+	}{
+		{"relative URL", "/hooks", []string{EventReportCompleted}},
+		{"unsupported scheme", "ftp://example.com/hooks", []string{EventReportCompleted}},
+		{"no events", "https://example.com/hooks", nil},
+		{"unknown event", "https://example.com/hooks", []string{"report.deleted"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := dispatcher.Register(tt.url, tt.events, "secret"); !errors.Is(err, ErrInvalidWebhook) {
+				t.Errorf("Expected ErrInvalidWebhook, got %v", err)
+			}
+		})
+	}
+
+	registration, err := dispatcher.Register("https://example.com/hooks", []string{EventReportCompleted}, "secret")
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if len(registration.ID) != 36 || registration.ID[14] != '4' {
+		t.Errorf("Expected a version 4 UUID, got %q", registration.ID)
+	}
+	if list := dispatcher.List(); len(list) != 1 || list[0].ID != registration.ID {
+		t.Errorf("Expected the registration to be listed, got %+v", list)
+	}
+
+	if !dispatcher.Delete(registration.ID) || dispatcher.Delete(registration.ID) {
+		t.Error("Expected Delete to succeed once")
+	}
+	if list := dispatcher.List(); len(list) != 0 {
+		t.Errorf("Expected no registrations after Delete, got %+v", list)
+	}
+}
+
+func TestWebhookDispatcher_Publish(t *testing.T) {
+	var attempts atomic.Int32
+	received := make(chan *http.Request, 1)
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail the first attempt to exercise retries
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ = io.ReadAll(r.Body)
+		received <- r
+	}))
+	defer server.Close()
+
+	dispatcher := newTestDispatcher()
+	if _, err := dispatcher.Register(server.URL, []string{EventReportCompleted}, "s3cret"); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if _, err := dispatcher.Register(server.URL, []string{EventAlertCritical}, "other"); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	dispatcher.Publish(EventReportCompleted, map[string]interface{}{"report_id": "costs"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := dispatcher.Wait(ctx); err != nil {
+		t.Fatalf("Deliveries did not finish: %v", err)
+	}
+
+	var req *http.Request
+	select {
+	case req = <-received:
+	default:
+		t.Fatal("Expected the webhook to be delivered")
+	}
+
+	if attempts.Load() != 2 {
+		t.Errorf("Expected delivery on the second attempt, got %d attempts", attempts.Load())
+	}
+	if req.Header.Get(WebhookEventHeader) != EventReportCompleted {
+		t.Errorf("Expected event header %s, got %s", EventReportCompleted, req.Header.Get(WebhookEventHeader))
+	}
+	if signature := req.Header.Get(WebhookSignatureHeader); signature != "sha256="+SignWebhookPayload("s3cret", body) {
+		t.Errorf("Signature %s does not match body", signature)
+	}
+
+	var payload WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil || payload.Event != EventReportCompleted || payload.Data["report_id"] != "costs" {
+		t.Errorf("Unexpected payload %s: %v", body, err)
+	}
+}
+
+func TestWebhookDispatcher_GivesUpAfterMaxAttempts(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	dispatcher := newTestDispatcher()
+	dispatcher.Register(server.URL, []string{EventAlertCritical}, "secret")
+
+	dispatcher.Trigger(context.Background(), Alert{DedupKey: "warning", Severity: SeverityWarning})
+	dispatcher.Trigger(context.Background(), Alert{DedupKey: "eol", Severity: SeverityCritical})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := dispatcher.Wait(ctx); err != nil {
+		t.Fatalf("Deliveries did not finish: %v", err)
+	}
+
+	if attempts.Load() != WebhookMaxAttempts {
+		t.Errorf("Expected %d attempts for the critical alert only, got %d", WebhookMaxAttempts, attempts.Load())
+	}
+}
@@ -0,0 +1,178 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"govuk-reports-dashboard/pkg/logger"
+)
+
+const (
+	PagerDutyAPIURL  = "https://api.pagerduty.com"
+	PagerDutyTimeout = 10 * time.Second
+
+	// OnCallCacheTTL is how long on-call schedules are cached. Rotations
+	// are usually daily or weekly, so a short cache is enough to avoid
+	// calling PagerDuty for every alert.
+	OnCallCacheTTL = 15 * time.Minute
+)
+
+// ErrNoOnCall is returned when nobody is on call for a schedule
+var ErrNoOnCall = errors.New("nobody is on call")
+
+// Person is a PagerDuty user
+type Person struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	HTMLURL string `json:"html_url"`
+}
+
+// OnCallSchedule is who is currently on call for a schedule. NextRotation
+// is when their shift ends, and is zero if it doesn't end.
+type OnCallSchedule struct {
+	ScheduleID    string    `json:"schedule_id"`
+	Name          string    `json:"name"`
+	CurrentOnCall Person    `json:"current_on_call"`
+	NextRotation  time.Time `json:"next_rotation"`
+}
+
+type onCallsResponse struct {
+	OnCalls []struct {
+		EscalationLevel int        `json:"escalation_level"`
+		End             *time.Time `json:"end"`
+		Schedule        struct {
+			ID      string `json:"id"`
+			Summary string `json:"summary"`
+		} `json:"schedule"`
+		User Person `json:"user"`
+	} `json:"oncalls"`
+}
+
+type onCallEntry struct {
+	schedule  *OnCallSchedule
+	expiresAt time.Time
+}
+
+// Client reads on-call schedules from the PagerDuty REST API v2
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+	logger     *logger.Logger
+	cache      map[string]onCallEntry
+	cacheMu    sync.RWMutex
+	cacheTTL   time.Duration
+}
+
+// NewClient creates a client authenticated with a REST API token
+func NewClient(token string, log *logger.Logger) *Client {
+	return &Client{
+		baseURL:    PagerDutyAPIURL,
+		token:      token,
+		httpClient: &http.Client{Timeout: PagerDutyTimeout},
+		logger:     log,
+		cache:      make(map[string]onCallEntry),
+		cacheTTL:   OnCallCacheTTL,
+	}
+}
+
+// WithBaseURL overrides the PagerDuty API URL, mainly for testing
+func (c *Client) WithBaseURL(baseURL string) *Client {
+	c.baseURL = strings.TrimSuffix(baseURL, "/")
+	return c
+}
+
+// GetOnCallSchedule returns who is on call for a schedule. Where several
+// escalation levels use the schedule, the first level's on-call person is
+// returned. Results are cached for OnCallCacheTTL.
+func (c *Client) GetOnCallSchedule(ctx context.Context, scheduleID string) (*OnCallSchedule, error) {
+	if scheduleID == "" {
+		return nil, fmt.Errorf("schedule ID is required")
+	}
+
+	c.cacheMu.RLock()
+	entry, found := c.cache[scheduleID]
+	c.cacheMu.RUnlock()
+	if found && time.Now().Before(entry.expiresAt) {
+		return entry.schedule, nil
+	}
+
+	query := url.Values{}
+	query.Set("schedule_ids[]", scheduleID)
+	query.Set("include[]", "users")
+	query.Set("earliest", "true")
+	endpoint := fmt.Sprintf("%s/oncalls?%s", c.baseURL, query.Encode())
+
+	var response onCallsResponse
+	if err := c.get(ctx, endpoint, &response); err != nil {
+		return nil, err
+	}
+
+	var schedule *OnCallSchedule
+	level := 0
+	for _, onCall := range response.OnCalls {
+		if onCall.Schedule.ID != scheduleID || (schedule != nil && onCall.EscalationLevel >= level) {
+			continue
+		}
+
+		schedule = &OnCallSchedule{
+			ScheduleID:    scheduleID,
+			Name:          onCall.Schedule.Summary,
+			CurrentOnCall: onCall.User,
+		}
+		if onCall.End != nil {
+			schedule.NextRotation = *onCall.End
+		}
+		level = onCall.EscalationLevel
+	}
+	if schedule == nil {
+		return nil, fmt.Errorf("schedule %s: %w", scheduleID, ErrNoOnCall)
+	}
+
+	c.cacheMu.Lock()
+	c.cache[scheduleID] = onCallEntry{
+		schedule:  schedule,
+		expiresAt: time.Now().Add(c.cacheTTL),
+	}
+	c.cacheMu.Unlock()
+
+	return schedule, nil
+}
+
+func (c *Client) get(ctx context.Context, endpoint string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create PagerDuty request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.pagerduty+json;version=2")
+	req.Header.Set("Authorization", "Token token="+c.token)
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.logger.LogAPICall("pagerduty", endpoint, time.Since(start), false)
+		return fmt.Errorf("PagerDuty request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	c.logger.LogAPICall("pagerduty", endpoint, time.Since(start), resp.StatusCode == http.StatusOK)
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 500))
+		return fmt.Errorf("PagerDuty returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode PagerDuty response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package pagerduty
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"govuk-reports-dashboard/pkg/logger"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, func()) {
+	server := httptest.NewServer(handler)
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	return NewClient("test-token", log).WithBaseURL(server.URL), server.Close
+}
+
+func TestClient_GetOnCallSchedule(t *testing.T) {
+	requests := 0
+	client, cleanup := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if got := r.Header.Get("Authorization"); got != "Token token=test-token" {
+			t.Errorf("Expected token auth, got '%s'", got)
+		}
+		if r.URL.Path != "/oncalls" || r.URL.Query().Get("schedule_ids[]") != "P1234567" {
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+
+		fmt.Fprint(w, `{"oncalls":[
+			{"escalation_level":2,"end":"2026-10-20T09:00:00Z","schedule":{"id":"P1234567","summary":"Publishing Platform"},
+			 "user":{"id":"PSECOND","name":"Second Line","email":"second@digital.cabinet-office.gov.uk"}},
+			{"escalation_level":1,"end":"2026-10-19T09:00:00Z","schedule":{"id":"P1234567","summary":"Publishing Platform"},
+			 "user":{"id":"PFIRST","name":"First Line","email":"first@digital.cabinet-office.gov.uk","html_url":"https://govuk.pagerduty.com/users/PFIRST"}}
+		]}`)
+	})
+	defer cleanup()
+
+	schedule, err := client.GetOnCallSchedule(context.Background(), "P1234567")
+	if err != nil {
+		t.Fatalf("GetOnCallSchedule failed: %v", err)
+	}
+
+	if schedule.Name != "Publishing Platform" {
+		t.Errorf("Expected schedule name, got '%s'", schedule.Name)
+	}
+	if schedule.CurrentOnCall.ID != "PFIRST" || schedule.CurrentOnCall.Email != "first@digital.cabinet-office.gov.uk" {
+		t.Errorf("Expected first escalation level's on-call person, got %+v", schedule.CurrentOnCall)
+	}
+	if want := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC); !schedule.NextRotation.Equal(want) {
+		t.Errorf("Expected next rotation %v, got %v", want, schedule.NextRotation)
+	}
+
+	if _, err := client.GetOnCallSchedule(context.Background(), "P1234567"); err != nil {
+		t.Fatalf("GetOnCallSchedule failed: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected the schedule to be cached, got %d requests", requests)
+	}
+}
+
+func TestClient_GetOnCallSchedule_NobodyOnCall(t *testing.T) {
+	client, cleanup := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"oncalls":[]}`)
+	})
+	defer cleanup()
+
+	if _, err := client.GetOnCallSchedule(context.Background(), "P1234567"); !errors.Is(err, ErrNoOnCall) {
+		t.Errorf("Expected ErrNoOnCall, got %v", err)
+	}
+}
+
+func TestClient_GetOnCallSchedule_APIError(t *testing.T) {
+	client, cleanup := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error":{"message":"Unauthorized"}}`)
+	})
+	defer cleanup()
+
+	if _, err := client.GetOnCallSchedule(context.Background(), "P1234567"); err == nil {
+		t.Error("Expected an error for a 401 response")
+	}
+}
@@ -0,0 +1,195 @@
+package sentry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"govuk-reports-dashboard/pkg/logger"
+)
+
+const (
+	SentryAPIURL  = "https://sentry.io/api/0"
+	SentryTimeout = 10 * time.Second
+)
+
+// SentryProject is a Sentry project in the configured organisation.
+// ErrorCount is only set when the project has been looked up for an
+// application.
+type SentryProject struct {
+	Slug       string `json:"slug"`
+	Name       string `json:"name"`
+	Platform   string `json:"platform"`
+	IsMember   bool   `json:"is_member"`
+	ErrorCount int    `json:"error_count"`
+}
+
+type projectResponse struct {
+	Slug     string `json:"slug"`
+	Name     string `json:"name"`
+	Platform string `json:"platform"`
+	IsMember bool   `json:"isMember"`
+}
+
+// SentryClient reads projects and error counts from the Sentry REST API
+type SentryClient struct {
+	baseURL      string
+	organization string
+	token        string
+	httpClient   *http.Client
+	logger       *logger.Logger
+}
+
+// NewSentryClient creates a client for an organisation's projects,
+// authenticated with an API auth token
+func NewSentryClient(token, organization string, log *logger.Logger) *SentryClient {
+	return &SentryClient{
+		baseURL:      SentryAPIURL,
+		organization: organization,
+		token:        token,
+		httpClient:   &http.Client{Timeout: SentryTimeout},
+		logger:       log,
+	}
+}
+
+// WithBaseURL overrides the Sentry API URL, for self-hosted Sentry or testing
+func (c *SentryClient) WithBaseURL(baseURL string) *SentryClient {
+	c.baseURL = strings.TrimSuffix(baseURL, "/")
+	return c
+}
+
+// GetProjects returns every project in the organisation
+func (c *SentryClient) GetProjects(ctx context.Context) ([]SentryProject, error) {
+	var projects []SentryProject
+
+	endpoint := fmt.Sprintf("%s/organizations/%s/projects/", c.baseURL, url.PathEscape(c.organization))
+	for endpoint != "" {
+		var page []projectResponse
+		resp, err := c.get(ctx, endpoint, &page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, project := range page {
+			projects = append(projects, SentryProject{
+				Slug:     project.Slug,
+				Name:     project.Name,
+				Platform: project.Platform,
+				IsMember: project.IsMember,
+			})
+		}
+
+		endpoint = nextPageURL(resp.Header.Get("Link"))
+	}
+
+	return projects, nil
+}
+
+// GetProjectErrorCount returns the number of errors a project received over
+// the last period
+func (c *SentryClient) GetProjectErrorCount(ctx context.Context, projectSlug string, period time.Duration) (int, error) {
+	until := time.Now()
+	since := until.Add(-period)
+
+	query := url.Values{}
+	query.Set("stat", "received")
+	query.Set("since", strconv.FormatInt(since.Unix(), 10))
+	query.Set("until", strconv.FormatInt(until.Unix(), 10))
+	query.Set("resolution", "1h")
+
+	endpoint := fmt.Sprintf("%s/projects/%s/%s/stats/?%s", c.baseURL, url.PathEscape(c.organization), url.PathEscape(projectSlug), query.Encode())
+
+	// Stats are returned as [timestamp, count] pairs
+	var buckets [][2]float64
+	if _, err := c.get(ctx, endpoint, &buckets); err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, bucket := range buckets {
+		total += int(bucket[1])
+	}
+	return total, nil
+}
+
+// GetProjectErrorRate returns the average number of errors per hour a project
+// received over the last period
+func (c *SentryClient) GetProjectErrorRate(ctx context.Context, projectSlug string, period time.Duration) (float64, error) {
+	if period < time.Hour {
+		return 0, fmt.Errorf("period must be at least an hour, got %v", period)
+	}
+
+	count, err := c.GetProjectErrorCount(ctx, projectSlug, period)
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(count) / period.Hours(), nil
+}
+
+func (c *SentryClient) get(ctx context.Context, endpoint string, out interface{}) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Sentry request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.logger.LogAPICall("sentry", endpoint, time.Since(start), false)
+		return nil, fmt.Errorf("Sentry request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	c.logger.LogAPICall("sentry", endpoint, time.Since(start), resp.StatusCode == http.StatusOK)
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 500))
+		return nil, fmt.Errorf("Sentry returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return nil, fmt.Errorf("failed to decode Sentry response: %w", err)
+	}
+
+	return resp, nil
+}
+
+var linkPattern = regexp.MustCompile(`<([^>]+)>;\s*rel="next";\s*results="true"`)
+
+// nextPageURL returns the next page from a Sentry Link header, or "" when
+// there are no more results
+func nextPageURL(link string) string {
+	for _, part := range strings.Split(link, ",") {
+		if match := linkPattern.FindStringSubmatch(strings.TrimSpace(part)); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+// ProjectSlugFromURL returns the project slug from a Sentry project URL such
+// as https://sentry.io/organizations/govuk/projects/publishing-api/, or "" if
+// the URL does not name a project
+func ProjectSlugFromURL(projectURL string) string {
+	parsed, err := url.Parse(projectURL)
+	if err != nil {
+		return ""
+	}
+
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	for i, segment := range segments {
+		if segment == "projects" && i+1 < len(segments) {
+			return segments[i+1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,111 @@
+package sentry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"govuk-reports-dashboard/pkg/logger"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*SentryClient, func()) {
+	server := httptest.NewServer(handler)
+	log, _ := logger.New(logger.Config{Level: "error", Format: "json"})
+	return NewSentryClient("test-token", "govuk", log).WithBaseURL(server.URL), server.Close
+}
+
+func TestSentryClient_GetProjects(t *testing.T) {
+	var serverURL string
+	client, cleanup := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("Expected bearer token, got '%s'", got)
+		}
+		if r.URL.Path != "/organizations/govuk/projects/" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+
+		if r.URL.Query().Get("cursor") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/organizations/govuk/projects/?cursor=0:1:0>; rel="next"; results="true"; cursor="0:1:0"`, serverURL))
+			fmt.Fprint(w, `[{"slug":"publishing-api","name":"Publishing API","platform":"ruby","isMember":true}]`)
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s/organizations/govuk/projects/?cursor=0:2:0>; rel="next"; results="false"; cursor="0:2:0"`, serverURL))
+		fmt.Fprint(w, `[{"slug":"frontend","name":"Frontend","platform":"ruby","isMember":false}]`)
+	})
+	defer cleanup()
+	serverURL = client.baseURL
+
+	projects, err := client.GetProjects(context.Background())
+	if err != nil {
+		t.Fatalf("GetProjects failed: %v", err)
+	}
+
+	if len(projects) != 2 {
+		t.Fatalf("Expected 2 projects across pages, got %d", len(projects))
+	}
+	if projects[0].Slug != "publishing-api" || !projects[0].IsMember {
+		t.Errorf("Unexpected first project: %+v", projects[0])
+	}
+	if projects[1].Slug != "frontend" || projects[1].IsMember {
+		t.Errorf("Unexpected second project: %+v", projects[1])
+	}
+}
+
+func TestSentryClient_GetProjectErrorRate(t *testing.T) {
+	client, cleanup := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/govuk/publishing-api/stats/" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("stat") != "received" {
+			t.Errorf("Expected received stat, got '%s'", r.URL.Query().Get("stat"))
+		}
+		fmt.Fprint(w, `[[1700000000, 30], [1700003600, 12], [1700007200, 6]]`)
+	})
+	defer cleanup()
+
+	count, err := client.GetProjectErrorCount(context.Background(), "publishing-api", 24*time.Hour)
+	if err != nil {
+		t.Fatalf("GetProjectErrorCount failed: %v", err)
+	}
+	if count != 48 {
+		t.Errorf("Expected 48 errors, got %d", count)
+	}
+
+	rate, err := client.GetProjectErrorRate(context.Background(), "publishing-api", 24*time.Hour)
+	if err != nil {
+		t.Fatalf("GetProjectErrorRate failed: %v", err)
+	}
+	if rate != 2 {
+		t.Errorf("Expected 2 errors per hour, got %v", rate)
+	}
+}
+
+func TestSentryClient_ErrorStatus(t *testing.T) {
+	client, cleanup := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"detail":"Invalid token"}`)
+	})
+	defer cleanup()
+
+	if _, err := client.GetProjects(context.Background()); err == nil {
+		t.Error("Expected error for unauthorised response")
+	}
+}
+
+func TestProjectSlugFromURL(t *testing.T) {
+	tests := map[string]string{
+		"https://sentry.io/organizations/govuk/projects/publishing-api/": "publishing-api",
+		"https://govuk.sentry.io/projects/frontend/?project=123":         "frontend",
+		"https://sentry.io/organizations/govuk/issues/":                  "",
+		"": "",
+	}
+
+	for url, expected := range tests {
+		if got := ProjectSlugFromURL(url); got != expected {
+			t.Errorf("ProjectSlugFromURL(%q) = %q, expected %q", url, got, expected)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Package tracing sets up OpenTelemetry tracing. Spans are started from the
+// global tracer provider, which does nothing until InitProvider or
+// SetProvider is called, so code can be traced unconditionally.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// DefaultServiceName is the service.name traces are exported with
+	// unless another is configured
+	DefaultServiceName = "govuk-reports-dashboard"
+
+	// instrumentationName identifies the application's own spans
+	instrumentationName = "govuk-reports-dashboard"
+
+	// shutdownTimeout bounds flushing spans when the provider shuts down
+	shutdownTimeout = 5 * time.Second
+)
+
+// InitProvider exports spans over OTLP/HTTP to exporterEndpoint, such as
+// "http://otel-collector:4318", and makes it the global tracer provider.
+// The returned function flushes outstanding spans and should be called on
+// shutdown.
+func InitProvider(serviceName, exporterEndpoint string) (func(), error) {
+	exporter, err := otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(exporterEndpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", serviceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	SetProvider(provider)
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		provider.Shutdown(ctx)
+	}, nil
+}
+
+// SetProvider makes provider the global tracer provider, and propagates
+// trace context in W3C traceparent and baggage headers
+func SetProvider(provider trace.TracerProvider) {
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+}
+
+// Tracer returns the tracer for the application's spans
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start starts a span named name, a child of any span in ctx
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// RecordError marks span as failed with err, if err isn't nil
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// InjectHTTPHeaders adds the trace context of ctx to header, so the
+// receiving service can continue the trace
+func InjectHTTPHeaders(ctx context.Context, header http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// ExtractHTTPHeaders returns ctx with any trace context sent in header
+func ExtractHTTPHeaders(ctx context.Context, header http.Header) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+}
@@ -40,27 +40,20 @@ class ApplicationDetail {
         this.hideError();
 
         try {
-            // Load application details and services in parallel
-            const [appResponse, servicesResponse] = await Promise.all([
-                fetch(`/api/applications/${encodeURIComponent(this.applicationName)}`),
-                fetch(`/api/applications/${encodeURIComponent(this.applicationName)}/services`)
-            ]);
-
-            if (!appResponse.ok) {
-                if (appResponse.status === 404) {
+            // Application details, services and infrastructure in one request
+            const response = await fetch(`/api/bff/applications/${encodeURIComponent(this.applicationName)}`);
+
+            if (!response.ok) {
+                if (response.status === 404) {
                     throw new Error('Application not found');
                 } else {
-                    throw new Error(`HTTP ${appResponse.status}: ${appResponse.statusText}`);
+                    throw new Error(`HTTP ${response.status}: ${response.statusText}`);
                 }
             }
 
-            if (!servicesResponse.ok) {
-                throw new Error(`Failed to load services: HTTP ${servicesResponse.status}`);
-            }
-
-            this.applicationData = await appResponse.json();
-            const servicesData = await servicesResponse.json();
-            this.servicesData = servicesData.services || [];
+            const costContext = await response.json();
+            this.applicationData = costContext.cost_summary;
+            this.servicesData = costContext.cost_summary.services || [];
 
             this.renderApplicationDetail();
             this.renderServicesTable();
@@ -145,12 +145,12 @@ class ReportsDashboard {
 
     async loadCostSummaryFallback() {
         try {
-            const response = await fetch('/api/applications');
+            const response = await fetch('/api/applications?limit=1');
             if (response.ok) {
                 const data = await response.json();
                 document.getElementById('cost-total').textContent = this.formatCurrency(data.total_cost, data.currency);
-                document.getElementById('cost-apps').textContent = data.count.toString();
-                const avgCost = data.count > 0 ? data.total_cost / data.count : 0;
+                document.getElementById('cost-apps').textContent = data.total.toString();
+                const avgCost = data.total > 0 ? data.total_cost / data.total : 0;
                 document.getElementById('cost-average').textContent = this.formatCurrency(avgCost, data.currency);
             }
         } catch (error) {
@@ -264,7 +264,7 @@ class ReportsDashboard {
         this.hideError();
 
         try {
-            const response = await fetch('/api/applications');
+            const response = await fetch('/api/applications?limit=500');
             if (!response.ok) {
                 throw new Error(`HTTP ${response.status}: ${response.statusText}`);
             }
@@ -295,13 +295,13 @@ class ReportsDashboard {
         // Update application count
         const appCountEl = document.getElementById('app-count');
         if (appCountEl) {
-            appCountEl.textContent = data.count.toString();
+            appCountEl.textContent = data.total.toString();
         }
 
         // Update average cost
         const avgCostEl = document.getElementById('avg-cost');
-        if (avgCostEl && data.count > 0) {
-            const avgCost = data.total_cost / data.count;
+        if (avgCostEl && data.total > 0) {
+            const avgCost = data.total_cost / data.total;
             avgCostEl.textContent = this.formatCurrency(avgCost, data.currency);
         }
     }
@@ -0,0 +1,89 @@
+// GOV.UK Reports Dashboard - Team Costs JavaScript
+// Handles the team costs table and data loading
+
+class TeamsPage {
+    constructor() {
+        this.init();
+    }
+
+    init() {
+        const retryButton = document.getElementById('retry-button');
+        if (retryButton) {
+            retryButton.addEventListener('click', () => {
+                this.loadTeamCosts();
+            });
+        }
+
+        this.loadTeamCosts();
+    }
+
+    async loadTeamCosts() {
+        this.toggle('loading-state', true);
+        this.toggle('error-state', false);
+
+        try {
+            const response = await fetch('/api/v1/costs/teams');
+            if (!response.ok) {
+                throw new Error(`Failed to load team costs: HTTP ${response.status}`);
+            }
+
+            const data = await response.json();
+            this.updateSummaryCards(data);
+            this.renderTeams(data);
+            this.toggle('teams-container', true);
+        } catch (error) {
+            console.error('Failed to load team costs:', error);
+            document.getElementById('error-message').textContent = error.message;
+            this.toggle('error-state', true);
+        } finally {
+            this.toggle('loading-state', false);
+        }
+    }
+
+    updateSummaryCards(data) {
+        document.getElementById('total-cost').textContent = this.formatCurrency(data.total_cost, data.currency);
+        document.getElementById('team-count').textContent = data.count;
+
+        const topTeam = data.teams[0];
+        document.getElementById('top-team').textContent = topTeam ? topTeam.team : 'None';
+        document.getElementById('top-team-cost').textContent = topTeam ? this.formatCurrency(topTeam.total_cost, topTeam.currency) : '';
+    }
+
+    renderTeams(data) {
+        const tbody = document.getElementById('teams-tbody');
+        tbody.innerHTML = '';
+
+        data.teams.forEach(team => {
+            const row = document.createElement('tr');
+            row.className = 'govuk-table__row';
+
+            const cells = [
+                [team.team, false],
+                [this.formatCurrency(team.total_cost, team.currency), true],
+                [team.application_count, true],
+                [this.formatCurrency(team.average_cost, team.currency), true],
+                [team.highest_cost_app.name, false],
+            ];
+            cells.forEach(([value, numeric]) => {
+                const cell = document.createElement('td');
+                cell.className = numeric ? 'govuk-table__cell govuk-table__cell--numeric' : 'govuk-table__cell';
+                cell.textContent = value;
+                row.appendChild(cell);
+            });
+
+            tbody.appendChild(row);
+        });
+    }
+
+    formatCurrency(amount, currency) {
+        return new Intl.NumberFormat('en-GB', { style: 'currency', currency: currency || 'GBP' }).format(amount);
+    }
+
+    toggle(elementId, visible) {
+        document.getElementById(elementId).style.display = visible ? '' : 'none';
+    }
+}
+
+document.addEventListener('DOMContentLoaded', () => {
+    new TeamsPage();
+});